@@ -34,10 +34,13 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // trigger active windows name IANA zones; the image is FROM scratch
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/auth"
@@ -91,7 +94,7 @@ func validateEnv() []string {
 	}
 
 	// Validate duration-typed env vars.
-	for _, name := range []string{"S3_METADATA_TIMEOUT", "S3_DATA_TIMEOUT"} {
+	for _, name := range []string{"S3_METADATA_TIMEOUT", "S3_DATA_TIMEOUT", "EXECUTOR_DRAIN_TIMEOUT", "REQUEST_TIMEOUT", "DB_QUERY_TIMEOUT", "DB_HEAVY_QUERY_TIMEOUT"} {
 		if v := os.Getenv(name); v != "" {
 			if _, err := time.ParseDuration(v); err != nil {
 				errs = append(errs, fmt.Sprintf("%s=%q: must be a valid Go duration (e.g. 10s, 2m) (%v)", name, v, err))
@@ -99,6 +102,46 @@ func validateEnv() []string {
 		}
 	}
 
+	if v := os.Getenv("RAT_API_KEYS"); v != "" {
+		if _, err := auth.ParseAPIKeys(v); err != nil {
+			errs = append(errs, fmt.Sprintf("RAT_API_KEYS: %v", err))
+		}
+	}
+	if v := os.Getenv("RAT_API_KEY_NAMESPACES"); v != "" {
+		restricted, err := auth.ParseAPIKeyNamespaces(v)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("RAT_API_KEY_NAMESPACES: %v", err))
+		}
+		keys := apiKeysFromEnv()
+		for key := range restricted {
+			if _, ok := keys[key]; !ok {
+				errs = append(errs, "RAT_API_KEY_NAMESPACES: lists a key that is not in RAT_API_KEY or RAT_API_KEYS")
+				break
+			}
+		}
+	}
+
+	if v := os.Getenv("CORS_ROUTE_ORIGINS"); v != "" {
+		if _, err := api.ParseRouteCORSOrigins(v); err != nil {
+			errs = append(errs, fmt.Sprintf("CORS_ROUTE_ORIGINS: %v", err))
+		}
+	}
+
+	if v := os.Getenv("BODY_LOG_SAMPLE_RATE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err != nil || f < 0 || f > 1 {
+			errs = append(errs, fmt.Sprintf("BODY_LOG_SAMPLE_RATE=%q: must be a number between 0 and 1", v))
+		}
+	}
+
+	// Validate positive-integer env vars.
+	for _, name := range []string{"SSE_MAX_PER_IP", "SSE_MAX_GLOBAL", "BODY_LOG_MAX_BYTES"} {
+		if v := os.Getenv(name); v != "" {
+			if n, err := strconv.Atoi(v); err != nil || n <= 0 {
+				errs = append(errs, fmt.Sprintf("%s=%q: must be a positive integer", name, v))
+			}
+		}
+	}
+
 	// Validate URL-typed env vars.
 	for _, name := range []string{"S3_ENDPOINT", "NESSIE_URL"} {
 		if v := os.Getenv(name); v != "" {
@@ -139,6 +182,31 @@ func validateEnv() []string {
 	return errs
 }
 
+// apiKeysFromEnv merges RAT_API_KEYS (scoped "key:rw,key:ro" pairs) with the
+// single read-write RAT_API_KEY. Empty when neither is set, meaning no
+// API key auth. RAT_API_KEYS was checked by validateEnv.
+func apiKeysFromEnv() map[string]auth.Scope {
+	keys, err := auth.ParseAPIKeys(os.Getenv("RAT_API_KEYS"))
+	if err != nil {
+		keys = make(map[string]auth.Scope)
+	}
+	if key := os.Getenv("RAT_API_KEY"); key != "" {
+		keys[key] = auth.ScopeReadWrite
+	}
+	return keys
+}
+
+// apiKeyNamespacesFromEnv returns the per-key namespace restrictions from
+// RAT_API_KEY_NAMESPACES (checked by validateEnv). Keys not listed may touch
+// every namespace.
+func apiKeyNamespacesFromEnv() map[string][]string {
+	namespaces, err := auth.ParseAPIKeyNamespaces(os.Getenv("RAT_API_KEY_NAMESPACES"))
+	if err != nil {
+		return nil
+	}
+	return namespaces
+}
+
 // warnDefaultCredentials logs security warnings when S3 or Postgres credentials
 // appear to be well-known defaults (e.g., minioadmin/minioadmin, rat/rat).
 // These are safe for local development but dangerous in production deployments.
@@ -202,10 +270,23 @@ func main() {
 		MaxEntries: 10, // namespace list is a single "all" entry
 	})
 	srv.PipelineCache = cache.New[string, *domain.Pipeline](cache.Options{
-		TTL:        30 * time.Second,
-		MaxEntries: 500, // reasonable upper bound for pipeline count
+		TTL:         30 * time.Second,
+		MaxEntries:  500,             // reasonable upper bound for pipeline count
+		NegativeTTL: 5 * time.Second, // absorbs bot probes for nonexistent pipelines
 	})
-	slog.Info("in-memory caches initialized", "namespace_ttl", "30s", "pipeline_ttl", "30s")
+	// Latest run per pipeline changes with every run, so keep the TTL short;
+	// run_completed events clear it early when the event bus is up.
+	srv.LatestRunCache = cache.New[string, map[uuid.UUID]*domain.Run](cache.Options{
+		TTL:        10 * time.Second,
+		MaxEntries: 100, // one entry per distinct dashboard page
+	})
+	// Preview results are keyed by a hash of the pipeline's draft files, so an
+	// edit misses immediately; the TTL bounds staleness from upstream data.
+	srv.PreviewCache = cache.New[string, *api.PreviewCacheEntry](cache.Options{
+		TTL:        2 * time.Minute,
+		MaxEntries: 100, // up to 1000 rows each; one entry per pipeline
+	})
+	slog.Info("in-memory caches initialized", "namespace_ttl", "30s", "pipeline_ttl", "30s", "latest_run_ttl", "10s")
 
 	// Load plugin config: RAT_CONFIG env > ./rat.yaml > community defaults.
 	configPath := config.ResolvePath()
@@ -263,9 +344,9 @@ func main() {
 	// Auth middleware: plugin auth (Pro) takes priority over API key (Community).
 	if registry.AuthEnabled() {
 		srv.Auth = registry.AuthMiddleware()
-	} else if apiKey := os.Getenv("RAT_API_KEY"); apiKey != "" {
-		srv.Auth = auth.APIKey(apiKey)
-		slog.Info("API key authentication enabled")
+	} else if keys := apiKeysFromEnv(); len(keys) > 0 {
+		srv.Auth = auth.APIKeys(keys, apiKeyNamespacesFromEnv())
+		slog.Info("API key authentication enabled", "keys", len(keys))
 	} else {
 		srv.Auth = auth.Noop()
 	}
@@ -275,8 +356,8 @@ func main() {
 		if reg.AuthEnabled() {
 			srv.Auth = reg.AuthMiddleware()
 			slog.Info("auth middleware re-wired (plugin change)")
-		} else if apiKey := os.Getenv("RAT_API_KEY"); apiKey != "" {
-			srv.Auth = auth.APIKey(apiKey)
+		} else if keys := apiKeysFromEnv(); len(keys) > 0 {
+			srv.Auth = auth.APIKeys(keys, apiKeyNamespacesFromEnv())
 		} else {
 			srv.Auth = auth.Noop()
 		}
@@ -297,6 +378,12 @@ func main() {
 		}
 	}
 
+	// Webhook signing secrets are encrypted at rest under a key derived from
+	// RAT_WEBHOOK_SECRET_KEY. Unset = HMAC-signed webhooks cannot be created.
+	if v := os.Getenv("RAT_WEBHOOK_SECRET_KEY"); v != "" {
+		srv.WebhookSecretKey = api.WebhookSecretKey(v)
+	}
+
 	// Decode license key for display (no validation — enforcement is in plugins).
 	if licenseKey := os.Getenv("RAT_LICENSE_KEY"); licenseKey != "" {
 		info, err := license.Decode(licenseKey)
@@ -320,9 +407,32 @@ func main() {
 		}
 	}
 
+	// License seats are enforced only while the enforcement plugin is loaded.
+	// One tracker outlives plugin re-wiring so held seats survive it; with
+	// Postgres, seats are persisted (see SetStore below).
+	var seatTracker *plugins.SeatTracker
+	if li := srv.LicenseInfo; li != nil && li.Valid && li.SeatLimit > 0 {
+		seatTracker = plugins.NewSeatTracker(li.SeatLimit, 0)
+	}
+	wireSeats := func(reg *plugins.Registry) {
+		if seatTracker != nil && reg.EnforcementEnabled() {
+			srv.Seats.Store(seatTracker)
+			slog.Info("license seat enforcement enabled", "seat_limit", seatTracker.Limit())
+		} else {
+			srv.Seats.Store(nil)
+		}
+	}
+	wireSeats(registry)
+	onEnforcementChanged := mgr.OnEnforcementChanged
+	mgr.OnEnforcementChanged = func(reg *plugins.Registry) {
+		onEnforcementChanged(reg)
+		wireSeats(reg)
+	}
+
 	// Shutdown hooks — populated below, called in order during graceful shutdown.
 	var (
 		stopLeader         func()
+		stepDownLeader     func()
 		stopScheduler      func()
 		stopEvaluator      func()
 		stopReaper         func()
@@ -404,12 +514,17 @@ func main() {
 		}
 
 		// Start event bus (Postgres LISTEN/NOTIFY) for instant event delivery.
+		// Either way it is a readiness dependency: a replica without a live
+		// LISTEN connection misses run-completion and trigger events.
 		eventBus = postgres.NewPgEventBus(pool)
 		if err := eventBus.Start(ctx); err != nil {
 			slog.Warn("event bus failed to start, continuing without instant events", "error", err)
 			eventBus = nil
+			startErr := err
+			srv.EventBusHealth = api.HealthCheckFunc(func(context.Context) error { return startErr })
 		} else {
 			stopEventBus = func() { eventBus.Stop() }
+			srv.EventBusHealth = eventBus
 		}
 
 		pipelineStore := postgres.NewPipelineStore(pool)
@@ -420,6 +535,8 @@ func main() {
 			pipelineStore.EventBus = eventBus
 			runStore.EventBus = eventBus
 			srv.EventBus = eventBus
+			srv.EventStream = &eventBusAdapter{bus: eventBus}
+			srv.WatchRunCompletions(ctx, srv.EventStream)
 		}
 
 		srv.Pipelines = pipelineStore
@@ -435,8 +552,13 @@ func main() {
 		srv.Audit = postgres.NewAuditStore(pool)
 		srv.FailedMerges = postgres.NewFailedMergesStore(pool)
 		srv.Settings = postgres.NewSettingsStore(pool)
+		srv.Notifications = postgres.NewNotificationStore(pool)
 
-		srv.DBHealth = postgres.NewHealthChecker(pool)
+		dbHealth := postgres.NewHealthChecker(pool)
+		srv.DBHealth = dbHealth
+		srv.PGPoolStats = dbHealth.PoolStats
+		// rat_runs / rat_schedules / rat_triggers breakdowns (cached 30s).
+		srv.Counts = postgres.NewMetricsStore(pool)
 		// Pool-saturation metrics: expose pgxpool.Stat() to /metrics via a
 		// closure so the api package never imports pgx. Returning int32
 		// (pgx's native type) avoids a per-scrape integer cast.
@@ -472,6 +594,10 @@ func main() {
 			srv.Authorizer = plugins.NewPluginAuthorizer(registry, srv.Pipelines)
 			slog.Info("enforcement authorizer initialized (plugin)")
 		}
+		if seatTracker != nil {
+			seatTracker.SetStore(postgres.NewSeatStore(pool))
+		}
+		wireSeats(registry)
 	} else {
 		slog.Warn("DATABASE_URL not set, running without persistence")
 	}
@@ -555,16 +681,27 @@ func main() {
 	atomicExec := executor.NewAtomicExecutor()
 	srv.Executor = atomicExec
 
+	// Outbound run-completion webhooks, configured per namespace.
+	var notifier *api.NotificationDispatcher
+	if srv.Notifications != nil {
+		notifier = api.NewNotificationDispatcher(srv.Notifications, srv.Pipelines, srv.Runs, srv.WebhookSecretKey)
+	}
+
 	onComplete := func(ctx context.Context, run *domain.Run, status domain.RunStatus) {
-		if status != domain.RunStatusSuccess || srv.Triggers == nil {
-			return
+		if status == domain.RunStatusSuccess && srv.Triggers != nil {
+			srv.EvaluatePipelineSuccessTriggers(ctx, run)
+		}
+		if notifier != nil {
+			notifier.OnRunComplete(ctx, run, status)
 		}
-		srv.EvaluatePipelineSuccessTriggers(ctx, run)
 	}
 
 	// Build the community executor from RUNNER_ADDR (if set).
 	// This is kept running as a persistent fallback — never stopped.
 	type stoppable interface{ Stop() }
+	type drainable interface {
+		Drain(ctx context.Context) error
+	}
 	var communityExec api.Executor
 	var stopCommunityExec func()
 	if runnerAddr := os.Getenv("RUNNER_ADDR"); runnerAddr != "" {
@@ -602,6 +739,7 @@ func main() {
 
 		old := atomicExec.Swap(pluginExec)
 		srv.RunnerHealth = transport.NewTCPHealthChecker(addr, "runner")
+		srv.ExecutorHealth = atomicExec
 
 		// Stop the previous plugin executor (not the community one — it keeps running).
 		if activePluginExec != nil {
@@ -635,15 +773,19 @@ func main() {
 		activatePluginExecutor(addr)
 	} else if communityExec != nil {
 		atomicExec.Swap(communityExec)
+		srv.ExecutorHealth = atomicExec
 		slog.Info("executor initialized (community)")
 	}
 
-	// Wire runner plugin lister for GET /api/v1/runner/plugins.
-	// Uses the community executor's gRPC connection to call ListPlugins on the runner.
+	// Wire runner plugin lister and capacity reporter for GET /api/v1/runner/plugins
+	// and /runner/capacity. Uses the community executor's gRPC connection to the runner(s).
 	if communityExec != nil {
 		if lister, ok := communityExec.(api.RunnerPluginLister); ok {
 			srv.RunnerPlugins = lister
 		}
+		if reporter, ok := communityExec.(api.RunnerCapacityReporter); ok {
+			srv.RunnerCapacity = reporter
+		}
 	}
 
 	// Dynamic re-wiring: fired when an executor plugin registers or unregisters.
@@ -656,11 +798,29 @@ func main() {
 		}
 	}
 
-	// Shutdown hook: stop both plugin and community executors.
+	// Shutdown hook: stop both plugin and community executors. The community
+	// executor is drained first so in-flight runs are recorded as finished
+	// rather than left "running" for the reaper (bounded by
+	// EXECUTOR_DRAIN_TIMEOUT, default 20s).
 	stopExecutor = func() {
 		if activePluginExec != nil {
 			activePluginExec.Stop()
 		}
+		if d, ok := communityExec.(drainable); ok {
+			drainTimeout := 20 * time.Second
+			if v := os.Getenv("EXECUTOR_DRAIN_TIMEOUT"); v != "" {
+				if parsed, err := time.ParseDuration(v); err == nil {
+					drainTimeout = parsed
+				}
+			}
+			drainCtx, drainCancel := context.WithTimeout(context.Background(), drainTimeout)
+			if err := d.Drain(drainCtx); err != nil {
+				slog.Warn("executor drain incomplete, abandoning in-flight runs", "error", err)
+			} else {
+				slog.Info("executor drained")
+			}
+			drainCancel()
+		}
 		if stopCommunityExec != nil {
 			stopCommunityExec()
 		}
@@ -683,6 +843,9 @@ func main() {
 			if eventBus != nil {
 				sched.EventBus = eventBus
 			}
+			if srv.Settings != nil {
+				sched.Settings = srv.Settings
+			}
 			sched.Start(ctx)
 			// Scheduler tick metrics: published as Prometheus gauges by the
 			// /metrics handler. Closure captures *sched so the api package
@@ -705,9 +868,14 @@ func main() {
 				ch, cancelSub := eventBus.Subscribe(postgres.ChannelRunCompleted)
 				eval.EventCh = ch
 				eval.SetEventCancel(cancelSub)
+				eval.EventBus = eventBus
 				slog.Info("trigger evaluator subscribed to run_completed events")
 			}
 
+			if srv.Settings != nil {
+				eval.Settings = srv.Settings
+			}
+
 			eval.Start(ctx)
 			stopEvaluator = func() { eval.Stop() }
 			slog.Info("trigger evaluator started")
@@ -719,11 +887,12 @@ func main() {
 			if nessieURL := os.Getenv("NESSIE_URL"); nessieURL != "" {
 				nessieClient = reaper.NewHTTPNessieClient(nessieURL)
 			}
-			reap := reaper.New(srv.Settings, srv.Runs, srv.Pipelines, srv.LandingZones, srv.Storage, srv.Audit, srv.FailedMerges, nessieClient)
+			reapOpts := reaper.Options{DryRun: os.Getenv("REAPER_DRY_RUN") == "true"}
+			reap := reaper.New(srv.Settings, srv.Runs, srv.Pipelines, srv.LandingZones, srv.Storage, srv.Audit, srv.FailedMerges, nessieClient, reapOpts)
 			reap.Start(ctx)
 			srv.Reaper = reap
 			stopReaper = func() { reap.Stop() }
-			slog.Info("reaper started")
+			slog.Info("reaper started", "dry_run", reapOpts.DryRun)
 		}
 
 		return func() {
@@ -758,11 +927,11 @@ func main() {
 		// A heartbeat goroutine pings Postgres every 5s while leader; two
 		// consecutive failures force a voluntary unlock so a partitioned
 		// replica cannot indefinitely hold the lock without running workers.
-		tryLock := func(ctx context.Context) (bool, error) {
-			var acquired bool
-			err := pool.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", leader.AdvisoryLockID).Scan(&acquired)
-			return acquired, err
-		}
+		//
+		// The lock lives on a dedicated connection so unlock and the
+		// per-tick ownership check run in the session that holds it; if that
+		// connection drops, the check notices and workers stop.
+		advisoryLock := postgres.NewAdvisoryLock(pool, leader.AdvisoryLockID)
 		// Heartbeat ping uses its own pool so a saturated main pool can't
 		// starve liveness checks. Falls back to the shared pool when the
 		// dedicated one is disabled via RAT_HEARTBEAT_POOL_ENABLED=false.
@@ -773,19 +942,24 @@ func main() {
 			heartbeatSource = "dedicated-pool"
 		}
 		ping := func(ctx context.Context) error { return pingPool.Ping(ctx) }
-		unlock := func(ctx context.Context) error {
-			_, err := pool.Exec(ctx, "SELECT pg_advisory_unlock($1)", leader.AdvisoryLockID)
-			return err
-		}
 		elector := leader.New(
-			tryLock,
+			advisoryLock.TryLock,
 			leader.RetryInterval,
 			startBackgroundWorkers,
 			leader.WithPing(ping),
-			leader.WithUnlock(unlock),
+			leader.WithUnlock(advisoryLock.Unlock),
+			leader.WithLockCheck(advisoryLock.Held),
 		)
 		elector.Start(ctx)
-		stopLeader = func() { elector.Stop() }
+		stopLeader = func() {
+			elector.Stop()
+			advisoryLock.Close()
+		}
+		stepDownLeader = func() {
+			stepCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			elector.StepDown(stepCtx)
+		}
 		slog.Info("leader election started (advisory lock)",
 			"heartbeat_interval", leader.DefaultHeartbeatInterval,
 			"heartbeat_source", heartbeatSource)
@@ -828,6 +1002,11 @@ func main() {
 	if corsEnv := os.Getenv("CORS_ORIGINS"); corsEnv != "" {
 		srv.CORSOrigins = strings.Split(corsEnv, ",")
 	}
+	// Per-route CORS origins (validated by validateEnv) replace CORS_ORIGINS on
+	// matching routes, e.g. to allow an embed domain on query endpoints only.
+	if v := os.Getenv("CORS_ROUTE_ORIGINS"); v != "" {
+		srv.RouteCORSOrigins, _ = api.ParseRouteCORSOrigins(v)
+	}
 
 	// Trusted reverse-proxy CIDRs / IPs (comma-separated, e.g.
 	// "10.0.0.0/8,192.168.1.5"). Only requests arriving from these peers have
@@ -844,11 +1023,45 @@ func main() {
 		slog.Info("trusted proxies configured", "count", len(proxies))
 	}
 
-	// Per-IP rate limiting (disable with RATE_LIMIT=0).
+	// Rate limiting (disable with RATE_LIMIT=0). RATE_LIMIT_KEY picks the
+	// bucket key: "ip" (default) or "principal" (user / API key, IP fallback).
 	if rl := os.Getenv("RATE_LIMIT"); rl != "0" {
 		cfg := api.DefaultRateLimitConfig()
+		strategy, err := api.ParseRateLimitKeyStrategy(os.Getenv("RATE_LIMIT_KEY"))
+		if err != nil {
+			slog.Error("invalid RATE_LIMIT_KEY", "error", err)
+			os.Exit(1)
+		}
+		cfg.KeyStrategy = strategy
 		srv.RateLimit = &cfg
-		slog.Info("rate limiting enabled", "rps", cfg.RequestsPerSecond, "burst", cfg.Burst)
+		// Query and preview execute SQL / pipelines, so they get tighter buckets.
+		srv.RouteRateLimits = api.DefaultRouteRateLimits()
+		slog.Info("rate limiting enabled", "rps", cfg.RequestsPerSecond, "burst", cfg.Burst, "key", cfg.KeyStrategy)
+	}
+
+	// Concurrent SSE caps: SSE_MAX_PER_IP stops one client from exhausting
+	// SSE_MAX_GLOBAL. Values were validated by validateEnv.
+	ssePerIP, _ := strconv.Atoi(os.Getenv("SSE_MAX_PER_IP"))
+	sseGlobal, _ := strconv.Atoi(os.Getenv("SSE_MAX_GLOBAL"))
+	srv.SSELimiter = api.NewSSELimiterWithLimits(ssePerIP, sseGlobal)
+
+	// Sampled, redacted body logging for debugging (off unless
+	// BODY_LOG_SAMPLE_RATE > 0). Values were validated by validateEnv.
+	if rate, _ := strconv.ParseFloat(os.Getenv("BODY_LOG_SAMPLE_RATE"), 64); rate > 0 {
+		maxBytes, _ := strconv.Atoi(os.Getenv("BODY_LOG_MAX_BYTES"))
+		srv.BodyLog = &api.BodyLogConfig{SampleRate: rate, MaxBytes: maxBytes}
+		slog.Warn("request body logging enabled", "sample_rate", rate)
+	}
+
+	// Per-request deadline for /api/v1 (default api.DefaultRequestTimeout;
+	// query and preview get longer overrides). REQUEST_TIMEOUT=0 disables it.
+	if v := os.Getenv("REQUEST_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			if d == 0 {
+				d = -1
+			}
+			srv.RequestTimeout = d
+		}
 	}
 
 	publicRouter := api.NewRouter(srv)
@@ -885,8 +1098,8 @@ func main() {
 	}
 
 	// Warn if listening on all interfaces without authentication.
-	if strings.HasPrefix(addr, "0.0.0.0") && os.Getenv("RAT_API_KEY") == "" && !registry.AuthEnabled() {
-		slog.Warn("listening on 0.0.0.0 without RAT_API_KEY — API is unauthenticated and accessible from the network")
+	if strings.HasPrefix(addr, "0.0.0.0") && len(apiKeysFromEnv()) == 0 && !registry.AuthEnabled() {
+		slog.Warn("listening on 0.0.0.0 without RAT_API_KEY or RAT_API_KEYS — API is unauthenticated and accessible from the network")
 	}
 
 	// Refuse to share a port between the public and internal listeners — that
@@ -991,6 +1204,14 @@ func main() {
 		}
 	}
 
+	// Hand leadership over first: stop the background workers and release the
+	// advisory lock before the HTTP drain so a standby replica isn't kept
+	// waiting.
+	if stepDownLeader != nil {
+		stepDownLeader()
+		slog.Info("leader stepped down")
+	}
+
 	// Graceful shutdown: drain HTTP connections on both listeners (15s timeout).
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
@@ -1020,6 +1241,14 @@ func main() {
 	}
 	stopExecutor()
 	slog.Info("executor stopped")
+	if notifier != nil {
+		// Runs finished during the drain may still be notifying.
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), 15*time.Second)
+		if err := notifier.Wait(waitCtx); err != nil {
+			slog.Warn("run notifications still in flight at shutdown", "error", err)
+		}
+		waitCancel()
+	}
 	if stopEventBus != nil {
 		stopEventBus()
 		slog.Info("event bus stopped")
@@ -1028,6 +1257,10 @@ func main() {
 		srv.RateLimiterStop()
 		slog.Info("rate limiter stopped")
 	}
+	if srv.RouteRateLimiterStop != nil {
+		srv.RouteRateLimiterStop()
+		slog.Info("route rate limiters stopped")
+	}
 	if srv.WebhookRateLimiterStop != nil {
 		srv.WebhookRateLimiterStop()
 		slog.Info("webhook rate limiter stopped")
@@ -32,6 +32,7 @@ type SubmitPipelineRequest struct {
 	Env               map[string]string      `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                                      // additional environment variables
 	PublishedVersions map[string]string      `protobuf:"bytes,7,rep,name=published_versions,json=publishedVersions,proto3" json:"published_versions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // file path -> S3 version ID
 	RunId             string                 `protobuf:"bytes,8,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`                                                                                                               // platform-assigned run ID (used for archive folder names)
+	Parameters        map[string]string      `protobuf:"bytes,9,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                        // per-run pipeline parameters
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -122,6 +123,13 @@ func (x *SubmitPipelineRequest) GetRunId() string {
 	return ""
 }
 
+func (x *SubmitPipelineRequest) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
 type SubmitPipelineResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
@@ -700,6 +708,7 @@ type ValidatePipelineRequest struct {
 	Layer         v1.Layer               `protobuf:"varint,2,opt,name=layer,proto3,enum=ratatouille.common.v1.Layer" json:"layer,omitempty"`
 	PipelineName  string                 `protobuf:"bytes,3,opt,name=pipeline_name,json=pipelineName,proto3" json:"pipeline_name,omitempty"`
 	S3Credentials *v1.S3Credentials      `protobuf:"bytes,4,opt,name=s3_credentials,json=s3Credentials,proto3" json:"s3_credentials,omitempty"` // S3 connection parameters
+	S3Prefix      string                 `protobuf:"bytes,5,opt,name=s3_prefix,json=s3Prefix,proto3" json:"s3_prefix,omitempty"`                // pipeline storage prefix (empty = default layout)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -762,6 +771,13 @@ func (x *ValidatePipelineRequest) GetS3Credentials() *v1.S3Credentials {
 	return nil
 }
 
+func (x *ValidatePipelineRequest) GetS3Prefix() string {
+	if x != nil {
+		return x.S3Prefix
+	}
+	return ""
+}
+
 type ValidatePipelineResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"` // true if all files passed validation
@@ -1032,11 +1048,187 @@ func (x *RunnerPlugin) GetPackageName() string {
 	return ""
 }
 
+type GetCapacityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCapacityRequest) Reset() {
+	*x = GetCapacityRequest{}
+	mi := &file_runner_v1_runner_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapacityRequest) ProtoMessage() {}
+
+func (x *GetCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_v1_runner_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapacityRequest.ProtoReflect.Descriptor instead.
+func (*GetCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_runner_v1_runner_proto_rawDescGZIP(), []int{14}
+}
+
+type GetCapacityResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MaxConcurrentRuns int32                  `protobuf:"varint,1,opt,name=max_concurrent_runs,json=maxConcurrentRuns,proto3" json:"max_concurrent_runs,omitempty"` // RUNNER_MAX_CONCURRENT
+	ActiveRuns        int32                  `protobuf:"varint,2,opt,name=active_runs,json=activeRuns,proto3" json:"active_runs,omitempty"`                        // runs currently pending or running
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetCapacityResponse) Reset() {
+	*x = GetCapacityResponse{}
+	mi := &file_runner_v1_runner_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapacityResponse) ProtoMessage() {}
+
+func (x *GetCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_v1_runner_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapacityResponse.ProtoReflect.Descriptor instead.
+func (*GetCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_runner_v1_runner_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetCapacityResponse) GetMaxConcurrentRuns() int32 {
+	if x != nil {
+		return x.MaxConcurrentRuns
+	}
+	return 0
+}
+
+func (x *GetCapacityResponse) GetActiveRuns() int32 {
+	if x != nil {
+		return x.ActiveRuns
+	}
+	return 0
+}
+
+type InferSampleSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []string               `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"` // S3 keys of the sample files
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InferSampleSchemaRequest) Reset() {
+	*x = InferSampleSchemaRequest{}
+	mi := &file_runner_v1_runner_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InferSampleSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InferSampleSchemaRequest) ProtoMessage() {}
+
+func (x *InferSampleSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_v1_runner_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InferSampleSchemaRequest.ProtoReflect.Descriptor instead.
+func (*InferSampleSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_runner_v1_runner_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InferSampleSchemaRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type InferSampleSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Columns       []*ColumnInfo          `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"` // columns across all samples, DuckDB types
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InferSampleSchemaResponse) Reset() {
+	*x = InferSampleSchemaResponse{}
+	mi := &file_runner_v1_runner_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InferSampleSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InferSampleSchemaResponse) ProtoMessage() {}
+
+func (x *InferSampleSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_runner_v1_runner_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InferSampleSchemaResponse.ProtoReflect.Descriptor instead.
+func (*InferSampleSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_runner_v1_runner_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *InferSampleSchemaResponse) GetColumns() []*ColumnInfo {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
 var File_runner_v1_runner_proto protoreflect.FileDescriptor
 
 const file_runner_v1_runner_proto_rawDesc = "" +
 	"\n" +
-	"\x16runner/v1/runner.proto\x12\x15ratatouille.runner.v1\x1a\x16common/v1/common.proto\"\xc7\x04\n" +
+	"\x16runner/v1/runner.proto\x12\x15ratatouille.runner.v1\x1a\x16common/v1/common.proto\"\xe4\x05\n" +
 	"\x15SubmitPipelineRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x122\n" +
 	"\x05layer\x18\x02 \x01(\x0e2\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n" +
@@ -1045,12 +1237,18 @@ const file_runner_v1_runner_proto_rawDesc = "" +
 	"\x0es3_credentials\x18\x05 \x01(\v2$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\x12G\n" +
 	"\x03env\x18\x06 \x03(\v25.ratatouille.runner.v1.SubmitPipelineRequest.EnvEntryR\x03env\x12r\n" +
 	"\x12published_versions\x18\a \x03(\v2C.ratatouille.runner.v1.SubmitPipelineRequest.PublishedVersionsEntryR\x11publishedVersions\x12\x15\n" +
-	"\x06run_id\x18\b \x01(\tR\x05runId\x1a6\n" +
+	"\x06run_id\x18\b \x01(\tR\x05runId\x12\\\n" +
+	"\n" +
+	"parameters\x18\t \x03(\v2<.ratatouille.runner.v1.SubmitPipelineRequest.ParametersEntryR\n" +
+	"parameters\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aD\n" +
 	"\x16PublishedVersionsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
 	"\x16SubmitPipelineResponse\x12\x15\n" +
 	"\x06run_id\x18\x01 \x01(\tR\x05runId\x128\n" +
@@ -1103,12 +1301,13 @@ const file_runner_v1_runner_proto_rawDesc = "" +
 	"\bmetadata\x18\x03 \x03(\v21.ratatouille.runner.v1.PhaseProfile.MetadataEntryR\bmetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xdd\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfa\x01\n" +
 	"\x17ValidatePipelineRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x122\n" +
 	"\x05layer\x18\x02 \x01(\x0e2\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n" +
 	"\rpipeline_name\x18\x03 \x01(\tR\fpipelineName\x12K\n" +
-	"\x0es3_credentials\x18\x04 \x01(\v2$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\"m\n" +
+	"\x0es3_credentials\x18\x04 \x01(\v2$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\x12\x1b\n" +
+	"\ts3_prefix\x18\x05 \x01(\tR\bs3Prefix\"m\n" +
 	"\x18ValidatePipelineResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12;\n" +
 	"\x05files\x18\x02 \x03(\v2%.ratatouille.runner.v1.FileValidationR\x05files\"n\n" +
@@ -1124,7 +1323,16 @@ const file_runner_v1_runner_proto_rawDesc = "" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n" +
 	"\aversion\x18\x03 \x01(\tR\aversion\x12!\n" +
-	"\fpackage_name\x18\x04 \x01(\tR\vpackageName2\xef\x05\n" +
+	"\fpackage_name\x18\x04 \x01(\tR\vpackageName\"\x14\n" +
+	"\x12GetCapacityRequest\"f\n" +
+	"\x13GetCapacityResponse\x12.\n" +
+	"\x13max_concurrent_runs\x18\x01 \x01(\x05R\x11maxConcurrentRuns\x12\x1f\n" +
+	"\vactive_runs\x18\x02 \x01(\x05R\n" +
+	"activeRuns\"0\n" +
+	"\x18InferSampleSchemaRequest\x12\x14\n" +
+	"\x05paths\x18\x01 \x03(\tR\x05paths\"X\n" +
+	"\x19InferSampleSchemaResponse\x12;\n" +
+	"\acolumns\x18\x01 \x03(\v2!.ratatouille.runner.v1.ColumnInfoR\acolumns2\xcd\a\n" +
 	"\rRunnerService\x12m\n" +
 	"\x0eSubmitPipeline\x12,.ratatouille.runner.v1.SubmitPipelineRequest\x1a-.ratatouille.runner.v1.SubmitPipelineResponse\x12g\n" +
 	"\fGetRunStatus\x12*.ratatouille.common.v1.GetRunStatusRequest\x1a+.ratatouille.common.v1.GetRunStatusResponse\x12Y\n" +
@@ -1133,7 +1341,9 @@ const file_runner_v1_runner_proto_rawDesc = "" +
 	"\tCancelRun\x12'.ratatouille.common.v1.CancelRunRequest\x1a(.ratatouille.common.v1.CancelRunResponse\x12p\n" +
 	"\x0fPreviewPipeline\x12-.ratatouille.runner.v1.PreviewPipelineRequest\x1a..ratatouille.runner.v1.PreviewPipelineResponse\x12s\n" +
 	"\x10ValidatePipeline\x12..ratatouille.runner.v1.ValidatePipelineRequest\x1a/.ratatouille.runner.v1.ValidatePipelineResponse\x12d\n" +
-	"\vListPlugins\x12).ratatouille.runner.v1.ListPluginsRequest\x1a*.ratatouille.runner.v1.ListPluginsResponseB\xd7\x01\n" +
+	"\vListPlugins\x12).ratatouille.runner.v1.ListPluginsRequest\x1a*.ratatouille.runner.v1.ListPluginsResponse\x12d\n" +
+	"\vGetCapacity\x12).ratatouille.runner.v1.GetCapacityRequest\x1a*.ratatouille.runner.v1.GetCapacityResponse\x12v\n" +
+	"\x11InferSampleSchema\x12/.ratatouille.runner.v1.InferSampleSchemaRequest\x1a0.ratatouille.runner.v1.InferSampleSchemaResponseB\xd7\x01\n" +
 	"\x19com.ratatouille.runner.v1B\vRunnerProtoP\x01Z7github.com/rat-data/rat/platform/gen/runner/v1;runnerv1\xa2\x02\x03RRX\xaa\x02\x15Ratatouille.Runner.V1\xca\x02\x15Ratatouille\\Runner\\V1\xe2\x02!Ratatouille\\Runner\\V1\\GPBMetadata\xea\x02\x17Ratatouille::Runner::V1b\x06proto3"
 
 var (
@@ -1148,76 +1358,87 @@ func file_runner_v1_runner_proto_rawDescGZIP() []byte {
 	return file_runner_v1_runner_proto_rawDescData
 }
 
-var file_runner_v1_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_runner_v1_runner_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_runner_v1_runner_proto_goTypes = []any{
-	(*SubmitPipelineRequest)(nil),    // 0: ratatouille.runner.v1.SubmitPipelineRequest
-	(*SubmitPipelineResponse)(nil),   // 1: ratatouille.runner.v1.SubmitPipelineResponse
-	(*PreviewPipelineRequest)(nil),   // 2: ratatouille.runner.v1.PreviewPipelineRequest
-	(*PreviewPipelineResponse)(nil),  // 3: ratatouille.runner.v1.PreviewPipelineResponse
-	(*PreviewSuccess)(nil),           // 4: ratatouille.runner.v1.PreviewSuccess
-	(*PreviewFailure)(nil),           // 5: ratatouille.runner.v1.PreviewFailure
-	(*ColumnInfo)(nil),               // 6: ratatouille.runner.v1.ColumnInfo
-	(*PhaseProfile)(nil),             // 7: ratatouille.runner.v1.PhaseProfile
-	(*ValidatePipelineRequest)(nil),  // 8: ratatouille.runner.v1.ValidatePipelineRequest
-	(*ValidatePipelineResponse)(nil), // 9: ratatouille.runner.v1.ValidatePipelineResponse
-	(*FileValidation)(nil),           // 10: ratatouille.runner.v1.FileValidation
-	(*ListPluginsRequest)(nil),       // 11: ratatouille.runner.v1.ListPluginsRequest
-	(*ListPluginsResponse)(nil),      // 12: ratatouille.runner.v1.ListPluginsResponse
-	(*RunnerPlugin)(nil),             // 13: ratatouille.runner.v1.RunnerPlugin
-	nil,                              // 14: ratatouille.runner.v1.SubmitPipelineRequest.EnvEntry
-	nil,                              // 15: ratatouille.runner.v1.SubmitPipelineRequest.PublishedVersionsEntry
-	nil,                              // 16: ratatouille.runner.v1.PreviewPipelineRequest.EnvEntry
-	nil,                              // 17: ratatouille.runner.v1.PhaseProfile.MetadataEntry
-	(v1.Layer)(0),                    // 18: ratatouille.common.v1.Layer
-	(*v1.S3Credentials)(nil),         // 19: ratatouille.common.v1.S3Credentials
-	(v1.RunStatus)(0),                // 20: ratatouille.common.v1.RunStatus
-	(*v1.LogEntry)(nil),              // 21: ratatouille.common.v1.LogEntry
-	(*v1.GetRunStatusRequest)(nil),   // 22: ratatouille.common.v1.GetRunStatusRequest
-	(*v1.StreamLogsRequest)(nil),     // 23: ratatouille.common.v1.StreamLogsRequest
-	(*v1.CancelRunRequest)(nil),      // 24: ratatouille.common.v1.CancelRunRequest
-	(*v1.GetRunStatusResponse)(nil),  // 25: ratatouille.common.v1.GetRunStatusResponse
-	(*v1.CancelRunResponse)(nil),     // 26: ratatouille.common.v1.CancelRunResponse
+	(*SubmitPipelineRequest)(nil),     // 0: ratatouille.runner.v1.SubmitPipelineRequest
+	(*SubmitPipelineResponse)(nil),    // 1: ratatouille.runner.v1.SubmitPipelineResponse
+	(*PreviewPipelineRequest)(nil),    // 2: ratatouille.runner.v1.PreviewPipelineRequest
+	(*PreviewPipelineResponse)(nil),   // 3: ratatouille.runner.v1.PreviewPipelineResponse
+	(*PreviewSuccess)(nil),            // 4: ratatouille.runner.v1.PreviewSuccess
+	(*PreviewFailure)(nil),            // 5: ratatouille.runner.v1.PreviewFailure
+	(*ColumnInfo)(nil),                // 6: ratatouille.runner.v1.ColumnInfo
+	(*PhaseProfile)(nil),              // 7: ratatouille.runner.v1.PhaseProfile
+	(*ValidatePipelineRequest)(nil),   // 8: ratatouille.runner.v1.ValidatePipelineRequest
+	(*ValidatePipelineResponse)(nil),  // 9: ratatouille.runner.v1.ValidatePipelineResponse
+	(*FileValidation)(nil),            // 10: ratatouille.runner.v1.FileValidation
+	(*ListPluginsRequest)(nil),        // 11: ratatouille.runner.v1.ListPluginsRequest
+	(*ListPluginsResponse)(nil),       // 12: ratatouille.runner.v1.ListPluginsResponse
+	(*RunnerPlugin)(nil),              // 13: ratatouille.runner.v1.RunnerPlugin
+	(*GetCapacityRequest)(nil),        // 14: ratatouille.runner.v1.GetCapacityRequest
+	(*GetCapacityResponse)(nil),       // 15: ratatouille.runner.v1.GetCapacityResponse
+	(*InferSampleSchemaRequest)(nil),  // 16: ratatouille.runner.v1.InferSampleSchemaRequest
+	(*InferSampleSchemaResponse)(nil), // 17: ratatouille.runner.v1.InferSampleSchemaResponse
+	nil,                               // 18: ratatouille.runner.v1.SubmitPipelineRequest.EnvEntry
+	nil,                               // 19: ratatouille.runner.v1.SubmitPipelineRequest.PublishedVersionsEntry
+	nil,                               // 20: ratatouille.runner.v1.SubmitPipelineRequest.ParametersEntry
+	nil,                               // 21: ratatouille.runner.v1.PreviewPipelineRequest.EnvEntry
+	nil,                               // 22: ratatouille.runner.v1.PhaseProfile.MetadataEntry
+	(v1.Layer)(0),                     // 23: ratatouille.common.v1.Layer
+	(*v1.S3Credentials)(nil),          // 24: ratatouille.common.v1.S3Credentials
+	(v1.RunStatus)(0),                 // 25: ratatouille.common.v1.RunStatus
+	(*v1.LogEntry)(nil),               // 26: ratatouille.common.v1.LogEntry
+	(*v1.GetRunStatusRequest)(nil),    // 27: ratatouille.common.v1.GetRunStatusRequest
+	(*v1.StreamLogsRequest)(nil),      // 28: ratatouille.common.v1.StreamLogsRequest
+	(*v1.CancelRunRequest)(nil),       // 29: ratatouille.common.v1.CancelRunRequest
+	(*v1.GetRunStatusResponse)(nil),   // 30: ratatouille.common.v1.GetRunStatusResponse
+	(*v1.CancelRunResponse)(nil),      // 31: ratatouille.common.v1.CancelRunResponse
 }
 var file_runner_v1_runner_proto_depIdxs = []int32{
-	18, // 0: ratatouille.runner.v1.SubmitPipelineRequest.layer:type_name -> ratatouille.common.v1.Layer
-	19, // 1: ratatouille.runner.v1.SubmitPipelineRequest.s3_credentials:type_name -> ratatouille.common.v1.S3Credentials
-	14, // 2: ratatouille.runner.v1.SubmitPipelineRequest.env:type_name -> ratatouille.runner.v1.SubmitPipelineRequest.EnvEntry
-	15, // 3: ratatouille.runner.v1.SubmitPipelineRequest.published_versions:type_name -> ratatouille.runner.v1.SubmitPipelineRequest.PublishedVersionsEntry
-	20, // 4: ratatouille.runner.v1.SubmitPipelineResponse.status:type_name -> ratatouille.common.v1.RunStatus
-	18, // 5: ratatouille.runner.v1.PreviewPipelineRequest.layer:type_name -> ratatouille.common.v1.Layer
-	19, // 6: ratatouille.runner.v1.PreviewPipelineRequest.s3_credentials:type_name -> ratatouille.common.v1.S3Credentials
-	16, // 7: ratatouille.runner.v1.PreviewPipelineRequest.env:type_name -> ratatouille.runner.v1.PreviewPipelineRequest.EnvEntry
-	4,  // 8: ratatouille.runner.v1.PreviewPipelineResponse.data:type_name -> ratatouille.runner.v1.PreviewSuccess
-	5,  // 9: ratatouille.runner.v1.PreviewPipelineResponse.preview_error:type_name -> ratatouille.runner.v1.PreviewFailure
-	21, // 10: ratatouille.runner.v1.PreviewPipelineResponse.logs:type_name -> ratatouille.common.v1.LogEntry
-	6,  // 11: ratatouille.runner.v1.PreviewPipelineResponse.columns:type_name -> ratatouille.runner.v1.ColumnInfo
-	7,  // 12: ratatouille.runner.v1.PreviewPipelineResponse.phases:type_name -> ratatouille.runner.v1.PhaseProfile
-	6,  // 13: ratatouille.runner.v1.PreviewSuccess.columns:type_name -> ratatouille.runner.v1.ColumnInfo
-	7,  // 14: ratatouille.runner.v1.PreviewSuccess.phases:type_name -> ratatouille.runner.v1.PhaseProfile
-	17, // 15: ratatouille.runner.v1.PhaseProfile.metadata:type_name -> ratatouille.runner.v1.PhaseProfile.MetadataEntry
-	18, // 16: ratatouille.runner.v1.ValidatePipelineRequest.layer:type_name -> ratatouille.common.v1.Layer
-	19, // 17: ratatouille.runner.v1.ValidatePipelineRequest.s3_credentials:type_name -> ratatouille.common.v1.S3Credentials
-	10, // 18: ratatouille.runner.v1.ValidatePipelineResponse.files:type_name -> ratatouille.runner.v1.FileValidation
-	13, // 19: ratatouille.runner.v1.ListPluginsResponse.plugins:type_name -> ratatouille.runner.v1.RunnerPlugin
-	0,  // 20: ratatouille.runner.v1.RunnerService.SubmitPipeline:input_type -> ratatouille.runner.v1.SubmitPipelineRequest
-	22, // 21: ratatouille.runner.v1.RunnerService.GetRunStatus:input_type -> ratatouille.common.v1.GetRunStatusRequest
-	23, // 22: ratatouille.runner.v1.RunnerService.StreamLogs:input_type -> ratatouille.common.v1.StreamLogsRequest
-	24, // 23: ratatouille.runner.v1.RunnerService.CancelRun:input_type -> ratatouille.common.v1.CancelRunRequest
-	2,  // 24: ratatouille.runner.v1.RunnerService.PreviewPipeline:input_type -> ratatouille.runner.v1.PreviewPipelineRequest
-	8,  // 25: ratatouille.runner.v1.RunnerService.ValidatePipeline:input_type -> ratatouille.runner.v1.ValidatePipelineRequest
-	11, // 26: ratatouille.runner.v1.RunnerService.ListPlugins:input_type -> ratatouille.runner.v1.ListPluginsRequest
-	1,  // 27: ratatouille.runner.v1.RunnerService.SubmitPipeline:output_type -> ratatouille.runner.v1.SubmitPipelineResponse
-	25, // 28: ratatouille.runner.v1.RunnerService.GetRunStatus:output_type -> ratatouille.common.v1.GetRunStatusResponse
-	21, // 29: ratatouille.runner.v1.RunnerService.StreamLogs:output_type -> ratatouille.common.v1.LogEntry
-	26, // 30: ratatouille.runner.v1.RunnerService.CancelRun:output_type -> ratatouille.common.v1.CancelRunResponse
-	3,  // 31: ratatouille.runner.v1.RunnerService.PreviewPipeline:output_type -> ratatouille.runner.v1.PreviewPipelineResponse
-	9,  // 32: ratatouille.runner.v1.RunnerService.ValidatePipeline:output_type -> ratatouille.runner.v1.ValidatePipelineResponse
-	12, // 33: ratatouille.runner.v1.RunnerService.ListPlugins:output_type -> ratatouille.runner.v1.ListPluginsResponse
-	27, // [27:34] is the sub-list for method output_type
-	20, // [20:27] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	23, // 0: ratatouille.runner.v1.SubmitPipelineRequest.layer:type_name -> ratatouille.common.v1.Layer
+	24, // 1: ratatouille.runner.v1.SubmitPipelineRequest.s3_credentials:type_name -> ratatouille.common.v1.S3Credentials
+	18, // 2: ratatouille.runner.v1.SubmitPipelineRequest.env:type_name -> ratatouille.runner.v1.SubmitPipelineRequest.EnvEntry
+	19, // 3: ratatouille.runner.v1.SubmitPipelineRequest.published_versions:type_name -> ratatouille.runner.v1.SubmitPipelineRequest.PublishedVersionsEntry
+	20, // 4: ratatouille.runner.v1.SubmitPipelineRequest.parameters:type_name -> ratatouille.runner.v1.SubmitPipelineRequest.ParametersEntry
+	25, // 5: ratatouille.runner.v1.SubmitPipelineResponse.status:type_name -> ratatouille.common.v1.RunStatus
+	23, // 6: ratatouille.runner.v1.PreviewPipelineRequest.layer:type_name -> ratatouille.common.v1.Layer
+	24, // 7: ratatouille.runner.v1.PreviewPipelineRequest.s3_credentials:type_name -> ratatouille.common.v1.S3Credentials
+	21, // 8: ratatouille.runner.v1.PreviewPipelineRequest.env:type_name -> ratatouille.runner.v1.PreviewPipelineRequest.EnvEntry
+	4,  // 9: ratatouille.runner.v1.PreviewPipelineResponse.data:type_name -> ratatouille.runner.v1.PreviewSuccess
+	5,  // 10: ratatouille.runner.v1.PreviewPipelineResponse.preview_error:type_name -> ratatouille.runner.v1.PreviewFailure
+	26, // 11: ratatouille.runner.v1.PreviewPipelineResponse.logs:type_name -> ratatouille.common.v1.LogEntry
+	6,  // 12: ratatouille.runner.v1.PreviewPipelineResponse.columns:type_name -> ratatouille.runner.v1.ColumnInfo
+	7,  // 13: ratatouille.runner.v1.PreviewPipelineResponse.phases:type_name -> ratatouille.runner.v1.PhaseProfile
+	6,  // 14: ratatouille.runner.v1.PreviewSuccess.columns:type_name -> ratatouille.runner.v1.ColumnInfo
+	7,  // 15: ratatouille.runner.v1.PreviewSuccess.phases:type_name -> ratatouille.runner.v1.PhaseProfile
+	22, // 16: ratatouille.runner.v1.PhaseProfile.metadata:type_name -> ratatouille.runner.v1.PhaseProfile.MetadataEntry
+	23, // 17: ratatouille.runner.v1.ValidatePipelineRequest.layer:type_name -> ratatouille.common.v1.Layer
+	24, // 18: ratatouille.runner.v1.ValidatePipelineRequest.s3_credentials:type_name -> ratatouille.common.v1.S3Credentials
+	10, // 19: ratatouille.runner.v1.ValidatePipelineResponse.files:type_name -> ratatouille.runner.v1.FileValidation
+	13, // 20: ratatouille.runner.v1.ListPluginsResponse.plugins:type_name -> ratatouille.runner.v1.RunnerPlugin
+	6,  // 21: ratatouille.runner.v1.InferSampleSchemaResponse.columns:type_name -> ratatouille.runner.v1.ColumnInfo
+	0,  // 22: ratatouille.runner.v1.RunnerService.SubmitPipeline:input_type -> ratatouille.runner.v1.SubmitPipelineRequest
+	27, // 23: ratatouille.runner.v1.RunnerService.GetRunStatus:input_type -> ratatouille.common.v1.GetRunStatusRequest
+	28, // 24: ratatouille.runner.v1.RunnerService.StreamLogs:input_type -> ratatouille.common.v1.StreamLogsRequest
+	29, // 25: ratatouille.runner.v1.RunnerService.CancelRun:input_type -> ratatouille.common.v1.CancelRunRequest
+	2,  // 26: ratatouille.runner.v1.RunnerService.PreviewPipeline:input_type -> ratatouille.runner.v1.PreviewPipelineRequest
+	8,  // 27: ratatouille.runner.v1.RunnerService.ValidatePipeline:input_type -> ratatouille.runner.v1.ValidatePipelineRequest
+	11, // 28: ratatouille.runner.v1.RunnerService.ListPlugins:input_type -> ratatouille.runner.v1.ListPluginsRequest
+	14, // 29: ratatouille.runner.v1.RunnerService.GetCapacity:input_type -> ratatouille.runner.v1.GetCapacityRequest
+	16, // 30: ratatouille.runner.v1.RunnerService.InferSampleSchema:input_type -> ratatouille.runner.v1.InferSampleSchemaRequest
+	1,  // 31: ratatouille.runner.v1.RunnerService.SubmitPipeline:output_type -> ratatouille.runner.v1.SubmitPipelineResponse
+	30, // 32: ratatouille.runner.v1.RunnerService.GetRunStatus:output_type -> ratatouille.common.v1.GetRunStatusResponse
+	26, // 33: ratatouille.runner.v1.RunnerService.StreamLogs:output_type -> ratatouille.common.v1.LogEntry
+	31, // 34: ratatouille.runner.v1.RunnerService.CancelRun:output_type -> ratatouille.common.v1.CancelRunResponse
+	3,  // 35: ratatouille.runner.v1.RunnerService.PreviewPipeline:output_type -> ratatouille.runner.v1.PreviewPipelineResponse
+	9,  // 36: ratatouille.runner.v1.RunnerService.ValidatePipeline:output_type -> ratatouille.runner.v1.ValidatePipelineResponse
+	12, // 37: ratatouille.runner.v1.RunnerService.ListPlugins:output_type -> ratatouille.runner.v1.ListPluginsResponse
+	15, // 38: ratatouille.runner.v1.RunnerService.GetCapacity:output_type -> ratatouille.runner.v1.GetCapacityResponse
+	17, // 39: ratatouille.runner.v1.RunnerService.InferSampleSchema:output_type -> ratatouille.runner.v1.InferSampleSchemaResponse
+	31, // [31:40] is the sub-list for method output_type
+	22, // [22:31] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_runner_v1_runner_proto_init() }
@@ -1235,7 +1456,7 @@ func file_runner_v1_runner_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_runner_v1_runner_proto_rawDesc), len(file_runner_v1_runner_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   18,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
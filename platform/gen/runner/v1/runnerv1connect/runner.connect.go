@@ -54,6 +54,12 @@ const (
 	// RunnerServiceListPluginsProcedure is the fully-qualified name of the RunnerService's ListPlugins
 	// RPC.
 	RunnerServiceListPluginsProcedure = "/ratatouille.runner.v1.RunnerService/ListPlugins"
+	// RunnerServiceGetCapacityProcedure is the fully-qualified name of the RunnerService's GetCapacity
+	// RPC.
+	RunnerServiceGetCapacityProcedure = "/ratatouille.runner.v1.RunnerService/GetCapacity"
+	// RunnerServiceInferSampleSchemaProcedure is the fully-qualified name of the RunnerService's
+	// InferSampleSchema RPC.
+	RunnerServiceInferSampleSchemaProcedure = "/ratatouille.runner.v1.RunnerService/InferSampleSchema"
 )
 
 // RunnerServiceClient is a client for the ratatouille.runner.v1.RunnerService service.
@@ -76,6 +82,14 @@ type RunnerServiceClient interface {
 	// List all discovered runner plugins (entry points installed in the runner container).
 	// Called by the platform to expose runner plugin metadata to the portal UI.
 	ListPlugins(context.Context, *connect.Request[v1.ListPluginsRequest]) (*connect.Response[v1.ListPluginsResponse], error)
+	// Report the runner's concurrent run limit and how many runs it is executing.
+	// Called by the platform so the UI can show runner saturation before submits
+	// start failing with RESOURCE_EXHAUSTED.
+	GetCapacity(context.Context, *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error)
+	// Infer the columns of landing zone sample files with the runner's DuckDB readers.
+	// Called by the platform for GET /landing-zones/{ns}/{name}/samples/schema, so the
+	// schema matches what a bronze pipeline reading those files would see.
+	InferSampleSchema(context.Context, *connect.Request[v1.InferSampleSchemaRequest]) (*connect.Response[v1.InferSampleSchemaResponse], error)
 }
 
 // NewRunnerServiceClient constructs a client for the ratatouille.runner.v1.RunnerService service.
@@ -131,18 +145,32 @@ func NewRunnerServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(runnerServiceMethods.ByName("ListPlugins")),
 			connect.WithClientOptions(opts...),
 		),
+		getCapacity: connect.NewClient[v1.GetCapacityRequest, v1.GetCapacityResponse](
+			httpClient,
+			baseURL+RunnerServiceGetCapacityProcedure,
+			connect.WithSchema(runnerServiceMethods.ByName("GetCapacity")),
+			connect.WithClientOptions(opts...),
+		),
+		inferSampleSchema: connect.NewClient[v1.InferSampleSchemaRequest, v1.InferSampleSchemaResponse](
+			httpClient,
+			baseURL+RunnerServiceInferSampleSchemaProcedure,
+			connect.WithSchema(runnerServiceMethods.ByName("InferSampleSchema")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // runnerServiceClient implements RunnerServiceClient.
 type runnerServiceClient struct {
-	submitPipeline   *connect.Client[v1.SubmitPipelineRequest, v1.SubmitPipelineResponse]
-	getRunStatus     *connect.Client[v11.GetRunStatusRequest, v11.GetRunStatusResponse]
-	streamLogs       *connect.Client[v11.StreamLogsRequest, v11.LogEntry]
-	cancelRun        *connect.Client[v11.CancelRunRequest, v11.CancelRunResponse]
-	previewPipeline  *connect.Client[v1.PreviewPipelineRequest, v1.PreviewPipelineResponse]
-	validatePipeline *connect.Client[v1.ValidatePipelineRequest, v1.ValidatePipelineResponse]
-	listPlugins      *connect.Client[v1.ListPluginsRequest, v1.ListPluginsResponse]
+	submitPipeline    *connect.Client[v1.SubmitPipelineRequest, v1.SubmitPipelineResponse]
+	getRunStatus      *connect.Client[v11.GetRunStatusRequest, v11.GetRunStatusResponse]
+	streamLogs        *connect.Client[v11.StreamLogsRequest, v11.LogEntry]
+	cancelRun         *connect.Client[v11.CancelRunRequest, v11.CancelRunResponse]
+	previewPipeline   *connect.Client[v1.PreviewPipelineRequest, v1.PreviewPipelineResponse]
+	validatePipeline  *connect.Client[v1.ValidatePipelineRequest, v1.ValidatePipelineResponse]
+	listPlugins       *connect.Client[v1.ListPluginsRequest, v1.ListPluginsResponse]
+	getCapacity       *connect.Client[v1.GetCapacityRequest, v1.GetCapacityResponse]
+	inferSampleSchema *connect.Client[v1.InferSampleSchemaRequest, v1.InferSampleSchemaResponse]
 }
 
 // SubmitPipeline calls ratatouille.runner.v1.RunnerService.SubmitPipeline.
@@ -180,6 +208,16 @@ func (c *runnerServiceClient) ListPlugins(ctx context.Context, req *connect.Requ
 	return c.listPlugins.CallUnary(ctx, req)
 }
 
+// GetCapacity calls ratatouille.runner.v1.RunnerService.GetCapacity.
+func (c *runnerServiceClient) GetCapacity(ctx context.Context, req *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error) {
+	return c.getCapacity.CallUnary(ctx, req)
+}
+
+// InferSampleSchema calls ratatouille.runner.v1.RunnerService.InferSampleSchema.
+func (c *runnerServiceClient) InferSampleSchema(ctx context.Context, req *connect.Request[v1.InferSampleSchemaRequest]) (*connect.Response[v1.InferSampleSchemaResponse], error) {
+	return c.inferSampleSchema.CallUnary(ctx, req)
+}
+
 // RunnerServiceHandler is an implementation of the ratatouille.runner.v1.RunnerService service.
 type RunnerServiceHandler interface {
 	// Submit a pipeline for execution.
@@ -200,6 +238,14 @@ type RunnerServiceHandler interface {
 	// List all discovered runner plugins (entry points installed in the runner container).
 	// Called by the platform to expose runner plugin metadata to the portal UI.
 	ListPlugins(context.Context, *connect.Request[v1.ListPluginsRequest]) (*connect.Response[v1.ListPluginsResponse], error)
+	// Report the runner's concurrent run limit and how many runs it is executing.
+	// Called by the platform so the UI can show runner saturation before submits
+	// start failing with RESOURCE_EXHAUSTED.
+	GetCapacity(context.Context, *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error)
+	// Infer the columns of landing zone sample files with the runner's DuckDB readers.
+	// Called by the platform for GET /landing-zones/{ns}/{name}/samples/schema, so the
+	// schema matches what a bronze pipeline reading those files would see.
+	InferSampleSchema(context.Context, *connect.Request[v1.InferSampleSchemaRequest]) (*connect.Response[v1.InferSampleSchemaResponse], error)
 }
 
 // NewRunnerServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -251,6 +297,18 @@ func NewRunnerServiceHandler(svc RunnerServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(runnerServiceMethods.ByName("ListPlugins")),
 		connect.WithHandlerOptions(opts...),
 	)
+	runnerServiceGetCapacityHandler := connect.NewUnaryHandler(
+		RunnerServiceGetCapacityProcedure,
+		svc.GetCapacity,
+		connect.WithSchema(runnerServiceMethods.ByName("GetCapacity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	runnerServiceInferSampleSchemaHandler := connect.NewUnaryHandler(
+		RunnerServiceInferSampleSchemaProcedure,
+		svc.InferSampleSchema,
+		connect.WithSchema(runnerServiceMethods.ByName("InferSampleSchema")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/ratatouille.runner.v1.RunnerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RunnerServiceSubmitPipelineProcedure:
@@ -267,6 +325,10 @@ func NewRunnerServiceHandler(svc RunnerServiceHandler, opts ...connect.HandlerOp
 			runnerServiceValidatePipelineHandler.ServeHTTP(w, r)
 		case RunnerServiceListPluginsProcedure:
 			runnerServiceListPluginsHandler.ServeHTTP(w, r)
+		case RunnerServiceGetCapacityProcedure:
+			runnerServiceGetCapacityHandler.ServeHTTP(w, r)
+		case RunnerServiceInferSampleSchemaProcedure:
+			runnerServiceInferSampleSchemaHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -303,3 +365,11 @@ func (UnimplementedRunnerServiceHandler) ValidatePipeline(context.Context, *conn
 func (UnimplementedRunnerServiceHandler) ListPlugins(context.Context, *connect.Request[v1.ListPluginsRequest]) (*connect.Response[v1.ListPluginsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ratatouille.runner.v1.RunnerService.ListPlugins is not implemented"))
 }
+
+func (UnimplementedRunnerServiceHandler) GetCapacity(context.Context, *connect.Request[v1.GetCapacityRequest]) (*connect.Response[v1.GetCapacityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ratatouille.runner.v1.RunnerService.GetCapacity is not implemented"))
+}
+
+func (UnimplementedRunnerServiceHandler) InferSampleSchema(context.Context, *connect.Request[v1.InferSampleSchemaRequest]) (*connect.Response[v1.InferSampleSchemaResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ratatouille.runner.v1.RunnerService.InferSampleSchema is not implemented"))
+}
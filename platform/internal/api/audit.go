@@ -2,11 +2,14 @@ package api
 
 import (
 	"context"
+	"encoding/csv"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/rat-data/rat/platform/internal/plugins"
 )
@@ -15,7 +18,67 @@ import (
 type AuditStore interface {
 	Log(ctx context.Context, userID, action, resource, detail, ip string) error
 	List(ctx context.Context, limit, offset int) ([]domain.AuditEntry, error)
+	ListFiltered(ctx context.Context, filter AuditFilter) ([]domain.AuditEntry, error)
+	// EachFiltered calls fn for every entry matching filter, most recent first,
+	// without materializing the result set (filter's paging fields are
+	// ignored). Stops at the first error from fn.
+	EachFiltered(ctx context.Context, filter AuditFilter, fn func(domain.AuditEntry) error) error
 	DeleteOlderThan(ctx context.Context, olderThan time.Time) (int, error)
+	// CountOlderThan returns how many entries DeleteOlderThan would remove.
+	CountOlderThan(ctx context.Context, olderThan time.Time) (int, error)
+}
+
+// AuditFilter holds optional filters for listing audit entries.
+// Zero-valued fields are ignored. Limit and Offset enable SQL-level pagination;
+// zero Limit means no limit.
+type AuditFilter struct {
+	UserID         string
+	Action         string     // exact match, e.g. "delete"
+	ResourcePrefix string     // prefix match, e.g. "/api/v1/pipelines/default/"
+	After          *time.Time // entries created at or after this time
+	Before         *time.Time // entries created before this time
+	Limit          int
+	Offset         int
+
+	// Cursor switches to keyset pagination: only entries strictly older than
+	// the cursor (by created_at, then id) are returned, newest first. Offset
+	// is ignored when set, so deep pages don't re-scan skipped rows.
+	Cursor *AuditCursor
+}
+
+// AuditCursor is the keyset position of an audit entry in the
+// created_at DESC, id DESC ordering. Only the export path pages by it.
+type AuditCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// parseAuditFilter reads audit filter query params. On a malformed timestamp
+// it writes a 400 and returns ok=false.
+func parseAuditFilter(w http.ResponseWriter, r *http.Request) (AuditFilter, bool) {
+	q := r.URL.Query()
+	filter := AuditFilter{
+		UserID:         q.Get("user_id"),
+		Action:         q.Get("action"),
+		ResourcePrefix: q.Get("resource"),
+	}
+	if v := q.Get("after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			errorJSON(w, "after must be RFC3339 format", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return filter, false
+		}
+		filter.After = &t
+	}
+	if v := q.Get("before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			errorJSON(w, "before must be RFC3339 format", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return filter, false
+		}
+		filter.Before = &t
+	}
+	return filter, true
 }
 
 // AuditMiddleware logs mutating API requests (POST, PUT, DELETE) to the audit store.
@@ -50,17 +113,24 @@ func AuditMiddleware(store AuditStore) func(http.Handler) http.Handler {
 // MountAuditRoutes registers audit log API endpoints.
 func MountAuditRoutes(r interface{ Get(string, http.HandlerFunc) }, srv *Server) {
 	r.Get("/audit", srv.HandleListAuditLog)
+	r.Get("/audit/export", srv.HandleExportAuditLog)
 }
 
-// HandleListAuditLog returns recent audit log entries.
+// HandleListAuditLog returns recent audit log entries, most recent first.
+// Optional filters: ?user_id=, ?action=, ?resource= (prefix match),
+// ?after=RFC3339 and ?before=RFC3339.
 func (s *Server) HandleListAuditLog(w http.ResponseWriter, r *http.Request) {
 	if s.Audit == nil {
 		errorJSON(w, "audit logging not enabled", "NOT_FOUND", http.StatusNotFound)
 		return
 	}
 
-	limit, offset := parsePagination(r)
-	entries, err := s.Audit.List(r.Context(), limit, offset)
+	filter, ok := parseAuditFilter(w, r)
+	if !ok {
+		return
+	}
+	filter.Limit, filter.Offset = parsePagination(r)
+	entries, err := s.Audit.ListFiltered(r.Context(), filter)
 	if err != nil {
 		internalError(w, "failed to list audit log", err)
 		return
@@ -71,3 +141,75 @@ func (s *Server) HandleListAuditLog(w http.ResponseWriter, r *http.Request) {
 		"total":   len(entries),
 	})
 }
+
+// auditExportFlushEvery is how many CSV rows are written between flushes, so
+// the client sees steady progress on large exports.
+const auditExportFlushEvery = 500
+
+// auditCSVHeader is the column order of the CSV audit export.
+var auditCSVHeader = []string{"id", "timestamp", "user", "action", "resource", "detail", "ip"}
+
+// csvSafe neutralizes spreadsheet formula injection: a cell starting with
+// =, +, -, @, tab or CR is prefixed with ' so spreadsheet apps show it as
+// text instead of evaluating it.
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// HandleExportAuditLog streams audit entries as a CSV attachment.
+// GET /api/v1/audit/export?format=csv
+//
+// Accepts the same filters as HandleListAuditLog (user_id, action, resource,
+// after, before) but no pagination — every matching entry is exported. The
+// store fetches keyset pages and rows are streamed to the response as they
+// arrive.
+func (s *Server) HandleExportAuditLog(w http.ResponseWriter, r *http.Request) {
+	if s.Audit == nil {
+		errorJSON(w, "audit logging not enabled", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if format := r.URL.Query().Get("format"); format != "" && format != "csv" {
+		errorJSON(w, "format must be csv", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	filter, ok := parseAuditFilter(w, r)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("audit-%s.csv", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	flusher, canFlush := w.(http.Flusher)
+	cw := csv.NewWriter(w)
+	_ = cw.Write(auditCSVHeader)
+
+	rows := 0
+	err := s.Audit.EachFiltered(r.Context(), filter, func(e domain.AuditEntry) error {
+		if err := cw.Write([]string{
+			csvSafe(e.ID), e.CreatedAt.UTC().Format(time.RFC3339), csvSafe(e.UserID), csvSafe(e.Action),
+			csvSafe(e.Resource), csvSafe(e.Detail), csvSafe(e.IP),
+		}); err != nil {
+			return err
+		}
+		rows++
+		if rows%auditExportFlushEvery == 0 {
+			cw.Flush()
+			if canFlush {
+				flusher.Flush()
+			}
+		}
+		return cw.Error()
+	})
+	cw.Flush()
+	if err != nil {
+		// Headers are already sent — the truncated file is all we can give.
+		slog.Error("audit export aborted", "rows_written", rows, "error", err)
+	}
+}
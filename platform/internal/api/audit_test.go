@@ -2,9 +2,11 @@ package api_test
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -38,6 +40,10 @@ func (s *memoryAuditStore) DeleteOlderThan(_ context.Context, _ time.Time) (int,
 	return 0, nil
 }
 
+func (s *memoryAuditStore) CountOlderThan(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
 func (s *memoryAuditStore) List(_ context.Context, limit, offset int) ([]domain.AuditEntry, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -51,6 +57,51 @@ func (s *memoryAuditStore) List(_ context.Context, limit, offset int) ([]domain.
 	return s.entries[offset:end], nil
 }
 
+func (s *memoryAuditStore) ListFiltered(_ context.Context, filter api.AuditFilter) ([]domain.AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []domain.AuditEntry{}
+	for _, e := range s.entries {
+		if filter.UserID != "" && e.UserID != filter.UserID {
+			continue
+		}
+		if filter.Action != "" && e.Action != filter.Action {
+			continue
+		}
+		if !strings.HasPrefix(e.Resource, filter.ResourcePrefix) {
+			continue
+		}
+		if filter.After != nil && e.CreatedAt.Before(*filter.After) {
+			continue
+		}
+		if filter.Before != nil && !e.CreatedAt.Before(*filter.Before) {
+			continue
+		}
+		result = append(result, e)
+	}
+	if filter.Limit > 0 {
+		if filter.Offset >= len(result) {
+			return []domain.AuditEntry{}, nil
+		}
+		end := filter.Offset + filter.Limit
+		if end > len(result) {
+			end = len(result)
+		}
+		result = result[filter.Offset:end]
+	}
+	return result, nil
+}
+
+func (s *memoryAuditStore) EachFiltered(ctx context.Context, filter api.AuditFilter, fn func(domain.AuditEntry) error) error {
+	entries, _ := s.ListFiltered(ctx, filter)
+	for _, e := range entries {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func TestAuditMiddleware_LogsMutatingRequests(t *testing.T) {
 	store := &memoryAuditStore{}
 	handler := api.AuditMiddleware(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -120,3 +171,103 @@ func TestHandleListAuditLog_NoStore_Returns404(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
+
+func TestHandleListAuditLog_Filters_ForwardedToStore(t *testing.T) {
+	lastWeek := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)
+	store := &memoryAuditStore{
+		entries: []domain.AuditEntry{
+			{ID: "1", UserID: "u-1", Action: "delete", Resource: "/api/v1/pipelines/default/bronze/orders", CreatedAt: lastWeek},
+			{ID: "2", UserID: "u-2", Action: "delete", Resource: "/api/v1/pipelines/default/bronze/events", CreatedAt: lastWeek},
+			{ID: "3", UserID: "u-1", Action: "post", Resource: "/api/v1/pipelines", CreatedAt: lastWeek},
+			{ID: "4", UserID: "u-1", Action: "delete", Resource: "/api/v1/namespaces/scratch", CreatedAt: lastWeek},
+			{ID: "5", UserID: "u-1", Action: "delete", Resource: "/api/v1/pipelines/default/bronze/old", CreatedAt: lastWeek.AddDate(0, -1, 0)},
+		},
+	}
+
+	srv := &api.Server{Audit: store}
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/audit?user_id=u-1&action=delete&resource=/api/v1/pipelines/&after=2026-02-01T00:00:00Z&before=2026-02-08T00:00:00Z", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	srv.HandleListAuditLog(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var envelope struct {
+		Entries []domain.AuditEntry `json:"entries"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &envelope))
+	require.Len(t, envelope.Entries, 1)
+	assert.Equal(t, "1", envelope.Entries[0].ID)
+}
+
+func TestHandleListAuditLog_InvalidAfter_Returns400(t *testing.T) {
+	srv := &api.Server{Audit: &memoryAuditStore{}}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit?after=yesterday", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	srv.HandleListAuditLog(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandleExportAuditLog_StreamsCSV(t *testing.T) {
+	at := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)
+	store := &memoryAuditStore{
+		entries: []domain.AuditEntry{
+			{ID: "1", UserID: "u-1", Action: "delete", Resource: "/api/v1/pipelines/default/bronze/orders", IP: "10.0.0.1", CreatedAt: at},
+			{ID: "2", UserID: "u-2", Action: "post", Resource: "/api/v1/pipelines", Detail: "said \"hi\", twice", CreatedAt: at},
+			{ID: "3", UserID: "u-1", Action: "put", Resource: "/api/v1/namespaces/x", CreatedAt: at.AddDate(0, -1, 0)},
+		},
+	}
+
+	srv := &api.Server{Audit: store}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit/export?format=csv&after=2026-02-01T00:00:00Z", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	srv.HandleExportAuditLog(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment; filename=")
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3, "header + 2 rows inside the window")
+	assert.Equal(t, []string{"id", "timestamp", "user", "action", "resource", "detail", "ip"}, records[0])
+	assert.Equal(t, []string{"1", "2026-02-05T12:00:00Z", "u-1", "delete", "/api/v1/pipelines/default/bronze/orders", "", "10.0.0.1"}, records[1])
+	assert.Equal(t, "said \"hi\", twice", records[2][5])
+}
+
+func TestHandleExportAuditLog_EscapesFormulaCells(t *testing.T) {
+	at := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)
+	store := &memoryAuditStore{
+		entries: []domain.AuditEntry{
+			{ID: "1", UserID: "=HYPERLINK(\"http://evil\")", Action: "+post", Resource: "-1+2", Detail: "@SUM(A1)", IP: "\t10.0.0.1", CreatedAt: at},
+			{ID: "2", UserID: "u-1", Action: "post", Resource: "/api/v1/x", Detail: "\rcmd", CreatedAt: at},
+		},
+	}
+
+	srv := &api.Server{Audit: store}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit/export", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	srv.HandleExportAuditLog(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"1", "2026-02-05T12:00:00Z", "'=HYPERLINK(\"http://evil\")", "'+post", "'-1+2", "'@SUM(A1)", "'\t10.0.0.1"}, records[1])
+	assert.Equal(t, "u-1", records[2][2], "ordinary cells are left alone")
+	assert.Equal(t, "'\rcmd", records[2][5])
+}
+
+func TestHandleExportAuditLog_UnsupportedFormat_Returns400(t *testing.T) {
+	srv := &api.Server{Audit: &memoryAuditStore{}}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit/export?format=xlsx", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	srv.HandleExportAuditLog(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AuditActionAuthFailure is the audit action of a rejected authentication
+// attempt: a bad or missing API key, a key used beyond its scope, or a bad
+// webhook token or signature.
+const AuditActionAuthFailure = "auth_failure"
+
+// Auth failure entries are capped per client IP and platform-wide so a
+// scanner hammering the API can't flood the audit log. Attempts over either
+// cap are still rejected, just not recorded.
+var (
+	authFailureAuditPerIP = registerLimiterConfig{RatePerMinute: 10, Burst: 10}
+	authFailureAuditTotal = registerLimiterConfig{RatePerMinute: 300, Burst: 100}
+)
+
+// authFailureDetailMax caps how much of the rejection message is kept in the
+// audit detail.
+const authFailureDetailMax = 200
+
+// authFailureAuditor records failed authentication attempts in the audit log,
+// rate-limited by client IP and overall.
+type authFailureAuditor struct {
+	store  AuditStore
+	perIP  *registerLimiter
+	global *registerLimiter
+}
+
+func newAuthFailureAuditor(store AuditStore) *authFailureAuditor {
+	return &authFailureAuditor{
+		store:  store,
+		perIP:  newRegisterLimiter(authFailureAuditPerIP),
+		global: newRegisterLimiter(authFailureAuditTotal),
+	}
+}
+
+// record writes an auth_failure entry for r: the resource is the request
+// path, the detail the attempted method and why it was rejected.
+func (a *authFailureAuditor) record(r *http.Request, reason string) {
+	ip := clientIP(r)
+	now := time.Now()
+	if !a.perIP.allow(ip, now).Allowed || !a.global.allow("", now).Allowed {
+		slog.Debug("auth failure audit entry suppressed", "ip", ip, "path", r.URL.Path)
+		return
+	}
+
+	detail := r.Method + ": " + reason
+	if len(detail) > authFailureDetailMax {
+		detail = detail[:authFailureDetailMax]
+	}
+	if err := a.store.Log(r.Context(), "anonymous", AuditActionAuthFailure, r.URL.Path, detail, ip); err != nil {
+		slog.Warn("audit log failed", "error", err)
+	}
+}
+
+// auditAuthFailure records a rejected authentication attempt handled outside
+// the auth middleware (webhook tokens). A no-op when audit logging is off.
+func (s *Server) auditAuthFailure(r *http.Request, reason string) {
+	if s.authFailures != nil {
+		s.authFailures.record(r, reason)
+	}
+}
+
+type authProbeCtxKey struct{}
+
+// authProbe carries the real ResponseWriter past the auth middleware and
+// notes whether the request got through it.
+type authProbe struct {
+	w      http.ResponseWriter
+	passed bool
+}
+
+// wrap returns authMW with rejections recorded: a request the auth
+// middleware answers itself with 401 or 403 is an auth failure. Works for
+// any auth middleware (API keys or the auth plugin) since only the response
+// is inspected. Only the middleware's own response is buffered; handlers
+// downstream get the original ResponseWriter.
+func (a *authFailureAuditor) wrap(authMW func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authed := authMW(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			probe := r.Context().Value(authProbeCtxKey{}).(*authProbe)
+			probe.passed = true
+			next.ServeHTTP(probe.w, r)
+		}))
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			probe := &authProbe{w: w}
+			rec := &authRejectionRecorder{ResponseWriter: w}
+			authed.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), authProbeCtxKey{}, probe)))
+
+			if !probe.passed && (rec.status == http.StatusUnauthorized || rec.status == http.StatusForbidden) {
+				reason := strings.TrimSpace(rec.body.String())
+				if reason == "" {
+					reason = http.StatusText(rec.status)
+				}
+				a.record(r, fmt.Sprintf("%d %s", rec.status, reason))
+			}
+		})
+	}
+}
+
+// authRejectionRecorder passes the auth middleware's response through while
+// keeping its status and the start of its body.
+type authRejectionRecorder struct {
+	http.ResponseWriter
+	status int
+	body   strings.Builder
+}
+
+func (rr *authRejectionRecorder) WriteHeader(code int) {
+	if rr.status == 0 {
+		rr.status = code
+	}
+	rr.ResponseWriter.WriteHeader(code)
+}
+
+func (rr *authRejectionRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.status = http.StatusOK
+	}
+	if room := authFailureDetailMax - rr.body.Len(); room > 0 {
+		rr.body.Write(b[:min(len(b), room)])
+	}
+	return rr.ResponseWriter.Write(b)
+}
@@ -0,0 +1,111 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/auth"
+)
+
+func newAuthFailureTestServer() (*api.Server, *memoryAuditStore) {
+	srv, _, _ := newRunTestServer()
+	store := &memoryAuditStore{}
+	srv.Audit = store
+	srv.Triggers = newMemoryTriggerStore()
+	srv.Auth = auth.APIKeys(map[string]auth.Scope{"good": auth.ScopeReadWrite, "reader": auth.ScopeReadOnly}, nil)
+	return srv, store
+}
+
+func TestAuthFailureAudit_BadAPIKey_RecordsEntry(t *testing.T) {
+	srv, store := newAuthFailureTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+	req.Header.Set("Authorization", "Bearer wrong")
+	req.RemoteAddr = "10.0.0.7:1234"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Len(t, store.entries, 1)
+	entry := store.entries[0]
+	assert.Equal(t, api.AuditActionAuthFailure, entry.Action)
+	assert.Equal(t, "anonymous", entry.UserID)
+	assert.Equal(t, "/api/v1/pipelines/default/bronze/orders", entry.Resource)
+	assert.Equal(t, "10.0.0.7", entry.IP)
+	assert.Contains(t, entry.Detail, "DELETE")
+	assert.Contains(t, entry.Detail, "invalid API key")
+}
+
+func TestAuthFailureAudit_ReadOnlyKeyWrite_RecordsEntry(t *testing.T) {
+	srv, store := newAuthFailureTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", http.NoBody)
+	req.Header.Set("Authorization", "Bearer reader")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	require.Len(t, store.entries, 1)
+	assert.Contains(t, store.entries[0].Detail, "read-only")
+}
+
+func TestAuthFailureAudit_ValidKey_RecordsNoFailure(t *testing.T) {
+	srv, store := newAuthFailureTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer good")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	for _, e := range store.entries {
+		assert.NotEqual(t, api.AuditActionAuthFailure, e.Action)
+	}
+}
+
+func TestAuthFailureAudit_CappedPerIP(t *testing.T) {
+	srv, store := newAuthFailureTestServer()
+	router := api.NewRouter(srv)
+
+	for i := 0; i < 50; i++ {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/runs?probe=%d", i), http.NoBody)
+		req.Header.Set("Authorization", "Bearer wrong")
+		req.RemoteAddr = "10.0.0.8:1234"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+	assert.Len(t, store.entries, 10)
+
+	// Another client still gets recorded.
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer wrong")
+	req.RemoteAddr = "10.0.0.9:1234"
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Len(t, store.entries, 11)
+}
+
+func TestAuthFailureAudit_UnknownWebhookToken_RecordsEntry(t *testing.T) {
+	srv, store := newAuthFailureTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", http.NoBody)
+	req.Header.Set("X-Webhook-Token", "not-a-real-token")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	require.Len(t, store.entries, 1)
+	assert.Equal(t, api.AuditActionAuthFailure, store.entries[0].Action)
+	assert.Equal(t, "/api/v1/webhooks", store.entries[0].Resource)
+	assert.Contains(t, store.entries[0].Detail, "unknown webhook token")
+}
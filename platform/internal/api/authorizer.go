@@ -58,6 +58,25 @@ func (s *Server) filterAccess(ctx context.Context, resourceType, action string,
 	return allowed
 }
 
+// adminRole is the identity role allowed to run platform-wide operations.
+const adminRole = "admin"
+
+// requireAdmin writes 403 unless the caller holds the admin role. Requests
+// without a user (community mode, shared API key) pass, same as requireAccess.
+func (s *Server) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	user := plugins.UserFromContext(r.Context())
+	if user == nil {
+		return true
+	}
+	for _, role := range user.Roles {
+		if role == adminRole {
+			return true
+		}
+	}
+	errorJSON(w, "admin role required", "FORBIDDEN", http.StatusForbidden)
+	return false
+}
+
 // requireAccess checks authorization and writes 403 if denied.
 // Returns true if access is allowed, false if denied (response already written).
 func (s *Server) requireAccess(w http.ResponseWriter, r *http.Request, resourceType, resourceID, action string) bool {
@@ -170,6 +170,29 @@ func TestListPipelines_FilterByAccess(t *testing.T) {
 	assert.Equal(t, float64(1), body["total"])
 }
 
+func TestListPipelineLabels_FilterByAccess(t *testing.T) {
+	srv, store := newTestServer()
+	visible := uuid.New()
+	store.pipelines = []domain.Pipeline{
+		{ID: visible, Namespace: "default", Layer: domain.LayerBronze, Name: "visible", Labels: map[string]string{"team": "analytics"}},
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "hidden", Labels: map[string]string{"team": "secret", "pii": "true"}},
+	}
+	srv.Authorizer = &mockAuthorizer{allowedIDs: map[string]bool{visible.String(): true}}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/labels", http.NoBody)
+	req = req.WithContext(plugins.ContextWithUser(req.Context(), &domain.UserIdentity{UserID: "alice"}))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Labels map[string][]string `json:"labels"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, map[string][]string{"team": {"analytics"}}, body.Labels, "labels of unreadable pipelines must not leak")
+}
+
 func TestListPipelines_NoUser_ReturnsAll(t *testing.T) {
 	srv, store := newTestServer()
 	store.pipelines = []domain.Pipeline{
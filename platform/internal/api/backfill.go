@@ -0,0 +1,313 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// BackfillDateMetadataKey is the run metadata key holding a backfill run's
+// partition date (YYYY-MM-DD). Executors forward it to the runner as the
+// BackfillDateEnvVar environment variable.
+const BackfillDateMetadataKey = "backfill_date"
+
+// BackfillDateEnvVar is the runner environment variable carrying the
+// partition date of a backfill run.
+const BackfillDateEnvVar = "RAT_BACKFILL_DATE"
+
+// Backfill granularities: one run per day, per 7 days from the start date,
+// or per calendar month.
+const (
+	backfillGranularityDay   = "day"
+	backfillGranularityWeek  = "week"
+	backfillGranularityMonth = "month"
+)
+
+const (
+	// maxBackfillRuns bounds how many runs one backfill request may create.
+	maxBackfillRuns = 366
+	// defaultBackfillConcurrency is how many backfill runs are in flight at
+	// once when the request doesn't say.
+	defaultBackfillConcurrency = 2
+	// maxBackfillConcurrency caps the per-backfill in-flight runs so a single
+	// request can't monopolise the runner.
+	maxBackfillConcurrency = 10
+	// defaultBackfillPollInterval is how often queued backfill runs are
+	// re-checked for a free slot (see Server.BackfillPollInterval).
+	defaultBackfillPollInterval = 5 * time.Second
+	// backfillDispatchTimeout bounds the background dispatcher. It matches the
+	// reaper's stuck-pending window: anything still queued by then would be
+	// force-failed anyway.
+	backfillDispatchTimeout = 24 * time.Hour
+)
+
+// BackfillRequest is the JSON body for POST .../backfill.
+type BackfillRequest struct {
+	Start       string `json:"start"`                 // first partition date, YYYY-MM-DD
+	End         string `json:"end"`                   // last partition date (inclusive), YYYY-MM-DD
+	Granularity string `json:"granularity,omitempty"` // day (default), week, or month
+	Concurrency int    `json:"concurrency,omitempty"` // max runs in flight (default 2, max 10)
+}
+
+// MountBackfillRoutes registers the pipeline backfill endpoint.
+func MountBackfillRoutes(r chi.Router, srv *Server) {
+	r.Post("/pipelines/{namespace}/{layer}/{name}/backfill", srv.HandleBackfillPipeline)
+}
+
+// HandleBackfillPipeline re-runs a pipeline once per interval of a date range.
+// All runs are created up front (pending, trigger "backfill:<date>") and their
+// IDs returned; at most Concurrency of them are submitted at a time, the rest
+// are fed to the executor in the background as earlier ones finish.
+// POST /api/v1/pipelines/{namespace}/{layer}/{name}/backfill
+func (s *Server) HandleBackfillPipeline(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	var req BackfillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if req.Granularity == "" {
+		req.Granularity = backfillGranularityDay
+	}
+	if req.Concurrency == 0 {
+		req.Concurrency = defaultBackfillConcurrency
+	}
+	if req.Concurrency < 1 || req.Concurrency > maxBackfillConcurrency {
+		errorJSON(w, fmt.Sprintf("concurrency must be between 1 and %d", maxBackfillConcurrency), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	start, err := time.Parse(time.DateOnly, req.Start)
+	if err != nil {
+		errorJSON(w, "start must be a YYYY-MM-DD date", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	end, err := time.Parse(time.DateOnly, req.End)
+	if err != nil {
+		errorJSON(w, "end must be a YYYY-MM-DD date", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if end.Before(start) {
+		errorJSON(w, "end must not be before start", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	dates, msg := backfillIntervals(start, end, req.Granularity)
+	if msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	// Backfilling = triggering runs = write access on the pipeline.
+	if !s.requireAccess(w, r, "pipeline", pipeline.ID.String(), "write") {
+		return
+	}
+
+	runs := make([]*domain.Run, len(dates))
+	for i, date := range dates {
+		label := date.Format(time.DateOnly)
+		runs[i] = &domain.Run{
+			PipelineID: pipeline.ID,
+			Status:     domain.RunStatusPending,
+			Trigger:    "backfill:" + label,
+			Metadata:   map[string]string{BackfillDateMetadataKey: label},
+		}
+	}
+
+	// All-or-nothing: a half-created backfill would leave gaps the caller
+	// can't see from the error response.
+	createAll := func(t TxStores) error {
+		for _, run := range runs {
+			if err := t.Runs.CreateRun(r.Context(), run); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := s.runFireTx(r.Context(), createAll); err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	// Submission runs outside the request: the dispatcher outlives it.
+	if s.Executor != nil {
+		d := &backfillDispatcher{
+			srv:         s,
+			pipeline:    pipeline,
+			queue:       runs,
+			concurrency: req.Concurrency,
+		}
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), backfillDispatchTimeout)
+		if d.dispatch(ctx) {
+			cancel()
+		} else {
+			go d.run(ctx, cancel)
+		}
+	}
+
+	runIDs := make([]string, len(runs))
+	for i, run := range runs {
+		runIDs[i] = run.ID.String()
+	}
+
+	slog.Info("backfill created", "pipeline", namespace+"/"+layer+"/"+name,
+		"start", req.Start, "end", req.End, "granularity", req.Granularity, "runs", len(runs))
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"run_ids":     runIDs,
+		"total":       len(runIDs),
+		"concurrency": req.Concurrency,
+	})
+}
+
+// backfillIntervals returns the start date of every interval from start to
+// end inclusive. Returns a client-facing error message for an unknown
+// granularity or a range producing more than maxBackfillRuns runs.
+func backfillIntervals(start, end time.Time, granularity string) ([]time.Time, string) {
+	var step func(time.Time) time.Time
+	switch granularity {
+	case backfillGranularityDay:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case backfillGranularityWeek:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	case backfillGranularityMonth:
+		// Step from the original start so e.g. Jan 31 → Feb 28/29 → Mar 31
+		// instead of drifting to the 28th/29th for good.
+		n := 0
+		step = func(time.Time) time.Time {
+			n++
+			return addMonthsClamped(start, n)
+		}
+	default:
+		return nil, "granularity must be day, week, or month"
+	}
+
+	var dates []time.Time
+	for d := start; !d.After(end); d = step(d) {
+		if len(dates) == maxBackfillRuns {
+			return nil, fmt.Sprintf("backfill range too large (max %d runs)", maxBackfillRuns)
+		}
+		dates = append(dates, d)
+	}
+	return dates, ""
+}
+
+// addMonthsClamped adds n calendar months to t, clamping the day to the last
+// day of the target month (time.AddDate would normalise Jan 31 + 1 month to
+// Mar 3).
+func addMonthsClamped(t time.Time, n int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// backfillDispatcher submits a backfill's runs while keeping at most
+// concurrency of them in flight.
+//
+// The queue lives in memory: if ratd restarts mid-backfill, runs that were
+// never submitted stay pending until the reaper's stuck-pending sweep fails
+// them. Cancelling a queued run (POST /runs/{id}/cancel) drops it from the
+// queue.
+type backfillDispatcher struct {
+	srv         *Server
+	pipeline    *domain.Pipeline
+	queue       []*domain.Run // not yet submitted, in date order
+	inFlight    []string      // submitted run IDs not yet seen terminal
+	concurrency int
+}
+
+// run polls until every queued run has been submitted (or ctx expires).
+func (d *backfillDispatcher) run(ctx context.Context, cancel context.CancelFunc) {
+	defer cancel()
+	interval := d.srv.BackfillPollInterval
+	if interval <= 0 {
+		interval = defaultBackfillPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Warn("backfill dispatcher timed out with runs still queued",
+				"pipeline_id", d.pipeline.ID, "queued", len(d.queue))
+			return
+		case <-ticker.C:
+			if d.dispatch(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// dispatch frees slots held by finished runs and submits queued runs into
+// them. Returns true once the queue is empty. A busy or unavailable runner
+// leaves the run at the head of the queue to be retried on the next tick; a
+// rejected pipeline (ErrPipelineInvalid) is dropped, as is a run dead-lettered
+// after MaxSubmitAttempts non-busy failures.
+func (d *backfillDispatcher) dispatch(ctx context.Context) bool {
+	active := d.inFlight[:0]
+	for _, id := range d.inFlight {
+		run, err := d.srv.Runs.GetRun(ctx, id)
+		if err != nil {
+			// Can't tell — keep holding the slot rather than over-submit.
+			active = append(active, id)
+			continue
+		}
+		if run != nil && !isTerminalStatus(run.Status) {
+			active = append(active, id)
+		}
+	}
+	d.inFlight = active
+
+	for len(d.queue) > 0 && len(d.inFlight) < d.concurrency {
+		next := d.queue[0]
+		current, err := d.srv.Runs.GetRun(ctx, next.ID.String())
+		if err != nil {
+			return false
+		}
+		if current == nil || current.Status != domain.RunStatusPending {
+			// Cancelled (or otherwise handled) while queued — skip it.
+			d.queue = d.queue[1:]
+			continue
+		}
+		if err := d.srv.Executor.Submit(ctx, next, d.pipeline); err != nil {
+			if errors.Is(err, ErrPipelineInvalid) {
+				// Failed by the executor; retrying can't help.
+				slog.Error("backfill run rejected by runner", "run_id", next.ID, "error", err)
+				d.queue = d.queue[1:]
+				continue
+			}
+			if !errors.Is(err, ErrRunnerBusy) && DeadLetterAfterSubmit(ctx, d.srv.Runs, next, err) {
+				d.queue = d.queue[1:]
+				continue
+			}
+			slog.Warn("backfill submit failed, will retry", "run_id", next.ID, "error", err)
+			return false
+		}
+		d.inFlight = append(d.inFlight, next.ID.String())
+		d.queue = d.queue[1:]
+	}
+	return len(d.queue) == 0
+}
@@ -0,0 +1,240 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBackfillTestServer() (*api.Server, *memoryRunStore, *mockExecutor) {
+	srv, pipelineStore, runStore := newRunTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+	srv.BackfillPollInterval = 5 * time.Millisecond
+	return srv, runStore, exec
+}
+
+type backfillResponse struct {
+	RunIDs      []string `json:"run_ids"`
+	Total       int      `json:"total"`
+	Concurrency int      `json:"concurrency"`
+}
+
+func postBackfill(t *testing.T, srv *api.Server, body string) (*httptest.ResponseRecorder, backfillResponse) {
+	t.Helper()
+	router := api.NewRouter(srv)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/backfill", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp backfillResponse
+	if rec.Code == http.StatusAccepted {
+		require.NoError(t, json.NewDecoder(bytes.NewReader(rec.Body.Bytes())).Decode(&resp))
+	}
+	return rec, resp
+}
+
+// backfillTriggers returns the trigger labels of the stored runs, in creation order.
+func backfillTriggers(store *memoryRunStore) []string {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	out := make([]string, len(store.runs))
+	for i, r := range store.runs {
+		out[i] = r.Trigger
+	}
+	return out
+}
+
+func TestBackfill_Daily_CreatesOneRunPerDay(t *testing.T) {
+	srv, runStore, _ := newBackfillTestServer()
+
+	rec, resp := postBackfill(t, srv, `{"start":"2026-01-30","end":"2026-02-02","concurrency":10}`)
+
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	assert.Equal(t, 4, resp.Total)
+	assert.Len(t, resp.RunIDs, 4)
+	assert.Equal(t, []string{
+		"backfill:2026-01-30", "backfill:2026-01-31", "backfill:2026-02-01", "backfill:2026-02-02",
+	}, backfillTriggers(runStore))
+
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Equal(t, "2026-01-30", runStore.runs[0].Metadata["backfill_date"])
+	assert.Equal(t, resp.RunIDs[0], runStore.runs[0].ID.String())
+}
+
+func TestBackfill_Weekly_StepsSevenDays(t *testing.T) {
+	srv, runStore, _ := newBackfillTestServer()
+
+	rec, _ := postBackfill(t, srv, `{"start":"2026-03-02","end":"2026-03-20","granularity":"week"}`)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, []string{"backfill:2026-03-02", "backfill:2026-03-09", "backfill:2026-03-16"}, backfillTriggers(runStore))
+}
+
+func TestBackfill_Monthly_ClampsToMonthEnd(t *testing.T) {
+	srv, runStore, _ := newBackfillTestServer()
+
+	rec, _ := postBackfill(t, srv, `{"start":"2026-01-31","end":"2026-04-30","granularity":"month"}`)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, []string{
+		"backfill:2026-01-31", "backfill:2026-02-28", "backfill:2026-03-31", "backfill:2026-04-30",
+	}, backfillTriggers(runStore))
+}
+
+func TestBackfill_SingleDay(t *testing.T) {
+	srv, _, _ := newBackfillTestServer()
+
+	rec, resp := postBackfill(t, srv, `{"start":"2026-05-01","end":"2026-05-01"}`)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 1, resp.Total)
+}
+
+func TestBackfill_ConcurrencyCap_SubmitsAsSlotsFree(t *testing.T) {
+	srv, runStore, exec := newBackfillTestServer()
+
+	rec, resp := postBackfill(t, srv, `{"start":"2026-01-01","end":"2026-01-05","concurrency":2}`)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 5, resp.Total)
+	assert.Equal(t, 2, resp.Concurrency)
+	// Only the first two are submitted straight away.
+	assert.Equal(t, 2, exec.submitCount())
+	// Give the dispatcher a few ticks: no slot is free, nothing more goes out.
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 2, exec.submitCount())
+
+	// One run finishes → exactly one more is submitted.
+	setRunStatus(runStore, resp.RunIDs[0], domain.RunStatusSuccess)
+	require.Eventually(t, func() bool { return exec.submitCount() == 3 }, time.Second, 5*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 3, exec.submitCount())
+
+	// A queued run cancelled before its turn is skipped, not submitted.
+	setRunStatus(runStore, resp.RunIDs[3], domain.RunStatusCancelled)
+	setRunStatus(runStore, resp.RunIDs[1], domain.RunStatusFailed)
+	setRunStatus(runStore, resp.RunIDs[2], domain.RunStatusSuccess)
+	require.Eventually(t, func() bool { return exec.submitCount() == 4 }, time.Second, 5*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+
+	exec.mu.Lock()
+	defer exec.mu.Unlock()
+	submitted := make([]string, len(exec.calls))
+	for i, c := range exec.calls {
+		submitted[i] = c.RunID.String()
+	}
+	assert.Equal(t, []string{resp.RunIDs[0], resp.RunIDs[1], resp.RunIDs[2], resp.RunIDs[4]}, submitted)
+}
+
+func TestBackfill_ConcurrencyCoversRange_SubmitsAllAtOnce(t *testing.T) {
+	srv, _, exec := newBackfillTestServer()
+
+	rec, _ := postBackfill(t, srv, `{"start":"2026-01-01","end":"2026-01-03","concurrency":5}`)
+
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 3, exec.submitCount())
+}
+
+func TestBackfill_InvalidRequests_Return400(t *testing.T) {
+	cases := map[string]string{
+		"bad start":          `{"start":"01/01/2026","end":"2026-01-02"}`,
+		"bad end":            `{"start":"2026-01-01","end":"tomorrow"}`,
+		"end before start":   `{"start":"2026-01-02","end":"2026-01-01"}`,
+		"unknown unit":       `{"start":"2026-01-01","end":"2026-01-02","granularity":"hour"}`,
+		"concurrency too hi": `{"start":"2026-01-01","end":"2026-01-02","concurrency":11}`,
+		"negative conc.":     `{"start":"2026-01-01","end":"2026-01-02","concurrency":-1}`,
+		"too many runs":      `{"start":"2020-01-01","end":"2026-01-01"}`,
+		"not json":           `nope`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, runStore, _ := newBackfillTestServer()
+			rec, _ := postBackfill(t, srv, body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Empty(t, backfillTriggers(runStore))
+		})
+	}
+}
+
+func TestBackfill_PipelineNotFound_Returns404(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/ghost/backfill",
+		bytes.NewBufferString(`{"start":"2026-01-01","end":"2026-01-02"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func setRunStatus(store *memoryRunStore, runID string, status domain.RunStatus) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for i := range store.runs {
+		if store.runs[i].ID.String() == runID {
+			store.runs[i].Status = status
+		}
+	}
+}
+
+func TestBackfill_RunnerUnavailable_DeadLettersAfterMaxAttempts(t *testing.T) {
+	srv, runStore, exec := newBackfillTestServer()
+	exec.failErr = fmt.Errorf("submit pipeline: %w", api.ErrRunnerUnavailable)
+
+	rec, resp := postBackfill(t, srv, `{"start":"2026-01-01","end":"2026-01-01"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	require.Eventually(t, func() bool {
+		run, _ := runStore.GetRun(context.Background(), resp.RunIDs[0])
+		return run.Status == domain.RunStatusFailed
+	}, time.Second, 5*time.Millisecond)
+	// The dispatcher gives up on the run: no submissions past the cap.
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, api.MaxSubmitAttempts, exec.submitCount())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/dead-lettered", http.NoBody)
+	rec = httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Runs  []domain.Run `json:"runs"`
+		Total int          `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Equal(t, 1, body.Total)
+	assert.Equal(t, resp.RunIDs[0], body.Runs[0].ID.String())
+	require.NotNil(t, body.Runs[0].Error)
+	assert.Contains(t, *body.Runs[0].Error, "dead-lettered after 10 failed submit attempts")
+}
+
+func TestBackfill_RunnerBusy_NeverDeadLetters(t *testing.T) {
+	srv, runStore, exec := newBackfillTestServer()
+	exec.failErr = fmt.Errorf("submit pipeline: %w", api.ErrRunnerBusy)
+
+	rec, resp := postBackfill(t, srv, `{"start":"2026-01-01","end":"2026-01-01"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	require.Eventually(t, func() bool { return exec.submitCount() > api.MaxSubmitAttempts }, time.Second, 5*time.Millisecond)
+	run, err := runStore.GetRun(context.Background(), resp.RunIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, domain.RunStatusPending, run.Status, "capacity backpressure doesn't count towards the cap")
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// DefaultBodyLogMaxBytes caps how much of each body BodyLogger records.
+const DefaultBodyLogMaxBytes = 4096
+
+// redactedValue replaces sensitive values in logged bodies.
+const redactedValue = "[REDACTED]"
+
+// BodyLogConfig configures sampled request/response body logging.
+type BodyLogConfig struct {
+	SampleRate float64 // Fraction of requests logged, 0..1.
+	MaxBytes   int     // Per-body cap; bodies beyond it are truncated. Zero = DefaultBodyLogMaxBytes.
+}
+
+// sensitiveKeyParts marks a JSON field as sensitive when its lowercased name
+// contains any of them ("webhook_token", "signing_secret", "api_key", ...).
+var sensitiveKeyParts = []string{"token", "secret", "password", "passwd", "api_key", "apikey", "authorization", "credential", "private_key", "access_key"}
+
+// sensitiveFieldRE finds `"key": "value"` pairs in bodies that aren't valid
+// JSON (truncated or malformed), where redactJSON can't be used.
+var sensitiveFieldRE = regexp.MustCompile(`(?i)("[^"]*(?:token|secret|password|passwd|api_?key|authorization|credential|private_key|access_key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+
+// BodyLogger returns a middleware that logs the method, path, status and
+// redacted request and response bodies of a sampled fraction of requests.
+// It is for debugging bad requests and is off unless configured.
+//
+// Bodies are capped at cfg.MaxBytes and only logged for JSON and text
+// content; uploads and Arrow results are logged as their size only. Values of
+// fields that look secret (tokens, passwords, API keys, credentials) are
+// replaced with "[REDACTED]". Headers are never logged. Records go through
+// slog with the request context, so ContextHandler attaches request_id.
+func BodyLogger(cfg BodyLogConfig) func(http.Handler) http.Handler {
+	maxBytes := cfg.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultBodyLogMaxBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cfg.SampleRate <= 0 || healthPaths[r.URL.Path] || rand.Float64() >= cfg.SampleRate {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reqType := r.Header.Get("Content-Type")
+			if r.Header.Get("Content-Encoding") != "" {
+				reqType = "compressed" // still gzipped here; decompressGzipBody runs later
+			}
+			var reqBody []byte
+			if r.Body != nil && r.Body != http.NoBody && loggableContentType(reqType) {
+				// Read only the capped prefix, then replay it ahead of the rest
+				// so the handler still sees the whole body.
+				reqBody, _ = io.ReadAll(io.LimitReader(r.Body, int64(maxBytes)+1))
+				r.Body = readCloser{io.MultiReader(bytes.NewReader(reqBody), r.Body), r.Body}
+			}
+
+			rec := &bodyRecorder{responseWriter: responseWriter{ResponseWriter: w, status: http.StatusOK}, max: maxBytes}
+			next.ServeHTTP(rec, r)
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rec.status),
+				slog.String("request_body", formatLoggedBody(reqBody, reqType, r.ContentLength, maxBytes)),
+				slog.String("response_body", formatLoggedBody(rec.buf.Bytes(), rec.Header().Get("Content-Type"), int64(rec.bytesWritten), maxBytes)),
+			}
+			slog.LogAttrs(r.Context(), slog.LevelInfo, "request body sample", attrs...)
+		})
+	}
+}
+
+// readCloser pairs a replaying reader with the original body's Close.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyRecorder keeps the first max bytes of the response body.
+type bodyRecorder struct {
+	responseWriter
+	buf bytes.Buffer
+	max int
+}
+
+// Write records up to max+1 bytes (the extra byte marks truncation) and
+// forwards everything.
+func (b *bodyRecorder) Write(p []byte) (int, error) {
+	if room := b.max + 1 - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(room, len(p))])
+	}
+	return b.responseWriter.Write(p)
+}
+
+// Flush forwards to the underlying writer so SSE still streams when sampled.
+func (b *bodyRecorder) Flush() {
+	if f, ok := b.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// loggableContentType reports whether a body of this type is worth logging
+// as text. An empty type counts: many clients omit it on JSON bodies.
+func loggableContentType(ct string) bool {
+	ct = strings.ToLower(ct)
+	return ct == "" || strings.Contains(ct, "json") || strings.HasPrefix(ct, "text/")
+}
+
+// formatLoggedBody renders a captured body for the log: redacted and capped,
+// or a size placeholder for binary content.
+func formatLoggedBody(body []byte, contentType string, size int64, maxBytes int) string {
+	if !loggableContentType(contentType) {
+		if size > 0 {
+			return "<" + contentType + " body omitted>"
+		}
+		return ""
+	}
+	if len(body) == 0 {
+		return ""
+	}
+	truncated := len(body) > maxBytes
+	if truncated {
+		body = body[:maxBytes]
+	}
+	out := redactBody(body)
+	if truncated {
+		out += "…(truncated)"
+	}
+	return out
+}
+
+// redactBody masks sensitive values. Complete JSON is walked so nested
+// objects under a sensitive key are masked whole; anything else falls back
+// to a pattern match on "key": "value" pairs.
+func redactBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if out, err := json.Marshal(redactJSON(v)); err == nil {
+			return string(out)
+		}
+	}
+	return sensitiveFieldRE.ReplaceAllString(string(body), `${1}"`+redactedValue+`"`)
+}
+
+// redactJSON returns v with the values of sensitive keys replaced.
+func redactJSON(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if isSensitiveKey(k) {
+				t[k] = redactedValue
+			} else {
+				t[k] = redactJSON(val)
+			}
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = redactJSON(val)
+		}
+	}
+	return v
+}
+
+// isSensitiveKey reports whether a JSON field name looks like it holds a secret.
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(k, part) {
+			return true
+		}
+	}
+	return false
+}
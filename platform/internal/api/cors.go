@@ -0,0 +1,159 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/cors"
+)
+
+// ParseRouteCORSOrigins parses a CORS_ROUTE_ORIGINS value: comma-separated
+// "pattern=origin1|origin2" entries, e.g.
+// "/api/v1/query=https://embed.example.com|https://app.example.com".
+// Patterns use chi syntax ("{name}" matches one segment, a trailing "/*"
+// matches the rest of the path).
+func ParseRouteCORSOrigins(spec string) (map[string][]string, error) {
+	routes := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		pattern, list, ok := strings.Cut(entry, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || !strings.HasPrefix(pattern, "/") {
+			return nil, errors.New("cors route entry must be /route/pattern=origin1|origin2")
+		}
+		if _, dup := routes[pattern]; dup {
+			return nil, fmt.Errorf("cors route %q listed more than once", pattern)
+		}
+		var origins []string
+		for _, o := range strings.Split(list, "|") {
+			if o = strings.TrimSpace(o); o != "" {
+				origins = append(origins, o)
+			}
+		}
+		if len(origins) == 0 {
+			return nil, fmt.Errorf("cors route %q lists no origins", pattern)
+		}
+		routes[pattern] = origins
+	}
+	return routes, nil
+}
+
+// corsHandler returns the CORS middleware for one origin list.
+func corsHandler(origins []string) func(http.Handler) http.Handler {
+	// P10-20: When AllowCredentials is true, Access-Control-Allow-Origin MUST NOT
+	// be "*". If the caller configured "*", use AllowOriginFunc to dynamically
+	// reflect the request Origin header (only when it matches a known allowed
+	// origin pattern). This satisfies the CORS spec while keeping credentials.
+	hasWildcard := false
+	for _, o := range origins {
+		if o == "*" {
+			hasWildcard = true
+			break
+		}
+	}
+
+	corsOpts := cors.Options{
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Webhook-Token", "Idempotency-Key", "X-Request-ID"},
+		ExposedHeaders:   []string{"Link", "X-Request-ID", "RateLimit-Limit", "RateLimit-Remaining", "Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           300,
+	}
+
+	if hasWildcard {
+		// Dynamic origin: reflect the request Origin when credentials are enabled.
+		// This avoids the browser-rejected "Access-Control-Allow-Origin: *" +
+		// "Access-Control-Allow-Credentials: true" combination.
+		slog.Warn("CORS: wildcard origin '*' with AllowCredentials — using dynamic origin reflection")
+		corsOpts.AllowOriginFunc = func(_ *http.Request, _ string) bool {
+			return true
+		}
+	} else {
+		corsOpts.AllowedOrigins = origins
+	}
+
+	return cors.Handler(corsOpts)
+}
+
+// routeCORS applies the CORS policy for the request path: the origins of the
+// matching entry in routes, otherwise the global origins. A route entry
+// replaces the global list for that route rather than extending it, so an
+// embed domain can be allowed on query endpoints without gaining the admin
+// ones. When several patterns match, the longest wins.
+//
+// It matches the raw URL path, not the chi route pattern: CORS runs before
+// routing, and preflight OPTIONS requests never match a route.
+func routeCORS(global []string, routes map[string][]string) func(http.Handler) http.Handler {
+	globalMW := corsHandler(global)
+	if len(routes) == 0 {
+		return globalMW
+	}
+
+	type routePolicy struct {
+		segments []string
+		mw       func(http.Handler) http.Handler
+	}
+	patterns := make([]string, 0, len(routes))
+	for p := range routes {
+		patterns = append(patterns, p)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	policies := make([]routePolicy, 0, len(patterns))
+	for _, p := range patterns {
+		policies = append(policies, routePolicy{
+			segments: strings.Split(strings.Trim(p, "/"), "/"),
+			mw:       corsHandler(routes[p]),
+		})
+	}
+
+	return func(next http.Handler) http.Handler {
+		globalHandler := globalMW(next)
+		handlers := make([]http.Handler, len(policies))
+		for i, p := range policies {
+			handlers[i] = p.mw(next)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+			for i, p := range policies {
+				if matchRouteSegments(p.segments, path) {
+					handlers[i].ServeHTTP(w, r)
+					return
+				}
+			}
+			globalHandler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchRouteSegments reports whether path matches a chi-style pattern split
+// into segments: "{param}" matches any one segment, a final "*" matches the
+// remaining segments, anything else must match literally.
+func matchRouteSegments(pattern, path []string) bool {
+	for i, seg := range pattern {
+		if seg == "*" && i == len(pattern)-1 {
+			return len(path) >= i
+		}
+		if i >= len(path) {
+			return false
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			continue
+		}
+		if seg != path[i] {
+			return false
+		}
+	}
+	return len(path) == len(pattern)
+}
@@ -0,0 +1,85 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func corsPreflight(router http.Handler, path, origin string) string {
+	req := httptest.NewRequest(http.MethodOptions, path, http.NoBody)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Header().Get("Access-Control-Allow-Origin")
+}
+
+func TestCORS_RouteOrigins_ReflectionDiffersByRoute(t *testing.T) {
+	srv := fullTestServer()
+	srv.CORSOrigins = []string{"https://admin.example.com"}
+	srv.RouteCORSOrigins = map[string][]string{
+		"/api/v1/query": {"https://embed.example.com"},
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/preview": {"https://embed.example.com", "https://admin.example.com"},
+	}
+	router := api.NewRouter(srv)
+
+	// Embed domain: allowed on query and preview, not on admin endpoints.
+	assert.Equal(t, "https://embed.example.com", corsPreflight(router, "/api/v1/query", "https://embed.example.com"))
+	assert.Equal(t, "https://embed.example.com", corsPreflight(router, "/api/v1/pipelines/default/silver/orders/preview", "https://embed.example.com"))
+	assert.Empty(t, corsPreflight(router, "/api/v1/settings/maintenance", "https://embed.example.com"))
+
+	// Admin domain: global fallback everywhere except where a route replaces it.
+	assert.Equal(t, "https://admin.example.com", corsPreflight(router, "/api/v1/settings/maintenance", "https://admin.example.com"))
+	assert.Equal(t, "https://admin.example.com", corsPreflight(router, "/api/v1/pipelines/default/silver/orders/preview", "https://admin.example.com"))
+	assert.Empty(t, corsPreflight(router, "/api/v1/query", "https://admin.example.com"))
+}
+
+func TestCORS_RouteOrigins_TrailingWildcardMatchesSubtree(t *testing.T) {
+	srv := fullTestServer()
+	srv.RouteCORSOrigins = map[string][]string{
+		"/api/v1/x/{plugin}/*": {"https://plugin.example.com"},
+	}
+	router := api.NewRouter(srv)
+
+	assert.Equal(t, "https://plugin.example.com", corsPreflight(router, "/api/v1/x/lineage/graph", "https://plugin.example.com"))
+	assert.Empty(t, corsPreflight(router, "/api/v1/pipelines", "https://plugin.example.com"))
+	assert.Equal(t, "http://localhost:3000", corsPreflight(router, "/api/v1/pipelines", "http://localhost:3000"))
+}
+
+func TestParseRouteCORSOrigins_ParsesEntries(t *testing.T) {
+	routes, err := api.ParseRouteCORSOrigins("/api/v1/query=https://a.example.com|https://b.example.com, /api/v1/x/{plugin}/*=https://c.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"/api/v1/query":        {"https://a.example.com", "https://b.example.com"},
+		"/api/v1/x/{plugin}/*": {"https://c.example.com"},
+	}, routes)
+}
+
+func TestParseRouteCORSOrigins_RejectsInvalidEntries(t *testing.T) {
+	for _, spec := range []string{
+		"api/v1/query=https://a.example.com",
+		"/api/v1/query",
+		"/api/v1/query=",
+		"/api/v1/query=https://a.example.com,/api/v1/query=https://b.example.com",
+	} {
+		_, err := api.ParseRouteCORSOrigins(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCORS_Preflight_CarriesRequestID(t *testing.T) {
+	router := api.NewRouter(fullTestServer())
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/features", http.NoBody)
+	req.Header.Set("Origin", "http://localhost:3000")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.NotEmpty(t, rec.Header().Get("X-Request-ID"))
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// Conditional GETs for endpoints the portal polls. Responses carry a weak
+// ETag; a request whose If-None-Match matches gets an empty 304 instead of
+// the JSON body. ETags are weak because they identify the resource state,
+// not the exact bytes (e.g. a pipeline's ETag survives JSON field reordering).
+
+// pipelineETag derives a pipeline's ETag from its ID and updated_at, which
+// every pipeline write bumps.
+func pipelineETag(p *domain.Pipeline) string {
+	return `W/"` + p.ID.String() + "-" + strconv.FormatInt(p.UpdatedAt.UnixNano(), 36) + `"`
+}
+
+// bodyETag derives an ETag from a response body, for lists whose freshness
+// can't be read off a single timestamp.
+func bodyETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether If-None-Match matches etag, using the weak
+// comparison RFC 9110 prescribes for If-None-Match.
+func etagMatches(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified sets the ETag and reports whether the client's copy is current,
+// in which case it has already written the 304. Cache-Control makes browsers
+// revalidate on every use instead of serving a stale copy, and keeps shared
+// caches out since responses are filtered per user.
+func notModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if !etagMatches(r, etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// writeJSONWithETag is writeJSON for 200 responses to conditional GETs: it
+// tags the encoded body with bodyETag and answers 304 when it matches.
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		internalError(w, "failed to encode response", err)
+		return
+	}
+	if notModified(w, r, bodyETag(body)) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		slog.Error("failed to write JSON response", "error", err)
+	}
+}
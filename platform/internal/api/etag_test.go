@@ -0,0 +1,94 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func conditionalGet(router http.Handler, path, etag string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetPipeline_ETag_NotModifiedUntilUpdated(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{{
+		ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders",
+		UpdatedAt: time.Now().Add(-time.Hour),
+	}}
+	router := api.NewRouter(srv)
+	const path = "/api/v1/pipelines/default/bronze/orders"
+
+	rec := conditionalGet(router, path, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Contains(t, etag, `W/"`)
+
+	rec = conditionalGet(router, path, etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+	req := httptest.NewRequest(http.MethodPut, path, bytes.NewBufferString(`{"description":"changed"}`))
+	upd := httptest.NewRecorder()
+	router.ServeHTTP(upd, req)
+	require.Equal(t, http.StatusOK, upd.Code, upd.Body.String())
+
+	rec = conditionalGet(router, path, etag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "changed")
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestListPipelines_ETag_NotModifiedUntilListChanges(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+	const path = "/api/v1/pipelines"
+
+	rec := conditionalGet(router, path, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	rec = conditionalGet(router, path, etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	store.pipelines = append(store.pipelines,
+		domain.Pipeline{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "customers"})
+
+	rec = conditionalGet(router, path, etag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+}
+
+func TestListPipelines_ETag_MatchesAnyListedTag(t *testing.T) {
+	srv, _ := newTestServer()
+	router := api.NewRouter(srv)
+
+	etag := conditionalGet(router, "/api/v1/pipelines", "").Header().Get("ETag")
+
+	rec := conditionalGet(router, "/api/v1/pipelines", `W/"stale", `+etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = conditionalGet(router, "/api/v1/pipelines", `W/"stale"`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
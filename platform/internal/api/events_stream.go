@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rat-data/rat/platform/internal/plugins"
+)
+
+// eventStreamChannels are the event bus channels GET /events/stream can tail.
+// Mirrors the channels PgEventBus listens on (postgres.allChannels).
+var eventStreamChannels = []string{
+	"run_completed",
+	"pipeline_created",
+	"pipeline_updated",
+	"pipeline_published",
+	"pipeline_deleted",
+	"file_uploaded",
+	"quality_failed",
+	"schedule_fired",
+	"trigger_fired",
+}
+
+// eventStreamBuffer is how many events may queue between the bus and a slow
+// client before the stream blocks its subscriptions.
+const eventStreamBuffer = 64
+
+// MountEventStreamRoutes registers the event bus tail endpoint.
+func MountEventStreamRoutes(r chi.Router, srv *Server) {
+	r.Get("/events/stream", srv.HandleStreamEvents)
+}
+
+// HandleStreamEvents tails the event bus as SSE so operators can watch the
+// NOTIFY traffic behind schedules, triggers and run completion live. Each
+// bus event is sent with the channel as the SSE event name and its payload
+// as data. ?channels=a,b limits the stream; empty means every channel.
+// Admin only, counted against the SSE limiter.
+// GET /api/v1/events/stream
+func (s *Server) HandleStreamEvents(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if s.EventStream == nil {
+		errorJSON(w, "event bus not available", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	channels := eventStreamChannels
+	if raw := r.URL.Query().Get("channels"); raw != "" {
+		channels = nil
+		for _, ch := range strings.Split(raw, ",") {
+			ch = strings.TrimSpace(ch)
+			if !slices.Contains(eventStreamChannels, ch) {
+				errorJSON(w, fmt.Sprintf("unknown channel %q: must be one of %s", ch, strings.Join(eventStreamChannels, ", ")), "INVALID_ARGUMENT", http.StatusBadRequest)
+				return
+			}
+			if !slices.Contains(channels, ch) {
+				channels = append(channels, ch)
+			}
+		}
+	}
+
+	release, ok := s.acquireSSE(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(MaxSSEDurationSeconds)*time.Second)
+	defer cancel()
+
+	events := make(chan plugins.DispatchEvent, eventStreamBuffer)
+	for _, ch := range channels {
+		sub, unsubscribe := s.EventStream.Subscribe(ch)
+		defer unsubscribe()
+		go func() {
+			// Once the stream ends, keep draining until unsubscribe closes
+			// sub so the bus side never blocks on this subscriber.
+			defer func() {
+				for range sub {
+				}
+			}()
+			for {
+				select {
+				case ev, ok := <-sub:
+					if !ok {
+						return
+					}
+					select {
+					case events <- ev:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	flusher, canFlush := w.(http.Flusher)
+	sendEvent := func(event string, data []byte) error {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
+		}
+		if canFlush {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	subscribed, _ := json.Marshal(map[string][]string{"channels": channels})
+	if err := sendEvent("subscribed", subscribed); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				data, _ := json.Marshal(map[string]string{
+					"code":    "TIMEOUT",
+					"message": "SSE connection closed: maximum duration exceeded",
+				})
+				_ = sendEvent("error", data)
+			}
+			return
+		case ev := <-events:
+			// SSE data can't span lines unescaped; NOTIFY payloads are JSON
+			// but not necessarily compact.
+			var data bytes.Buffer
+			if err := json.Compact(&data, ev.Payload); err != nil {
+				data.Reset()
+				quoted, _ := json.Marshal(string(ev.Payload))
+				data.Write(quoted)
+			}
+			if err := sendEvent(ev.Channel, data.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package api_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+)
+
+// memoryEventBus is an in-process plugins.DispatchEventBus with Publish.
+type memoryEventBus struct {
+	mu   sync.Mutex
+	subs map[string][]chan plugins.DispatchEvent
+}
+
+func newMemoryEventBus() *memoryEventBus {
+	return &memoryEventBus{subs: map[string][]chan plugins.DispatchEvent{}}
+}
+
+func (b *memoryEventBus) Subscribe(channel string) (<-chan plugins.DispatchEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan plugins.DispatchEvent, 16)
+	b.subs[channel] = append(b.subs[channel], ch)
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, c := range b.subs[channel] {
+			if c == ch {
+				b.subs[channel] = append(b.subs[channel][:i], b.subs[channel][i+1:]...)
+				close(ch)
+				return
+			}
+		}
+	}
+}
+
+func (b *memoryEventBus) Publish(channel string, payload string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.subs[channel] {
+		ch <- plugins.DispatchEvent{Channel: channel, Payload: json.RawMessage(payload)}
+	}
+}
+
+// sseEvent is one parsed server-sent event.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// nextSSEEvent reads lines from sc until a complete event has been read.
+func nextSSEEvent(t *testing.T, sc *bufio.Scanner) sseEvent {
+	t.Helper()
+	var ev sseEvent
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			return ev
+		case strings.HasPrefix(line, "event: "):
+			ev.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			ev.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	require.NoError(t, sc.Err())
+	t.Fatal("stream ended before a complete event")
+	return ev
+}
+
+func TestStreamEvents_PublishedEventReachesSubscribedStream(t *testing.T) {
+	srv, _ := newTestServer()
+	bus := newMemoryEventBus()
+	srv.EventStream = bus
+	ts := httptest.NewServer(api.NewRouter(srv))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/v1/events/stream?channels=run_completed,trigger_fired", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	sc := bufio.NewScanner(resp.Body)
+	ev := nextSSEEvent(t, sc)
+	assert.Equal(t, "subscribed", ev.name)
+	assert.JSONEq(t, `{"channels":["run_completed","trigger_fired"]}`, ev.data)
+
+	bus.Publish("pipeline_created", `{"pipeline_id":"p1"}`)
+	bus.Publish("trigger_fired", `{
+		"trigger_id": "t1",
+		"run_id": "r1"
+	}`)
+
+	ev = nextSSEEvent(t, sc)
+	assert.Equal(t, "trigger_fired", ev.name, "unsubscribed channels are not streamed")
+	assert.Equal(t, `{"trigger_id":"t1","run_id":"r1"}`, ev.data)
+}
+
+func TestStreamEvents_UnknownChannel_Returns400(t *testing.T) {
+	srv, _ := newTestServer()
+	srv.EventStream = newMemoryEventBus()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream?channels=run_completed,run_started", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `unknown channel \"run_started\"`)
+}
+
+func TestStreamEvents_NonAdmin_Returns403(t *testing.T) {
+	srv, _ := newTestServer()
+	srv.EventStream = newMemoryEventBus()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream", http.NoBody)
+	req = req.WithContext(plugins.ContextWithUser(req.Context(), &domain.UserIdentity{UserID: "bob", Roles: []string{"editor"}}))
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestStreamEvents_NoEventBus_Returns503(t *testing.T) {
+	srv, _ := newTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestStreamEvents_SSELimitReached_Returns429(t *testing.T) {
+	srv, _ := newTestServer()
+	srv.EventStream = newMemoryEventBus()
+	srv.SSELimiter = api.NewSSELimiterWithLimits(1, 10)
+	require.True(t, srv.SSELimiter.Acquire("10.0.0.1"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream", http.NoBody)
+	req.RemoteAddr = "10.0.0.1:1234"
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
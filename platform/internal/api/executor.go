@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
 	"github.com/rat-data/rat/platform/internal/domain"
 )
@@ -9,7 +11,7 @@ import (
 // PreviewResult holds the response from a pipeline preview execution.
 type PreviewResult struct {
 	Columns       []QueryColumn            `json:"columns"`
-	Rows          []map[string]interface{}  `json:"rows"`
+	Rows          []map[string]interface{} `json:"rows"`
 	TotalRowCount int64                    `json:"total_row_count"`
 	Phases        []PhaseProfile           `json:"phases"`
 	ExplainOutput string                   `json:"explain_output"`
@@ -19,25 +21,83 @@ type PreviewResult struct {
 	Warnings      []string                 `json:"warnings"`
 }
 
-// PhaseProfile captures timing for a single execution phase.
-type PhaseProfile struct {
-	Name       string            `json:"name"`
-	DurationMs int64             `json:"duration_ms"`
-	Metadata   map[string]string `json:"metadata,omitempty"`
-}
+// PhaseProfile captures timing for a single execution phase. Aliased from
+// domain so completed runs can carry the same breakdown as previews.
+type PhaseProfile = domain.PhaseProfile
 
 // ValidationResult holds the outcome of template validation for a pipeline.
-type ValidationResult struct {
-	Valid bool             `json:"valid"`
-	Files []FileValidation `json:"files"`
-}
+// Aliased from domain so the latest result can be persisted on the pipeline.
+type ValidationResult = domain.ValidationResult
 
 // FileValidation holds per-file validation results.
-type FileValidation struct {
-	Path     string   `json:"path"`
-	Valid    bool     `json:"valid"`
-	Errors   []string `json:"errors"`
-	Warnings []string `json:"warnings"`
+type FileValidation = domain.FileValidation
+
+// Submit failure classes. Executor.Submit wraps every RPC failure in exactly
+// one of these so callers can tell "retry later" from "permanent failure"
+// without inspecting transport errors:
+//
+//   - ErrRunnerBusy: the runner is at capacity. The run is left pending.
+//   - ErrRunnerUnavailable: transient outage (unreachable, timed out,
+//     draining, internal error). The run is left pending; each caller
+//     decides whether to retry or give up.
+//   - ErrPipelineInvalid: the runner rejected the pipeline itself. Retrying
+//     cannot help; the executor has already marked the run failed.
+var (
+	ErrRunnerBusy        = errors.New("runner at capacity")
+	ErrRunnerUnavailable = errors.New("runner unavailable")
+	ErrPipelineInvalid   = errors.New("pipeline rejected by runner")
+)
+
+// MaxSubmitAttempts is how many retryable submit failures (runner
+// unavailable, timeouts) a pending run may accumulate before it is
+// dead-lettered. ErrRunnerBusy doesn't count: capacity backpressure says
+// nothing about the run. At the scheduler's 30s tick this tolerates a runner
+// outage of about five minutes.
+const MaxSubmitAttempts = 10
+
+// DeadLetterAfterSubmit records a retryable submit failure of run via
+// RunStore.RecordSubmitFailure and reports whether the run is now
+// dead-lettered, in which case callers must stop retrying it. A store error
+// is logged and reported as false so the caller keeps its usual retry path.
+func DeadLetterAfterSubmit(ctx context.Context, runs RunStore, run *domain.Run, err error) bool {
+	deadLettered, rerr := runs.RecordSubmitFailure(ctx, run.ID.String(), err.Error(), MaxSubmitAttempts)
+	if rerr != nil {
+		slog.Error("failed to record submit failure", "run_id", run.ID, "error", rerr)
+		return false
+	}
+	if deadLettered {
+		slog.Error("run dead-lettered after repeated submit failures",
+			"run_id", run.ID, "attempts", MaxSubmitAttempts, "error", err)
+		run.Status = domain.RunStatusFailed
+	}
+	return deadLettered
+}
+
+// SubmitOrFail submits a run that nothing will resubmit: manual runs,
+// retries and trigger-fired runs. Unlike the scheduler and the backfill
+// dispatcher, these paths don't come back for a pending run, which would sit
+// there holding its pipeline's slot in the scheduler until the reaper's
+// stuck-pending timeout. So a busy or unavailable runner fails the run on
+// the spot; a rejected pipeline has already been failed by the executor.
+// Returns Submit's error.
+func SubmitOrFail(ctx context.Context, exec Executor, runs RunStore, run *domain.Run, pipeline *domain.Pipeline) error {
+	err := exec.Submit(ctx, run, pipeline)
+	if err != nil && (errors.Is(err, ErrRunnerBusy) || errors.Is(err, ErrRunnerUnavailable)) {
+		// ctx may be the submit deadline that just expired.
+		FailRunAfterSubmit(context.WithoutCancel(ctx), runs, run, err)
+	}
+	return err
+}
+
+// FailRunAfterSubmit marks a run failed because Submit returned err. Used by
+// executors when the runner rejects the pipeline (ErrPipelineInvalid).
+func FailRunAfterSubmit(ctx context.Context, runs RunStore, run *domain.Run, err error) {
+	msg := err.Error()
+	if uerr := runs.UpdateRunStatus(ctx, run.ID.String(), domain.RunStatusFailed, &msg, nil, nil); uerr != nil {
+		slog.Error("failed to mark run failed after submit error", "run_id", run.ID, "error", uerr)
+		return
+	}
+	run.Status = domain.RunStatusFailed
 }
 
 // Executor dispatches pipeline runs to the runner service.
@@ -64,13 +124,67 @@ type StatusCallbackReceiver interface {
 	HandleStatusCallback(ctx context.Context, update RunStatusUpdate) error
 }
 
+// ErrLogStreamingUnsupported is returned by executor wrappers whose inner
+// executor cannot relay live logs. Callers fall back to polling GetLogs.
+var ErrLogStreamingUnsupported = errors.New("executor does not support live log streaming")
+
+// LogStreamer is an optional interface that executors can implement to relay
+// a run's logs live, as the runner produces them, instead of the snapshot
+// returned by GetLogs. StreamLogs calls send once per entry and returns when
+// the runner closes the stream (run reached a terminal state), ctx is
+// cancelled, or send returns an error.
+type LogStreamer interface {
+	StreamLogs(ctx context.Context, runID string, send func(LogEntry) error) error
+}
+
+// ErrSchemaInferenceUnsupported is returned by executor wrappers whose inner
+// executor cannot infer sample schemas. Callers fall back to parsing the
+// samples themselves.
+var ErrSchemaInferenceUnsupported = errors.New("executor does not support sample schema inference")
+
+// ErrInvalidSamples is returned by SampleSchemaInferrer when none of the
+// sample files can be read as a table (unknown format or malformed content).
+var ErrInvalidSamples = errors.New("sample files cannot be read")
+
+// SampleSchemaInferrer is an optional interface that executors can implement
+// to infer the columns of landing zone sample files with the runner's reader
+// (full type detection across every sample). paths are S3 keys under the
+// zone's _samples/ folder. Without it, ratd reads the header of the first
+// CSV or Parquet sample itself.
+type SampleSchemaInferrer interface {
+	InferSampleSchema(ctx context.Context, paths []string) ([]QueryColumn, error)
+}
+
 // RunStatusUpdate is the JSON payload the runner sends to ratd when a run
 // reaches a terminal state (success/failed/cancelled).
 type RunStatusUpdate struct {
 	RunID                string   `json:"run_id"`
-	Status               string   `json:"status"`                          // "success", "failed", "cancelled"
+	Status               string   `json:"status"` // "success", "failed", "cancelled"
 	Error                string   `json:"error,omitempty"`
 	DurationMs           int64    `json:"duration_ms,omitempty"`
 	RowsWritten          int64    `json:"rows_written"`
 	ArchivedLandingZones []string `json:"archived_landing_zones,omitempty"` // "{ns}/{zone}" pairs
+
+	// Phases is the run's per-phase timing breakdown, persisted on the run.
+	// Optional: older runners don't send it.
+	Phases []PhaseProfile `json:"phases,omitempty"`
+}
+
+// ExecutorStats is a point-in-time snapshot of an executor's internal counters.
+// Rendered by HandleMetrics as the rat_executor_* Prometheus series so operators
+// can alert on runner saturation (active runs climbing, busy failures spiking).
+type ExecutorStats struct {
+	ActiveRuns          int               // runs currently tracked as in-flight
+	SubmitsTotal        uint64            // Submit calls since process start
+	SubmitFailures      map[string]uint64 // failure reason → count (e.g. "runner_busy")
+	PollCount           uint64            // completed poll cycles
+	PollDurationSeconds float64           // cumulative wall time spent in poll cycles
+}
+
+// ExecutorStatsReporter is an optional interface that executors can implement
+// to expose internal counters on /metrics. The bool is false when the executor
+// has nothing to report (e.g. an AtomicExecutor whose inner executor doesn't
+// track stats), in which case the series are omitted rather than zeroed.
+type ExecutorStatsReporter interface {
+	ExecutorStats() (ExecutorStats, bool)
 }
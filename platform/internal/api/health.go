@@ -3,11 +3,14 @@ package api
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/rat-data/rat/platform/internal/cache"
 	"github.com/rat-data/rat/platform/internal/domain"
 )
 
@@ -32,6 +35,27 @@ type HealthChecker interface {
 	HealthCheck(ctx context.Context) error
 }
 
+// PoolStats is a point-in-time snapshot of a Postgres connection pool
+// (pgxpool.Stat). Rendered by HandleMetrics as the rat_pg_pool_* series so
+// operators can size DB_MAX_CONNS: a wait count that keeps climbing means
+// requests are queueing for a connection.
+type PoolStats struct {
+	Acquired     int32         // connections currently checked out
+	Idle         int32         // open connections waiting to be acquired
+	Total        int32         // open connections (acquired + idle + being constructed)
+	Max          int32         // configured pool size
+	WaitCount    int64         // acquires that had to wait for a connection, since start
+	WaitDuration time.Duration // cumulative time spent in those waits
+}
+
+// HealthCheckFunc adapts a plain function to HealthChecker.
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheck calls f(ctx).
+func (f HealthCheckFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
 // CheckResult holds the outcome of a single dependency health check.
 type CheckResult struct {
 	Status string `json:"status"`          // "ok" or "error"
@@ -140,13 +164,19 @@ func (s *Server) healthCheckers() map[string]HealthChecker {
 	if s.QueryHealth != nil {
 		checkers["query"] = s.QueryHealth
 	}
+	if s.ExecutorHealth != nil {
+		checkers["executor"] = s.ExecutorHealth
+	}
+	if s.EventBusHealth != nil {
+		checkers["event_bus"] = s.EventBusHealth
+	}
 	return checkers
 }
 
 // HandleMetrics returns basic application metrics in Prometheus text exposition format.
 // This is a lightweight implementation suitable for scraping by Prometheus.
 // For production use, consider integrating prometheus/client_golang for full histogram support.
-func (s *Server) HandleMetrics(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) HandleMetrics(w http.ResponseWriter, r *http.Request) {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
@@ -179,6 +209,22 @@ func (s *Server) HandleMetrics(w http.ResponseWriter, _ *http.Request) {
 		fmt.Fprintf(w, "# HELP ratd_sse_connections_active Current number of active SSE connections.\n")
 		fmt.Fprintf(w, "# TYPE ratd_sse_connections_active gauge\n")
 		fmt.Fprintf(w, "ratd_sse_connections_active %d\n", s.SSELimiter.GlobalCount())
+
+		fmt.Fprintf(w, "# HELP ratd_sse_clients_active Distinct client IPs holding at least one SSE connection.\n")
+		fmt.Fprintf(w, "# TYPE ratd_sse_clients_active gauge\n")
+		fmt.Fprintf(w, "ratd_sse_clients_active %d\n", s.SSELimiter.ClientCount())
+
+		perIP, global := s.SSELimiter.Limits()
+		fmt.Fprintf(w, "# HELP ratd_sse_connections_limit Configured SSE connection caps.\n")
+		fmt.Fprintf(w, "# TYPE ratd_sse_connections_limit gauge\n")
+		fmt.Fprintf(w, "ratd_sse_connections_limit{scope=\"per_ip\"} %d\n", perIP)
+		fmt.Fprintf(w, "ratd_sse_connections_limit{scope=\"global\"} %d\n", global)
+
+		rejectedPerIP, rejectedGlobal := s.SSELimiter.Rejections()
+		fmt.Fprintf(w, "# HELP ratd_sse_rejected_total SSE connections refused with 429, by the cap that was hit.\n")
+		fmt.Fprintf(w, "# TYPE ratd_sse_rejected_total counter\n")
+		fmt.Fprintf(w, "ratd_sse_rejected_total{scope=\"per_ip\"} %d\n", rejectedPerIP)
+		fmt.Fprintf(w, "ratd_sse_rejected_total{scope=\"global\"} %d\n", rejectedGlobal)
 	}
 
 	// Postgres pool saturation — main pool.
@@ -197,6 +243,13 @@ func (s *Server) HandleMetrics(w http.ResponseWriter, _ *http.Request) {
 		fmt.Fprintf(w, "ratd_postgres_pool_acquired %d\n", acquired)
 	}
 
+	// Postgres pool detail for sizing. wait_count_total rising under load
+	// means acquires are blocking on an exhausted pool; divide the
+	// wait_duration rate by the wait_count rate for the average wait.
+	if s.PGPoolStats != nil {
+		writePoolMetrics(w, s.PGPoolStats())
+	}
+
 	// Postgres pool saturation — dedicated heartbeat pool (when enabled).
 	// This pool exists so a saturated main pool can't starve the leader
 	// heartbeat ping (see main.go: RAT_HEARTBEAT_POOL_ENABLED). If this
@@ -242,6 +295,137 @@ func (s *Server) HandleMetrics(w http.ResponseWriter, _ *http.Request) {
 		fmt.Fprintf(w, "# TYPE ratd_scheduler_last_tick_dispatched_total gauge\n")
 		fmt.Fprintf(w, "ratd_scheduler_last_tick_dispatched_total %d\n", dispatched)
 	}
+
+	// Executor internals (only when the active executor reports stats).
+	// active_runs approaching the runner's max concurrency, or a rising
+	// submit_failures_total{reason="runner_busy"}, means the runner fleet
+	// is saturated and runs are queueing in pending.
+	if reporter, ok := s.Executor.(ExecutorStatsReporter); ok {
+		if stats, ok := reporter.ExecutorStats(); ok {
+			writeExecutorMetrics(w, stats)
+		}
+	}
+
+	// Run / schedule / trigger breakdowns for dashboards, from cached
+	// store counts (see platformCounts).
+	if counts := s.platformCounts(r.Context()); counts != nil {
+		writePlatformCountMetrics(w, counts)
+	}
+
+	// In-memory cache effectiveness. A low hits/(hits+misses) ratio means
+	// the TTL is too short for the access pattern; a climbing evictions
+	// counter means MaxEntries is too small for the working set.
+	if stats := s.cacheStats(); len(stats) > 0 {
+		writeCacheMetrics(w, stats)
+	}
+}
+
+// cacheStats snapshots every configured in-memory cache, keyed by the name
+// used for the cache label in /metrics. Nil caches are skipped.
+func (s *Server) cacheStats() map[string]cache.Stats {
+	stats := make(map[string]cache.Stats, 4)
+	if s.NamespaceCache != nil {
+		stats["namespace"] = s.NamespaceCache.Stats()
+	}
+	if s.PipelineCache != nil {
+		stats["pipeline"] = s.PipelineCache.Stats()
+	}
+	if s.LatestRunCache != nil {
+		stats["latest_run"] = s.LatestRunCache.Stats()
+	}
+	if s.PreviewCache != nil {
+		stats["preview"] = s.PreviewCache.Stats()
+	}
+	return stats
+}
+
+// writeCacheMetrics renders the rat_cache_* series, one label set per cache,
+// in sorted cache-name order so scrapes are stable.
+func writeCacheMetrics(w io.Writer, stats map[string]cache.Stats) {
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "# HELP rat_cache_hits_total Cache lookups that returned a live entry.\n")
+	fmt.Fprintf(w, "# TYPE rat_cache_hits_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "rat_cache_hits_total{cache=%q} %d\n", name, stats[name].Hits)
+	}
+
+	fmt.Fprintf(w, "# HELP rat_cache_misses_total Cache lookups for a missing or expired key.\n")
+	fmt.Fprintf(w, "# TYPE rat_cache_misses_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "rat_cache_misses_total{cache=%q} %d\n", name, stats[name].Misses)
+	}
+
+	fmt.Fprintf(w, "# HELP rat_cache_evictions_total Live entries evicted because the cache was at max entries.\n")
+	fmt.Fprintf(w, "# TYPE rat_cache_evictions_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "rat_cache_evictions_total{cache=%q} %d\n", name, stats[name].Evictions)
+	}
+
+	fmt.Fprintf(w, "# HELP rat_cache_entries Entries currently held by the cache.\n")
+	fmt.Fprintf(w, "# TYPE rat_cache_entries gauge\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "rat_cache_entries{cache=%q} %d\n", name, stats[name].Size)
+	}
+}
+
+// writePoolMetrics renders PoolStats as the rat_pg_pool_* series.
+func writePoolMetrics(w io.Writer, stats PoolStats) {
+	fmt.Fprintf(w, "# HELP rat_pg_pool_acquired_connections Connections currently acquired from the Postgres pool.\n")
+	fmt.Fprintf(w, "# TYPE rat_pg_pool_acquired_connections gauge\n")
+	fmt.Fprintf(w, "rat_pg_pool_acquired_connections %d\n", stats.Acquired)
+
+	fmt.Fprintf(w, "# HELP rat_pg_pool_idle_connections Idle connections held open by the Postgres pool.\n")
+	fmt.Fprintf(w, "# TYPE rat_pg_pool_idle_connections gauge\n")
+	fmt.Fprintf(w, "rat_pg_pool_idle_connections %d\n", stats.Idle)
+
+	fmt.Fprintf(w, "# HELP rat_pg_pool_total_connections Open connections in the Postgres pool.\n")
+	fmt.Fprintf(w, "# TYPE rat_pg_pool_total_connections gauge\n")
+	fmt.Fprintf(w, "rat_pg_pool_total_connections %d\n", stats.Total)
+
+	fmt.Fprintf(w, "# HELP rat_pg_pool_max_connections Configured maximum size of the Postgres pool.\n")
+	fmt.Fprintf(w, "# TYPE rat_pg_pool_max_connections gauge\n")
+	fmt.Fprintf(w, "rat_pg_pool_max_connections %d\n", stats.Max)
+
+	fmt.Fprintf(w, "# HELP rat_pg_pool_wait_count_total Acquires that waited for a free connection.\n")
+	fmt.Fprintf(w, "# TYPE rat_pg_pool_wait_count_total counter\n")
+	fmt.Fprintf(w, "rat_pg_pool_wait_count_total %d\n", stats.WaitCount)
+
+	fmt.Fprintf(w, "# HELP rat_pg_pool_wait_duration_seconds_total Cumulative time acquires spent waiting for a free connection.\n")
+	fmt.Fprintf(w, "# TYPE rat_pg_pool_wait_duration_seconds_total counter\n")
+	fmt.Fprintf(w, "rat_pg_pool_wait_duration_seconds_total %g\n", stats.WaitDuration.Seconds())
+}
+
+// writeExecutorMetrics renders ExecutorStats in Prometheus text format.
+// Failure reasons are emitted in sorted order so scrapes are stable.
+func writeExecutorMetrics(w io.Writer, stats ExecutorStats) {
+	fmt.Fprintf(w, "# HELP rat_executor_active_runs Runs currently in flight on the executor.\n")
+	fmt.Fprintf(w, "# TYPE rat_executor_active_runs gauge\n")
+	fmt.Fprintf(w, "rat_executor_active_runs %d\n", stats.ActiveRuns)
+
+	fmt.Fprintf(w, "# HELP rat_executor_submits_total Total run submissions attempted by the executor.\n")
+	fmt.Fprintf(w, "# TYPE rat_executor_submits_total counter\n")
+	fmt.Fprintf(w, "rat_executor_submits_total %d\n", stats.SubmitsTotal)
+
+	fmt.Fprintf(w, "# HELP rat_executor_submit_failures_total Run submissions that failed, by reason.\n")
+	fmt.Fprintf(w, "# TYPE rat_executor_submit_failures_total counter\n")
+	reasons := make([]string, 0, len(stats.SubmitFailures))
+	for reason := range stats.SubmitFailures {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "rat_executor_submit_failures_total{reason=%q} %d\n", reason, stats.SubmitFailures[reason])
+	}
+
+	fmt.Fprintf(w, "# HELP rat_executor_poll_duration_seconds Time spent polling the runner for run status.\n")
+	fmt.Fprintf(w, "# TYPE rat_executor_poll_duration_seconds summary\n")
+	fmt.Fprintf(w, "rat_executor_poll_duration_seconds_sum %g\n", stats.PollDurationSeconds)
+	fmt.Fprintf(w, "rat_executor_poll_duration_seconds_count %d\n", stats.PollCount)
 }
 
 // HandleFeatures returns the active platform capabilities.
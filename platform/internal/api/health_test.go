@@ -11,8 +11,10 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/cache"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -148,6 +150,55 @@ func TestHandleHealthReady_PostgresDown_Returns503(t *testing.T) {
 	assert.Equal(t, "ok", body.Checks["s3"].Status)
 }
 
+func TestHandleHealthReady_ExecutorNotAccepting_Returns503(t *testing.T) {
+	srv := &api.Server{
+		LandingZones:   newMemoryLandingZoneStore(),
+		DBHealth:       &mockHealthChecker{err: nil},
+		ExecutorHealth: &mockHealthChecker{err: errors.New("executor is draining")},
+		EventBusHealth: &mockHealthChecker{err: nil},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/health/ready", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var body api.ReadinessResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "not_ready", body.Status)
+	assert.Equal(t, "error", body.Checks["executor"].Status)
+	assert.Equal(t, "executor is draining", body.Checks["executor"].Error)
+	assert.Equal(t, "ok", body.Checks["event_bus"].Status)
+	assert.Equal(t, "ok", body.Checks["postgres"].Status)
+}
+
+func TestHandleHealthReady_EventBusDisconnected_Returns503(t *testing.T) {
+	srv := &api.Server{
+		LandingZones:   newMemoryLandingZoneStore(),
+		ExecutorHealth: &mockHealthChecker{err: nil},
+		EventBusHealth: api.HealthCheckFunc(func(context.Context) error {
+			return errors.New("event bus: LISTEN connection lost: conn closed")
+		}),
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/health/ready", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var body api.ReadinessResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "error", body.Checks["event_bus"].Status)
+	assert.Contains(t, body.Checks["event_bus"].Error, "LISTEN connection lost")
+	assert.Equal(t, "ok", body.Checks["executor"].Status)
+}
+
 func TestHandleHealthReady_S3Down_Returns503(t *testing.T) {
 	srv := &api.Server{
 		LandingZones: newMemoryLandingZoneStore(),
@@ -415,6 +466,29 @@ func TestHandleMetrics_AllClosuresWired_EmitsEveryMetric(t *testing.T) {
 	assert.InDelta(t, 7.0, metrics["ratd_scheduler_last_tick_dispatched_total"], 0.0001)
 }
 
+func TestHandleMetrics_PGPoolStats_EmitsPoolSeries(t *testing.T) {
+	srv := &api.Server{
+		LandingZones: newMemoryLandingZoneStore(),
+		PGPoolStats: func() api.PoolStats {
+			return api.PoolStats{Acquired: 20, Idle: 3, Total: 24, Max: 25, WaitCount: 12, WaitDuration: 1500 * time.Millisecond}
+		},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	metrics := parsePromMetrics(t, rec.Body)
+	assert.InDelta(t, 20.0, metrics["rat_pg_pool_acquired_connections"], 0.0001)
+	assert.InDelta(t, 3.0, metrics["rat_pg_pool_idle_connections"], 0.0001)
+	assert.InDelta(t, 24.0, metrics["rat_pg_pool_total_connections"], 0.0001)
+	assert.InDelta(t, 25.0, metrics["rat_pg_pool_max_connections"], 0.0001)
+	assert.InDelta(t, 12.0, metrics["rat_pg_pool_wait_count_total"], 0.0001)
+	assert.InDelta(t, 1.5, metrics["rat_pg_pool_wait_duration_seconds_total"], 0.0001)
+}
+
 func TestHandleMetrics_OmitsHeartbeatPoolWhenNotWired(t *testing.T) {
 	// Mirrors production behaviour when RAT_HEARTBEAT_POOL_ENABLED=false:
 	// main pool is exposed, dedicated heartbeat pool is not.
@@ -461,3 +535,143 @@ func TestHandleMetrics_ZeroValuesAreEmittedNotSuppressed(t *testing.T) {
 	require.Contains(t, metrics, "ratd_scheduler_last_tick_dispatched_total")
 	assert.Equal(t, 0.0, metrics["ratd_scheduler_last_tick_dispatched_total"])
 }
+
+// statsExecutor is a mockExecutor that also reports executor stats.
+type statsExecutor struct {
+	mockExecutor
+	stats api.ExecutorStats
+}
+
+func (s *statsExecutor) ExecutorStats() (api.ExecutorStats, bool) {
+	return s.stats, true
+}
+
+func TestHandleMetrics_ExecutorStats_EmitsExecutorSeries(t *testing.T) {
+	srv := &api.Server{
+		LandingZones: newMemoryLandingZoneStore(),
+		Executor: &statsExecutor{stats: api.ExecutorStats{
+			ActiveRuns:          4,
+			SubmitsTotal:        12,
+			SubmitFailures:      map[string]uint64{"runner_busy": 3, "runner_unavailable": 1},
+			PollCount:           5,
+			PollDurationSeconds: 0.25,
+		}},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	metrics := parsePromMetrics(t, rec.Body)
+
+	assert.InDelta(t, 4.0, metrics["rat_executor_active_runs"], 0.0001)
+	assert.InDelta(t, 12.0, metrics["rat_executor_submits_total"], 0.0001)
+	assert.InDelta(t, 3.0, metrics[`rat_executor_submit_failures_total{reason="runner_busy"}`], 0.0001)
+	assert.InDelta(t, 1.0, metrics[`rat_executor_submit_failures_total{reason="runner_unavailable"}`], 0.0001)
+	assert.InDelta(t, 0.25, metrics["rat_executor_poll_duration_seconds_sum"], 0.0001)
+	assert.InDelta(t, 5.0, metrics["rat_executor_poll_duration_seconds_count"], 0.0001)
+}
+
+func TestHandleMetrics_Caches_EmitsCacheSeries(t *testing.T) {
+	pipelineCache := cache.New[string, *domain.Pipeline](cache.Options{TTL: time.Minute, MaxEntries: 1})
+	pipelineCache.Set("a", &domain.Pipeline{})
+	pipelineCache.Get("a")
+	pipelineCache.Get("missing")
+	pipelineCache.Set("b", &domain.Pipeline{}) // evicts a
+
+	srv := &api.Server{
+		LandingZones:   newMemoryLandingZoneStore(),
+		NamespaceCache: cache.New[string, []domain.Namespace](cache.Options{}),
+		PipelineCache:  pipelineCache,
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	metrics := parsePromMetrics(t, rec.Body)
+
+	assert.InDelta(t, 1.0, metrics[`rat_cache_hits_total{cache="pipeline"}`], 0.0001)
+	assert.InDelta(t, 1.0, metrics[`rat_cache_misses_total{cache="pipeline"}`], 0.0001)
+	assert.InDelta(t, 1.0, metrics[`rat_cache_evictions_total{cache="pipeline"}`], 0.0001)
+	assert.InDelta(t, 1.0, metrics[`rat_cache_entries{cache="pipeline"}`], 0.0001)
+	assert.Contains(t, metrics, `rat_cache_hits_total{cache="namespace"}`)
+	assert.NotContains(t, metrics, `rat_cache_hits_total{cache="latest_run"}`)
+}
+
+func TestHandleMetrics_ExecutorWithoutStats_OmitsExecutorSeries(t *testing.T) {
+	srv := &api.Server{
+		LandingZones: newMemoryLandingZoneStore(),
+		Executor:     &mockExecutor{},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	metrics := parsePromMetrics(t, rec.Body)
+
+	assert.NotContains(t, metrics, "rat_executor_active_runs")
+}
+
+// countingPlatformCounter returns fixed counts and records how often the
+// stores would have been queried.
+type countingPlatformCounter struct {
+	calls  int
+	counts api.PlatformCounts
+}
+
+func (c *countingPlatformCounter) PlatformCounts(_ context.Context) (*api.PlatformCounts, error) {
+	c.calls++
+	counts := c.counts
+	return &counts, nil
+}
+
+func TestHandleMetrics_PlatformCounts_EmitsRunScheduleTriggerSeries(t *testing.T) {
+	counter := &countingPlatformCounter{counts: api.PlatformCounts{
+		RunsByStatus:   map[string]int{"success": 12, "failed": 3, "running": 1},
+		RunsByTrigger:  map[string]int{"schedule": 10, "manual": 4, "trigger:webhook": 2},
+		Schedules:      api.EnabledCount{Enabled: 5, Disabled: 2},
+		TriggersByType: map[string]api.EnabledCount{"cron": {Enabled: 3}, "webhook": {Enabled: 1, Disabled: 1}},
+	}}
+	srv := &api.Server{LandingZones: newMemoryLandingZoneStore(), Counts: counter}
+	router := api.NewRouter(srv)
+
+	scrape := func() map[string]float64 {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return parsePromMetrics(t, rec.Body)
+	}
+	metrics := scrape()
+
+	assert.Equal(t, 12.0, metrics[`rat_runs{status="success"}`])
+	assert.Equal(t, 3.0, metrics[`rat_runs{status="failed"}`])
+	assert.Equal(t, 1.0, metrics[`rat_runs{status="running"}`])
+	assert.Equal(t, 10.0, metrics[`rat_runs_by_trigger{source="schedule"}`])
+	assert.Equal(t, 2.0, metrics[`rat_runs_by_trigger{source="trigger:webhook"}`])
+	assert.Equal(t, 5.0, metrics[`rat_schedules{enabled="true"}`])
+	assert.Equal(t, 2.0, metrics[`rat_schedules{enabled="false"}`])
+	assert.Equal(t, 3.0, metrics[`rat_triggers{type="cron",enabled="true"}`])
+	assert.Contains(t, metrics, `rat_triggers{type="cron",enabled="false"}`)
+	assert.Equal(t, 1.0, metrics[`rat_triggers{type="webhook",enabled="false"}`])
+
+	scrape()
+	assert.Equal(t, 1, counter.calls, "second scrape is served from the counts cache")
+}
+
+func TestHandleMetrics_NoPlatformCounter_OmitsRunSeries(t *testing.T) {
+	router := api.NewRouter(&api.Server{LandingZones: newMemoryLandingZoneStore()})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+	assert.NotContains(t, rec.Body.String(), "rat_runs")
+	assert.NotContains(t, rec.Body.String(), "rat_schedules")
+}
@@ -0,0 +1,430 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+)
+
+// Type families used to compare expected_schema types against file types.
+// Engine-specific spellings (bigint, int64, varchar(255), double, ...) all
+// collapse to one family; unrecognised expected types are not type-checked.
+const (
+	typeFamilyInteger   = "integer"
+	typeFamilyFloat     = "float"
+	typeFamilyString    = "string"
+	typeFamilyBoolean   = "boolean"
+	typeFamilyDate      = "date"
+	typeFamilyTimestamp = "timestamp"
+)
+
+// SchemaColumn is one column of an expected or uploaded schema. Type is empty
+// when unknown (CSV headers carry no types).
+type SchemaColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+// ColumnTypeMismatch is a column present on both sides with incompatible types.
+type ColumnTypeMismatch struct {
+	Column   string `json:"column"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// SchemaDiff describes how an uploaded file deviates from expected_schema.
+type SchemaDiff struct {
+	Missing        []string             `json:"missing"`
+	Extra          []string             `json:"extra"`
+	TypeMismatches []ColumnTypeMismatch `json:"type_mismatches"`
+}
+
+// Empty reports whether the file matched.
+func (d SchemaDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.TypeMismatches) == 0
+}
+
+// parseExpectedSchema parses a zone's expected_schema, e.g.
+// "id:int,name:varchar,amount:decimal(10,2)". Entries are separated by commas
+// or newlines; the type is optional ("id" and "id int" are also accepted).
+func parseExpectedSchema(raw string) ([]SchemaColumn, error) {
+	var cols []SchemaColumn
+	seen := map[string]bool{}
+	for _, entry := range splitSchemaEntries(raw) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, typ, ok := strings.Cut(entry, ":")
+		if !ok {
+			name, typ, _ = strings.Cut(entry, " ")
+		}
+		name = strings.TrimSpace(name)
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		if name == "" {
+			return nil, fmt.Errorf("column %q has no name", entry)
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			return nil, fmt.Errorf("column %q listed twice", name)
+		}
+		seen[key] = true
+		cols = append(cols, SchemaColumn{Name: name, Type: typ})
+	}
+	if len(cols) == 0 {
+		return nil, errors.New("no columns")
+	}
+	return cols, nil
+}
+
+// splitSchemaEntries splits on commas and newlines outside parentheses, so
+// "decimal(10,2)" stays in one piece.
+func splitSchemaEntries(raw string) []string {
+	var entries []string
+	depth, start := 0, 0
+	for i, c := range raw {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ',', '\n':
+			if depth == 0 {
+				entries = append(entries, raw[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(entries, raw[start:])
+}
+
+// typeFamily maps an expected_schema type spelling to its family, or "" when
+// it isn't recognised.
+func typeFamily(t string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(t)), "(")
+	switch strings.TrimSpace(base) {
+	case "int", "integer", "bigint", "smallint", "tinyint", "hugeint", "long", "short",
+		"int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return typeFamilyInteger
+	case "float", "double", "real", "float4", "float8", "decimal", "numeric", "number":
+		return typeFamilyFloat
+	case "string", "varchar", "text", "char", "utf8", "str":
+		return typeFamilyString
+	case "bool", "boolean":
+		return typeFamilyBoolean
+	case "date":
+		return typeFamilyDate
+	case "timestamp", "timestamptz", "datetime":
+		return typeFamilyTimestamp
+	}
+	return ""
+}
+
+// uploadedSchema reads the column list of a CSV or Parquet upload. ok is false
+// for other formats, which are never validated. Only the parts of the file
+// that hold the schema are read: the CSV header or the Parquet footer.
+func uploadedSchema(filename string, content parquet.ReaderAtSeeker) (cols []SchemaColumn, ok bool, err error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		cols, err = csvSchema(io.NewSectionReader(content, 0, math.MaxInt64))
+		return cols, true, err
+	case ".parquet":
+		cols, err = parquetSchema(content)
+		return cols, true, err
+	}
+	return nil, false, nil
+}
+
+// csvSchema returns the header row of a CSV file. Types are unknown.
+func csvSchema(content io.Reader) ([]SchemaColumn, error) {
+	br := bufio.NewReader(content)
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = br.Discard(3)
+	}
+	r := csv.NewReader(br)
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty CSV file")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	cols := make([]SchemaColumn, len(header))
+	for i, h := range header {
+		cols[i] = SchemaColumn{Name: strings.TrimSpace(h)}
+	}
+	return cols, nil
+}
+
+// parquetSchema returns the top-level columns of a Parquet file, typed by family.
+func parquetSchema(content parquet.ReaderAtSeeker) ([]SchemaColumn, error) {
+	rdr, err := file.NewParquetReader(content)
+	if err != nil {
+		return nil, fmt.Errorf("open parquet file: %w", err)
+	}
+	defer rdr.Close()
+
+	root := rdr.MetaData().Schema.Root()
+	cols := make([]SchemaColumn, root.NumFields())
+	for i := range cols {
+		field := root.Field(i)
+		cols[i] = SchemaColumn{Name: field.Name(), Type: parquetTypeFamily(field)}
+	}
+	return cols, nil
+}
+
+// parquetTypeFamily maps a Parquet node to a type family. Nested (group)
+// columns return "" and are not type-checked.
+func parquetTypeFamily(n schema.Node) string {
+	prim, ok := n.(*schema.PrimitiveNode)
+	if !ok {
+		return ""
+	}
+	switch prim.LogicalType().(type) {
+	case schema.StringLogicalType, schema.EnumLogicalType, schema.JSONLogicalType:
+		return typeFamilyString
+	case schema.DateLogicalType:
+		return typeFamilyDate
+	case *schema.TimestampLogicalType:
+		return typeFamilyTimestamp
+	case *schema.DecimalLogicalType:
+		return typeFamilyFloat
+	case *schema.IntLogicalType:
+		return typeFamilyInteger
+	}
+	switch prim.PhysicalType() {
+	case parquet.Types.Boolean:
+		return typeFamilyBoolean
+	case parquet.Types.Int32, parquet.Types.Int64:
+		return typeFamilyInteger
+	case parquet.Types.Int96:
+		return typeFamilyTimestamp
+	case parquet.Types.Float, parquet.Types.Double:
+		return typeFamilyFloat
+	case parquet.Types.ByteArray, parquet.Types.FixedLenByteArray:
+		return typeFamilyString
+	}
+	return ""
+}
+
+// diffSchema compares an uploaded schema against the expected one. Column
+// names match case-insensitively; types are compared by family, and only when
+// both sides have a recognised type.
+func diffSchema(expected, actual []SchemaColumn) SchemaDiff {
+	diff := SchemaDiff{Missing: []string{}, Extra: []string{}, TypeMismatches: []ColumnTypeMismatch{}}
+
+	actualByName := make(map[string]SchemaColumn, len(actual))
+	for _, c := range actual {
+		actualByName[strings.ToLower(c.Name)] = c
+	}
+	expectedNames := make(map[string]bool, len(expected))
+	for _, exp := range expected {
+		key := strings.ToLower(exp.Name)
+		expectedNames[key] = true
+		got, ok := actualByName[key]
+		if !ok {
+			diff.Missing = append(diff.Missing, exp.Name)
+			continue
+		}
+		want := typeFamily(exp.Type)
+		if want != "" && got.Type != "" && want != got.Type {
+			diff.TypeMismatches = append(diff.TypeMismatches, ColumnTypeMismatch{
+				Column: exp.Name, Expected: exp.Type, Actual: got.Type,
+			})
+		}
+	}
+	for _, c := range actual {
+		if !expectedNames[strings.ToLower(c.Name)] {
+			diff.Extra = append(diff.Extra, c.Name)
+		}
+	}
+	return diff
+}
+
+// validateLandingUpload enforces a strict zone's expected_schema against an
+// upload. It writes a 422 and returns false on mismatch; zones without
+// schema_strict and non-CSV/Parquet files always pass.
+func validateLandingUpload(w http.ResponseWriter, zone *LandingZoneDetail, filename string, content parquet.ReaderAtSeeker) bool {
+	if !zone.SchemaStrict {
+		return true
+	}
+	expected, err := parseExpectedSchema(zone.ExpectedSchema)
+	if err != nil {
+		// SchemaStrict can't be enabled with an unparseable schema, but the
+		// schema may predate the flag; don't lock the zone on a config error.
+		return true
+	}
+	actual, ok, err := uploadedSchema(filename, content)
+	if !ok {
+		return true
+	}
+	if err != nil {
+		writeSchemaMismatch(w, "could not read file schema: "+err.Error(), nil)
+		return false
+	}
+	if diff := diffSchema(expected, actual); !diff.Empty() {
+		writeSchemaMismatch(w, "file does not match the zone's expected_schema", &diff)
+		return false
+	}
+	return true
+}
+
+// writeSchemaMismatch writes the standard error envelope plus the schema diff.
+func writeSchemaMismatch(w http.ResponseWriter, message string, diff *SchemaDiff) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"error": APIErrorDetail{
+			Code:    "SCHEMA_MISMATCH",
+			Type:    ErrorTypeValidation,
+			Message: message,
+		},
+		"schema_diff": diff,
+	})
+}
+
+// sampleInferRows bounds how many CSV data rows inferSampleColumns scans.
+const sampleInferRows = 1000
+
+// isTabularSample reports whether ratd can infer a schema from the file.
+func isTabularSample(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv", ".parquet":
+		return true
+	}
+	return false
+}
+
+// inferSampleColumns is the server-side fallback for sample schema inference
+// when the executor can't ask the runner. Parquet columns come from the file
+// schema; CSV columns are typed by scanning the first sampleInferRows rows.
+// Types use the engine's spelling (BIGINT, DOUBLE, VARCHAR, ...).
+func inferSampleColumns(path string, content []byte) ([]QueryColumn, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".parquet" {
+		schemaCols, err := parquetSchema(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		cols := make([]QueryColumn, len(schemaCols))
+		for i, c := range schemaCols {
+			cols[i] = QueryColumn{Name: c.Name, Type: familyEngineType(c.Type)}
+		}
+		return cols, nil
+	}
+	return inferCSVColumns(content, sampleInferRows)
+}
+
+// inferCSVColumns reads the header and up to maxRows rows, widening each
+// column's type as values are seen: BIGINT → DOUBLE, DATE → TIMESTAMP, and
+// anything mixed → VARCHAR. Empty cells are ignored; a column with no values
+// is VARCHAR.
+func inferCSVColumns(content []byte, maxRows int) ([]QueryColumn, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty CSV file")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+
+	types := make([]string, len(header))
+	for n := 0; n < maxRows; n++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV row %d: %w", n+2, err)
+		}
+		for i := 0; i < len(record) && i < len(types); i++ {
+			v := strings.TrimSpace(record[i])
+			if v == "" {
+				continue
+			}
+			types[i] = widenCSVType(types[i], csvValueType(v))
+		}
+	}
+
+	cols := make([]QueryColumn, len(header))
+	for i, h := range header {
+		typ := types[i]
+		if typ == "" {
+			typ = "VARCHAR"
+		}
+		cols[i] = QueryColumn{Name: strings.TrimSpace(h), Type: typ}
+	}
+	return cols, nil
+}
+
+// csvTimestampLayouts are the timestamp spellings recognised in CSV samples.
+var csvTimestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05"}
+
+// csvValueType returns the narrowest type that holds a single CSV value.
+func csvValueType(v string) string {
+	if strings.EqualFold(v, "true") || strings.EqualFold(v, "false") {
+		return "BOOLEAN"
+	}
+	if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return "BIGINT"
+	}
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return "DOUBLE"
+	}
+	if _, err := time.Parse(time.DateOnly, v); err == nil {
+		return "DATE"
+	}
+	for _, layout := range csvTimestampLayouts {
+		if _, err := time.Parse(layout, v); err == nil {
+			return "TIMESTAMP"
+		}
+	}
+	return "VARCHAR"
+}
+
+// widenCSVType merges a column's type so far with the type of a new value.
+func widenCSVType(current, next string) string {
+	switch {
+	case current == "" || current == next:
+		return next
+	case (current == "BIGINT" && next == "DOUBLE") || (current == "DOUBLE" && next == "BIGINT"):
+		return "DOUBLE"
+	case (current == "DATE" && next == "TIMESTAMP") || (current == "TIMESTAMP" && next == "DATE"):
+		return "TIMESTAMP"
+	}
+	return "VARCHAR"
+}
+
+// familyEngineType maps a type family to the engine type name reported in
+// QueryColumn. Unknown families (nested Parquet columns) stay empty.
+func familyEngineType(family string) string {
+	switch family {
+	case typeFamilyInteger:
+		return "BIGINT"
+	case typeFamilyFloat:
+		return "DOUBLE"
+	case typeFamilyString:
+		return "VARCHAR"
+	case typeFamilyBoolean:
+		return "BOOLEAN"
+	case typeFamilyDate:
+		return "DATE"
+	case typeFamilyTimestamp:
+		return "TIMESTAMP"
+	}
+	return ""
+}
@@ -0,0 +1,328 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+	"github.com/google/uuid"
+	runnerv1 "github.com/rat-data/rat/platform/gen/runner/v1"
+	"github.com/rat-data/rat/platform/gen/runner/v1/runnerv1connect"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStrictZoneServer returns a landing test server with one zone "orders"
+// whose expected_schema is "id:int,name:varchar,amount:decimal(10,2)".
+func newStrictZoneServer(strict bool) (*api.Server, *memoryLandingZoneStore, *memoryStorageStore) {
+	srv, store := newLandingTestServer()
+	store.zones = []api.LandingZoneListItem{{LandingZone: domain.LandingZone{
+		ID: uuid.New(), Namespace: "default", Name: "orders",
+		ExpectedSchema: "id:int,name:varchar,amount:decimal(10,2)",
+		SchemaStrict:   strict,
+	}}}
+	return srv, store, srv.Storage.(*memoryStorageStore)
+}
+
+func uploadLandingFile(t *testing.T, srv *api.Server, filename string, content []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/landing-zones/default/orders/files", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+// buildParquet writes a one-row Parquet file with columns id (int64) and
+// name (int32 — deliberately the wrong type for a varchar column).
+func buildParquet(t *testing.T) []byte {
+	t.Helper()
+	id, err := schema.NewPrimitiveNode("id", parquet.Repetitions.Required, parquet.Types.Int64, -1, -1)
+	require.NoError(t, err)
+	name, err := schema.NewPrimitiveNode("name", parquet.Repetitions.Required, parquet.Types.Int32, -1, -1)
+	require.NoError(t, err)
+	amount, err := schema.NewPrimitiveNode("amount", parquet.Repetitions.Required, parquet.Types.Double, -1, -1)
+	require.NoError(t, err)
+	root, err := schema.NewGroupNode("schema", parquet.Repetitions.Required, schema.FieldList{id, name, amount}, -1)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := file.NewParquetWriter(&buf, root)
+	rg := w.AppendRowGroup()
+	cw, err := rg.NextColumn()
+	require.NoError(t, err)
+	_, err = cw.(*file.Int64ColumnChunkWriter).WriteBatch([]int64{1}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+	cw, err = rg.NextColumn()
+	require.NoError(t, err)
+	_, err = cw.(*file.Int32ColumnChunkWriter).WriteBatch([]int32{7}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+	cw, err = rg.NextColumn()
+	require.NoError(t, err)
+	_, err = cw.(*file.Float64ColumnChunkWriter).WriteBatch([]float64{9.99}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cw.Close())
+	require.NoError(t, rg.Close())
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestUploadLandingFile_StrictMatchingCSV_Returns201(t *testing.T) {
+	srv, store, _ := newStrictZoneServer(true)
+
+	rec := uploadLandingFile(t, srv, "orders.csv", []byte("ID,Name,amount\n1,Alice,9.99\n"))
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Len(t, store.files, 1)
+}
+
+func TestUploadLandingFile_StrictMissingColumn_Returns422WithDiff(t *testing.T) {
+	srv, store, storage := newStrictZoneServer(true)
+
+	rec := uploadLandingFile(t, srv, "orders.csv", []byte("id,name,region\n1,Alice,EU\n"))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	var body struct {
+		Error      api.APIErrorDetail `json:"error"`
+		SchemaDiff api.SchemaDiff     `json:"schema_diff"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "SCHEMA_MISMATCH", body.Error.Code)
+	assert.Equal(t, []string{"amount"}, body.SchemaDiff.Missing)
+	assert.Equal(t, []string{"region"}, body.SchemaDiff.Extra)
+	assert.Empty(t, body.SchemaDiff.TypeMismatches)
+
+	assert.Empty(t, store.files, "rejected file must not be registered")
+	assert.Empty(t, storage.files, "rejected file must not reach S3")
+}
+
+func TestUploadLandingFile_StrictParquetTypeMismatch_Returns422(t *testing.T) {
+	srv, _, _ := newStrictZoneServer(true)
+
+	rec := uploadLandingFile(t, srv, "orders.parquet", buildParquet(t))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	var body struct {
+		SchemaDiff api.SchemaDiff `json:"schema_diff"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Empty(t, body.SchemaDiff.Missing)
+	assert.Empty(t, body.SchemaDiff.Extra)
+	assert.Equal(t, []api.ColumnTypeMismatch{{Column: "name", Expected: "varchar", Actual: "integer"}},
+		body.SchemaDiff.TypeMismatches)
+}
+
+func TestUploadLandingFile_StrictOff_AcceptsMismatch(t *testing.T) {
+	srv, store, _ := newStrictZoneServer(false)
+
+	rec := uploadLandingFile(t, srv, "orders.csv", []byte("completely,different\n1,2\n"))
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Len(t, store.files, 1)
+}
+
+func TestUploadLandingFile_StrictNonTabularFile_Returns201(t *testing.T) {
+	srv, _, _ := newStrictZoneServer(true)
+
+	rec := uploadLandingFile(t, srv, "orders.json", []byte(`[{"foo":1}]`))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestUpdateLandingZone_EnableStrict(t *testing.T) {
+	srv, store, _ := newStrictZoneServer(false)
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/landing-zones/default/orders", bytes.NewBufferString(`{"schema_strict":true}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.True(t, store.zones[0].SchemaStrict)
+	var zone domain.LandingZone
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&zone))
+	assert.True(t, zone.SchemaStrict)
+}
+
+func TestUpdateLandingZone_EnableStrictWithoutSchema_Returns400(t *testing.T) {
+	srv, store, _ := newStrictZoneServer(false)
+	store.zones[0].ExpectedSchema = ""
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/landing-zones/default/orders", bytes.NewBufferString(`{"schema_strict":true}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.False(t, store.zones[0].SchemaStrict)
+}
+
+// --- Sample schema inference ---
+
+// schemaRunner is a runner service that only answers InferSampleSchema.
+type schemaRunner struct {
+	runnerv1connect.UnimplementedRunnerServiceHandler
+	columns []*runnerv1.ColumnInfo
+	err     error
+	paths   []string
+}
+
+func (r *schemaRunner) InferSampleSchema(_ context.Context, req *connect.Request[runnerv1.InferSampleSchemaRequest]) (*connect.Response[runnerv1.InferSampleSchemaResponse], error) {
+	r.paths = req.Msg.Paths
+	if r.err != nil {
+		return nil, r.err
+	}
+	return connect.NewResponse(&runnerv1.InferSampleSchemaResponse{Columns: r.columns}), nil
+}
+
+// newRunnerExecutor serves handler as a ConnectRPC runner over h2c and
+// returns the executor ratd would use to reach it.
+func newRunnerExecutor(t *testing.T, handler runnerv1connect.RunnerServiceHandler) api.Executor {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(runnerv1connect.NewRunnerServiceHandler(handler))
+	runner := httptest.NewUnstartedServer(mux)
+	runner.Config.Protocols = new(http.Protocols)
+	runner.Config.Protocols.SetUnencryptedHTTP2(true)
+	runner.Start()
+	t.Cleanup(runner.Close)
+
+	exec := executor.NewAtomicExecutor()
+	exec.Swap(executor.NewWarmPoolExecutor(runner.URL, &memoryRunStore{}))
+	return exec
+}
+
+func getSampleSchema(t *testing.T, srv *api.Server) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/landing-zones/default/orders/samples/schema", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	var body map[string]interface{}
+	_ = json.NewDecoder(rec.Body).Decode(&body)
+	return rec, body
+}
+
+func TestInferLandingSampleSchema_RunnerInferrer_ReturnsRunnerColumns(t *testing.T) {
+	srv, _, storage := newStrictZoneServer(false)
+	_, err := storage.WriteFile(context.Background(), "default/landing/orders/_samples/orders.csv", []byte("id,name\n1,a\n"))
+	require.NoError(t, err)
+	runner := &schemaRunner{columns: []*runnerv1.ColumnInfo{{Name: "id", Type: "INTEGER"}, {Name: "name", Type: "VARCHAR"}}}
+	srv.Executor = newRunnerExecutor(t, runner)
+
+	rec, body := getSampleSchema(t, srv)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "runner", body["source"])
+	assert.Equal(t, []string{"default/landing/orders/_samples/orders.csv"}, runner.paths)
+	cols := body["columns"].([]interface{})
+	require.Len(t, cols, 2)
+	assert.Equal(t, "INTEGER", cols[0].(map[string]interface{})["type"])
+}
+
+func TestInferLandingSampleSchema_RunnerError_Returns500(t *testing.T) {
+	srv, _, storage := newStrictZoneServer(false)
+	_, err := storage.WriteFile(context.Background(), "default/landing/orders/_samples/orders.csv", []byte("id\n1\n"))
+	require.NoError(t, err)
+	srv.Executor = newRunnerExecutor(t, &schemaRunner{err: connect.NewError(connect.CodeInternal, errors.New("runner exploded"))})
+
+	rec, _ := getSampleSchema(t, srv)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestInferLandingSampleSchema_RunnerRejectsSamples_Returns422(t *testing.T) {
+	srv, _, storage := newStrictZoneServer(false)
+	_, err := storage.WriteFile(context.Background(), "default/landing/orders/_samples/notes.txt", []byte("hello"))
+	require.NoError(t, err)
+	srv.Executor = newRunnerExecutor(t, &schemaRunner{err: connect.NewError(connect.CodeInvalidArgument, errors.New("no readable sample files"))})
+
+	rec, body := getSampleSchema(t, srv)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, body["error"].(map[string]interface{})["message"], "no readable sample files")
+}
+
+func TestInferLandingSampleSchema_RunnerWithoutRPC_ParsesServerSide(t *testing.T) {
+	srv, _, storage := newStrictZoneServer(false)
+	_, err := storage.WriteFile(context.Background(), "default/landing/orders/_samples/orders.csv", []byte("id,name\n1,a\n"))
+	require.NoError(t, err)
+	srv.Executor = newRunnerExecutor(t, runnerv1connect.UnimplementedRunnerServiceHandler{})
+
+	rec, body := getSampleSchema(t, srv)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "server", body["source"])
+}
+
+func TestInferLandingSampleSchema_NoInferrer_ParsesCSVServerSide(t *testing.T) {
+	srv, _, storage := newStrictZoneServer(false)
+	_, err := storage.WriteFile(context.Background(), "default/landing/orders/_samples/notes.txt", []byte("hello"))
+	require.NoError(t, err)
+	csv := "id,amount,paid,ordered_on,updated_at,note\n" +
+		"1,9,true,2026-01-02,2026-01-02 10:00:00,\n" +
+		"2,9.5,false,2026-01-03,2026-01-03,x\n"
+	_, err = storage.WriteFile(context.Background(), "default/landing/orders/_samples/orders.csv", []byte(csv))
+	require.NoError(t, err)
+	srv.Executor = &mockExecutor{}
+
+	rec, body := getSampleSchema(t, srv)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "server", body["source"])
+	types := map[string]string{}
+	for _, c := range body["columns"].([]interface{}) {
+		col := c.(map[string]interface{})
+		types[col["name"].(string)] = col["type"].(string)
+	}
+	assert.Equal(t, map[string]string{
+		"id":         "BIGINT",
+		"amount":     "DOUBLE",
+		"paid":       "BOOLEAN",
+		"ordered_on": "DATE",
+		"updated_at": "TIMESTAMP",
+		"note":       "VARCHAR",
+	}, types)
+}
+
+func TestInferLandingSampleSchema_Parquet_ParsesServerSide(t *testing.T) {
+	srv, _, storage := newStrictZoneServer(false)
+	_, err := storage.WriteFile(context.Background(), "default/landing/orders/_samples/orders.parquet", buildParquet(t))
+	require.NoError(t, err)
+
+	rec, body := getSampleSchema(t, srv)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	cols := body["columns"].([]interface{})
+	require.Len(t, cols, 3)
+	assert.Equal(t, map[string]interface{}{"name": "id", "type": "BIGINT"}, cols[0])
+}
+
+func TestInferLandingSampleSchema_NoSamples_Returns404(t *testing.T) {
+	srv, _, _ := newStrictZoneServer(false)
+
+	rec, _ := getSampleSchema(t, srv)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
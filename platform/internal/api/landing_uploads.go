@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+)
+
+// presignUploadExpiry is how long a presigned landing upload URL stays valid.
+const presignUploadExpiry = 15 * time.Minute
+
+// landingTimestampLayout prefixes stored landing filenames to avoid
+// collisions across uploads.
+const landingTimestampLayout = "20060102_150405_"
+
+// PresignedUploader is an optional StorageStore capability: issuing a
+// time-limited URL that lets a client PUT an object directly, bypassing ratd.
+// Checked via type assertion on Server.Storage.
+type PresignedUploader interface {
+	PresignPutURL(ctx context.Context, path string, expiry time.Duration) (string, error)
+}
+
+// PresignLandingUploadRequest is the JSON body for POST .../files/presign.
+type PresignLandingUploadRequest struct {
+	Filename string `json:"filename"`
+}
+
+// PresignLandingUploadResponse tells the client where to PUT the file and
+// which filename to hand back to .../files/complete.
+type PresignLandingUploadResponse struct {
+	UploadURL string    `json:"upload_url"`
+	Method    string    `json:"method"`
+	Filename  string    `json:"filename"`
+	S3Path    string    `json:"s3_path"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CompleteLandingUploadRequest is the JSON body for POST .../files/complete.
+type CompleteLandingUploadRequest struct {
+	// Filename is the stored filename returned by .../files/presign.
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// landingObjectName sanitizes a client-supplied filename and prefixes it with
+// a UTC timestamp. Returns false if nothing safe is left.
+func landingObjectName(filename string, now time.Time) (string, bool) {
+	// Sanitize filename to prevent path traversal (e.g., "../../pipelines/victim/pipeline.py")
+	safe := filepath.Base(filename)
+	if safe == "." || safe == "/" || strings.ContainsAny(safe, "\\/\x00") {
+		return "", false
+	}
+	return now.UTC().Format(landingTimestampLayout) + safe, true
+}
+
+// landingOriginalFilename strips the timestamp prefix added by
+// landingObjectName, recovering the name file_pattern triggers match against.
+func landingOriginalFilename(stored string) string {
+	if len(stored) <= len(landingTimestampLayout) {
+		return stored
+	}
+	if _, err := time.Parse(landingTimestampLayout, stored[:len(landingTimestampLayout)]); err != nil {
+		return stored
+	}
+	return stored[len(landingTimestampLayout):]
+}
+
+// landingZonePath returns the S3 key for a stored file in a landing zone.
+func landingZonePath(namespace, zoneName, filename string) string {
+	return namespace + "/landing/" + zoneName + "/" + filename
+}
+
+// landingChecksum returns the hex SHA-256 of a landing file's content.
+func landingChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// landingChecksumReader is landingChecksum for content read from r.
+func landingChecksumReader(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// discardLandingObject deletes an uploaded object that won't be registered.
+// Best-effort: a leftover object is harmless beyond the storage it uses.
+func (s *Server) discardLandingObject(ctx context.Context, s3Path string) {
+	if err := s.Storage.DeleteFile(ctx, s3Path); err != nil {
+		slog.Warn("failed to delete discarded landing upload", "path", s3Path, "error", err)
+	}
+}
+
+// HandlePresignLandingUpload returns a presigned S3 PUT URL so the client can
+// upload straight to object storage. The file isn't registered until the
+// client calls .../files/complete.
+// POST /api/v1/landing-zones/{namespace}/{name}/files/presign
+func (s *Server) HandlePresignLandingUpload(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	uploader, ok := s.Storage.(PresignedUploader)
+	if !ok {
+		errorJSON(w, "presigned uploads not available", "NOT_IMPLEMENTED", http.StatusNotImplemented)
+		return
+	}
+
+	var req PresignLandingUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	storedName, ok := landingObjectName(req.Filename, time.Now())
+	if req.Filename == "" || !ok {
+		errorJSON(w, "invalid filename", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	zone, err := s.LandingZones.GetZone(r.Context(), namespace, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if zone == nil {
+		errorJSON(w, "landing zone not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	s3Path := landingZonePath(namespace, name, storedName)
+	uploadURL, err := uploader.PresignPutURL(r.Context(), s3Path, presignUploadExpiry)
+	if err != nil {
+		internalError(w, "failed to presign upload", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PresignLandingUploadResponse{
+		UploadURL: uploadURL,
+		Method:    http.MethodPut,
+		Filename:  storedName,
+		S3Path:    s3Path,
+		ExpiresAt: time.Now().Add(presignUploadExpiry).UTC(),
+	})
+}
+
+// HandleCompleteLandingUpload registers a file the client uploaded via a
+// presigned URL and fires the zone's triggers, same as a proxied upload.
+// The object must already exist in S3; its size comes from S3, not the client.
+// The content is only streamed back (for schema checks and the checksum) when
+// the zone has schema_strict or dedupe enabled. Completing an object that is
+// already registered returns its record with 200 and fires nothing, so
+// clients can safely retry.
+// POST /api/v1/landing-zones/{namespace}/{name}/files/complete
+func (s *Server) HandleCompleteLandingUpload(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	var req CompleteLandingUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if req.Filename == "" || filepath.Base(req.Filename) != req.Filename || strings.ContainsAny(req.Filename, "\\/\x00") || req.Filename == ".." {
+		errorJSON(w, "invalid filename", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	zone, err := s.LandingZones.GetZone(r.Context(), namespace, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if zone == nil {
+		errorJSON(w, "landing zone not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	s3Path := landingZonePath(namespace, name, req.Filename)
+	registered, err := s.LandingZones.FindFileByPath(r.Context(), zone.ID, s3Path)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if registered != nil {
+		writeJSON(w, http.StatusOK, registered)
+		return
+	}
+
+	info, err := s.Storage.StatFile(r.Context(), s3Path)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if info == nil {
+		errorJSON(w, "uploaded object not found", "FAILED_PRECONDITION", http.StatusConflict)
+		return
+	}
+
+	// Strict and dedupe zones need the object's content, streamed rather
+	// than loaded: schema checks read only the header or footer. A rejected
+	// or duplicate object is removed so a pipeline reading the zone prefix
+	// can't pick it up.
+	var checksum string
+	if zone.SchemaStrict || zone.Dedupe {
+		obj, err := s.Storage.OpenFile(r.Context(), s3Path)
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		if obj == nil {
+			errorJSON(w, "uploaded object not found", "FAILED_PRECONDITION", http.StatusConflict)
+			return
+		}
+		defer obj.Close()
+		if !validateLandingUpload(w, zone, req.Filename, obj) {
+			s.discardLandingObject(r.Context(), s3Path)
+			return
+		}
+		checksum, err = landingChecksumReader(io.NewSectionReader(obj, 0, info.Size))
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		if zone.Dedupe {
+			existing, err := s.LandingZones.FindFileByChecksum(r.Context(), zone.ID, checksum)
+			if err != nil {
+				internalError(w, "internal error", err)
+				return
+			}
+			if existing != nil {
+				s.discardLandingObject(r.Context(), s3Path)
+				writeJSON(w, http.StatusOK, existing)
+				return
+			}
+		}
+	}
+
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	lf := &domain.LandingFile{
+		ZoneID:      zone.ID,
+		Filename:    req.Filename,
+		S3Path:      s3Path,
+		SizeBytes:   info.Size,
+		ContentType: contentType,
+		Checksum:    checksum,
+	}
+	if user := plugins.UserFromContext(r.Context()); user != nil {
+		lf.UploadedBy = &user.UserID
+	}
+
+	if err := s.LandingZones.CreateFile(r.Context(), lf); err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	s.evaluateLandingZoneTriggersAsync(namespace, name, landingOriginalFilename(req.Filename))
+
+	writeJSON(w, http.StatusCreated, lf)
+}
@@ -0,0 +1,194 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// presigningStorageStore adds api.PresignedUploader to the in-memory store.
+type presigningStorageStore struct {
+	*memoryStorageStore
+}
+
+func (p presigningStorageStore) PresignPutURL(_ context.Context, path string, expiry time.Duration) (string, error) {
+	return "https://s3.example.com/rat/" + path + "?X-Amz-Expires=" + url.QueryEscape(expiry.String()), nil
+}
+
+func postLandingJSON(srv *api.Server, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPresignLandingUpload_ReturnsPutURLForTimestampedPath(t *testing.T) {
+	srv, store := newLandingTestServer()
+	srv.Storage = presigningStorageStore{newMemoryStorageStore()}
+	store.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "uploads"}},
+	}
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/uploads/files/presign", `{"filename":"../../orders.csv"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp api.PresignLandingUploadResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, http.MethodPut, resp.Method)
+	assert.Regexp(t, `^\d{8}_\d{6}_orders\.csv$`, resp.Filename)
+	assert.Equal(t, "default/landing/uploads/"+resp.Filename, resp.S3Path)
+	assert.Equal(t, "https://s3.example.com/rat/"+resp.S3Path+"?X-Amz-Expires=15m0s", resp.UploadURL)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), resp.ExpiresAt, time.Minute)
+	assert.Empty(t, store.files, "presign must not register the file")
+}
+
+func TestPresignLandingUpload_StorageWithoutPresign_Returns501(t *testing.T) {
+	srv, store := newLandingTestServer()
+	store.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "uploads"}},
+	}
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/uploads/files/presign", `{"filename":"orders.csv"}`)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
+
+func TestPresignLandingUpload_ZoneNotFound_Returns404(t *testing.T) {
+	srv, _ := newLandingTestServer()
+	srv.Storage = presigningStorageStore{newMemoryStorageStore()}
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/missing/files/presign", `{"filename":"orders.csv"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCompleteLandingUpload_RegistersFileAndFiresTriggers(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	landingZoneTriggerFixture(pipelineStore, triggerStore)
+	exec := &mockExecutor{}
+	srv.Executor = exec
+	storage := newMemoryStorageStore()
+	srv.Storage = presigningStorageStore{storage}
+	zoneID := uuid.New()
+	lzStore := srv.LandingZones.(*memoryLandingZoneStore)
+	lzStore.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: zoneID, Namespace: "default", Name: "orders"}},
+	}
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/orders/files/presign", `{"filename":"orders.csv"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var presigned api.PresignLandingUploadResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&presigned))
+
+	// The client PUTs straight to S3.
+	storage.files[presigned.S3Path] = []byte("id,total\n1,9.99\n")
+
+	rec = postLandingJSON(srv, "/api/v1/landing-zones/default/orders/files/complete",
+		`{"filename":"`+presigned.Filename+`","content_type":"text/csv"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	lzStore.mu.Lock()
+	require.Len(t, lzStore.files, 1)
+	file := lzStore.files[0]
+	lzStore.mu.Unlock()
+	assert.Equal(t, zoneID, file.ZoneID)
+	assert.Equal(t, presigned.Filename, file.Filename)
+	assert.Equal(t, presigned.S3Path, file.S3Path)
+	assert.Equal(t, int64(len("id,total\n1,9.99\n")), file.SizeBytes)
+	assert.Equal(t, "text/csv", file.ContentType)
+
+	require.Eventually(t, func() bool { return exec.submitCount() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestCompleteLandingUpload_ObjectMissing_Returns409(t *testing.T) {
+	srv, store := newLandingTestServer()
+	store.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "uploads"}},
+	}
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/uploads/files/complete", `{"filename":"20260101_120000_orders.csv"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Empty(t, store.files)
+}
+
+func TestCompleteLandingUpload_PathInFilename_Returns400(t *testing.T) {
+	srv, store := newLandingTestServer()
+	store.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "uploads"}},
+	}
+
+	for _, name := range []string{"", "..", "../other/secret.csv", "a/b.csv"} {
+		rec := postLandingJSON(srv, "/api/v1/landing-zones/default/uploads/files/complete", `{"filename":"`+name+`"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+}
+
+func TestCompleteLandingUpload_Retry_ReturnsExistingWithoutRefiring(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	landingZoneTriggerFixture(pipelineStore, triggerStore)
+	exec := &mockExecutor{}
+	srv.Executor = exec
+	storage := newMemoryStorageStore()
+	srv.Storage = storage
+	lzStore := srv.LandingZones.(*memoryLandingZoneStore)
+	lzStore.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "orders"}},
+	}
+	storage.files["default/landing/orders/20260101_120000_orders.csv"] = []byte("id\n1\n")
+	body := `{"filename":"20260101_120000_orders.csv"}`
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/orders/files/complete", body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var first domain.LandingFile
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&first))
+	require.Eventually(t, func() bool { return exec.submitCount() == 1 }, time.Second, 5*time.Millisecond)
+
+	rec = postLandingJSON(srv, "/api/v1/landing-zones/default/orders/files/complete", body)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var second domain.LandingFile
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&second))
+	assert.Equal(t, first.ID, second.ID)
+
+	lzStore.mu.Lock()
+	assert.Len(t, lzStore.files, 1)
+	lzStore.mu.Unlock()
+	assert.Never(t, func() bool { return exec.submitCount() > 1 }, 50*time.Millisecond, 5*time.Millisecond)
+}
+
+func TestCompleteLandingUpload_StrictDedupeZone_StreamsObject(t *testing.T) {
+	srv, store, storage := newStrictZoneServer(true)
+	store.zones[0].Dedupe = true
+	content := []byte("id,name,amount\n1,a,9.99\n")
+	storage.files["default/landing/orders/20260101_120000_orders.csv"] = content
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/orders/files/complete", `{"filename":"20260101_120000_orders.csv"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	sum := sha256.Sum256(content)
+	require.Len(t, store.files, 1)
+	assert.Equal(t, hex.EncodeToString(sum[:]), store.files[0].Checksum)
+	assert.Equal(t, 0, storage.reads, "content must be streamed, not read whole")
+	assert.Equal(t, 1, storage.opens)
+}
+
+func TestCompleteLandingUpload_StrictZoneMismatch_Returns422AndDiscards(t *testing.T) {
+	srv, store, storage := newStrictZoneServer(true)
+	storage.files["default/landing/orders/20260101_120000_orders.csv"] = []byte("id,name\n1,a\n")
+
+	rec := postLandingJSON(srv, "/api/v1/landing-zones/default/orders/files/complete", `{"filename":"20260101_120000_orders.csv"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Empty(t, store.files)
+	assert.NotContains(t, storage.files, "default/landing/orders/20260101_120000_orders.csv")
+	assert.Equal(t, 0, storage.reads)
+}
@@ -1,12 +1,15 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,7 +32,22 @@ type LandingZoneStore interface {
 	DeleteFile(ctx context.Context, fileID uuid.UUID) error
 	GetZoneByID(ctx context.Context, zoneID uuid.UUID) (*domain.LandingZone, error)
 	UpdateZoneLifecycle(ctx context.Context, zoneID uuid.UUID, processedMaxAgeDays *int, autoPurge *bool) error
+	// UpdateZoneUploadPolicy sets schema_strict and/or dedupe; nil leaves a
+	// setting unchanged.
+	UpdateZoneUploadPolicy(ctx context.Context, zoneID uuid.UUID, schemaStrict, dedupe *bool) error
+	// FindFileByChecksum returns the newest pending file in the zone with the
+	// given SHA-256, or nil, nil if there is none.
+	FindFileByChecksum(ctx context.Context, zoneID uuid.UUID, checksum string) (*domain.LandingFile, error)
+	// FindFileByPath returns the file registered for an S3 key in the zone,
+	// or nil, nil if there is none.
+	FindFileByPath(ctx context.Context, zoneID uuid.UUID, s3Path string) (*domain.LandingFile, error)
 	ListZonesWithAutoPurge(ctx context.Context) ([]domain.LandingZone, error)
+	// RestoreZone undeletes the most recently soft-deleted zone with this
+	// namespace/name. Returns nil, nil if there is none, and
+	// domain.ErrAlreadyExists if a live zone already uses the name.
+	RestoreZone(ctx context.Context, namespace, name string) (*domain.LandingZone, error)
+	ListSoftDeletedZones(ctx context.Context, olderThan time.Time) ([]domain.LandingZone, error)
+	HardDeleteZone(ctx context.Context, zoneID uuid.UUID) error
 }
 
 // LandingZoneFilter holds optional filters for listing landing zones.
@@ -63,6 +81,8 @@ type UpdateLandingZoneRequest struct {
 	Description    *string `json:"description,omitempty"`
 	Owner          *string `json:"owner,omitempty"`
 	ExpectedSchema *string `json:"expected_schema,omitempty"`
+	SchemaStrict   *bool   `json:"schema_strict,omitempty"`
+	Dedupe         *bool   `json:"dedupe,omitempty"`
 }
 
 // MountLandingZoneRoutes registers landing zone endpoints on the router.
@@ -72,11 +92,15 @@ func MountLandingZoneRoutes(r chi.Router, srv *Server) {
 	r.Get("/landing-zones/{namespace}/{name}", srv.HandleGetLandingZone)
 	r.Put("/landing-zones/{namespace}/{name}", srv.HandleUpdateLandingZone)
 	r.Delete("/landing-zones/{namespace}/{name}", srv.HandleDeleteLandingZone)
+	r.Post("/landing-zones/{namespace}/{name}/restore", srv.HandleRestoreLandingZone)
 	r.Get("/landing-zones/{namespace}/{name}/files", srv.HandleListLandingFiles)
 	r.Post("/landing-zones/{namespace}/{name}/files", srv.HandleUploadLandingFile)
+	r.Post("/landing-zones/{namespace}/{name}/files/presign", srv.HandlePresignLandingUpload)
+	r.Post("/landing-zones/{namespace}/{name}/files/complete", srv.HandleCompleteLandingUpload)
 	r.Get("/landing-zones/{namespace}/{name}/files/{fileID}", srv.HandleGetLandingFile)
 	r.Delete("/landing-zones/{namespace}/{name}/files/{fileID}", srv.HandleDeleteLandingFile)
 	r.Get("/landing-zones/{namespace}/{name}/samples", srv.HandleListLandingSamples)
+	r.Get("/landing-zones/{namespace}/{name}/samples/schema", srv.HandleInferLandingSampleSchema)
 	r.Post("/landing-zones/{namespace}/{name}/samples", srv.HandleUploadLandingSample)
 	r.Delete("/landing-zones/{namespace}/{name}/samples/{filename}", srv.HandleDeleteLandingSample)
 }
@@ -156,7 +180,8 @@ func (s *Server) HandleGetLandingZone(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, zone)
 }
 
-// HandleUpdateLandingZone updates a landing zone's description, owner, or expected schema.
+// HandleUpdateLandingZone updates a landing zone's description, owner,
+// expected schema, or upload policy (schema_strict, dedupe).
 func (s *Server) HandleUpdateLandingZone(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
 	name := chi.URLParam(r, "name")
@@ -167,6 +192,33 @@ func (s *Server) HandleUpdateLandingZone(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	current, err := s.LandingZones.GetZone(r.Context(), namespace, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if current == nil {
+		errorJSON(w, "landing zone not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	// A strict zone must keep a schema uploads can be checked against.
+	strict := current.SchemaStrict
+	if req.SchemaStrict != nil {
+		strict = *req.SchemaStrict
+	}
+	if strict {
+		schema := current.ExpectedSchema
+		if req.ExpectedSchema != nil {
+			schema = *req.ExpectedSchema
+		}
+		if _, err := parseExpectedSchema(schema); err != nil {
+			errorJSON(w, "schema_strict requires a valid expected_schema (e.g. \"id:int,name:varchar\"): "+err.Error(),
+				"INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+
 	zone, err := s.LandingZones.UpdateZone(r.Context(), namespace, name, req.Description, req.Owner, req.ExpectedSchema)
 	if err != nil {
 		internalError(w, "internal error", err)
@@ -177,10 +229,25 @@ func (s *Server) HandleUpdateLandingZone(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if req.SchemaStrict != nil || req.Dedupe != nil {
+		if err := s.LandingZones.UpdateZoneUploadPolicy(r.Context(), zone.ID, req.SchemaStrict, req.Dedupe); err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		if req.SchemaStrict != nil {
+			zone.SchemaStrict = *req.SchemaStrict
+		}
+		if req.Dedupe != nil {
+			zone.Dedupe = *req.Dedupe
+		}
+	}
+
 	writeJSON(w, http.StatusOK, zone)
 }
 
-// HandleDeleteLandingZone deletes a landing zone and its files from S3.
+// HandleDeleteLandingZone soft-deletes a landing zone. Its files stay in S3
+// until the reaper purges the zone after the soft-delete grace period, so the
+// zone can be brought back with HandleRestoreLandingZone.
 func (s *Server) HandleDeleteLandingZone(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
 	name := chi.URLParam(r, "name")
@@ -195,23 +262,6 @@ func (s *Server) HandleDeleteLandingZone(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Delete files from S3 if storage is available.
-	if s.Storage != nil {
-		files, err := s.LandingZones.ListFiles(r.Context(), zone.ID)
-		if err == nil {
-			for _, f := range files {
-				_ = s.Storage.DeleteFile(r.Context(), f.S3Path)
-			}
-		}
-		// Also clean up _samples/ files from S3.
-		samplesPrefix := namespace + "/landing/" + name + "/_samples/"
-		if sampleFiles, err := s.Storage.ListFiles(r.Context(), samplesPrefix); err == nil {
-			for _, sf := range sampleFiles {
-				_ = s.Storage.DeleteFile(r.Context(), sf.Path)
-			}
-		}
-	}
-
 	if err := s.LandingZones.DeleteZone(r.Context(), namespace, name); err != nil {
 		internalError(w, "internal error", err)
 		return
@@ -220,6 +270,29 @@ func (s *Server) HandleDeleteLandingZone(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// HandleRestoreLandingZone undoes a soft delete.
+// POST /api/v1/landing-zones/{namespace}/{name}/restore
+func (s *Server) HandleRestoreLandingZone(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	zone, err := s.LandingZones.RestoreZone(r.Context(), namespace, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			errorJSON(w, "a landing zone with this name already exists", "ALREADY_EXISTS", http.StatusConflict)
+			return
+		}
+		internalError(w, "internal error", err)
+		return
+	}
+	if zone == nil {
+		errorJSON(w, "no deleted landing zone to restore", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, zone)
+}
+
 // HandleListLandingFiles returns files in a landing zone with pagination support.
 func (s *Server) HandleListLandingFiles(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
@@ -285,17 +358,32 @@ func (s *Server) HandleUploadLandingFile(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Sanitize filename to prevent path traversal (e.g., "../../pipelines/victim/pipeline.py")
-	safeFilename := filepath.Base(header.Filename)
-	if safeFilename == "." || safeFilename == "/" || strings.ContainsAny(safeFilename, "\\/\x00") {
+	safeFilename, ok := landingObjectName(header.Filename, time.Now())
+	if !ok {
 		errorJSON(w, "invalid filename", "INVALID_ARGUMENT", http.StatusBadRequest)
 		return
 	}
 
-	// Prepend UTC timestamp to avoid filename collisions across uploads
-	safeFilename = time.Now().UTC().Format("20060102_150405_") + safeFilename
+	if !validateLandingUpload(w, zone, header.Filename, bytes.NewReader(content)) {
+		return
+	}
 
-	s3Path := namespace + "/landing/" + name + "/" + safeFilename
+	checksum := landingChecksum(content)
+	if zone.Dedupe {
+		existing, err := s.LandingZones.FindFileByChecksum(r.Context(), zone.ID, checksum)
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		if existing != nil {
+			// Same content is already waiting to be processed: keep the
+			// original and don't fire triggers a second time.
+			writeJSON(w, http.StatusOK, existing)
+			return
+		}
+	}
+
+	s3Path := landingZonePath(namespace, name, safeFilename)
 
 	if s.Storage != nil {
 		if _, err := s.Storage.WriteFile(r.Context(), s3Path, content); err != nil {
@@ -315,6 +403,7 @@ func (s *Server) HandleUploadLandingFile(w http.ResponseWriter, r *http.Request)
 		S3Path:      s3Path,
 		SizeBytes:   header.Size,
 		ContentType: contentType,
+		Checksum:    checksum,
 	}
 
 	if user := plugins.UserFromContext(r.Context()); user != nil {
@@ -326,24 +415,30 @@ func (s *Server) HandleUploadLandingFile(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Evaluate landing zone triggers in the background — never block the upload response.
-	// Use a detached context with a timeout rather than context.Background() to bound lifetime.
-	if s.Triggers != nil {
-		triggerCtx, triggerCancel := context.WithTimeout(context.Background(), 30*time.Second)
-		go func() {
-			defer triggerCancel()
-			defer func() {
-				if rec := recover(); rec != nil {
-					slog.Error("panic in landing zone trigger evaluation", "panic", rec)
-				}
-			}()
-			s.evaluateLandingZoneTriggers(triggerCtx, namespace, name, header.Filename)
-		}()
-	}
+	s.evaluateLandingZoneTriggersAsync(namespace, name, header.Filename)
 
 	writeJSON(w, http.StatusCreated, lf)
 }
 
+// evaluateLandingZoneTriggersAsync evaluates landing zone triggers in the
+// background — never block the upload response. Uses a detached context with
+// a timeout rather than context.Background() to bound lifetime.
+func (s *Server) evaluateLandingZoneTriggersAsync(namespace, zoneName, filename string) {
+	if s.Triggers == nil {
+		return
+	}
+	triggerCtx, triggerCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	go func() {
+		defer triggerCancel()
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.Error("panic in landing zone trigger evaluation", "panic", rec)
+			}
+		}()
+		s.evaluateLandingZoneTriggers(triggerCtx, namespace, zoneName, filename)
+	}()
+}
+
 // HandleGetLandingFile returns metadata for a single file.
 func (s *Server) HandleGetLandingFile(w http.ResponseWriter, r *http.Request) {
 	fileIDStr := chi.URLParam(r, "fileID")
@@ -431,6 +526,91 @@ func (s *Server) HandleListLandingSamples(w http.ResponseWriter, r *http.Request
 	})
 }
 
+// HandleInferLandingSampleSchema returns the inferred columns of a landing
+// zone's sample files, so a bronze pipeline can be written against them. The
+// runner infers the schema when the executor supports it; otherwise ratd
+// parses the first CSV or Parquet sample itself (see inferSampleColumns).
+func (s *Server) HandleInferLandingSampleSchema(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	zone, err := s.LandingZones.GetZone(r.Context(), namespace, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if zone == nil {
+		errorJSON(w, "landing zone not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if s.Storage == nil {
+		errorJSON(w, "storage not available", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	files, err := s.Storage.ListFiles(r.Context(), namespace+"/landing/"+name+"/_samples/")
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	sort.Strings(paths)
+	if len(paths) == 0 {
+		errorJSON(w, "landing zone has no sample files", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	if inferrer, ok := s.Executor.(SampleSchemaInferrer); ok {
+		cols, err := inferrer.InferSampleSchema(r.Context(), paths)
+		switch {
+		case err == nil:
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"columns": cols,
+				"source":  "runner",
+				"files":   paths,
+			})
+			return
+		case errors.Is(err, ErrInvalidSamples):
+			errorJSON(w, err.Error(), "INVALID_ARGUMENT", http.StatusUnprocessableEntity)
+			return
+		case !errors.Is(err, ErrSchemaInferenceUnsupported):
+			slog.Error("sample schema inference failed", "zone", namespace+"/"+name, "error", err)
+			errorJSON(w, "schema inference failed", "INTERNAL", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// Best effort: the first sample ratd can read.
+	for _, path := range paths {
+		if !isTabularSample(path) {
+			continue
+		}
+		fc, err := s.Storage.ReadFile(r.Context(), path)
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		if fc == nil {
+			continue // deleted between list and read
+		}
+		cols, err := inferSampleColumns(path, []byte(fc.Content))
+		if err != nil {
+			errorJSON(w, path+": "+err.Error(), "INVALID_ARGUMENT", http.StatusUnprocessableEntity)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"columns": cols,
+			"source":  "server",
+			"files":   []string{path},
+		})
+		return
+	}
+	errorJSON(w, "no CSV or Parquet sample files to infer a schema from", "INVALID_ARGUMENT", http.StatusUnprocessableEntity)
+}
+
 // HandleUploadLandingSample handles multipart file upload to a landing zone's _samples/ folder.
 func (s *Server) HandleUploadLandingSample(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
@@ -2,11 +2,14 @@ package api_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
@@ -200,6 +203,90 @@ func TestDeleteLandingZone_Exists_Returns204(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 }
 
+func TestDeleteLandingZone_SoftDeletes_KeepsFiles(t *testing.T) {
+	srv, store := newLandingTestServer()
+	zoneID := uuid.New()
+	store.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: zoneID, Namespace: "default", Name: "uploads"}},
+	}
+	store.files = []domain.LandingFile{{ID: uuid.New(), ZoneID: zoneID, S3Path: "default/landing/uploads/a.csv"}}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/landing-zones/default/uploads", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/landing-zones/default/uploads", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	require.Len(t, store.deleted, 1)
+	assert.NotNil(t, store.deleted[0].DeletedAt)
+	assert.Len(t, store.files, 1, "files are kept until the reaper purges the zone")
+}
+
+// --- Restore Zone ---
+
+func TestRestoreLandingZone_AfterDelete_Returns200(t *testing.T) {
+	srv, store := newLandingTestServer()
+	zoneID := uuid.New()
+	store.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: zoneID, Namespace: "default", Name: "uploads"}},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/landing-zones/default/uploads", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/landing-zones/default/uploads/restore", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var zone domain.LandingZone
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&zone))
+	assert.Equal(t, zoneID, zone.ID)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/landing-zones/default/uploads", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRestoreLandingZone_NothingDeleted_Returns404(t *testing.T) {
+	srv, _ := newLandingTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/landing-zones/default/uploads/restore", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestRestoreLandingZone_NameTaken_Returns409(t *testing.T) {
+	srv, store := newLandingTestServer()
+	deletedAt := time.Now()
+	store.zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "uploads"}},
+	}
+	store.deleted = []domain.LandingZone{
+		{ID: uuid.New(), Namespace: "default", Name: "uploads", DeletedAt: &deletedAt},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/landing-zones/default/uploads/restore", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Len(t, store.deleted, 1)
+}
+
 // --- List Files ---
 
 func TestListLandingFiles_Empty_ReturnsEmptyList(t *testing.T) {
@@ -437,3 +524,62 @@ func TestDeleteLandingSample_InvalidFilename_Returns400(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+// --- Dedupe ---
+
+// lookupCountingTriggerStore counts landing zone trigger evaluations, i.e.
+// how many uploads went on to fire triggers.
+type lookupCountingTriggerStore struct {
+	*memoryTriggerStore
+	lookups atomic.Int32
+}
+
+func (c *lookupCountingTriggerStore) FindTriggersByLandingZone(ctx context.Context, namespace, zoneName string) ([]domain.PipelineTrigger, error) {
+	c.lookups.Add(1)
+	return c.memoryTriggerStore.FindTriggersByLandingZone(ctx, namespace, zoneName)
+}
+
+func newDedupeTestServer(dedupe bool) (*api.Server, *memoryLandingZoneStore, *lookupCountingTriggerStore) {
+	srv, store := newLandingTestServer()
+	store.zones = []api.LandingZoneListItem{{LandingZone: domain.LandingZone{
+		ID: uuid.New(), Namespace: "default", Name: "orders", Dedupe: dedupe,
+	}}}
+	triggers := &lookupCountingTriggerStore{memoryTriggerStore: newMemoryTriggerStore()}
+	srv.Triggers = triggers
+	return srv, store, triggers
+}
+
+func TestUploadLandingFile_DedupeOn_DuplicateReturnsExistingWithoutTriggers(t *testing.T) {
+	srv, store, triggers := newDedupeTestServer(true)
+	content := []byte("id,total\n1,9.99\n")
+
+	first := uploadLandingFile(t, srv, "orders.csv", content)
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+	var original domain.LandingFile
+	require.NoError(t, json.NewDecoder(first.Body).Decode(&original))
+	assert.Equal(t, "0e96f5383bd9ccbee267bb4d0491c055dd5f80cdd7ea3813d247e891ecc119f1", original.Checksum) // sha256sum of the content
+	require.Eventually(t, func() bool { return triggers.lookups.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	second := uploadLandingFile(t, srv, "orders-again.csv", content)
+	require.Equal(t, http.StatusOK, second.Code, second.Body.String())
+	var dup domain.LandingFile
+	require.NoError(t, json.NewDecoder(second.Body).Decode(&dup))
+	assert.Equal(t, original.ID, dup.ID)
+
+	assert.Len(t, store.files, 1)
+	assert.Never(t, func() bool { return triggers.lookups.Load() > 1 }, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestUploadLandingFile_DedupeOff_DuplicateStoredAndTriggered(t *testing.T) {
+	srv, store, triggers := newDedupeTestServer(false)
+	content := []byte("id,total\n1,9.99\n")
+
+	for range 2 {
+		rec := uploadLandingFile(t, srv, "orders.csv", content)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	assert.Len(t, store.files, 2)
+	assert.Equal(t, store.files[0].Checksum, store.files[1].Checksum)
+	require.Eventually(t, func() bool { return triggers.lookups.Load() == 2 }, time.Second, 5*time.Millisecond)
+}
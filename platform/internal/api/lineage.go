@@ -0,0 +1,126 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// defaultLineageDepth and maxLineageDepth bound how many trigger hops the
+// pipeline lineage endpoint walks in each direction.
+const (
+	defaultLineageDepth = 1
+	maxLineageDepth     = 10
+)
+
+// TriggerLineageNode is a pipeline reached through pipeline_success triggers,
+// with its hop distance from the requested pipeline.
+type TriggerLineageNode struct {
+	Namespace string `json:"namespace"`
+	Layer     string `json:"layer"`
+	Name      string `json:"name"`
+	Depth     int    `json:"depth"`
+}
+
+// TriggerLineageEdge is one pipeline_success trigger walked: a successful run
+// of Source fires Target. Both are "namespace/layer/name" keys.
+type TriggerLineageEdge struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	TriggerID string `json:"trigger_id"`
+}
+
+// MountLineageRoutes registers the operational (trigger-derived) lineage
+// endpoints. Data lineage — ref() and landing_zone() dependencies — lives in
+// rat-plugin-lineage under /api/v1/x/lineage.
+func MountLineageRoutes(r chi.Router, srv *Server) {
+	r.Get("/lineage/pipelines/{namespace}/{layer}/{name}", srv.HandleGetPipelineTriggerLineage)
+}
+
+// HandleGetPipelineTriggerLineage returns the pipelines upstream (whose
+// success fires this one) and downstream (fired by this one's success) of a
+// pipeline, following pipeline_success triggers up to ?depth= hops.
+func (s *Server) HandleGetPipelineTriggerLineage(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	depth := defaultLineageDepth
+	if v := r.URL.Query().Get("depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			errorJSON(w, "depth must be a positive integer", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		depth = min(n, maxLineageDepth)
+	}
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if !s.requireAccess(w, r, "pipeline", pipeline.ID.String(), "read") {
+		return
+	}
+
+	graph, err := BuildTriggerGraph(r.Context(), s.Triggers)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	key := pipelineGraphKey(pipeline.Namespace, string(pipeline.Layer), pipeline.Name)
+	upstream, upEdges := graph.Reachable(key, depth, true)
+	downstream, downEdges := graph.Reachable(key, depth, false)
+
+	edges := []TriggerLineageEdge{}
+	seen := map[string]bool{}
+	for _, e := range append(upEdges, downEdges...) {
+		id := e.TriggerID.String()
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		edges = append(edges, TriggerLineageEdge{Source: e.From, Target: e.To, TriggerID: id})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"pipeline":   key,
+		"depth":      depth,
+		"upstream":   lineageNodes(upstream),
+		"downstream": lineageNodes(downstream),
+		"edges":      edges,
+	})
+}
+
+// lineageNodes turns a key→distance map into nodes sorted by distance, then key.
+func lineageNodes(dist map[string]int) []TriggerLineageNode {
+	keys := make([]string, 0, len(dist))
+	for k := range dist {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if dist[keys[i]] != dist[keys[j]] {
+			return dist[keys[i]] < dist[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	nodes := make([]TriggerLineageNode, 0, len(keys))
+	for _, k := range keys {
+		parts := strings.SplitN(k, "/", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		nodes = append(nodes, TriggerLineageNode{Namespace: parts[0], Layer: parts[1], Name: parts[2], Depth: dist[k]})
+	}
+	return nodes
+}
@@ -0,0 +1,75 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type triggerLineageResponse struct {
+	Pipeline   string                   `json:"pipeline"`
+	Depth      int                      `json:"depth"`
+	Upstream   []api.TriggerLineageNode `json:"upstream"`
+	Downstream []api.TriggerLineageNode `json:"downstream"`
+	Edges      []api.TriggerLineageEdge `json:"edges"`
+}
+
+func getTriggerLineage(t *testing.T, router http.Handler, path string) (int, triggerLineageResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/lineage/pipelines/"+path, http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp triggerLineageResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	}
+	return rec.Code, resp
+}
+
+func TestGetPipelineTriggerLineage_Chain(t *testing.T) {
+	// a -> b -> c -> d
+	srv, _ := newTriggerGraphTestServer()
+	router := api.NewRouter(srv)
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "a", "b").Code)
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "b", "c").Code)
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "c", "d").Code)
+
+	code, resp := getTriggerLineage(t, router, "default/bronze/b")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "default/bronze/b", resp.Pipeline)
+	assert.Equal(t, 1, resp.Depth)
+	assert.Equal(t, []api.TriggerLineageNode{{Namespace: "default", Layer: "bronze", Name: "a", Depth: 1}}, resp.Upstream)
+	assert.Equal(t, []api.TriggerLineageNode{{Namespace: "default", Layer: "bronze", Name: "c", Depth: 1}}, resp.Downstream)
+	assert.Len(t, resp.Edges, 2)
+
+	code, resp = getTriggerLineage(t, router, "default/bronze/b?depth=2")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []api.TriggerLineageNode{
+		{Namespace: "default", Layer: "bronze", Name: "c", Depth: 1},
+		{Namespace: "default", Layer: "bronze", Name: "d", Depth: 2},
+	}, resp.Downstream)
+	assert.Len(t, resp.Upstream, 1)
+	assert.Len(t, resp.Edges, 3)
+
+	code, resp = getTriggerLineage(t, router, "default/bronze/a?depth=10")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, resp.Upstream)
+	assert.Len(t, resp.Downstream, 3)
+}
+
+func TestGetPipelineTriggerLineage_Errors(t *testing.T) {
+	srv, _ := newTriggerGraphTestServer()
+	router := api.NewRouter(srv)
+
+	code, _ := getTriggerLineage(t, router, "default/bronze/missing")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _ = getTriggerLineage(t, router, "default/bronze/a?depth=0")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -4,6 +4,8 @@ import (
 	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // responseWriter wraps http.ResponseWriter to capture the status code and
@@ -41,6 +43,14 @@ func (rw *responseWriter) Unwrap() http.ResponseWriter {
 	return rw.ResponseWriter
 }
 
+// Flush forwards to the underlying writer so SSE handlers' w.(http.Flusher)
+// checks see through the logging wrapper.
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
 // healthPaths contains the paths to skip logging for — they are called
 // frequently by orchestrators and produce excessive noise.
 var healthPaths = map[string]bool{
@@ -50,8 +60,11 @@ var healthPaths = map[string]bool{
 
 // RequestLogger is middleware that logs every HTTP request with structured slog output.
 //
-// For each request it logs: method, path, status code, duration, request size (Content-Length),
-// and response size. The log level depends on the response status code:
+// For each request it logs: method, path, the matched chi route pattern
+// ("route", e.g. "/api/v1/pipelines/{namespace}/{layer}/{name}"; empty when
+// nothing matched), status code, duration, latency in milliseconds, request
+// size (Content-Length), and response size. Aggregate by route rather than
+// path — paths carry IDs and names. The log level depends on the response status code:
 //   - 2xx/3xx: slog.Info
 //   - 4xx:     slog.Warn
 //   - 5xx:     slog.Error
@@ -86,11 +99,19 @@ func RequestLogger(next http.Handler) http.Handler {
 		// Build structured log attributes. request_id is intentionally omitted
 		// here — see the package doc comment above; ContextHandler adds it
 		// automatically from the request context.
+		// chi fills the route context in place while routing, so after
+		// next returns it holds the full pattern of the matched route.
+		route := ""
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
 		attrs := []slog.Attr{
 			slog.String("method", r.Method),
 			slog.String("path", r.URL.Path),
+			slog.String("route", route),
 			slog.Int("status", wrapped.status),
 			slog.String("duration", duration.String()),
+			slog.Float64("latency_ms", float64(duration.Microseconds())/1000),
 			slog.Int64("request_size", r.ContentLength),
 			slog.Int("response_size", wrapped.bytesWritten),
 		}
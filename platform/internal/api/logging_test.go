@@ -307,3 +307,33 @@ func TestRequestLogger_IntegrationWithRouter_HealthSkipped(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	assert.Empty(t, output, "health endpoint should not produce log output through the router")
 }
+
+func TestRequestLogger_IntegrationWithRouter_LogsRouteStatusLatencyAndRequestID(t *testing.T) {
+	srv := fullTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/missing", http.NoBody)
+	req.Header.Set("X-Request-ID", "corr-42")
+	rec := httptest.NewRecorder()
+
+	output := captureLogs(t, func() {
+		router.ServeHTTP(rec, req)
+	})
+
+	assert.Equal(t, "corr-42", rec.Header().Get("X-Request-ID"))
+	var access map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var obj map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &obj))
+		if obj["msg"] == "request completed" {
+			access = obj
+		}
+	}
+	require.NotNil(t, access, "expected an access log record in: %s", output)
+	assert.Equal(t, "corr-42", access["request_id"])
+	assert.Equal(t, "/api/v1/pipelines/{namespace}/{layer}/{name}", access["route"])
+	assert.Equal(t, "/api/v1/pipelines/default/bronze/missing", access["path"])
+	assert.Equal(t, float64(rec.Code), access["status"])
+	require.Contains(t, access, "latency_ms")
+	assert.GreaterOrEqual(t, access["latency_ms"], 0.0)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MaintenanceModeKey is the platform_settings key holding the maintenance
+// mode flag (a JSON bool). While it is true the scheduler and the trigger
+// evaluator skip their ticks, freezing scheduled work without a restart.
+const MaintenanceModeKey = "maintenance_mode"
+
+// MaintenanceModeRequest is the body of PUT /settings/maintenance and the
+// response of both maintenance endpoints.
+type MaintenanceModeRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// MaintenanceModeEnabled reports whether maintenance mode is on. A setting
+// that has never been written means off.
+func MaintenanceModeEnabled(ctx context.Context, settings SettingsStore) (bool, error) {
+	return boolSetting(ctx, settings, MaintenanceModeKey)
+}
+
+// boolSetting reads a JSON bool platform setting. A setting that has never
+// been written means false.
+func boolSetting(ctx context.Context, settings SettingsStore, key string) (bool, error) {
+	raw, err := settings.GetSetting(ctx, key)
+	if errors.Is(err, ErrSettingNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	var enabled bool
+	if err := json.Unmarshal(raw, &enabled); err != nil {
+		return false, err
+	}
+	return enabled, nil
+}
+
+// MountMaintenanceRoutes registers the maintenance mode toggle.
+func MountMaintenanceRoutes(r chi.Router, srv *Server) {
+	r.Get("/settings/maintenance", srv.HandleGetMaintenanceMode)
+	r.Put("/settings/maintenance", srv.HandlePutMaintenanceMode)
+}
+
+// HandleGetMaintenanceMode returns whether scheduling is paused.
+func (s *Server) HandleGetMaintenanceMode(w http.ResponseWriter, r *http.Request) {
+	if s.Settings == nil {
+		errorJSON(w, "settings not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	enabled, err := MaintenanceModeEnabled(r.Context(), s.Settings)
+	if err != nil {
+		internalError(w, "failed to load maintenance mode", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, MaintenanceModeRequest{Enabled: enabled})
+}
+
+// HandlePutMaintenanceMode turns maintenance mode on or off. Admin-only: it
+// pauses every schedule and cron trigger platform-wide. Takes effect on the
+// next scheduler and evaluator tick.
+func (s *Server) HandlePutMaintenanceMode(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if s.Settings == nil {
+		errorJSON(w, "settings not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req MaintenanceModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid JSON body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(req.Enabled)
+	if err != nil {
+		internalError(w, "failed to marshal maintenance mode", err)
+		return
+	}
+	if err := s.Settings.PutSetting(r.Context(), MaintenanceModeKey, data); err != nil {
+		internalError(w, "failed to save maintenance mode", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, req)
+}
@@ -0,0 +1,68 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceMode_DefaultsOff(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/settings/maintenance", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"enabled": false}`, rec.Body.String())
+}
+
+func TestMaintenanceMode_PutEnables(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/maintenance", bytes.NewBufferString(`{"enabled": true}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"enabled": true}`, rec.Body.String())
+	assert.JSONEq(t, `true`, string(settings.settings[api.MaintenanceModeKey]))
+
+	on, err := api.MaintenanceModeEnabled(context.Background(), settings)
+	require.NoError(t, err)
+	assert.True(t, on)
+}
+
+func TestMaintenanceMode_PutNonAdmin_Returns403(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/maintenance", bytes.NewBufferString(`{"enabled": true}`))
+	req = req.WithContext(plugins.ContextWithUser(req.Context(), &domain.UserIdentity{UserID: "bob"}))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.NotContains(t, settings.settings, api.MaintenanceModeKey)
+}
+
+func TestMaintenanceMode_PutInvalidBody_Returns400(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/maintenance", bytes.NewBufferString(`{"enabled": "yes"}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
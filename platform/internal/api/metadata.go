@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/domain"
 )
 
 // MountMetadataRoutes registers metadata endpoints on the router.
@@ -26,7 +28,12 @@ func (s *Server) HandleGetPipelineMeta(w http.ResponseWriter, r *http.Request) {
 	layer := chi.URLParam(r, "layer")
 	name := chi.URLParam(r, "name")
 
-	path := namespace + "/pipelines/" + layer + "/" + name + "/pipeline.meta.yaml"
+	prefix, err := s.pipelineStoragePrefix(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	path := prefix + "pipeline.meta.yaml"
 	file, err := s.Storage.ReadFile(r.Context(), path)
 	if err != nil {
 		internalError(w, "internal error", err)
@@ -49,7 +56,12 @@ func (s *Server) HandleGetQualityMeta(w http.ResponseWriter, r *http.Request) {
 	layer := chi.URLParam(r, "layer")
 	name := chi.URLParam(r, "name")
 
-	path := namespace + "/pipelines/" + layer + "/" + name + "/tests/quality.meta.yaml"
+	prefix, err := s.pipelineStoragePrefix(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	path := prefix + "tests/quality.meta.yaml"
 	file, err := s.Storage.ReadFile(r.Context(), path)
 	if err != nil {
 		internalError(w, "internal error", err)
@@ -65,3 +77,17 @@ func (s *Server) HandleGetQualityMeta(w http.ResponseWriter, r *http.Request) {
 		"content": file.Content,
 	})
 }
+
+// pipelineStoragePrefix returns the storage prefix of the named pipeline —
+// its custom s3_prefix if it has one — or the default layout when the
+// pipeline isn't registered.
+func (s *Server) pipelineStoragePrefix(ctx context.Context, namespace, layer, name string) (string, error) {
+	p, err := s.Pipelines.GetPipeline(ctx, namespace, layer, name)
+	if err != nil {
+		return "", err
+	}
+	if p == nil {
+		return domain.PipelineS3Path(namespace, layer, name), nil
+	}
+	return p.StoragePrefix(), nil
+}
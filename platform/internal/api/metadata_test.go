@@ -6,7 +6,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,6 +47,25 @@ func TestGetPipelineMeta_Exists_ReturnsContent(t *testing.T) {
 	assert.Contains(t, body["content"], "run_id: abc123")
 }
 
+func TestGetPipelineMeta_CustomS3Prefix_ReadsFromPrefix(t *testing.T) {
+	srv, store := newMetaTestServer()
+	pipelines := srv.Pipelines.(*memoryPipelineStore)
+	pipelines.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", S3Path: "default/shared/orders/"},
+	}
+	store.files["default/shared/orders/pipeline.meta.yaml"] = []byte("owner: analytics")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/metadata", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "default/shared/orders/pipeline.meta.yaml", body["path"])
+	assert.Equal(t, "owner: analytics", body["content"])
+}
+
 func TestGetPipelineMeta_NotFound_Returns404(t *testing.T) {
 	srv, _ := newMetaTestServer()
 	router := api.NewRouter(srv)
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// Namespace rename failures. RenameNamespace returns exactly one of these
+// (possibly wrapped) for a precondition that fails inside its transaction.
+var (
+	ErrNamespaceNotFound = errors.New("namespace not found")
+	ErrNamespaceExists   = errors.New("namespace already exists")
+)
+
+// NamespaceRenamer is an optional NamespaceStore extension that renames a
+// namespace together with every row that refers to it by name: pipelines,
+// landing zones and their files, table metadata, run log paths, trigger
+// configs and the namespace's settings. Schedules, runs and quality tests
+// hang off pipeline IDs and follow automatically.
+//
+// All updates happen in one transaction. publishedVersions replaces the
+// published_versions of the listed pipelines (pipeline ID → file path →
+// S3 version ID), since moving files to the new prefix gives them new
+// version IDs. history does the same for version history snapshots: each
+// entry replaces the published_versions of the pipeline_versions row with
+// its PipelineID and VersionNumber. Returns ErrNamespaceNotFound or
+// ErrNamespaceExists when oldName is missing or newName is taken.
+type NamespaceRenamer interface {
+	RenameNamespace(ctx context.Context, oldName, newName string, publishedVersions map[uuid.UUID]map[string]string, history []domain.PipelineVersion) error
+}
+
+// RenameNamespaceRequest is the JSON body for POST /api/v1/namespaces/{name}/rename.
+type RenameNamespaceRequest struct {
+	Name string `json:"name"`
+}
+
+// HandleRenameNamespace renames a namespace and moves its S3 prefix.
+//
+// S3 can't take part in the database transaction, so the move is ordered to
+// never leave rows pointing at missing files: objects are copied to the new
+// prefix first (file versions pinned by version history and publishes before
+// the latest), then the rename commits, then the old objects are deleted. A
+// failed copy or rename removes the copies and leaves everything as it was;
+// a failed delete only leaves stale objects behind under the old prefix.
+func (s *Server) HandleRenameNamespace(w http.ResponseWriter, r *http.Request) {
+	oldName := chi.URLParam(r, "name")
+
+	renamer, ok := s.Namespaces.(NamespaceRenamer)
+	if !ok {
+		errorJSON(w, "namespace rename not supported", "UNIMPLEMENTED", http.StatusNotImplemented)
+		return
+	}
+
+	var req RenameNamespaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if !validName(req.Name) {
+		errorJSON(w, "name must be a lowercase slug (a-z, 0-9, hyphens, underscores; must start with a letter)", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if oldName == "default" {
+		errorJSON(w, "cannot rename the default namespace", "FORBIDDEN", http.StatusForbidden)
+		return
+	}
+	if req.Name == oldName {
+		errorJSON(w, "new name must differ from the current name", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	// Renaming removes the old namespace, so it needs the same access as
+	// deleting it.
+	if !s.requireAccess(w, r, "namespace", oldName, "delete") {
+		return
+	}
+
+	namespaces, err := s.Namespaces.ListNamespaces(r.Context())
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	var oldFound, newTaken bool
+	for _, ns := range namespaces {
+		oldFound = oldFound || ns.Name == oldName
+		newTaken = newTaken || ns.Name == req.Name
+	}
+	if !oldFound {
+		errorJSON(w, "namespace not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if newTaken {
+		errorJSON(w, fmt.Sprintf("namespace %q already exists", req.Name), "ALREADY_EXISTS", http.StatusConflict)
+		return
+	}
+
+	pipelines, err := s.Pipelines.ListPipelines(r.Context(), PipelineFilter{Namespace: oldName})
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	oldPrefix, newPrefix := oldName+"/", req.Name+"/"
+	moved := func(path string) string { return newPrefix + strings.TrimPrefix(path, oldPrefix) }
+
+	var files []FileInfo
+	if s.Storage != nil {
+		files, err = s.Storage.ListFiles(r.Context(), oldPrefix)
+		if err != nil {
+			internalError(w, "failed to list namespace files", err)
+			return
+		}
+	}
+
+	// Cleanup must finish even if the client goes away mid-rename, or the
+	// copies (or originals) are left behind.
+	cleanupCtx := context.WithoutCancel(r.Context())
+	var copied []string
+	removeCopies := func() {
+		for _, path := range copied {
+			if err := s.Storage.DeleteFile(cleanupCtx, path); err != nil {
+				slog.Warn("namespace rename: failed to remove copied file", "path", path, "error", err)
+			}
+		}
+	}
+
+	// movedVersions copies every pinned file version in a snapshot to the
+	// new prefix and returns the snapshot re-pinned to the copies. A version
+	// shared by several snapshots is copied once.
+	versionCopies := make(map[string]string) // old path + "@" + version ID → new version ID
+	movedVersions := func(snapshot map[string]string) (map[string]string, error) {
+		versions := make(map[string]string, len(snapshot))
+		for path, versionID := range snapshot {
+			if !strings.HasPrefix(path, oldPrefix) || s.Storage == nil {
+				versions[path] = versionID
+				continue
+			}
+			key := path + "@" + versionID
+			newVersionID, ok := versionCopies[key]
+			if !ok {
+				var err error
+				newVersionID, err = s.Storage.CopyFileVersion(r.Context(), path, versionID, moved(path))
+				if err != nil {
+					return nil, err
+				}
+				copied = append(copied, moved(path))
+				versionCopies[key] = newVersionID
+			}
+			versions[moved(path)] = newVersionID
+		}
+		return versions, nil
+	}
+
+	// Version history is copied oldest first, then the published versions,
+	// so the latest copy below stays the head at the new path.
+	var history []domain.PipelineVersion
+	if s.Versions != nil {
+		for _, p := range pipelines {
+			versions, err := s.Versions.ListVersions(r.Context(), p.ID)
+			if err != nil {
+				removeCopies()
+				internalError(w, "internal error", err)
+				return
+			}
+			for i := len(versions) - 1; i >= 0; i-- {
+				v := versions[i]
+				v.PublishedVersions, err = movedVersions(v.PublishedVersions)
+				if err != nil {
+					removeCopies()
+					internalError(w, "failed to copy version history file", err)
+					return
+				}
+				history = append(history, v)
+			}
+		}
+	}
+
+	published := make(map[uuid.UUID]map[string]string)
+	for _, p := range pipelines {
+		if len(p.PublishedVersions) == 0 {
+			continue
+		}
+		versions, err := movedVersions(p.PublishedVersions)
+		if err != nil {
+			removeCopies()
+			internalError(w, "failed to copy published file", err)
+			return
+		}
+		published[p.ID] = versions
+	}
+	for _, f := range files {
+		if _, err := s.Storage.CopyFile(r.Context(), f.Path, moved(f.Path)); err != nil {
+			removeCopies()
+			internalError(w, "failed to copy namespace file", err)
+			return
+		}
+		copied = append(copied, moved(f.Path))
+	}
+
+	if err := renamer.RenameNamespace(r.Context(), oldName, req.Name, published, history); err != nil {
+		removeCopies()
+		switch {
+		case errors.Is(err, ErrNamespaceNotFound):
+			errorJSON(w, "namespace not found", "NOT_FOUND", http.StatusNotFound)
+		case errors.Is(err, ErrNamespaceExists):
+			errorJSON(w, fmt.Sprintf("namespace %q already exists", req.Name), "ALREADY_EXISTS", http.StatusConflict)
+		default:
+			internalError(w, "failed to rename namespace", err)
+		}
+		return
+	}
+
+	for _, f := range files {
+		if err := s.Storage.DeleteFile(cleanupCtx, f.Path); err != nil {
+			slog.Warn("namespace rename: failed to delete old file", "path", f.Path, "error", err)
+		}
+	}
+
+	// Cached entries are keyed by namespace name.
+	if s.NamespaceCache != nil {
+		s.NamespaceCache.Clear()
+	}
+	if s.PipelineCache != nil {
+		s.PipelineCache.Clear()
+	}
+	if s.PreviewCache != nil {
+		s.PreviewCache.Clear()
+	}
+
+	slog.Info("namespace renamed", "from", oldName, "to", req.Name,
+		"pipelines", len(pipelines), "files", len(files))
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":          req.Name,
+		"previous_name": oldName,
+		"pipelines":     len(pipelines),
+		"files_moved":   len(files),
+	})
+}
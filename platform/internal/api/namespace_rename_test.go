@@ -0,0 +1,186 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// renamingNamespaceStore adds api.NamespaceRenamer to memoryNamespaceStore,
+// cascading the rename to the in-memory pipelines like the Postgres store
+// does to every dependent table.
+type renamingNamespaceStore struct {
+	*memoryNamespaceStore
+	pipelines *memoryPipelineStore
+	published map[uuid.UUID]map[string]string
+	history   []domain.PipelineVersion
+	err       error // returned instead of renaming, when set
+}
+
+func (m *renamingNamespaceStore) RenameNamespace(_ context.Context, oldName, newName string, publishedVersions map[uuid.UUID]map[string]string, history []domain.PipelineVersion) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	found := -1
+	for i, ns := range m.namespaces {
+		if ns.Name == newName {
+			return fmt.Errorf("rename: %w", api.ErrNamespaceExists)
+		}
+		if ns.Name == oldName {
+			found = i
+		}
+	}
+	if found < 0 {
+		return fmt.Errorf("rename: %w", api.ErrNamespaceNotFound)
+	}
+	m.namespaces[found].Name = newName
+
+	m.pipelines.mu.Lock()
+	defer m.pipelines.mu.Unlock()
+	for i := range m.pipelines.pipelines {
+		if m.pipelines.pipelines[i].Namespace == oldName {
+			m.pipelines.pipelines[i].Namespace = newName
+		}
+	}
+	m.published = publishedVersions
+	m.history = history
+	return nil
+}
+
+func newRenameTestServer() (*api.Server, *renamingNamespaceStore, *memoryStorageStore) {
+	srv, nsStore := newNsTestServer()
+	nsStore.namespaces = append(nsStore.namespaces, domain.Namespace{Name: "sales"}, domain.Namespace{Name: "finance"})
+	renamer := &renamingNamespaceStore{memoryNamespaceStore: nsStore, pipelines: srv.Pipelines.(*memoryPipelineStore)}
+	srv.Namespaces = renamer
+
+	orders := domain.Pipeline{
+		ID: uuid.New(), Namespace: "sales", Layer: domain.LayerBronze, Name: "orders",
+		PublishedVersions: map[string]string{"sales/pipelines/bronze/orders/pipeline.sql": "v1"},
+	}
+	renamer.pipelines.pipelines = []domain.Pipeline{orders}
+
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.files["sales/pipelines/bronze/orders/pipeline.sql"] = []byte("SELECT 1")
+	storage.files["sales/landing/uploads/data.csv"] = []byte("a,b\n1,2\n")
+	storage.files["finance/pipelines/bronze/ledger/pipeline.sql"] = []byte("SELECT 2")
+	return srv, renamer, storage
+}
+
+func postRename(router http.Handler, from, to string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/"+from+"/rename",
+		bytes.NewBufferString(`{"name":"`+to+`"}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRenameNamespace_CascadesAndMovesFiles(t *testing.T) {
+	srv, renamer, storage := newRenameTestServer()
+	router := api.NewRouter(srv)
+
+	rec := postRename(router, "sales", "revenue")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"name":"revenue","previous_name":"sales","pipelines":1,"files_moved":2}`, rec.Body.String())
+
+	names := make([]string, 0, len(renamer.namespaces))
+	for _, ns := range renamer.namespaces {
+		names = append(names, ns.Name)
+	}
+	assert.ElementsMatch(t, []string{"default", "revenue", "finance"}, names)
+	assert.Equal(t, "revenue", renamer.pipelines.pipelines[0].Namespace)
+
+	// Files moved under the new prefix; other namespaces untouched.
+	assert.Contains(t, storage.files, "revenue/pipelines/bronze/orders/pipeline.sql")
+	assert.Contains(t, storage.files, "revenue/landing/uploads/data.csv")
+	assert.NotContains(t, storage.files, "sales/pipelines/bronze/orders/pipeline.sql")
+	assert.NotContains(t, storage.files, "sales/landing/uploads/data.csv")
+	assert.Contains(t, storage.files, "finance/pipelines/bronze/ledger/pipeline.sql")
+
+	// Published versions are re-pinned to the copies.
+	pipelineID := renamer.pipelines.pipelines[0].ID
+	assert.Equal(t, map[string]string{"revenue/pipelines/bronze/orders/pipeline.sql": "mock-version-id"},
+		renamer.published[pipelineID])
+}
+
+func TestRenameNamespace_RepinsVersionHistory(t *testing.T) {
+	srv, renamer, storage := newRenameTestServer()
+	pipelineID := renamer.pipelines.pipelines[0].ID
+	versions := newMemoryVersionStore()
+	versions.versions = []domain.PipelineVersion{
+		{PipelineID: pipelineID, VersionNumber: 2, PublishedVersions: map[string]string{"sales/pipelines/bronze/orders/pipeline.sql": "v1"}},
+		{PipelineID: pipelineID, VersionNumber: 1, PublishedVersions: map[string]string{"sales/pipelines/bronze/orders/pipeline.sql": "v0"}},
+	}
+	srv.Versions = versions
+	storage.versions = map[string][]byte{"sales/pipelines/bronze/orders/pipeline.sql@v0": []byte("SELECT 0")}
+	router := api.NewRouter(srv)
+
+	rec := postRename(router, "sales", "revenue")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	require.Len(t, renamer.history, 2)
+	for _, v := range renamer.history {
+		assert.Equal(t, map[string]string{"revenue/pipelines/bronze/orders/pipeline.sql": "mock-version-id"}, v.PublishedVersions,
+			"version %d must point at the moved copy", v.VersionNumber)
+	}
+	// v0 and v1 once each (v1 is shared with the published pin), then the
+	// two latest files.
+	assert.Equal(t, 4, storage.copies)
+	assert.Equal(t, "SELECT 1", string(storage.files["revenue/pipelines/bronze/orders/pipeline.sql"]), "latest copy stays the head")
+}
+
+func TestRenameNamespace_TargetExists_Returns409(t *testing.T) {
+	srv, _, storage := newRenameTestServer()
+	router := api.NewRouter(srv)
+
+	rec := postRename(router, "sales", "finance")
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, 0, storage.copies, "nothing may be copied when the target exists")
+	assert.Contains(t, storage.files, "sales/pipelines/bronze/orders/pipeline.sql")
+}
+
+func TestRenameNamespace_StoreFailure_RemovesCopies(t *testing.T) {
+	srv, renamer, storage := newRenameTestServer()
+	renamer.err = errors.New("connection reset")
+	router := api.NewRouter(srv)
+
+	rec := postRename(router, "sales", "revenue")
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, storage.files, "sales/pipelines/bronze/orders/pipeline.sql")
+	assert.Contains(t, storage.files, "sales/landing/uploads/data.csv")
+	for path := range storage.files {
+		assert.NotContains(t, path, "revenue/")
+	}
+}
+
+func TestRenameNamespace_Validation(t *testing.T) {
+	srv, _, _ := newRenameTestServer()
+	router := api.NewRouter(srv)
+
+	assert.Equal(t, http.StatusNotFound, postRename(router, "ghost", "spirit").Code)
+	assert.Equal(t, http.StatusForbidden, postRename(router, "default", "main").Code)
+	assert.Equal(t, http.StatusBadRequest, postRename(router, "sales", "Not A Slug").Code)
+	assert.Equal(t, http.StatusBadRequest, postRename(router, "sales", "sales").Code)
+}
+
+func TestRenameNamespace_StoreWithoutRenamer_Returns501(t *testing.T) {
+	srv, _ := newNsTestServer()
+	router := api.NewRouter(srv)
+
+	assert.Equal(t, http.StatusNotImplemented, postRename(router, "default", "main").Code)
+}
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rat-data/rat/platform/internal/auth"
+)
+
+// enforceKeyNamespaces restricts namespace-scoped API keys (see
+// RAT_API_KEY_NAMESPACES) to their namespaces. Mounted post-match, like
+// ValidatePathParams, because the target namespace comes from URL params.
+//
+// The namespaces a request touches are collected from, in order: the
+// {namespace} URL param (or {name} under /namespaces), the first segment of
+// a /files path, the ?namespace= and ?prefix= query params, the namespace
+// field of a JSON or the path field of a multipart body, the pipelines named
+// by a /runs/latest body, the items of a /schedules/import document, and the
+// pipeline behind a {runID}, {scheduleID} or {triggerID}. Every one must be
+// allowed.
+// A request that names no namespace at all (cross-namespace listings, SQL
+// queries, plugin and permission management) is refused: deny by default.
+//
+// Requests without a namespace restriction pass through untouched.
+func (s *Server) enforceKeyNamespaces(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed, restricted := auth.AllowedNamespaces(r.Context())
+		if !restricted {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		namespaces, err := s.requestNamespaces(r)
+		if err != nil {
+			internalError(w, "failed to resolve request namespace", err)
+			return
+		}
+		if len(namespaces) == 0 {
+			errorJSON(w, "this endpoint is not available to namespace-scoped API keys", "FORBIDDEN", http.StatusForbidden)
+			return
+		}
+		for _, ns := range namespaces {
+			if !slices.Contains(allowed, ns) {
+				errorJSON(w, fmt.Sprintf("API key is not allowed to access namespace %q", ns), "FORBIDDEN", http.StatusForbidden)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestNamespaces returns every namespace r names. See enforceKeyNamespaces.
+// A run, schedule or trigger that doesn't exist contributes nothing; the handler
+// reports the 404 if the request is otherwise allowed.
+func (s *Server) requestNamespaces(r *http.Request) ([]string, error) {
+	var namespaces []string
+	add := func(ns string) {
+		if ns != "" && !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	add(chi.URLParam(r, "namespace"))
+	var routePattern string
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		routePattern = rctx.RoutePattern()
+	}
+	if strings.HasPrefix(routePattern, "/api/v1/namespaces/{name}") {
+		add(chi.URLParam(r, "name"))
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/v1/files/") {
+		add(firstPathSegment(chi.URLParam(r, "*")))
+	}
+	q := r.URL.Query()
+	add(q.Get("namespace"))
+	add(firstPathSegment(q.Get("prefix")))
+
+	add(requestBodyNamespace(r))
+
+	switch routePattern {
+	case "/api/v1/runs/latest":
+		var body LatestRunsRequest
+		if json.Unmarshal(peekRequestBody(r), &body) == nil {
+			for _, id := range body.PipelineIDs {
+				if _, err := uuid.Parse(id); err != nil {
+					continue // the handler rejects it
+				}
+				ns, err := s.pipelineNamespace(r, id)
+				if err != nil {
+					return nil, err
+				}
+				add(ns)
+			}
+		}
+	case "/api/v1/schedules/import":
+		var doc ScheduleDocument
+		if yaml.Unmarshal(peekRequestBody(r), &doc) == nil {
+			for _, item := range doc.Schedules {
+				add(item.Namespace)
+			}
+		}
+	}
+
+	if runID := chi.URLParam(r, "runID"); runID != "" && s.Runs != nil {
+		run, err := s.Runs.GetRun(r.Context(), runID)
+		if err != nil {
+			return nil, err
+		}
+		if run != nil {
+			ns, err := s.pipelineNamespace(r, run.PipelineID.String())
+			if err != nil {
+				return nil, err
+			}
+			add(ns)
+		}
+	}
+	if scheduleID := chi.URLParam(r, "scheduleID"); scheduleID != "" && s.Schedules != nil {
+		schedule, err := s.Schedules.GetSchedule(r.Context(), scheduleID)
+		if err != nil {
+			return nil, err
+		}
+		if schedule != nil {
+			ns, err := s.pipelineNamespace(r, schedule.PipelineID.String())
+			if err != nil {
+				return nil, err
+			}
+			add(ns)
+		}
+	}
+	if triggerID := chi.URLParam(r, "triggerID"); triggerID != "" && s.Triggers != nil {
+		trigger, err := s.Triggers.GetTrigger(r.Context(), triggerID)
+		if err != nil {
+			return nil, err
+		}
+		if trigger != nil {
+			ns, err := s.pipelineNamespace(r, trigger.PipelineID.String())
+			if err != nil {
+				return nil, err
+			}
+			add(ns)
+		}
+	}
+	return namespaces, nil
+}
+
+// pipelineNamespace returns the namespace of the pipeline with the given ID,
+// or "" when it doesn't exist.
+func (s *Server) pipelineNamespace(r *http.Request, pipelineID string) (string, error) {
+	if s.Pipelines == nil {
+		return "", nil
+	}
+	pipeline, err := s.Pipelines.GetPipelineByID(r.Context(), pipelineID)
+	if err != nil || pipeline == nil {
+		return "", err
+	}
+	return pipeline.Namespace, nil
+}
+
+// requestBodyNamespace peeks at a write request's body for the namespace it
+// targets: the "namespace" field of a JSON object, or the first segment of
+// the "path" field of a multipart upload. The body is restored for the
+// handler. A body that doesn't parse yields "" and is left for the handler
+// to reject.
+func requestBodyNamespace(r *http.Request) string {
+	if r.Body == nil || r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return ""
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		// A successful parse is cached on r, so the handler's own
+		// ParseMultipartForm is a no-op.
+		if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+			return ""
+		}
+		return firstPathSegment(r.FormValue("path"))
+	}
+
+	var body struct {
+		Namespace string `json:"namespace"`
+	}
+	if json.Unmarshal(peekRequestBody(r), &body) != nil {
+		return ""
+	}
+	return body.Namespace
+}
+
+// peekRequestBody reads r's body and restores it for the handler. Returns
+// nil when there is no body or it can't be read in full.
+func peekRequestBody(r *http.Request) []byte {
+	if r.Body == nil {
+		return nil
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		// Over the body limit or a broken connection: replay what was read,
+		// then the same error, so the handler responds as it would have.
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(data), errReader{err}))
+		return nil
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return data
+}
+
+// errReader is an io.Reader that always fails with err.
+type errReader struct{ err error }
+
+func (e errReader) Read([]byte) (int, error) { return 0, e.err }
+
+// firstPathSegment returns the part of p before its first slash.
+func firstPathSegment(p string) string {
+	p = strings.TrimPrefix(p, "/")
+	if i := strings.Index(p, "/"); i >= 0 {
+		return p[:i]
+	}
+	return p
+}
@@ -0,0 +1,230 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/auth"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// newNamespaceScopeRouter returns a router authenticating "sales-key"
+// (restricted to the sales namespace) and "admin-key" (unrestricted), plus a
+// run each in sales and finance.
+func newNamespaceScopeRouter() (router http.Handler, salesRunID, financeRunID string) {
+	srv, pipelineStore, runStore := newRunTestServer()
+	srv.Auth = auth.APIKeys(
+		map[string]auth.Scope{"sales-key": auth.ScopeReadWrite, "admin-key": auth.ScopeReadWrite},
+		map[string][]string{"sales-key": {"sales"}},
+	)
+
+	sales := domain.Pipeline{ID: uuid.New(), Namespace: "sales", Layer: domain.LayerBronze, Name: "orders"}
+	finance := domain.Pipeline{ID: uuid.New(), Namespace: "finance", Layer: domain.LayerBronze, Name: "ledger"}
+	pipelineStore.pipelines = []domain.Pipeline{sales, finance}
+
+	salesRun := domain.Run{ID: uuid.New(), PipelineID: sales.ID, Status: domain.RunStatusSuccess}
+	financeRun := domain.Run{ID: uuid.New(), PipelineID: finance.ID, Status: domain.RunStatusSuccess}
+	runStore.runs = []domain.Run{salesRun, financeRun}
+
+	return api.NewRouter(srv), salesRun.ID.String(), financeRun.ID.String()
+}
+
+func serveWithKey(router http.Handler, key, method, target, body string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body == "" {
+		req = httptest.NewRequest(method, target, http.NoBody)
+	} else {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNamespaceScope_PipelineInAllowedNamespace_Passes(t *testing.T) {
+	router, _, _ := newNamespaceScopeRouter()
+
+	rec := serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/pipelines/sales/bronze/orders", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestNamespaceScope_PipelineInOtherNamespace_Returns403(t *testing.T) {
+	router, _, _ := newNamespaceScopeRouter()
+
+	rec := serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/pipelines/finance/bronze/ledger", "")
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "finance")
+}
+
+func TestNamespaceScope_BodyNamespaceOutsideScope_Returns403(t *testing.T) {
+	router, _, _ := newNamespaceScopeRouter()
+
+	rec := serveWithKey(router, "sales-key", http.MethodPost, "/api/v1/pipelines",
+		`{"namespace":"finance","layer":"bronze","name":"sneaky"}`)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestNamespaceScope_QueryNamespaceFilter_Scopes(t *testing.T) {
+	router, _, _ := newNamespaceScopeRouter()
+
+	assert.Equal(t, http.StatusOK,
+		serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/pipelines?namespace=sales", "").Code)
+	assert.Equal(t, http.StatusForbidden,
+		serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/pipelines?namespace=finance", "").Code)
+}
+
+func TestNamespaceScope_UnscopedEndpoint_DeniedByDefault(t *testing.T) {
+	router, _, _ := newNamespaceScopeRouter()
+
+	rec := serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/runs", "")
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "namespace-scoped")
+}
+
+func TestNamespaceScope_RunResolvedThroughPipeline(t *testing.T) {
+	router, salesRunID, financeRunID := newNamespaceScopeRouter()
+
+	assert.Equal(t, http.StatusOK,
+		serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/runs/"+salesRunID, "").Code)
+	assert.Equal(t, http.StatusForbidden,
+		serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/runs/"+financeRunID, "").Code)
+}
+
+func TestNamespaceScope_LatestRuns_ResolvedThroughPipelines(t *testing.T) {
+	srv, pipelineStore, runStore := newRunTestServer()
+	srv.Auth = auth.APIKeys(
+		map[string]auth.Scope{"sales-key": auth.ScopeReadOnly},
+		map[string][]string{"sales-key": {"sales"}},
+	)
+	sales := domain.Pipeline{ID: uuid.New(), Namespace: "sales", Layer: domain.LayerBronze, Name: "orders"}
+	finance := domain.Pipeline{ID: uuid.New(), Namespace: "finance", Layer: domain.LayerBronze, Name: "ledger"}
+	pipelineStore.pipelines = []domain.Pipeline{sales, finance}
+	runStore.runs = []domain.Run{{ID: uuid.New(), PipelineID: sales.ID, Status: domain.RunStatusSuccess}}
+	router := api.NewRouter(srv)
+
+	rec := serveWithKey(router, "sales-key", http.MethodPost, "/api/v1/runs/latest",
+		`{"pipeline_ids":["`+sales.ID.String()+`"]}`)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), sales.ID.String())
+
+	rec = serveWithKey(router, "sales-key", http.MethodPost, "/api/v1/runs/latest",
+		`{"pipeline_ids":["`+sales.ID.String()+`","`+finance.ID.String()+`"]}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "finance")
+}
+
+func TestNamespaceScope_ScheduleImport_ResolvedFromDocument(t *testing.T) {
+	router, _, _ := newNamespaceScopeRouter()
+
+	rec := serveWithKey(router, "sales-key", http.MethodPost, "/api/v1/schedules/import",
+		"schedules:\n  - {namespace: sales, layer: bronze, pipeline: orders, cron: \"0 * * * *\"}\n")
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = serveWithKey(router, "sales-key", http.MethodPost, "/api/v1/schedules/import",
+		"schedules:\n  - {namespace: sales, layer: bronze, pipeline: orders, cron: \"0 * * * *\"}\n"+
+			"  - {namespace: finance, layer: bronze, pipeline: ledger, cron: \"0 * * * *\"}\n")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "finance")
+}
+
+func TestNamespaceScope_UnrestrictedKey_Passes(t *testing.T) {
+	router, _, financeRunID := newNamespaceScopeRouter()
+
+	assert.Equal(t, http.StatusOK,
+		serveWithKey(router, "admin-key", http.MethodGet, "/api/v1/runs", "").Code)
+	assert.Equal(t, http.StatusOK,
+		serveWithKey(router, "admin-key", http.MethodGet, "/api/v1/runs/"+financeRunID, "").Code)
+}
+
+func TestNamespaceScope_ForeignTriggerID_Returns403(t *testing.T) {
+	srv, pipelineStore, runStore := newRunTestServer()
+	srv.Auth = auth.APIKeys(
+		map[string]auth.Scope{"sales-key": auth.ScopeReadWrite},
+		map[string][]string{"sales-key": {"sales"}},
+	)
+	triggerStore := newMemoryTriggerStore()
+	srv.Triggers = triggerStore
+
+	sales := domain.Pipeline{ID: uuid.New(), Namespace: "sales", Layer: domain.LayerBronze, Name: "orders"}
+	finance := domain.Pipeline{ID: uuid.New(), Namespace: "finance", Layer: domain.LayerBronze, Name: "ledger"}
+	pipelineStore.pipelines = []domain.Pipeline{sales, finance}
+	financeTrigger := domain.PipelineTrigger{ID: uuid.New(), PipelineID: finance.ID, Type: domain.TriggerTypeWebhook, Config: []byte(`{}`), Enabled: true}
+	triggerStore.triggers = []domain.PipelineTrigger{financeTrigger}
+	router := api.NewRouter(srv)
+
+	// The URL names the key's own pipeline, but the trigger is finance's.
+	base := "/api/v1/pipelines/sales/bronze/orders/triggers/" + financeTrigger.ID.String()
+	for _, tc := range []struct{ method, path, body string }{
+		{http.MethodGet, base, ""},
+		{http.MethodPut, base, `{"enabled":false}`},
+		{http.MethodDelete, base, ""},
+		{http.MethodPost, base + "/rotate-token", ""},
+		{http.MethodPost, base + "/fire", ""},
+	} {
+		rec := serveWithKey(router, "sales-key", tc.method, tc.path, tc.body)
+		assert.Equal(t, http.StatusForbidden, rec.Code, "%s %s", tc.method, tc.path)
+	}
+
+	assert.Len(t, triggerStore.triggers, 1)
+	assert.True(t, triggerStore.triggers[0].Enabled)
+	assert.Empty(t, runStore.runs)
+}
+
+func TestNamespaceScope_CancelCascade_StaysInsideScope(t *testing.T) {
+	srv, pipelineStore, runStore := newRunTestServer()
+	srv.Auth = auth.APIKeys(
+		map[string]auth.Scope{"sales-key": auth.ScopeReadWrite},
+		map[string][]string{"sales-key": {"sales"}},
+	)
+
+	sales := domain.Pipeline{ID: uuid.New(), Namespace: "sales", Layer: domain.LayerBronze, Name: "orders"}
+	finance := domain.Pipeline{ID: uuid.New(), Namespace: "finance", Layer: domain.LayerBronze, Name: "ledger"}
+	pipelineStore.pipelines = []domain.Pipeline{sales, finance}
+	rootID, salesChildID, financeChildID, salesGrandchildID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: rootID, PipelineID: sales.ID, Status: domain.RunStatusRunning},
+		{ID: salesChildID, PipelineID: sales.ID, Status: domain.RunStatusPending, ParentRunID: &rootID},
+		{ID: financeChildID, PipelineID: finance.ID, Status: domain.RunStatusSuccess, ParentRunID: &rootID},
+		{ID: salesGrandchildID, PipelineID: sales.ID, Status: domain.RunStatusRunning, ParentRunID: &financeChildID},
+	}
+	router := api.NewRouter(srv)
+
+	rec := serveWithKey(router, "sales-key", http.MethodPost, "/api/v1/runs/"+rootID.String()+"/cancel?cascade=true", "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), salesChildID.String())
+	// Runs below a finance run are out of reach, even back in sales.
+	assert.NotContains(t, rec.Body.String(), salesGrandchildID.String())
+	assert.Equal(t, domain.RunStatusRunning, runStore.runs[3].Status)
+}
+
+func TestNamespaceScope_PipelineLabels_OnlyKeyNamespace(t *testing.T) {
+	srv, pipelineStore, _ := newRunTestServer()
+	srv.Auth = auth.APIKeys(map[string]auth.Scope{"sales-key": auth.ScopeReadWrite}, map[string][]string{"sales-key": {"sales"}})
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "sales", Layer: domain.LayerBronze, Name: "orders", Labels: map[string]string{"team": "sales"}},
+		{ID: uuid.New(), Namespace: "finance", Layer: domain.LayerBronze, Name: "ledger", Labels: map[string]string{"team": "finance"}},
+	}
+	router := api.NewRouter(srv)
+
+	rec := serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/pipelines/labels?namespace=sales", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"labels":{"team":["sales"]}}`, rec.Body.String())
+
+	rec = serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/pipelines/labels?namespace=finance", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = serveWithKey(router, "sales-key", http.MethodGet, "/api/v1/pipelines/labels", "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -3,7 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rat-data/rat/platform/internal/domain"
@@ -28,17 +32,30 @@ type UpdateNamespaceRequest struct {
 	Description *string `json:"description"`
 }
 
+// NamespaceInUseError is the 409 body of DELETE /api/v1/namespaces/{name}
+// when the namespace still holds live pipelines or landing zones. The
+// standard error envelope plus the blocking resources, so a client can show
+// what has to go first (or retry with ?force=true).
+type NamespaceInUseError struct {
+	Error        APIErrorDetail `json:"error"`
+	Pipelines    []string       `json:"pipelines"`     // "layer/name"
+	LandingZones []string       `json:"landing_zones"` // zone names
+}
+
 // MountNamespaceRoutes registers namespace endpoints on the router.
 func MountNamespaceRoutes(r chi.Router, srv *Server) {
 	r.Get("/namespaces", srv.HandleListNamespaces)
 	r.Post("/namespaces", srv.HandleCreateNamespace)
 	r.Put("/namespaces/{name}", srv.HandleUpdateNamespace)
 	r.Delete("/namespaces/{name}", srv.HandleDeleteNamespace)
+	r.Post("/namespaces/{name}/rename", srv.HandleRenameNamespace)
+	r.Get("/namespaces/{name}/stats", srv.HandleGetNamespaceStats)
 }
 
 // HandleListNamespaces returns all namespaces with pagination support.
 // Results are cached because namespace lists rarely change and are fetched on every portal page load.
 // Pagination is applied in-memory since namespace counts are typically small (< 100).
+// Responses carry a body-hash ETag so polling clients can revalidate with If-None-Match.
 func (s *Server) HandleListNamespaces(w http.ResponseWriter, r *http.Request) {
 	const cacheKey = "all"
 
@@ -93,7 +110,7 @@ func (s *Server) HandleListNamespaces(w http.ResponseWriter, r *http.Request) {
 	limit, offset := parsePagination(r)
 	namespaces = paginate(namespaces, limit, offset)
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	writeJSONWithETag(w, r, map[string]interface{}{
 		"namespaces": namespaces,
 		"total":      total,
 	})
@@ -167,6 +184,12 @@ func (s *Server) HandleUpdateNamespace(w http.ResponseWriter, r *http.Request) {
 
 // HandleDeleteNamespace deletes a namespace.
 // The "default" namespace cannot be deleted.
+//
+// Deleting the namespace row would cascade to every pipeline and landing
+// zone in it, so a namespace that still has live ones is refused with a 409
+// listing them. ?force=true deletes them along with the namespace. Either
+// way the delete is permanent: the cascade also removes soft-deleted
+// pipelines and zones, and the namespace's S3 prefix is purged.
 func (s *Server) HandleDeleteNamespace(w http.ResponseWriter, r *http.Request) {
 	name := chi.URLParam(r, "name")
 
@@ -175,14 +198,77 @@ func (s *Server) HandleDeleteNamespace(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	force := false
+	if v := r.URL.Query().Get("force"); v != "" {
+		var err error
+		if force, err = strconv.ParseBool(v); err != nil {
+			errorJSON(w, "force must be a boolean", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+
 	if !s.requireAccess(w, r, "namespace", name, "delete") {
 		return
 	}
 
+	pipelines, err := s.Pipelines.ListPipelines(r.Context(), PipelineFilter{Namespace: name})
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	var zones []LandingZoneListItem
+	if s.LandingZones != nil {
+		zones, err = s.LandingZones.ListZones(r.Context(), LandingZoneFilter{Namespace: name})
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+	}
+
+	if (len(pipelines) > 0 || len(zones) > 0) && !force {
+		blocked := NamespaceInUseError{
+			Error: APIErrorDetail{
+				Code:    "FAILED_PRECONDITION",
+				Type:    errorTypeFromStatus(http.StatusConflict),
+				Message: fmt.Sprintf("namespace %q still has %d pipeline(s) and %d landing zone(s); delete them first or retry with ?force=true", name, len(pipelines), len(zones)),
+			},
+			Pipelines:    make([]string, len(pipelines)),
+			LandingZones: make([]string, len(zones)),
+		}
+		for i, p := range pipelines {
+			blocked.Pipelines[i] = string(p.Layer) + "/" + p.Name
+		}
+		for i, z := range zones {
+			blocked.LandingZones[i] = z.Name
+		}
+		writeJSON(w, http.StatusConflict, blocked)
+		return
+	}
+
+	for _, p := range pipelines {
+		if err := s.Pipelines.DeletePipeline(r.Context(), name, string(p.Layer), p.Name); err != nil {
+			internalError(w, "failed to delete pipeline", err)
+			return
+		}
+		if s.PipelineCache != nil {
+			s.PipelineCache.Delete(pipelineCacheKey(name, string(p.Layer), p.Name))
+		}
+	}
+	for _, z := range zones {
+		if err := s.LandingZones.DeleteZone(r.Context(), name, z.Name); err != nil {
+			internalError(w, "failed to delete landing zone", err)
+			return
+		}
+	}
+
+	// Deleting the row cascades to every pipeline, landing zone and run in
+	// the namespace, soft-deleted ones included, so nothing is left to
+	// restore and the reaper will never purge their files: purge them here.
 	if err := s.Namespaces.DeleteNamespace(r.Context(), name); err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
+	s.purgeNamespaceFiles(context.WithoutCancel(r.Context()), name)
 
 	// Invalidate namespace cache after mutation.
 	if s.NamespaceCache != nil {
@@ -191,3 +277,95 @@ func (s *Server) HandleDeleteNamespace(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// purgeNamespaceFiles deletes every S3 object under a deleted namespace's
+// prefix. Best-effort: failures are logged and the rest still deleted.
+func (s *Server) purgeNamespaceFiles(ctx context.Context, name string) {
+	if s.Storage == nil {
+		return
+	}
+	files, err := s.Storage.ListFiles(ctx, name+"/")
+	if err != nil {
+		slog.Warn("namespace delete: failed to list files", "namespace", name, "error", err)
+		return
+	}
+	for _, f := range files {
+		if err := s.Storage.DeleteFile(ctx, f.Path); err != nil {
+			slog.Warn("namespace delete: failed to delete file", "namespace", name, "path", f.Path, "error", err)
+		}
+	}
+}
+
+// namespaceStatsWindow is the trailing window the namespace stats endpoint
+// reports run counts over.
+const namespaceStatsWindow = 7 * 24 * time.Hour
+
+// HandleGetNamespaceStats returns usage numbers for one namespace in a single
+// call: pipeline count, run counts by status over the last 7 days with the
+// success rate of the finished ones, and S3 object count and bytes under the
+// namespace prefix. Each figure comes from one aggregate call per store.
+func (s *Server) HandleGetNamespaceStats(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	namespaces, err := s.Namespaces.ListNamespaces(r.Context())
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	found := false
+	for _, ns := range namespaces {
+		if ns.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		errorJSON(w, "namespace not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	if !s.requireAccess(w, r, "namespace", name, "read") {
+		return
+	}
+
+	pipelineCount, err := s.Pipelines.CountPipelines(r.Context(), PipelineFilter{Namespace: name})
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	since := time.Now().Add(-namespaceStatsWindow)
+	runStats, err := s.Runs.NamespaceRunStats(r.Context(), name, since)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	// Success rate over finished runs only; nil when nothing finished.
+	var successRate *float64
+	succeeded := runStats.ByStatus[string(domain.RunStatusSuccess)]
+	if finished := succeeded + runStats.ByStatus[string(domain.RunStatusFailed)]; finished > 0 {
+		rate := float64(succeeded) / float64(finished)
+		successRate = &rate
+	}
+
+	files, err := s.Storage.ListFiles(r.Context(), name+"/")
+	if err != nil {
+		internalError(w, "failed to list namespace files", err)
+		return
+	}
+	var storageBytes int64
+	for _, f := range files {
+		storageBytes += f.Size
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"namespace":       name,
+		"pipelines":       pipelineCount,
+		"runs":            runStats,
+		"runs_since":      since.UTC(),
+		"success_rate":    successRate,
+		"storage_objects": len(files),
+		"storage_bytes":   storageBytes,
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -212,3 +213,166 @@ func TestDeleteNamespace_NotFound_Returns500(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
+
+func seedNamespaceDependents(srv *api.Server) {
+	srv.Pipelines.(*memoryPipelineStore).pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "analytics", Layer: domain.LayerBronze, Name: "events"},
+		{ID: uuid.New(), Namespace: "analytics", Layer: domain.LayerSilver, Name: "sessions"},
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders"},
+	}
+	srv.LandingZones.(*memoryLandingZoneStore).zones = []api.LandingZoneListItem{
+		{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "analytics", Name: "uploads"}},
+	}
+}
+
+func TestDeleteNamespace_WithDependents_Returns409ListingThem(t *testing.T) {
+	srv, nsStore := newNsTestServer()
+	nsStore.namespaces = append(nsStore.namespaces, domain.Namespace{Name: "analytics"})
+	seedNamespaceDependents(srv)
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/namespaces/analytics", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusConflict, rec.Code)
+	var body api.NamespaceInUseError
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "FAILED_PRECONDITION", body.Error.Code)
+	assert.ElementsMatch(t, []string{"bronze/events", "silver/sessions"}, body.Pipelines)
+	assert.Equal(t, []string{"uploads"}, body.LandingZones)
+
+	// Nothing was deleted.
+	assert.Len(t, nsStore.namespaces, 2)
+	assert.Len(t, srv.Pipelines.(*memoryPipelineStore).pipelines, 3)
+}
+
+func TestDeleteNamespace_Force_DeletesDependentsFirst(t *testing.T) {
+	srv, nsStore := newNsTestServer()
+	nsStore.namespaces = append(nsStore.namespaces, domain.Namespace{Name: "analytics"})
+	seedNamespaceDependents(srv)
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.files["analytics/pipelines/bronze/events/pipeline.sql"] = []byte("SELECT 1")
+	storage.files["analytics/landing/uploads/a.csv"] = []byte("a")
+	storage.files["analytics-archive/x.csv"] = []byte("x")
+	storage.files["default/pipelines/bronze/orders/pipeline.sql"] = []byte("SELECT 2")
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/namespaces/analytics?force=true", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Len(t, nsStore.namespaces, 1)
+
+	pipelines := srv.Pipelines.(*memoryPipelineStore).pipelines
+	require.Len(t, pipelines, 1)
+	assert.Equal(t, "default", pipelines[0].Namespace)
+
+	zones := srv.LandingZones.(*memoryLandingZoneStore)
+	assert.Empty(t, zones.zones)
+	require.Len(t, zones.deleted, 1)
+	assert.Equal(t, "uploads", zones.deleted[0].Name)
+
+	// The namespace's files are purged; other namespaces' are untouched.
+	var remaining []string
+	for path := range storage.files {
+		remaining = append(remaining, path)
+	}
+	assert.ElementsMatch(t, []string{"analytics-archive/x.csv", "default/pipelines/bronze/orders/pipeline.sql"}, remaining)
+}
+
+func TestDeleteNamespace_InvalidForce_Returns400(t *testing.T) {
+	srv, nsStore := newNsTestServer()
+	nsStore.namespaces = append(nsStore.namespaces, domain.Namespace{Name: "analytics"})
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/namespaces/analytics?force=maybe", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// --- Namespace Stats ---
+
+func TestGetNamespaceStats_AggregatesSeededData(t *testing.T) {
+	srv, _ := newNsTestServer()
+	pipelineStore := srv.Pipelines.(*memoryPipelineStore)
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.pipelines = pipelineStore
+
+	orders := domain.Pipeline{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"}
+	events := domain.Pipeline{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "events"}
+	other := domain.Pipeline{ID: uuid.New(), Namespace: "analytics", Layer: domain.LayerBronze, Name: "clicks"}
+	pipelineStore.pipelines = []domain.Pipeline{orders, events, other}
+
+	now := time.Now()
+	run := func(p domain.Pipeline, status domain.RunStatus, age time.Duration) domain.Run {
+		return domain.Run{ID: uuid.New(), PipelineID: p.ID, Status: status, CreatedAt: now.Add(-age)}
+	}
+	runStore.runs = []domain.Run{
+		run(orders, domain.RunStatusSuccess, time.Hour),
+		run(orders, domain.RunStatusSuccess, 2*time.Hour),
+		run(events, domain.RunStatusSuccess, 24*time.Hour),
+		run(events, domain.RunStatusFailed, 48*time.Hour),
+		run(events, domain.RunStatusRunning, time.Minute),
+		run(orders, domain.RunStatusFailed, 8*24*time.Hour), // outside the 7d window
+		run(other, domain.RunStatusFailed, time.Hour),       // other namespace
+	}
+
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT 1")   // 8 bytes
+	storage.files["default/landing/raw/data.csv"] = []byte("a,b\n1,2\n")                 // 8 bytes
+	storage.files["analytics/pipelines/bronze/clicks/pipeline.sql"] = []byte("SELECT 2") // other namespace
+
+	router := api.NewRouter(srv)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/stats", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Namespace      string                `json:"namespace"`
+		Pipelines      int                   `json:"pipelines"`
+		Runs           api.NamespaceRunStats `json:"runs"`
+		SuccessRate    *float64              `json:"success_rate"`
+		StorageObjects int                   `json:"storage_objects"`
+		StorageBytes   int64                 `json:"storage_bytes"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "default", resp.Namespace)
+	assert.Equal(t, 2, resp.Pipelines)
+	assert.Equal(t, 5, resp.Runs.Total)
+	assert.Equal(t, map[string]int{"success": 3, "failed": 1, "running": 1}, resp.Runs.ByStatus)
+	require.NotNil(t, resp.SuccessRate)
+	assert.InDelta(t, 0.75, *resp.SuccessRate, 1e-9)
+	assert.Equal(t, 2, resp.StorageObjects)
+	assert.Equal(t, int64(16), resp.StorageBytes)
+}
+
+func TestGetNamespaceStats_NoRuns_NullSuccessRate(t *testing.T) {
+	srv, _ := newNsTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/stats", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Nil(t, resp["success_rate"])
+	assert.Equal(t, float64(0), resp["pipelines"])
+}
+
+func TestGetNamespaceStats_UnknownNamespace_Returns404(t *testing.T) {
+	srv, _ := newNsTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/nonexistent/stats", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
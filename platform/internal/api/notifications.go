@@ -0,0 +1,402 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// NotificationStore persists per-namespace outbound run-completion webhooks.
+type NotificationStore interface {
+	// GetNotificationConfig returns nil, nil when the namespace has none.
+	GetNotificationConfig(ctx context.Context, namespace string) (*domain.NotificationConfig, error)
+	PutNotificationConfig(ctx context.Context, namespace string, cfg *domain.NotificationConfig) error
+	DeleteNotificationConfig(ctx context.Context, namespace string) error
+}
+
+// NamespaceNotificationsKey is the platform_settings key holding a
+// namespace's notification config.
+func NamespaceNotificationsKey(namespace string) string {
+	return "notifications:" + namespace
+}
+
+const (
+	// defaultNotificationAttempts is how many times a notification is POSTed
+	// before it is dropped.
+	defaultNotificationAttempts = 4
+	// defaultNotificationBackoff is the delay before the first retry; it
+	// doubles on each subsequent attempt.
+	defaultNotificationBackoff = time.Second
+	// notificationRequestTimeout bounds a single delivery attempt.
+	notificationRequestTimeout = 10 * time.Second
+)
+
+// NotificationConfigRequest is the JSON body for PUT /namespaces/{name}/notifications.
+type NotificationConfigRequest struct {
+	URL    string `json:"url"`
+	Events string `json:"events,omitempty"` // success, failure (default), or all
+	Secret string `json:"secret,omitempty"` // optional HMAC signing secret
+}
+
+// NotificationConfigResponse is a namespace's notification config as
+// returned by the API. The signing secret is never echoed back.
+type NotificationConfigResponse struct {
+	Namespace string `json:"namespace"`
+	URL       string `json:"url"`
+	Events    string `json:"events"`
+	HasSecret bool   `json:"has_secret"`
+}
+
+// MountNotificationRoutes registers the namespace notification endpoints.
+func MountNotificationRoutes(r chi.Router, srv *Server) {
+	r.Get("/namespaces/{name}/notifications", srv.HandleGetNotificationConfig)
+	r.Put("/namespaces/{name}/notifications", srv.HandlePutNotificationConfig)
+	r.Delete("/namespaces/{name}/notifications", srv.HandleDeleteNotificationConfig)
+}
+
+// HandleGetNotificationConfig returns a namespace's notification config.
+// GET /api/v1/namespaces/{name}/notifications
+func (s *Server) HandleGetNotificationConfig(w http.ResponseWriter, r *http.Request) {
+	ns := chi.URLParam(r, "name")
+	if !s.namespaceExists(w, r, ns) {
+		return
+	}
+
+	cfg, err := s.Notifications.GetNotificationConfig(r.Context(), ns)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if cfg == nil {
+		errorJSON(w, "no notifications configured", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NotificationConfigResponse{
+		Namespace: ns,
+		URL:       cfg.URL,
+		Events:    cfg.Events,
+		HasSecret: cfg.Secret != "",
+	})
+}
+
+// HandlePutNotificationConfig replaces a namespace's notification config.
+// PUT /api/v1/namespaces/{name}/notifications
+func (s *Server) HandlePutNotificationConfig(w http.ResponseWriter, r *http.Request) {
+	ns := chi.URLParam(r, "name")
+	if !s.namespaceExists(w, r, ns) {
+		return
+	}
+	if !s.requireAccess(w, r, "namespace", ns, "write") {
+		return
+	}
+
+	var req NotificationConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if msg := validateNotificationURL(req.URL); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	switch req.Events {
+	case "":
+		req.Events = domain.NotificationEventsFailure
+	case domain.NotificationEventsSuccess, domain.NotificationEventsFailure, domain.NotificationEventsAll:
+	default:
+		errorJSON(w, "events must be success, failure, or all", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	cfg := &domain.NotificationConfig{URL: req.URL, Events: req.Events}
+	if req.Secret != "" {
+		if len(req.Secret) < minWebhookSigningSecretLength {
+			errorJSON(w, fmt.Sprintf("secret must be at least %d characters", minWebhookSigningSecretLength), "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		if len(s.WebhookSecretKey) == 0 {
+			errorJSON(w, "notification signing requires RAT_WEBHOOK_SECRET_KEY to be configured", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		encrypted, err := encryptWebhookSecret(s.WebhookSecretKey, req.Secret)
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		cfg.Secret = encrypted
+	}
+
+	if err := s.Notifications.PutNotificationConfig(r.Context(), ns, cfg); err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NotificationConfigResponse{
+		Namespace: ns,
+		URL:       cfg.URL,
+		Events:    cfg.Events,
+		HasSecret: cfg.Secret != "",
+	})
+}
+
+// HandleDeleteNotificationConfig removes a namespace's notification config.
+// DELETE /api/v1/namespaces/{name}/notifications
+func (s *Server) HandleDeleteNotificationConfig(w http.ResponseWriter, r *http.Request) {
+	ns := chi.URLParam(r, "name")
+	if !s.namespaceExists(w, r, ns) {
+		return
+	}
+	if !s.requireAccess(w, r, "namespace", ns, "write") {
+		return
+	}
+
+	if err := s.Notifications.DeleteNotificationConfig(r.Context(), ns); err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateNotificationURL returns a client-facing error message, or "".
+func validateNotificationURL(raw string) string {
+	if raw == "" {
+		return "url is required"
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "url must be an absolute http or https URL"
+	}
+	return ""
+}
+
+// RunNotification is the JSON payload POSTed to a namespace's notification
+// URL when one of its runs completes.
+type RunNotification struct {
+	Event      string           `json:"event"` // always "run_completed"
+	RunID      string           `json:"run_id"`
+	Namespace  string           `json:"namespace"`
+	Layer      string           `json:"layer"`
+	Pipeline   string           `json:"pipeline"`
+	Status     domain.RunStatus `json:"status"`
+	Trigger    string           `json:"trigger"`
+	DurationMs *int             `json:"duration_ms"`
+	Error      *string          `json:"error"`
+	FinishedAt *time.Time       `json:"finished_at"`
+}
+
+// NotificationDispatcher delivers run-completion notifications to the
+// webhook configured for the run's namespace. Wire OnRunComplete into the
+// executor's completion callback.
+//
+// When the config has a secret, each request carries X-Signature-256: the
+// hex HMAC-SHA256 of the body (same scheme as inbound webhook triggers, see
+// SignWebhookBody). Non-2xx responses and transport errors are retried with
+// exponential backoff; a notification that still fails is logged and dropped.
+type NotificationDispatcher struct {
+	store     NotificationStore
+	pipelines PipelineStore
+	runs      RunStore
+	secretKey []byte
+	inflight  sync.WaitGroup
+
+	Client      *http.Client  // nil = http.DefaultClient
+	MaxAttempts int           // zero = 4
+	Backoff     time.Duration // delay before the first retry, doubled per attempt; zero = 1s
+}
+
+// NewNotificationDispatcher creates a dispatcher. secretKey decrypts
+// notification signing secrets (see WebhookSecretKey).
+func NewNotificationDispatcher(store NotificationStore, pipelines PipelineStore, runs RunStore, secretKey []byte) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		store:     store,
+		pipelines: pipelines,
+		runs:      runs,
+		secretKey: secretKey,
+	}
+}
+
+// OnRunComplete notifies the run's namespace webhook, if one is configured
+// and its events filter matches status. The config and run are loaded on
+// ctx; delivery then runs in the background on a context detached from ctx
+// (the executor's completion callback deadline is far shorter than a full
+// retry sequence), bounded by deliveryBudget.
+func (d *NotificationDispatcher) OnRunComplete(ctx context.Context, run *domain.Run, status domain.RunStatus) {
+	pipeline, err := d.pipelines.GetPipelineByID(ctx, run.PipelineID.String())
+	if err != nil || pipeline == nil {
+		slog.Warn("notification: pipeline lookup failed", "run_id", run.ID, "pipeline_id", run.PipelineID, "error", err)
+		return
+	}
+
+	cfg, err := d.store.GetNotificationConfig(ctx, pipeline.Namespace)
+	if err != nil {
+		slog.Error("notification: failed to load config", "namespace", pipeline.Namespace, "error", err)
+		return
+	}
+	if cfg == nil || !notificationEventMatches(cfg.Events, status) {
+		return
+	}
+
+	var secret string
+	if cfg.Secret != "" {
+		secret, err = decryptWebhookSecret(d.secretKey, cfg.Secret)
+		if err != nil {
+			slog.Error("notification: failed to decrypt signing secret", "namespace", pipeline.Namespace, "error", err)
+			return
+		}
+	}
+
+	// The executor's copy of the run predates its final status update;
+	// prefer the stored row for duration and error.
+	if stored, err := d.runs.GetRun(ctx, run.ID.String()); err == nil && stored != nil {
+		run = stored
+	}
+
+	body, err := json.Marshal(RunNotification{
+		Event:      "run_completed",
+		RunID:      run.ID.String(),
+		Namespace:  pipeline.Namespace,
+		Layer:      string(pipeline.Layer),
+		Pipeline:   pipeline.Name,
+		Status:     status,
+		Trigger:    run.Trigger,
+		DurationMs: run.DurationMs,
+		Error:      run.Error,
+		FinishedAt: run.FinishedAt,
+	})
+	if err != nil {
+		slog.Error("notification: failed to encode payload", "run_id", run.ID, "error", err)
+		return
+	}
+
+	deliverCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.deliveryBudget())
+	d.inflight.Add(1)
+	go func() {
+		defer d.inflight.Done()
+		defer cancel()
+		if err := d.deliver(deliverCtx, cfg.URL, secret, body); err != nil {
+			slog.Error("notification: delivery failed", "namespace", pipeline.Namespace, "run_id", run.ID, "error", err)
+			return
+		}
+		slog.Info("notification delivered", "namespace", pipeline.Namespace, "run_id", run.ID, "status", status)
+	}()
+}
+
+// Wait blocks until background deliveries finish or ctx is done. Call it on
+// shutdown, after the executor has drained.
+func (d *NotificationDispatcher) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// attempts returns MaxAttempts, or the default when unset.
+func (d *NotificationDispatcher) attempts() int {
+	if d.MaxAttempts <= 0 {
+		return defaultNotificationAttempts
+	}
+	return d.MaxAttempts
+}
+
+// backoff returns Backoff, or the default when unset.
+func (d *NotificationDispatcher) backoff() time.Duration {
+	if d.Backoff <= 0 {
+		return defaultNotificationBackoff
+	}
+	return d.Backoff
+}
+
+// deliveryBudget is the longest a full delivery can take: every attempt
+// timing out plus every backoff between them.
+func (d *NotificationDispatcher) deliveryBudget() time.Duration {
+	attempts := d.attempts()
+	waits := d.backoff() * time.Duration(1<<(attempts-1)-1) // backoff doubles: b + 2b + ... + 2^(n-2)b
+	return time.Duration(attempts)*notificationRequestTimeout + waits
+}
+
+// deliver POSTs body to target, retrying with exponential backoff.
+func (d *NotificationDispatcher) deliver(ctx context.Context, target, secret string, body []byte) error {
+	attempts := d.attempts()
+	backoff := d.backoff()
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		lastErr = d.post(ctx, target, secret, body)
+		if lastErr == nil {
+			return nil
+		}
+		slog.Warn("notification attempt failed", "url", target, "attempt", attempt, "error", lastErr)
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, lastErr)
+}
+
+// post makes a single delivery attempt. Any non-2xx response is an error.
+func (d *NotificationDispatcher) post(ctx context.Context, target, secret string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, notificationRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Rat-Event", "run_completed")
+	if secret != "" {
+		req.Header.Set("X-Signature-256", "sha256="+SignWebhookBody(secret, body))
+	}
+
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// notificationEventMatches reports whether a completed run with status
+// passes the config's events filter. Cancelled runs only match "all".
+func notificationEventMatches(events string, status domain.RunStatus) bool {
+	switch events {
+	case domain.NotificationEventsAll:
+		return true
+	case domain.NotificationEventsSuccess:
+		return status == domain.RunStatusSuccess
+	case domain.NotificationEventsFailure, "":
+		return status == domain.RunStatusFailed
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryNotificationStore struct {
+	mu      sync.Mutex
+	configs map[string]*domain.NotificationConfig
+}
+
+func newMemoryNotificationStore() *memoryNotificationStore {
+	return &memoryNotificationStore{configs: map[string]*domain.NotificationConfig{}}
+}
+
+func (m *memoryNotificationStore) GetNotificationConfig(_ context.Context, namespace string) (*domain.NotificationConfig, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cfg, ok := m.configs[namespace]
+	if !ok {
+		return nil, nil
+	}
+	c := *cfg
+	return &c, nil
+}
+
+func (m *memoryNotificationStore) PutNotificationConfig(_ context.Context, namespace string, cfg *domain.NotificationConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := *cfg
+	m.configs[namespace] = &c
+	return nil
+}
+
+func (m *memoryNotificationStore) DeleteNotificationConfig(_ context.Context, namespace string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.configs, namespace)
+	return nil
+}
+
+// notificationSink is an httptest server recording every POST it receives.
+// The first failures requests are answered with 500.
+type notificationSink struct {
+	*httptest.Server
+	mu       sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	failures int
+}
+
+func newNotificationSink(t *testing.T, failures int) *notificationSink {
+	s := &notificationSink{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.bodies = append(s.bodies, body)
+		s.headers = append(s.headers, r.Header.Clone())
+		if len(s.bodies) <= s.failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *notificationSink) received() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.bodies)
+}
+
+type notificationFixture struct {
+	dispatcher *api.NotificationDispatcher
+	store      *memoryNotificationStore
+	run        *domain.Run
+}
+
+func newNotificationFixture(t *testing.T, secretKey []byte) notificationFixture {
+	t.Helper()
+	_, pipelineStore, runStore := newRunTestServer()
+	pipeline := domain.Pipeline{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"}
+	pipelineStore.pipelines = []domain.Pipeline{pipeline}
+
+	errMsg := "duckdb: table not found"
+	duration := 4200
+	run := &domain.Run{ID: uuid.New(), PipelineID: pipeline.ID, Status: domain.RunStatusFailed,
+		Trigger: "manual", Error: &errMsg, DurationMs: &duration}
+	require.NoError(t, runStore.CreateRun(context.Background(), run))
+
+	store := newMemoryNotificationStore()
+	d := api.NewNotificationDispatcher(store, pipelineStore, runStore, secretKey)
+	d.Backoff = time.Millisecond
+	return notificationFixture{dispatcher: d, store: store, run: run}
+}
+
+func TestNotificationDispatcher_Failure_DeliversSignedPayload(t *testing.T) {
+	key := api.WebhookSecretKey("test-passphrase")
+	f := newNotificationFixture(t, key)
+	sink := newNotificationSink(t, 0)
+
+	// Configure via the API so the secret is stored encrypted, as in production.
+	srv, _, _ := newRunTestServer()
+	srv.Notifications = f.store
+	srv.WebhookSecretKey = key
+	rec := putNotificationConfig(srv, `{"url":"`+sink.URL+`","events":"failure","secret":"0123456789abcdef"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	f.dispatcher.OnRunComplete(context.Background(), f.run, domain.RunStatusFailed)
+	require.NoError(t, f.dispatcher.Wait(context.Background()))
+
+	require.Equal(t, 1, sink.received())
+	var got api.RunNotification
+	require.NoError(t, json.Unmarshal(sink.bodies[0], &got))
+	assert.Equal(t, "run_completed", got.Event)
+	assert.Equal(t, f.run.ID.String(), got.RunID)
+	assert.Equal(t, "default", got.Namespace)
+	assert.Equal(t, "silver", got.Layer)
+	assert.Equal(t, "orders", got.Pipeline)
+	assert.Equal(t, domain.RunStatusFailed, got.Status)
+	require.NotNil(t, got.DurationMs)
+	assert.Equal(t, 4200, *got.DurationMs)
+	require.NotNil(t, got.Error)
+	assert.Equal(t, "duckdb: table not found", *got.Error)
+
+	assert.Equal(t, "sha256="+api.SignWebhookBody("0123456789abcdef", sink.bodies[0]), sink.headers[0].Get("X-Signature-256"))
+}
+
+func TestNotificationDispatcher_FilteredSuccess_Skipped(t *testing.T) {
+	f := newNotificationFixture(t, nil)
+	sink := newNotificationSink(t, 0)
+	f.store.configs["default"] = &domain.NotificationConfig{URL: sink.URL, Events: domain.NotificationEventsFailure}
+
+	f.dispatcher.OnRunComplete(context.Background(), f.run, domain.RunStatusSuccess)
+	require.NoError(t, f.dispatcher.Wait(context.Background()))
+
+	assert.Zero(t, sink.received())
+}
+
+func TestNotificationDispatcher_NoConfig_Skipped(t *testing.T) {
+	f := newNotificationFixture(t, nil)
+	sink := newNotificationSink(t, 0)
+
+	f.dispatcher.OnRunComplete(context.Background(), f.run, domain.RunStatusFailed)
+	require.NoError(t, f.dispatcher.Wait(context.Background()))
+
+	assert.Zero(t, sink.received())
+}
+
+func TestNotificationDispatcher_Non2xx_RetriesWithBackoff(t *testing.T) {
+	f := newNotificationFixture(t, nil)
+	sink := newNotificationSink(t, 2)
+	f.store.configs["default"] = &domain.NotificationConfig{URL: sink.URL, Events: domain.NotificationEventsAll}
+
+	f.dispatcher.OnRunComplete(context.Background(), f.run, domain.RunStatusFailed)
+	require.NoError(t, f.dispatcher.Wait(context.Background()))
+
+	assert.Equal(t, 3, sink.received())
+	assert.Empty(t, sink.headers[2].Get("X-Signature-256"))
+}
+
+func TestNotificationDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	f := newNotificationFixture(t, nil)
+	f.dispatcher.MaxAttempts = 3
+	sink := newNotificationSink(t, 100)
+	f.store.configs["default"] = &domain.NotificationConfig{URL: sink.URL, Events: domain.NotificationEventsAll}
+
+	f.dispatcher.OnRunComplete(context.Background(), f.run, domain.RunStatusFailed)
+	require.NoError(t, f.dispatcher.Wait(context.Background()))
+
+	assert.Equal(t, 3, sink.received())
+}
+
+func TestNotificationDispatcher_CallerContextCancelled_KeepsRetrying(t *testing.T) {
+	f := newNotificationFixture(t, nil)
+	f.dispatcher.Backoff = 20 * time.Millisecond
+	sink := newNotificationSink(t, 2)
+	f.store.configs["default"] = &domain.NotificationConfig{URL: sink.URL, Events: domain.NotificationEventsAll}
+
+	// The executor's completion callback context ends as soon as it returns.
+	ctx, cancel := context.WithCancel(context.Background())
+	f.dispatcher.OnRunComplete(ctx, f.run, domain.RunStatusFailed)
+	cancel()
+	require.NoError(t, f.dispatcher.Wait(context.Background()))
+
+	assert.Equal(t, 3, sink.received())
+}
+
+func putNotificationConfig(srv *api.Server, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/namespaces/default/notifications", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNotificationConfig_PutGetDelete(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	srv.Notifications = newMemoryNotificationStore()
+	srv.WebhookSecretKey = api.WebhookSecretKey("test-passphrase")
+	router := api.NewRouter(srv)
+
+	rec := putNotificationConfig(srv, `{"url":"https://hooks.example.com/rat","secret":"0123456789abcdef"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/notifications", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"namespace":"default","url":"https://hooks.example.com/rat","events":"failure","has_secret":true}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/namespaces/default/notifications", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/notifications", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestNotificationConfig_InvalidRequests_Return400(t *testing.T) {
+	cases := map[string]string{
+		"missing url":        `{"events":"all"}`,
+		"relative url":       `{"url":"/hooks"}`,
+		"bad scheme":         `{"url":"ftp://example.com"}`,
+		"unknown events":     `{"url":"https://example.com","events":"sometimes"}`,
+		"short secret":       `{"url":"https://example.com","secret":"short"}`,
+		"secret without key": `{"url":"https://example.com","secret":"0123456789abcdef"}`,
+		"not json":           `nope`,
+	}
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, _, _ := newRunTestServer()
+			store := newMemoryNotificationStore()
+			srv.Notifications = store
+			rec := putNotificationConfig(srv, body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Empty(t, store.configs)
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// The OpenAPI document is generated rather than hand-written: paths come from
+// walking the live router (so a route can't be added without appearing, and
+// optional route groups only appear when mounted), and schemas are reflected
+// from the Go request/response types via their json tags. The tables below
+// only say which type belongs to which route.
+
+// openAPIRequestBodies maps "METHOD /path" to the type its JSON body decodes into.
+var openAPIRequestBodies = map[string]any{
+	"POST /api/v1/pipelines":                                                CreatePipelineRequest{},
+	"POST /api/v1/pipelines/batch":                                          BatchCreatePipelinesRequest{},
+	"PUT /api/v1/pipelines/{namespace}/{layer}/{name}":                      UpdatePipelineRequest{},
+	"POST /api/v1/pipelines/{namespace}/{layer}/{name}/backfill":            BackfillRequest{},
+	"POST /api/v1/pipelines/{namespace}/{layer}/{name}/preview":             PreviewRequest{},
+	"POST /api/v1/pipelines/{namespace}/{layer}/{name}/tests":               CreateQualityTestRequest{},
+	"POST /api/v1/pipelines/{namespace}/{layer}/{name}/triggers":            CreateTriggerRequest{},
+	"PUT /api/v1/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}": UpdateTriggerRequest{},
+	"POST /api/v1/runs":                            CreateRunRequest{},
+	"POST /api/v1/runs/{runID}/cancel":             CancelRunRequest{},
+	"POST /api/v1/runs/latest":                     LatestRunsRequest{},
+	"POST /api/v1/namespaces":                      CreateNamespaceRequest{},
+	"PUT /api/v1/namespaces/{name}":                UpdateNamespaceRequest{},
+	"POST /api/v1/schedules":                       CreateScheduleRequest{},
+	"PUT /api/v1/schedules/{scheduleID}":           UpdateScheduleRequest{},
+	"POST /api/v1/landing-zones":                   CreateLandingZoneRequest{},
+	"PUT /api/v1/landing-zones/{namespace}/{name}": UpdateLandingZoneRequest{},
+	"POST /api/v1/query":                           ExecuteQueryRequest{},
+}
+
+// openAPIResponse is the documented success response of a route.
+type openAPIResponse struct {
+	status int
+	body   any
+}
+
+// openAPIResponses maps "METHOD /path" to its success response. Routes not
+// listed are documented with a generic 2XX.
+var openAPIResponses = map[string]openAPIResponse{
+	"GET /api/v1/pipelines": {http.StatusOK, struct {
+		Pipelines []domain.Pipeline `json:"pipelines"`
+		Total     int               `json:"total"`
+	}{}},
+	"GET /api/v1/pipelines/{namespace}/{layer}/{name}": {http.StatusOK, domain.Pipeline{}},
+	"PUT /api/v1/pipelines/{namespace}/{layer}/{name}": {http.StatusOK, domain.Pipeline{}},
+	"GET /api/v1/runs": {http.StatusOK, struct {
+		Runs       []domain.Run `json:"runs"`
+		Total      int          `json:"total"`
+		NextCursor string       `json:"next_cursor,omitempty"`
+	}{}},
+	"GET /api/v1/runs/{runID}": {http.StatusOK, domain.Run{}},
+	"GET /api/v1/namespaces": {http.StatusOK, struct {
+		Namespaces []domain.Namespace `json:"namespaces"`
+		Total      int                `json:"total"`
+	}{}},
+	"GET /api/v1/schedules/{scheduleID}":                                    {http.StatusOK, domain.Schedule{}},
+	"GET /api/v1/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}": {http.StatusOK, domain.PipelineTrigger{}},
+	"PUT /api/v1/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}": {http.StatusOK, domain.PipelineTrigger{}},
+	"GET /api/v1/triggers": {http.StatusOK, struct {
+		Triggers []domain.PipelineTriggerListItem `json:"triggers"`
+		Total    int                              `json:"total"`
+	}{}},
+	"GET /api/v1/features": {http.StatusOK, domain.Features{}},
+}
+
+// openAPIQueryParam is a documented query parameter.
+type openAPIQueryParam struct {
+	name, typ, description string
+}
+
+var paginationParams = []openAPIQueryParam{
+	{"limit", "integer", "Page size (default 50, max 1000)"},
+	{"offset", "integer", "Rows to skip"},
+}
+
+// openAPIQueryParams maps "METHOD /path" to the query parameters it reads —
+// the filter structs (PipelineFilter, RunFilter, TriggerFilter) are built
+// from these rather than decoded from JSON.
+var openAPIQueryParams = map[string][]openAPIQueryParam{
+	"GET /api/v1/pipelines": append([]openAPIQueryParam{
+		{"namespace", "string", "PipelineFilter.Namespace"},
+		{"layer", "string", "PipelineFilter.Layer"},
+		{"search", "string", "Case-insensitive substring match on name and description"},
+		{"label", "string", "key:value; repeat to require several labels"},
+		{"sort", "string", "Field, prefixed with - for descending"},
+		{"include", "string", "latest_run to embed each pipeline's most recent run"},
+	}, paginationParams...),
+	"GET /api/v1/runs": append([]openAPIQueryParam{
+		{"namespace", "string", "RunFilter.Namespace"},
+		{"layer", "string", "RunFilter.Layer"},
+		{"pipeline", "string", "RunFilter.Pipeline"},
+		{"status", "string", "RunFilter.Status"},
+		{"trigger", "string", "Prefix match on the trigger label"},
+		{"parent_run_id", "string", "Children fired by a pipeline_success trigger"},
+		{"started_after", "string", "RFC 3339"},
+		{"started_before", "string", "RFC 3339"},
+		{"created_after", "string", "RFC 3339"},
+		{"created_before", "string", "RFC 3339"},
+		{"cursor", "string", "Opaque keyset cursor from next_cursor"},
+		{"sort", "string", "Field, prefixed with - for descending"},
+	}, paginationParams...),
+	"GET /api/v1/triggers": append([]openAPIQueryParam{
+		{"type", "string", "TriggerFilter.Type"},
+		{"namespace", "string", "TriggerFilter.Namespace"},
+		{"enabled", "boolean", "TriggerFilter.Enabled"},
+	}, paginationParams...),
+	"GET /api/v1/pipelines/{namespace}/{layer}/{name}/stats": {
+		{"window", "string", "Trailing window such as 7d or 24h (default 7d)"},
+	},
+	"GET /api/v1/lineage/pipelines/{namespace}/{layer}/{name}": {
+		{"depth", "integer", "Trigger hops to walk (default 1, max 10)"},
+	},
+}
+
+// openAPIRegexParam matches chi's {name:regex} parameters.
+var openAPIRegexParam = regexp.MustCompile(`\{([^}:]+):[^}]*\}`)
+
+// openAPIPathParam matches {name} parameters.
+var openAPIPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// MountOpenAPIRoute registers GET /openapi.json, describing the routes of
+// router. The document is built on first request, once every route exists.
+func MountOpenAPIRoute(router chi.Router) {
+	var (
+		once sync.Once
+		doc  []byte
+		err  error
+	)
+	router.Get("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			var spec map[string]any
+			if spec, err = BuildOpenAPISpec(router); err == nil {
+				doc, err = json.Marshal(spec)
+			}
+		})
+		if err != nil {
+			internalError(w, "failed to build OpenAPI document", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(doc)
+	})
+}
+
+// BuildOpenAPISpec returns an OpenAPI 3.0 document for the /api/v1 and
+// webhook routes registered on router.
+func BuildOpenAPISpec(router chi.Routes) (map[string]any, error) {
+	b := &openAPIBuilder{schemas: map[string]any{}}
+	errorRef := b.schema(reflect.TypeOf(APIError{}))
+
+	paths := map[string]map[string]any{}
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		path := openAPIPath(route)
+		if !strings.HasPrefix(path, "/api/v1/") && !strings.HasPrefix(path, "/webhooks") {
+			return nil
+		}
+		key := method + " " + path
+		op := map[string]any{
+			"operationId": openAPIOperationID(method, path),
+			"tags":        []string{openAPITag(path)},
+			"responses":   b.responses(key, errorRef),
+		}
+		if params := b.parameters(key, path); len(params) > 0 {
+			op["parameters"] = params
+		}
+		if body, ok := openAPIRequestBodies[key]; ok {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": b.schema(reflect.TypeOf(body))}},
+			}
+		}
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(method)] = op
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "RAT API",
+			"version": "v1",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": b.schemas},
+	}, nil
+}
+
+// openAPIPath converts a chi route pattern to an OpenAPI path template.
+func openAPIPath(route string) string {
+	path := openAPIRegexParam.ReplaceAllString(route, "{$1}")
+	if strings.HasSuffix(path, "/*") {
+		path = strings.TrimSuffix(path, "*") + "{path}"
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+// openAPIOperationID derives a stable operationId, e.g.
+// "get_pipelines_by_namespace_layer_name" for GET /api/v1/pipelines/{namespace}/{layer}/{name}.
+func openAPIOperationID(method, path string) string {
+	parts := []string{strings.ToLower(method)}
+	var params []string
+	for _, seg := range strings.Split(strings.TrimPrefix(path, "/api/v1"), "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") {
+			params = append(params, strings.Trim(seg, "{}"))
+			continue
+		}
+		if len(params) > 0 {
+			parts = append(parts, "by", strings.Join(params, "_"))
+			params = nil
+		}
+		parts = append(parts, strings.ReplaceAll(seg, "-", "_"))
+	}
+	if len(params) > 0 {
+		parts = append(parts, "by", strings.Join(params, "_"))
+	}
+	return strings.Join(parts, "_")
+}
+
+// openAPITag groups operations by their first path segment under /api/v1.
+func openAPITag(path string) string {
+	seg := strings.SplitN(strings.TrimPrefix(strings.TrimPrefix(path, "/api/v1"), "/"), "/", 2)[0]
+	if seg == "" {
+		return "root"
+	}
+	return seg
+}
+
+// openAPIBuilder accumulates named schemas into components while reflecting.
+type openAPIBuilder struct {
+	schemas map[string]any
+}
+
+func (b *openAPIBuilder) parameters(key, path string) []any {
+	var params []any
+	for _, m := range openAPIPathParam.FindAllStringSubmatch(path, -1) {
+		params = append(params, map[string]any{
+			"name": m[1], "in": "path", "required": true,
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+	for _, q := range openAPIQueryParams[key] {
+		params = append(params, map[string]any{
+			"name": q.name, "in": "query", "description": q.description,
+			"schema": map[string]any{"type": q.typ},
+		})
+	}
+	return params
+}
+
+func (b *openAPIBuilder) responses(key string, errorRef map[string]any) map[string]any {
+	resps := map[string]any{
+		"default": map[string]any{
+			"description": "Error envelope",
+			"content":     map[string]any{"application/json": map[string]any{"schema": errorRef}},
+		},
+	}
+	if resp, ok := openAPIResponses[key]; ok {
+		resps[strconv.Itoa(resp.status)] = map[string]any{
+			"description": http.StatusText(resp.status),
+			"content":     map[string]any{"application/json": map[string]any{"schema": b.schema(reflect.TypeOf(resp.body))}},
+		}
+	} else {
+		resps["2XX"] = map[string]any{"description": "Success"}
+	}
+	return resps
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+)
+
+// schema returns the JSON schema of t. Named structs are added to
+// components and referenced; everything else is inlined.
+func (b *openAPIBuilder) schema(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case uuidType:
+		return map[string]any{"type": "string", "format": "uuid"}
+	case rawMessageType:
+		return map[string]any{}
+	case durationType:
+		return map[string]any{"type": "integer", "format": "int64"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := b.schema(t.Elem())
+		if _, isRef := s["$ref"]; isRef {
+			// $ref siblings are ignored in 3.0; wrap to carry nullable.
+			return map[string]any{"allOf": []any{s}, "nullable": true}
+		}
+		out := map[string]any{"nullable": true}
+		for k, v := range s {
+			out[k] = v
+		}
+		return out
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := t.Name()
+		if _, seen := b.schemas[name]; !seen {
+			b.schemas[name] = map[string]any{} // reserve first: types may be recursive
+			b.schemas[name] = b.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]any{}
+}
+
+// structSchema reflects a struct's exported fields by their json tags.
+// Embedded structs without a tag are flattened, as encoding/json does.
+// Fields without omitempty that aren't pointers are marked required.
+func (b *openAPIBuilder) structSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	var required []string
+
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if f.Anonymous && name == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					collect(ft)
+					continue
+				}
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			props[name] = b.schema(f.Type)
+			if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+				required = append(required, name)
+			}
+		}
+	}
+	collect(t)
+
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		sort.Strings(required)
+		s["required"] = required
+	}
+	return s
+}
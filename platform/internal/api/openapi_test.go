@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fetchOpenAPISpec(t *testing.T) map[string]any {
+	t.Helper()
+	srv, _, _ := newTriggerTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	return spec
+}
+
+func TestOpenAPI_DocumentParsesWithKeyPaths(t *testing.T) {
+	spec := fetchOpenAPISpec(t)
+	assert.Equal(t, "3.0.3", spec["openapi"])
+
+	paths := spec["paths"].(map[string]any)
+	for path, methods := range map[string][]string{
+		"/api/v1/pipelines":                                     {"get", "post"},
+		"/api/v1/pipelines/{namespace}/{layer}/{name}":          {"get", "put", "delete"},
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/triggers": {"get", "post"},
+		"/api/v1/runs":         {"get", "post"},
+		"/api/v1/runs/{runID}": {"get"},
+		"/api/v1/namespaces":   {"get", "post"},
+		"/api/v1/files/{path}": {"get", "put", "delete"},
+	} {
+		require.Contains(t, paths, path)
+		for _, m := range methods {
+			assert.Contains(t, paths[path], m, "%s %s", m, path)
+		}
+	}
+	// Non-API routes stay out.
+	assert.NotContains(t, paths, "/health")
+	assert.NotContains(t, paths, "/openapi.json")
+}
+
+func TestOpenAPI_ShapesAndErrorEnvelope(t *testing.T) {
+	spec := fetchOpenAPISpec(t)
+	paths := spec["paths"].(map[string]any)
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+
+	createTrigger := paths["/api/v1/pipelines/{namespace}/{layer}/{name}/triggers"].(map[string]any)["post"].(map[string]any)
+	body := createTrigger["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	assert.Equal(t, "#/components/schemas/CreateTriggerRequest", body["$ref"])
+
+	trigger := schemas["CreateTriggerRequest"].(map[string]any)
+	props := trigger["properties"].(map[string]any)
+	assert.Contains(t, props, "type")
+	assert.Contains(t, props, "cooldown_seconds")
+	assert.Equal(t, true, props["enabled"].(map[string]any)["nullable"])
+
+	// Every operation documents the error envelope.
+	errResp := createTrigger["responses"].(map[string]any)["default"].(map[string]any)
+	errSchema := errResp["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	assert.Equal(t, "#/components/schemas/APIError", errSchema["$ref"])
+	require.Contains(t, schemas, "APIErrorDetail")
+
+	// List filters are documented as query parameters.
+	listRuns := paths["/api/v1/runs"].(map[string]any)["get"].(map[string]any)
+	var names []string
+	for _, p := range listRuns["parameters"].([]any) {
+		names = append(names, p.(map[string]any)["name"].(string))
+	}
+	assert.Contains(t, names, "status")
+	assert.Contains(t, names, "cursor")
+
+	pipeline := schemas["Pipeline"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, "uuid", pipeline["id"].(map[string]any)["format"])
+	assert.Contains(t, pipeline, "priority")
+}
@@ -0,0 +1,434 @@
+package api
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// pipelineBundleFormatVersion is written to every bundle manifest. Import
+// rejects bundles with a newer version than it understands.
+const pipelineBundleFormatVersion = 1
+
+// Limits on an imported bundle once decompressed. maxUploadSize only caps the
+// compressed upload, and gzip expands by orders of magnitude.
+const (
+	maxBundleSize     = maxUploadSize // all entries together
+	maxBundleFileSize = 8 << 20       // a single entry
+	maxBundleEntries  = 1000
+)
+
+// errBundleTooLarge is returned by readPipelineBundle when an archive exceeds
+// one of the bundle limits.
+var errBundleTooLarge = errors.New("bundle too large")
+
+// Entry names inside a pipeline bundle archive.
+const (
+	bundleManifestName = "pipeline.json"
+	bundleFilesDir     = "files/"
+)
+
+// PipelineBundleManifest is the pipeline.json entry of a bundle produced by
+// GET /pipelines/{namespace}/{layer}/{name}/export. IDs, run history and
+// published versions are environment-specific and not included.
+type PipelineBundleManifest struct {
+	FormatVersion int                    `json:"format_version"`
+	ExportedAt    time.Time              `json:"exported_at"`
+	Pipeline      BundlePipeline         `json:"pipeline"`
+	Triggers      []CreateTriggerRequest `json:"triggers"`
+	Schedules     []BundleSchedule       `json:"schedules"`
+}
+
+// BundlePipeline is the pipeline metadata carried in a bundle. S3Prefix is
+// relative to the namespace and only set for a custom prefix.
+type BundlePipeline struct {
+	Namespace   string            `json:"namespace"`
+	Layer       string            `json:"layer"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Priority    int               `json:"priority"`
+	S3Prefix    string            `json:"s3_prefix,omitempty"`
+}
+
+// BundleSchedule is one schedule of a bundled pipeline.
+type BundleSchedule struct {
+	Cron    string `json:"cron"`
+	Enabled bool   `json:"enabled"`
+}
+
+// bundleFile is a draft file read from an uploaded bundle, keyed by its path
+// relative to the pipeline's storage prefix.
+type bundleFile struct {
+	rel     string
+	content []byte
+}
+
+// HandleExportPipeline streams a pipeline as a gzipped tar bundle.
+// GET /api/v1/pipelines/{namespace}/{layer}/{name}/export
+//
+// The archive holds pipeline.json (metadata, triggers, schedules) followed by
+// the draft files under files/, each read from storage and written to the
+// response in turn. Webhook triggers are exported without their token and
+// signing secret. Requires read access to the pipeline.
+func (s *Server) HandleExportPipeline(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if !s.requireAccess(w, r, "pipeline", pipeline.ID.String(), "read") {
+		return
+	}
+
+	manifest, err := s.buildBundleManifest(r, pipeline)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	files, err := s.Storage.ListFiles(r.Context(), pipeline.StoragePrefix())
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s-%s.tar.gz", namespace, layer, name)
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err = writeTarEntry(tw, bundleManifestName, manifestJSON, manifest.ExportedAt)
+	for _, f := range files {
+		rel, ok := bundleRelPath(pipeline, f.Path)
+		if !ok || err != nil {
+			continue
+		}
+		var content *FileContent
+		if content, err = s.Storage.ReadFile(r.Context(), f.Path); err != nil || content == nil {
+			continue // content == nil: deleted since listing
+		}
+		err = writeTarEntry(tw, bundleFilesDir+rel, []byte(content.Content), content.Modified)
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		// Headers are already sent — the truncated archive is all we can give.
+		slog.Error("pipeline export aborted", "pipeline", namespace+"/"+layer+"/"+name, "error", err)
+	}
+}
+
+// buildBundleManifest collects a pipeline's metadata, triggers and schedules.
+func (s *Server) buildBundleManifest(r *http.Request, p *domain.Pipeline) (*PipelineBundleManifest, error) {
+	manifest := &PipelineBundleManifest{
+		FormatVersion: pipelineBundleFormatVersion,
+		ExportedAt:    time.Now().UTC(),
+		Pipeline: BundlePipeline{
+			Namespace:   p.Namespace,
+			Layer:       string(p.Layer),
+			Name:        p.Name,
+			Type:        p.Type,
+			Description: p.Description,
+			Labels:      p.Labels,
+			Priority:    p.Priority,
+		},
+		Triggers:  []CreateTriggerRequest{},
+		Schedules: []BundleSchedule{},
+	}
+	if p.HasCustomS3Prefix() {
+		manifest.Pipeline.S3Prefix = strings.TrimPrefix(p.StoragePrefix(), p.Namespace+"/")
+	}
+
+	if s.Triggers != nil {
+		triggers, err := s.Triggers.ListTriggers(r.Context(), p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list triggers: %w", err)
+		}
+		for _, t := range triggers {
+			enabled, cooldown := t.Enabled, t.CooldownSeconds
+			manifest.Triggers = append(manifest.Triggers, CreateTriggerRequest{
+				Type:            string(t.Type),
+				Config:          redactTriggerConfig(t),
+				Enabled:         &enabled,
+				CooldownSeconds: &cooldown,
+			})
+		}
+	}
+
+	schedules, err := s.Schedules.ListSchedules(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("list schedules: %w", err)
+	}
+	for _, sched := range schedules {
+		if sched.PipelineID == p.ID {
+			manifest.Schedules = append(manifest.Schedules, BundleSchedule{Cron: sched.CronExpr, Enabled: sched.Enabled})
+		}
+	}
+	return manifest, nil
+}
+
+// bundleRelPath maps a storage path of p to its path inside the bundle's
+// files/ directory.
+func bundleRelPath(p *domain.Pipeline, storagePath string) (string, bool) {
+	rel, ok := strings.CutPrefix(storagePath, p.StoragePrefix())
+	return rel, ok && rel != ""
+}
+
+// writeTarEntry writes one regular file to tw.
+func writeTarEntry(tw *tar.Writer, name string, content []byte, modified time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: modified,
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// HandleImportPipeline recreates a pipeline from a bundle made by
+// HandleExportPipeline, uploaded as the "bundle" field of a multipart form.
+// POST /api/v1/pipelines/import?namespace=
+//
+// ?namespace= imports into another namespace than the bundle's (required for
+// namespace-scoped API keys, which can't see inside the archive). The
+// pipeline, its triggers and schedules are created and its files written,
+// then the files are published as the first version. Triggers are validated
+// as on create: webhook triggers get a fresh token, returned once in the
+// response, and a pipeline_success trigger needs its upstream pipeline to
+// exist. Responds 409 if the pipeline already exists.
+func (s *Server) HandleImportPipeline(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		errorJSON(w, "bundle too large (max 32MB)", "INVALID_ARGUMENT", http.StatusRequestEntityTooLarge)
+		return
+	}
+	file, _, err := r.FormFile("bundle")
+	if err != nil {
+		errorJSON(w, "bundle form field is required", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	manifest, files, err := readPipelineBundle(file)
+	if errors.Is(err, errBundleTooLarge) {
+		errorJSON(w, err.Error(), "INVALID_ARGUMENT", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		errorJSON(w, "invalid bundle: "+err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	bp := manifest.Pipeline
+	if ns := r.URL.Query().Get("namespace"); ns != "" {
+		bp.Namespace = ns
+	}
+	req := CreatePipelineRequest{
+		Namespace:   bp.Namespace,
+		Layer:       bp.Layer,
+		Name:        bp.Name,
+		Type:        bp.Type,
+		Description: bp.Description,
+		Labels:      bp.Labels,
+		Priority:    bp.Priority,
+	}
+	if bp.S3Prefix != "" {
+		req.S3Prefix = bp.Namespace + "/" + bp.S3Prefix
+	}
+	if msg := validateCreatePipelineRequest(&req); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	for _, sched := range manifest.Schedules {
+		if _, err := cronParser.Parse(sched.Cron); err != nil {
+			errorJSON(w, "invalid schedule cron expression: "+err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+
+	pipeline := newPipelineFromRequest(r, req)
+
+	var triggers []*domain.PipelineTrigger
+	var triggerReqs []*http.Request
+	if len(manifest.Triggers) > 0 {
+		if s.Triggers == nil {
+			errorJSON(w, "triggers are not supported", "UNIMPLEMENTED", http.StatusNotImplemented)
+			return
+		}
+		for _, treq := range manifest.Triggers {
+			trigger, tr, ok := s.prepareTrigger(w, r, pipeline, treq)
+			if !ok {
+				return
+			}
+			triggers = append(triggers, trigger)
+			triggerReqs = append(triggerReqs, tr)
+		}
+	}
+
+	if err := s.Pipelines.CreatePipeline(r.Context(), pipeline); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			errorJSON(w, "a pipeline with this namespace, layer, and name already exists", "ALREADY_EXISTS", http.StatusConflict)
+		} else {
+			internalError(w, "internal error", err)
+		}
+		return
+	}
+	if !s.createPipelineTriggers(w, r, pipeline, triggers) {
+		return
+	}
+
+	if written, err := s.writeBundleFiles(r, pipeline, files); err != nil {
+		for _, p := range written {
+			if delErr := s.Storage.DeleteFile(r.Context(), p); delErr != nil {
+				slog.Warn("pipeline import: failed to remove file", "path", p, "error", delErr)
+			}
+		}
+		if delErr := s.Pipelines.HardDeletePipeline(r.Context(), pipeline.ID); delErr != nil {
+			slog.Error("failed to roll back pipeline after import failed",
+				"pipeline", pipeline.Namespace+"/"+string(pipeline.Layer)+"/"+pipeline.Name,
+				"error", delErr)
+		}
+		internalError(w, "failed to write pipeline files", err)
+		return
+	}
+
+	schedules := make([]domain.Schedule, 0, len(manifest.Schedules))
+	for _, bs := range manifest.Schedules {
+		sched := &domain.Schedule{PipelineID: pipeline.ID, CronExpr: bs.Cron, Enabled: bs.Enabled}
+		if err := s.Schedules.CreateSchedule(r.Context(), sched); err != nil {
+			// The pipeline is usable without it; report what was created.
+			slog.Warn("pipeline import: failed to create schedule", "cron", bs.Cron, "error", err)
+			continue
+		}
+		schedules = append(schedules, *sched)
+	}
+
+	s.afterPipelineCreated(r.Context(), pipeline)
+
+	created := make([]map[string]interface{}, len(triggers))
+	for i, t := range triggers {
+		created[i] = s.triggerToResponse(*t, triggerReqs[i])
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"namespace": pipeline.Namespace,
+		"layer":     pipeline.Layer,
+		"name":      pipeline.Name,
+		"s3_path":   pipeline.S3Path,
+		"files":     len(files),
+		"triggers":  created,
+		"schedules": schedules,
+	})
+}
+
+// readPipelineBundle reads the manifest and draft files from a gzipped tar
+// bundle. Entries other than pipeline.json and files/... are ignored.
+func readPipelineBundle(rd io.Reader) (*PipelineBundleManifest, []bundleFile, error) {
+	gz, err := gzip.NewReader(rd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a gzip archive")
+	}
+	defer gz.Close()
+
+	// One byte over the budget tells an oversized archive from one that
+	// fills it exactly.
+	limited := &io.LimitedReader{R: gz, N: maxBundleSize + 1}
+	var manifest *PipelineBundleManifest
+	var files []bundleFile
+	tr := tar.NewReader(limited)
+	for entries := 0; ; entries++ {
+		hdr, err := tr.Next()
+		if limited.N == 0 {
+			return nil, nil, fmt.Errorf("%w (max %d MB decompressed)", errBundleTooLarge, maxBundleSize>>20)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("read archive: %w", err)
+		}
+		if entries == maxBundleEntries {
+			return nil, nil, fmt.Errorf("%w (max %d entries)", errBundleTooLarge, maxBundleEntries)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > maxBundleFileSize {
+			return nil, nil, fmt.Errorf("%w: %s exceeds %d MB", errBundleTooLarge, hdr.Name, maxBundleFileSize>>20)
+		}
+		switch {
+		case hdr.Name == bundleManifestName:
+			manifest = &PipelineBundleManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", bundleManifestName, err)
+			}
+		case strings.HasPrefix(hdr.Name, bundleFilesDir):
+			rel := strings.TrimPrefix(hdr.Name, bundleFilesDir)
+			if validateFilePath(rel) != "" || path.Clean(rel) != rel {
+				return nil, nil, fmt.Errorf("invalid file path %q", hdr.Name)
+			}
+			content, err := io.ReadAll(tr)
+			if limited.N == 0 {
+				return nil, nil, fmt.Errorf("%w (max %d MB decompressed)", errBundleTooLarge, maxBundleSize>>20)
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("read %s: %w", hdr.Name, err)
+			}
+			files = append(files, bundleFile{rel: rel, content: content})
+		}
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("missing %s", bundleManifestName)
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > pipelineBundleFormatVersion {
+		return nil, nil, fmt.Errorf("unsupported format_version %d", manifest.FormatVersion)
+	}
+	return manifest, files, nil
+}
+
+// writeBundleFiles writes imported files under the pipeline's storage prefix
+// and returns the paths written.
+func (s *Server) writeBundleFiles(r *http.Request, p *domain.Pipeline, files []bundleFile) ([]string, error) {
+	var written []string
+	for _, f := range files {
+		dst := p.StoragePrefix() + f.rel
+		if _, err := s.Storage.WriteFile(r.Context(), dst, f.content); err != nil {
+			return written, fmt.Errorf("write %s: %w", dst, err)
+		}
+		written = append(written, dst)
+	}
+	return written, nil
+}
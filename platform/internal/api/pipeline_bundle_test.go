@@ -0,0 +1,262 @@
+package api_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// newBundleTestServer returns a server with default/silver/orders (two files,
+// a schedule, a pipeline_success trigger on bronze/raw_orders and a webhook
+// trigger) plus its upstream.
+func newBundleTestServer() (*api.Server, *memoryPipelineStore, *memoryStorageStore, *memoryTriggerStore) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	storageStore := newMemoryStorageStore()
+	srv.Storage = storageStore
+
+	ordersID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "raw_orders"},
+		{ID: ordersID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql",
+			Description: "Cleaned orders", Labels: map[string]string{"team": "sales"}, Priority: 5,
+			S3Path: "default/pipelines/silver/orders/"},
+	}
+	storageStore.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT * FROM raw_orders")
+	storageStore.files["default/pipelines/silver/orders/tests/quality/no_nulls.sql"] = []byte("SELECT 1 WHERE false")
+
+	srv.Schedules.(*memoryScheduleStore).schedules = []domain.Schedule{
+		{ID: uuid.New(), PipelineID: ordersID, CronExpr: "0 * * * *", Enabled: true},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: ordersID, Type: domain.TriggerTypePipelineSuccess, Enabled: true, CooldownSeconds: 60,
+			Config: json.RawMessage(`{"namespace":"default","layer":"bronze","pipeline":"raw_orders"}`)},
+		{ID: uuid.New(), PipelineID: ordersID, Type: domain.TriggerTypeWebhook, Enabled: true,
+			Config: json.RawMessage(`{"token_hash":"deadbeef"}`)},
+	}
+	return srv, pipelineStore, storageStore, triggerStore
+}
+
+func exportPipeline(t *testing.T, srv *api.Server, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/"+path+"/export", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func importPipeline(t *testing.T, srv *api.Server, bundle []byte, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("bundle", "bundle.tar.gz")
+	require.NoError(t, err)
+	_, err = fw.Write(bundle)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/import"+query, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+// untarBundle returns the entries of a gzipped tar by name.
+func untarBundle(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	entries := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[hdr.Name] = content
+	}
+	return entries
+}
+
+func TestExportPipeline_BundleContainsFilesAndConfig(t *testing.T) {
+	srv, _, _, _ := newBundleTestServer()
+
+	rec := exportPipeline(t, srv, "default/silver/orders")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/gzip", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "default-silver-orders.tar.gz")
+
+	entries := untarBundle(t, rec.Body.Bytes())
+	assert.Equal(t, "SELECT * FROM raw_orders", string(entries["files/pipeline.sql"]))
+	assert.Equal(t, "SELECT 1 WHERE false", string(entries["files/tests/quality/no_nulls.sql"]))
+
+	var manifest api.PipelineBundleManifest
+	require.NoError(t, json.Unmarshal(entries["pipeline.json"], &manifest))
+	assert.Equal(t, 1, manifest.FormatVersion)
+	assert.Equal(t, "orders", manifest.Pipeline.Name)
+	assert.Equal(t, "Cleaned orders", manifest.Pipeline.Description)
+	assert.Equal(t, map[string]string{"team": "sales"}, manifest.Pipeline.Labels)
+	assert.Equal(t, 5, manifest.Pipeline.Priority)
+	assert.Empty(t, manifest.Pipeline.S3Prefix)
+	assert.Equal(t, []api.BundleSchedule{{Cron: "0 * * * *", Enabled: true}}, manifest.Schedules)
+	require.Len(t, manifest.Triggers, 2)
+	assert.Equal(t, "pipeline_success", manifest.Triggers[0].Type)
+	require.NotNil(t, manifest.Triggers[0].CooldownSeconds)
+	assert.Equal(t, 60, *manifest.Triggers[0].CooldownSeconds)
+	assert.NotContains(t, string(manifest.Triggers[1].Config), "token_hash", "webhook secrets are not exported")
+}
+
+func TestExportPipeline_UnknownPipeline_Returns404(t *testing.T) {
+	srv, _, _, _ := newBundleTestServer()
+
+	rec := exportPipeline(t, srv, "default/silver/missing")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestImportPipeline_RecreatesPipelineAndTriggers(t *testing.T) {
+	src, _, _, _ := newBundleTestServer()
+	rec := exportPipeline(t, src, "default/silver/orders")
+	require.Equal(t, http.StatusOK, rec.Code)
+	bundle := rec.Body.Bytes()
+
+	// Target environment has the upstream pipeline but not orders.
+	dst, dstPipelines, dstStorage, dstTriggers := newBundleTestServer()
+	dstPipelines.pipelines = dstPipelines.pipelines[:1]
+	dstStorage.files = map[string][]byte{}
+	dstTriggers.triggers = nil
+	dstSchedules := dst.Schedules.(*memoryScheduleStore)
+	dstSchedules.schedules = nil
+
+	rec = importPipeline(t, dst, bundle, "")
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, float64(2), resp["files"])
+	triggers, _ := resp["triggers"].([]interface{})
+	require.Len(t, triggers, 2)
+	assert.NotEmpty(t, triggers[1].(map[string]interface{})["webhook_token"], "imported webhook gets a fresh token")
+
+	p, err := dstPipelines.GetPipeline(context.Background(), "default", "silver", "orders")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, "Cleaned orders", p.Description)
+	assert.Equal(t, 5, p.Priority)
+
+	assert.Equal(t, "SELECT * FROM raw_orders", string(dstStorage.files["default/pipelines/silver/orders/pipeline.sql"]))
+	assert.Contains(t, dstStorage.files, "default/pipelines/silver/orders/tests/quality/no_nulls.sql")
+
+	require.Len(t, dstTriggers.triggers, 2)
+	for _, tr := range dstTriggers.triggers {
+		assert.Equal(t, p.ID, tr.PipelineID)
+	}
+	assert.Equal(t, domain.TriggerTypePipelineSuccess, dstTriggers.triggers[0].Type)
+	assert.Equal(t, 60, dstTriggers.triggers[0].CooldownSeconds)
+	assert.NotContains(t, string(dstTriggers.triggers[1].Config), "deadbeef")
+
+	require.Len(t, dstSchedules.schedules, 1)
+	assert.Equal(t, p.ID, dstSchedules.schedules[0].PipelineID)
+	assert.Equal(t, "0 * * * *", dstSchedules.schedules[0].CronExpr)
+}
+
+func TestImportPipeline_IntoOtherNamespace(t *testing.T) {
+	src, _, _, _ := newBundleTestServer()
+	src.Triggers.(*memoryTriggerStore).triggers = nil
+	rec := exportPipeline(t, src, "default/silver/orders")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	dst, dstPipelines, dstStorage, _ := newBundleTestServer()
+	rec = importPipeline(t, dst, rec.Body.Bytes(), "?namespace=staging")
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	p, err := dstPipelines.GetPipeline(context.Background(), "staging", "silver", "orders")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Contains(t, dstStorage.files, "staging/pipelines/silver/orders/pipeline.sql")
+}
+
+func TestImportPipeline_AlreadyExists_Returns409(t *testing.T) {
+	srv, _, _, triggerStore := newBundleTestServer()
+	rec := exportPipeline(t, srv, "default/silver/orders")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = importPipeline(t, srv, rec.Body.Bytes(), "")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Len(t, triggerStore.triggers, 2, "no triggers created for the rejected import")
+}
+
+func TestImportPipeline_InvalidBundle_Returns400(t *testing.T) {
+	srv, _, _, _ := newBundleTestServer()
+
+	rec := importPipeline(t, srv, []byte("not a tarball"), "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// gzipTar builds a gzipped tar bundle with a valid manifest followed by files
+// of the given sizes (zero bytes, so they compress to almost nothing).
+func gzipTar(t *testing.T, sizes ...int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	manifest := []byte(`{"format_version":1,"pipeline":{"namespace":"default","layer":"silver","name":"bomb","type":"sql"}}`)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "pipeline.json", Mode: 0o644, Size: int64(len(manifest))}))
+	_, err := tw.Write(manifest)
+	require.NoError(t, err)
+	for i, size := range sizes {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: fmt.Sprintf("files/f%d.sql", i), Mode: 0o644, Size: int64(size)}))
+		_, err := tw.Write(make([]byte, size))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestImportPipeline_DecompressedBundleTooLarge_Returns413(t *testing.T) {
+	for name, sizes := range map[string][]int{
+		"total":   {7 << 20, 7 << 20, 7 << 20, 7 << 20, 7 << 20},
+		"entry":   {9 << 20},
+		"entries": make([]int, 1001),
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv, pipelineStore, storageStore, _ := newBundleTestServer()
+			bundle := gzipTar(t, sizes...)
+			require.Less(t, len(bundle), 1<<20, "the upload itself is small")
+
+			rec := importPipeline(t, srv, bundle, "")
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code, rec.Body.String())
+			assert.Len(t, pipelineStore.pipelines, 2)
+			assert.NotContains(t, storageStore.files, "default/pipelines/silver/bomb/f0.sql")
+		})
+	}
+}
+
+func TestImportPipeline_BundleWithinLimits_Imports(t *testing.T) {
+	srv, pipelineStore, _, _ := newBundleTestServer()
+
+	rec := importPipeline(t, srv, gzipTar(t, 1<<20, 1<<20), "")
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Len(t, pipelineStore.pipelines, 3)
+}
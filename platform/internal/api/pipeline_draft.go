@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// SaveDraftRequest is the JSON body for
+// PUT /api/v1/pipelines/{namespace}/{layer}/{name}/draft/{path}.
+type SaveDraftRequest struct {
+	Content string `json:"content"`
+}
+
+// HandleSaveDraft writes one draft file of a pipeline, guarded by the
+// pipeline's draft_revision so concurrent editors can't clobber each other.
+// PUT /api/v1/pipelines/{namespace}/{layer}/{name}/draft/{path}
+//
+// {path} is relative to the pipeline's s3_path. If-Match must carry the
+// draft_revision the editor's copy is based on (as returned by the pipeline
+// detail or the previous save). When another save — or any other draft write,
+// e.g. PUT /files or a draft restore — has landed since, the save is rejected
+// with 409 and the current revision in the ETag header, so the UI can fetch
+// the latest content and prompt for a merge. On success the response and
+// ETag carry the new revision for the next save.
+//
+// The revision is claimed before the file is written, so two saves on the
+// same base can't both pass. If the write itself then fails, the claim is
+// rolled back so the editor can retry on the same base.
+func (s *Server) HandleSaveDraft(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+	relPath := chi.URLParam(r, "*")
+
+	if msg := validateFilePath(relPath); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	base, hadIfMatch, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		errorJSON(w, err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if !hadIfMatch {
+		errorJSON(w, "If-Match with the base draft_revision is required", "FAILED_PRECONDITION", http.StatusPreconditionRequired)
+		return
+	}
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if !s.requireAccess(w, r, "pipeline", pipeline.ID.String(), "write") {
+		return
+	}
+
+	var req SaveDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	revision, err := s.Pipelines.BumpDraftRevision(r.Context(), pipeline.ID, *base)
+	if errors.Is(err, domain.ErrDraftRevisionMismatch) {
+		setETag(w, revision)
+		msg := fmt.Sprintf("draft revision mismatch; base %d, current %d", *base, revision)
+		errorJSON(w, msg, "DRAFT_REVISION_MISMATCH", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if revision == 0 {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	path := pipeline.StoragePrefix() + relPath
+	versionID, err := s.Storage.WriteFile(r.Context(), path, []byte(req.Content))
+	if err != nil {
+		if rbErr := s.Pipelines.RollbackDraftRevision(r.Context(), pipeline.ID, revision, pipeline.DraftDirty); rbErr != nil {
+			slog.Error("failed to roll back draft revision", "pipeline_id", pipeline.ID, "error", rbErr)
+		}
+		internalError(w, "failed to write draft file", err)
+		return
+	}
+
+	// draft_revision and draft_dirty changed.
+	if s.PipelineCache != nil {
+		s.PipelineCache.Delete(pipelineCacheKey(namespace, layer, name))
+	}
+	s.invalidatePreview(namespace, layer, name)
+
+	if s.EventBus != nil {
+		_ = s.EventBus.Publish(r.Context(), "file_uploaded", map[string]interface{}{
+			"path":      path,
+			"namespace": namespace,
+			"size":      int64(len(req.Content)),
+		})
+	}
+
+	setETag(w, revision)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"path":           path,
+		"version_id":     versionID,
+		"draft_revision": revision,
+	})
+}
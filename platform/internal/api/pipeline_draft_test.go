@@ -0,0 +1,123 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+func newDraftTestServer() (*api.Server, *memoryPipelineStore, *memoryStorageStore) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql",
+			S3Path: "default/pipelines/silver/orders/", DraftRevision: 3},
+	}
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT 1")
+	return srv, store, storage
+}
+
+func saveDraft(t *testing.T, srv *api.Server, file, ifMatch, content string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, _ := json.Marshal(api.SaveDraftRequest{Content: content})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/silver/orders/draft/"+file, strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSaveDraft_CurrentBase_WritesAndBumpsRevision(t *testing.T) {
+	srv, store, storage := newDraftTestServer()
+
+	rec := saveDraft(t, srv, "pipeline.sql", `"3"`, "SELECT 2")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, float64(4), resp["draft_revision"])
+	assert.Equal(t, "default/pipelines/silver/orders/pipeline.sql", resp["path"])
+
+	assert.Equal(t, "SELECT 2", string(storage.files["default/pipelines/silver/orders/pipeline.sql"]))
+	assert.Equal(t, int64(4), store.pipelines[0].DraftRevision)
+	assert.True(t, store.pipelines[0].DraftDirty)
+
+	// The returned ETag is the base for the next save.
+	rec = saveDraft(t, srv, "pipeline.sql", rec.Header().Get("ETag"), "SELECT 3")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, `"5"`, rec.Header().Get("ETag"))
+}
+
+func TestSaveDraft_StaleBase_Returns409(t *testing.T) {
+	srv, store, storage := newDraftTestServer()
+
+	rec := saveDraft(t, srv, "pipeline.sql", `"2"`, "SELECT 2")
+	require.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"), "409 carries the current revision")
+	assert.Contains(t, rec.Body.String(), "DRAFT_REVISION_MISMATCH")
+
+	assert.Equal(t, "SELECT 1", string(storage.files["default/pipelines/silver/orders/pipeline.sql"]), "stale save must not write")
+	assert.Equal(t, int64(3), store.pipelines[0].DraftRevision)
+}
+
+func TestSaveDraft_WriteFails_RollsBackRevision(t *testing.T) {
+	srv, store, storage := newDraftTestServer()
+	storage.writeErr = errors.New("s3 unavailable")
+
+	rec := saveDraft(t, srv, "pipeline.sql", `"3"`, "SELECT 2")
+	require.Equal(t, http.StatusInternalServerError, rec.Code, rec.Body.String())
+	assert.Equal(t, int64(3), store.pipelines[0].DraftRevision, "failed write must not consume the revision")
+	assert.False(t, store.pipelines[0].DraftDirty)
+
+	// The editor can retry on the same base once storage recovers.
+	storage.writeErr = nil
+	rec = saveDraft(t, srv, "pipeline.sql", `"3"`, "SELECT 2")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+}
+
+func TestSaveDraft_FilesAPIWriteMakesBaseStale(t *testing.T) {
+	srv, _, storage := newDraftTestServer()
+
+	// Another editor writes the draft through the plain files API.
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/files/default/pipelines/silver/orders/pipeline.sql", strings.NewReader(`{"content":"SELECT 'theirs'"}`))
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = saveDraft(t, srv, "pipeline.sql", `"3"`, "SELECT 'mine'")
+	require.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, `"4"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "SELECT 'theirs'", string(storage.files["default/pipelines/silver/orders/pipeline.sql"]))
+}
+
+func TestSaveDraft_MissingIfMatch_Returns428(t *testing.T) {
+	srv, _, _ := newDraftTestServer()
+
+	rec := saveDraft(t, srv, "pipeline.sql", "", "SELECT 2")
+	assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+
+	rec = saveDraft(t, srv, "pipeline.sql", `"three"`, "SELECT 2")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSaveDraft_PathTraversal_Returns400(t *testing.T) {
+	srv, _, _ := newDraftTestServer()
+
+	rec := saveDraft(t, srv, "..%2F..%2Fother%2Fpipeline.sql", `"3"`, "SELECT 2")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
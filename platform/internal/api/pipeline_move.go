@@ -0,0 +1,334 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// MovePipelineRequest is the JSON body for
+// POST /api/v1/pipelines/{namespace}/{layer}/{name}/move. An empty field keeps
+// the current value; at least one must change. Moves stay in the namespace.
+type MovePipelineRequest struct {
+	Layer string `json:"layer"`
+	Name  string `json:"name"`
+}
+
+// MovePipelineResponse reports the moved pipeline and what was carried over.
+type MovePipelineResponse struct {
+	Pipeline        *domain.Pipeline `json:"pipeline"`
+	FilesMoved      int              `json:"files_moved"`
+	TriggersUpdated int              `json:"triggers_updated"`
+}
+
+// movedFile is one object copied from the old storage prefix to the new one.
+type movedFile struct {
+	src, dst string
+}
+
+// HandleMovePipeline renames a pipeline and/or moves it to another layer.
+// POST /api/v1/pipelines/{namespace}/{layer}/{name}/move
+//
+// The pipeline row keeps its ID, so runs, versions, schedules and its own
+// triggers follow it. Files under the default {namespace}/pipelines/{layer}/{name}/
+// prefix are copied to the new prefix (published versions are re-pointed at
+// copies of the same content) and the old objects are deleted once the row is
+// updated. A pipeline with a custom s3_prefix keeps it; only its quality tests,
+// which always live under the default prefix, move. Other pipelines'
+// pipeline_success and cron_dependency triggers that name the pipeline are
+// re-pointed. The pipeline's output table is not renamed.
+//
+// Rejected with 409 when the target name is taken or the pipeline has pending
+// or running runs.
+func (s *Server) HandleMovePipeline(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if !s.requireAccess(w, r, "pipeline", pipeline.ID.String(), "write") {
+		return
+	}
+
+	var req MovePipelineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if req.Layer == "" {
+		req.Layer = layer
+	}
+	if req.Name == "" {
+		req.Name = name
+	}
+	if !domain.ValidLayer(req.Layer) {
+		errorJSON(w, "layer must be bronze, silver, or gold", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if !validName(req.Name) {
+		errorJSON(w, "name must be a lowercase slug (a-z, 0-9, hyphens, underscores; must start with a letter)", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if req.Layer == layer && req.Name == name {
+		errorJSON(w, "layer or name must differ from the current one", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	target, err := s.Pipelines.GetPipeline(r.Context(), namespace, req.Layer, req.Name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if target != nil {
+		errorJSON(w, "a pipeline named "+namespace+"/"+req.Layer+"/"+req.Name+" already exists", "ALREADY_EXISTS", http.StatusConflict)
+		return
+	}
+
+	active, err := s.hasActiveRuns(r.Context(), pipeline)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if active {
+		errorJSON(w, "pipeline has pending or running runs; wait for them or cancel them first", "FAILED_PRECONDITION", http.StatusConflict)
+		return
+	}
+
+	oldPrefix := domain.PipelineS3Path(namespace, layer, name)
+	newPrefix := domain.PipelineS3Path(namespace, req.Layer, req.Name)
+	s3Path := pipeline.S3Path
+	if !pipeline.HasCustomS3Prefix() {
+		s3Path = newPrefix
+	}
+
+	// Cleanup must finish even if the client goes away mid-move, or the
+	// copies (or originals) are left behind.
+	cleanupCtx := context.WithoutCancel(r.Context())
+	published, copied, err := s.copyPipelineFiles(r.Context(), pipeline, oldPrefix, newPrefix)
+	if err != nil {
+		s.deleteFiles(cleanupCtx, copied, "dst")
+		internalError(w, "failed to copy pipeline files", err)
+		return
+	}
+
+	moved, err := s.Pipelines.MovePipeline(r.Context(), pipeline.ID, req.Layer, req.Name, s3Path, published)
+	if err != nil || moved == nil {
+		s.deleteFiles(cleanupCtx, copied, "dst")
+		switch {
+		case errors.Is(err, domain.ErrAlreadyExists):
+			errorJSON(w, "a pipeline named "+namespace+"/"+req.Layer+"/"+req.Name+" already exists", "ALREADY_EXISTS", http.StatusConflict)
+		case err != nil:
+			internalError(w, "internal error", err)
+		default:
+			errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		}
+		return
+	}
+
+	// The row now points at the new prefix; the old objects are garbage.
+	s.deleteFiles(cleanupCtx, copied, "src")
+
+	updated := s.repointDownstreamTriggers(cleanupCtx, namespace, layer, name, req.Layer, req.Name)
+
+	if s.PipelineCache != nil {
+		s.PipelineCache.Delete(pipelineCacheKey(namespace, layer, name))
+		s.PipelineCache.Delete(pipelineCacheKey(namespace, req.Layer, req.Name))
+	}
+
+	slog.Info("pipeline moved",
+		"from", namespace+"/"+layer+"/"+name, "to", namespace+"/"+req.Layer+"/"+req.Name,
+		"files", len(copied), "triggers", updated)
+
+	writeJSON(w, http.StatusOK, MovePipelineResponse{
+		Pipeline:        moved,
+		FilesMoved:      len(copied),
+		TriggersUpdated: updated,
+	})
+}
+
+// hasActiveRuns reports whether the pipeline has a pending or running run.
+func (s *Server) hasActiveRuns(ctx context.Context, p *domain.Pipeline) (bool, error) {
+	for _, status := range []domain.RunStatus{domain.RunStatusPending, domain.RunStatusRunning} {
+		n, err := s.Runs.CountRuns(ctx, RunFilter{PipelineID: p.ID.String(), Status: string(status)})
+		if err != nil {
+			return false, fmt.Errorf("count %s runs: %w", status, err)
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// copyPipelineFiles copies every object under oldPrefix to the same relative
+// path under newPrefix and returns the pipeline's published versions with the
+// moved paths re-pointed at the copies. A published version that differs from
+// the current draft is copied first, so the new path carries both: the
+// published content as an older version and the draft as the latest. A
+// published file that was since deleted from the draft is copied and then
+// deleted again at the new path. Returns the copies made so far on error.
+func (s *Server) copyPipelineFiles(ctx context.Context, p *domain.Pipeline, oldPrefix, newPrefix string) (map[string]string, []movedFile, error) {
+	published := make(map[string]string, len(p.PublishedVersions))
+	for path, version := range p.PublishedVersions {
+		published[path] = version
+	}
+	if s.Storage == nil {
+		return published, nil, nil
+	}
+
+	files, err := s.Storage.ListFiles(ctx, oldPrefix)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list %s: %w", oldPrefix, err)
+	}
+
+	var copied []movedFile
+	current := make(map[string]bool, len(files))
+	for _, f := range files {
+		current[f.Path] = true
+		dst := newPrefix + strings.TrimPrefix(f.Path, oldPrefix)
+		pubVersion, isPublished := p.PublishedVersions[f.Path]
+
+		if isPublished {
+			info, err := s.Storage.StatFile(ctx, f.Path)
+			if err != nil {
+				return nil, copied, fmt.Errorf("stat %s: %w", f.Path, err)
+			}
+			if info == nil || info.VersionID != pubVersion {
+				v, err := s.Storage.CopyFileVersion(ctx, f.Path, pubVersion, dst)
+				if err != nil {
+					return nil, copied, fmt.Errorf("copy published %s: %w", f.Path, err)
+				}
+				copied = append(copied, movedFile{src: f.Path, dst: dst})
+				delete(published, f.Path)
+				published[dst] = v
+				isPublished = false
+			}
+		}
+
+		v, err := s.Storage.CopyFile(ctx, f.Path, dst)
+		if err != nil {
+			return nil, copied, fmt.Errorf("copy %s: %w", f.Path, err)
+		}
+		if len(copied) == 0 || copied[len(copied)-1].dst != dst {
+			copied = append(copied, movedFile{src: f.Path, dst: dst})
+		}
+		if isPublished {
+			delete(published, f.Path)
+			published[dst] = v
+		}
+	}
+
+	for path, version := range p.PublishedVersions {
+		if current[path] || !strings.HasPrefix(path, oldPrefix) {
+			continue
+		}
+		dst := newPrefix + strings.TrimPrefix(path, oldPrefix)
+		v, err := s.Storage.CopyFileVersion(ctx, path, version, dst)
+		if err != nil {
+			return nil, copied, fmt.Errorf("copy published %s: %w", path, err)
+		}
+		delete(published, path)
+		published[dst] = v
+		if err := s.Storage.DeleteFile(ctx, dst); err != nil {
+			return nil, copied, fmt.Errorf("delete draft %s: %w", dst, err)
+		}
+	}
+	return published, copied, nil
+}
+
+// deleteFiles removes one side ("src" or "dst") of the given copies,
+// logging failures. Used to clean up after a move, or after a failed one.
+func (s *Server) deleteFiles(ctx context.Context, files []movedFile, side string) {
+	for _, f := range files {
+		path := f.src
+		if side == "dst" {
+			path = f.dst
+		}
+		if err := s.Storage.DeleteFile(ctx, path); err != nil {
+			slog.Warn("pipeline move: failed to delete file", "path", path, "error", err)
+		}
+	}
+}
+
+// repointDownstreamTriggers rewrites other pipelines' pipeline_success and
+// cron_dependency triggers that name the moved pipeline. Best-effort: a
+// failure is logged and the trigger left as it was. Returns how many changed.
+func (s *Server) repointDownstreamTriggers(ctx context.Context, namespace, oldLayer, oldName, newLayer, newName string) int {
+	if s.Triggers == nil {
+		return 0
+	}
+	updated := 0
+
+	successTriggers, _, err := s.Triggers.ListAllTriggers(ctx, TriggerFilter{Type: string(domain.TriggerTypePipelineSuccess)})
+	if err != nil {
+		slog.Warn("pipeline move: failed to list pipeline_success triggers", "error", err)
+	}
+	for _, t := range successTriggers {
+		var cfg map[string]any
+		if err := json.Unmarshal(t.Config, &cfg); err != nil {
+			continue
+		}
+		if cfg["namespace"] != namespace || cfg["layer"] != oldLayer || cfg["pipeline"] != oldName {
+			continue
+		}
+		cfg["layer"], cfg["pipeline"] = newLayer, newName
+		if s.updateTriggerConfig(ctx, t.ID.String(), cfg) {
+			updated++
+		}
+	}
+
+	oldDep := namespace + "." + oldLayer + "." + oldName
+	newDep := namespace + "." + newLayer + "." + newName
+	depTriggers, _, err := s.Triggers.ListAllTriggers(ctx, TriggerFilter{Type: string(domain.TriggerTypeCronDependency)})
+	if err != nil {
+		slog.Warn("pipeline move: failed to list cron_dependency triggers", "error", err)
+	}
+	for _, t := range depTriggers {
+		var cfg map[string]any
+		if err := json.Unmarshal(t.Config, &cfg); err != nil {
+			continue
+		}
+		deps, _ := cfg["dependencies"].([]any)
+		changed := false
+		for i, dep := range deps {
+			if dep == oldDep {
+				deps[i] = newDep
+				changed = true
+			}
+		}
+		if changed && s.updateTriggerConfig(ctx, t.ID.String(), cfg) {
+			updated++
+		}
+	}
+	return updated
+}
+
+// updateTriggerConfig stores cfg as a trigger's config, logging failures.
+func (s *Server) updateTriggerConfig(ctx context.Context, triggerID string, cfg map[string]any) bool {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return false
+	}
+	config := json.RawMessage(raw)
+	if _, err := s.Triggers.UpdateTrigger(ctx, triggerID, UpdateTriggerRequest{Config: &config}); err != nil {
+		slog.Warn("pipeline move: failed to re-point trigger", "trigger_id", triggerID, "error", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+func postMovePipeline(t *testing.T, srv *api.Server, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/"+path+"/move", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+// newMoveTestServer returns a server holding default/silver/orders with one
+// file, one finished run, and a downstream trigger watching it.
+func newMoveTestServer(t *testing.T) (*api.Server, *memoryPipelineStore, *memoryStorageStore, *memoryTriggerStore, uuid.UUID) {
+	t.Helper()
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	storageStore := newMemoryStorageStore()
+	srv.Storage = storageStore
+
+	pipelineID, downstreamID := uuid.New(), uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders",
+			S3Path: "default/pipelines/silver/orders/"},
+		{ID: downstreamID, Namespace: "default", Layer: domain.LayerGold, Name: "revenue",
+			S3Path: "default/pipelines/gold/revenue/"},
+	}
+	storageStore.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT 1")
+
+	runs := srv.Runs.(*memoryRunStore)
+	runs.runs = []domain.Run{{ID: uuid.New(), PipelineID: pipelineID, Status: domain.RunStatusSuccess}}
+
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID:         uuid.New(),
+		PipelineID: downstreamID,
+		Type:       domain.TriggerTypePipelineSuccess,
+		Config:     json.RawMessage(`{"namespace":"default","layer":"silver","pipeline":"orders"}`),
+		Enabled:    true,
+	}}
+	return srv, pipelineStore, storageStore, triggerStore, pipelineID
+}
+
+func TestMovePipeline_RenamesAndMovesFiles(t *testing.T) {
+	srv, pipelineStore, storageStore, triggerStore, pipelineID := newMoveTestServer(t)
+
+	rec := postMovePipeline(t, srv, "default/silver/orders", `{"layer":"gold","name":"orders_v2"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp api.MovePipelineResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, pipelineID, resp.Pipeline.ID, "identity is preserved")
+	assert.Equal(t, domain.LayerGold, resp.Pipeline.Layer)
+	assert.Equal(t, "orders_v2", resp.Pipeline.Name)
+	assert.Equal(t, "default/pipelines/gold/orders_v2/", resp.Pipeline.S3Path)
+	assert.Equal(t, 1, resp.FilesMoved)
+	assert.Equal(t, 1, resp.TriggersUpdated)
+
+	assert.Equal(t, "SELECT 1", string(storageStore.files["default/pipelines/gold/orders_v2/pipeline.sql"]))
+	assert.NotContains(t, storageStore.files, "default/pipelines/silver/orders/pipeline.sql")
+
+	moved, err := pipelineStore.GetPipeline(context.Background(), "default", "gold", "orders_v2")
+	require.NoError(t, err)
+	require.NotNil(t, moved)
+	assert.Equal(t, pipelineID, moved.ID)
+	old, err := pipelineStore.GetPipeline(context.Background(), "default", "silver", "orders")
+	require.NoError(t, err)
+	assert.Nil(t, old)
+
+	var cfg map[string]string
+	require.NoError(t, json.Unmarshal(triggerStore.triggers[0].Config, &cfg))
+	assert.Equal(t, "gold", cfg["layer"])
+	assert.Equal(t, "orders_v2", cfg["pipeline"])
+}
+
+func TestMovePipeline_RepointsPublishedVersions(t *testing.T) {
+	srv, pipelineStore, storageStore, _, _ := newMoveTestServer(t)
+	pipelineStore.pipelines[0].PublishedVersions = map[string]string{
+		"default/pipelines/silver/orders/pipeline.sql": "mock-version-id",
+	}
+
+	rec := postMovePipeline(t, srv, "default/silver/orders", `{"name":"orders_v2"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp api.MovePipelineResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, map[string]string{
+		"default/pipelines/silver/orders_v2/pipeline.sql": "mock-version-id",
+	}, resp.Pipeline.PublishedVersions)
+	assert.Contains(t, storageStore.files, "default/pipelines/silver/orders_v2/pipeline.sql")
+}
+
+func TestMovePipeline_TargetExists_Returns409(t *testing.T) {
+	srv, pipelineStore, storageStore, triggerStore, _ := newMoveTestServer(t)
+
+	rec := postMovePipeline(t, srv, "default/silver/orders", `{"layer":"gold","name":"revenue"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "ALREADY_EXISTS")
+
+	p, err := pipelineStore.GetPipeline(context.Background(), "default", "silver", "orders")
+	require.NoError(t, err)
+	assert.NotNil(t, p, "source pipeline is untouched")
+	assert.Contains(t, storageStore.files, "default/pipelines/silver/orders/pipeline.sql")
+	assert.JSONEq(t, `{"namespace":"default","layer":"silver","pipeline":"orders"}`, string(triggerStore.triggers[0].Config))
+}
+
+func TestMovePipeline_ActiveRun_Returns409(t *testing.T) {
+	srv, _, storageStore, _, pipelineID := newMoveTestServer(t)
+	runs := srv.Runs.(*memoryRunStore)
+	runs.runs = append(runs.runs, domain.Run{ID: uuid.New(), PipelineID: pipelineID, Status: domain.RunStatusRunning})
+
+	rec := postMovePipeline(t, srv, "default/silver/orders", `{"name":"orders_v2"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "FAILED_PRECONDITION")
+	assert.NotContains(t, storageStore.files, "default/pipelines/silver/orders_v2/pipeline.sql")
+}
+
+func TestMovePipeline_InvalidTarget_Returns400(t *testing.T) {
+	srv, _, _, _, _ := newMoveTestServer(t)
+
+	for _, body := range []string{`{}`, `{"layer":"silver","name":"orders"}`, `{"layer":"platinum"}`, `{"name":"Bad Name"}`} {
+		rec := postMovePipeline(t, srv, "default/silver/orders", body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+}
+
+func TestMovePipeline_UnknownPipeline_Returns404(t *testing.T) {
+	srv, _, _, _, _ := newMoveTestServer(t)
+
+	rec := postMovePipeline(t, srv, "default/silver/missing", `{"name":"other"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
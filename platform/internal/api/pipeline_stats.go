@@ -0,0 +1,102 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Bounds for the ?window= of the pipeline stats endpoint.
+const (
+	defaultPipelineStatsWindow = 7 * 24 * time.Hour
+	maxPipelineStatsWindow     = 90 * 24 * time.Hour
+)
+
+// parseStatsWindow parses a trailing window such as "7d", "12h" or "90m".
+// Days are accepted on top of what time.ParseDuration understands.
+func parseStatsWindow(v string) (time.Duration, error) {
+	if v == "" {
+		return defaultPipelineStatsWindow, nil
+	}
+	var d time.Duration
+	if days, ok := strings.CutSuffix(v, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("window must look like 7d, 12h or 30m")
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(v); err != nil {
+			return 0, fmt.Errorf("window must look like 7d, 12h or 30m")
+		}
+	}
+	if d <= 0 || d > maxPipelineStatsWindow {
+		return 0, fmt.Errorf("window must be positive and at most %dd", int(maxPipelineStatsWindow/(24*time.Hour)))
+	}
+	return d, nil
+}
+
+// HandleGetPipelineStats returns a pipeline's run rollup over a trailing
+// window (?window=, default 7d): run count, success rate, p50/p95/p99
+// duration and average rows written.
+func (s *Server) HandleGetPipelineStats(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	windowParam := r.URL.Query().Get("window")
+	window, err := parseStatsWindow(windowParam)
+	if err != nil {
+		errorJSON(w, err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if !s.requireAccess(w, r, "pipeline", pipeline.ID.String(), "read") {
+		return
+	}
+
+	if windowParam == "" {
+		windowParam = "7d"
+	}
+
+	since := time.Now().Add(-window)
+	stats, err := s.Runs.PipelineStats(r.Context(), pipeline.ID, since)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	// Success rate over finished runs only; nil when nothing finished.
+	var successRate *float64
+	if finished := stats.Succeeded + stats.Failed; finished > 0 {
+		rate := float64(stats.Succeeded) / float64(finished)
+		successRate = &rate
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"window":           windowParam,
+		"since":            since.UTC(),
+		"runs":             stats.Runs,
+		"succeeded":        stats.Succeeded,
+		"failed":           stats.Failed,
+		"success_rate":     successRate,
+		"duration_p50_ms":  stats.DurationP50Ms,
+		"duration_p95_ms":  stats.DurationP95Ms,
+		"duration_p99_ms":  stats.DurationP99Ms,
+		"avg_rows_written": stats.AvgRowsWritten,
+	})
+}
@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPipelineStats_SeededDurations(t *testing.T) {
+	srv, store := newTestServer()
+	pipelineID := uuid.New()
+	store.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	runStore := srv.Runs.(*memoryRunStore)
+	now := time.Now()
+	// Successful durations 100..1000ms in steps of 100, rows 10..100.
+	for i := 1; i <= 10; i++ {
+		d, rows := i*100, int64(i*10)
+		runStore.runs = append(runStore.runs, domain.Run{
+			ID: uuid.New(), PipelineID: pipelineID, Status: domain.RunStatusSuccess,
+			DurationMs: &d, RowsWritten: &rows, CreatedAt: now.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	slow := 60000
+	runStore.runs = append(runStore.runs,
+		domain.Run{ID: uuid.New(), PipelineID: pipelineID, Status: domain.RunStatusFailed, DurationMs: &slow, CreatedAt: now.Add(-time.Hour)},
+		domain.Run{ID: uuid.New(), PipelineID: pipelineID, Status: domain.RunStatusSuccess, DurationMs: &slow, CreatedAt: now.Add(-30 * 24 * time.Hour)}, // outside 7d
+	)
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/stats", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "7d", resp["window"])
+	assert.Equal(t, float64(11), resp["runs"])
+	assert.Equal(t, float64(10), resp["succeeded"])
+	assert.Equal(t, float64(1), resp["failed"])
+	assert.InDelta(t, 10.0/11.0, resp["success_rate"], 1e-9)
+	assert.InDelta(t, 550, resp["duration_p50_ms"], 1e-9)
+	assert.InDelta(t, 955, resp["duration_p95_ms"], 1e-9)
+	assert.InDelta(t, 991, resp["duration_p99_ms"], 1e-9)
+	assert.InDelta(t, 55, resp["avg_rows_written"], 1e-9)
+
+	// A 60d window picks up the old slow run.
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/stats?window=60d", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, float64(12), resp["runs"])
+}
+
+func TestGetPipelineStats_NoRuns_NullFigures(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/stats?window=24h", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, float64(0), resp["runs"])
+	assert.Nil(t, resp["success_rate"])
+	assert.Nil(t, resp["duration_p50_ms"])
+	assert.Nil(t, resp["avg_rows_written"])
+}
+
+func TestGetPipelineStats_Errors(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+
+	cases := map[string]int{
+		"/api/v1/pipelines/default/silver/missing/stats":            http.StatusNotFound,
+		"/api/v1/pipelines/default/silver/orders/stats?window=abc":  http.StatusBadRequest,
+		"/api/v1/pipelines/default/silver/orders/stats?window=0d":   http.StatusBadRequest,
+		"/api/v1/pipelines/default/silver/orders/stats?window=365d": http.StatusBadRequest,
+	}
+	for path, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, path)
+	}
+}
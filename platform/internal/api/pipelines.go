@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -23,11 +27,35 @@ type PipelineStore interface {
 	GetPipeline(ctx context.Context, namespace, layer, name string) (*domain.Pipeline, error)
 	GetPipelineByID(ctx context.Context, id string) (*domain.Pipeline, error)
 	CreatePipeline(ctx context.Context, p *domain.Pipeline) error
+	// CreatePipelinesBatch inserts all pipelines in one transaction. The
+	// returned slice is parallel to pipelines: nil for created (ID and
+	// timestamps are filled in), domain.ErrAlreadyExists for a name clash.
+	// A non-nil second error means the whole batch was rolled back.
+	CreatePipelinesBatch(ctx context.Context, pipelines []*domain.Pipeline) ([]error, error)
 	UpdatePipeline(ctx context.Context, namespace, layer, name string, update UpdatePipelineRequest) (*domain.Pipeline, error)
 	DeletePipeline(ctx context.Context, namespace, layer, name string) error
+	// MovePipeline renames a pipeline and/or changes its layer in place,
+	// setting its storage prefix and published versions to match the moved
+	// files. Returns domain.ErrAlreadyExists when the new name is taken and
+	// (nil, nil) when the pipeline doesn't exist.
+	MovePipeline(ctx context.Context, pipelineID uuid.UUID, layer, name, s3Path string, publishedVersions map[string]string) (*domain.Pipeline, error)
+	// SetDraftDirty sets draft_dirty; marking the draft dirty also bumps
+	// draft_revision so conflict-checked draft saves see the write.
 	SetDraftDirty(ctx context.Context, namespace, layer, name string, dirty bool) error
+	// BumpDraftRevision advances draft_revision (and marks the draft dirty)
+	// only if it is still base. On a stale base it returns the current
+	// revision with domain.ErrDraftRevisionMismatch; (0, nil) when the
+	// pipeline doesn't exist.
+	BumpDraftRevision(ctx context.Context, pipelineID uuid.UUID, base int64) (int64, error)
+	// RollbackDraftRevision undoes a BumpDraftRevision that returned
+	// revision, restoring draft_dirty to dirty. It is a no-op when the
+	// revision has moved on since.
+	RollbackDraftRevision(ctx context.Context, pipelineID uuid.UUID, revision int64, dirty bool) error
 	PublishPipeline(ctx context.Context, namespace, layer, name string, versions map[string]string) error
 	UpdatePipelineRetention(ctx context.Context, pipelineID uuid.UUID, config json.RawMessage) error
+	// UpdatePipelineValidation stores the latest publish-time template
+	// validation result on the pipeline.
+	UpdatePipelineValidation(ctx context.Context, pipelineID uuid.UUID, result *domain.ValidationResult) error
 	ListSoftDeletedPipelines(ctx context.Context, olderThan time.Time) ([]domain.Pipeline, error)
 	HardDeletePipeline(ctx context.Context, pipelineID uuid.UUID) error
 }
@@ -70,7 +98,8 @@ var pipelineSortFields = map[string]bool{
 type PipelineFilter struct {
 	Namespace string
 	Layer     string
-	Search    string // substring match on pipeline name (P10-102)
+	Search    string            // case-insensitive substring match on name and description (P10-102)
+	Labels    map[string]string // pipeline must carry every key:value pair
 	Limit     int
 	Offset    int
 	Sort      *SortOrder // optional sort directive
@@ -78,41 +107,126 @@ type PipelineFilter struct {
 
 // CreatePipelineRequest is the JSON body for POST /api/v1/pipelines.
 type CreatePipelineRequest struct {
-	Namespace   string `json:"namespace"`
-	Layer       string `json:"layer"`
-	Name        string `json:"name"`
-	Type        string `json:"type"`
-	Source      string `json:"source"`
-	UniqueKey   string `json:"unique_key"`
-	Description string `json:"description"`
+	Namespace   string            `json:"namespace"`
+	Layer       string            `json:"layer"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Source      string            `json:"source"`
+	UniqueKey   string            `json:"unique_key"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Priority    int               `json:"priority,omitempty"`
+	S3Prefix    string            `json:"s3_prefix,omitempty"` // custom storage prefix; default {ns}/pipelines/{layer}/{name}/
+
+	// Triggers are created along with the pipeline. Single create only; the
+	// batch endpoint rejects items that set them.
+	Triggers []CreateTriggerRequest `json:"triggers,omitempty"`
 }
 
+// PipelineS3PrefixEnvVar is the runner environment variable carrying a
+// pipeline's custom storage prefix. Executors only send it when the pipeline
+// has one; otherwise the runner uses the default {ns}/pipelines/{layer}/{name}/.
+const PipelineS3PrefixEnvVar = "RAT_PIPELINE_S3_PREFIX"
+
 // UpdatePipelineRequest is the JSON body for PUT /api/v1/pipelines/:ns/:layer/:name.
 type UpdatePipelineRequest struct {
-	Description *string `json:"description"`
-	Type        *string `json:"type"`
-	Owner       *string `json:"owner"`
+	Description *string           `json:"description"`
+	Type        *string           `json:"type"`
+	Owner       *string           `json:"owner"`
+	Labels      map[string]string `json:"labels"` // nil = unchanged, {} = clear all labels
+	Priority    *int              `json:"priority"`
+}
+
+// Pipeline priority bounds. Higher priority runs are submitted first; 0 is
+// the default.
+const (
+	minPipelinePriority = -100
+	maxPipelinePriority = 100
+)
+
+// validatePriority returns a client-facing error message, or "" when the
+// priority is in range.
+func validatePriority(priority int) string {
+	if priority < minPipelinePriority || priority > maxPipelinePriority {
+		return fmt.Sprintf("priority must be between %d and %d", minPipelinePriority, maxPipelinePriority)
+	}
+	return ""
+}
+
+const (
+	maxPipelineLabels    = 32
+	maxLabelValueLength  = 255
+	labelFilterSeparator = ":"
+)
+
+// validLabelKeyRe matches label keys: lowercase alphanumerics plus . _ - and /
+// (so "team", "data.sensitivity" and "acme.io/owner" all work), max 63 chars.
+var validLabelKeyRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9._/-]{0,62})$`)
+
+// validateLabels returns a client-facing error message, or "" when labels are valid.
+func validateLabels(labels map[string]string) string {
+	if len(labels) > maxPipelineLabels {
+		return fmt.Sprintf("too many labels (%d, max %d)", len(labels), maxPipelineLabels)
+	}
+	for k, v := range labels {
+		if !validLabelKeyRe.MatchString(k) {
+			return fmt.Sprintf("invalid label key %q: lowercase letters, digits, '.', '_', '-', '/' (max 63 chars)", k)
+		}
+		if len(v) > maxLabelValueLength {
+			return fmt.Sprintf("label %q value too long (%d chars, max %d)", k, len(v), maxLabelValueLength)
+		}
+	}
+	return ""
+}
+
+// parseLabelFilter reads repeated ?label=key:value params into a filter map.
+// Returns ok=false when any param is missing the key:value separator.
+func parseLabelFilter(r *http.Request) (map[string]string, bool) {
+	params := r.URL.Query()["label"]
+	if len(params) == 0 {
+		return nil, true
+	}
+	labels := make(map[string]string, len(params))
+	for _, p := range params {
+		k, v, found := strings.Cut(p, labelFilterSeparator)
+		if !found || k == "" {
+			return nil, false
+		}
+		labels[k] = v
+	}
+	return labels, true
 }
 
 // MountPipelineRoutes registers pipeline CRUD endpoints on the router.
 func MountPipelineRoutes(r chi.Router, srv *Server) {
 	r.Get("/pipelines", srv.HandleListPipelines)
 	r.Post("/pipelines", srv.HandleCreatePipeline)
+	r.Post("/pipelines/batch", srv.HandleBatchCreatePipelines)
+	r.Post("/pipelines/import", srv.HandleImportPipeline)
+	r.Get("/pipelines/labels", srv.HandleListPipelineLabels)
 	r.Get("/pipelines/{namespace}/{layer}/{name}", srv.HandleGetPipeline)
 	r.Put("/pipelines/{namespace}/{layer}/{name}", srv.HandleUpdatePipeline)
 	r.Delete("/pipelines/{namespace}/{layer}/{name}", srv.HandleDeletePipeline)
+	r.Post("/pipelines/{namespace}/{layer}/{name}/move", srv.HandleMovePipeline)
+	r.Put("/pipelines/{namespace}/{layer}/{name}/draft/*", srv.HandleSaveDraft)
+	r.Get("/pipelines/{namespace}/{layer}/{name}/stats", srv.HandleGetPipelineStats)
+	r.Get("/pipelines/{namespace}/{layer}/{name}/export", srv.HandleExportPipeline)
 }
 
 // HandleListPipelines returns pipelines, optionally filtered by namespace, layer, and search term.
 // Pagination is pushed to SQL via LIMIT/OFFSET for efficiency.
 // Supports sorting via ?sort=field or ?sort=-field (descending).
-// Supports search via ?search=term (substring match on pipeline name).
+// Supports search via ?search=term (case-insensitive substring match on name,
+// description, and the output table's documented description).
+// Supports label filtering via ?label=key:value (repeatable; all must match).
 //
 // When an Authorizer is configured (Pro), the result page is post-filtered
 // to only the pipelines the caller can read. `total` is the visible count
 // for the current page — note this means pagination becomes "fetch more"
 // rather than "page N of M" in Pro deployments. SQL-side filtering is the
 // follow-up when a Pro user hits the scale that makes this insufficient.
+//
+// The response carries an ETag hashed from the body; If-None-Match gets a 304.
 func (s *Server) HandleListPipelines(w http.ResponseWriter, r *http.Request) {
 	limit, offset := parsePagination(r)
 	filter := PipelineFilter{
@@ -123,6 +237,12 @@ func (s *Server) HandleListPipelines(w http.ResponseWriter, r *http.Request) {
 		Offset:    offset,
 		Sort:      parseSorting(r, pipelineSortFields),
 	}
+	labels, ok := parseLabelFilter(r)
+	if !ok {
+		errorJSON(w, "label filter must be key:value", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	filter.Labels = labels
 
 	pipelines, err := s.Pipelines.ListPipelines(r.Context(), filter)
 	if err != nil {
@@ -143,10 +263,31 @@ func (s *Server) HandleListPipelines(w http.ResponseWriter, r *http.Request) {
 		total = len(pipelines)
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	resp := map[string]interface{}{
 		"pipelines": pipelines,
 		"total":     total,
-	})
+	}
+
+	// ?include=latest_run adds the most recent run of each listed pipeline —
+	// the dashboard polls this, so it goes through LatestRunCache.
+	if r.URL.Query().Get("include") == "latest_run" {
+		ids := make([]uuid.UUID, len(pipelines))
+		for i, p := range pipelines {
+			ids[i] = p.ID
+		}
+		latest, err := s.latestRunPerPipeline(r.Context(), ids)
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		latestRuns := make(map[string]*domain.Run, len(latest))
+		for id, run := range latest {
+			latestRuns[id.String()] = run
+		}
+		resp["latest_runs"] = latestRuns
+	}
+
+	writeJSONWithETag(w, r, resp)
 }
 
 // filterPipelinesByAccess returns only the pipelines the current request's
@@ -184,26 +325,29 @@ func pipelineCacheKey(namespace, layer, name string) string {
 
 // HandleGetPipeline returns a single pipeline by namespace/layer/name.
 // Results are cached because pipeline metadata rarely changes between edits.
+// Concurrent misses for the same pipeline share one Postgres lookup, and
+// "not found" is cached briefly so probes for missing pipelines stay cheap.
+// Supports If-None-Match against an ETag derived from updated_at.
 func (s *Server) HandleGetPipeline(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
 	layer := chi.URLParam(r, "layer")
 	name := chi.URLParam(r, "name")
 
-	cacheKey := pipelineCacheKey(namespace, layer, name)
+	// The load may be shared with other requests waiting on the same key,
+	// so it must not be cancelled when this particular client goes away.
+	loadCtx := context.WithoutCancel(r.Context())
+	load := func() (*domain.Pipeline, bool, error) {
+		pipeline, err := s.Pipelines.GetPipeline(loadCtx, namespace, layer, name)
+		return pipeline, pipeline != nil, err
+	}
 
-	// Try cache first.
+	var pipeline *domain.Pipeline
+	var err error
 	if s.PipelineCache != nil {
-		if cached, ok := s.PipelineCache.Get(cacheKey); ok {
-			if cached == nil {
-				errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
-				return
-			}
-			writeJSON(w, http.StatusOK, cached)
-			return
-		}
+		pipeline, _, err = s.PipelineCache.GetOrLoad(pipelineCacheKey(namespace, layer, name), load)
+	} else {
+		pipeline, _, err = load()
 	}
-
-	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
 	if err != nil {
 		internalError(w, "internal error", err)
 		return
@@ -213,15 +357,15 @@ func (s *Server) HandleGetPipeline(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Checked on every request, cached or not — the cache is shared
+	// across users.
 	if !s.requireAccess(w, r, "pipeline", pipeline.ID.String(), "read") {
 		return
 	}
 
-	// Populate cache.
-	if s.PipelineCache != nil {
-		s.PipelineCache.Set(cacheKey, pipeline)
+	if notModified(w, r, pipelineETag(pipeline)) {
+		return
 	}
-
 	writeJSON(w, http.StatusOK, pipeline)
 }
 
@@ -233,77 +377,185 @@ func (s *Server) HandleCreatePipeline(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate required fields
-	if req.Namespace == "" || req.Layer == "" || req.Name == "" {
-		errorJSON(w, "namespace, layer, and name are required", "INVALID_ARGUMENT", http.StatusBadRequest)
+	if msg := validateCreatePipelineRequest(&req); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
 		return
 	}
-	if !validName(req.Namespace) || !validName(req.Name) {
-		errorJSON(w, "namespace and name must be a lowercase slug (a-z, 0-9, hyphens, underscores; must start with a letter)", "INVALID_ARGUMENT", http.StatusBadRequest)
+
+	pipeline := newPipelineFromRequest(r, req)
+	s3Path := pipeline.S3Path
+
+	// Triggers are validated before the pipeline exists so an invalid one
+	// leaves nothing behind. Each keeps its own request: webhook triggers
+	// carry their one-time secrets in its context.
+	var triggers []*domain.PipelineTrigger
+	var triggerReqs []*http.Request
+	if len(req.Triggers) > 0 {
+		if s.Triggers == nil {
+			errorJSON(w, "triggers are not supported", "UNIMPLEMENTED", http.StatusNotImplemented)
+			return
+		}
+		for _, treq := range req.Triggers {
+			trigger, tr, ok := s.prepareTrigger(w, r, pipeline, treq)
+			if !ok {
+				return
+			}
+			triggers = append(triggers, trigger)
+			triggerReqs = append(triggerReqs, tr)
+		}
+	}
+
+	if err := s.Pipelines.CreatePipeline(r.Context(), pipeline); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			// Return a generic conflict message instead of the raw error which
+			// may contain internal details (e.g., SQL constraint names).
+			errorJSON(w, "a pipeline with this namespace, layer, and name already exists", "ALREADY_EXISTS", http.StatusConflict)
+		} else {
+			internalError(w, "internal error", err)
+		}
 		return
 	}
-	if !domain.ValidLayer(req.Layer) {
-		errorJSON(w, "layer must be bronze, silver, or gold", "INVALID_ARGUMENT", http.StatusBadRequest)
+
+	if !s.createPipelineTriggers(w, r, pipeline, triggers) {
 		return
 	}
+
+	s.afterPipelineCreated(r.Context(), pipeline)
+
+	resp := map[string]interface{}{
+		"namespace":     pipeline.Namespace,
+		"layer":         pipeline.Layer,
+		"name":          pipeline.Name,
+		"s3_path":       s3Path,
+		"files_created": []string{"pipeline.sql", "config.yaml"},
+	}
+	if len(triggers) > 0 {
+		created := make([]map[string]interface{}, len(triggers))
+		for i, t := range triggers {
+			created[i] = s.triggerToResponse(*t, triggerReqs[i])
+		}
+		resp["triggers"] = created
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// createPipelineTriggers stores the validated triggers of a just-created
+// pipeline. If one fails, the pipeline is hard-deleted, taking the triggers
+// already stored with it, and the error response is written.
+func (s *Server) createPipelineTriggers(w http.ResponseWriter, r *http.Request, pipeline *domain.Pipeline, triggers []*domain.PipelineTrigger) bool {
+	for _, t := range triggers {
+		t.PipelineID = pipeline.ID
+		if err := s.Triggers.CreateTrigger(r.Context(), t); err != nil {
+			if delErr := s.Pipelines.HardDeletePipeline(r.Context(), pipeline.ID); delErr != nil {
+				slog.Error("failed to roll back pipeline after trigger create failed",
+					"pipeline", pipeline.Namespace+"/"+string(pipeline.Layer)+"/"+pipeline.Name,
+					"error", delErr)
+			}
+			internalError(w, "failed to create pipeline triggers", err)
+			return false
+		}
+	}
+	return true
+}
+
+// validateCreatePipelineRequest applies the create-time rules shared by the
+// single and batch endpoints. Defaults Type to "sql". Returns a client-facing
+// error message, or "" when the request is valid.
+func validateCreatePipelineRequest(req *CreatePipelineRequest) string {
+	if req.Namespace == "" || req.Layer == "" || req.Name == "" {
+		return "namespace, layer, and name are required"
+	}
+	if !validName(req.Namespace) || !validName(req.Name) {
+		return "namespace and name must be a lowercase slug (a-z, 0-9, hyphens, underscores; must start with a letter)"
+	}
+	if !domain.ValidLayer(req.Layer) {
+		return "layer must be bronze, silver, or gold"
+	}
 	if req.Type == "" {
 		req.Type = "sql"
 	}
 	if len(req.Description) > maxDescriptionLength {
-		errorJSON(w, fmt.Sprintf("description too long (%d chars, max %d)", len(req.Description), maxDescriptionLength), "INVALID_ARGUMENT", http.StatusBadRequest)
-		return
+		return fmt.Sprintf("description too long (%d chars, max %d)", len(req.Description), maxDescriptionLength)
+	}
+	if msg := validatePriority(req.Priority); msg != "" {
+		return msg
+	}
+	if msg := validateS3Prefix(req); msg != "" {
+		return msg
 	}
+	return validateLabels(req.Labels)
+}
 
-	s3Path := req.Namespace + "/pipelines/" + req.Layer + "/" + req.Name + "/"
+// validateS3Prefix checks an optional custom storage prefix and normalizes it
+// to end in "/". The prefix must stay inside the pipeline's namespace so
+// namespace-scoped file access checks still cover the pipeline's files.
+func validateS3Prefix(req *CreatePipelineRequest) string {
+	if req.S3Prefix == "" {
+		return ""
+	}
+	if msg := validateFilePath(req.S3Prefix); msg != "" {
+		return "s3_prefix: " + msg
+	}
+	if !strings.HasSuffix(req.S3Prefix, "/") {
+		req.S3Prefix += "/"
+	}
+	if !strings.HasPrefix(req.S3Prefix, req.Namespace+"/") || req.S3Prefix == req.Namespace+"/" {
+		return "s3_prefix must be a path inside the pipeline's namespace (e.g. " + req.Namespace + "/shared/orders/)"
+	}
+	if strings.Contains(req.S3Prefix, "//") {
+		return "s3_prefix must not contain empty path segments"
+	}
+	return ""
+}
 
+// newPipelineFromRequest builds the domain pipeline for a validated create
+// request, owned by the authenticated user (nil owner in community mode).
+func newPipelineFromRequest(r *http.Request, req CreatePipelineRequest) *domain.Pipeline {
 	pipeline := &domain.Pipeline{
 		Namespace:   req.Namespace,
 		Layer:       domain.Layer(req.Layer),
 		Name:        req.Name,
 		Type:        req.Type,
-		S3Path:      s3Path,
+		S3Path:      domain.PipelineS3Path(req.Namespace, req.Layer, req.Name),
 		Description: req.Description,
+		Labels:      req.Labels,
+		Priority:    req.Priority,
+	}
+	if req.S3Prefix != "" {
+		pipeline.S3Path = req.S3Prefix
 	}
-
-	// Set owner from auth context (nil in community mode).
 	if user := plugins.UserFromContext(r.Context()); user != nil {
 		pipeline.Owner = &user.UserID
 	}
+	return pipeline
+}
 
-	if err := s.Pipelines.CreatePipeline(r.Context(), pipeline); err != nil {
-		if errors.Is(err, domain.ErrAlreadyExists) {
-			// Return a generic conflict message instead of the raw error which
-			// may contain internal details (e.g., SQL constraint names).
-			errorJSON(w, "a pipeline with this namespace, layer, and name already exists", "ALREADY_EXISTS", http.StatusConflict)
-		} else {
-			internalError(w, "internal error", err)
-		}
-		return
-	}
-
+// afterPipelineCreated runs the best-effort follow-ups to a successful create:
+// cache invalidation and the initial auto-publish.
+func (s *Server) afterPipelineCreated(ctx context.Context, pipeline *domain.Pipeline) {
 	// Invalidate pipeline cache after creation.
 	if s.PipelineCache != nil {
-		s.PipelineCache.Delete(pipelineCacheKey(req.Namespace, req.Layer, req.Name))
+		s.PipelineCache.Delete(pipelineCacheKey(pipeline.Namespace, string(pipeline.Layer), pipeline.Name))
 	}
 
 	// Auto-publish: snapshot initial file versions so first run has something to use.
 	// Errors are logged but do not fail the pipeline creation (best-effort).
 	if s.Storage != nil {
-		if files, err := s.Storage.ListFiles(r.Context(), s3Path); err != nil {
+		if files, err := s.Storage.ListFiles(ctx, pipeline.StoragePrefix()); err != nil {
 			slog.Warn("auto-publish: failed to list files for initial snapshot",
 				"pipeline", pipeline.Namespace+"/"+string(pipeline.Layer)+"/"+pipeline.Name,
 				"error", err)
 		} else {
 			versions := make(map[string]string, len(files))
 			for _, f := range files {
-				if info, err := s.Storage.StatFile(r.Context(), f.Path); err != nil {
+				if info, err := s.Storage.StatFile(ctx, f.Path); err != nil {
 					slog.Warn("auto-publish: failed to stat file", "path", f.Path, "error", err)
 				} else if info != nil && info.VersionID != "" {
 					versions[f.Path] = info.VersionID
 				}
 			}
 			if len(versions) > 0 {
-				if err := s.Pipelines.PublishPipeline(r.Context(), pipeline.Namespace, string(pipeline.Layer), pipeline.Name, versions); err != nil {
+				if err := s.Pipelines.PublishPipeline(ctx, pipeline.Namespace, string(pipeline.Layer), pipeline.Name, versions); err != nil {
 					slog.Error("auto-publish: failed to publish initial versions",
 						"pipeline", pipeline.Namespace+"/"+string(pipeline.Layer)+"/"+pipeline.Name,
 						"error", err)
@@ -311,13 +563,116 @@ func (s *Server) HandleCreatePipeline(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+}
 
-	writeJSON(w, http.StatusCreated, map[string]interface{}{
-		"namespace":     pipeline.Namespace,
-		"layer":         pipeline.Layer,
-		"name":          pipeline.Name,
-		"s3_path":       pipeline.S3Path,
-		"files_created": []string{"pipeline.sql", "config.yaml"},
+// maxBatchCreatePipelines caps POST /pipelines/batch so a single request
+// can't hold a transaction open for an unbounded number of inserts.
+const maxBatchCreatePipelines = 100
+
+// Per-item outcomes reported by HandleBatchCreatePipelines.
+const (
+	batchItemCreated  = "created"
+	batchItemConflict = "conflict"
+	batchItemInvalid  = "invalid"
+)
+
+// BatchCreatePipelinesRequest is the JSON body for POST /api/v1/pipelines/batch.
+type BatchCreatePipelinesRequest struct {
+	Pipelines []CreatePipelineRequest `json:"pipelines"`
+}
+
+// BatchCreatePipelineResult reports the outcome of one item in a batch create.
+// Results are returned in request order.
+type BatchCreatePipelineResult struct {
+	Index     int    `json:"index"`
+	Namespace string `json:"namespace"`
+	Layer     string `json:"layer"`
+	Name      string `json:"name"`
+	Status    string `json:"status"` // created, conflict, or invalid
+	Error     string `json:"error,omitempty"`
+}
+
+// HandleBatchCreatePipelines creates many pipelines in one call.
+// POST /api/v1/pipelines/batch
+//
+// Each item is validated with the same rules as POST /pipelines; invalid
+// items are reported and skipped. The valid ones are inserted in a single
+// transaction — an item whose name is already taken (including by an earlier
+// item in the same batch) is reported as a conflict without aborting the rest.
+// Responds 200 with per-item results; a store failure rolls back every insert
+// and responds 500.
+func (s *Server) HandleBatchCreatePipelines(w http.ResponseWriter, r *http.Request) {
+	var req BatchCreatePipelinesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if len(req.Pipelines) == 0 {
+		errorJSON(w, "pipelines must not be empty", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if len(req.Pipelines) > maxBatchCreatePipelines {
+		errorJSON(w, fmt.Sprintf("too many pipelines in batch (%d, max %d)", len(req.Pipelines), maxBatchCreatePipelines), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	results := make([]BatchCreatePipelineResult, len(req.Pipelines))
+	var toCreate []*domain.Pipeline
+	var createIdx []int // toCreate[i] came from req.Pipelines[createIdx[i]]
+	for i := range req.Pipelines {
+		item := &req.Pipelines[i]
+		results[i] = BatchCreatePipelineResult{Index: i, Namespace: item.Namespace, Layer: item.Layer, Name: item.Name}
+		if msg := validateCreatePipelineRequest(item); msg != "" {
+			results[i].Status = batchItemInvalid
+			results[i].Error = msg
+			continue
+		}
+		if len(item.Triggers) > 0 {
+			results[i].Status = batchItemInvalid
+			results[i].Error = "triggers are not supported in batch create"
+			continue
+		}
+		toCreate = append(toCreate, newPipelineFromRequest(r, *item))
+		createIdx = append(createIdx, i)
+	}
+
+	if len(toCreate) > 0 {
+		itemErrs, err := s.Pipelines.CreatePipelinesBatch(r.Context(), toCreate)
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		for j, itemErr := range itemErrs {
+			res := &results[createIdx[j]]
+			switch {
+			case itemErr == nil:
+				res.Status = batchItemCreated
+			case errors.Is(itemErr, domain.ErrAlreadyExists):
+				res.Status = batchItemConflict
+				res.Error = "a pipeline with this namespace, layer, and name already exists"
+			default:
+				// Not expected from the store contract; surface generically.
+				res.Status = batchItemInvalid
+				res.Error = "could not create pipeline"
+			}
+		}
+		for j, p := range toCreate {
+			if itemErrs[j] == nil {
+				s.afterPipelineCreated(r.Context(), p)
+			}
+		}
+	}
+
+	created := 0
+	for _, res := range results {
+		if res.Status == batchItemCreated {
+			created++
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results": results,
+		"created": created,
+		"failed":  len(results) - created,
 	})
 }
 
@@ -347,6 +702,16 @@ func (s *Server) HandleUpdatePipeline(w http.ResponseWriter, r *http.Request) {
 		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
 		return
 	}
+	if msg := validateLabels(req.Labels); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if req.Priority != nil {
+		if msg := validatePriority(*req.Priority); msg != "" {
+			errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
 
 	pipeline, err := s.Pipelines.UpdatePipeline(r.Context(), namespace, layer, name, req)
 	if err != nil {
@@ -366,6 +731,37 @@ func (s *Server) HandleUpdatePipeline(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, pipeline)
 }
 
+// HandleListPipelineLabels returns every distinct label key and its sorted
+// values, for building label filter pickers.
+// GET /api/v1/pipelines/labels?namespace=
+//
+// Labels come from the same pipelines HandleListPipelines would return for
+// the namespace filter, after the per-pipeline read check, so a caller only
+// sees labels of pipelines it can read.
+func (s *Server) HandleListPipelineLabels(w http.ResponseWriter, r *http.Request) {
+	pipelines, err := s.Pipelines.ListPipelines(r.Context(), PipelineFilter{Namespace: r.URL.Query().Get("namespace")})
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	pipelines = filterPipelinesByAccess(r.Context(), s, pipelines, "read")
+
+	labels := make(map[string][]string)
+	for _, p := range pipelines {
+		for k, v := range p.Labels {
+			if !slices.Contains(labels[k], v) {
+				labels[k] = append(labels[k], v)
+			}
+		}
+	}
+	for _, values := range labels {
+		sort.Strings(values)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"labels": labels,
+	})
+}
+
 // HandleDeletePipeline deletes a pipeline by namespace/layer/name.
 func (s *Server) HandleDeletePipeline(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
@@ -399,9 +795,10 @@ func (s *Server) HandleDeletePipeline(w http.ResponseWriter, r *http.Request) {
 
 	// Best-effort S3 cleanup — deletion already succeeded in Postgres, so
 	// S3 errors are logged but do not fail the request. Orphaned files can
-	// be cleaned up by the reaper.
-	if s.Storage != nil {
-		s3Prefix := namespace + "/pipelines/" + layer + "/" + name + "/"
+	// be cleaned up by the reaper. A custom s3_prefix may be shared with
+	// other pipelines (e.g. a common library), so its files are left alone.
+	if s.Storage != nil && !existing.HasCustomS3Prefix() {
+		s3Prefix := existing.StoragePrefix()
 		files, err := s.Storage.ListFiles(r.Context(), s3Prefix)
 		if err != nil {
 			slog.Warn("delete pipeline: failed to list S3 files for cleanup",
@@ -4,16 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/cache"
 	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,11 +46,29 @@ func (m *memoryPipelineStore) filteredPipelines(filter api.PipelineFilter) []dom
 		if filter.Layer != "" && string(p.Layer) != filter.Layer {
 			continue
 		}
+		if !hasLabels(p.Labels, filter.Labels) {
+			continue
+		}
+		if q := strings.ToLower(filter.Search); q != "" &&
+			!strings.Contains(strings.ToLower(p.Name), q) &&
+			!strings.Contains(strings.ToLower(p.Description), q) {
+			continue
+		}
 		result = append(result, p)
 	}
 	return result
 }
 
+// hasLabels reports whether labels contains every key:value pair in want.
+func hasLabels(labels, want map[string]string) bool {
+	for k, v := range want {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *memoryPipelineStore) ListPipelines(_ context.Context, filter api.PipelineFilter) ([]domain.Pipeline, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -107,10 +131,21 @@ func (m *memoryPipelineStore) CreatePipeline(_ context.Context, p *domain.Pipeli
 			return fmt.Errorf("pipeline %s/%s/%s: %w", p.Namespace, p.Layer, p.Name, domain.ErrAlreadyExists)
 		}
 	}
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
 	m.pipelines = append(m.pipelines, *p)
 	return nil
 }
 
+func (m *memoryPipelineStore) CreatePipelinesBatch(ctx context.Context, pipelines []*domain.Pipeline) ([]error, error) {
+	results := make([]error, len(pipelines))
+	for i, p := range pipelines {
+		results[i] = m.CreatePipeline(ctx, p)
+	}
+	return results, nil
+}
+
 func (m *memoryPipelineStore) UpdatePipeline(_ context.Context, namespace, layer, name string, update api.UpdatePipelineRequest) (*domain.Pipeline, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -123,6 +158,13 @@ func (m *memoryPipelineStore) UpdatePipeline(_ context.Context, namespace, layer
 			if update.Type != nil {
 				m.pipelines[i].Type = *update.Type
 			}
+			if update.Labels != nil {
+				m.pipelines[i].Labels = update.Labels
+			}
+			if update.Priority != nil {
+				m.pipelines[i].Priority = *update.Priority
+			}
+			m.pipelines[i].UpdatedAt = time.Now()
 			result := m.pipelines[i]
 			return &result, nil
 		}
@@ -130,6 +172,33 @@ func (m *memoryPipelineStore) UpdatePipeline(_ context.Context, namespace, layer
 	return nil, nil
 }
 
+func (m *memoryPipelineStore) MovePipeline(_ context.Context, pipelineID uuid.UUID, layer, name, s3Path string, publishedVersions map[string]string) (*domain.Pipeline, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx := -1
+	for i, p := range m.pipelines {
+		if p.ID == pipelineID {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return nil, nil
+	}
+	for _, p := range m.pipelines {
+		if p.ID != pipelineID && p.Namespace == m.pipelines[idx].Namespace && string(p.Layer) == layer && p.Name == name {
+			return nil, fmt.Errorf("pipeline %s: %w", name, domain.ErrAlreadyExists)
+		}
+	}
+	m.pipelines[idx].Layer = domain.Layer(layer)
+	m.pipelines[idx].Name = name
+	m.pipelines[idx].S3Path = s3Path
+	m.pipelines[idx].PublishedVersions = publishedVersions
+	m.pipelines[idx].UpdatedAt = time.Now()
+	result := m.pipelines[idx]
+	return &result, nil
+}
+
 func (m *memoryPipelineStore) DeletePipeline(_ context.Context, namespace, layer, name string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -150,12 +219,46 @@ func (m *memoryPipelineStore) SetDraftDirty(_ context.Context, namespace, layer,
 	for i, p := range m.pipelines {
 		if p.Namespace == namespace && string(p.Layer) == layer && p.Name == name {
 			m.pipelines[i].DraftDirty = dirty
+			if dirty {
+				m.pipelines[i].DraftRevision++
+			}
 			return nil
 		}
 	}
 	return nil // no-op if not found
 }
 
+func (m *memoryPipelineStore) BumpDraftRevision(_ context.Context, pipelineID uuid.UUID, base int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, p := range m.pipelines {
+		if p.ID != pipelineID {
+			continue
+		}
+		if p.DraftRevision != base {
+			return p.DraftRevision, domain.ErrDraftRevisionMismatch
+		}
+		m.pipelines[i].DraftRevision++
+		m.pipelines[i].DraftDirty = true
+		return m.pipelines[i].DraftRevision, nil
+	}
+	return 0, nil
+}
+
+func (m *memoryPipelineStore) RollbackDraftRevision(_ context.Context, pipelineID uuid.UUID, revision int64, dirty bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, p := range m.pipelines {
+		if p.ID == pipelineID && p.DraftRevision == revision {
+			m.pipelines[i].DraftRevision--
+			m.pipelines[i].DraftDirty = dirty
+		}
+	}
+	return nil
+}
+
 func (m *memoryPipelineStore) UpdatePipelineRetention(_ context.Context, pipelineID uuid.UUID, config json.RawMessage) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -164,11 +267,29 @@ func (m *memoryPipelineStore) UpdatePipelineRetention(_ context.Context, pipelin
 	return nil
 }
 
+func (m *memoryPipelineStore) UpdatePipelineValidation(_ context.Context, pipelineID uuid.UUID, result *domain.ValidationResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.pipelines {
+		if m.pipelines[i].ID == pipelineID {
+			m.pipelines[i].Validation = result
+			m.pipelines[i].UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return nil
+}
+
 func (m *memoryPipelineStore) ListSoftDeletedPipelines(_ context.Context, _ time.Time) ([]domain.Pipeline, error) {
 	return nil, nil
 }
 
-func (m *memoryPipelineStore) HardDeletePipeline(_ context.Context, _ uuid.UUID) error {
+func (m *memoryPipelineStore) HardDeletePipeline(_ context.Context, pipelineID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pipelines = slices.DeleteFunc(m.pipelines, func(p domain.Pipeline) bool { return p.ID == pipelineID })
 	return nil
 }
 
@@ -261,6 +382,266 @@ func TestListPipelines_FilterByNamespace_ReturnsFiltered(t *testing.T) {
 	assert.Equal(t, float64(1), body["total"])
 }
 
+// chanEventBus is a plugins.DispatchEventBus backed by a single channel.
+type chanEventBus struct {
+	ch chan plugins.DispatchEvent
+}
+
+func (b *chanEventBus) Subscribe(_ string) (<-chan plugins.DispatchEvent, func()) {
+	return b.ch, func() {}
+}
+
+func TestListPipelines_IncludeLatestRun_CachedUntilRunCompleted(t *testing.T) {
+	srv, store := newTestServer()
+	runStore := newMemoryRunStore()
+	srv.Runs = runStore
+	srv.LatestRunCache = cache.New[string, map[uuid.UUID]*domain.Run](cache.Options{TTL: time.Minute})
+	pipelineID := uuid.New()
+	runID := uuid.New()
+	store.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "orders"},
+	}
+	runStore.runs = []domain.Run{{ID: runID, PipelineID: pipelineID, Status: domain.RunStatusRunning}}
+
+	bus := &chanEventBus{ch: make(chan plugins.DispatchEvent, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv.WatchRunCompletions(ctx, bus)
+	router := api.NewRouter(srv)
+
+	latestStatus := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines?include=latest_run", http.NoBody)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			LatestRuns map[string]domain.Run `json:"latest_runs"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+		return string(body.LatestRuns[pipelineID.String()].Status)
+	}
+
+	assert.Equal(t, "running", latestStatus())
+
+	// Change the run behind the cache's back — the cached entry still serves.
+	runStore.mu.Lock()
+	runStore.runs[0].Status = domain.RunStatusSuccess
+	runStore.mu.Unlock()
+	assert.Equal(t, "running", latestStatus())
+	assert.Equal(t, 1, srv.LatestRunCache.Len())
+
+	bus.ch <- plugins.DispatchEvent{Channel: plugins.ChannelRunCompleted}
+	require.Eventually(t, func() bool { return srv.LatestRunCache.Len() == 0 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "success", latestStatus())
+}
+
+func TestListPipelines_WithoutInclude_OmitsLatestRuns(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders"}}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.NotContains(t, body, "latest_runs")
+}
+
+func TestListPipelines_Search_ForwardsTermToStore(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "orders", Description: "Raw order events"},
+		{Namespace: "default", Layer: domain.LayerSilver, Name: "customers", Description: "Deduplicated customer records"},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines?search=CUSTOMER", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var body struct {
+		Pipelines []domain.Pipeline `json:"pipelines"`
+		Total     int               `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Len(t, body.Pipelines, 1)
+	assert.Equal(t, "customers", body.Pipelines[0].Name)
+	assert.Equal(t, 1, body.Total)
+}
+
+func TestListPipelines_FilterByLabel_ReturnsMatching(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "orders", Labels: map[string]string{"team": "analytics", "pii": "true"}},
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "events", Labels: map[string]string{"team": "analytics"}},
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "campaigns", Labels: map[string]string{"team": "marketing"}},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines?label=team:analytics&label=pii:true", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var body struct {
+		Pipelines []domain.Pipeline `json:"pipelines"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Len(t, body.Pipelines, 1)
+	assert.Equal(t, "orders", body.Pipelines[0].Name)
+}
+
+func TestListPipelines_MalformedLabelFilter_Returns400(t *testing.T) {
+	srv, _ := newTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines?label=analytics", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListPipelineLabels_ReturnsDistinctKeysAndValues(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "orders", Labels: map[string]string{"team": "analytics", "pii": "true"}},
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "events", Labels: map[string]string{"team": "analytics"}},
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "campaigns", Labels: map[string]string{"team": "marketing"}},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/labels", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Labels map[string][]string `json:"labels"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, map[string][]string{
+		"team": {"analytics", "marketing"},
+		"pii":  {"true"},
+	}, body.Labels)
+}
+
+func TestCreatePipeline_WithLabels_StoresLabels(t *testing.T) {
+	srv, store := newTestServer()
+	router := api.NewRouter(srv)
+
+	body := `{"namespace":"default","layer":"bronze","name":"orders","labels":{"team":"analytics"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Len(t, store.pipelines, 1)
+	assert.Equal(t, map[string]string{"team": "analytics"}, store.pipelines[0].Labels)
+}
+
+func TestCreatePipeline_InvalidLabelKey_Returns400(t *testing.T) {
+	srv, _ := newTestServer()
+	router := api.NewRouter(srv)
+
+	body := `{"namespace":"default","layer":"bronze","name":"orders","labels":{"Team Name":"analytics"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCreatePipeline_S3Prefix_StoredAndUsedForAutoPublish(t *testing.T) {
+	srv, store := newTestServer()
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.files["default/shared/orders/pipeline.sql"] = []byte("SELECT 1")
+	storage.files["default/pipelines/bronze/orders/pipeline.sql"] = []byte("SELECT 2")
+
+	body := `{"namespace":"default","layer":"bronze","name":"orders","s3_prefix":"default/shared/orders"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "default/shared/orders/", resp["s3_path"], "prefix is normalized to end in /")
+
+	require.Len(t, store.pipelines, 1)
+	assert.Equal(t, "default/shared/orders/", store.pipelines[0].S3Path)
+	assert.Equal(t, map[string]string{"default/shared/orders/pipeline.sql": "mock-version-id"}, store.pipelines[0].PublishedVersions,
+		"auto-publish lists the custom prefix, not the default layout")
+}
+
+func TestCreatePipeline_InvalidS3Prefix_Returns400(t *testing.T) {
+	for _, prefix := range []string{"other/shared/orders/", "default/", "default/../other/", "/default/shared/", "default//shared/"} {
+		srv, store := newTestServer()
+
+		body := `{"namespace":"default","layer":"bronze","name":"orders","s3_prefix":"` + prefix + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+		api.NewRouter(srv).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "prefix %q", prefix)
+		assert.Empty(t, store.pipelines, "prefix %q", prefix)
+	}
+}
+
+func TestDeletePipeline_CustomS3Prefix_LeavesFiles(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders", Type: "sql", S3Path: "default/shared/orders/"},
+	}
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.files["default/shared/orders/pipeline.sql"] = []byte("SELECT 1")
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, storage.files, "default/shared/orders/pipeline.sql", "a custom prefix may be shared; its files are kept")
+}
+
+func TestCreatePipeline_Priority_StoresAndValidates(t *testing.T) {
+	srv, store := newTestServer()
+	router := api.NewRouter(srv)
+
+	body := `{"namespace":"default","layer":"bronze","name":"orders","priority":50}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Len(t, store.pipelines, 1)
+	assert.Equal(t, 50, store.pipelines[0].Priority)
+
+	body = `{"namespace":"default","layer":"bronze","name":"events","priority":101}`
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bytes.NewBufferString(body))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUpdatePipeline_Labels_ReplacesLabels(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders", Labels: map[string]string{"team": "analytics"}},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"labels":{"team":"growth","tier":"gold"}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/orders", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]string{"team": "growth", "tier": "gold"}, store.pipelines[0].Labels)
+}
+
 // --- Get Pipeline ---
 
 func TestGetPipeline_Exists_ReturnsPipeline(t *testing.T) {
@@ -397,6 +778,217 @@ func TestCreatePipeline_DefaultsToSQL(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, rec.Code)
 }
 
+// failingTriggerStore fails CreateTrigger once ok creates have succeeded.
+type failingTriggerStore struct {
+	*memoryTriggerStore
+	ok int
+}
+
+func (m *failingTriggerStore) CreateTrigger(ctx context.Context, trigger *domain.PipelineTrigger) error {
+	if m.ok == 0 {
+		return fmt.Errorf("connection reset")
+	}
+	m.ok--
+	return m.memoryTriggerStore.CreateTrigger(ctx, trigger)
+}
+
+func postCreatePipeline(router http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCreatePipeline_WithTriggers_CreatesThem(t *testing.T) {
+	srv, store := newTestServer()
+	triggerStore := newMemoryTriggerStore()
+	srv.Triggers = triggerStore
+	router := api.NewRouter(srv)
+
+	rec := postCreatePipeline(router, `{"namespace":"default","layer":"bronze","name":"orders","triggers":[
+		{"type":"cron","config":{"cron_expr":"0 * * * *"}},
+		{"type":"webhook","cooldown_seconds":60}
+	]}`)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var resp struct {
+		Triggers []map[string]interface{} `json:"triggers"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp.Triggers, 2)
+	assert.Equal(t, "cron", resp.Triggers[0]["type"])
+	assert.Equal(t, "webhook", resp.Triggers[1]["type"])
+	assert.NotEmpty(t, resp.Triggers[1]["webhook_token"], "webhook token is returned once on create")
+
+	require.Len(t, store.pipelines, 1)
+	require.Len(t, triggerStore.triggers, 2)
+	for _, trigger := range triggerStore.triggers {
+		assert.Equal(t, store.pipelines[0].ID, trigger.PipelineID)
+	}
+	assert.Equal(t, 60, triggerStore.triggers[1].CooldownSeconds)
+}
+
+func TestCreatePipeline_InvalidTrigger_CreatesNothing(t *testing.T) {
+	srv, store := newTestServer()
+	triggerStore := newMemoryTriggerStore()
+	srv.Triggers = triggerStore
+	router := api.NewRouter(srv)
+
+	rec := postCreatePipeline(router, `{"namespace":"default","layer":"bronze","name":"orders","triggers":[
+		{"type":"cron","config":{"cron_expr":"0 * * * *"}},
+		{"type":"cron","config":{"cron_expr":"not a cron"}}
+	]}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid cron expression")
+	assert.Empty(t, store.pipelines)
+	assert.Empty(t, triggerStore.triggers)
+}
+
+func TestCreatePipeline_TriggerStoreFailure_RollsBackPipeline(t *testing.T) {
+	srv, store := newTestServer()
+	srv.Triggers = &failingTriggerStore{memoryTriggerStore: newMemoryTriggerStore(), ok: 1}
+	router := api.NewRouter(srv)
+
+	rec := postCreatePipeline(router, `{"namespace":"default","layer":"bronze","name":"orders","triggers":[
+		{"type":"cron","config":{"cron_expr":"0 * * * *"}},
+		{"type":"cron","config":{"cron_expr":"30 * * * *"}}
+	]}`)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Empty(t, store.pipelines, "pipeline is hard-deleted when a trigger can't be stored")
+}
+
+func TestBatchCreatePipelines_WithTriggers_RejectsItem(t *testing.T) {
+	srv, store := newTestServer()
+	srv.Triggers = newMemoryTriggerStore()
+	router := api.NewRouter(srv)
+
+	rec, resp := postBatchCreate(t, router, `{"pipelines":[
+		{"namespace":"default","layer":"bronze","name":"orders","triggers":[{"type":"cron","config":{"cron_expr":"0 * * * *"}}]}
+	]}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, "invalid", resp.Results[0].Status)
+	assert.Empty(t, store.pipelines)
+}
+
+// --- Batch Create Pipelines ---
+
+type batchCreateResponse struct {
+	Results []api.BatchCreatePipelineResult `json:"results"`
+	Created int                             `json:"created"`
+	Failed  int                             `json:"failed"`
+}
+
+func postBatchCreate(t *testing.T, router http.Handler, body string) (*httptest.ResponseRecorder, batchCreateResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp batchCreateResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	}
+	return rec, resp
+}
+
+func TestBatchCreatePipelines_AllValid_CreatesAll(t *testing.T) {
+	srv, store := newTestServer()
+	router := api.NewRouter(srv)
+
+	rec, resp := postBatchCreate(t, router, `{"pipelines":[
+		{"namespace":"default","layer":"bronze","name":"orders"},
+		{"namespace":"default","layer":"silver","name":"orders_clean","type":"python"}
+	]}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, resp.Created)
+	assert.Equal(t, 0, resp.Failed)
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, "created", resp.Results[0].Status)
+	assert.Equal(t, "created", resp.Results[1].Status)
+	assert.Equal(t, 1, resp.Results[1].Index)
+	require.Len(t, store.pipelines, 2)
+	assert.Equal(t, "sql", store.pipelines[0].Type)
+	assert.Equal(t, "python", store.pipelines[1].Type)
+}
+
+func TestBatchCreatePipelines_PartialConflict_ReportsPerItem(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+
+	rec, resp := postBatchCreate(t, router, `{"pipelines":[
+		{"namespace":"default","layer":"bronze","name":"orders"},
+		{"namespace":"default","layer":"bronze","name":"customers"},
+		{"namespace":"default","layer":"bronze","name":"customers"}
+	]}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, resp.Created)
+	assert.Equal(t, 2, resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, "conflict", resp.Results[0].Status)
+	assert.NotEmpty(t, resp.Results[0].Error)
+	assert.Equal(t, "created", resp.Results[1].Status)
+	assert.Equal(t, "conflict", resp.Results[2].Status, "duplicate within the batch is a conflict")
+	assert.Len(t, store.pipelines, 2)
+}
+
+func TestBatchCreatePipelines_ValidationErrors_SkipsInvalidItems(t *testing.T) {
+	srv, store := newTestServer()
+	router := api.NewRouter(srv)
+
+	rec, resp := postBatchCreate(t, router, `{"pipelines":[
+		{"namespace":"default","layer":"platinum","name":"orders"},
+		{"namespace":"default","layer":"bronze","name":"MyPipeline"},
+		{"namespace":"default","layer":"bronze"},
+		{"namespace":"default","layer":"gold","name":"revenue"}
+	]}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, resp.Created)
+	assert.Equal(t, 3, resp.Failed)
+	require.Len(t, resp.Results, 4)
+	assert.Equal(t, "invalid", resp.Results[0].Status)
+	assert.Contains(t, resp.Results[0].Error, "layer must be")
+	assert.Equal(t, "invalid", resp.Results[1].Status)
+	assert.Contains(t, resp.Results[1].Error, "lowercase slug")
+	assert.Equal(t, "invalid", resp.Results[2].Status)
+	assert.Equal(t, "created", resp.Results[3].Status)
+	require.Len(t, store.pipelines, 1)
+	assert.Equal(t, "revenue", store.pipelines[0].Name)
+}
+
+func TestBatchCreatePipelines_EmptyBatch_Returns400(t *testing.T) {
+	srv, _ := newTestServer()
+	router := api.NewRouter(srv)
+
+	rec, _ := postBatchCreate(t, router, `{"pipelines":[]}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestBatchCreatePipelines_TooMany_Returns400(t *testing.T) {
+	srv, _ := newTestServer()
+	router := api.NewRouter(srv)
+
+	items := make([]string, 101)
+	for i := range items {
+		items[i] = fmt.Sprintf(`{"namespace":"default","layer":"bronze","name":"p%d"}`, i)
+	}
+	rec, _ := postBatchCreate(t, router, `{"pipelines":[`+strings.Join(items, ",")+`]}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 // --- Update Pipeline ---
 
 func TestUpdatePipeline_UpdateDescription_ReturnsUpdated(t *testing.T) {
@@ -421,6 +1013,102 @@ func TestUpdatePipeline_UpdateDescription_ReturnsUpdated(t *testing.T) {
 	assert.Equal(t, "orders", resp["name"])
 }
 
+// slowPipelineStore counts GetPipeline calls and holds each one open until
+// release is closed, so concurrent cache misses overlap.
+type slowPipelineStore struct {
+	*memoryPipelineStore
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (s *slowPipelineStore) GetPipeline(ctx context.Context, namespace, layer, name string) (*domain.Pipeline, error) {
+	s.calls.Add(1)
+	<-s.release
+	return s.memoryPipelineStore.GetPipeline(ctx, namespace, layer, name)
+}
+
+func TestGetPipeline_ColdCache_ConcurrentRequestsLoadOnce(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders"},
+	}
+	slow := &slowPipelineStore{memoryPipelineStore: store, release: make(chan struct{})}
+	srv.Pipelines = slow
+	srv.PipelineCache = cache.New[string, *domain.Pipeline](cache.Options{TTL: time.Minute})
+	router := api.NewRouter(srv)
+
+	const n = 10
+	var wg sync.WaitGroup
+	codes := make([]int, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			codes[i] = rec.Code
+		}(i)
+	}
+	require.Eventually(t, func() bool { return slow.calls.Load() >= 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond) // let the other requests pile up on the in-flight load
+	close(slow.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), slow.calls.Load())
+	for _, code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+}
+
+func TestGetPipeline_NotFound_CachedNegatively(t *testing.T) {
+	srv, store := newTestServer()
+	slow := &slowPipelineStore{memoryPipelineStore: store, release: make(chan struct{})}
+	close(slow.release)
+	srv.Pipelines = slow
+	srv.PipelineCache = cache.New[string, *domain.Pipeline](cache.Options{TTL: time.Minute, NegativeTTL: time.Minute})
+	router := api.NewRouter(srv)
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/ghost", http.NoBody)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+	assert.Equal(t, int32(1), slow.calls.Load())
+}
+
+func TestUpdatePipeline_WithCache_GetReturnsNewValue(t *testing.T) {
+	srv, store := newTestServer()
+	srv.PipelineCache = cache.New[string, *domain.Pipeline](cache.Options{TTL: time.Minute})
+	store.pipelines = []domain.Pipeline{
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "orders", Type: "sql", Description: "old desc"},
+	}
+	router := api.NewRouter(srv)
+
+	getDescription := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp["description"].(string)
+	}
+
+	// Warm the cache.
+	assert.Equal(t, "old desc", getDescription())
+	require.Equal(t, 1, srv.PipelineCache.Len())
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/orders", bytes.NewBufferString(`{"description":"new desc"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, "new desc", getDescription())
+}
+
 func TestUpdatePipeline_NotFound_Returns404(t *testing.T) {
 	srv, _ := newTestServer()
 	router := api.NewRouter(srv)
@@ -450,6 +1138,30 @@ func TestDeletePipeline_Exists_Returns204(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 }
 
+func TestDeletePipeline_WithCache_GetReturns404(t *testing.T) {
+	srv, store := newTestServer()
+	srv.PipelineCache = cache.New[string, *domain.Pipeline](cache.Options{TTL: time.Minute})
+	store.pipelines = []domain.Pipeline{
+		{Namespace: "default", Layer: domain.LayerBronze, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
 func TestDeletePipeline_NotFound_Returns404(t *testing.T) {
 	srv, _ := newTestServer()
 	router := api.NewRouter(srv)
@@ -612,3 +1324,92 @@ func TestPublishPipeline_ValidationWarnings_StillPublishes(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "published", body["status"])
 }
+
+func TestPublishPipeline_ValidationWarnings_StoredOnPipeline(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	storageStore := srv.Storage.(*memoryStorageStore)
+	storageStore.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT 1")
+
+	result := &api.ValidationResult{
+		Valid: true,
+		Files: []api.FileValidation{
+			{
+				Path:     "default/pipelines/silver/orders/pipeline.sql",
+				Valid:    true,
+				Warnings: []string{"Bare function call outside Jinja delimiters"},
+			},
+		},
+	}
+	srv.Executor = &publishMockExecutor{validateResult: result}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var publishBody struct {
+		Validation *api.ValidationResult `json:"validation"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&publishBody))
+	assert.Equal(t, result, publishBody.Validation)
+
+	// The warnings outlive the publish response: the pipeline detail returns them.
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var pipeline domain.Pipeline
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&pipeline))
+	require.NotNil(t, pipeline.Validation)
+	assert.True(t, pipeline.Validation.Valid)
+	require.Len(t, pipeline.Validation.Files, 1)
+	assert.Equal(t, []string{"Bare function call outside Jinja delimiters"}, pipeline.Validation.Files[0].Warnings)
+}
+
+func TestPublishPipeline_ValidationErrors_StoredOnPipeline(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql",
+			Validation: &api.ValidationResult{Valid: true, Files: []api.FileValidation{}}},
+	}
+	srv.Executor = &publishMockExecutor{
+		validateResult: &api.ValidationResult{
+			Valid: false,
+			Files: []api.FileValidation{
+				{Path: "default/pipelines/silver/orders/pipeline.sql", Errors: []string{"Jinja syntax error: unexpected '}'"}},
+			},
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	// The failed validation replaces the previous (valid) result.
+	require.NotNil(t, store.pipelines[0].Validation)
+	assert.False(t, store.pipelines[0].Validation.Valid)
+	assert.Nil(t, store.pipelines[0].PublishedAt, "rejected publish leaves published state alone")
+}
+
+func TestPublishPipeline_RunnerUnavailable_KeepsStoredValidation(t *testing.T) {
+	srv, store := newTestServer()
+	previous := &api.ValidationResult{Valid: true, Files: []api.FileValidation{}}
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql", Validation: previous},
+	}
+	srv.Executor = &publishMockExecutor{validateErr: errors.New("runner unavailable")}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	assert.Same(t, previous, store.pipelines[0].Validation)
+	assert.NotContains(t, rec.Body.String(), `"validation"`)
+}
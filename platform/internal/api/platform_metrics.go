@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/rat-data/rat/platform/internal/cache"
+)
+
+// DefaultPlatformCountsTTL is how long /metrics reuses the store counts.
+// Scrapes every 15s across a few replicas would otherwise run the GROUP BY
+// queries several times a minute for numbers that move slowly.
+const DefaultPlatformCountsTTL = 30 * time.Second
+
+// EnabledCount splits a count of schedules or triggers by enabled flag.
+type EnabledCount struct {
+	Enabled  int
+	Disabled int
+}
+
+// PlatformCounts is a snapshot of run, schedule and trigger counts for /metrics.
+type PlatformCounts struct {
+	RunsByStatus   map[string]int          // status → runs
+	RunsByTrigger  map[string]int          // trigger source → runs ("manual", "schedule", "trigger:webhook", ...)
+	Schedules      EnabledCount            // schedules of live pipelines
+	TriggersByType map[string]EnabledCount // trigger type → triggers of live pipelines
+}
+
+// PlatformCounter computes PlatformCounts from the stores (COUNT ... GROUP BY).
+type PlatformCounter interface {
+	PlatformCounts(ctx context.Context) (*PlatformCounts, error)
+}
+
+// platformCounts returns the cached counts, recomputing them when the cache
+// is empty or expired. Nil when no counter is wired or the query failed —
+// /metrics then omits the series rather than failing the whole scrape.
+func (s *Server) platformCounts(ctx context.Context) *PlatformCounts {
+	if s.Counts == nil {
+		return nil
+	}
+	if s.CountsCache != nil {
+		if counts, ok := s.CountsCache.Get("all"); ok {
+			return counts
+		}
+	}
+	counts, err := s.Counts.PlatformCounts(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "metrics: platform counts failed", "error", err)
+		return nil
+	}
+	if s.CountsCache != nil {
+		s.CountsCache.Set("all", counts)
+	}
+	return counts
+}
+
+// newPlatformCountsCache returns the cache NewRouter installs for /metrics.
+func newPlatformCountsCache() *cache.Cache[string, *PlatformCounts] {
+	return cache.New[string, *PlatformCounts](cache.Options{TTL: DefaultPlatformCountsTTL, MaxEntries: 1})
+}
+
+// writePlatformCountMetrics renders PlatformCounts as the rat_runs,
+// rat_runs_by_trigger, rat_schedules and rat_triggers gauges. Label values
+// are emitted in sorted order so scrapes are stable.
+func writePlatformCountMetrics(w io.Writer, counts *PlatformCounts) {
+	fmt.Fprintf(w, "# HELP rat_runs Runs currently stored, by status.\n")
+	fmt.Fprintf(w, "# TYPE rat_runs gauge\n")
+	for _, status := range sortedKeys(counts.RunsByStatus) {
+		fmt.Fprintf(w, "rat_runs{status=%q} %d\n", status, counts.RunsByStatus[status])
+	}
+
+	fmt.Fprintf(w, "# HELP rat_runs_by_trigger Runs currently stored, by what started them.\n")
+	fmt.Fprintf(w, "# TYPE rat_runs_by_trigger gauge\n")
+	for _, source := range sortedKeys(counts.RunsByTrigger) {
+		fmt.Fprintf(w, "rat_runs_by_trigger{source=%q} %d\n", source, counts.RunsByTrigger[source])
+	}
+
+	fmt.Fprintf(w, "# HELP rat_schedules Cron schedules of live pipelines, by enabled flag.\n")
+	fmt.Fprintf(w, "# TYPE rat_schedules gauge\n")
+	fmt.Fprintf(w, "rat_schedules{enabled=\"true\"} %d\n", counts.Schedules.Enabled)
+	fmt.Fprintf(w, "rat_schedules{enabled=\"false\"} %d\n", counts.Schedules.Disabled)
+
+	fmt.Fprintf(w, "# HELP rat_triggers Pipeline triggers of live pipelines, by type and enabled flag.\n")
+	fmt.Fprintf(w, "# TYPE rat_triggers gauge\n")
+	for _, typ := range sortedKeys(counts.TriggersByType) {
+		c := counts.TriggersByType[typ]
+		fmt.Fprintf(w, "rat_triggers{type=%q,enabled=\"true\"} %d\n", typ, c.Enabled)
+		fmt.Fprintf(w, "rat_triggers{type=%q,enabled=\"false\"} %d\n", typ, c.Disabled)
+	}
+}
+
+// sortedKeys returns m's keys in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -126,7 +126,7 @@ func parseIfMatch(h string) (*int64, bool, error) {
 	raw = strings.Trim(raw, `"`)
 	v, err := strconv.ParseInt(raw, 10, 64)
 	if err != nil {
-		return nil, true, fmt.Errorf("If-Match must be a quoted integer (config_version), got %q", h)
+		return nil, true, fmt.Errorf("If-Match must be a quoted integer version, got %q", h)
 	}
 	if v < 0 {
 		return nil, true, fmt.Errorf("If-Match must be non-negative, got %d", v)
@@ -134,8 +134,9 @@ func parseIfMatch(h string) (*int64, bool, error) {
 	return &v, true, nil
 }
 
-// setETag sets the ETag response header from a config_version. The value is
-// quoted per RFC 7232 so clients can echo it back verbatim in If-Match.
+// setETag sets the ETag response header from a config_version (or a
+// pipeline's draft_revision). The value is quoted per RFC 7232 so clients can
+// echo it back verbatim in If-Match.
 func setETag(w http.ResponseWriter, configVersion int64) {
 	w.Header().Set("ETag", `"`+strconv.FormatInt(configVersion, 10)+`"`)
 }
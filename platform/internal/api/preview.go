@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -16,9 +18,10 @@ type PreviewRequest struct {
 	Code        string   `json:"code,omitempty"`
 }
 
-// MountPreviewRoutes registers the preview endpoint on the router.
+// MountPreviewRoutes registers the preview and validate endpoints on the router.
 func MountPreviewRoutes(r chi.Router, srv *Server) {
 	r.Post("/pipelines/{namespace}/{layer}/{name}/preview", srv.HandlePreviewPipeline)
+	r.Post("/pipelines/{namespace}/{layer}/{name}/validate", srv.HandleValidatePipeline)
 }
 
 // HandlePreviewPipeline executes a pipeline in preview mode (dry-run).
@@ -69,12 +72,83 @@ func (s *Server) HandlePreviewPipeline(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Serve an unchanged pipeline's last preview from cache. ?no_cache=true
+	// skips the lookup but still refreshes the entry.
+	cacheKey := pipelineCacheKey(namespace, layer, name)
+	var fingerprint string
+	if s.PreviewCache != nil && s.Storage != nil {
+		fp, err := s.previewFingerprint(r.Context(), pipeline, req)
+		if err != nil {
+			slog.Warn("preview cache: fingerprint failed, bypassing cache", "pipeline", cacheKey, "error", err)
+		} else {
+			fingerprint = fp
+		}
+		if fingerprint != "" && r.URL.Query().Get("no_cache") != "true" {
+			if entry, ok := s.PreviewCache.Get(cacheKey); ok && entry != nil && entry.Key == fingerprint {
+				w.Header().Set("X-Preview-Cache", "hit")
+				writeJSON(w, http.StatusOK, entry.Result)
+				return
+			}
+		}
+	}
+
+	// The request context reaches the runner RPC, so a client that navigates
+	// away cancels the preview on the runner too.
 	result, err := s.Executor.Preview(r.Context(), pipeline, req.Limit, req.SampleFiles, req.Code)
 	if err != nil {
+		if errors.Is(r.Context().Err(), context.Canceled) {
+			slog.Debug("preview cancelled: client disconnected", "pipeline", namespace+"/"+layer+"/"+name)
+			return
+		}
 		slog.Error("preview failed", "pipeline", namespace+"/"+layer+"/"+name, "error", err)
 		errorJSON(w, "preview execution failed", "INTERNAL", http.StatusInternalServerError)
 		return
 	}
 
+	// Errored previews aren't cached: the cause may be transient upstream.
+	if fingerprint != "" && result.Error == "" {
+		s.PreviewCache.Set(cacheKey, &PreviewCacheEntry{Key: fingerprint, Result: result})
+	}
+	if s.PreviewCache != nil {
+		w.Header().Set("X-Preview-Cache", "miss")
+	}
 	writeJSON(w, http.StatusOK, result)
 }
+
+// HandleValidatePipeline runs the runner's template validation on a pipeline
+// — the same check publish does — without snapshotting versions or touching
+// publish state. Responds 200 with the ValidationResult when valid and 422
+// with it when not.
+func (s *Server) HandleValidatePipeline(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	if s.Executor == nil {
+		errorJSON(w, "executor not available", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	result, err := s.Executor.ValidatePipeline(r.Context(), pipeline)
+	if err != nil {
+		slog.Warn("template validation failed to run", "pipeline", namespace+"/"+layer+"/"+name, "error", err)
+		errorJSON(w, "template validation unavailable", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Valid {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, result)
+}
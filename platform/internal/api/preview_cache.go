@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// PreviewCacheEntry is the value stored in Server.PreviewCache: the last
+// successful preview of a pipeline and the fingerprint of its inputs.
+// One entry per pipeline — previewing with different sample files or limit
+// replaces it — so a draft edit only has one key to invalidate.
+type PreviewCacheEntry struct {
+	Key    string
+	Result *PreviewResult
+}
+
+// previewFingerprint hashes everything that determines a preview's output
+// on ratd's side: the pipeline, every draft file under its storage prefix,
+// the sample file set, the row limit and any inline code. Draft files are
+// identified by the ETag from the listing, so a preview costs one LIST rather
+// than a read per file; a file listed without an ETag is read and hashed
+// instead. Upstream table data isn't covered — the cache TTL bounds that
+// staleness.
+func (s *Server) previewFingerprint(ctx context.Context, pipeline *domain.Pipeline, req PreviewRequest) (string, error) {
+	files, err := s.Storage.ListFiles(ctx, pipeline.StoragePrefix())
+	if err != nil {
+		return "", fmt.Errorf("list pipeline files: %w", err)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	h := sha256.New()
+	fmt.Fprintf(h, "pipeline=%s\nlimit=%d\nsamples=%s\ncode=%d:%s\n",
+		pipeline.ID, req.Limit, strings.Join(req.SampleFiles, ","), len(req.Code), req.Code)
+	for _, f := range files {
+		if f.ETag != "" {
+			fmt.Fprintf(h, "file=%s:%d:etag=%s\n", f.Path, f.Size, f.ETag)
+			continue
+		}
+		fc, err := s.Storage.ReadFile(ctx, f.Path)
+		if err != nil {
+			return "", fmt.Errorf("read %s: %w", f.Path, err)
+		}
+		if fc == nil {
+			continue // deleted between list and read
+		}
+		fmt.Fprintf(h, "file=%s:%d:%s\n", f.Path, len(fc.Content), fc.Content)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// invalidatePreview drops the cached preview of a pipeline after a draft edit.
+func (s *Server) invalidatePreview(namespace, layer, name string) {
+	if s.PreviewCache != nil {
+		s.PreviewCache.Delete(pipelineCacheKey(namespace, layer, name))
+	}
+}
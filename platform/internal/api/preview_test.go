@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/cache"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +24,7 @@ type previewExecutor struct {
 	result      *api.PreviewResult
 	err         error
 	capturedCode string
+	calls        int
 }
 
 func (e *previewExecutor) Submit(_ context.Context, _ *domain.Run, _ *domain.Pipeline) error {
@@ -32,6 +36,7 @@ func (e *previewExecutor) GetLogs(_ context.Context, _ string) ([]api.LogEntry,
 }
 func (e *previewExecutor) Preview(_ context.Context, _ *domain.Pipeline, _ int, _ []string, code string) (*api.PreviewResult, error) {
 	e.capturedCode = code
+	e.calls++
 	return e.result, e.err
 }
 func (e *previewExecutor) ValidatePipeline(_ context.Context, _ *domain.Pipeline) (*api.ValidationResult, error) {
@@ -198,3 +203,231 @@ func TestHandlePreviewPipeline_WithInlineCode(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Equal(t, "SELECT 1 AS x", exec.capturedCode)
 }
+
+// blockingPreviewExecutor blocks in Preview until ctx is cancelled.
+type blockingPreviewExecutor struct {
+	previewExecutor
+	started chan struct{}
+	ctxErr  chan error
+}
+
+func (e *blockingPreviewExecutor) Preview(ctx context.Context, _ *domain.Pipeline, _ int, _ []string, _ string) (*api.PreviewResult, error) {
+	close(e.started)
+	<-ctx.Done()
+	e.ctxErr <- ctx.Err()
+	return nil, fmt.Errorf("preview pipeline: %w", ctx.Err())
+}
+
+func TestHandlePreviewPipeline_ClientAbort_CancelsPreview(t *testing.T) {
+	pipelineStore := newMemoryPipelineStore()
+	require.NoError(t, pipelineStore.CreatePipeline(context.Background(), &domain.Pipeline{
+		Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql",
+	}))
+	exec := &blockingPreviewExecutor{started: make(chan struct{}), ctxErr: make(chan error, 1)}
+	srv := &api.Server{Pipelines: pipelineStore, Executor: exec}
+	r := chi.NewRouter()
+	r.Post("/api/v1/pipelines/{namespace}/{layer}/{name}/preview", srv.HandlePreviewPipeline)
+
+	ctx, abort := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/preview", http.NoBody).WithContext(ctx)
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		r.ServeHTTP(w, req)
+		close(done)
+	}()
+
+	<-exec.started
+	abort() // the client disconnects
+	select {
+	case err := <-exec.ctxErr:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("preview context was not cancelled on client abort")
+	}
+	<-done
+	assert.Empty(t, w.Body.String(), "nothing is written to a disconnected client")
+}
+
+// --- Preview cache ---
+
+// newPreviewCacheTestServer returns a full router over a server with one SQL
+// pipeline (default/silver/orders), its draft file and a preview cache.
+func newPreviewCacheTestServer(t *testing.T) (http.Handler, *previewExecutor, *memoryStorageStore) {
+	t.Helper()
+	srv, pipelineStore := newTestServer()
+	require.NoError(t, pipelineStore.CreatePipeline(context.Background(), &domain.Pipeline{
+		Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql",
+		S3Path: "default/pipelines/silver/orders/",
+	}))
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT 1 AS id")
+
+	exec := &previewExecutor{result: &api.PreviewResult{Rows: []map[string]interface{}{{"id": 1}}}}
+	srv.Executor = exec
+	srv.PreviewCache = cache.New[string, *api.PreviewCacheEntry](cache.Options{})
+	return api.NewRouter(srv), exec, storage
+}
+
+func postPreview(t *testing.T, router http.Handler, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/preview"+query, http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	return rec
+}
+
+func TestHandlePreviewPipeline_Cache_HitOnUnchangedPipeline(t *testing.T) {
+	router, exec, _ := newPreviewCacheTestServer(t)
+
+	first := postPreview(t, router, "")
+	assert.Equal(t, "miss", first.Header().Get("X-Preview-Cache"))
+
+	second := postPreview(t, router, "")
+	assert.Equal(t, "hit", second.Header().Get("X-Preview-Cache"))
+	assert.Equal(t, 1, exec.calls, "second preview is served from cache")
+	assert.JSONEq(t, first.Body.String(), second.Body.String())
+
+	// A different limit is a different preview.
+	postPreview(t, router, "?limit=10")
+	assert.Equal(t, 2, exec.calls)
+}
+
+func TestHandlePreviewPipeline_Cache_FingerprintDoesNotReadDraftFiles(t *testing.T) {
+	router, _, storage := newPreviewCacheTestServer(t)
+
+	postPreview(t, router, "")
+	postPreview(t, router, "")
+
+	storage.mu.Lock()
+	defer storage.mu.Unlock()
+	assert.Zero(t, storage.reads, "listed ETags identify the draft files")
+}
+
+func TestHandlePreviewPipeline_Cache_NoCacheBypasses(t *testing.T) {
+	router, exec, _ := newPreviewCacheTestServer(t)
+
+	postPreview(t, router, "")
+	rec := postPreview(t, router, "?no_cache=true")
+	assert.Equal(t, "miss", rec.Header().Get("X-Preview-Cache"))
+	assert.Equal(t, 2, exec.calls)
+}
+
+func TestHandlePreviewPipeline_Cache_InvalidatedOnContentChange(t *testing.T) {
+	router, exec, storage := newPreviewCacheTestServer(t)
+	postPreview(t, router, "")
+
+	// Edit through the files API: the entry is dropped and the content hash changes.
+	body := `{"content":"SELECT 2 AS id"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/files/default/pipelines/silver/orders/pipeline.sql", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, "miss", postPreview(t, router, "").Header().Get("X-Preview-Cache"))
+	assert.Equal(t, 2, exec.calls)
+
+	// An edit that bypasses the API (e.g. a direct S3 write) still misses
+	// because the draft content hash changed.
+	storage.mu.Lock()
+	storage.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT 3 AS id")
+	storage.mu.Unlock()
+	assert.Equal(t, "miss", postPreview(t, router, "").Header().Get("X-Preview-Cache"))
+	assert.Equal(t, 3, exec.calls)
+}
+
+func TestHandlePreviewPipeline_Cache_ErroredPreviewNotCached(t *testing.T) {
+	router, exec, _ := newPreviewCacheTestServer(t)
+	exec.result = &api.PreviewResult{Error: "upstream table missing"}
+
+	postPreview(t, router, "")
+	postPreview(t, router, "")
+	assert.Equal(t, 2, exec.calls)
+}
+
+// --- Validate ---
+
+func postValidate(t *testing.T, srv *api.Server, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/"+path+"/validate", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleValidatePipeline_Valid_Returns200(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	srv.Executor = &publishMockExecutor{
+		validateResult: &api.ValidationResult{
+			Valid: true,
+			Files: []api.FileValidation{
+				{Path: "default/pipelines/silver/orders/pipeline.sql", Valid: true, Warnings: []string{"unused macro"}},
+			},
+		},
+	}
+
+	rec := postValidate(t, srv, "default/silver/orders")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var result api.ValidationResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.True(t, result.Valid)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, []string{"unused macro"}, result.Files[0].Warnings)
+}
+
+func TestHandleValidatePipeline_Invalid_Returns422WithoutPublishing(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql", DraftDirty: true},
+	}
+	versions := newMemoryVersionStore()
+	srv.Versions = versions
+	srv.Executor = &publishMockExecutor{
+		validateResult: &api.ValidationResult{
+			Valid: false,
+			Files: []api.FileValidation{
+				{Path: "default/pipelines/silver/orders/pipeline.sql", Errors: []string{"Jinja syntax error: unexpected '}'"}},
+			},
+		},
+	}
+
+	rec := postValidate(t, srv, "default/silver/orders")
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+
+	var result api.ValidationResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.False(t, result.Valid)
+	assert.Equal(t, []string{"Jinja syntax error: unexpected '}'"}, result.Files[0].Errors)
+
+	p := store.pipelines[0]
+	assert.Nil(t, p.PublishedAt)
+	assert.True(t, p.DraftDirty)
+	assert.Nil(t, p.Validation, "validate doesn't store the result")
+	latest, err := versions.LatestVersionNumber(context.Background(), p.ID)
+	require.NoError(t, err)
+	assert.Zero(t, latest)
+}
+
+func TestHandleValidatePipeline_RunnerUnavailable_Returns503(t *testing.T) {
+	srv, store := newTestServer()
+	store.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	srv.Executor = &publishMockExecutor{validateErr: fmt.Errorf("runner unreachable")}
+
+	rec := postValidate(t, srv, "default/silver/orders")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestHandleValidatePipeline_UnknownPipeline_Returns404(t *testing.T) {
+	srv, _ := newTestServer()
+	srv.Executor = &publishMockExecutor{validateResult: &api.ValidationResult{Valid: true}}
+
+	rec := postValidate(t, srv, "default/silver/missing")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
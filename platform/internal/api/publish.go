@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -43,24 +44,43 @@ func (s *Server) HandlePublishPipeline(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Refuse to publish a pipeline that sits on a pipeline_success trigger
+	// cycle (e.g. one created before cycle checks existed) — its runs would
+	// re-trigger each other forever.
+	if s.Triggers != nil {
+		graph, err := BuildTriggerGraph(r.Context(), s.Triggers)
+		if err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+		if cycle := graph.FindCycle(pipelineGraphKey(pipeline.Namespace, string(pipeline.Layer), pipeline.Name)); cycle != nil {
+			writeTriggerCycle(w, cycle)
+			return
+		}
+	}
+
 	// Validate templates if executor is available (soft dependency)
+	var validation *ValidationResult
 	if s.Executor != nil {
 		result, err := s.Executor.ValidatePipeline(r.Context(), pipeline)
 		if err != nil {
 			// Runner unavailable — log and proceed (don't block publish)
 			slog.Warn("template validation skipped: runner unavailable", "error", err)
-		} else if !result.Valid {
-			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
-				"error":      "template validation failed",
-				"validation": result,
-			})
-			return
+		} else {
+			validation = result
+			s.storeValidation(r.Context(), pipeline, result)
+			if !result.Valid {
+				writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+					"error":      "template validation failed",
+					"validation": result,
+				})
+				return
+			}
 		}
 	}
 
 	// List all files under the pipeline's S3 prefix
-	prefix := namespace + "/pipelines/" + layer + "/" + name + "/"
-	files, err := s.Storage.ListFiles(r.Context(), prefix)
+	files, err := s.Storage.ListFiles(r.Context(), pipeline.StoragePrefix())
 	if err != nil {
 		internalError(w, "failed to list pipeline files", err)
 		return
@@ -136,10 +156,28 @@ func (s *Server) HandlePublishPipeline(w http.ResponseWriter, r *http.Request) {
 		s.PipelineCache.Delete(pipelineCacheKey(namespace, layer, name))
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	resp := map[string]interface{}{
 		"status":   "published",
 		"version":  versionNumber,
 		"message":  req.Message,
 		"versions": versions,
-	})
+	}
+	if validation != nil {
+		resp["validation"] = validation
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// storeValidation keeps the latest validation result on the pipeline so
+// warnings stay visible after a successful publish. Best-effort: a failed
+// write is logged and doesn't block the publish.
+func (s *Server) storeValidation(ctx context.Context, pipeline *domain.Pipeline, result *ValidationResult) {
+	if err := s.Pipelines.UpdatePipelineValidation(ctx, pipeline.ID, result); err != nil {
+		slog.Warn("failed to store validation result", "pipeline_id", pipeline.ID, "error", err)
+		return
+	}
+	if s.PipelineCache != nil {
+		s.PipelineCache.Delete(pipelineCacheKey(pipeline.Namespace, string(pipeline.Layer), pipeline.Name))
+	}
 }
+
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/domain"
 )
 
 // QualityTest represents a data quality test definition.
@@ -30,18 +31,25 @@ type QualityTestResult struct {
 }
 
 // QualityStore defines the persistence interface for quality tests.
-// Tests are SQL files stored in S3 under pipelines/{layer}/{name}/tests/quality/.
+// Tests are SQL files stored in S3 under {prefix}tests/quality/, where prefix
+// is the pipeline's storage prefix (domain.Pipeline.StoragePrefix).
 type QualityStore interface {
-	ListTests(ctx context.Context, namespace, layer, pipeline string) ([]QualityTest, error)
-	CreateTest(ctx context.Context, namespace, layer, pipeline string, test QualityTest) error
-	DeleteTest(ctx context.Context, namespace, layer, pipeline, testName string) error
-	RunTests(ctx context.Context, namespace, layer, pipeline string) ([]QualityTestResult, error)
+	ListTests(ctx context.Context, prefix string) ([]QualityTest, error)
+	CreateTest(ctx context.Context, prefix string, test QualityTest) error
+	DeleteTest(ctx context.Context, prefix, testName string) error
+	RunTests(ctx context.Context, prefix string) ([]QualityTestResult, error)
 
 	// ListTestCounts returns the count of quality tests per pipeline key ("ns.layer.name")
 	// in a single batch operation, avoiding N+1 queries when building the lineage graph.
 	ListTestCounts(ctx context.Context, namespace string) (map[string]int, error)
 }
 
+// qualityTestPath returns the storage path of a quality test of the pipeline
+// stored under prefix.
+func qualityTestPath(prefix, testName string) string {
+	return prefix + "tests/quality/" + testName + ".sql"
+}
+
 // CreateQualityTestRequest is the JSON body for POST /api/v1/pipelines/{namespace}/{layer}/{name}/tests.
 type CreateQualityTestRequest struct {
 	Name        string `json:"name"`
@@ -66,7 +74,17 @@ func (s *Server) HandleListQualityTests(w http.ResponseWriter, r *http.Request)
 	layer := chi.URLParam(r, "layer")
 	name := chi.URLParam(r, "name")
 
-	tests, err := s.Quality.ListTests(r.Context(), namespace, layer, name)
+	p, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	prefix := domain.PipelineS3Path(namespace, layer, name)
+	if p != nil {
+		prefix = p.StoragePrefix()
+	}
+
+	tests, err := s.Quality.ListTests(r.Context(), prefix)
 	if err != nil {
 		internalError(w, "internal error", err)
 		return
@@ -76,12 +94,9 @@ func (s *Server) HandleListQualityTests(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Annotate each test with published status based on pipeline's PublishedVersions.
-	p, pErr := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
-	if pErr == nil && p != nil && p.PublishedVersions != nil {
-		qualityPrefix := namespace + "/pipelines/" + layer + "/" + name + "/tests/quality/"
+	if p != nil && p.PublishedVersions != nil {
 		for i := range tests {
-			key := qualityPrefix + tests[i].Name + ".sql"
-			_, tests[i].Published = p.PublishedVersions[key]
+			_, tests[i].Published = p.PublishedVersions[qualityTestPath(prefix, tests[i].Name)]
 		}
 	}
 
@@ -130,7 +145,12 @@ func (s *Server) HandleCreateQualityTest(w http.ResponseWriter, r *http.Request)
 		Description: req.Description,
 	}
 
-	if err := s.Quality.CreateTest(r.Context(), namespace, layer, name, test); err != nil {
+	prefix, err := s.pipelineStoragePrefix(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if err := s.Quality.CreateTest(r.Context(), prefix, test); err != nil {
 		errorJSON(w, err.Error(), "ALREADY_EXISTS", http.StatusConflict)
 		return
 	}
@@ -138,7 +158,7 @@ func (s *Server) HandleCreateQualityTest(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusCreated, map[string]interface{}{
 		"name":     test.Name,
 		"severity": test.Severity,
-		"path":     namespace + "/pipelines/" + layer + "/" + name + "/tests/quality/" + test.Name + ".sql",
+		"path":     qualityTestPath(prefix, test.Name),
 	})
 }
 
@@ -149,7 +169,12 @@ func (s *Server) HandleDeleteQualityTest(w http.ResponseWriter, r *http.Request)
 	name := chi.URLParam(r, "name")
 	testName := chi.URLParam(r, "testName")
 
-	if err := s.Quality.DeleteTest(r.Context(), namespace, layer, name, testName); err != nil {
+	prefix, err := s.pipelineStoragePrefix(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if err := s.Quality.DeleteTest(r.Context(), prefix, testName); err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
@@ -163,7 +188,12 @@ func (s *Server) HandleRunQualityTests(w http.ResponseWriter, r *http.Request) {
 	layer := chi.URLParam(r, "layer")
 	name := chi.URLParam(r, "name")
 
-	results, err := s.Quality.RunTests(r.Context(), namespace, layer, name)
+	prefix, err := s.pipelineStoragePrefix(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	results, err := s.Quality.RunTests(r.Context(), prefix)
 	if err != nil {
 		internalError(w, "internal error", err)
 		return
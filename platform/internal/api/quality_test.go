@@ -48,7 +48,7 @@ func TestListQualityTests_Empty_ReturnsEmptyList(t *testing.T) {
 
 func TestListQualityTests_WithData_ReturnsAll(t *testing.T) {
 	srv, qStore := newQualityTestServer()
-	qStore.tests["default/silver/orders"] = []api.QualityTest{
+	qStore.tests["default/pipelines/silver/orders/"] = []api.QualityTest{
 		{Name: "no_null_ids", SQL: "SELECT COUNT(*) FROM {{ this }} WHERE id IS NULL", Severity: "error"},
 		{Name: "positive_amounts", SQL: "SELECT COUNT(*) FROM {{ this }} WHERE amount < 0", Severity: "warn"},
 	}
@@ -77,7 +77,7 @@ func TestListQualityTests_AnnotatesPublished(t *testing.T) {
 			"default/pipelines/silver/orders/tests/quality/no_null_ids.sql":   "v2",
 		},
 	})
-	qStore.tests["default/silver/orders"] = []api.QualityTest{
+	qStore.tests["default/pipelines/silver/orders/"] = []api.QualityTest{
 		{Name: "no_null_ids", SQL: "SELECT 1", Severity: "error"},
 		{Name: "positive_amounts", SQL: "SELECT 1", Severity: "warn"},
 	}
@@ -114,7 +114,7 @@ func TestListQualityTests_NeverPublished_AllDraft(t *testing.T) {
 		Name:              "orders",
 		PublishedVersions: nil,
 	})
-	qStore.tests["default/silver/orders"] = []api.QualityTest{
+	qStore.tests["default/pipelines/silver/orders/"] = []api.QualityTest{
 		{Name: "test1", SQL: "SELECT 1", Severity: "error"},
 	}
 	router := api.NewRouter(srv)
@@ -173,7 +173,7 @@ func TestCreateQualityTest_MissingSQL_Returns400(t *testing.T) {
 
 func TestCreateQualityTest_Duplicate_Returns409(t *testing.T) {
 	srv, qStore := newQualityTestServer()
-	qStore.tests["default/silver/orders"] = []api.QualityTest{
+	qStore.tests["default/pipelines/silver/orders/"] = []api.QualityTest{
 		{Name: "no_null_ids", SQL: "SELECT 1"},
 	}
 	router := api.NewRouter(srv)
@@ -236,7 +236,7 @@ func TestCreateQualityTest_DefaultsSeverityToError(t *testing.T) {
 
 func TestDeleteQualityTest_Exists_Returns204(t *testing.T) {
 	srv, qStore := newQualityTestServer()
-	qStore.tests["default/silver/orders"] = []api.QualityTest{
+	qStore.tests["default/pipelines/silver/orders/"] = []api.QualityTest{
 		{Name: "no_null_ids"},
 	}
 	router := api.NewRouter(srv)
@@ -263,7 +263,7 @@ func TestDeleteQualityTest_NotFound_Returns500(t *testing.T) {
 
 func TestRunQualityTests_WithTests_ReturnsResults(t *testing.T) {
 	srv, qStore := newQualityTestServer()
-	qStore.tests["default/silver/orders"] = []api.QualityTest{
+	qStore.tests["default/pipelines/silver/orders/"] = []api.QualityTest{
 		{Name: "no_null_ids", SQL: "SELECT 1", Severity: "error"},
 		{Name: "positive_amounts", SQL: "SELECT 1", Severity: "warn"},
 	}
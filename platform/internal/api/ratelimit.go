@@ -1,18 +1,45 @@
 package api
 
 import (
+	"fmt"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/rat-data/rat/platform/internal/auth"
+	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/plugins"
+)
+
+// Rate limit key strategies: what identifies a caller's bucket.
+const (
+	// RateLimitKeyIP buckets every request by resolved client IP (default).
+	RateLimitKeyIP = "ip"
+	// RateLimitKeyPrincipal buckets authenticated requests by user id (auth
+	// plugin) or validated API key, falling back to client IP for anonymous ones.
+	RateLimitKeyPrincipal = "principal"
 )
 
-// RateLimitConfig configures the per-IP rate limiter.
+// ParseRateLimitKeyStrategy validates a RATE_LIMIT_KEY value. Empty means ip.
+func ParseRateLimitKeyStrategy(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", RateLimitKeyIP:
+		return RateLimitKeyIP, nil
+	case RateLimitKeyPrincipal:
+		return RateLimitKeyPrincipal, nil
+	}
+	return "", fmt.Errorf("unknown rate limit key strategy %q (want %q or %q)", s, RateLimitKeyIP, RateLimitKeyPrincipal)
+}
+
+// RateLimitConfig configures the rate limiter.
 type RateLimitConfig struct {
 	RequestsPerSecond float64       // Token refill rate (e.g. 50 = 50 req/s)
 	Burst             int           // Max burst size (tokens in bucket)
 	CleanupInterval   time.Duration // How often to evict stale entries
+	KeyStrategy       string        // RateLimitKeyIP (default when empty) or RateLimitKeyPrincipal
 }
 
 // DefaultRateLimitConfig returns sensible defaults (50 req/s, burst of 100).
@@ -56,13 +83,75 @@ func DefaultEndpointRateLimitConfig() EndpointRateLimitConfig {
 	}
 }
 
+// DefaultRouteRateLimits maps the expensive chi route patterns to the tighter
+// Query and Preview limits from DefaultEndpointRateLimitConfig. Everything not
+// listed is governed by the global limiter alone.
+func DefaultRouteRateLimits() map[string]RateLimitConfig {
+	endpoints := DefaultEndpointRateLimitConfig()
+	return map[string]RateLimitConfig{
+		"/api/v1/query": endpoints.Query,
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/preview": endpoints.Preview,
+		"/api/v1/tables/{namespace}/{layer}/{name}/preview":    endpoints.Preview,
+	}
+}
+
+// RouteRateLimit returns a middleware that applies a separate limiter to each
+// route pattern in routes; requests to other routes pass through untouched.
+// Configs with an empty KeyStrategy use defaultStrategy.
+//
+// It reads the matched chi route pattern, so it must be mounted post-match
+// (via r.With on the routes, like ValidatePathParams) — under r.Use the
+// pattern is still empty. Call the returned stop func on shutdown.
+func RouteRateLimit(routes map[string]RateLimitConfig, defaultStrategy string) (stop func(), mw func(http.Handler) http.Handler) {
+	limiters := make(map[string]*RateLimiter, len(routes))
+	strategies := make(map[string]string, len(routes))
+	for pattern, cfg := range routes {
+		if cfg.KeyStrategy == "" {
+			cfg.KeyStrategy = defaultStrategy
+		}
+		limiters[pattern] = newRateLimiter(cfg)
+		strategies[pattern] = cfg.KeyStrategy
+	}
+
+	stop = func() {
+		for _, rl := range limiters {
+			rl.Stop()
+		}
+	}
+	mw = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			pattern := rctx.RoutePattern()
+			rl, ok := limiters[pattern]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result := rl.allow(rateLimitKey(r, strategies[pattern]))
+			setRateLimitHeaders(w, result)
+
+			if !result.Allowed {
+				errorJSON(w, "rate limit exceeded", "RESOURCE_EXHAUSTED", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	return stop, mw
+}
+
 // RateLimitForEndpoint creates a rate limit middleware for a specific endpoint type.
 // Use this for endpoints that need tighter limits than the global default.
 func RateLimitForEndpoint(cfg RateLimitConfig) (*RateLimiter, func(http.Handler) http.Handler) {
 	return RateLimit(cfg)
 }
 
-// tokenBucket implements a simple per-IP token bucket.
+// tokenBucket implements a simple per-key token bucket.
 type tokenBucket struct {
 	tokens   float64
 	maxBurst float64
@@ -85,7 +174,7 @@ func (b *tokenBucket) allow(now time.Time) bool {
 	return true
 }
 
-// RateLimiter is a concurrent-safe per-IP rate limiter.
+// RateLimiter is a concurrent-safe per-key rate limiter.
 type RateLimiter struct {
 	mu      sync.Mutex
 	buckets map[string]*tokenBucket
@@ -113,13 +202,13 @@ type rateLimitResult struct {
 	Limit     int     // bucket capacity (for RateLimit-Limit header)
 }
 
-// allow checks whether a request from the given IP is allowed.
-func (rl *RateLimiter) allow(ip string) rateLimitResult {
+// allow checks whether a request for the given bucket key is allowed.
+func (rl *RateLimiter) allow(key string) rateLimitResult {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
 	now := time.Now()
-	b, ok := rl.buckets[ip]
+	b, ok := rl.buckets[key]
 	if !ok {
 		b = &tokenBucket{
 			tokens:   float64(rl.config.Burst),
@@ -127,7 +216,7 @@ func (rl *RateLimiter) allow(ip string) rateLimitResult {
 			rate:     rl.config.RequestsPerSecond,
 			lastSeen: now,
 		}
-		rl.buckets[ip] = b
+		rl.buckets[key] = b
 	}
 
 	allowed := b.allow(now)
@@ -149,7 +238,28 @@ func (rl *RateLimiter) allow(ip string) rateLimitResult {
 	}
 }
 
-// cleanup periodically removes stale IP entries (no requests for 10+ minutes).
+// exhausted reports whether the bucket for key has no token left, without
+// taking one.
+func (rl *RateLimiter) exhausted(key string) (rateLimitResult, bool) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	b, ok := rl.buckets[key]
+	if !ok {
+		return rateLimitResult{}, false
+	}
+	now := time.Now()
+	tokens := math.Min(b.maxBurst, b.tokens+now.Sub(b.lastSeen).Seconds()*b.rate)
+	if tokens >= 1 {
+		return rateLimitResult{}, false
+	}
+	var resetMs int64 = 1000
+	if b.rate > 0 {
+		resetMs = int64((1.0 - tokens) / b.rate * 1000)
+	}
+	return rateLimitResult{ResetMs: resetMs, Limit: int(b.maxBurst)}, true
+}
+
+// cleanup periodically removes stale bucket entries (no requests for 10+ minutes).
 func (rl *RateLimiter) cleanup() {
 	ticker := time.NewTicker(rl.config.CleanupInterval)
 	defer ticker.Stop()
@@ -161,9 +271,9 @@ func (rl *RateLimiter) cleanup() {
 		case <-ticker.C:
 			rl.mu.Lock()
 			cutoff := time.Now().Add(-10 * time.Minute)
-			for ip, b := range rl.buckets {
+			for key, b := range rl.buckets {
 				if b.lastSeen.Before(cutoff) {
-					delete(rl.buckets, ip)
+					delete(rl.buckets, key)
 				}
 			}
 			rl.mu.Unlock()
@@ -198,7 +308,31 @@ func setRateLimitHeaders(w http.ResponseWriter, result rateLimitResult) {
 	}
 }
 
-// RateLimit returns a middleware that limits requests per IP.
+// rateLimitKey returns the bucket key for a request. Under the principal
+// strategy it prefers the auth plugin's user id, then the fingerprint of the
+// API key that authenticated the request, and falls back to the client IP.
+// Keys are prefixed by kind so a user id can never collide with an IP.
+//
+// Only principals the auth middleware validated count — never the raw
+// Authorization header — otherwise a client could mint a fresh bucket per
+// request by sending random tokens. So the principal strategy must run after
+// the auth middleware.
+func rateLimitKey(r *http.Request, strategy string) string {
+	if strategy == RateLimitKeyPrincipal {
+		if user := plugins.UserFromContext(r.Context()); user != nil && user.UserID != "" {
+			return "user:" + user.UserID
+		}
+		if key, ok := auth.APIKeyFromContext(r.Context()); ok && key.ID != "" {
+			return "key:" + key.ID
+		}
+	}
+	// clientIP reads the trusted-proxy-resolved RemoteAddr (realip.go);
+	// the raw X-Real-IP / X-Forwarded-For headers are not trusted here.
+	return "ip:" + clientIP(r)
+}
+
+// RateLimit returns a middleware that limits requests per caller, keyed
+// according to cfg.KeyStrategy (per IP by default).
 // The returned RateLimiter can be stopped via its Stop() method.
 // On 429 responses, standard rate limit headers are included.
 func RateLimit(cfg RateLimitConfig) (*RateLimiter, func(http.Handler) http.Handler) {
@@ -206,11 +340,7 @@ func RateLimit(cfg RateLimitConfig) (*RateLimiter, func(http.Handler) http.Handl
 
 	mw := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// clientIP reads the trusted-proxy-resolved RemoteAddr (realip.go);
-			// the raw X-Real-IP / X-Forwarded-For headers are not trusted here.
-			ip := clientIP(r)
-
-			result := rl.allow(ip)
+			result := rl.allow(rateLimitKey(r, cfg.KeyStrategy))
 			setRateLimitHeaders(w, result)
 
 			if !result.Allowed {
@@ -222,3 +352,29 @@ func RateLimit(cfg RateLimitConfig) (*RateLimiter, func(http.Handler) http.Handl
 	}
 	return rl, mw
 }
+
+// FailedAuthRateLimit returns a middleware, mounted in front of auth, that
+// throttles per client IP only the requests auth rejects with 401. It keeps
+// API key guessing throttled when the main limiter is keyed on principals
+// and so has to run after auth, without making users who share an egress IP
+// share a budget for their authenticated requests.
+func FailedAuthRateLimit(cfg RateLimitConfig) (*RateLimiter, func(http.Handler) http.Handler) {
+	rl := newRateLimiter(cfg)
+
+	mw := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := "ip:" + clientIP(r)
+			if result, blocked := rl.exhausted(key); blocked {
+				setRateLimitHeaders(w, result)
+				errorJSON(w, "rate limit exceeded", "RESOURCE_EXHAUSTED", http.StatusTooManyRequests)
+				return
+			}
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+			if rw.status == http.StatusUnauthorized {
+				rl.allow(key)
+			}
+		})
+	}
+	return rl, mw
+}
@@ -6,7 +6,11 @@ import (
 	"testing"
 
 	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/auth"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRateLimit_AllowsBurst(t *testing.T) {
@@ -101,3 +105,247 @@ func TestRateLimit_KeysOnRemoteAddr_IgnoresRawXRealIPHeader(t *testing.T) {
 	handler.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
 }
+
+// sharedIPRequest builds a request from one shared egress IP, optionally as
+// an authenticated plugin user and/or with a bearer token the auth
+// middleware validated as an API key.
+func sharedIPRequest(userID, token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.RemoteAddr = "203.0.113.7:4000"
+	if userID != "" {
+		req = req.WithContext(plugins.ContextWithUser(req.Context(), &domain.UserIdentity{UserID: userID}))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		req = req.WithContext(auth.WithAPIKey(req.Context(), auth.APIKeyIdentity{ID: "apikey:" + token}))
+	}
+	return req
+}
+
+func TestRateLimit_PrincipalKey_UsersBehindOneIPAreIndependent(t *testing.T) {
+	rl, mw := api.RateLimit(api.RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		Burst:             2,
+		CleanupInterval:   60_000_000_000,
+		KeyStrategy:       api.RateLimitKeyPrincipal,
+	})
+	defer rl.Stop()
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Alice exhausts her bucket.
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, sharedIPRequest("alice", ""))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, sharedIPRequest("alice", ""))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// Bob, same IP, still has his full burst.
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, sharedIPRequest("bob", ""))
+		assert.Equal(t, http.StatusOK, rec.Code, "bob request %d", i+1)
+	}
+}
+
+func TestRateLimit_PrincipalKey_APIKeysBehindOneIPAreIndependent(t *testing.T) {
+	rl, mw := api.RateLimit(api.RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		Burst:             1,
+		CleanupInterval:   60_000_000_000,
+		KeyStrategy:       api.RateLimitKeyPrincipal,
+	})
+	defer rl.Stop()
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, sharedIPRequest("", "key-one"))
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, sharedIPRequest("", "key-one"))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, sharedIPRequest("", "key-two"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Anonymous callers fall back to the IP bucket, separate from both keys.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, sharedIPRequest("", ""))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// Without an auth middleware validating it, a bearer token is just a header:
+// rotating random tokens must not get a fresh bucket each time.
+func TestRateLimit_PrincipalKey_UnvalidatedTokensShareIPBucket(t *testing.T) {
+	rl, mw := api.RateLimit(api.RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		Burst:             1,
+		CleanupInterval:   60_000_000_000,
+		KeyStrategy:       api.RateLimitKeyPrincipal,
+	})
+	defer rl.Stop()
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	codes := make([]int, 0, 2)
+	for _, token := range []string{"random-1", "random-2"} {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.RemoteAddr = "203.0.113.7:4000"
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+// Under the principal strategy the main limiter runs after auth, so rejected
+// credentials are throttled per IP in front of it; accepted ones cost nothing.
+func TestFailedAuthRateLimit_ThrottlesOnlyRejectedRequests(t *testing.T) {
+	rl, mw := api.FailedAuthRateLimit(api.RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		Burst:             2,
+		CleanupInterval:   60_000_000_000,
+	})
+	defer rl.Stop()
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	send := func(token string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, sharedIPRequest("", token))
+		return rec.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, send("good"), "valid request %d", i+1)
+	}
+	assert.Equal(t, http.StatusUnauthorized, send("guess-1"))
+	assert.Equal(t, http.StatusUnauthorized, send("guess-2"))
+	assert.Equal(t, http.StatusTooManyRequests, send("guess-3"))
+	// The IP is throttled outright once its failed-auth budget is spent.
+	assert.Equal(t, http.StatusTooManyRequests, send("good"))
+}
+
+func TestRateLimit_IPKey_UsersBehindOneIPShareBucket(t *testing.T) {
+	rl, mw := api.RateLimit(api.RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		Burst:             1,
+		CleanupInterval:   60_000_000_000,
+	})
+	defer rl.Stop()
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, sharedIPRequest("alice", ""))
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, sharedIPRequest("bob", ""))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+// In principal mode NewRouter must mount the limiter after srv.Auth, or the
+// user the auth middleware resolves would never reach it.
+func TestNewRouter_PrincipalRateLimit_RunsAfterAuth(t *testing.T) {
+	srv, _, _ := newTriggerTestServer()
+	srv.RateLimit = &api.RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		Burst:             1,
+		CleanupInterval:   60_000_000_000,
+		KeyStrategy:       api.RateLimitKeyPrincipal,
+	}
+	srv.Auth = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := &domain.UserIdentity{UserID: r.Header.Get("X-Test-User")}
+			next.ServeHTTP(w, r.WithContext(plugins.ContextWithUser(r.Context(), user)))
+		})
+	}
+	router := api.NewRouter(srv)
+	defer srv.RateLimiterStop()
+
+	get := func(user string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/features", http.NoBody)
+		req.RemoteAddr = "203.0.113.7:4000"
+		req.Header.Set("X-Test-User", user)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get("alice"))
+	assert.Equal(t, http.StatusTooManyRequests, get("alice"))
+	assert.Equal(t, http.StatusOK, get("bob"))
+}
+
+func TestNewRouter_RouteRateLimits_StrictAndLenientRoutesAreIndependent(t *testing.T) {
+	srv, _, _ := newTriggerTestServer()
+	srv.RateLimit = &api.RateLimitConfig{
+		RequestsPerSecond: 0.001,
+		Burst:             5,
+		CleanupInterval:   60_000_000_000,
+	}
+	srv.RouteRateLimits = map[string]api.RateLimitConfig{
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/triggers": {
+			RequestsPerSecond: 0.001,
+			Burst:             1,
+			CleanupInterval:   60_000_000_000,
+		},
+	}
+	router := api.NewRouter(srv)
+	defer srv.RateLimiterStop()
+	defer srv.RouteRateLimiterStop()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		req.RemoteAddr = "203.0.113.7:4000"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Strict route: the second request hits its 1-token bucket.
+	rec := get("/api/v1/pipelines/default/silver/orders/triggers")
+	assert.NotEqual(t, http.StatusTooManyRequests, rec.Code)
+	rec = get("/api/v1/pipelines/default/silver/other/triggers")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code, "bucket is per route pattern, not per path")
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+
+	// Lenient route is unaffected and still has global tokens left.
+	rec = get("/api/v1/features")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, http.StatusOK, get("/api/v1/features").Code)
+
+	// The global budget still caps everything: 4 of its 5 tokens are spent.
+	assert.Equal(t, http.StatusOK, get("/api/v1/features").Code)
+	assert.Equal(t, http.StatusTooManyRequests, get("/api/v1/features").Code)
+}
+
+func TestDefaultRouteRateLimits_TighterThanGlobal(t *testing.T) {
+	global := api.DefaultRateLimitConfig()
+	routes := api.DefaultRouteRateLimits()
+	for _, pattern := range []string{
+		"/api/v1/query",
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/preview",
+		"/api/v1/tables/{namespace}/{layer}/{name}/preview",
+	} {
+		cfg, ok := routes[pattern]
+		require.True(t, ok, pattern)
+		assert.Less(t, cfg.RequestsPerSecond, global.RequestsPerSecond, pattern)
+		assert.Less(t, cfg.Burst, global.Burst, pattern)
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/auth"
+	"github.com/rat-data/rat/platform/internal/cache"
+)
+
+// ReadOnlyModeKey is the platform_settings key holding the read-only mode
+// flag (a JSON bool). While it is true every mutating API request is refused
+// with 503, for DB migrations and incident response.
+const ReadOnlyModeKey = "read_only_mode"
+
+// readOnlyTogglePath stays writable in read-only mode, or it could never be
+// turned off again.
+const readOnlyTogglePath = "/api/v1/settings/read-only"
+
+// DefaultReadOnlyCacheTTL is how long readOnlyGuard reuses the read-only flag.
+// It bounds how late other replicas see a toggle; the replica that served the
+// toggle sees it on the next request.
+const DefaultReadOnlyCacheTTL = 5 * time.Second
+
+// ReadOnlyModeRequest is the body of PUT /settings/read-only and the response
+// of both read-only endpoints.
+type ReadOnlyModeRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ReadOnlyModeEnabled reports whether read-only mode is on. A setting that has
+// never been written means off.
+func ReadOnlyModeEnabled(ctx context.Context, settings SettingsStore) (bool, error) {
+	return boolSetting(ctx, settings, ReadOnlyModeKey)
+}
+
+// MountReadOnlyRoutes registers the read-only mode toggle.
+func MountReadOnlyRoutes(r chi.Router, srv *Server) {
+	r.Get("/settings/read-only", srv.HandleGetReadOnlyMode)
+	r.Put("/settings/read-only", srv.HandlePutReadOnlyMode)
+}
+
+// newReadOnlyCache returns the cache NewRouter installs for readOnlyGuard.
+func newReadOnlyCache() *cache.Cache[string, bool] {
+	return cache.New[string, bool](cache.Options{TTL: DefaultReadOnlyCacheTTL, MaxEntries: 1})
+}
+
+// readOnlyEnabled is ReadOnlyModeEnabled through s.ReadOnlyCache, so mutating
+// requests don't each cost a settings query. Failed reads aren't cached.
+func (s *Server) readOnlyEnabled(ctx context.Context) (bool, error) {
+	if s.ReadOnlyCache == nil {
+		return ReadOnlyModeEnabled(ctx, s.Settings)
+	}
+	on, _, err := s.ReadOnlyCache.GetOrLoad(ReadOnlyModeKey, func() (bool, bool, error) {
+		on, err := ReadOnlyModeEnabled(ctx, s.Settings)
+		return on, true, err
+	})
+	return on, err
+}
+
+// readOnlyGuard refuses mutating requests with 503 READ_ONLY while read-only
+// mode is on. Reads (auth.IsReadRequest) and the toggle itself always pass. The flag is cached for
+// DefaultReadOnlyCacheTTL, so other replicas pick up a toggle within that. If
+// the flag can't be read the request is let through: the write will surface
+// the underlying outage itself, and a settings hiccup shouldn't freeze the API.
+func (s *Server) readOnlyGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.Settings == nil || auth.IsReadRequest(r) || r.URL.Path == readOnlyTogglePath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		on, err := s.readOnlyEnabled(r.Context())
+		if err != nil {
+			slog.WarnContext(r.Context(), "read-only mode check failed, allowing request",
+				"method", r.Method, "path", r.URL.Path, "error", err)
+		} else if on {
+			errorJSON(w, "platform is in read-only mode; writes are disabled until an administrator turns it off",
+				"READ_ONLY", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleGetReadOnlyMode returns whether the API is read-only.
+func (s *Server) HandleGetReadOnlyMode(w http.ResponseWriter, r *http.Request) {
+	if s.Settings == nil {
+		errorJSON(w, "settings not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	enabled, err := ReadOnlyModeEnabled(r.Context(), s.Settings)
+	if err != nil {
+		internalError(w, "failed to load read-only mode", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ReadOnlyModeRequest{Enabled: enabled})
+}
+
+// HandlePutReadOnlyMode turns read-only mode on or off. Admin-only: it blocks
+// every write platform-wide. Takes effect on this replica's next request and
+// on the others within DefaultReadOnlyCacheTTL.
+func (s *Server) HandlePutReadOnlyMode(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if s.Settings == nil {
+		errorJSON(w, "settings not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req ReadOnlyModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid JSON body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	data, err := json.Marshal(req.Enabled)
+	if err != nil {
+		internalError(w, "failed to marshal read-only mode", err)
+		return
+	}
+	if err := s.Settings.PutSetting(r.Context(), ReadOnlyModeKey, data); err != nil {
+		internalError(w, "failed to save read-only mode", err)
+		return
+	}
+	if s.ReadOnlyCache != nil {
+		s.ReadOnlyCache.Delete(ReadOnlyModeKey)
+	}
+
+	slog.Warn("read-only mode changed", "enabled", req.Enabled)
+	writeJSON(w, http.StatusOK, req)
+}
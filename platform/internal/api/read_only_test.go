@@ -0,0 +1,175 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakySettingsStore counts read-only flag reads and fails them while failing
+// is set.
+type flakySettingsStore struct {
+	*memorySettingsStore
+	reads   atomic.Int32
+	failing atomic.Bool
+}
+
+func (f *flakySettingsStore) GetSetting(ctx context.Context, key string) (json.RawMessage, error) {
+	if key == api.ReadOnlyModeKey {
+		f.reads.Add(1)
+		if f.failing.Load() {
+			return nil, errors.New("connection refused")
+		}
+	}
+	return f.memorySettingsStore.GetSetting(ctx, key)
+}
+
+func TestReadOnlyMode_Enabled_BlocksMutatingMethods(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	settings.settings[api.ReadOnlyModeKey] = []byte(`true`)
+	router := api.NewRouter(srv)
+
+	for _, tc := range []struct{ method, path, body string }{
+		{http.MethodPost, "/api/v1/pipelines", `{"namespace":"default","layer":"bronze","name":"orders"}`},
+		{http.MethodPut, "/api/v1/settings/maintenance", `{"enabled": true}`},
+		{http.MethodDelete, "/api/v1/pipelines/default/bronze/orders", ""},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "%s %s", tc.method, tc.path)
+		assert.Contains(t, rec.Body.String(), `"READ_ONLY"`)
+	}
+	assert.NotContains(t, settings.settings, api.MaintenanceModeKey)
+}
+
+func TestReadOnlyMode_Enabled_AllowsReads(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	settings.settings[api.ReadOnlyModeKey] = []byte(`true`)
+	router := api.NewRouter(srv)
+
+	for _, path := range []string{"/api/v1/pipelines", "/api/v1/settings/read-only", "/health"} {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+}
+
+func TestReadOnlyMode_Enabled_AllowsReadOnlyPOSTs(t *testing.T) {
+	srv, pipelineStore, settings := newRetentionTestServer()
+	settings.settings[api.ReadOnlyModeKey] = []byte(`true`)
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = append(pipelineStore.pipelines, domain.Pipeline{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "orders"})
+	runs := &memoryRunStore{}
+	srv.Runs = runs
+	require.NoError(t, runs.CreateRun(context.Background(), &domain.Run{PipelineID: pipelineID, Status: domain.RunStatusSuccess}))
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/latest", bytes.NewBufferString(`{"pipeline_ids":["`+pipelineID.String()+`"]}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), pipelineID.String())
+
+	// Preview and validate reach their handlers (which report the missing
+	// pipeline) instead of the read-only guard.
+	for _, path := range []string{"/api/v1/pipelines/default/silver/missing/preview", "/api/v1/pipelines/default/silver/missing/validate"} {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(`{}`))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.NotContains(t, rec.Body.String(), `"READ_ONLY"`, path)
+	}
+}
+
+func TestReadOnlyMode_PutToggle_WorksWhileEnabled(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	settings.settings[api.ReadOnlyModeKey] = []byte(`true`)
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/read-only", bytes.NewBufferString(`{"enabled": false}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `false`, string(settings.settings[api.ReadOnlyModeKey]))
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/settings/maintenance", bytes.NewBufferString(`{"enabled": true}`))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, "writes resume once read-only mode is off")
+}
+
+func TestReadOnlyMode_PutNonAdmin_Returns403(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/read-only", bytes.NewBufferString(`{"enabled": true}`))
+	req = req.WithContext(plugins.ContextWithUser(req.Context(), &domain.UserIdentity{UserID: "bob"}))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.NotContains(t, settings.settings, api.ReadOnlyModeKey)
+}
+
+func TestReadOnlyMode_Guard_CachesFlagUntilToggled(t *testing.T) {
+	srv, _, memory := newRetentionTestServer()
+	memory.settings[api.ReadOnlyModeKey] = []byte(`true`)
+	settings := &flakySettingsStore{memorySettingsStore: memory}
+	srv.Settings = settings
+	router := api.NewRouter(srv)
+
+	for range 3 {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/maintenance", bytes.NewBufferString(`{"enabled": true}`))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	}
+	assert.Equal(t, int32(1), settings.reads.Load(), "flag is read once per TTL, not per request")
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/read-only", bytes.NewBufferString(`{"enabled": false}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/settings/maintenance", bytes.NewBufferString(`{"enabled": true}`))
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, "the toggle clears the cached flag")
+}
+
+func TestReadOnlyMode_Guard_FlagReadFails_AllowsRequestWithoutCaching(t *testing.T) {
+	srv, _, memory := newRetentionTestServer()
+	memory.settings[api.ReadOnlyModeKey] = []byte(`true`)
+	settings := &flakySettingsStore{memorySettingsStore: memory}
+	settings.failing.Store(true)
+	srv.Settings = settings
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/settings/maintenance", bytes.NewBufferString(`{"enabled": true}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, "fails open")
+
+	settings.failing.Store(false)
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/pipelines/default/bronze/orders", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "a failed read isn't cached")
+}
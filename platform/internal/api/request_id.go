@@ -31,22 +31,40 @@ func ContextWithRequestID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, requestIDKey{}, id)
 }
 
+// maxRequestIDLength caps a client-supplied X-Request-ID.
+const maxRequestIDLength = 128
+
+// validRequestID reports whether a client-supplied request ID is safe to
+// echo in headers and logs: 1-128 printable ASCII characters, no spaces.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
 // RequestID is middleware that propagates or generates a request ID for every request.
 //
 // Behavior:
-//  1. If the incoming request has an X-Request-ID header, that value is used.
+//  1. If the incoming request has a valid X-Request-ID header (see
+//     validRequestID), that value is used.
 //  2. Otherwise, a new UUID v4 is generated.
 //  3. The request ID is stored in the request context (retrieve via RequestIDFromContext).
 //  4. The request ID is set on the response as the X-Request-ID header.
 //  5. A request-scoped slog logger with the "request_id" attribute is injected into the context.
 //
-// This middleware should be placed early in the chain — after CORS (which must
-// handle preflight before anything else) and security headers, but before auth
-// and application-level middleware.
+// This middleware should be the first in the chain, so that responses written
+// by any later middleware (CORS preflight, auth, rate limiting) carry the
+// header too.
 func RequestID(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		id := r.Header.Get(requestIDHeader)
-		if id == "" {
+		if !validRequestID(id) {
 			id = uuid.New().String()
 		}
 
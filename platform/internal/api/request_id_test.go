@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -117,3 +118,19 @@ func TestLoggerFromContext_FallsBackToDefault(t *testing.T) {
 	logger := api.LoggerFromContext(context.Background())
 	assert.NotNil(t, logger, "should fall back to slog.Default()")
 }
+
+func TestRequestID_InvalidHeader_Replaced(t *testing.T) {
+	handler := api.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, bad := range []string{"has space", "new\nline", strings.Repeat("a", 129)} {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set("X-Request-ID", bad)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		got := rec.Header().Get("X-Request-ID")
+		assert.NotEqual(t, bad, got)
+		_, err := uuid.Parse(got)
+		assert.NoError(t, err, "a fresh UUID replaces %q", bad)
+	}
+}
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
@@ -18,9 +20,48 @@ type SettingsStore interface {
 	UpdateReaperStatus(ctx context.Context, status *domain.ReaperStatus) error
 }
 
+// ErrSettingNotFound is returned (wrapped) by SettingsStore.GetSetting when
+// the key has never been written.
+var ErrSettingNotFound = errors.New("setting not found")
+
+// NamespaceRetentionKey is the platform_settings key holding a namespace's
+// retention overrides.
+func NamespaceRetentionKey(namespace string) string {
+	return "retention:ns:" + namespace
+}
+
+// EffectiveRetentionConfig layers retention overrides onto the system config,
+// in order — pass the namespace overrides, then the pipeline's, so the
+// pipeline wins over the namespace and both win over the system. Overrides
+// are partial JSON objects: only the fields they set replace the value below.
+// Empty, null, or malformed layers are skipped.
+func EffectiveRetentionConfig(system domain.RetentionConfig, overrides ...json.RawMessage) domain.RetentionConfig {
+	effective := system
+	for _, layer := range overrides {
+		if isEmptyRetentionOverride(layer) {
+			continue
+		}
+		next := effective
+		if err := json.Unmarshal(layer, &next); err != nil {
+			slog.Warn("ignoring malformed retention overrides", "error", err)
+			continue
+		}
+		effective = next
+	}
+	return effective
+}
+
+// isEmptyRetentionOverride reports whether raw carries no overrides.
+func isEmptyRetentionOverride(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
 // ReaperRunner allows the API to trigger a manual reaper run.
 type ReaperRunner interface {
 	RunNow(ctx context.Context) (*domain.ReaperStatus, error)
+	// Preview computes what a reaper run would clean up, without deleting.
+	Preview(ctx context.Context) (*domain.RetentionPreview, error)
 }
 
 // RetentionConfigResponse wraps the retention config for API responses.
@@ -31,10 +72,19 @@ type RetentionConfigResponse struct {
 // PipelineRetentionResponse shows system defaults, overrides, and effective config.
 type PipelineRetentionResponse struct {
 	System    domain.RetentionConfig  `json:"system"`
+	Namespace json.RawMessage         `json:"namespace"` // namespace overrides, null if none
 	Overrides json.RawMessage         `json:"overrides"` // null if no overrides
 	Effective domain.RetentionConfig  `json:"effective"`
 }
 
+// NamespaceRetentionResponse shows system defaults, a namespace's overrides,
+// and the resulting config its pipelines inherit.
+type NamespaceRetentionResponse struct {
+	System    domain.RetentionConfig `json:"system"`
+	Overrides json.RawMessage        `json:"overrides"` // null if no overrides
+	Effective domain.RetentionConfig `json:"effective"`
+}
+
 // ZoneLifecycleResponse holds landing zone lifecycle settings.
 type ZoneLifecycleResponse struct {
 	ProcessedMaxAgeDays *int `json:"processed_max_age_days"`
@@ -54,6 +104,12 @@ func MountRetentionRoutes(r chi.Router, srv *Server) {
 	r.Put("/admin/retention/config", srv.HandlePutRetentionConfig)
 	r.Get("/admin/retention/status", srv.HandleGetReaperStatus)
 	r.Post("/admin/retention/run", srv.HandleTriggerReaper)
+	r.Get("/retention/preview", srv.HandleRetentionPreview)
+	r.Post("/retention/run", srv.HandleTriggerReaper)
+
+	// Per-namespace retention (defaults for the namespace's pipelines)
+	r.Get("/namespaces/{name}/retention", srv.HandleGetNamespaceRetention)
+	r.Put("/namespaces/{name}/retention", srv.HandlePutNamespaceRetention)
 
 	// Per-pipeline retention
 	r.Get("/pipelines/{namespace}/{layer}/{name}/retention", srv.HandleGetPipelineRetention)
@@ -87,7 +143,9 @@ func (s *Server) HandlePutRetentionConfig(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var cfg domain.RetentionConfig
+	// Omitted fields keep their defaults, so a client that predates a field
+	// (e.g. audit_log_max_age_days) can't zero it by accident.
+	cfg := domain.DefaultRetentionConfig()
 	if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
 		errorJSON(w, "invalid JSON body", "INVALID_ARGUMENT", http.StatusBadRequest)
 		return
@@ -102,6 +160,10 @@ func (s *Server) HandlePutRetentionConfig(w http.ResponseWriter, r *http.Request
 		errorJSON(w, "reaper_interval_minutes must be >= 1", "INVALID_ARGUMENT", http.StatusBadRequest)
 		return
 	}
+	if cfg.AuditLogMaxAgeDays < 1 {
+		errorJSON(w, "audit_log_max_age_days must be >= 1", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
 
 	data, err := json.Marshal(cfg)
 	if err != nil {
@@ -133,14 +195,20 @@ func (s *Server) HandleGetReaperStatus(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, status)
 }
 
-// HandleTriggerReaper triggers a manual reaper run.
+// HandleTriggerReaper runs one reaper cycle synchronously and returns what it
+// cleaned up. Admin-only: it deletes data platform-wide. A call made while a
+// cycle is already running waits for it rather than overlapping.
 func (s *Server) HandleTriggerReaper(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
 	if s.Reaper == nil {
 		errorJSON(w, "reaper not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
 		return
 	}
 
-	status, err := s.Reaper.RunNow(r.Context())
+	// A client that disconnects mustn't stop the cycle half-way through its deletes.
+	status, err := s.Reaper.RunNow(context.WithoutCancel(r.Context()))
 	if err != nil {
 		internalError(w, "reaper run failed", err)
 		return
@@ -149,6 +217,27 @@ func (s *Server) HandleTriggerReaper(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusAccepted, status)
 }
 
+// HandleRetentionPreview reports what the next reaper run would delete or
+// fail, per task, without touching any data. Admin-only, like running the
+// reaper: it lists what would be deleted platform-wide.
+func (s *Server) HandleRetentionPreview(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	if s.Reaper == nil {
+		errorJSON(w, "reaper not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	preview, err := s.Reaper.Preview(r.Context())
+	if err != nil {
+		internalError(w, "reaper preview failed", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, preview)
+}
+
 // HandleGetPipelineRetention returns the pipeline's retention config (system + overrides + effective).
 func (s *Server) HandleGetPipelineRetention(w http.ResponseWriter, r *http.Request) {
 	if s.Settings == nil || s.Pipelines == nil {
@@ -176,19 +265,17 @@ func (s *Server) HandleGetPipelineRetention(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	effective := systemCfg
-	if len(pipeline.RetentionConfig) > 0 {
-		// Merge pipeline overrides onto system defaults
-		if err := json.Unmarshal(pipeline.RetentionConfig, &effective); err != nil {
-			slog.Warn("failed to unmarshal pipeline retention overrides, using system defaults",
-				"pipeline", ns+"/"+layer+"/"+name, "error", err)
-		}
+	nsOverrides, err := s.loadNamespaceRetention(r.Context(), ns)
+	if err != nil {
+		internalError(w, "failed to load namespace retention", err)
+		return
 	}
 
 	writeJSON(w, http.StatusOK, PipelineRetentionResponse{
 		System:    systemCfg,
+		Namespace: nsOverrides,
 		Overrides: pipeline.RetentionConfig,
-		Effective: effective,
+		Effective: EffectiveRetentionConfig(systemCfg, nsOverrides, pipeline.RetentionConfig),
 	})
 }
 
@@ -232,6 +319,112 @@ func (s *Server) HandlePutPipelineRetention(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// HandleGetNamespaceRetention returns a namespace's retention overrides and
+// the config its pipelines inherit (before their own overrides).
+func (s *Server) HandleGetNamespaceRetention(w http.ResponseWriter, r *http.Request) {
+	if s.Settings == nil {
+		errorJSON(w, "settings not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	ns := chi.URLParam(r, "name")
+	if !s.namespaceExists(w, r, ns) {
+		return
+	}
+
+	systemCfg, err := s.loadRetentionConfig(r.Context())
+	if err != nil {
+		internalError(w, "failed to load retention config", err)
+		return
+	}
+
+	overrides, err := s.loadNamespaceRetention(r.Context(), ns)
+	if err != nil {
+		internalError(w, "failed to load namespace retention", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NamespaceRetentionResponse{
+		System:    systemCfg,
+		Overrides: overrides,
+		Effective: EffectiveRetentionConfig(systemCfg, overrides),
+	})
+}
+
+// HandlePutNamespaceRetention replaces a namespace's retention overrides.
+// The body is a partial retention config; null clears the overrides.
+func (s *Server) HandlePutNamespaceRetention(w http.ResponseWriter, r *http.Request) {
+	if s.Settings == nil {
+		errorJSON(w, "settings not configured", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	ns := chi.URLParam(r, "name")
+	if !s.namespaceExists(w, r, ns) {
+		return
+	}
+
+	if !s.requireAccess(w, r, "namespace", ns, "write") {
+		return
+	}
+
+	var overrides json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&overrides); err != nil {
+		errorJSON(w, "invalid JSON body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if !isEmptyRetentionOverride(overrides) {
+		var cfg domain.RetentionConfig
+		if err := json.Unmarshal(overrides, &cfg); err != nil {
+			errorJSON(w, "overrides must be a retention config object", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := s.Settings.PutSetting(r.Context(), NamespaceRetentionKey(ns), overrides); err != nil {
+		internalError(w, "failed to save namespace retention", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// namespaceExists writes a 404 and returns false when the namespace is
+// unknown. Without a namespace store every name is accepted.
+func (s *Server) namespaceExists(w http.ResponseWriter, r *http.Request, ns string) bool {
+	if s.Namespaces == nil {
+		return true
+	}
+	namespaces, err := s.Namespaces.ListNamespaces(r.Context())
+	if err != nil {
+		internalError(w, "internal error", err)
+		return false
+	}
+	for _, n := range namespaces {
+		if n.Name == ns {
+			return true
+		}
+	}
+	errorJSON(w, "namespace not found", "NOT_FOUND", http.StatusNotFound)
+	return false
+}
+
+// loadNamespaceRetention returns a namespace's retention overrides, or nil
+// when none are set.
+func (s *Server) loadNamespaceRetention(ctx context.Context, ns string) (json.RawMessage, error) {
+	data, err := s.Settings.GetSetting(ctx, NamespaceRetentionKey(ns))
+	if errors.Is(err, ErrSettingNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if isEmptyRetentionOverride(data) {
+		return nil, nil
+	}
+	return data, nil
+}
+
 // HandleGetZoneLifecycle returns landing zone lifecycle settings.
 func (s *Server) HandleGetZoneLifecycle(w http.ResponseWriter, r *http.Request) {
 	if s.LandingZones == nil {
@@ -301,7 +494,7 @@ func (s *Server) loadRetentionConfig(ctx context.Context) (domain.RetentionConfi
 		return domain.DefaultRetentionConfig(), nil
 	}
 
-	var cfg domain.RetentionConfig
+	cfg := domain.DefaultRetentionConfig()
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		slog.Warn("loadRetentionConfig: failed to unmarshal config, using defaults", "error", err)
 		return domain.DefaultRetentionConfig(), nil
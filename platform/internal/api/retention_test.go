@@ -0,0 +1,261 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memorySettingsStore struct {
+	mu       sync.Mutex
+	settings map[string]json.RawMessage
+}
+
+func newMemorySettingsStore() *memorySettingsStore {
+	return &memorySettingsStore{settings: map[string]json.RawMessage{}}
+}
+
+func (m *memorySettingsStore) GetSetting(_ context.Context, key string) (json.RawMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.settings[key]
+	if !ok {
+		return nil, fmt.Errorf("setting %q: %w", key, api.ErrSettingNotFound)
+	}
+	return v, nil
+}
+
+func (m *memorySettingsStore) PutSetting(_ context.Context, key string, value json.RawMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings[key] = value
+	return nil
+}
+
+func (m *memorySettingsStore) GetReaperStatus(_ context.Context) (*domain.ReaperStatus, error) {
+	return &domain.ReaperStatus{}, nil
+}
+
+func (m *memorySettingsStore) UpdateReaperStatus(_ context.Context, _ *domain.ReaperStatus) error {
+	return nil
+}
+
+func TestEffectiveRetentionConfig_PipelineOverNamespaceOverSystem(t *testing.T) {
+	system := domain.DefaultRetentionConfig()
+	namespace := json.RawMessage(`{"runs_max_per_pipeline": 20, "runs_max_age_days": 30}`)
+	pipeline := json.RawMessage(`{"runs_max_age_days": 7}`)
+
+	got := api.EffectiveRetentionConfig(system, namespace, pipeline)
+
+	want := system
+	want.RunsMaxPerPipeline = 20 // namespace
+	want.RunsMaxAgeDays = 7      // pipeline beats namespace
+	assert.Equal(t, want, got)
+}
+
+func TestEffectiveRetentionConfig_SkipsEmptyAndMalformedLayers(t *testing.T) {
+	system := domain.DefaultRetentionConfig()
+
+	assert.Equal(t, system, api.EffectiveRetentionConfig(system))
+	assert.Equal(t, system, api.EffectiveRetentionConfig(system, nil, json.RawMessage("null")))
+
+	got := api.EffectiveRetentionConfig(system, json.RawMessage(`[1, 2]`), json.RawMessage(`{"runs_max_per_pipeline": 5}`))
+	want := system
+	want.RunsMaxPerPipeline = 5
+	assert.Equal(t, want, got)
+}
+
+func newRetentionTestServer() (*api.Server, *memoryPipelineStore, *memorySettingsStore) {
+	srv, pipelineStore := newTestServer()
+	settings := newMemorySettingsStore()
+	srv.Settings = settings
+	return srv, pipelineStore, settings
+}
+
+func TestNamespaceRetention_PutThenGet(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/namespaces/default/retention",
+		bytes.NewBufferString(`{"runs_max_per_pipeline": 25}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"runs_max_per_pipeline": 25}`, string(settings.settings["retention:ns:default"]))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/retention", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp api.NamespaceRetentionResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, domain.DefaultRetentionConfig(), resp.System)
+	assert.Equal(t, 25, resp.Effective.RunsMaxPerPipeline)
+	assert.Equal(t, resp.System.RunsMaxAgeDays, resp.Effective.RunsMaxAgeDays)
+}
+
+func TestRetentionConfig_PutWithoutAuditHorizon_KeepsDefault(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/retention/config",
+		bytes.NewBufferString(`{"runs_max_per_pipeline": 50, "runs_max_age_days": 7, "reaper_interval_minutes": 15}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp api.RetentionConfigResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, 7, resp.Config.RunsMaxAgeDays)
+	assert.Equal(t, domain.DefaultRetentionConfig().AuditLogMaxAgeDays, resp.Config.AuditLogMaxAgeDays)
+}
+
+func TestRetentionConfig_PutZeroAuditHorizon_Returns400(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/retention/config",
+		bytes.NewBufferString(`{"runs_max_per_pipeline": 50, "reaper_interval_minutes": 15, "audit_log_max_age_days": 0}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "audit_log_max_age_days")
+	assert.Empty(t, settings.settings)
+}
+
+func TestNamespaceRetention_InvalidBody_Returns400(t *testing.T) {
+	srv, _, settings := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/namespaces/default/retention",
+		bytes.NewBufferString(`{"runs_max_per_pipeline": "lots"}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, settings.settings)
+}
+
+func TestNamespaceRetention_UnknownNamespace_Returns404(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/ghost/retention", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestPipelineRetention_EffectiveIncludesNamespaceLayer(t *testing.T) {
+	srv, pipelineStore, settings := newRetentionTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{{
+		ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders",
+		RetentionConfig: json.RawMessage(`{"runs_max_age_days": 7}`),
+	}}
+	settings.settings["retention:ns:default"] = json.RawMessage(`{"runs_max_per_pipeline": 20, "runs_max_age_days": 30}`)
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/retention", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp api.PipelineRetentionResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.JSONEq(t, `{"runs_max_per_pipeline": 20, "runs_max_age_days": 30}`, string(resp.Namespace))
+	assert.Equal(t, 20, resp.Effective.RunsMaxPerPipeline)
+	assert.Equal(t, 7, resp.Effective.RunsMaxAgeDays)
+}
+
+type fakeReaper struct {
+	calls  int
+	status *domain.ReaperStatus
+}
+
+func (f *fakeReaper) RunNow(_ context.Context) (*domain.ReaperStatus, error) {
+	f.calls++
+	return f.status, nil
+}
+
+func (f *fakeReaper) Preview(_ context.Context) (*domain.RetentionPreview, error) {
+	return &domain.RetentionPreview{}, nil
+}
+
+func postRetentionRun(srv *api.Server, user *domain.UserIdentity) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/retention/run", http.NoBody)
+	if user != nil {
+		req = req.WithContext(plugins.ContextWithUser(req.Context(), user))
+	}
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRetentionRun_ReturnsCycleStats(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+	reaper := &fakeReaper{status: &domain.ReaperStatus{RunsPruned: 12, AuditPruned: 3}}
+	srv.Reaper = reaper
+
+	rec := postRetentionRun(srv, nil)
+
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var got domain.ReaperStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	assert.Equal(t, 12, got.RunsPruned)
+	assert.Equal(t, 3, got.AuditPruned)
+	assert.Equal(t, 1, reaper.calls)
+}
+
+func TestRetentionRun_NonAdmin_Returns403(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+	reaper := &fakeReaper{status: &domain.ReaperStatus{}}
+	srv.Reaper = reaper
+
+	rec := postRetentionRun(srv, &domain.UserIdentity{UserID: "bob", Roles: []string{"editor"}})
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Zero(t, reaper.calls)
+
+	rec = postRetentionRun(srv, &domain.UserIdentity{UserID: "alice", Roles: []string{"admin"}})
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 1, reaper.calls)
+}
+
+func TestRetentionPreview_NonAdmin_Returns403(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+	srv.Reaper = &fakeReaper{}
+
+	preview := func(user *domain.UserIdentity) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/retention/preview", http.NoBody)
+		req = req.WithContext(plugins.ContextWithUser(req.Context(), user))
+		rec := httptest.NewRecorder()
+		api.NewRouter(srv).ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, preview(&domain.UserIdentity{UserID: "bob", Roles: []string{"editor"}}))
+	assert.Equal(t, http.StatusOK, preview(&domain.UserIdentity{UserID: "alice", Roles: []string{"admin"}}))
+}
+
+func TestRetentionRun_NoReaper_Returns503(t *testing.T) {
+	srv, _, _ := newRetentionTestServer()
+
+	rec := postRetentionRun(srv, nil)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
@@ -13,19 +13,22 @@
 package api
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/netip"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
+	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/cache"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/rat-data/rat/platform/internal/plugins"
@@ -160,7 +163,7 @@ func errorTypeFromStatus(status int) string {
 		return ErrorTypeConflict
 	case status == http.StatusTooManyRequests:
 		return ErrorTypeRateLimit
-	case status == http.StatusServiceUnavailable:
+	case status == http.StatusServiceUnavailable, status == http.StatusGatewayTimeout:
 		return ErrorTypeUnavailable
 	case status >= 500:
 		return ErrorTypeInternal
@@ -210,6 +213,44 @@ func limitJSONBody(next http.Handler) http.Handler {
 	})
 }
 
+// gzipBody decompresses a request body and closes both the gzip reader and
+// the underlying body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g gzipBody) Close() error {
+	gzErr := g.Reader.Close()
+	if err := g.body.Close(); err != nil {
+		return err
+	}
+	return gzErr
+}
+
+// decompressGzipBody transparently decompresses "Content-Encoding: gzip"
+// request bodies. It must run before limitJSONBody so the size cap applies to
+// the decompressed stream — a tiny gzip bomb can't expand past maxJSONBodySize.
+func decompressGzipBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		enc := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+		if r.Body == nil || r.Body == http.NoBody || (enc != "gzip" && enc != "x-gzip") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			errorJSON(w, "invalid gzip request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		r.Body = gzipBody{Reader: zr, body: r.Body}
+		// Handlers see a plain body of unknown length.
+		r.Header.Del("Content-Encoding")
+		r.ContentLength = -1
+		next.ServeHTTP(w, r)
+	})
+}
+
 // nameParams is the set of URL path parameter names that must pass validName().
 var nameParams = map[string]bool{
 	"namespace": true,
@@ -264,6 +305,11 @@ type RunnerPluginLister interface {
 	ListRunnerPlugins(ctx context.Context) ([]domain.RunnerPlugin, error)
 }
 
+// RunnerCapacityReporter asks the runner(s) for their concurrency limit and load.
+type RunnerCapacityReporter interface {
+	RunnerCapacity(ctx context.Context) (*domain.RunnerCapacity, error)
+}
+
 // CloudProvider vends scoped cloud credentials for pipeline runs.
 // Implemented by the plugins.Registry when a plugin with capability "cloud"
 // is loaded. Mirrors the GetCredentials RPC declared in proto/cloud/v1/cloud.proto
@@ -325,9 +371,12 @@ type Server struct {
 	LandingZones  LandingZoneStore
 	Triggers      PipelineTriggerStore
 	Audit         AuditStore
+	authFailures  *authFailureAuditor // Populated by NewRouter when Audit is set.
 	FailedMerges  FailedMergesStore // optional: audit log for Phase 5 merge failures from the runner.
 	Settings      SettingsStore
+	Notifications NotificationStore // Optional: per-namespace outbound run-completion webhooks.
 	EventBus      EventPublisher // Optional: publishes events for plugin dispatch.
+	EventStream   plugins.DispatchEventBus // Optional: tailed by GET /events/stream. Nil = 503.
 	Auth           func(http.Handler) http.Handler
 	Authorizer     Authorizer
 	Executor       Executor
@@ -335,23 +384,36 @@ type Server struct {
 	Plugins        PluginRegistry
 	Cloud          CloudProvider
 	RunnerPlugins  RunnerPluginLister
+	RunnerCapacity RunnerCapacityReporter // Optional: GET /runner/capacity. Nil = 503.
 	LicenseInfo    *domain.LicenseInfo
+	Seats          atomic.Pointer[plugins.SeatTracker] // Optional: license seat limit, (un)wired with the enforcement plugin at runtime. Nil = unlimited.
 	PluginManager  PluginManager   // lifecycle operations (register, enable, disable, remove)
 	PluginCatalog  PluginLister    // read-only catalog queries
 	PluginRegistry PluginRegistryLive // live registry for proxy route lookups
 	PluginSources  PluginSourceStore  // plugin source repository management
 	PluginPolicies PluginPolicyStore  // plugin allow/deny policy management
 	CORSOrigins   []string          // Allowed CORS origins. Defaults to ["http://localhost:3000"].
+	RouteCORSOrigins map[string][]string // Per-route origin overrides keyed by route pattern (see routeCORS). Nil = CORSOrigins everywhere.
 	TrustedProxies []netip.Prefix   // Proxies whose X-Forwarded-For/X-Real-IP are trusted. Empty = trust none (use direct peer).
-	RateLimit        *RateLimitConfig   // Per-IP rate limiting config. Nil disables rate limiting.
+	RateLimit        *RateLimitConfig   // API rate limiting config (per IP or per principal). Nil disables rate limiting.
 	RateLimiterStop  func()            // Populated by NewRouter when rate limiting is enabled.
+	RouteRateLimits      map[string]RateLimitConfig // Per-route overrides keyed by chi route pattern (e.g. "/api/v1/query"), applied on top of RateLimit. Nil = none.
+	RouteRateLimiterStop func()                     // Populated by NewRouter when RouteRateLimits is set.
+	BodyLog          *BodyLogConfig           // Sampled, redacted request/response body logging. Nil disables it.
+	RequestTimeout   time.Duration            // Context deadline per /api/v1 request. Zero = DefaultRequestTimeout; negative disables.
+	RouteTimeouts    map[string]time.Duration // Per-route deadline overrides keyed by chi route pattern; 0 = no deadline. Nil = DefaultRouteTimeouts().
 	WebhookRateLimit *WebhookRateLimitConfig // Per-IP webhook rate limiting. Nil = uses default config.
 	WebhookRateLimiterStop func()            // Populated by NewRouter for webhook rate limiter cleanup.
+	WebhookSecretKey []byte                  // AES-256 key for webhook signing secrets (see WebhookSecretKey). Nil disables HMAC-signed webhooks.
+	WebhookIdempotency *cache.Cache[string, uuid.UUID] // Webhook Idempotency-Key → run ID (key: "<trigger_id>:<key>"). Nil = NewRouter creates one with DefaultWebhookIdempotencyTTL.
 	SSELimiter       *SSELimiter       // Concurrent SSE connection limiter. Nil = uses a default limiter.
+	BackfillPollInterval time.Duration // How often queued backfill runs are checked for a free slot. Zero = 5s.
 	DBHealth         HealthChecker     // Postgres health check (pool.Ping). Nil = skip.
 	S3Health         HealthChecker     // S3/MinIO health check (BucketExists). Nil = skip.
 	RunnerHealth     HealthChecker     // Runner gRPC health check. Nil = skip.
 	QueryHealth      HealthChecker     // ratq gRPC health check. Nil = skip.
+	ExecutorHealth   HealthChecker     // Executor accepting work (loaded, started, not draining). Nil = skip.
+	EventBusHealth   HealthChecker     // Event bus LISTEN connection alive. Nil = skip.
 
 	// Metrics callables — exported as Prometheus gauges by HandleMetrics.
 	// Each is optional; the corresponding metric is omitted when nil so dev
@@ -362,13 +424,19 @@ type Server struct {
 	// keeps the test helpers dependency-light).
 	DBPoolStats        func() (total, acquired int32)   // main pgxpool.Pool.Stat()
 	HeartbeatPoolStats func() (total, acquired int32)   // dedicated heartbeat pool (nil when unused)
+	PGPoolStats        func() PoolStats                 // main pool detail for rat_pg_pool_* (postgres.HealthChecker.PoolStats)
 	PluginHealthStats  func() (total, healthy int)      // plugins.Registry.All() count + filter
 	SchedulerMetrics   func() (lastTickSeconds float64, dispatched int) // scheduler.LastTickStats()
+	Counts             PlatformCounter                  // run/schedule/trigger counts for rat_runs etc. (postgres.MetricsStore)
 
 	// Caches reduce Postgres load for slow-changing data.
 	// Nil caches are safe — handlers check before using.
 	NamespaceCache *cache.Cache[string, []domain.Namespace]   // key: "all" (namespace list rarely changes)
 	PipelineCache  *cache.Cache[string, *domain.Pipeline]     // key: "ns/layer/name"
+	LatestRunCache *cache.Cache[string, map[uuid.UUID]*domain.Run] // key: sorted pipeline IDs; cleared on run_completed
+	PreviewCache   *cache.Cache[string, *PreviewCacheEntry]        // key: "ns/layer/name"; cleared on draft edit
+	CountsCache    *cache.Cache[string, *PlatformCounts]           // key: "all"; NewRouter creates one when Counts is set
+	ReadOnlyCache  *cache.Cache[string, bool]                      // key: ReadOnlyModeKey; NewRouter creates one when Settings is set
 }
 
 // NewRouter creates the PUBLIC chi router with end-user APIs mounted.
@@ -385,6 +453,15 @@ func NewRouter(srv *Server) chi.Router {
 	if srv.SSELimiter == nil {
 		srv.SSELimiter = NewSSELimiter()
 	}
+	if srv.Audit != nil {
+		srv.authFailures = newAuthFailureAuditor(srv.Audit)
+	}
+	if srv.Counts != nil && srv.CountsCache == nil {
+		srv.CountsCache = newPlatformCountsCache()
+	}
+	if srv.Settings != nil && srv.ReadOnlyCache == nil {
+		srv.ReadOnlyCache = newReadOnlyCache()
+	}
 
 	r := chi.NewRouter()
 
@@ -393,47 +470,19 @@ func NewRouter(srv *Server) chi.Router {
 	if len(corsOrigins) == 0 {
 		corsOrigins = []string{"http://localhost:3000"}
 	}
-
-	// P10-20: When AllowCredentials is true, Access-Control-Allow-Origin MUST NOT
-	// be "*". If the caller configured "*", use AllowOriginFunc to dynamically
-	// reflect the request Origin header (only when it matches a known allowed
-	// origin pattern). This satisfies the CORS spec while keeping credentials.
-	hasWildcard := false
-	for _, o := range corsOrigins {
-		if o == "*" {
-			hasWildcard = true
-			break
-		}
-	}
-
-	corsOpts := cors.Options{
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Webhook-Token", "X-Request-ID"},
-		ExposedHeaders:   []string{"Link", "X-Request-ID", "RateLimit-Limit", "RateLimit-Remaining", "Retry-After"},
-		AllowCredentials: true,
-		MaxAge:           300,
-	}
-
-	if hasWildcard {
-		// Dynamic origin: reflect the request Origin when credentials are enabled.
-		// This avoids the browser-rejected "Access-Control-Allow-Origin: *" +
-		// "Access-Control-Allow-Credentials: true" combination.
-		slog.Warn("CORS: wildcard origin '*' with AllowCredentials — using dynamic origin reflection")
-		corsOpts.AllowOriginFunc = func(_ *http.Request, _ string) bool {
-			return true
-		}
-	} else {
-		corsOpts.AllowedOrigins = corsOrigins
-	}
-
-	r.Use(cors.Handler(corsOpts))
-	r.Use(securityHeaders)
+	// RequestID goes first so every response carries X-Request-ID, including
+	// CORS preflights that routeCORS answers without calling further in.
 	r.Use(RequestID)
+	r.Use(routeCORS(corsOrigins, srv.RouteCORSOrigins))
+	r.Use(securityHeaders)
 	// Resolve the real client IP from trusted-proxy forwarded headers (replaces
 	// chi's spoofable middleware.RealIP — see realip.go). With no trusted proxies
 	// configured (the default), the direct peer address is used verbatim.
 	r.Use(realIPMiddleware(srv.TrustedProxies))
 	r.Use(RequestLogger)
+	if srv.BodyLog != nil {
+		r.Use(BodyLogger(*srv.BodyLog))
+	}
 	r.Use(middleware.Recoverer)
 
 	// Health & metrics (unauthenticated, outside /api/v1)
@@ -441,6 +490,7 @@ func NewRouter(srv *Server) chi.Router {
 	r.Get("/health/live", srv.HandleHealthLive)
 	r.Get("/health/ready", srv.HandleHealthReady)
 	r.Get("/metrics", srv.HandleMetrics)
+	MountOpenAPIRoute(r)
 
 	// Webhooks (token-authenticated, no JWT required).
 	// Rate-limited separately from the main API because webhooks are externally
@@ -450,10 +500,21 @@ func NewRouter(srv *Server) chi.Router {
 		if srv.WebhookRateLimit != nil {
 			webhookCfg = *srv.WebhookRateLimit
 		}
-		wrl, wmw := RateLimit(RateLimitConfig(webhookCfg))
+		wrl, wmw := RateLimit(RateLimitConfig{
+			RequestsPerSecond: webhookCfg.RequestsPerSecond,
+			Burst:             webhookCfg.Burst,
+			CleanupInterval:   webhookCfg.CleanupInterval,
+		})
 		srv.WebhookRateLimiterStop = wrl.Stop
+		if srv.WebhookIdempotency == nil {
+			srv.WebhookIdempotency = cache.New[string, uuid.UUID](cache.Options{
+				TTL:        DefaultWebhookIdempotencyTTL,
+				MaxEntries: 10000,
+			})
+		}
 		r.Group(func(r chi.Router) {
 			r.Use(wmw)
+			r.Use(srv.readOnlyGuard)
 			MountWebhookRoutes(r, srv)
 		})
 	}
@@ -465,26 +526,77 @@ func NewRouter(srv *Server) chi.Router {
 
 	// API v1
 	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(decompressGzipBody)
 		r.Use(limitJSONBody)
+		// Per-IP limiting runs before auth so failed auth attempts are also
+		// throttled; per-principal limiting needs the authenticated identity,
+		// so it runs after, and a failed-auth limiter keeps the per-IP
+		// throttle on rejected credentials in front of auth.
+		var rateLimitMW func(http.Handler) http.Handler
 		if srv.RateLimit != nil {
 			rl, mw := RateLimit(*srv.RateLimit)
 			srv.RateLimiterStop = rl.Stop
-			r.Use(mw)
+			rateLimitMW = mw
+		}
+		principalKeyed := srv.RateLimit != nil && srv.RateLimit.KeyStrategy == RateLimitKeyPrincipal
+		if rateLimitMW != nil && !principalKeyed {
+			r.Use(rateLimitMW)
+		}
+		if rateLimitMW != nil && principalKeyed {
+			frl, fmw := FailedAuthRateLimit(*srv.RateLimit)
+			stopPrincipal := srv.RateLimiterStop
+			srv.RateLimiterStop = func() {
+				stopPrincipal()
+				frl.Stop()
+			}
+			r.Use(fmw)
 		}
 		if srv.Auth != nil {
-			r.Use(srv.Auth)
+			if srv.authFailures != nil {
+				r.Use(srv.authFailures.wrap(srv.Auth))
+			} else {
+				r.Use(srv.Auth)
+			}
+		}
+		r.Use(srv.seatEnforcement)
+		r.Use(srv.readOnlyGuard)
+		if rateLimitMW != nil && principalKeyed {
+			r.Use(rateLimitMW)
 		}
 		if srv.Audit != nil {
 			r.Use(AuditMiddleware(srv.Audit))
 		}
 		r.Get("/features", srv.HandleFeatures)
 		r.Get("/me", srv.HandleMe)
+		r.Get("/whoami", srv.HandleWhoami)
 
 		// ValidatePathParams needs URL params, which are only available after
 		// chi matches the specific route. r.With() creates an inline router where
 		// middleware wraps each handler (runs post-match), unlike r.Use() which
 		// wraps routeHTTP (runs pre-match).
-		vr := r.With(ValidatePathParams)
+		//
+		// Per-route rate limits select their bucket from the matched route
+		// pattern, and namespace-scoped API keys are checked against the
+		// matched namespace, so they are post-match too.
+		vr := r.With(ValidatePathParams, srv.enforceKeyNamespaces)
+		if len(srv.RouteRateLimits) > 0 {
+			defaultStrategy := RateLimitKeyIP
+			if srv.RateLimit != nil && srv.RateLimit.KeyStrategy != "" {
+				defaultStrategy = srv.RateLimit.KeyStrategy
+			}
+			stop, mw := RouteRateLimit(srv.RouteRateLimits, defaultStrategy)
+			srv.RouteRateLimiterStop = stop
+			vr = vr.With(mw)
+		}
+		requestTimeout := srv.RequestTimeout
+		if requestTimeout == 0 {
+			requestTimeout = DefaultRequestTimeout
+		}
+		routeTimeouts := srv.RouteTimeouts
+		if routeTimeouts == nil {
+			routeTimeouts = DefaultRouteTimeouts()
+		}
+		vr = vr.With(RequestTimeout(requestTimeout, routeTimeouts))
 		MountPipelineRoutes(vr, srv)
 		MountRunRoutes(vr, srv)
 		MountNamespaceRoutes(vr, srv)
@@ -495,18 +607,28 @@ func NewRouter(srv *Server) chi.Router {
 		MountQueryRoutes(vr, srv)
 		// Lineage moved out of core into rat-plugin-lineage. Mounted at
 		// /api/v1/x/lineage/graph by the plugin proxy; the plugin's UI
-		// bundle adds /x/lineage to the sidebar nav.
+		// bundle adds /x/lineage to the sidebar nav. Core keeps only the
+		// trigger-derived lineage (MountLineageRoutes, below).
 		MountSharingRoutes(vr, srv)
 		MountLandingZoneRoutes(vr, srv)
 		if srv.Triggers != nil {
 			MountTriggerRoutes(vr, srv)
+			MountLineageRoutes(vr, srv)
 		}
 		MountAuditRoutes(vr, srv)
 		MountPreviewRoutes(vr, srv)
 		MountPublishRoutes(vr, srv)
+		MountBackfillRoutes(vr, srv)
 		MountRunnerPluginRoutes(vr, srv)
+		MountRunnerCapacityRoutes(vr, srv)
+		MountEventStreamRoutes(vr, srv)
 		if srv.Settings != nil {
 			MountRetentionRoutes(vr, srv)
+			MountMaintenanceRoutes(vr, srv)
+			MountReadOnlyRoutes(vr, srv)
+		}
+		if srv.Notifications != nil {
+			MountNotificationRoutes(vr, srv)
 		}
 		if srv.Versions != nil {
 			MountVersionRoutes(vr, srv)
@@ -572,8 +694,8 @@ func NewInternalRouter(srv *Server) chi.Router {
 
 	// Minimal middleware — no CORS, no auth, no rate limiting. The internal
 	// listener is for trusted in-cluster callers only.
-	r.Use(securityHeaders)
 	r.Use(RequestID)
+	r.Use(securityHeaders)
 	// Resolve the real client IP from trusted-proxy forwarded headers (replaces
 	// chi's spoofable middleware.RealIP — see realip.go). With no trusted proxies
 	// configured (the default), the direct peer address is used verbatim.
@@ -1,12 +1,16 @@
 package api_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // --- validName tests via middleware ---
@@ -286,3 +290,66 @@ func TestWebhookRateLimit_DoesNotAffectAPIRoutes(t *testing.T) {
 	router.ServeHTTP(rec, req)
 	assert.NotEqual(t, http.StatusTooManyRequests, rec.Code)
 }
+
+// --- gzip request bodies ---
+
+func gzipped(t *testing.T, s string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return &buf
+}
+
+func TestGzipBody_CreatePipeline_Returns201(t *testing.T) {
+	srv, store := newTestServer()
+	router := api.NewRouter(srv)
+
+	body := gzipped(t, `{"namespace":"default","layer":"bronze","name":"orders","type":"sql","description":"compressed"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	require.Len(t, store.pipelines, 1)
+	assert.Equal(t, "orders", store.pipelines[0].Name)
+	assert.Equal(t, "compressed", store.pipelines[0].Description)
+}
+
+func TestGzipBody_NotGzip_Returns400(t *testing.T) {
+	srv, store := newTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines",
+		strings.NewReader(`{"namespace":"default","layer":"bronze","name":"orders"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, store.pipelines)
+}
+
+// A few KB of gzip that inflates past maxJSONBodySize (1MB) must be cut off
+// by the decompressed-size cap, not read into memory.
+func TestGzipBody_DecompressedOverLimit_Rejected(t *testing.T) {
+	srv, store := newTestServer()
+	router := api.NewRouter(srv)
+
+	bomb := gzipped(t, `{"namespace":"default","layer":"bronze","name":"orders","description":"`+
+		strings.Repeat("a", 4<<20)+`"}`)
+	require.Less(t, bomb.Len(), 64<<10)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines", bomb)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, store.pipelines)
+}
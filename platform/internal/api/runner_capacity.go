@@ -0,0 +1,33 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// MountRunnerCapacityRoutes registers the runner capacity endpoint.
+func MountRunnerCapacityRoutes(r chi.Router, srv *Server) {
+	r.Get("/runner/capacity", srv.HandleRunnerCapacity)
+}
+
+// HandleRunnerCapacity reports the runner's concurrent run limit and how many
+// runs it is executing, so the UI can show "runner 8/10 busy" before submits
+// fail with RESOURCE_EXHAUSTED. With several runners the counts are summed.
+// GET /api/v1/runner/capacity
+func (s *Server) HandleRunnerCapacity(w http.ResponseWriter, r *http.Request) {
+	if s.RunnerCapacity == nil {
+		errorJSON(w, "runner capacity not available", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	capacity, err := s.RunnerCapacity.RunnerCapacity(r.Context())
+	if err != nil {
+		slog.Warn("runner capacity query failed", "error", err)
+		errorJSON(w, "runner unreachable", "UNAVAILABLE", http.StatusServiceUnavailable)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, capacity)
+}
@@ -0,0 +1,63 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRunnerCapacityReporter implements api.RunnerCapacityReporter for testing.
+type mockRunnerCapacityReporter struct {
+	capacity *domain.RunnerCapacity
+	err      error
+}
+
+func (m *mockRunnerCapacityReporter) RunnerCapacity(_ context.Context) (*domain.RunnerCapacity, error) {
+	return m.capacity, m.err
+}
+
+func getRunnerCapacity(srv *api.Server) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runner/capacity", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleRunnerCapacity_ReturnsCapacity(t *testing.T) {
+	reporter := &mockRunnerCapacityReporter{capacity: &domain.RunnerCapacity{
+		MaxConcurrentRuns: 10,
+		ActiveRuns:        8,
+		Runners:           []domain.RunnerInstanceCapacity{{Addr: "http://runner:50052", MaxConcurrentRuns: 10, ActiveRuns: 8}},
+	}}
+
+	rec := getRunnerCapacity(&api.Server{RunnerCapacity: reporter})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var capacity domain.RunnerCapacity
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&capacity))
+	assert.Equal(t, 10, capacity.MaxConcurrentRuns)
+	assert.Equal(t, 8, capacity.ActiveRuns)
+	require.Len(t, capacity.Runners, 1)
+	assert.Equal(t, "http://runner:50052", capacity.Runners[0].Addr)
+}
+
+func TestHandleRunnerCapacity_NotConfigured_Returns503(t *testing.T) {
+	rec := getRunnerCapacity(&api.Server{})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestHandleRunnerCapacity_RunnerError_Returns503(t *testing.T) {
+	reporter := &mockRunnerCapacityReporter{err: errors.New("connection refused")}
+
+	rec := getRunnerCapacity(&api.Server{RunnerCapacity: reporter})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "connection refused")
+}
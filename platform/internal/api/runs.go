@@ -1,15 +1,25 @@
 package api
 
 import (
+	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/auth"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/rat-data/rat/platform/internal/plugins"
 )
@@ -31,8 +41,15 @@ type RunStore interface {
 	UpdateRunStatus(ctx context.Context, runID string, status domain.RunStatus, errMsg *string, durationMs *int64, rowsWritten *int64) error
 	GetRunLogs(ctx context.Context, runID string) ([]LogEntry, error)
 	SaveRunLogs(ctx context.Context, runID string, logs []LogEntry) error
+	// SaveRunPhases stores the per-phase timing breakdown of a finished run,
+	// replacing any previous one.
+	SaveRunPhases(ctx context.Context, runID string, phases []PhaseProfile) error
 	DeleteRunsBeyondLimit(ctx context.Context, pipelineID uuid.UUID, keepCount int) (int, error)
-	DeleteRunsOlderThan(ctx context.Context, olderThan time.Time) (int, error)
+	DeleteRunsOlderThan(ctx context.Context, pipelineID uuid.UUID, olderThan time.Time) (int, error)
+	// CountRunsBeyondLimit and CountRunsOlderThan return how many runs the
+	// matching Delete* call would remove, without deleting (reaper dry-run).
+	CountRunsBeyondLimit(ctx context.Context, pipelineID uuid.UUID, keepCount int) (int, error)
+	CountRunsOlderThan(ctx context.Context, pipelineID uuid.UUID, olderThan time.Time) (int, error)
 	ListStuckRuns(ctx context.Context, olderThan time.Time) ([]domain.Run, error)
 	ListStuckPendingRuns(ctx context.Context, olderThan time.Time) ([]domain.Run, error)
 
@@ -40,6 +57,44 @@ type RunStore interface {
 	// in a single batch query, avoiding N+1 queries when building the lineage graph.
 	// The returned map is keyed by pipeline ID.
 	LatestRunPerPipeline(ctx context.Context, pipelineIDs []uuid.UUID) (map[uuid.UUID]*domain.Run, error)
+
+	// RunStatuses returns the status of each of the given runs in a single
+	// query, keyed by run ID. Runs that no longer exist are left out.
+	RunStatuses(ctx context.Context, runIDs []uuid.UUID) (map[uuid.UUID]domain.RunStatus, error)
+
+	// NamespaceRunStats counts a namespace's runs created at or after since,
+	// grouped by status, in a single aggregate query.
+	NamespaceRunStats(ctx context.Context, namespace string, since time.Time) (*NamespaceRunStats, error)
+
+	// PipelineStats rolls up a pipeline's runs created at or after since:
+	// counts plus duration percentiles and average rows written of the
+	// successful ones, computed in a single aggregate query.
+	PipelineStats(ctx context.Context, pipelineID uuid.UUID, since time.Time) (*PipelineRunStats, error)
+
+	// RecordSubmitFailure counts a retryable submit failure against a pending
+	// run. When the count reaches maxAttempts the run is dead-lettered: marked
+	// failed with reason in its error and excluded from further retries.
+	// Reports whether this call dead-lettered the run; runs no longer pending
+	// are left alone.
+	RecordSubmitFailure(ctx context.Context, runID string, reason string, maxAttempts int) (deadLettered bool, err error)
+}
+
+// NamespaceRunStats is the per-status run count of a namespace over a window.
+type NamespaceRunStats struct {
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"by_status"`
+}
+
+// PipelineRunStats is a pipeline's run rollup over a window. Duration and
+// rows figures cover successful runs only and are nil when there are none.
+type PipelineRunStats struct {
+	Runs           int      `json:"runs"`
+	Succeeded      int      `json:"succeeded"`
+	Failed         int      `json:"failed"`
+	DurationP50Ms  *float64 `json:"duration_p50_ms"`
+	DurationP95Ms  *float64 `json:"duration_p95_ms"`
+	DurationP99Ms  *float64 `json:"duration_p99_ms"`
+	AvgRowsWritten *float64 `json:"avg_rows_written"`
 }
 
 // Allowed sort fields for run list endpoints.
@@ -60,38 +115,186 @@ type RunFilter struct {
 	Pipeline   string
 	Status     string
 	PipelineID string // filter by pipeline UUID (used by scheduler to check active runs)
+	ParentRunID string // filter by parent run UUID (children fired by a pipeline_success trigger)
 	StartedAfter  *time.Time // filter runs started after this time (P10-101)
 	StartedBefore *time.Time // filter runs started before this time (P10-101)
+	CreatedAfter  *time.Time // filter runs created at or after this time
+	CreatedBefore *time.Time // filter runs created before this time
+	FinishedAfter *time.Time // filter runs finished at or after this time
+	Trigger       string     // prefix match on the trigger label, e.g. "schedule:" or "trigger:webhook"
+	DeadLettered  bool       // only runs failed by the submit-attempt cap (see MaxSubmitAttempts)
 	Limit      int
 	Offset     int
 	Sort       *SortOrder // optional sort directive (P10-100)
+
+	// After switches ListRuns to keyset pagination: only runs strictly older
+	// than the cursor (by created_at, then id) are returned, newest first.
+	// Offset is ignored when set. Stable while new runs are being inserted.
+	After *RunCursor
+}
+
+// RunCursor is the keyset position of a run in the created_at DESC, id DESC
+// ordering used by cursor pagination. Clients see it only as the opaque
+// string produced by Encode.
+type RunCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode returns the opaque, URL-safe form of the cursor.
+func (c RunCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeRunCursor parses a cursor produced by RunCursor.Encode.
+func DecodeRunCursor(s string) (*RunCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", err)
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errors.New("decode cursor: malformed")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", err)
+	}
+	runID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", err)
+	}
+	return &RunCursor{CreatedAt: createdAt, ID: runID}, nil
 }
 
 // CreateRunRequest is the JSON body for POST /api/v1/runs.
 type CreateRunRequest struct {
-	Namespace string `json:"namespace"`
-	Layer     string `json:"layer"`
-	Pipeline  string `json:"pipeline"`
-	Trigger   string `json:"trigger"`
+	Namespace string            `json:"namespace"`
+	Layer     string            `json:"layer"`
+	Pipeline  string            `json:"pipeline"`
+	Trigger   string            `json:"trigger"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+
+	// Parameters override pipeline parameters (dates, limits, feature flags)
+	// for this run only. Stored in the run's metadata under
+	// RunParameterMetadataPrefix and forwarded to the runner on submit.
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+const (
+	maxRunMetadataEntries     = 32
+	maxRunMetadataValueLength = 1024
+)
+
+// validRunMetadataKeyRe is looser than label keys: metadata keys often mirror
+// payload field names ("commitSha", "repository.name"), so mixed case is allowed.
+var validRunMetadataKeyRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]{0,62}$`)
+
+// validateRunMetadata returns a client-facing error message, or "" when the
+// metadata is valid.
+func validateRunMetadata(metadata map[string]string) string {
+	if len(metadata) > maxRunMetadataEntries {
+		return fmt.Sprintf("too many metadata entries (%d, max %d)", len(metadata), maxRunMetadataEntries)
+	}
+	for k, v := range metadata {
+		if !validRunMetadataKeyRe.MatchString(k) {
+			return fmt.Sprintf("invalid metadata key %q: letters, digits, '.', '_', '-', '/' (max 63 chars)", k)
+		}
+		if len(v) > maxRunMetadataValueLength {
+			return fmt.Sprintf("metadata %q value too long (%d chars, max %d)", k, len(v), maxRunMetadataValueLength)
+		}
+	}
+	return ""
+}
+
+// RunParameterMetadataPrefix prefixes the run metadata keys holding a run's
+// parameters. Keeping them in metadata shows them on the run and carries
+// them over to retries.
+const RunParameterMetadataPrefix = "param."
+
+// validRunParameterKeyRe keeps parameter keys usable as identifiers in
+// pipeline code. The length cap leaves room for the metadata prefix.
+var validRunParameterKeyRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,56}$`)
+
+// validateRunParameters returns a client-facing error message, or "" when
+// the parameters are valid. Value limits are checked with the metadata they
+// are stored in.
+func validateRunParameters(params map[string]string) string {
+	for k := range params {
+		if !validRunParameterKeyRe.MatchString(k) {
+			return fmt.Sprintf("invalid parameter key %q: letters, digits, '_', must not start with a digit (max 57 chars)", k)
+		}
+	}
+	return ""
+}
+
+// runMetadataWithParameters returns metadata with params folded in under
+// RunParameterMetadataPrefix. Returns metadata unchanged when there are no
+// params.
+func runMetadataWithParameters(metadata, params map[string]string) map[string]string {
+	if len(params) == 0 {
+		return metadata
+	}
+	merged := make(map[string]string, len(metadata)+len(params))
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	for k, v := range params {
+		merged[RunParameterMetadataPrefix+k] = v
+	}
+	return merged
+}
+
+// RunParameters returns the parameters stored in a run's metadata, or nil
+// when it has none.
+func RunParameters(run *domain.Run) map[string]string {
+	var params map[string]string
+	for k, v := range run.Metadata {
+		name, ok := strings.CutPrefix(k, RunParameterMetadataPrefix)
+		if !ok {
+			continue
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[name] = v
+	}
+	return params
 }
 
 // MountRunRoutes registers run endpoints on the router.
 func MountRunRoutes(r chi.Router, srv *Server) {
 	r.Get("/runs", srv.HandleListRuns)
 	r.Post("/runs", srv.HandleCreateRun)
+	r.Get("/runs/active", srv.HandleListActiveRuns)
+	r.Get("/runs/dead-lettered", srv.HandleListDeadLetteredRuns)
+	r.Get("/runs/stuck", srv.HandleListStuckRuns)
+	r.Post("/runs/cancel-all", srv.HandleCancelAllRuns)
+	r.Post("/runs/latest", srv.HandleLatestRuns)
 	r.Get("/runs/{runID}", srv.HandleGetRun)
 	r.Post("/runs/{runID}/cancel", srv.HandleCancelRun)
+	r.Post("/runs/{runID}/retry", srv.HandleRetryRun)
+	r.Post("/runs/{runID}/force-fail", srv.HandleForceFailRun)
 	r.Get("/runs/{runID}/logs", srv.HandleGetRunLogs)
+	r.Get("/runs/{runID}/logs/stream", srv.HandleStreamRunLogs)
+	r.Get("/runs/{runID}/logs/download", srv.HandleDownloadRunLogs)
 }
 
 // HandleListRuns returns runs, optionally filtered by pipeline, status, and date range.
 // Pagination is pushed to SQL via LIMIT/OFFSET for efficiency.
-// Date range filters: ?started_after=RFC3339 and ?started_before=RFC3339.
+// Date range filters: ?started_after=RFC3339 and ?started_before=RFC3339,
+// ?created_after=RFC3339 and ?created_before=RFC3339.
+// Trigger filter: ?trigger=schedule: (prefix match on the trigger label).
 // Sorting: ?sort=field or ?sort=-field (descending).
 //
+// Cursor pagination: when more runs exist past this page the response carries
+// a next_cursor; pass it back as ?cursor= to fetch the next page. Cursor pages
+// are stable while new runs are being created, unlike offset pages.
+//
 // When an Authorizer is configured (Pro), the page is post-filtered to only
 // runs whose parent pipeline the caller can read. Same pagination caveat as
-// HandleListPipelines applies.
+// HandleListPipelines applies. Like it, responses carry a body-hash ETag.
 func (s *Server) HandleListRuns(w http.ResponseWriter, r *http.Request) {
 	limit, offset := parsePagination(r)
 	filter := RunFilter{
@@ -99,11 +302,20 @@ func (s *Server) HandleListRuns(w http.ResponseWriter, r *http.Request) {
 		Layer:     r.URL.Query().Get("layer"),
 		Pipeline:  r.URL.Query().Get("pipeline"),
 		Status:    r.URL.Query().Get("status"),
+		Trigger:   r.URL.Query().Get("trigger"),
+		ParentRunID: r.URL.Query().Get("parent_run_id"),
 		Limit:     limit,
 		Offset:    offset,
 		Sort:      parseSorting(r, runSortFields),
 	}
 
+	if filter.ParentRunID != "" {
+		if _, err := uuid.Parse(filter.ParentRunID); err != nil {
+			errorJSON(w, "parent_run_id must be a UUID", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Parse optional date range filters.
 	if v := r.URL.Query().Get("started_after"); v != "" {
 		if t, err := time.Parse(time.RFC3339, v); err == nil {
@@ -121,13 +333,49 @@ func (s *Server) HandleListRuns(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	if v := r.URL.Query().Get("created_after"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.CreatedAfter = &t
+		} else {
+			errorJSON(w, "created_after must be RFC3339 format", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("created_before"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.CreatedBefore = &t
+		} else {
+			errorJSON(w, "created_before must be RFC3339 format", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
 
-	runs, err := s.Runs.ListRuns(r.Context(), filter)
+	if v := r.URL.Query().Get("cursor"); v != "" {
+		cursor, err := DecodeRunCursor(v)
+		if err != nil {
+			errorJSON(w, "invalid cursor", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		filter.After = cursor
+		filter.Offset = 0
+	}
+
+	// Fetch one extra row to learn whether another page exists.
+	pageFilter := filter
+	pageFilter.Limit = limit + 1
+	runs, err := s.Runs.ListRuns(r.Context(), pageFilter)
 	if err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
 
+	var nextCursor string
+	if len(runs) > limit {
+		runs = runs[:limit]
+		last := runs[len(runs)-1]
+		nextCursor = RunCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
 	runs = filterRunsByPipelineAccess(r.Context(), s, runs, "read")
 
 	total, err := s.Runs.CountRuns(r.Context(), filter)
@@ -139,10 +387,14 @@ func (s *Server) HandleListRuns(w http.ResponseWriter, r *http.Request) {
 		total = len(runs)
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	resp := map[string]interface{}{
 		"runs":  runs,
 		"total": total,
-	})
+	}
+	if nextCursor != "" {
+		resp["next_cursor"] = nextCursor
+	}
+	writeJSONWithETag(w, r, resp)
 }
 
 // filterRunsByPipelineAccess restricts runs to those whose parent pipeline
@@ -178,6 +430,18 @@ func filterRunsByPipelineAccess(ctx context.Context, s *Server, runs []domain.Ru
 	return out
 }
 
+// maxChildRunIDs caps child_run_ids in GET /runs/{runID}; a fan-out run's
+// full child list is paged through GET /runs?parent_run_id= instead.
+const maxChildRunIDs = 100
+
+// RunDetailResponse is a run plus the IDs of the runs it fired downstream
+// through pipeline_success triggers (newest first, at most maxChildRunIDs).
+type RunDetailResponse struct {
+	domain.Run
+	ChildRunIDs        []string `json:"child_run_ids"`
+	ChildRunsTruncated bool     `json:"child_runs_truncated,omitempty"`
+}
+
 // HandleGetRun returns a single run by ID.
 func (s *Server) HandleGetRun(w http.ResponseWriter, r *http.Request) {
 	runID := chi.URLParam(r, "runID")
@@ -196,7 +460,22 @@ func (s *Server) HandleGetRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, run)
+	// One extra row tells us whether the list was cut short.
+	children, err := s.Runs.ListRuns(r.Context(), RunFilter{ParentRunID: runID, Limit: maxChildRunIDs + 1})
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	truncated := len(children) > maxChildRunIDs
+	if truncated {
+		children = children[:maxChildRunIDs]
+	}
+	childIDs := make([]string, 0, len(children))
+	for _, child := range children {
+		childIDs = append(childIDs, child.ID.String())
+	}
+
+	writeJSON(w, http.StatusOK, RunDetailResponse{Run: *run, ChildRunIDs: childIDs, ChildRunsTruncated: truncated})
 }
 
 // HandleCreateRun triggers a new pipeline run.
@@ -224,6 +503,21 @@ func (s *Server) HandleCreateRun(w http.ResponseWriter, r *http.Request) {
 	if req.Trigger == "" {
 		req.Trigger = "manual"
 	}
+	if msg := validateRunParameters(req.Parameters); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	for k := range req.Metadata {
+		if strings.HasPrefix(k, RunParameterMetadataPrefix) {
+			errorJSON(w, fmt.Sprintf("metadata key %q is reserved; pass it in parameters", k), "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+	metadata := runMetadataWithParameters(req.Metadata, req.Parameters)
+	if msg := validateRunMetadata(metadata); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
 
 	// Verify pipeline exists
 	pipeline, err := s.Pipelines.GetPipeline(r.Context(), req.Namespace, req.Layer, req.Pipeline)
@@ -245,6 +539,7 @@ func (s *Server) HandleCreateRun(w http.ResponseWriter, r *http.Request) {
 		PipelineID: pipeline.ID,
 		Status:     domain.RunStatusPending,
 		Trigger:    req.Trigger,
+		Metadata:   metadata,
 	}
 
 	if err := s.Runs.CreateRun(r.Context(), run); err != nil {
@@ -252,45 +547,11 @@ func (s *Server) HandleCreateRun(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Inject cloud credentials if a cloud provider plugin is available and the
-	// caller is authenticated. The runner-side integration (closing the loop
-	// from ADR-018) consumes `run.S3Overrides` as the per-run S3Credentials in
-	// the SubmitRequest proto — see executor.s3OverridesToProto and the runner
-	// server's _s3_credentials_to_dict.
-	//
-	// Failure to fetch credentials is logged but never blocks the run — pipelines
-	// that don't need cloud credentials (the no-cloud-plugin path) must keep
-	// working. The Expiry field is checked by the HTTP handler (cloud.go) but is
-	// NOT propagated to the runner: it's a freshness gate for ratd only.
-	//
-	// The cloud plugin call is OUTSIDE any DB transaction (per ADR-022).
-	if s.Cloud != nil && s.Cloud.CloudEnabled() {
-		user := plugins.UserFromContext(r.Context())
-		if user != nil {
-			creds, err := s.Cloud.GetCredentials(r.Context(), user.UserID, req.Namespace)
-			if err != nil {
-				// Don't fail the run — non-cloud-aware pipelines still work.
-				slog.Warn("cloud credentials unavailable, proceeding without overrides",
-					"run_id", run.ID, "namespace", req.Namespace, "error", err)
-			} else if creds != nil {
-				// Keys MUST match the lowercase proto field names consumed by
-				// s3OverridesToProto (executor/plugin.go and warmpool.go) and by
-				// the runner's _s3_credentials_to_dict.
-				run.S3Overrides = map[string]string{
-					"access_key_id":     creds.AccessKey,
-					"secret_access_key": creds.SecretKey,
-					"session_token":     creds.SessionToken,
-					"region":            creds.Region,
-				}
-			}
-		}
-	}
+	s.injectCloudCredentials(r.Context(), run, req.Namespace)
 
 	// Dispatch to executor if available
 	if s.Executor != nil {
-		if err := s.Executor.Submit(r.Context(), run, pipeline); err != nil {
-			slog.Error("executor submit failed", "run_id", run.ID, "error", err)
-		}
+		s.submitInteractiveRun(r.Context(), run, pipeline)
 	}
 
 	writeJSON(w, http.StatusAccepted, map[string]interface{}{
@@ -299,10 +560,169 @@ func (s *Server) HandleCreateRun(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// submitInteractiveRun submits a run somebody is waiting on (manual run,
+// retry, webhook). Nothing retries these, so any submit failure fails the
+// run (see SubmitOrFail) and the caller sees it straight away.
+func (s *Server) submitInteractiveRun(ctx context.Context, run *domain.Run, pipeline *domain.Pipeline) {
+	if err := SubmitOrFail(ctx, s.Executor, s.Runs, run, pipeline); err != nil {
+		slog.Error("executor submit failed", "run_id", run.ID, "error", err)
+	}
+}
+
+// injectCloudCredentials sets run.S3Overrides from the cloud provider plugin
+// when one is enabled and the caller is authenticated. The runner-side
+// integration (closing the loop from ADR-018) consumes `run.S3Overrides` as
+// the per-run S3Credentials in the SubmitRequest proto — see
+// executor.s3OverridesToProto and the runner server's _s3_credentials_to_dict.
+//
+// Failure to fetch credentials is logged but never blocks the run — pipelines
+// that don't need cloud credentials (the no-cloud-plugin path) must keep
+// working. The Expiry field is checked by the HTTP handler (cloud.go) but is
+// NOT propagated to the runner: it's a freshness gate for ratd only.
+//
+// The cloud plugin call must stay OUTSIDE any DB transaction (per ADR-022).
+func (s *Server) injectCloudCredentials(ctx context.Context, run *domain.Run, namespace string) {
+	if s.Cloud == nil || !s.Cloud.CloudEnabled() {
+		return
+	}
+	user := plugins.UserFromContext(ctx)
+	if user == nil {
+		return
+	}
+	creds, err := s.Cloud.GetCredentials(ctx, user.UserID, namespace)
+	if err != nil {
+		// Don't fail the run — non-cloud-aware pipelines still work.
+		slog.Warn("cloud credentials unavailable, proceeding without overrides",
+			"run_id", run.ID, "namespace", namespace, "error", err)
+		return
+	}
+	if creds != nil {
+		// Keys MUST match the lowercase proto field names consumed by
+		// s3OverridesToProto (executor/plugin.go and warmpool.go) and by
+		// the runner's _s3_credentials_to_dict.
+		run.S3Overrides = map[string]string{
+			"access_key_id":     creds.AccessKey,
+			"secret_access_key": creds.SecretKey,
+			"session_token":     creds.SessionToken,
+			"region":            creds.Region,
+		}
+	}
+}
+
+// RetryOfMetadataKey is the run metadata key linking a retry to the run it
+// re-executes.
+const RetryOfMetadataKey = "retry_of"
+
+// HandleRetryRun re-executes a finished run: a new run is created for the same
+// pipeline with trigger "retry:<original run ID>", the original's metadata plus
+// retry_of, and submitted to the executor. Pending or running runs are
+// rejected with 409 — cancel them first.
+// POST /api/v1/runs/{runID}/retry
+func (s *Server) HandleRetryRun(w http.ResponseWriter, r *http.Request) {
+	runID := chi.URLParam(r, "runID")
+
+	original, err := s.Runs.GetRun(r.Context(), runID)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if original == nil {
+		errorJSON(w, "run not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	// Retrying = triggering a run = write access on the pipeline.
+	if !s.requireAccess(w, r, "pipeline", original.PipelineID.String(), "write") {
+		return
+	}
+
+	if !isTerminalStatus(original.Status) {
+		errorJSON(w, "run is not retryable (status: "+string(original.Status)+")", "ALREADY_EXISTS", http.StatusConflict)
+		return
+	}
+
+	pipeline, err := s.Pipelines.GetPipelineByID(r.Context(), original.PipelineID.String())
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	// Carry the original's metadata over (e.g. backfill_date) so the retry
+	// processes the same inputs.
+	metadata := make(map[string]string, len(original.Metadata)+1)
+	for k, v := range original.Metadata {
+		metadata[k] = v
+	}
+	metadata[RetryOfMetadataKey] = original.ID.String()
+
+	run := &domain.Run{
+		PipelineID: pipeline.ID,
+		Status:     domain.RunStatusPending,
+		Trigger:    "retry:" + original.ID.String(),
+		Metadata:   metadata,
+	}
+	if err := s.Runs.CreateRun(r.Context(), run); err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	s.injectCloudCredentials(r.Context(), run, pipeline.Namespace)
+
+	if s.Executor != nil {
+		s.submitInteractiveRun(r.Context(), run, pipeline)
+	}
+
+	slog.Info("run retried", "run_id", run.ID, "retry_of", original.ID)
+
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"run_id":   run.ID.String(),
+		"status":   run.Status,
+		"retry_of": original.ID.String(),
+	})
+}
+
+// CancelRunRequest is the optional JSON body for POST /runs/{runID}/cancel.
+type CancelRunRequest struct {
+	// CancelCascade also cancels pending/running runs fired downstream of
+	// this one by pipeline_success triggers, recursively.
+	CancelCascade bool `json:"cascade"`
+}
+
+// maxCancelCascadeDepth bounds how far down a trigger chain a cascading
+// cancel walks.
+const maxCancelCascadeDepth = 32
+
+// cancelCascadePageSize is how many child runs a cascading cancel loads per
+// query, so a wide fan-out is never read into memory in one go.
+const cancelCascadePageSize = 500
+
 // HandleCancelRun cancels a running pipeline.
+// With cascade (?cascade=true or {"cascade": true}) in-flight child runs are
+// cancelled too. A finished run can still be cascade-cancelled: the run itself
+// is left as-is and only its in-flight descendants are stopped.
 func (s *Server) HandleCancelRun(w http.ResponseWriter, r *http.Request) {
 	runID := chi.URLParam(r, "runID")
 
+	var req CancelRunRequest
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := r.URL.Query().Get("cascade"); v != "" {
+		cascade, err := strconv.ParseBool(v)
+		if err != nil {
+			errorJSON(w, "cascade must be a boolean", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		req.CancelCascade = req.CancelCascade || cascade
+	}
+
 	run, err := s.Runs.GetRun(r.Context(), runID)
 	if err != nil {
 		internalError(w, "internal error", err)
@@ -314,27 +734,391 @@ func (s *Server) HandleCancelRun(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Can only cancel pending or running
-	if run.Status != domain.RunStatusPending && run.Status != domain.RunStatusRunning {
+	active := run.Status == domain.RunStatusPending || run.Status == domain.RunStatusRunning
+	if !active && !req.CancelCascade {
 		errorJSON(w, "run is not cancellable (status: "+string(run.Status)+")", "ALREADY_EXISTS", http.StatusConflict)
 		return
 	}
 
-	if err := s.Runs.UpdateRunStatus(r.Context(), runID, domain.RunStatusCancelled, nil, nil, nil); err != nil {
+	if active {
+		if err := s.cancelRun(r.Context(), runID); err != nil {
+			internalError(w, "internal error", err)
+			return
+		}
+	}
+
+	if !req.CancelCascade {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"run_id": runID,
+			"status": string(domain.RunStatusCancelled),
+		})
+		return
+	}
+
+	cancelled, err := s.cancelChildRuns(r.Context(), run.ID.String())
+	if err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
 
+	status := run.Status
+	if active {
+		status = domain.RunStatusCancelled
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"run_id":             runID,
+		"status":             string(status),
+		"cancelled_children": cancelled,
+	})
+}
+
+// cancelRun marks a run cancelled and asks the executor to stop it.
+func (s *Server) cancelRun(ctx context.Context, runID string) error {
+	if err := s.Runs.UpdateRunStatus(ctx, runID, domain.RunStatusCancelled, nil, nil, nil); err != nil {
+		return err
+	}
+
 	// Best-effort cancel in executor
 	if s.Executor != nil {
-		_ = s.Executor.Cancel(r.Context(), runID)
+		_ = s.Executor.Cancel(ctx, runID)
+	}
+	return nil
+}
+
+// cancelChildRuns walks the runs fired downstream of parentID and cancels
+// every pending or running one. Finished children are not touched but are
+// still descended into — a succeeded child may itself have fired runs that
+// are in flight. Returns the IDs of the runs it cancelled.
+//
+// For a namespace-scoped API key the cascade stays inside the key's
+// namespaces: a child in any other namespace is neither cancelled nor
+// descended into.
+func (s *Server) cancelChildRuns(ctx context.Context, parentID string) ([]string, error) {
+	allowed, restricted := auth.AllowedNamespaces(ctx)
+	namespaces := map[uuid.UUID]string{} // pipeline ID -> namespace
+	inScope := func(child domain.Run) (bool, error) {
+		if !restricted {
+			return true, nil
+		}
+		ns, ok := namespaces[child.PipelineID]
+		if !ok {
+			pipeline, err := s.Pipelines.GetPipelineByID(ctx, child.PipelineID.String())
+			if err != nil {
+				return false, fmt.Errorf("get pipeline of child run %s: %w", child.ID, err)
+			}
+			if pipeline != nil {
+				ns = pipeline.Namespace
+			}
+			namespaces[child.PipelineID] = ns
+		}
+		return slices.Contains(allowed, ns), nil
+	}
+
+	cancelled := []string{}
+	level := []string{parentID}
+	for depth := 0; depth < maxCancelCascadeDepth && len(level) > 0; depth++ {
+		var next []string
+		for _, id := range level {
+			// Keyset pages: cancelling a child changes its status, not its
+			// place in the created_at ordering, so no child is skipped.
+			filter := RunFilter{ParentRunID: id, Limit: cancelCascadePageSize}
+			for {
+				children, err := s.Runs.ListRuns(ctx, filter)
+				if err != nil {
+					return cancelled, fmt.Errorf("list child runs of %s: %w", id, err)
+				}
+				for _, child := range children {
+					ok, err := inScope(child)
+					if err != nil {
+						return cancelled, err
+					}
+					if !ok {
+						continue
+					}
+					childID := child.ID.String()
+					if child.Status == domain.RunStatusPending || child.Status == domain.RunStatusRunning {
+						if err := s.cancelRun(ctx, childID); err != nil {
+							return cancelled, fmt.Errorf("cancel child run %s: %w", childID, err)
+						}
+						cancelled = append(cancelled, childID)
+					}
+					next = append(next, childID)
+				}
+				if len(children) < cancelCascadePageSize {
+					break
+				}
+				last := children[len(children)-1]
+				filter.After = &RunCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+			}
+		}
+		level = next
+	}
+	return cancelled, nil
+}
+
+// activeRuns returns every pending or running run matching the scope in
+// ?namespace=, ?layer= and ?pipeline=, newest first.
+func (s *Server) activeRuns(r *http.Request) ([]domain.Run, error) {
+	q := r.URL.Query()
+	var active []domain.Run
+	for _, status := range []domain.RunStatus{domain.RunStatusPending, domain.RunStatusRunning} {
+		runs, err := s.Runs.ListRuns(r.Context(), RunFilter{
+			Namespace: q.Get("namespace"),
+			Layer:     q.Get("layer"),
+			Pipeline:  q.Get("pipeline"),
+			Status:    string(status),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list %s runs: %w", status, err)
+		}
+		active = append(active, runs...)
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].CreatedAt.After(active[j].CreatedAt)
+	})
+	return active, nil
+}
+
+// HandleListActiveRuns returns every pending or running run, unpaginated, so
+// ops can see everything in flight at once. Optional ?namespace=, ?layer= and
+// ?pipeline= narrow the scope.
+func (s *Server) HandleListActiveRuns(w http.ResponseWriter, r *http.Request) {
+	runs, err := s.activeRuns(r)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	runs = filterRunsByPipelineAccess(r.Context(), s, runs, "read")
+	if runs == nil {
+		runs = []domain.Run{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"runs":  runs,
+		"total": len(runs),
+	})
+}
+
+// HandleListDeadLetteredRuns returns runs that were failed after
+// MaxSubmitAttempts retryable submit failures, newest first. Filterable by
+// namespace, layer and pipeline; paginated like GET /runs. POST
+// /runs/{runID}/retry re-drives one once the cause is fixed.
+func (s *Server) HandleListDeadLetteredRuns(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+	filter := RunFilter{
+		Namespace:    r.URL.Query().Get("namespace"),
+		Layer:        r.URL.Query().Get("layer"),
+		Pipeline:     r.URL.Query().Get("pipeline"),
+		DeadLettered: true,
+		Limit:        limit,
+		Offset:       offset,
+	}
+
+	runs, err := s.Runs.ListRuns(r.Context(), filter)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	runs = filterRunsByPipelineAccess(r.Context(), s, runs, "read")
+	if runs == nil {
+		runs = []domain.Run{}
+	}
+
+	total, err := s.Runs.CountRuns(r.Context(), filter)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if plugins.UserFromContext(r.Context()) != nil {
+		total = len(runs)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"runs":  runs,
+		"total": total,
+	})
+}
+
+// CancelAllResult is the outcome of cancelling one run in POST /runs/cancel-all.
+type CancelAllResult struct {
+	RunID      string `json:"run_id"`
+	PipelineID string `json:"pipeline_id"`
+	Cancelled  bool   `json:"cancelled"`
+	Error      string `json:"error,omitempty"`
+}
+
+// HandleCancelAllRuns cancels every pending or running run in scope — the
+// incident "stop everything" switch. Scoped like HandleListActiveRuns; with no
+// scope it cancels platform-wide. Admin only.
+//
+// Each run is marked cancelled and then cancelled in the executor, the same
+// as HandleCancelRun. A failure on one run doesn't stop the rest: the response
+// lists a result per run. A run whose status update failed is reported with
+// cancelled = false; an executor cancel failure is reported as an error on a
+// run that is still marked cancelled (the reaper and the runner's status
+// callback reconcile it).
+func (s *Server) HandleCancelAllRuns(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	runs, err := s.activeRuns(r)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	results := make([]CancelAllResult, 0, len(runs))
+	cancelled := 0
+	for _, run := range runs {
+		runID := run.ID.String()
+		result := CancelAllResult{RunID: runID, PipelineID: run.PipelineID.String()}
+		if err := s.Runs.UpdateRunStatus(r.Context(), runID, domain.RunStatusCancelled, nil, nil, nil); err != nil {
+			slog.Error("cancel-all: failed to mark run cancelled", "run_id", runID, "error", err)
+			result.Error = "failed to mark run cancelled"
+			results = append(results, result)
+			continue
+		}
+		result.Cancelled = true
+		cancelled++
+		if s.Executor != nil {
+			if err := s.Executor.Cancel(r.Context(), runID); err != nil {
+				slog.Warn("cancel-all: executor cancel failed", "run_id", runID, "error", err)
+				result.Error = "executor cancel failed: " + err.Error()
+			}
+		}
+		results = append(results, result)
+	}
+
+	slog.Info("cancel-all", "namespace", r.URL.Query().Get("namespace"),
+		"layer", r.URL.Query().Get("layer"), "pipeline", r.URL.Query().Get("pipeline"),
+		"active", len(runs), "cancelled", cancelled)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results":   results,
+		"cancelled": cancelled,
+		"failed":    len(runs) - cancelled,
+	})
+}
+
+// maxLatestRunsPipelines caps the pipeline IDs accepted by POST /runs/latest.
+const maxLatestRunsPipelines = 500
+
+// LatestRunsRequest is the body of POST /runs/latest.
+type LatestRunsRequest struct {
+	PipelineIDs []string `json:"pipeline_ids"`
+}
+
+// HandleLatestRuns returns the most recent run of each requested pipeline in
+// one call, replacing a status request per pipeline on the dashboard. The
+// response maps pipeline ID to run (same shape as ?include=latest_run on
+// GET /pipelines); pipelines without runs, or that the caller can't read,
+// are left out.
+func (s *Server) HandleLatestRuns(w http.ResponseWriter, r *http.Request) {
+	var req LatestRunsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if len(req.PipelineIDs) > maxLatestRunsPipelines {
+		errorJSON(w, fmt.Sprintf("too many pipeline_ids (%d, max %d)", len(req.PipelineIDs), maxLatestRunsPipelines), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	parsed := make(map[string]uuid.UUID, len(req.PipelineIDs))
+	ids := make([]string, 0, len(req.PipelineIDs))
+	for _, raw := range req.PipelineIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			errorJSON(w, fmt.Sprintf("pipeline_ids: %q is not a UUID", raw), "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		if _, dup := parsed[id.String()]; !dup {
+			parsed[id.String()] = id
+			ids = append(ids, id.String())
+		}
+	}
+
+	allowed := s.filterAccess(r.Context(), "pipeline", "read", ids)
+	pipelineIDs := make([]uuid.UUID, 0, len(allowed))
+	for _, id := range allowed {
+		if pid, ok := parsed[id]; ok {
+			pipelineIDs = append(pipelineIDs, pid)
+		}
+	}
+	latest, err := s.latestRunPerPipeline(r.Context(), pipelineIDs)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
-		"run_id": runID,
-		"status": string(domain.RunStatusCancelled),
+	latestRuns := make(map[string]*domain.Run, len(latest))
+	for id, run := range latest {
+		latestRuns[id.String()] = run
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"latest_runs": latestRuns,
 	})
 }
 
+// latestRunPerPipeline is a read-through wrapper around
+// RunStore.LatestRunPerPipeline using LatestRunCache. Entries live for the
+// cache TTL or until a run_completed event clears them (WatchRunCompletions).
+func (s *Server) latestRunPerPipeline(ctx context.Context, pipelineIDs []uuid.UUID) (map[uuid.UUID]*domain.Run, error) {
+	if len(pipelineIDs) == 0 {
+		return map[uuid.UUID]*domain.Run{}, nil
+	}
+	key := latestRunCacheKey(pipelineIDs)
+	if s.LatestRunCache != nil {
+		if cached, ok := s.LatestRunCache.Get(key); ok {
+			return cached, nil
+		}
+	}
+
+	latest, err := s.Runs.LatestRunPerPipeline(ctx, pipelineIDs)
+	if err != nil {
+		return nil, err
+	}
+	if s.LatestRunCache != nil {
+		s.LatestRunCache.Set(key, latest)
+	}
+	return latest, nil
+}
+
+// latestRunCacheKey builds the LatestRunCache key for a pipeline-ID set:
+// the sorted IDs joined by commas, so order in the request doesn't matter.
+func latestRunCacheKey(pipelineIDs []uuid.UUID) string {
+	ids := make([]string, len(pipelineIDs))
+	for i, id := range pipelineIDs {
+		ids[i] = id.String()
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+// WatchRunCompletions clears LatestRunCache whenever a run reaches a terminal
+// status, so dashboards never show a finished run as still running for the
+// rest of the TTL. Returns immediately if there is no cache; otherwise runs
+// until ctx is cancelled or the subscription channel closes.
+func (s *Server) WatchRunCompletions(ctx context.Context, bus plugins.DispatchEventBus) {
+	if s.LatestRunCache == nil {
+		return
+	}
+	ch, cancel := bus.Subscribe(plugins.ChannelRunCompleted)
+	go func() {
+		defer cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-ch:
+				if !ok {
+					return
+				}
+				s.LatestRunCache.Clear()
+			}
+		}
+	}()
+}
+
 // isTerminalStatus returns true if the run status is a final state.
 func isTerminalStatus(s domain.RunStatus) bool {
 	return s == domain.RunStatusSuccess || s == domain.RunStatusFailed || s == domain.RunStatusCancelled
@@ -359,51 +1143,160 @@ func (s *Server) HandleGetRunLogs(w http.ResponseWriter, r *http.Request) {
 	// Check if client wants SSE
 	if r.Header.Get("Accept") == "text/event-stream" {
 		// Enforce SSE connection limits to prevent DoS.
-		ip := clientIP(r)
-		if s.SSELimiter != nil && !s.SSELimiter.Acquire(ip) {
-			errorJSON(w, "too many SSE connections", "RESOURCE_EXHAUSTED", http.StatusTooManyRequests)
+		release, ok := s.acquireSSE(w, r)
+		if !ok {
 			return
 		}
-		s.streamRunLogs(w, r, runID, run, ip)
+		defer release()
+		s.streamRunLogs(w, r, runID, run)
+		return
+	}
+
+	// JSON fallback
+	logs, err := s.loadRunLogs(r.Context(), run)
+	if err != nil {
+		internalError(w, "internal error", err)
 		return
 	}
 
-	// JSON fallback — try executor first for active runs
+	q := r.URL.Query()
+	logs = filterLogEntries(logs, q.Get("level"), q.Get("contains"))
+	total := len(logs)
+	// Only paginate on request: existing clients expect the full log.
+	if q.Has("limit") || q.Has("offset") {
+		limit, offset := parsePagination(r)
+		logs = paginate(logs, limit, offset)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"logs":   logs,
+		"total":  total,
+		"status": run.Status,
+	})
+}
+
+// filterLogEntries keeps the entries whose level is one of the comma-separated
+// levels and whose message contains the substring contains. Both matches are
+// case-insensitive; an empty argument disables that filter.
+func filterLogEntries(logs []LogEntry, levels, contains string) []LogEntry {
+	if levels == "" && contains == "" {
+		return logs
+	}
+	wantLevel := make(map[string]bool)
+	for _, l := range strings.Split(levels, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			wantLevel[strings.ToLower(l)] = true
+		}
+	}
+	needle := strings.ToLower(contains)
+
+	filtered := make([]LogEntry, 0, len(logs))
+	for _, entry := range logs {
+		if len(wantLevel) > 0 && !wantLevel[strings.ToLower(entry.Level)] {
+			continue
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(entry.Message), needle) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// loadRunLogs returns a snapshot of run's logs. Active runs are read live from
+// the executor; terminal runs (or an executor error) fall back to the logs
+// persisted in the run store. Never returns a nil slice.
+func (s *Server) loadRunLogs(ctx context.Context, run *domain.Run) ([]LogEntry, error) {
+	runID := run.ID.String()
 	var logs []LogEntry
 	if s.Executor != nil && !isTerminalStatus(run.Status) {
-		executorLogs, err := s.Executor.GetLogs(r.Context(), runID)
+		executorLogs, err := s.Executor.GetLogs(ctx, runID)
 		if err == nil {
 			logs = executorLogs
 		}
 	}
 	if logs == nil {
-		dbLogs, err := s.Runs.GetRunLogs(r.Context(), runID)
+		dbLogs, err := s.Runs.GetRunLogs(ctx, runID)
 		if err != nil {
-			internalError(w, "internal error", err)
-			return
+			return nil, err
 		}
 		logs = dbLogs
 	}
 	if logs == nil {
 		logs = []LogEntry{}
 	}
+	return logs, nil
+}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"logs":   logs,
-		"status": run.Status,
-	})
+// HandleDownloadRunLogs returns a run's logs as a file attachment for offline
+// analysis.
+// GET /api/v1/runs/{runID}/logs/download?format=txt|json
+//
+// format=txt (the default) writes one "<timestamp> <LEVEL> <message>" line per
+// entry; format=json writes the entries as a JSON array. A still-running run
+// gets the executor's live logs at the time of the request.
+func (s *Server) HandleDownloadRunLogs(w http.ResponseWriter, r *http.Request) {
+	runID := chi.URLParam(r, "runID")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "txt"
+	}
+	if format != "txt" && format != "json" {
+		errorJSON(w, "format must be txt or json", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	run, err := s.Runs.GetRun(r.Context(), runID)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if run == nil {
+		errorJSON(w, "run not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	if !s.requireAccess(w, r, "pipeline", run.PipelineID.String(), "read") {
+		return
+	}
+
+	logs, err := s.loadRunLogs(r.Context(), run)
+	if err != nil {
+		internalError(w, "failed to load run logs", err)
+		return
+	}
+
+	filename := fmt.Sprintf("run-%s.log", run.ID)
+	if format == "json" {
+		filename = fmt.Sprintf("run-%s.json", run.ID)
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	if format == "json" {
+		if err := json.NewEncoder(w).Encode(logs); err != nil {
+			slog.Error("run log download aborted", "run_id", runID, "error", err)
+		}
+		return
+	}
+	bw := bufio.NewWriter(w)
+	for _, entry := range logs {
+		fmt.Fprintf(bw, "%s %s %s\n", entry.Timestamp, strings.ToUpper(entry.Level), entry.Message)
+	}
+	if err := bw.Flush(); err != nil {
+		slog.Error("run log download aborted", "run_id", runID, "error", err)
+	}
 }
 
 // streamRunLogs implements the SSE streaming path for run logs.
 // It keeps the connection open, polls for new logs every 2 seconds,
 // and closes when the run reaches a terminal state or the max duration is reached.
-// The ip parameter is used to release the SSE limiter slot on exit.
-func (s *Server) streamRunLogs(w http.ResponseWriter, r *http.Request, runID string, run *domain.Run, ip string) {
-	// Release SSE limiter slot when the connection ends.
-	if s.SSELimiter != nil {
-		defer s.SSELimiter.Release(ip)
-	}
-
+// The caller owns the SSE limiter slot and releases it after this returns.
+func (s *Server) streamRunLogs(w http.ResponseWriter, r *http.Request, runID string, run *domain.Run) {
 	// Enforce max SSE connection duration to prevent indefinite resource consumption.
 	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(MaxSSEDurationSeconds)*time.Second)
 	defer cancel()
@@ -506,3 +1399,118 @@ func (s *Server) streamRunLogs(w http.ResponseWriter, r *http.Request, runID str
 		}
 	}
 }
+
+// runStatusSettleInterval is how often relayRunLogs re-reads the run after the
+// runner closes the log stream. The runner closes the stream the moment the
+// run finishes, but the terminal status lands in Postgres via the status
+// callback a beat later — this bridges that gap.
+const runStatusSettleInterval = 500 * time.Millisecond
+
+// HandleStreamRunLogs relays a run's logs live as Server-Sent Events.
+// GET /api/v1/runs/{runID}/logs/stream
+//
+// When the executor implements LogStreamer, each runner LogEntry is forwarded
+// as a "log" event the moment it's produced (runner StreamLogs with Follow).
+// Once the runner closes the stream a final "status" event carries the
+// terminal run status and the connection closes. Terminal runs, and executors
+// without live streaming, fall back to the polling stream used by
+// GET /runs/{runID}/logs with Accept: text/event-stream.
+//
+// Subject to the same SSELimiter caps as every other SSE endpoint.
+func (s *Server) HandleStreamRunLogs(w http.ResponseWriter, r *http.Request) {
+	runID := chi.URLParam(r, "runID")
+
+	run, err := s.Runs.GetRun(r.Context(), runID)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if run == nil {
+		errorJSON(w, "run not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	if !s.requireAccess(w, r, "pipeline", run.PipelineID.String(), "read") {
+		return
+	}
+
+	release, ok := s.acquireSSE(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	streamer, canStream := s.Executor.(LogStreamer)
+	if !canStream || isTerminalStatus(run.Status) {
+		s.streamRunLogs(w, r, runID, run)
+		return
+	}
+	s.relayRunLogs(w, r, runID, run, streamer)
+}
+
+// relayRunLogs forwards the executor's live log stream to the client as SSE.
+// If the executor can't stream this run before sending anything (e.g. the run
+// is still pending, or is owned by another replica), it degrades to the
+// polling stream so the client still gets logs.
+func (s *Server) relayRunLogs(w http.ResponseWriter, r *http.Request, runID string, run *domain.Run, streamer LogStreamer) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(MaxSSEDurationSeconds)*time.Second)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	flusher, canFlush := w.(http.Flusher)
+	sendEvent := func(event string, payload interface{}) error {
+		data, _ := json.Marshal(payload)
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
+		}
+		if canFlush {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	sent := 0
+	err := streamer.StreamLogs(ctx, runID, func(entry LogEntry) error {
+		if err := sendEvent("log", entry); err != nil {
+			return err
+		}
+		sent++
+		return nil
+	})
+	if err != nil && sent == 0 && ctx.Err() == nil {
+		slog.Debug("live log stream unavailable, falling back to polling", "run_id", runID, "error", err)
+		s.streamRunLogs(w, r, runID, run)
+		return
+	}
+	if err != nil && ctx.Err() == nil {
+		slog.Warn("live log stream interrupted", "run_id", runID, "error", err)
+	}
+
+	// The runner closed the stream — wait for the terminal status to land.
+	ticker := time.NewTicker(runStatusSettleInterval)
+	defer ticker.Stop()
+	for {
+		if ctx.Err() == nil {
+			current, err := s.Runs.GetRun(ctx, runID)
+			if err == nil && current != nil && isTerminalStatus(current.Status) {
+				_ = sendEvent("status", map[string]interface{}{"status": current.Status})
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				_ = sendEvent("error", map[string]string{
+					"code":    "TIMEOUT",
+					"message": "SSE connection closed: maximum duration exceeded",
+				})
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -24,6 +27,14 @@ import (
 type memoryRunStore struct {
 	mu   sync.Mutex
 	runs []domain.Run
+	// pipelines resolves run namespaces for NamespaceRunStats. Runs whose
+	// pipeline is unknown are not counted, mirroring the SQL join.
+	pipelines *memoryPipelineStore
+	// submitAttempts and deadLettered back RecordSubmitFailure.
+	submitAttempts map[uuid.UUID]int
+	deadLettered   map[uuid.UUID]bool
+	// statusLookups counts RunStatuses calls.
+	statusLookups int
 }
 
 func newMemoryRunStore() *memoryRunStore {
@@ -36,6 +47,31 @@ func (m *memoryRunStore) filteredRuns(filter api.RunFilter) []domain.Run {
 		if filter.Status != "" && string(r.Status) != filter.Status {
 			continue
 		}
+		if filter.PipelineID != "" && r.PipelineID.String() != filter.PipelineID {
+			continue
+		}
+		if filter.Trigger != "" && !strings.HasPrefix(r.Trigger, filter.Trigger) {
+			continue
+		}
+		if filter.DeadLettered && !m.deadLettered[r.ID] {
+			continue
+		}
+		if filter.ParentRunID != "" && (r.ParentRunID == nil || r.ParentRunID.String() != filter.ParentRunID) {
+			continue
+		}
+		if filter.CreatedAfter != nil && r.CreatedAt.Before(*filter.CreatedAfter) {
+			continue
+		}
+		if filter.CreatedBefore != nil && !r.CreatedAt.Before(*filter.CreatedBefore) {
+			continue
+		}
+		if filter.FinishedAfter != nil && (r.FinishedAt == nil || r.FinishedAt.Before(*filter.FinishedAfter)) {
+			continue
+		}
+		if c := filter.After; c != nil && !(r.CreatedAt.Before(c.CreatedAt) ||
+			(r.CreatedAt.Equal(c.CreatedAt) && r.ID.String() < c.ID.String())) {
+			continue
+		}
 		result = append(result, r)
 	}
 	return result
@@ -114,16 +150,54 @@ func (m *memoryRunStore) DeleteRunsBeyondLimit(_ context.Context, _ uuid.UUID, _
 	return 0, nil
 }
 
-func (m *memoryRunStore) DeleteRunsOlderThan(_ context.Context, _ time.Time) (int, error) {
+func (m *memoryRunStore) DeleteRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
 	return 0, nil
 }
 
-func (m *memoryRunStore) ListStuckRuns(_ context.Context, _ time.Time) ([]domain.Run, error) {
-	return nil, nil
+func (m *memoryRunStore) CountRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ int) (int, error) {
+	return 0, nil
 }
 
-func (m *memoryRunStore) ListStuckPendingRuns(_ context.Context, _ time.Time) ([]domain.Run, error) {
-	return nil, nil
+func (m *memoryRunStore) CountRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *memoryRunStore) ListStuckRuns(_ context.Context, olderThan time.Time) ([]domain.Run, error) {
+	return m.runsCreatedBefore(domain.RunStatusRunning, olderThan), nil
+}
+
+func (m *memoryRunStore) ListStuckPendingRuns(_ context.Context, olderThan time.Time) ([]domain.Run, error) {
+	return m.runsCreatedBefore(domain.RunStatusPending, olderThan), nil
+}
+
+func (m *memoryRunStore) runsCreatedBefore(status domain.RunStatus, cutoff time.Time) []domain.Run {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []domain.Run
+	for _, r := range m.runs {
+		if r.Status == status && r.CreatedAt.Before(cutoff) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func (m *memoryRunStore) RunStatuses(_ context.Context, runIDs []uuid.UUID) (map[uuid.UUID]domain.RunStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.statusLookups++
+	result := make(map[uuid.UUID]domain.RunStatus)
+	for _, id := range runIDs {
+		for _, r := range m.runs {
+			if r.ID == id {
+				result[id] = r.Status
+				break
+			}
+		}
+	}
+	return result, nil
 }
 
 func (m *memoryRunStore) LatestRunPerPipeline(_ context.Context, pipelineIDs []uuid.UUID) (map[uuid.UUID]*domain.Run, error) {
@@ -143,10 +217,121 @@ func (m *memoryRunStore) LatestRunPerPipeline(_ context.Context, pipelineIDs []u
 	return result, nil
 }
 
+func (m *memoryRunStore) NamespaceRunStats(_ context.Context, namespace string, since time.Time) (*api.NamespaceRunStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inNamespace := map[uuid.UUID]bool{}
+	if m.pipelines != nil {
+		m.pipelines.mu.Lock()
+		for _, p := range m.pipelines.pipelines {
+			if p.Namespace == namespace {
+				inNamespace[p.ID] = true
+			}
+		}
+		m.pipelines.mu.Unlock()
+	}
+
+	stats := &api.NamespaceRunStats{ByStatus: map[string]int{}}
+	for _, r := range m.runs {
+		if inNamespace[r.PipelineID] && !r.CreatedAt.Before(since) {
+			stats.ByStatus[string(r.Status)]++
+			stats.Total++
+		}
+	}
+	return stats, nil
+}
+
+func (m *memoryRunStore) RecordSubmitFailure(_ context.Context, runID string, reason string, maxAttempts int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.runs {
+		r := &m.runs[i]
+		if r.ID.String() != runID || r.Status != domain.RunStatusPending {
+			continue
+		}
+		if m.submitAttempts == nil {
+			m.submitAttempts = map[uuid.UUID]int{}
+			m.deadLettered = map[uuid.UUID]bool{}
+		}
+		m.submitAttempts[r.ID]++
+		if m.submitAttempts[r.ID] < maxAttempts {
+			return false, nil
+		}
+		msg := fmt.Sprintf("dead-lettered after %d failed submit attempts: %s", m.submitAttempts[r.ID], reason)
+		r.Status = domain.RunStatusFailed
+		r.Error = &msg
+		m.deadLettered[r.ID] = true
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *memoryRunStore) PipelineStats(_ context.Context, pipelineID uuid.UUID, since time.Time) (*api.PipelineRunStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := &api.PipelineRunStats{}
+	var durations []float64
+	var rows, rowsCount float64
+	for _, r := range m.runs {
+		if r.PipelineID != pipelineID || r.CreatedAt.Before(since) {
+			continue
+		}
+		stats.Runs++
+		switch r.Status {
+		case domain.RunStatusFailed:
+			stats.Failed++
+		case domain.RunStatusSuccess:
+			stats.Succeeded++
+			if r.DurationMs != nil {
+				durations = append(durations, float64(*r.DurationMs))
+			}
+			if r.RowsWritten != nil {
+				rows += float64(*r.RowsWritten)
+				rowsCount++
+			}
+		}
+	}
+	sort.Float64s(durations)
+	// Linear interpolation, as percentile_cont does.
+	percentile := func(p float64) *float64 {
+		if len(durations) == 0 {
+			return nil
+		}
+		pos := p * float64(len(durations)-1)
+		lo := int(pos)
+		v := durations[lo]
+		if lo+1 < len(durations) {
+			v += (pos - float64(lo)) * (durations[lo+1] - durations[lo])
+		}
+		return &v
+	}
+	stats.DurationP50Ms, stats.DurationP95Ms, stats.DurationP99Ms = percentile(0.50), percentile(0.95), percentile(0.99)
+	if rowsCount > 0 {
+		avg := rows / rowsCount
+		stats.AvgRowsWritten = &avg
+	}
+	return stats, nil
+}
+
 func (m *memoryRunStore) SaveRunLogs(_ context.Context, _ string, _ []api.LogEntry) error {
 	return nil
 }
 
+func (m *memoryRunStore) SaveRunPhases(_ context.Context, runID string, phases []api.PhaseProfile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, r := range m.runs {
+		if r.ID.String() == runID {
+			m.runs[i].Phases = phases
+		}
+	}
+	return nil
+}
+
 func (m *memoryRunStore) GetRunLogs(_ context.Context, runID string) ([]api.LogEntry, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -238,6 +423,104 @@ func TestListRuns_FilterByStatus_ReturnsFiltered(t *testing.T) {
 	assert.Equal(t, float64(1), body["total"])
 }
 
+func TestListRuns_FilterByTriggerAndCreatedRange_ReturnsFiltered(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 12, 0, 0, 0, time.UTC) }
+	scheduledID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: uuid.New(), Status: domain.RunStatusSuccess, Trigger: "manual", CreatedAt: day(10)},
+		{ID: uuid.New(), Status: domain.RunStatusSuccess, Trigger: "schedule:hourly", CreatedAt: day(9)},
+		{ID: scheduledID, Status: domain.RunStatusSuccess, Trigger: "schedule:hourly", CreatedAt: day(10)},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/v1/runs?trigger=schedule:&created_after=2026-02-10T00:00:00Z&created_before=2026-02-11T00:00:00Z", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Runs  []domain.Run `json:"runs"`
+		Total int          `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Len(t, body.Runs, 1)
+	assert.Equal(t, scheduledID, body.Runs[0].ID)
+	assert.Equal(t, 1, body.Total)
+}
+
+func TestListRuns_InvalidCreatedAfter_Returns400(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs?created_after=last-tuesday", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestListRuns_MorePages_ReturnsNextCursor(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	base := time.Date(2026, 2, 12, 14, 0, 0, 0, time.UTC)
+	// Newest first, matching the store's created_at DESC order.
+	for i := 0; i < 3; i++ {
+		runStore.runs = append(runStore.runs, domain.Run{
+			ID: uuid.New(), Status: domain.RunStatusSuccess, CreatedAt: base.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs?limit=2", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var page1 struct {
+		Runs       []domain.Run `json:"runs"`
+		NextCursor string       `json:"next_cursor"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page1))
+	require.Len(t, page1.Runs, 2)
+	require.NotEmpty(t, page1.NextCursor)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/runs?limit=2&cursor="+page1.NextCursor, http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var page2 struct {
+		Runs       []domain.Run `json:"runs"`
+		NextCursor *string      `json:"next_cursor"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&page2))
+	require.Len(t, page2.Runs, 1)
+	assert.Equal(t, runStore.runs[2].ID, page2.Runs[0].ID)
+	assert.Nil(t, page2.NextCursor, "last page must not carry a cursor")
+}
+
+func TestListRuns_InvalidCursor_Returns400(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs?cursor=not-a-cursor", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRunCursor_EncodeDecode_RoundTrips(t *testing.T) {
+	want := api.RunCursor{
+		CreatedAt: time.Date(2026, 2, 12, 14, 0, 0, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	got, err := api.DecodeRunCursor(want.Encode())
+	require.NoError(t, err)
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, want.ID, got.ID)
+}
+
 // --- Get Run ---
 
 func TestGetRun_Exists_ReturnsRun(t *testing.T) {
@@ -261,6 +544,58 @@ func TestGetRun_Exists_ReturnsRun(t *testing.T) {
 	assert.Equal(t, "manual", body["trigger"])
 }
 
+func TestGetRun_WithChildren_ReturnsChildRunIDs(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	parentID := uuid.New()
+	childID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: parentID, Status: domain.RunStatusSuccess},
+		{ID: childID, Status: domain.RunStatusRunning, ParentRunID: &parentID},
+		{ID: uuid.New(), Status: domain.RunStatusRunning},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+childID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var child map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&child))
+	assert.Equal(t, parentID.String(), child["parent_run_id"])
+	assert.Empty(t, child["child_run_ids"])
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+parentID.String(), http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var parent map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&parent))
+	assert.NotContains(t, parent, "parent_run_id")
+	assert.Equal(t, []interface{}{childID.String()}, parent["child_run_ids"])
+}
+
+func TestGetRun_FanOut_CapsChildRunIDs(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	parentID := uuid.New()
+	runStore.runs = []domain.Run{{ID: parentID, Status: domain.RunStatusSuccess}}
+	for i := 0; i < 150; i++ {
+		runStore.runs = append(runStore.runs, domain.Run{ID: uuid.New(), Status: domain.RunStatusPending, ParentRunID: &parentID})
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+parentID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Len(t, resp["child_run_ids"], 100)
+	assert.Equal(t, true, resp["child_runs_truncated"])
+}
+
 func TestGetRun_NotFound_Returns404(t *testing.T) {
 	srv, _, _ := newRunTestServer()
 	router := api.NewRouter(srv)
@@ -297,6 +632,40 @@ func TestCreateRun_ValidRequest_Returns202(t *testing.T) {
 	assert.NotEmpty(t, resp["run_id"])
 }
 
+// Nothing resubmits a manual run, so a busy or unavailable runner fails it
+// rather than leaving it pending.
+func TestCreateRun_RetryableSubmitErrors_FailRun(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want domain.RunStatus
+	}{
+		"busy":        {fmt.Errorf("submit pipeline: %w", api.ErrRunnerBusy), domain.RunStatusFailed},
+		"unavailable": {fmt.Errorf("submit pipeline: %w", api.ErrRunnerUnavailable), domain.RunStatusFailed},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, pipelineStore, runStore := newRunTestServer()
+			pipelineStore.pipelines = []domain.Pipeline{
+				{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+			}
+			srv.Executor = &mockExecutor{failErr: tc.err}
+			router := api.NewRouter(srv)
+
+			body := `{"namespace":"default","layer":"silver","pipeline":"orders"}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/runs", bytes.NewBufferString(body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			var resp map[string]interface{}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, string(tc.want), resp["status"])
+			require.Len(t, runStore.runs, 1)
+			assert.Equal(t, tc.want, runStore.runs[0].Status)
+		})
+	}
+}
+
 func TestCreateRun_MissingPipeline_Returns400(t *testing.T) {
 	srv, _, _ := newRunTestServer()
 	router := api.NewRouter(srv)
@@ -364,6 +733,106 @@ func TestCreateRun_InvalidLayer_Returns400(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "layer must be bronze, silver, or gold")
 }
 
+func TestCreateRun_WithMetadata_ReturnedInRunDetail(t *testing.T) {
+	srv, pipelineStore, _ := newRunTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"namespace":"default","layer":"silver","pipeline":"orders","metadata":{"source":"backfill","ticket":"OPS-12"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var created map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&created))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+created["run_id"].(string), http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var run domain.Run
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&run))
+	assert.Equal(t, map[string]string{"source": "backfill", "ticket": "OPS-12"}, run.Metadata)
+}
+
+func TestCreateRun_InvalidMetadataKey_Returns400(t *testing.T) {
+	srv, pipelineStore, _ := newRunTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"namespace":"default","layer":"silver","pipeline":"orders","metadata":{"bad key":"x"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid metadata key")
+}
+
+func TestCreateRun_WithParameters_ReachExecutor(t *testing.T) {
+	srv, pipelineStore, _ := newRunTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	exec := &captureExecutor{}
+	srv.Executor = exec
+	router := api.NewRouter(srv)
+
+	body := `{"namespace":"default","layer":"silver","pipeline":"orders","metadata":{"ticket":"OPS-12"},"parameters":{"run_date":"2026-03-01","limit":"500"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+	require.Equal(t, 1, exec.calls)
+	assert.Equal(t, map[string]string{
+		"ticket":         "OPS-12",
+		"param.run_date": "2026-03-01",
+		"param.limit":    "500",
+	}, exec.submitted.Metadata)
+	assert.Equal(t, map[string]string{"run_date": "2026-03-01", "limit": "500"}, api.RunParameters(exec.submitted))
+}
+
+func TestCreateRun_InvalidParameters_Returns400(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantMsg string
+	}{
+		{"key with dash", `"parameters":{"run-date":"2026-03-01"}`, "invalid parameter key"},
+		{"key starting with digit", `"parameters":{"1st":"x"}`, "invalid parameter key"},
+		{"reserved metadata key", `"metadata":{"param.limit":"5"}`, "reserved"},
+		{"value too long", `"parameters":{"limit":"` + strings.Repeat("9", 1025) + `"}`, "value too long"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, pipelineStore, _ := newRunTestServer()
+			pipelineStore.pipelines = []domain.Pipeline{
+				{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+			}
+			router := api.NewRouter(srv)
+
+			body := `{"namespace":"default","layer":"silver","pipeline":"orders",` + tt.body + `}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/runs", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantMsg)
+		})
+	}
+}
+
 func TestCreateRun_DefaultsTriggerToManual(t *testing.T) {
 	srv, pipelineStore, _ := newRunTestServer()
 	pipelineStore.pipelines = []domain.Pipeline{
@@ -432,52 +901,414 @@ func TestCancelRun_CompletedRun_Returns409(t *testing.T) {
 	assert.Equal(t, http.StatusConflict, rec.Code)
 }
 
-func TestCancelRun_NotFound_Returns404(t *testing.T) {
-	srv, _, _ := newRunTestServer()
+func TestCancelRun_Cascade_CancelsInFlightDescendants(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	rootID := uuid.New()
+	childDoneID := uuid.New()
+	childActiveID := uuid.New()
+	grandchildID := uuid.New()
+	unrelatedID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: rootID, Status: domain.RunStatusRunning},
+		{ID: childDoneID, Status: domain.RunStatusSuccess, ParentRunID: &rootID},
+		{ID: childActiveID, Status: domain.RunStatusPending, ParentRunID: &rootID},
+		{ID: grandchildID, Status: domain.RunStatusRunning, ParentRunID: &childDoneID},
+		{ID: unrelatedID, Status: domain.RunStatusRunning},
+	}
 	router := api.NewRouter(srv)
 
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+uuid.New().String()+"/cancel", http.NoBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+rootID.String()+"/cancel?cascade=true", http.NoBody)
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusNotFound, rec.Code)
-}
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "cancelled", resp["status"])
+	assert.ElementsMatch(t, []interface{}{childActiveID.String(), grandchildID.String()}, resp["cancelled_children"])
 
-// --- Run Logs ---
+	statuses := map[uuid.UUID]domain.RunStatus{}
+	runStore.mu.Lock()
+	for _, r := range runStore.runs {
+		statuses[r.ID] = r.Status
+	}
+	runStore.mu.Unlock()
+	assert.Equal(t, domain.RunStatusCancelled, statuses[rootID])
+	assert.Equal(t, domain.RunStatusSuccess, statuses[childDoneID])
+	assert.Equal(t, domain.RunStatusCancelled, statuses[childActiveID])
+	assert.Equal(t, domain.RunStatusCancelled, statuses[grandchildID])
+	assert.Equal(t, domain.RunStatusRunning, statuses[unrelatedID])
+}
 
-func TestGetRunLogs_JSON_ReturnsLogs(t *testing.T) {
+func TestCancelRun_CascadeOnFinishedRun_CancelsChildrenOnly(t *testing.T) {
 	srv, _, runStore := newRunTestServer()
-	runID := uuid.New()
+	rootID := uuid.New()
+	childID := uuid.New()
 	runStore.runs = []domain.Run{
-		{ID: runID, Status: domain.RunStatusSuccess},
+		{ID: rootID, Status: domain.RunStatusSuccess},
+		{ID: childID, Status: domain.RunStatusRunning, ParentRunID: &rootID},
 	}
 	router := api.NewRouter(srv)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs", http.NoBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+rootID.String()+"/cancel", bytes.NewBufferString(`{"cascade":true}`))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
-
-	var body map[string]interface{}
-	err := json.NewDecoder(rec.Body).Decode(&body)
-	require.NoError(t, err)
-	assert.Equal(t, "success", body["status"])
-
-	logs := body["logs"].([]interface{})
-	assert.Len(t, logs, 2)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "success", resp["status"])
+	assert.Equal(t, []interface{}{childID.String()}, resp["cancelled_children"])
+
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Equal(t, domain.RunStatusSuccess, runStore.runs[0].Status)
+	assert.Equal(t, domain.RunStatusCancelled, runStore.runs[1].Status)
 }
 
-func TestGetRunLogs_SSE_TerminalRun_ReturnsAllLogsAndCloses(t *testing.T) {
+func TestCancelRun_WithoutCascade_LeavesChildrenRunning(t *testing.T) {
 	srv, _, runStore := newRunTestServer()
-	runID := uuid.New()
+	rootID := uuid.New()
 	runStore.runs = []domain.Run{
-		{ID: runID, Status: domain.RunStatusSuccess},
+		{ID: rootID, Status: domain.RunStatusRunning},
+		{ID: uuid.New(), Status: domain.RunStatusRunning, ParentRunID: &rootID},
 	}
 	router := api.NewRouter(srv)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs", http.NoBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+rootID.String()+"/cancel", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Equal(t, domain.RunStatusRunning, runStore.runs[1].Status)
+}
+
+func TestCancelRun_InvalidCascade_Returns400(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{{ID: runID, Status: domain.RunStatusRunning}}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+runID.String()+"/cancel?cascade=maybe", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestCancelRun_NotFound_Returns404(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+uuid.New().String()+"/cancel", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// --- Active runs / cancel-all ---
+
+func TestListActiveRuns_ReturnsPendingAndRunningOnly(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	pendingID, runningID := uuid.New(), uuid.New()
+	now := time.Now()
+	runStore.runs = []domain.Run{
+		{ID: pendingID, Status: domain.RunStatusPending, CreatedAt: now},
+		{ID: runningID, Status: domain.RunStatusRunning, CreatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), Status: domain.RunStatusSuccess, CreatedAt: now},
+		{ID: uuid.New(), Status: domain.RunStatusCancelled, CreatedAt: now},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/active", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Runs  []domain.Run `json:"runs"`
+		Total int          `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+	require.Len(t, resp.Runs, 2)
+	assert.Equal(t, pendingID, resp.Runs[0].ID, "newest first")
+	assert.Equal(t, runningID, resp.Runs[1].ID)
+}
+
+func TestCancelAllRuns_CancelsEveryActiveRun(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	pendingID, runningID, doneID := uuid.New(), uuid.New(), uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: pendingID, Status: domain.RunStatusPending},
+		{ID: runningID, Status: domain.RunStatusRunning},
+		{ID: doneID, Status: domain.RunStatusSuccess},
+	}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/cancel-all", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		Results   []api.CancelAllResult `json:"results"`
+		Cancelled int                   `json:"cancelled"`
+		Failed    int                   `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Cancelled)
+	assert.Equal(t, 0, resp.Failed)
+	require.Len(t, resp.Results, 2)
+	for _, result := range resp.Results {
+		assert.True(t, result.Cancelled)
+		assert.Empty(t, result.Error)
+	}
+
+	exec.mu.Lock()
+	assert.ElementsMatch(t, []string{pendingID.String(), runningID.String()}, exec.cancelled)
+	exec.mu.Unlock()
+
+	for _, run := range runStore.runs {
+		if run.ID == doneID {
+			assert.Equal(t, domain.RunStatusSuccess, run.Status, "finished runs are left alone")
+		} else {
+			assert.Equal(t, domain.RunStatusCancelled, run.Status)
+		}
+	}
+}
+
+func TestCancelAllRuns_ExecutorError_ReportedPerRun(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{{ID: runID, Status: domain.RunStatusRunning}}
+	srv.Executor = &mockExecutor{cancelErr: errors.New("runner unreachable")}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/cancel-all", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Results []api.CancelAllResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, runID.String(), resp.Results[0].RunID)
+	assert.True(t, resp.Results[0].Cancelled, "still marked cancelled")
+	assert.Contains(t, resp.Results[0].Error, "runner unreachable")
+}
+
+func TestCancelAllRuns_NonAdmin_Returns403(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runStore.runs = []domain.Run{{ID: uuid.New(), Status: domain.RunStatusRunning}}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/cancel-all", http.NoBody)
+	req = req.WithContext(plugins.ContextWithUser(req.Context(), &domain.UserIdentity{UserID: "bob", Roles: []string{"editor"}}))
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, exec.cancelled)
+	assert.Equal(t, domain.RunStatusRunning, runStore.runs[0].Status)
+}
+
+// --- Latest runs ---
+
+func postLatestRuns(t *testing.T, srv *api.Server, body string, user *domain.UserIdentity) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/latest", strings.NewReader(body))
+	if user != nil {
+		req = req.WithContext(plugins.ContextWithUser(req.Context(), user))
+	}
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLatestRuns_ReturnsOneRunPerPipeline(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	p1, p2, p3, idle := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	latest1, latest2, latest3 := uuid.New(), uuid.New(), uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: uuid.New(), PipelineID: p1, Status: domain.RunStatusFailed},
+		{ID: uuid.New(), PipelineID: p2, Status: domain.RunStatusSuccess},
+		{ID: latest1, PipelineID: p1, Status: domain.RunStatusSuccess},
+		{ID: latest2, PipelineID: p2, Status: domain.RunStatusRunning},
+		{ID: latest3, PipelineID: p3, Status: domain.RunStatusPending},
+	}
+
+	body := `{"pipeline_ids":["` + p1.String() + `","` + p2.String() + `","` + p3.String() + `","` + idle.String() + `","` + p1.String() + `"]}`
+	rec := postLatestRuns(t, srv, body, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp struct {
+		LatestRuns map[string]domain.Run `json:"latest_runs"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.LatestRuns, 3, "pipelines without runs are omitted")
+	assert.Equal(t, latest1, resp.LatestRuns[p1.String()].ID)
+	assert.Equal(t, latest2, resp.LatestRuns[p2.String()].ID)
+	assert.Equal(t, latest3, resp.LatestRuns[p3.String()].ID)
+}
+
+func TestLatestRuns_FiltersUnreadablePipelines(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	visible, hidden := uuid.New(), uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: uuid.New(), PipelineID: visible, Status: domain.RunStatusSuccess},
+		{ID: uuid.New(), PipelineID: hidden, Status: domain.RunStatusSuccess},
+	}
+	srv.Authorizer = &mockAuthorizer{allowedIDs: map[string]bool{visible.String(): true}}
+
+	body := `{"pipeline_ids":["` + visible.String() + `","` + hidden.String() + `"]}`
+	rec := postLatestRuns(t, srv, body, &domain.UserIdentity{UserID: "bob"})
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		LatestRuns map[string]domain.Run `json:"latest_runs"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Contains(t, resp.LatestRuns, visible.String())
+	assert.NotContains(t, resp.LatestRuns, hidden.String())
+}
+
+func TestLatestRuns_InvalidRequest_Returns400(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+
+	tooMany := make([]string, 501)
+	for i := range tooMany {
+		tooMany[i] = uuid.NewString()
+	}
+	tooManyBody, err := json.Marshal(api.LatestRunsRequest{PipelineIDs: tooMany})
+	require.NoError(t, err)
+
+	for name, body := range map[string]string{
+		"malformed": `{"pipeline_ids":`,
+		"not uuid":  `{"pipeline_ids":["orders"]}`,
+		"too many":  string(tooManyBody),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, http.StatusBadRequest, postLatestRuns(t, srv, body, nil).Code)
+		})
+	}
+}
+
+// --- Retry Run ---
+
+func TestRetryRun_FailedRun_CreatesAndSubmitsLinkedRun(t *testing.T) {
+	srv, pipelineStore, runStore := newRunTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+	}
+	originalID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: originalID, PipelineID: pipelineID, Status: domain.RunStatusFailed, Trigger: "manual",
+			Metadata: map[string]string{"backfill_date": "2026-01-01"}},
+	}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+originalID.String()+"/retry", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var resp map[string]interface{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, originalID.String(), resp["retry_of"])
+	assert.Equal(t, "pending", resp["status"])
+
+	require.Len(t, runStore.runs, 2)
+	retry := runStore.runs[1]
+	assert.Equal(t, resp["run_id"], retry.ID.String())
+	assert.Equal(t, pipelineID, retry.PipelineID)
+	assert.Equal(t, "retry:"+originalID.String(), retry.Trigger)
+	assert.Equal(t, originalID.String(), retry.Metadata["retry_of"])
+	assert.Equal(t, "2026-01-01", retry.Metadata["backfill_date"])
+	// The original run's metadata is copied, not shared.
+	assert.NotContains(t, runStore.runs[0].Metadata, "retry_of")
+
+	require.Equal(t, 1, exec.submitCount())
+	assert.Equal(t, retry.ID, exec.calls[0].RunID)
+	assert.Equal(t, pipelineID, exec.calls[0].PipelineID)
+}
+
+func TestRetryRun_ActiveRun_Returns409(t *testing.T) {
+	for _, status := range []domain.RunStatus{domain.RunStatusPending, domain.RunStatusRunning} {
+		t.Run(string(status), func(t *testing.T) {
+			srv, _, runStore := newRunTestServer()
+			runID := uuid.New()
+			runStore.runs = []domain.Run{{ID: runID, Status: status}}
+			exec := &mockExecutor{}
+			srv.Executor = exec
+			router := api.NewRouter(srv)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+runID.String()+"/retry", http.NoBody)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusConflict, rec.Code)
+			assert.Len(t, runStore.runs, 1)
+			assert.Equal(t, 0, exec.submitCount())
+		})
+	}
+}
+
+func TestRetryRun_NotFound_Returns404(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+uuid.New().String()+"/retry", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// --- Run Logs ---
+
+func TestGetRunLogs_JSON_ReturnsLogs(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusSuccess},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	err := json.NewDecoder(rec.Body).Decode(&body)
+	require.NoError(t, err)
+	assert.Equal(t, "success", body["status"])
+
+	logs := body["logs"].([]interface{})
+	assert.Len(t, logs, 2)
+}
+
+func TestGetRunLogs_SSE_TerminalRun_ReturnsAllLogsAndCloses(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusSuccess},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs", http.NoBody)
 	req.Header.Set("Accept", "text/event-stream")
 	rec := httptest.NewRecorder()
 	router.ServeHTTP(rec, req)
@@ -530,6 +1361,281 @@ func TestGetRunLogs_NotFound_Returns404(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
 
+// streamingExecutor relays a fixed set of log entries through StreamLogs and
+// then invokes onClose, simulating the runner finishing the run.
+type streamingExecutor struct {
+	mockExecutor
+	entries []api.LogEntry
+	onClose func()
+}
+
+func (e *streamingExecutor) StreamLogs(_ context.Context, _ string, send func(api.LogEntry) error) error {
+	for _, entry := range e.entries {
+		if err := send(entry); err != nil {
+			return err
+		}
+	}
+	if e.onClose != nil {
+		e.onClose()
+	}
+	return nil
+}
+
+func TestStreamRunLogs_RelaysLogsAndClosesOnTerminalStatus(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusRunning},
+	}
+	srv.Executor = &streamingExecutor{
+		entries: []api.LogEntry{
+			{Timestamp: "2026-02-12T14:00:00Z", Level: "info", Message: "reading source"},
+			{Timestamp: "2026-02-12T14:00:01Z", Level: "info", Message: "wrote 42 rows"},
+		},
+		onClose: func() {
+			_ = runStore.UpdateRunStatus(context.Background(), runID.String(), domain.RunStatusSuccess, nil, nil, nil)
+		},
+	}
+	srv.SSELimiter = api.NewSSELimiter()
+	router := api.NewRouter(srv)
+
+	srvHTTP := httptest.NewServer(router)
+	defer srvHTTP.Close()
+
+	resp, err := http.Get(srvHTTP.URL + "/api/v1/runs/" + runID.String() + "/logs/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// ReadAll returns only once the server closes the stream.
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), "reading source")
+	assert.Contains(t, string(body), "wrote 42 rows")
+	assert.Contains(t, string(body), "event: status")
+	assert.Contains(t, string(body), `"status":"success"`)
+}
+
+func TestStreamRunLogs_ExecutorWithoutStreaming_FallsBackToPolling(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusSuccess},
+	}
+	srv.Executor = &mockExecutor{}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs/stream", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Starting pipeline")
+	assert.Contains(t, rec.Body.String(), `"status":"success"`)
+}
+
+func TestStreamRunLogs_NotFound_Returns404(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+uuid.New().String()+"/logs/stream", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// --- Run log filtering ---
+
+// newLogFilterTestRouter serves a running run whose live logs mix levels.
+func newLogFilterTestRouter(t *testing.T) (http.Handler, string) {
+	t.Helper()
+	srv, _, runStore := newRunTestServer()
+	srv.Executor = &liveLogsExecutor{entries: []api.LogEntry{
+		{Timestamp: "2026-02-12T14:00:00Z", Level: "info", Message: "Starting pipeline"},
+		{Timestamp: "2026-02-12T14:00:01Z", Level: "error", Message: "S3 read timeout"},
+		{Timestamp: "2026-02-12T14:00:02Z", Level: "warn", Message: "Retrying after Timeout"},
+		{Timestamp: "2026-02-12T14:00:03Z", Level: "error", Message: "Merge failed"},
+		{Timestamp: "2026-02-12T14:00:04Z", Level: "info", Message: "Cleaning up"},
+	}}
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusRunning},
+	}
+	return api.NewRouter(srv), runID.String()
+}
+
+func getFilteredLogs(t *testing.T, router http.Handler, runID, query string) (messages []string, total int) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID+"/logs?"+query, http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Logs  []api.LogEntry `json:"logs"`
+		Total int            `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	messages = []string{}
+	for _, entry := range body.Logs {
+		messages = append(messages, entry.Message)
+	}
+	return messages, body.Total
+}
+
+func TestGetRunLogs_LevelFilter(t *testing.T) {
+	router, runID := newLogFilterTestRouter(t)
+
+	messages, total := getFilteredLogs(t, router, runID, "level=ERROR")
+	assert.Equal(t, []string{"S3 read timeout", "Merge failed"}, messages)
+	assert.Equal(t, 2, total)
+
+	messages, _ = getFilteredLogs(t, router, runID, "level=error,warn")
+	assert.Equal(t, []string{"S3 read timeout", "Retrying after Timeout", "Merge failed"}, messages)
+}
+
+func TestGetRunLogs_ContainsFilter(t *testing.T) {
+	router, runID := newLogFilterTestRouter(t)
+
+	messages, total := getFilteredLogs(t, router, runID, "contains=timeout")
+	assert.Equal(t, []string{"S3 read timeout", "Retrying after Timeout"}, messages)
+	assert.Equal(t, 2, total)
+
+	messages, _ = getFilteredLogs(t, router, runID, "level=error&contains=timeout")
+	assert.Equal(t, []string{"S3 read timeout"}, messages)
+}
+
+func TestGetRunLogs_Pagination(t *testing.T) {
+	router, runID := newLogFilterTestRouter(t)
+
+	messages, total := getFilteredLogs(t, router, runID, "limit=2&offset=1")
+	assert.Equal(t, []string{"S3 read timeout", "Retrying after Timeout"}, messages)
+	assert.Equal(t, 5, total)
+
+	messages, total = getFilteredLogs(t, router, runID, "level=info&limit=1&offset=1")
+	assert.Equal(t, []string{"Cleaning up"}, messages)
+	assert.Equal(t, 2, total)
+
+	messages, _ = getFilteredLogs(t, router, runID, "offset=10")
+	assert.Empty(t, messages)
+
+	// No pagination params: the whole log, not the default page size.
+	messages, _ = getFilteredLogs(t, router, runID, "")
+	assert.Len(t, messages, 5)
+}
+
+// --- Run log download ---
+
+// liveLogsExecutor serves a fixed set of live logs from GetLogs, as the
+// runner does for a run that is still executing.
+type liveLogsExecutor struct {
+	mockExecutor
+	entries []api.LogEntry
+}
+
+func (e *liveLogsExecutor) GetLogs(_ context.Context, _ string) ([]api.LogEntry, error) {
+	return e.entries, nil
+}
+
+func TestDownloadRunLogs_CompletedRun_Text(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusSuccess},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs/download", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="run-`+runID.String()+`.log"`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t,
+		"2026-02-12T14:00:00Z INFO Starting pipeline\n"+
+			"2026-02-12T14:00:01Z INFO Pipeline completed\n",
+		rec.Body.String())
+}
+
+func TestDownloadRunLogs_CompletedRun_JSON(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusFailed},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs/download?format=json", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="run-`+runID.String()+`.json"`, rec.Header().Get("Content-Disposition"))
+
+	var logs []api.LogEntry
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&logs))
+	require.Len(t, logs, 2)
+	assert.Equal(t, "Starting pipeline", logs[0].Message)
+}
+
+func TestDownloadRunLogs_RunningRun_UsesExecutorLogs(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	srv.Executor = &liveLogsExecutor{entries: []api.LogEntry{
+		{Timestamp: "2026-02-12T14:00:05Z", Level: "warn", Message: "live line"},
+	}}
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusRunning},
+	}
+	router := api.NewRouter(srv)
+
+	for _, tc := range []struct {
+		format string
+		want   string
+	}{
+		{"txt", "2026-02-12T14:00:05Z WARN live line\n"},
+		{"json", `[{"timestamp":"2026-02-12T14:00:05Z","level":"warn","message":"live line"}]` + "\n"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs/download?format="+tc.format, http.NoBody)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, tc.format)
+		assert.Equal(t, tc.want, rec.Body.String(), tc.format)
+	}
+}
+
+func TestDownloadRunLogs_InvalidFormat_Returns400(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusSuccess},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs/download?format=csv", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDownloadRunLogs_NotFound_Returns404(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+uuid.New().String()+"/logs/download", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
 // --- Cloud-credential plumbing into the executor ---
 
 // captureExecutor records the *domain.Run passed to Submit so tests can inspect
@@ -39,6 +39,8 @@ type UpdateScheduleRequest struct {
 func MountScheduleRoutes(r chi.Router, srv *Server) {
 	r.Get("/schedules", srv.HandleListSchedules)
 	r.Post("/schedules", srv.HandleCreateSchedule)
+	r.Get("/schedules/export", srv.HandleExportSchedules)
+	r.Post("/schedules/import", srv.HandleImportSchedules)
 	r.Get("/schedules/{scheduleID}", srv.HandleGetSchedule)
 	r.Put("/schedules/{scheduleID}", srv.HandleUpdateSchedule)
 	r.Delete("/schedules/{scheduleID}", srv.HandleDeleteSchedule)
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// maxScheduleImportItems caps the schedules accepted by one import.
+const maxScheduleImportItems = 1000
+
+// Per-item outcomes reported by HandleImportSchedules.
+const (
+	scheduleImportCreated   = "created"
+	scheduleImportUpdated   = "updated"
+	scheduleImportUnchanged = "unchanged"
+	scheduleImportInvalid   = "invalid"
+	scheduleImportError     = "error"
+)
+
+// ScheduleDocument is the YAML form of a set of schedules, produced by
+// GET /api/v1/schedules/export and accepted by POST /api/v1/schedules/import.
+type ScheduleDocument struct {
+	Schedules []ScheduleSpec `yaml:"schedules"`
+}
+
+// ScheduleSpec is one schedule in a ScheduleDocument. A schedule is identified
+// by its pipeline and cron expression; IDs and run state are not exported.
+type ScheduleSpec struct {
+	Namespace string `yaml:"namespace"`
+	Layer     string `yaml:"layer"`
+	Pipeline  string `yaml:"pipeline"`
+	Cron      string `yaml:"cron"`
+	Enabled   *bool  `yaml:"enabled,omitempty"` // default true
+}
+
+// ScheduleImportResult reports the outcome of one item in an import.
+// Results are returned in document order.
+type ScheduleImportResult struct {
+	Index      int    `json:"index"`
+	Namespace  string `json:"namespace"`
+	Layer      string `json:"layer"`
+	Pipeline   string `json:"pipeline"`
+	Cron       string `json:"cron"`
+	Status     string `json:"status"` // created, updated, unchanged, or invalid
+	ScheduleID string `json:"schedule_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// HandleExportSchedules returns every schedule as a YAML document, sorted by
+// pipeline then cron so repeated exports diff cleanly.
+// GET /api/v1/schedules/export?namespace=
+//
+// Schedules whose pipeline has been deleted are left out.
+func (s *Server) HandleExportSchedules(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+
+	pipelines, err := s.Pipelines.ListPipelines(r.Context(), PipelineFilter{Namespace: namespace})
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	byID := make(map[string]domain.Pipeline, len(pipelines))
+	for _, p := range pipelines {
+		byID[p.ID.String()] = p
+	}
+
+	schedules, err := s.Schedules.ListSchedules(r.Context())
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	doc := ScheduleDocument{Schedules: []ScheduleSpec{}}
+	for _, sched := range schedules {
+		p, ok := byID[sched.PipelineID.String()]
+		if !ok {
+			continue
+		}
+		enabled := sched.Enabled
+		doc.Schedules = append(doc.Schedules, ScheduleSpec{
+			Namespace: p.Namespace,
+			Layer:     string(p.Layer),
+			Pipeline:  p.Name,
+			Cron:      sched.CronExpr,
+			Enabled:   &enabled,
+		})
+	}
+	sort.Slice(doc.Schedules, func(i, j int) bool {
+		a, b := doc.Schedules[i], doc.Schedules[j]
+		if ka, kb := a.Namespace+"/"+a.Layer+"/"+a.Pipeline, b.Namespace+"/"+b.Layer+"/"+b.Pipeline; ka != kb {
+			return ka < kb
+		}
+		return a.Cron < b.Cron
+	})
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
+
+// HandleImportSchedules upserts the schedules in a YAML document (JSON is
+// accepted too, being valid YAML).
+// POST /api/v1/schedules/import
+//
+// Each item is matched to an existing schedule by pipeline and cron: a match
+// has its enabled flag brought in line, anything else is created. Schedules
+// missing from the document are left alone, so importing the same document
+// twice changes nothing the second time. Invalid items (bad cron, unknown
+// pipeline) are reported and skipped. A store error on one item is recorded
+// in that item's result and the rest are still processed, so the response
+// always describes exactly what was applied. Responds 200 with per-item
+// results.
+func (s *Server) HandleImportSchedules(w http.ResponseWriter, r *http.Request) {
+	var doc ScheduleDocument
+	dec := yaml.NewDecoder(r.Body)
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		errorJSON(w, "invalid schedule document: "+err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if len(doc.Schedules) == 0 {
+		errorJSON(w, "schedules must not be empty", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if len(doc.Schedules) > maxScheduleImportItems {
+		errorJSON(w, fmt.Sprintf("too many schedules (%d, max %d)", len(doc.Schedules), maxScheduleImportItems), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	existing, err := s.Schedules.ListSchedules(r.Context())
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	// pipeline ID + "|" + cron → schedule
+	index := make(map[string]domain.Schedule, len(existing))
+	for _, sched := range existing {
+		index[sched.PipelineID.String()+"|"+sched.CronExpr] = sched
+	}
+
+	results := make([]ScheduleImportResult, len(doc.Schedules))
+	for i, item := range doc.Schedules {
+		res := &results[i]
+		*res = ScheduleImportResult{Index: i, Namespace: item.Namespace, Layer: item.Layer, Pipeline: item.Pipeline, Cron: item.Cron}
+
+		if msg := validateScheduleSpec(item); msg != "" {
+			res.Status, res.Error = scheduleImportInvalid, msg
+			continue
+		}
+		pipeline, err := s.Pipelines.GetPipeline(r.Context(), item.Namespace, item.Layer, item.Pipeline)
+		if err != nil {
+			scheduleImportFailed(res, err)
+			continue
+		}
+		if pipeline == nil {
+			res.Status, res.Error = scheduleImportInvalid, "pipeline not found"
+			continue
+		}
+
+		enabled := true
+		if item.Enabled != nil {
+			enabled = *item.Enabled
+		}
+		key := pipeline.ID.String() + "|" + item.Cron
+
+		if sched, ok := index[key]; ok {
+			res.ScheduleID = sched.ID.String()
+			if sched.Enabled == enabled {
+				res.Status = scheduleImportUnchanged
+				continue
+			}
+			if _, err := s.Schedules.UpdateSchedule(r.Context(), sched.ID.String(), UpdateScheduleRequest{Enabled: &enabled}); err != nil {
+				scheduleImportFailed(res, err)
+				continue
+			}
+			sched.Enabled = enabled
+			index[key] = sched
+			res.Status = scheduleImportUpdated
+			continue
+		}
+
+		sched := &domain.Schedule{PipelineID: pipeline.ID, CronExpr: item.Cron, Enabled: enabled}
+		if err := s.Schedules.CreateSchedule(r.Context(), sched); err != nil {
+			scheduleImportFailed(res, err)
+			continue
+		}
+		index[key] = *sched
+		res.ScheduleID = sched.ID.String()
+		res.Status = scheduleImportCreated
+	}
+
+	counts := map[string]int{}
+	for _, res := range results {
+		counts[res.Status]++
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results":   results,
+		"created":   counts[scheduleImportCreated],
+		"updated":   counts[scheduleImportUpdated],
+		"unchanged": counts[scheduleImportUnchanged],
+		"failed":    counts[scheduleImportInvalid] + counts[scheduleImportError],
+	})
+}
+
+// scheduleImportFailed records a store error on an imported item. The cause
+// is logged rather than returned, like internalError.
+func scheduleImportFailed(res *ScheduleImportResult, err error) {
+	slog.Error("schedule import item failed", "index", res.Index, "error", err)
+	res.Status, res.Error = scheduleImportError, "internal error"
+}
+
+// validateScheduleSpec applies the POST /schedules rules to an imported item.
+// Returns "" when valid.
+func validateScheduleSpec(item ScheduleSpec) string {
+	if item.Namespace == "" || item.Layer == "" || item.Pipeline == "" || item.Cron == "" {
+		return "namespace, layer, pipeline, and cron are required"
+	}
+	if !validName(item.Namespace) || !validName(item.Pipeline) {
+		return "namespace and pipeline must be a lowercase slug (a-z, 0-9, hyphens, underscores; must start with a letter)"
+	}
+	if !domain.ValidLayer(item.Layer) {
+		return "layer must be bronze, silver, or gold"
+	}
+	if _, err := cronParser.Parse(item.Cron); err != nil {
+		return "invalid cron expression: " + err.Error()
+	}
+	return ""
+}
@@ -0,0 +1,219 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+type scheduleImportResponse struct {
+	Results   []api.ScheduleImportResult `json:"results"`
+	Created   int                        `json:"created"`
+	Updated   int                        `json:"updated"`
+	Unchanged int                        `json:"unchanged"`
+	Failed    int                        `json:"failed"`
+}
+
+func exportSchedules(t *testing.T, srv *api.Server, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/schedules/export"+query, http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func importSchedules(t *testing.T, srv *api.Server, doc string) scheduleImportResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/schedules/import", strings.NewReader(doc))
+	req.Header.Set("Content-Type", "application/yaml")
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp scheduleImportResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func seedSchedulePipelines(pipelineStore *memoryPipelineStore) (orders, revenue uuid.UUID) {
+	orders, revenue = uuid.New(), uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: orders, Namespace: "default", Layer: domain.LayerSilver, Name: "orders"},
+		{ID: revenue, Namespace: "finance", Layer: domain.LayerGold, Name: "revenue"},
+	}
+	return orders, revenue
+}
+
+func TestExportSchedules_ReturnsSortedYAML(t *testing.T) {
+	srv, pipelineStore, schedStore := newScheduleTestServer()
+	orders, revenue := seedSchedulePipelines(pipelineStore)
+	schedStore.schedules = []domain.Schedule{
+		{ID: uuid.New(), PipelineID: revenue, CronExpr: "0 6 * * *", Enabled: true},
+		{ID: uuid.New(), PipelineID: orders, CronExpr: "0 * * * *", Enabled: false},
+		{ID: uuid.New(), PipelineID: uuid.New(), CronExpr: "0 0 * * *", Enabled: true}, // pipeline deleted
+	}
+
+	rec := exportSchedules(t, srv, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+
+	var doc api.ScheduleDocument
+	require.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Len(t, doc.Schedules, 2)
+	assert.Equal(t, "default", doc.Schedules[0].Namespace)
+	assert.Equal(t, "orders", doc.Schedules[0].Pipeline)
+	require.NotNil(t, doc.Schedules[0].Enabled)
+	assert.False(t, *doc.Schedules[0].Enabled)
+	assert.Equal(t, "revenue", doc.Schedules[1].Pipeline)
+	assert.Equal(t, "0 6 * * *", doc.Schedules[1].Cron)
+}
+
+func TestExportSchedules_NamespaceFilter(t *testing.T) {
+	srv, pipelineStore, schedStore := newScheduleTestServer()
+	orders, revenue := seedSchedulePipelines(pipelineStore)
+	schedStore.schedules = []domain.Schedule{
+		{ID: uuid.New(), PipelineID: orders, CronExpr: "0 * * * *", Enabled: true},
+		{ID: uuid.New(), PipelineID: revenue, CronExpr: "0 6 * * *", Enabled: true},
+	}
+
+	rec := exportSchedules(t, srv, "?namespace=finance")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc api.ScheduleDocument
+	require.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Len(t, doc.Schedules, 1)
+	assert.Equal(t, "revenue", doc.Schedules[0].Pipeline)
+}
+
+func TestScheduleExportImport_RoundTripIsIdempotent(t *testing.T) {
+	src, srcPipelines, srcSchedules := newScheduleTestServer()
+	orders, revenue := seedSchedulePipelines(srcPipelines)
+	srcSchedules.schedules = []domain.Schedule{
+		{ID: uuid.New(), PipelineID: orders, CronExpr: "0 * * * *", Enabled: true},
+		{ID: uuid.New(), PipelineID: orders, CronExpr: "30 2 * * *", Enabled: false},
+		{ID: uuid.New(), PipelineID: revenue, CronExpr: "0 6 * * 1", Enabled: true},
+	}
+	rec := exportSchedules(t, src, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	exported := rec.Body.String()
+
+	// Import into an environment with the same pipelines but no schedules.
+	dst, dstPipelines, dstSchedules := newScheduleTestServer()
+	dstPipelines.pipelines = srcPipelines.pipelines
+
+	first := importSchedules(t, dst, exported)
+	assert.Equal(t, 3, first.Created)
+	assert.Equal(t, 0, first.Failed)
+	require.Len(t, dstSchedules.schedules, 3)
+
+	second := importSchedules(t, dst, exported)
+	assert.Equal(t, 0, second.Created)
+	assert.Equal(t, 0, second.Updated)
+	assert.Equal(t, 3, second.Unchanged)
+	assert.Len(t, dstSchedules.schedules, 3, "re-import must not duplicate schedules")
+
+	rec = exportSchedules(t, dst, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, exported, rec.Body.String())
+}
+
+func TestImportSchedules_UpdatesEnabledOnMatch(t *testing.T) {
+	srv, pipelineStore, schedStore := newScheduleTestServer()
+	orders, _ := seedSchedulePipelines(pipelineStore)
+	schedID := uuid.New()
+	schedStore.schedules = []domain.Schedule{{ID: schedID, PipelineID: orders, CronExpr: "0 * * * *", Enabled: true}}
+
+	resp := importSchedules(t, srv, `
+schedules:
+  - namespace: default
+    layer: silver
+    pipeline: orders
+    cron: "0 * * * *"
+    enabled: false
+`)
+	assert.Equal(t, 1, resp.Updated)
+	assert.Equal(t, schedID.String(), resp.Results[0].ScheduleID)
+	require.Len(t, schedStore.schedules, 1)
+	assert.False(t, schedStore.schedules[0].Enabled)
+}
+
+func TestImportSchedules_ReportsInvalidItems(t *testing.T) {
+	srv, pipelineStore, schedStore := newScheduleTestServer()
+	seedSchedulePipelines(pipelineStore)
+
+	resp := importSchedules(t, srv, `
+schedules:
+  - {namespace: default, layer: silver, pipeline: orders, cron: "not a cron"}
+  - {namespace: default, layer: silver, pipeline: missing, cron: "0 * * * *"}
+  - {namespace: default, layer: platinum, pipeline: orders, cron: "0 * * * *"}
+  - {namespace: default, layer: silver, pipeline: orders, cron: "15 * * * *"}
+`)
+	assert.Equal(t, 1, resp.Created)
+	assert.Equal(t, 3, resp.Failed)
+	require.Len(t, resp.Results, 4)
+	assert.Equal(t, "invalid", resp.Results[0].Status)
+	assert.Contains(t, resp.Results[0].Error, "invalid cron expression")
+	assert.Equal(t, "pipeline not found", resp.Results[1].Error)
+	assert.Equal(t, "invalid", resp.Results[2].Status)
+	assert.Equal(t, "created", resp.Results[3].Status)
+	require.Len(t, schedStore.schedules, 1)
+	assert.True(t, schedStore.schedules[0].Enabled, "enabled defaults to true")
+}
+
+// failingScheduleStore fails CreateSchedule for one cron expression.
+type failingScheduleStore struct {
+	*memoryScheduleStore
+	failCron string
+}
+
+func (f *failingScheduleStore) CreateSchedule(ctx context.Context, schedule *domain.Schedule) error {
+	if schedule.CronExpr == f.failCron {
+		return errors.New("connection reset")
+	}
+	return f.memoryScheduleStore.CreateSchedule(ctx, schedule)
+}
+
+func TestImportSchedules_StoreErrorMidway_ReportsItemAndContinues(t *testing.T) {
+	srv, pipelineStore, schedStore := newScheduleTestServer()
+	seedSchedulePipelines(pipelineStore)
+	srv.Schedules = &failingScheduleStore{memoryScheduleStore: schedStore, failCron: "15 * * * *"}
+
+	resp := importSchedules(t, srv, `
+schedules:
+  - {namespace: default, layer: silver, pipeline: orders, cron: "0 * * * *"}
+  - {namespace: default, layer: silver, pipeline: orders, cron: "15 * * * *"}
+  - {namespace: finance, layer: gold, pipeline: revenue, cron: "0 0 * * *"}
+`)
+	assert.Equal(t, 2, resp.Created)
+	assert.Equal(t, 1, resp.Failed)
+	require.Len(t, resp.Results, 3)
+	assert.Equal(t, "created", resp.Results[0].Status)
+	assert.Equal(t, "error", resp.Results[1].Status)
+	assert.Equal(t, "internal error", resp.Results[1].Error)
+	assert.Empty(t, resp.Results[1].ScheduleID)
+	assert.Equal(t, "created", resp.Results[2].Status)
+	assert.Len(t, schedStore.schedules, 2)
+}
+
+func TestImportSchedules_MalformedDocument_Returns400(t *testing.T) {
+	srv, _, _ := newScheduleTestServer()
+
+	for _, doc := range []string{"", "schedules: []", "schedules: [", "schedule:\n  - cron: x"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/schedules/import", strings.NewReader(doc))
+		rec := httptest.NewRecorder()
+		api.NewRouter(srv).ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, doc)
+	}
+}
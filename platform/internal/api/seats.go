@@ -0,0 +1,32 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/rat-data/rat/platform/internal/plugins"
+)
+
+// seatEnforcement rejects authenticated users beyond the license seat limit
+// with 403 SEAT_LIMIT_EXCEEDED. Users that already hold a seat continue
+// normally. Requests without a plugin user (API keys, no auth) are not
+// seat-counted. s.Seats is loaded per request so main.go can (un)wire it
+// when the enforcement plugin comes and goes.
+func (s *Server) seatEnforcement(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seats := s.Seats.Load()
+		user := plugins.UserFromContext(r.Context())
+		if seats == nil || user == nil || user.UserID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !seats.Admit(r.Context(), user.UserID) {
+			slog.Warn("license seat limit reached, user refused", "user_id", user.UserID, "seat_limit", seats.Limit())
+			errorJSON(w, fmt.Sprintf("license seat limit reached (%d seats in use); ask an administrator to free a seat or upgrade the license", seats.Limit()),
+				"SEAT_LIMIT_EXCEEDED", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,67 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSeatTestRouter returns a router whose auth resolves the X-Test-User
+// header to a plugin user, as the auth plugin would.
+func newSeatTestRouter(seats *plugins.SeatTracker) http.Handler {
+	srv, _, _ := newTriggerTestServer()
+	srv.Seats.Store(seats)
+	srv.Auth = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := r.Header.Get("X-Test-User"); id != "" {
+				r = r.WithContext(plugins.ContextWithUser(r.Context(), &domain.UserIdentity{UserID: id}))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	return api.NewRouter(srv)
+}
+
+func getFeaturesAs(router http.Handler, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/features", http.NoBody)
+	req.Header.Set("X-Test-User", user)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSeatEnforcement_LimitOne_SecondUserBlocked(t *testing.T) {
+	router := newSeatTestRouter(plugins.NewSeatTracker(1, 0))
+
+	assert.Equal(t, http.StatusOK, getFeaturesAs(router, "alice").Code)
+
+	rec := getFeaturesAs(router, "bob")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var body api.APIError
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "SEAT_LIMIT_EXCEEDED", body.Error.Code)
+	assert.Contains(t, body.Error.Message, "seat limit")
+
+	assert.Equal(t, http.StatusOK, getFeaturesAs(router, "alice").Code, "existing seat holder continues")
+}
+
+func TestSeatEnforcement_NoUser_NotCounted(t *testing.T) {
+	router := newSeatTestRouter(plugins.NewSeatTracker(1, 0))
+
+	assert.Equal(t, http.StatusOK, getFeaturesAs(router, "").Code)
+	assert.Equal(t, http.StatusOK, getFeaturesAs(router, "alice").Code)
+}
+
+func TestSeatEnforcement_NilSeats_Unlimited(t *testing.T) {
+	router := newSeatTestRouter(nil)
+
+	assert.Equal(t, http.StatusOK, getFeaturesAs(router, "alice").Code)
+	assert.Equal(t, http.StatusOK, getFeaturesAs(router, "bob").Code)
+}
@@ -177,6 +177,11 @@ func (s *Server) HandleTransferOwnership(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Invalidate pipeline cache after the owner change.
+	if s.PipelineCache != nil {
+		s.PipelineCache.Delete(pipelineCacheKey(pipeline.Namespace, string(pipeline.Layer), pipeline.Name))
+	}
+
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"transferred": true,
 		"resource_id": req.ResourceID,
@@ -3,6 +3,7 @@ package api
 import (
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
 	"sync/atomic"
 )
@@ -12,25 +13,55 @@ const (
 	// MaxSSEDurationSeconds is the maximum lifetime of a single SSE connection (30 minutes).
 	MaxSSEDurationSeconds = 30 * 60
 
-	// MaxSSEPerIP is the maximum number of concurrent SSE connections from a single IP.
+	// MaxSSEPerIP is the default maximum number of concurrent SSE connections
+	// from a single IP. Override with SSE_MAX_PER_IP.
 	MaxSSEPerIP = 10
 
-	// MaxSSEGlobal is the global cap on concurrent SSE connections across all clients.
+	// MaxSSEGlobal is the default global cap on concurrent SSE connections
+	// across all clients. Override with SSE_MAX_GLOBAL.
 	MaxSSEGlobal = 1000
+
+	// SSERetryAfterSeconds is the Retry-After hint sent with a 429 when an SSE
+	// cap is hit. Streams are long-lived, so slots free up slowly; a client
+	// reconnecting sooner would only be rejected again.
+	SSERetryAfterSeconds = 10
 )
 
 // SSELimiter tracks concurrent SSE connections per IP and globally.
 // It uses atomic counters for the global cap and a mutex-protected map for per-IP tracking.
+// The per-IP cap keeps one client from exhausting the global pool.
 type SSELimiter struct {
+	maxPerIP  int64
+	maxGlobal int64
+
 	globalCount atomic.Int64
 	mu          sync.Mutex
 	perIP       map[string]*atomic.Int64
+
+	// Rejected Acquire calls since process start, by the cap that was hit.
+	rejectedPerIP  atomic.Uint64
+	rejectedGlobal atomic.Uint64
 }
 
-// NewSSELimiter creates a new SSE connection limiter.
+// NewSSELimiter creates a new SSE connection limiter with the default caps
+// (MaxSSEPerIP, MaxSSEGlobal).
 func NewSSELimiter() *SSELimiter {
+	return NewSSELimiterWithLimits(MaxSSEPerIP, MaxSSEGlobal)
+}
+
+// NewSSELimiterWithLimits creates an SSE connection limiter with explicit
+// caps. A non-positive value falls back to the default for that cap.
+func NewSSELimiterWithLimits(maxPerIP, maxGlobal int) *SSELimiter {
+	if maxPerIP <= 0 {
+		maxPerIP = MaxSSEPerIP
+	}
+	if maxGlobal <= 0 {
+		maxGlobal = MaxSSEGlobal
+	}
 	return &SSELimiter{
-		perIP: make(map[string]*atomic.Int64),
+		maxPerIP:  int64(maxPerIP),
+		maxGlobal: int64(maxGlobal),
+		perIP:     make(map[string]*atomic.Int64),
 	}
 }
 
@@ -39,7 +70,8 @@ func NewSSELimiter() *SSELimiter {
 // On success, the caller MUST call Release when the connection ends.
 func (l *SSELimiter) Acquire(ip string) bool {
 	// Check global limit first (cheap atomic check).
-	if l.globalCount.Load() >= MaxSSEGlobal {
+	if l.globalCount.Load() >= l.maxGlobal {
+		l.rejectedGlobal.Add(1)
 		return false
 	}
 
@@ -52,7 +84,8 @@ func (l *SSELimiter) Acquire(ip string) bool {
 	}
 	l.mu.Unlock()
 
-	if counter.Load() >= int64(MaxSSEPerIP) {
+	if counter.Load() >= l.maxPerIP {
+		l.rejectedPerIP.Add(1)
 		return false
 	}
 
@@ -61,10 +94,15 @@ func (l *SSELimiter) Acquire(ip string) bool {
 	ipCount := counter.Add(1)
 	globalCount := l.globalCount.Add(1)
 
-	if ipCount > int64(MaxSSEPerIP) || globalCount > MaxSSEGlobal {
+	if ipCount > l.maxPerIP || globalCount > l.maxGlobal {
 		// Roll back — we exceeded the limit in a race.
 		counter.Add(-1)
 		l.globalCount.Add(-1)
+		if ipCount > l.maxPerIP {
+			l.rejectedPerIP.Add(1)
+		} else {
+			l.rejectedGlobal.Add(1)
+		}
 		return false
 	}
 
@@ -109,6 +147,41 @@ func (l *SSELimiter) IPCount(ip string) int64 {
 	return counter.Load()
 }
 
+// ClientCount returns the number of distinct IPs holding at least one SSE
+// connection.
+func (l *SSELimiter) ClientCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.perIP)
+}
+
+// Limits returns the configured per-IP and global caps.
+func (l *SSELimiter) Limits() (perIP, global int) {
+	return int(l.maxPerIP), int(l.maxGlobal)
+}
+
+// Rejections returns how many connections were refused since process start
+// because the per-IP or the global cap was reached.
+func (l *SSELimiter) Rejections() (perIP, global uint64) {
+	return l.rejectedPerIP.Load(), l.rejectedGlobal.Load()
+}
+
+// acquireSSE registers an SSE connection for the request's client IP. When a
+// cap is hit it writes 429 with a Retry-After hint and returns ok = false.
+// Otherwise the caller must invoke release when the stream ends.
+func (s *Server) acquireSSE(w http.ResponseWriter, r *http.Request) (release func(), ok bool) {
+	if s.SSELimiter == nil {
+		return func() {}, true
+	}
+	ip := clientIP(r)
+	if !s.SSELimiter.Acquire(ip) {
+		w.Header().Set("Retry-After", strconv.Itoa(SSERetryAfterSeconds))
+		errorJSON(w, "too many SSE connections", "RESOURCE_EXHAUSTED", http.StatusTooManyRequests)
+		return nil, false
+	}
+	return func() { s.SSELimiter.Release(ip) }, true
+}
+
 // clientIP returns the request's client IP by stripping the port from
 // r.RemoteAddr. realIPMiddleware (see realip.go) is responsible for having
 // resolved RemoteAddr from trusted-proxy forwarded headers upstream — callers
@@ -111,6 +111,31 @@ func TestSSELimiter_ConcurrentAccess(t *testing.T) {
 	assert.Equal(t, int64(0), limiter.GlobalCount(), "all connections should be released")
 }
 
+func TestSSELimiter_WithLimits_CapsSingleIPOthersConnect(t *testing.T) {
+	limiter := api.NewSSELimiterWithLimits(2, 5)
+
+	assert.True(t, limiter.Acquire("10.0.0.1"))
+	assert.True(t, limiter.Acquire("10.0.0.1"))
+	assert.False(t, limiter.Acquire("10.0.0.1"), "third connection from the same IP should be refused")
+
+	// Other clients still get the remaining global slots.
+	assert.True(t, limiter.Acquire("10.0.0.2"))
+	assert.True(t, limiter.Acquire("10.0.0.2"))
+	assert.True(t, limiter.Acquire("10.0.0.3"))
+	assert.False(t, limiter.Acquire("10.0.0.4"), "global cap of 5 reached")
+
+	assert.Equal(t, 3, limiter.ClientCount())
+	perIP, global := limiter.Rejections()
+	assert.Equal(t, uint64(1), perIP)
+	assert.Equal(t, uint64(1), global)
+}
+
+func TestSSELimiter_WithLimits_NonPositiveFallsBackToDefaults(t *testing.T) {
+	perIP, global := api.NewSSELimiterWithLimits(0, -1).Limits()
+	assert.Equal(t, api.MaxSSEPerIP, perIP)
+	assert.Equal(t, api.MaxSSEGlobal, global)
+}
+
 // --- SSE endpoint integration tests ---
 
 func TestSSE_PerIPLimit_Returns429(t *testing.T) {
@@ -330,6 +355,74 @@ func TestSSE_JSONFallback_NotAffectedByLimiter(t *testing.T) {
 	}
 }
 
+func TestSSE_ConfiguredPerIPLimit_Returns429WithRetryAfter(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	limiter := api.NewSSELimiterWithLimits(1, 100)
+	srv.SSELimiter = limiter
+
+	runID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: runID, Status: domain.RunStatusRunning},
+	}
+	router := api.NewRouter(srv)
+
+	// Hold the only slot 10.0.0.1 is allowed.
+	require.True(t, limiter.Acquire("10.0.0.1"))
+	defer limiter.Release("10.0.0.1")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs/stream", http.NoBody)
+	req.RemoteAddr = "10.0.0.1:1234"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, itoa(api.SSERetryAfterSeconds), rec.Header().Get("Retry-After"))
+
+	// Another client can still stream.
+	ctx, cancel := context.WithCancel(context.Background())
+	req2 := httptest.NewRequest(http.MethodGet, "/api/v1/runs/"+runID.String()+"/logs", http.NoBody)
+	req2 = req2.WithContext(ctx)
+	req2.Header.Set("Accept", "text/event-stream")
+	req2.RemoteAddr = "10.0.0.2:1234"
+	rec2 := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(rec2, req2)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, "text/event-stream", rec2.Result().Header.Get("Content-Type"))
+}
+
+func TestSSE_MetricsExposeUsage(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+	limiter := api.NewSSELimiterWithLimits(1, 50)
+	srv.SSELimiter = limiter
+	router := api.NewRouter(srv)
+
+	require.True(t, limiter.Acquire("10.0.0.1"))
+	require.True(t, limiter.Acquire("10.0.0.2"))
+	require.False(t, limiter.Acquire("10.0.0.2"))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "ratd_sse_connections_active 2\n")
+	assert.Contains(t, body, "ratd_sse_clients_active 2\n")
+	assert.Contains(t, body, `ratd_sse_connections_limit{scope="per_ip"} 1`)
+	assert.Contains(t, body, `ratd_sse_connections_limit{scope="global"} 50`)
+	assert.Contains(t, body, `ratd_sse_rejected_total{scope="per_ip"} 1`)
+
+	limiter.Release("10.0.0.1")
+	limiter.Release("10.0.0.2")
+}
+
 // itoa is a quick int-to-string helper for test IPs.
 func itoa(n int) string {
 	return fmt.Sprintf("%d", n)
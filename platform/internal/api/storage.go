@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -22,6 +23,9 @@ type FileInfo struct {
 	Modified  time.Time `json:"modified"`
 	Type      string    `json:"type"` // pipeline-sql, config, meta, doc, test, hook
 	VersionID string    `json:"version_id,omitempty"`
+	// ETag changes whenever the object's content does. Set by ListFiles only;
+	// kept out of API responses.
+	ETag string `json:"-"`
 }
 
 // FileContent represents a file's content and metadata.
@@ -33,19 +37,42 @@ type FileContent struct {
 	VersionID string    `json:"version_id,omitempty"`
 }
 
+// FileReader streams an object's content without loading it into memory.
+// ReadAt and Seek let format readers jump around (Parquet reads its footer
+// first).
+type FileReader interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
 // WriteFileRequest is the JSON body for PUT /api/v1/files/*.
 type WriteFileRequest struct {
 	Content string `json:"content"`
 }
 
+// ErrFileNotFound is returned by StorageStore copy operations when the source
+// object (or the requested version of it) does not exist.
+var ErrFileNotFound = errors.New("file not found")
+
 // StorageStore defines the persistence interface for S3 file operations.
+//
+// CopyFile and CopyFileVersion copy server-side — the bytes never pass
+// through ratd — and return the version ID of the new destination object.
+// OpenFile returns nil, nil if the object does not exist; the caller closes
+// the reader.
 type StorageStore interface {
 	ListFiles(ctx context.Context, prefix string) ([]FileInfo, error)
 	ReadFile(ctx context.Context, path string) (*FileContent, error)
+	OpenFile(ctx context.Context, path string) (FileReader, error)
 	WriteFile(ctx context.Context, path string, content []byte) (versionID string, err error)
 	DeleteFile(ctx context.Context, path string) error
 	StatFile(ctx context.Context, path string) (*FileInfo, error)
 	ReadFileVersion(ctx context.Context, path, versionID string) (*FileContent, error)
+	StatFileVersion(ctx context.Context, path, versionID string) (*FileInfo, error)
+	CopyFile(ctx context.Context, srcPath, dstPath string) (versionID string, err error)
+	CopyFileVersion(ctx context.Context, srcPath, srcVersionID, dstPath string) (versionID string, err error)
 }
 
 // MountStorageRoutes registers file/storage endpoints on the router.
@@ -221,6 +248,7 @@ func (s *Server) HandleWriteFile(w http.ResponseWriter, r *http.Request) {
 		if s.PipelineCache != nil {
 			s.PipelineCache.Delete(pipelineCacheKey(pipelineRef.Namespace, pipelineRef.Layer, pipelineRef.Name))
 		}
+		s.invalidatePreview(pipelineRef.Namespace, pipelineRef.Layer, pipelineRef.Name)
 	}
 
 	// Publish file_uploaded event (best-effort).
@@ -280,6 +308,9 @@ func (s *Server) HandleDeleteFile(w http.ResponseWriter, r *http.Request) {
 		internalError(w, "internal error", err)
 		return
 	}
+	if pipelineRef := parsePipelinePath(path); pipelineRef != nil {
+		s.invalidatePreview(pipelineRef.Namespace, pipelineRef.Layer, pipelineRef.Name)
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -336,6 +367,7 @@ func (s *Server) HandleUploadFile(w http.ResponseWriter, r *http.Request) {
 		if s.PipelineCache != nil {
 			s.PipelineCache.Delete(pipelineCacheKey(pipelineRef.Namespace, pipelineRef.Layer, pipelineRef.Name))
 		}
+		s.invalidatePreview(pipelineRef.Namespace, pipelineRef.Layer, pipelineRef.Name)
 	}
 
 	// Publish file_uploaded event (best-effort).
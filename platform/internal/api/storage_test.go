@@ -3,6 +3,7 @@ package api_test
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"encoding/json"
 	"fmt"
 	"mime/multipart"
@@ -21,8 +22,13 @@ import (
 
 // memoryStorageStore is an in-memory StorageStore for tests.
 type memoryStorageStore struct {
-	mu    sync.Mutex
-	files map[string][]byte // path → content
+	mu       sync.Mutex
+	files    map[string][]byte // path → content
+	versions map[string][]byte // path + "@" + versionID → content; falls back to files
+	copies   int               // server-side copies made via CopyFile/CopyFileVersion
+	reads    int               // ReadFile calls
+	opens    int               // OpenFile calls
+	writeErr error             // returned by WriteFile when set
 }
 
 func newMemoryStorageStore() *memoryStorageStore {
@@ -40,6 +46,7 @@ func (m *memoryStorageStore) ListFiles(_ context.Context, prefix string) ([]api.
 				Path:     path,
 				Size:     int64(len(content)),
 				Modified: time.Now(),
+				ETag:     fmt.Sprintf("%x", md5.Sum(content)),
 			})
 		}
 	}
@@ -49,6 +56,7 @@ func (m *memoryStorageStore) ListFiles(_ context.Context, prefix string) ([]api.
 func (m *memoryStorageStore) ReadFile(_ context.Context, path string) (*api.FileContent, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.reads++
 
 	content, ok := m.files[path]
 	if !ok {
@@ -62,10 +70,33 @@ func (m *memoryStorageStore) ReadFile(_ context.Context, path string) (*api.File
 	}, nil
 }
 
+// memoryFileReader is an api.FileReader over an in-memory copy of a file.
+type memoryFileReader struct {
+	*bytes.Reader
+}
+
+func (memoryFileReader) Close() error { return nil }
+
+func (m *memoryStorageStore) OpenFile(_ context.Context, path string) (api.FileReader, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.opens++
+
+	content, ok := m.files[path]
+	if !ok {
+		return nil, nil
+	}
+	return memoryFileReader{bytes.NewReader(content)}, nil
+}
+
 func (m *memoryStorageStore) WriteFile(_ context.Context, path string, content []byte) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if m.writeErr != nil {
+		return "", m.writeErr
+	}
+
 	m.files[path] = content
 	return "mock-version-id", nil
 }
@@ -90,7 +121,10 @@ func (m *memoryStorageStore) ReadFileVersion(_ context.Context, path, versionID
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	content, ok := m.files[path]
+	content, ok := m.versions[path+"@"+versionID]
+	if !ok {
+		content, ok = m.files[path]
+	}
 	if !ok {
 		return nil, nil
 	}
@@ -103,6 +137,45 @@ func (m *memoryStorageStore) ReadFileVersion(_ context.Context, path, versionID
 	}, nil
 }
 
+func (m *memoryStorageStore) StatFileVersion(_ context.Context, path, versionID string) (*api.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	content, ok := m.versions[path+"@"+versionID]
+	if !ok {
+		content, ok = m.files[path]
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &api.FileInfo{
+		Path:      path,
+		Size:      int64(len(content)),
+		Modified:  time.Now(),
+		VersionID: versionID,
+	}, nil
+}
+
+func (m *memoryStorageStore) CopyFile(ctx context.Context, srcPath, dstPath string) (string, error) {
+	return m.CopyFileVersion(ctx, srcPath, "", dstPath)
+}
+
+func (m *memoryStorageStore) CopyFileVersion(_ context.Context, srcPath, srcVersionID, dstPath string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	content, ok := m.versions[srcPath+"@"+srcVersionID]
+	if !ok {
+		content, ok = m.files[srcPath]
+	}
+	if !ok {
+		return "", fmt.Errorf("copy %s: %w", srcPath, api.ErrFileNotFound)
+	}
+	m.files[dstPath] = content
+	m.copies++
+	return "mock-version-id", nil
+}
+
 func (m *memoryStorageStore) DeleteFile(_ context.Context, path string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// defaultStuckRunAge is the ?older_than= used by GET /runs/stuck when none is given.
+const defaultStuckRunAge = 30 * time.Minute
+
+// forceFailMessage is the error recorded on a run failed via force-fail.
+const forceFailMessage = "force-failed by operator"
+
+// maxForceFailReasonLength caps the optional reason appended to forceFailMessage.
+const maxForceFailReasonLength = 500
+
+// ForceFailRunRequest is the optional JSON body for POST /runs/{runID}/force-fail.
+type ForceFailRunRequest struct {
+	Reason string `json:"reason"`
+}
+
+// HandleListStuckRuns returns pending and running runs created more than
+// ?older_than= ago (Go duration, default 30m), oldest first. These are the
+// runs the reaper would eventually time out; listing them lets an operator
+// act before it does.
+func (s *Server) HandleListStuckRuns(w http.ResponseWriter, r *http.Request) {
+	olderThan := defaultStuckRunAge
+	if v := r.URL.Query().Get("older_than"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			errorJSON(w, "older_than must be a positive duration like 30m or 2h", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		olderThan = d
+	}
+	cutoff := time.Now().Add(-olderThan)
+
+	running, err := s.Runs.ListStuckRuns(r.Context(), cutoff)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	pending, err := s.Runs.ListStuckPendingRuns(r.Context(), cutoff)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	runs := append(running, pending...)
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreatedAt.Before(runs[j].CreatedAt)
+	})
+	runs = filterRunsByPipelineAccess(r.Context(), s, runs, "read")
+	if runs == nil {
+		runs = []domain.Run{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"runs":       runs,
+		"total":      len(runs),
+		"older_than": olderThan.String(),
+	})
+}
+
+// HandleForceFailRun marks a pending or running run failed, for runs whose
+// runner is gone and will never report back. The executor is asked to cancel
+// the run first; that is best-effort and its failure doesn't block the status
+// update. An optional {"reason": "..."} is appended to the recorded error.
+// Admin only.
+func (s *Server) HandleForceFailRun(w http.ResponseWriter, r *http.Request) {
+	if !s.requireAdmin(w, r) {
+		return
+	}
+	runID := chi.URLParam(r, "runID")
+
+	var req ForceFailRunRequest
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if len(req.Reason) > maxForceFailReasonLength {
+		errorJSON(w, "reason too long", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	run, err := s.Runs.GetRun(r.Context(), runID)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if run == nil {
+		errorJSON(w, "run not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+	if run.Status != domain.RunStatusPending && run.Status != domain.RunStatusRunning {
+		errorJSON(w, "run is not pending or running (status: "+string(run.Status)+")", "ALREADY_EXISTS", http.StatusConflict)
+		return
+	}
+
+	cancelErr := ""
+	if s.Executor != nil {
+		if err := s.Executor.Cancel(r.Context(), runID); err != nil {
+			slog.Warn("force-fail: executor cancel failed", "run_id", runID, "error", err)
+			cancelErr = err.Error()
+		}
+	}
+
+	msg := forceFailMessage
+	if req.Reason != "" {
+		msg += ": " + req.Reason
+	}
+	if err := s.Runs.UpdateRunStatus(r.Context(), runID, domain.RunStatusFailed, &msg, nil, nil); err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	slog.Info("run force-failed", "run_id", runID, "previous_status", run.Status)
+
+	resp := map[string]string{
+		"run_id": runID,
+		"status": string(domain.RunStatusFailed),
+		"error":  msg,
+	}
+	if cancelErr != "" {
+		resp["cancel_error"] = cancelErr
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,164 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+)
+
+type stuckRunsResponse struct {
+	Runs      []domain.Run `json:"runs"`
+	Total     int          `json:"total"`
+	OlderThan string       `json:"older_than"`
+}
+
+func getStuckRuns(t *testing.T, srv *api.Server, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs/stuck"+query, http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListStuckRuns_ReturnsOldActiveRunsOnly(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	now := time.Now()
+	stuckRunning, stuckPending := uuid.New(), uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: stuckRunning, Status: domain.RunStatusRunning, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: stuckPending, Status: domain.RunStatusPending, CreatedAt: now.Add(-45 * time.Minute)},
+		{ID: uuid.New(), Status: domain.RunStatusRunning, CreatedAt: now.Add(-5 * time.Minute)},
+		{ID: uuid.New(), Status: domain.RunStatusFailed, CreatedAt: now.Add(-3 * time.Hour)},
+	}
+
+	rec := getStuckRuns(t, srv, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp stuckRunsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+	assert.Equal(t, "30m0s", resp.OlderThan)
+	require.Len(t, resp.Runs, 2)
+	assert.Equal(t, stuckRunning, resp.Runs[0].ID, "oldest first")
+	assert.Equal(t, stuckPending, resp.Runs[1].ID)
+}
+
+func TestListStuckRuns_OlderThanNarrowsResult(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	now := time.Now()
+	oldID := uuid.New()
+	runStore.runs = []domain.Run{
+		{ID: oldID, Status: domain.RunStatusRunning, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: uuid.New(), Status: domain.RunStatusPending, CreatedAt: now.Add(-45 * time.Minute)},
+	}
+
+	rec := getStuckRuns(t, srv, "?older_than=1h")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp stuckRunsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Runs, 1)
+	assert.Equal(t, oldID, resp.Runs[0].ID)
+}
+
+func TestListStuckRuns_InvalidOlderThan_Returns400(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+
+	for _, v := range []string{"soon", "-5m", "0s"} {
+		rec := getStuckRuns(t, srv, "?older_than="+v)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, v)
+	}
+}
+
+func TestListStuckRuns_NoneStuck_ReturnsEmptyList(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+
+	rec := getStuckRuns(t, srv, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"runs":[]`)
+}
+
+func postForceFail(t *testing.T, srv *api.Server, runID, body string, user *domain.UserIdentity) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs/"+runID+"/force-fail", strings.NewReader(body))
+	if user != nil {
+		req = req.WithContext(plugins.ContextWithUser(req.Context(), user))
+	}
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestForceFailRun_StuckRun_CancelsAndMarksFailed(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{{ID: runID, Status: domain.RunStatusRunning, CreatedAt: time.Now().Add(-3 * time.Hour)}}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+
+	rec := postForceFail(t, srv, runID.String(), `{"reason":"runner node lost"}`, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	assert.Equal(t, []string{runID.String()}, exec.cancelled)
+	assert.Equal(t, domain.RunStatusFailed, runStore.runs[0].Status)
+	require.NotNil(t, runStore.runs[0].Error)
+	assert.Equal(t, "force-failed by operator: runner node lost", *runStore.runs[0].Error)
+}
+
+func TestForceFailRun_ExecutorCancelFails_StillMarksFailed(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{{ID: runID, Status: domain.RunStatusPending, CreatedAt: time.Now().Add(-time.Hour)}}
+	srv.Executor = &mockExecutor{cancelErr: errors.New("runner unreachable")}
+
+	rec := postForceFail(t, srv, runID.String(), "", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "failed", resp["status"])
+	assert.Contains(t, resp["cancel_error"], "runner unreachable")
+	assert.Equal(t, domain.RunStatusFailed, runStore.runs[0].Status)
+}
+
+func TestForceFailRun_FinishedRun_Returns409(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{{ID: runID, Status: domain.RunStatusSuccess}}
+
+	rec := postForceFail(t, srv, runID.String(), "", nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, domain.RunStatusSuccess, runStore.runs[0].Status)
+}
+
+func TestForceFailRun_UnknownRun_Returns404(t *testing.T) {
+	srv, _, _ := newRunTestServer()
+
+	rec := postForceFail(t, srv, uuid.New().String(), "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestForceFailRun_NonAdmin_Returns403(t *testing.T) {
+	srv, _, runStore := newRunTestServer()
+	runID := uuid.New()
+	runStore.runs = []domain.Run{{ID: runID, Status: domain.RunStatusRunning}}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+
+	rec := postForceFail(t, srv, runID.String(), "", &domain.UserIdentity{UserID: "bob", Roles: []string{"editor"}})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, exec.cancelled)
+	assert.Equal(t, domain.RunStatusRunning, runStore.runs[0].Status)
+}
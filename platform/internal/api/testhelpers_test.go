@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
@@ -14,28 +15,23 @@ import (
 // memoryQualityStore is an in-memory QualityStore for tests.
 type memoryQualityStore struct {
 	mu    sync.Mutex
-	tests map[string][]api.QualityTest // key: "ns/layer/pipeline"
+	tests map[string][]api.QualityTest // key: pipeline storage prefix
 }
 
 func newMemoryQualityStore() *memoryQualityStore {
 	return &memoryQualityStore{tests: make(map[string][]api.QualityTest)}
 }
 
-func qualityKey(ns, layer, pipeline string) string {
-	return ns + "/" + layer + "/" + pipeline
-}
-
-func (m *memoryQualityStore) ListTests(_ context.Context, ns, layer, pipeline string) ([]api.QualityTest, error) {
+func (m *memoryQualityStore) ListTests(_ context.Context, prefix string) ([]api.QualityTest, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.tests[qualityKey(ns, layer, pipeline)], nil
+	return m.tests[prefix], nil
 }
 
-func (m *memoryQualityStore) CreateTest(_ context.Context, ns, layer, pipeline string, test api.QualityTest) error {
+func (m *memoryQualityStore) CreateTest(_ context.Context, key string, test api.QualityTest) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	key := qualityKey(ns, layer, pipeline)
 	for _, t := range m.tests[key] {
 		if t.Name == test.Name {
 			return fmt.Errorf("test %q already exists", test.Name)
@@ -45,11 +41,10 @@ func (m *memoryQualityStore) CreateTest(_ context.Context, ns, layer, pipeline s
 	return nil
 }
 
-func (m *memoryQualityStore) DeleteTest(_ context.Context, ns, layer, pipeline, testName string) error {
+func (m *memoryQualityStore) DeleteTest(_ context.Context, key, testName string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	key := qualityKey(ns, layer, pipeline)
 	tests := m.tests[key]
 	for i, t := range tests {
 		if t.Name == testName {
@@ -60,11 +55,10 @@ func (m *memoryQualityStore) DeleteTest(_ context.Context, ns, layer, pipeline,
 	return fmt.Errorf("test %q not found", testName)
 }
 
-func (m *memoryQualityStore) RunTests(_ context.Context, ns, layer, pipeline string) ([]api.QualityTestResult, error) {
+func (m *memoryQualityStore) RunTests(_ context.Context, key string) ([]api.QualityTestResult, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	key := qualityKey(ns, layer, pipeline)
 	tests := m.tests[key]
 	var results []api.QualityTestResult
 	for _, t := range tests {
@@ -86,15 +80,15 @@ func (m *memoryQualityStore) ListTestCounts(_ context.Context, namespace string)
 
 	result := make(map[string]int)
 	for key, tests := range m.tests {
-		// key format: "ns/layer/pipeline" — convert to "ns.layer.pipeline" for lineage map
-		parts := strings.SplitN(key, "/", 3)
-		if len(parts) != 3 {
+		// key format: "ns/pipelines/layer/pipeline/" — convert to "ns.layer.pipeline" for lineage map
+		parts := strings.Split(strings.TrimSuffix(key, "/"), "/")
+		if len(parts) != 4 || parts[1] != "pipelines" {
 			continue
 		}
 		if namespace != "" && parts[0] != namespace {
 			continue
 		}
-		dotKey := parts[0] + "." + parts[1] + "." + parts[2]
+		dotKey := parts[0] + "." + parts[2] + "." + parts[3]
 		result[dotKey] = len(tests)
 	}
 	return result, nil
@@ -190,9 +184,10 @@ func (m *memoryQueryStore) PreviewTable(_ context.Context, namespace, layer, nam
 
 // memoryLandingZoneStore is an in-memory LandingZoneStore for tests.
 type memoryLandingZoneStore struct {
-	mu    sync.Mutex
-	zones []api.LandingZoneListItem
-	files []domain.LandingFile
+	mu      sync.Mutex
+	zones   []api.LandingZoneListItem
+	deleted []domain.LandingZone // soft-deleted; files stay in m.files until hard delete
+	files   []domain.LandingFile
 }
 
 func newMemoryLandingZoneStore() *memoryLandingZoneStore {
@@ -287,20 +282,70 @@ func (m *memoryLandingZoneStore) DeleteZone(_ context.Context, namespace, name s
 		if z.Namespace != namespace || z.Name != name {
 			continue
 		}
-		// Remove associated files
-		var remaining []domain.LandingFile
-		for _, f := range m.files {
-			if f.ZoneID != z.ID {
-				remaining = append(remaining, f)
-			}
-		}
-		m.files = remaining
+		now := time.Now()
+		z.DeletedAt = &now
+		m.deleted = append(m.deleted, z.LandingZone)
 		m.zones = append(m.zones[:i], m.zones[i+1:]...)
 		return nil
 	}
 	return nil
 }
 
+func (m *memoryLandingZoneStore) RestoreZone(_ context.Context, namespace, name string) (*domain.LandingZone, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.deleted) - 1; i >= 0; i-- {
+		z := m.deleted[i]
+		if z.Namespace != namespace || z.Name != name {
+			continue
+		}
+		for _, live := range m.zones {
+			if live.Namespace == namespace && live.Name == name {
+				return nil, fmt.Errorf("landing zone %s/%s: %w", namespace, name, domain.ErrAlreadyExists)
+			}
+		}
+		z.DeletedAt = nil
+		m.deleted = append(m.deleted[:i], m.deleted[i+1:]...)
+		m.zones = append(m.zones, api.LandingZoneListItem{LandingZone: z})
+		return &z, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryLandingZoneStore) ListSoftDeletedZones(_ context.Context, olderThan time.Time) ([]domain.LandingZone, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []domain.LandingZone
+	for _, z := range m.deleted {
+		if z.DeletedAt.Before(olderThan) {
+			result = append(result, z)
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryLandingZoneStore) HardDeleteZone(_ context.Context, zoneID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, z := range m.deleted {
+		if z.ID == zoneID {
+			m.deleted = append(m.deleted[:i], m.deleted[i+1:]...)
+			break
+		}
+	}
+	var remaining []domain.LandingFile
+	for _, f := range m.files {
+		if f.ZoneID != zoneID {
+			remaining = append(remaining, f)
+		}
+	}
+	m.files = remaining
+	return nil
+}
+
 func (m *memoryLandingZoneStore) ListFiles(_ context.Context, zoneID uuid.UUID) ([]domain.LandingFile, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -367,6 +412,48 @@ func (m *memoryLandingZoneStore) UpdateZoneLifecycle(_ context.Context, zoneID u
 	return fmt.Errorf("landing zone %s not found", zoneID)
 }
 
+func (m *memoryLandingZoneStore) UpdateZoneUploadPolicy(_ context.Context, zoneID uuid.UUID, schemaStrict, dedupe *bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, z := range m.zones {
+		if z.ID == zoneID {
+			if schemaStrict != nil {
+				m.zones[i].SchemaStrict = *schemaStrict
+			}
+			if dedupe != nil {
+				m.zones[i].Dedupe = *dedupe
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("landing zone %s not found", zoneID)
+}
+
+func (m *memoryLandingZoneStore) FindFileByChecksum(_ context.Context, zoneID uuid.UUID, checksum string) (*domain.LandingFile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.files) - 1; i >= 0; i-- {
+		if f := m.files[i]; f.ZoneID == zoneID && f.Checksum == checksum {
+			return &f, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryLandingZoneStore) FindFileByPath(_ context.Context, zoneID uuid.UUID, s3Path string) (*domain.LandingFile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.files) - 1; i >= 0; i-- {
+		if f := m.files[i]; f.ZoneID == zoneID && f.S3Path == s3Path {
+			return &f, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *memoryLandingZoneStore) ListZonesWithAutoPurge(_ context.Context) ([]domain.LandingZone, error) {
 	return nil, nil
 }
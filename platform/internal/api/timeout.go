@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DefaultRequestTimeout is the context deadline given to every /api/v1
+// request whose route has no override. Store calls take r.Context(), so a
+// slow Postgres query is cancelled instead of pinning the connection until
+// the server's WriteTimeout.
+const DefaultRequestTimeout = 30 * time.Second
+
+// DefaultRouteTimeouts maps chi route patterns to deadline overrides. Query
+// and preview run SQL on ratq and legitimately take longer; they stay under
+// the public listener's 120s WriteTimeout so the 504 still reaches the client.
+// A zero duration means no deadline: streams, exports and uploads are bounded
+// by their own limits (MaxSSEDurationSeconds, DB_HEAVY_QUERY_TIMEOUT, the
+// server Read/Write timeouts). Namespace renames, pipeline moves, bundle and
+// schedule imports, backfills and manual reaper runs write or delete in
+// bulk, so a deadline would abort them half-way.
+func DefaultRouteTimeouts() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/api/v1/query": 90 * time.Second,
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/preview":    90 * time.Second,
+		"/api/v1/tables/{namespace}/{layer}/{name}/preview":       90 * time.Second,
+		"/api/v1/runs/{runID}/logs/stream":                        0,
+		"/api/v1/runs/{runID}/logs/download":                      0,
+		"/api/v1/namespaces/{name}/rename":                        0,
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/move":       0,
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/export":     0,
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/backfill":   0,
+		"/api/v1/pipelines/import":                                0,
+		"/api/v1/schedules/import":                                0,
+		"/api/v1/retention/run":                                   0,
+		"/api/v1/admin/retention/run":                             0,
+		"/api/v1/events/stream":                                   0,
+		"/api/v1/audit/export":                                    0,
+		"/api/v1/files/upload":                                    0,
+		"/api/v1/landing-zones/{namespace}/{name}/files":          0,
+		"/api/v1/landing-zones/{namespace}/{name}/files/complete": 0,
+		"/api/v1/landing-zones/{namespace}/{name}/samples":        0,
+		"/api/v1/x/{plugin}/*":                                    0,
+		"/api/v1/x/{plugin}":                                      0,
+	}
+}
+
+// RequestTimeout returns a middleware that sets a context deadline on each
+// request: routes[pattern] when the matched route has an override, otherwise
+// defaultTimeout. A timeout <= 0 leaves the request without a deadline, as do
+// SSE requests (Accept: text/event-stream), which are long-lived by design.
+//
+// When the deadline passes, a handler that reports the cancelled store call
+// as a 500 has that response replaced with a 504 error envelope; a handler
+// that returns without writing anything gets the same 504.
+//
+// It reads the matched chi route pattern, so it must be mounted post-match
+// (via r.With, like RouteRateLimit).
+func RequestTimeout(defaultTimeout time.Duration, routes map[string]time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := defaultTimeout
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if d, ok := routes[rctx.RoutePattern()]; ok {
+					timeout = d
+				}
+			}
+			if timeout <= 0 || r.Header.Get("Accept") == "text/event-stream" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutWriter{ResponseWriter: w, ctx: ctx}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+			if !tw.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				tw.writeTimeout()
+			}
+		})
+	}
+}
+
+// timeoutWriter turns a 500 written after the request deadline into a 504.
+// Other statuses — including a handler's own 503/504 — pass through.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	wroteHeader bool
+	swallow     bool // the handler's body belongs to the replaced 500
+}
+
+func (tw *timeoutWriter) writeTimeout() {
+	tw.wroteHeader = true
+	tw.swallow = true
+	errorJSON(tw.ResponseWriter, "request timed out", "DEADLINE_EXCEEDED", http.StatusGatewayTimeout)
+}
+
+// WriteHeader replaces a 500 with the timeout envelope once the deadline has passed.
+func (tw *timeoutWriter) WriteHeader(code int) {
+	if tw.wroteHeader {
+		return
+	}
+	if code == http.StatusInternalServerError && errors.Is(tw.ctx.Err(), context.DeadlineExceeded) {
+		tw.writeTimeout()
+		return
+	}
+	tw.wroteHeader = true
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+// Write discards the body of a replaced 500.
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.swallow {
+		return len(b), nil
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
+
+// Flush keeps streaming handlers working behind the wrapper.
+func (tw *timeoutWriter) Flush() {
+	if f, ok := tw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,123 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hangingPipelineStore blocks ListPipelines until the request context ends,
+// like a Postgres query stuck behind a lock.
+type hangingPipelineStore struct {
+	*memoryPipelineStore
+}
+
+func (s *hangingPipelineStore) ListPipelines(ctx context.Context, _ api.PipelineFilter) ([]domain.Pipeline, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestRequestTimeout_SlowStore_Returns504(t *testing.T) {
+	srv, store := newTestServer()
+	srv.Pipelines = &hangingPipelineStore{memoryPipelineStore: store}
+	srv.RequestTimeout = 50 * time.Millisecond
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines", http.NoBody)
+	rec := httptest.NewRecorder()
+	start := time.Now()
+	router.ServeHTTP(rec, req)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	var resp api.APIError
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "DEADLINE_EXCEEDED", resp.Error.Code)
+	assert.Equal(t, api.ErrorTypeUnavailable, resp.Error.Type)
+	assert.Equal(t, "request timed out", resp.Error.Message)
+}
+
+func TestRequestTimeout_RouteOverrides(t *testing.T) {
+	r := chi.NewRouter()
+	mw := api.RequestTimeout(time.Second, map[string]time.Duration{
+		"/slow": time.Hour,
+		"/none": 0,
+	})
+	deadlines := map[string]time.Duration{}
+	for _, path := range []string{"/fast", "/slow", "/none"} {
+		r.With(mw).Get(path, func(w http.ResponseWriter, r *http.Request) {
+			if dl, ok := r.Context().Deadline(); ok {
+				deadlines[r.URL.Path] = time.Until(dl)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	for _, path := range []string{"/fast", "/slow", "/none"} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+	assert.LessOrEqual(t, deadlines["/fast"], time.Second)
+	assert.Greater(t, deadlines["/slow"], time.Minute)
+	assert.NotContains(t, deadlines, "/none")
+
+	// SSE requests are long-lived and never get a deadline.
+	delete(deadlines, "/fast")
+	req := httptest.NewRequest(http.MethodGet, "/fast", http.NoBody)
+	req.Header.Set("Accept", "text/event-stream")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.NotContains(t, deadlines, "/fast")
+}
+
+func TestDefaultRouteTimeouts_UploadsHaveNoDeadline(t *testing.T) {
+	routes := api.DefaultRouteTimeouts()
+	for _, pattern := range []string{
+		"/api/v1/files/upload",
+		"/api/v1/landing-zones/{namespace}/{name}/files",
+		"/api/v1/landing-zones/{namespace}/{name}/files/complete",
+		"/api/v1/landing-zones/{namespace}/{name}/samples",
+	} {
+		d, ok := routes[pattern]
+		assert.True(t, ok, pattern)
+		assert.Zero(t, d, pattern)
+	}
+}
+
+func TestDefaultRouteTimeouts_BulkWritesAndDownloadsHaveNoDeadline(t *testing.T) {
+	routes := api.DefaultRouteTimeouts()
+	for _, pattern := range []string{
+		"/api/v1/namespaces/{name}/rename",
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/move",
+		"/api/v1/runs/{runID}/logs/download",
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/export",
+		"/api/v1/pipelines/{namespace}/{layer}/{name}/backfill",
+		"/api/v1/pipelines/import",
+		"/api/v1/schedules/import",
+		"/api/v1/retention/run",
+		"/api/v1/admin/retention/run",
+	} {
+		d, ok := routes[pattern]
+		assert.True(t, ok, pattern)
+		assert.Zero(t, d, pattern)
+	}
+}
+
+func TestRequestTimeout_HandlerStatusPassesThroughBeforeDeadline(t *testing.T) {
+	handler := api.RequestTimeout(time.Minute, nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "boom")
+}
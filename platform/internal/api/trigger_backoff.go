@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// triggerBackoff makes a flapping trigger back off: each fire that lands
+// within ResetAfterSeconds of the previous one doubles the effective
+// cooldown, up to MaxCooldownSeconds. A quiet period of ResetAfterSeconds
+// resets it to the trigger's base cooldown_seconds. Empty ResetAfterSeconds
+// defaults to MaxCooldownSeconds.
+type triggerBackoff struct {
+	MaxCooldownSeconds int `json:"max_cooldown_seconds"`
+	ResetAfterSeconds  int `json:"reset_after_seconds,omitempty"`
+}
+
+// triggerBackoffConfig is the part of any trigger config read for backoff.
+type triggerBackoffConfig struct {
+	Backoff *triggerBackoff `json:"backoff,omitempty"`
+}
+
+// triggerBackoffOf returns the backoff in a trigger config, or nil if it has
+// none.
+func triggerBackoffOf(config json.RawMessage) (*triggerBackoff, error) {
+	if len(config) == 0 {
+		return nil, nil
+	}
+	var cfg triggerBackoffConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg.Backoff, nil
+}
+
+// validateTriggerBackoff checks the optional backoff of a trigger config
+// against the trigger's base cooldown. Returns a client-facing error message,
+// or "" when valid.
+func validateTriggerBackoff(t domain.TriggerType, config json.RawMessage, cooldownSeconds int) string {
+	b, err := triggerBackoffOf(config)
+	if err != nil {
+		return "backoff must be an object with max_cooldown_seconds and optional reset_after_seconds"
+	}
+	if b == nil {
+		return ""
+	}
+	if t == domain.TriggerTypeCron || t == domain.TriggerTypeCronDependency {
+		return "backoff is only supported on event-driven triggers"
+	}
+	if cooldownSeconds <= 0 {
+		return "backoff requires a positive cooldown_seconds to grow from"
+	}
+	if b.MaxCooldownSeconds < cooldownSeconds {
+		return "backoff max_cooldown_seconds must be at least cooldown_seconds"
+	}
+	if b.ResetAfterSeconds < 0 {
+		return "backoff reset_after_seconds must not be negative"
+	}
+	if b.ResetAfterSeconds > 0 && b.ResetAfterSeconds < b.MaxCooldownSeconds {
+		return "backoff reset_after_seconds must be at least max_cooldown_seconds"
+	}
+	return ""
+}
+
+func (b *triggerBackoff) resetAfter() time.Duration {
+	if b.ResetAfterSeconds > 0 {
+		return time.Duration(b.ResetAfterSeconds) * time.Second
+	}
+	return time.Duration(b.MaxCooldownSeconds) * time.Second
+}
+
+// cooldown returns base doubled streak times, capped at the max.
+func (b *triggerBackoff) cooldown(base time.Duration, streak int) time.Duration {
+	limit := time.Duration(b.MaxCooldownSeconds) * time.Second
+	d := base
+	for i := 0; i < streak && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+// triggerCooldown returns how long trigger must wait after its last fire.
+// Without backoff that is cooldown_seconds; with backoff it grows with the
+// trigger's fire streak. An unreadable config counts as no backoff.
+func triggerCooldown(trigger domain.PipelineTrigger) time.Duration {
+	base := time.Duration(trigger.CooldownSeconds) * time.Second
+	b, err := triggerBackoffOf(trigger.Config)
+	if err != nil || b == nil || base <= 0 {
+		return base
+	}
+	return b.cooldown(base, trigger.BackoffStreak)
+}
+
+// nextBackoffStreak returns the streak to record when trigger fires at now,
+// and false when trigger has no backoff. The streak grows while fires keep
+// landing within the reset period and stops growing once the cooldown hits
+// its cap.
+func nextBackoffStreak(trigger domain.PipelineTrigger, now time.Time) (int, bool) {
+	b, err := triggerBackoffOf(trigger.Config)
+	if err != nil || b == nil {
+		return 0, false
+	}
+	if trigger.LastTriggeredAt == nil || now.Sub(*trigger.LastTriggeredAt) >= b.resetAfter() {
+		return 0, true
+	}
+	base := time.Duration(trigger.CooldownSeconds) * time.Second
+	if base <= 0 || b.cooldown(base, trigger.BackoffStreak) >= time.Duration(b.MaxCooldownSeconds)*time.Second {
+		return trigger.BackoffStreak, true
+	}
+	return trigger.BackoffStreak + 1, true
+}
+
+// recordTriggerFired marks trigger as having fired runID and, for a backoff
+// trigger, records its next fire streak. Every fire path calls it inside the
+// tx that creates the run.
+func recordTriggerFired(ctx context.Context, triggers PipelineTriggerStore, trigger domain.PipelineTrigger, runID uuid.UUID) error {
+	if streak, ok := nextBackoffStreak(trigger, time.Now()); ok && streak != trigger.BackoffStreak {
+		if err := triggers.UpdateTriggerBackoffStreak(ctx, trigger.ID.String(), streak); err != nil {
+			return err
+		}
+	}
+	return triggers.UpdateTriggerFired(ctx, trigger.ID.String(), runID)
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// TriggerEdge is one pipeline_success trigger: a successful run of From
+// fires To. Pipelines are identified by "namespace/layer/name".
+type TriggerEdge struct {
+	TriggerID uuid.UUID
+	From      string
+	To        string
+}
+
+// TriggerGraph is the directed graph of pipeline_success triggers across all
+// pipelines. Disabled triggers are included — enabling one must not be able
+// to close a cycle that creation would have rejected.
+type TriggerGraph struct {
+	edges map[string][]TriggerEdge // keyed by From
+}
+
+// pipelineGraphKey is the node key used by TriggerGraph.
+func pipelineGraphKey(namespace, layer, name string) string {
+	return namespace + "/" + layer + "/" + name
+}
+
+// BuildTriggerGraph loads every pipeline_success trigger via ListAllTriggers
+// and returns the resulting graph. Triggers with unparseable config are
+// skipped (they can never fire).
+func BuildTriggerGraph(ctx context.Context, store PipelineTriggerStore) (*TriggerGraph, error) {
+	items, _, err := store.ListAllTriggers(ctx, TriggerFilter{Type: string(domain.TriggerTypePipelineSuccess)})
+	if err != nil {
+		return nil, fmt.Errorf("list pipeline_success triggers: %w", err)
+	}
+
+	g := &TriggerGraph{edges: map[string][]TriggerEdge{}}
+	for _, item := range items {
+		var cfg pipelineSuccessConfig
+		if err := json.Unmarshal(item.Config, &cfg); err != nil || cfg.Namespace == "" || cfg.Layer == "" || cfg.Pipeline == "" {
+			continue
+		}
+		g.AddEdge(TriggerEdge{
+			TriggerID: item.ID,
+			From:      pipelineGraphKey(cfg.Namespace, cfg.Layer, cfg.Pipeline),
+			To:        pipelineGraphKey(item.Namespace, string(item.Layer), item.PipelineName),
+		})
+	}
+	return g, nil
+}
+
+// AddEdge adds a trigger edge to the graph.
+func (g *TriggerGraph) AddEdge(e TriggerEdge) {
+	g.edges[e.From] = append(g.edges[e.From], e)
+}
+
+// RemoveTrigger drops every edge belonging to the given trigger, e.g. before
+// re-adding it with an updated config.
+func (g *TriggerGraph) RemoveTrigger(triggerID uuid.UUID) {
+	for from, edges := range g.edges {
+		kept := edges[:0]
+		for _, e := range edges {
+			if e.TriggerID != triggerID {
+				kept = append(kept, e)
+			}
+		}
+		g.edges[from] = kept
+	}
+}
+
+// FindCycle returns a cycle through start as a closed path of pipeline keys
+// (first and last element are both start), or nil when start is not on a
+// cycle. Neighbours are visited in sorted order so the reported cycle is
+// deterministic.
+func (g *TriggerGraph) FindCycle(start string) []string {
+	visited := map[string]bool{}
+	path := []string{start}
+
+	var walk func(node string) bool
+	walk = func(node string) bool {
+		next := make([]string, 0, len(g.edges[node]))
+		for _, e := range g.edges[node] {
+			next = append(next, e.To)
+		}
+		sort.Strings(next)
+		for _, to := range next {
+			if to == start {
+				path = append(path, to)
+				return true
+			}
+			if visited[to] {
+				continue
+			}
+			visited[to] = true
+			path = append(path, to)
+			if walk(to) {
+				return true
+			}
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+
+	if walk(start) {
+		return path
+	}
+	return nil
+}
+
+// Reachable walks the graph breadth-first from start, downstream along
+// edges or upstream against them, up to maxDepth hops. It returns each
+// reached pipeline (start excluded) with its hop distance, plus the edges
+// walked, in the order visited.
+func (g *TriggerGraph) Reachable(start string, maxDepth int, upstream bool) (map[string]int, []TriggerEdge) {
+	next := g.edges
+	if upstream {
+		next = map[string][]TriggerEdge{}
+		for _, edges := range g.edges {
+			for _, e := range edges {
+				next[e.To] = append(next[e.To], e)
+			}
+		}
+	}
+
+	dist := map[string]int{start: 0}
+	var walked []TriggerEdge
+	frontier := []string{start}
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		var nextFrontier []string
+		for _, node := range frontier {
+			edges := append([]TriggerEdge(nil), next[node]...)
+			sort.Slice(edges, func(i, j int) bool {
+				if edges[i].From != edges[j].From {
+					return edges[i].From < edges[j].From
+				}
+				return edges[i].To < edges[j].To
+			})
+			for _, e := range edges {
+				walked = append(walked, e)
+				other := e.To
+				if upstream {
+					other = e.From
+				}
+				if _, seen := dist[other]; seen {
+					continue
+				}
+				dist[other] = depth
+				nextFrontier = append(nextFrontier, other)
+			}
+		}
+		frontier = nextFrontier
+	}
+	delete(dist, start)
+	return dist, walked
+}
+
+// writeTriggerCycle writes the standard error envelope plus the offending
+// cycle as a 422.
+func writeTriggerCycle(w http.ResponseWriter, cycle []string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"error": APIErrorDetail{
+			Code:    "TRIGGER_CYCLE",
+			Type:    ErrorTypeValidation,
+			Message: "pipeline_success triggers would form a cycle: " + strings.Join(cycle, " -> "),
+		},
+		"cycle": cycle,
+	})
+}
+
+// pipelineSuccessCycle reports the cycle, if any, that a pipeline_success
+// trigger from cfg's upstream to downstream would close. triggerID is the
+// trigger being updated (its current edge is replaced), or uuid.Nil for a
+// new trigger.
+func (s *Server) pipelineSuccessCycle(ctx context.Context, triggerID uuid.UUID, cfg pipelineSuccessConfig, downstream *domain.Pipeline) ([]string, error) {
+	g, err := BuildTriggerGraph(ctx, s.Triggers)
+	if err != nil {
+		return nil, err
+	}
+	to := pipelineGraphKey(downstream.Namespace, string(downstream.Layer), downstream.Name)
+	g.RemoveTrigger(triggerID)
+	g.AddEdge(TriggerEdge{
+		TriggerID: triggerID,
+		From:      pipelineGraphKey(cfg.Namespace, cfg.Layer, cfg.Pipeline),
+		To:        to,
+	})
+	return g.FindCycle(to), nil
+}
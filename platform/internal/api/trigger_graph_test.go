@@ -0,0 +1,133 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTriggerGraphTestServer seeds bronze pipelines a, b, c and d.
+func newTriggerGraphTestServer() (*api.Server, *memoryTriggerStore) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		pipelineStore.pipelines = append(pipelineStore.pipelines, domain.Pipeline{
+			ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: name,
+		})
+	}
+	return srv, triggerStore
+}
+
+// createSuccessTrigger makes downstream fire on upstream's success.
+func createSuccessTrigger(t *testing.T, router http.Handler, upstream, downstream string) *httptest.ResponseRecorder {
+	t.Helper()
+	body := `{"type":"pipeline_success","config":{"namespace":"default","layer":"bronze","pipeline":"` + upstream + `"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/"+downstream+"/triggers", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeCycle(t *testing.T, rec *httptest.ResponseRecorder) []string {
+	t.Helper()
+	var resp struct {
+		Error api.APIErrorDetail `json:"error"`
+		Cycle []string           `json:"cycle"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "TRIGGER_CYCLE", resp.Error.Code)
+	return resp.Cycle
+}
+
+func TestCreateTrigger_PipelineSuccessChain_Returns201(t *testing.T) {
+	srv, triggerStore := newTriggerGraphTestServer()
+	router := api.NewRouter(srv)
+
+	assert.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "a", "b").Code)
+	assert.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "b", "c").Code)
+	// A diamond (a fires c directly as well) is not a cycle.
+	assert.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "a", "c").Code)
+	assert.Len(t, triggerStore.triggers, 3)
+}
+
+func TestCreateTrigger_PipelineSuccessCycle_Returns422(t *testing.T) {
+	srv, triggerStore := newTriggerGraphTestServer()
+	router := api.NewRouter(srv)
+
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "a", "b").Code)
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "b", "c").Code)
+
+	rec := createSuccessTrigger(t, router, "c", "a")
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, []string{"default/bronze/a", "default/bronze/b", "default/bronze/c", "default/bronze/a"}, decodeCycle(t, rec))
+	assert.Len(t, triggerStore.triggers, 2, "cyclic trigger must not be stored")
+}
+
+func TestCreateTrigger_PipelineSuccessSelfLoop_Returns422(t *testing.T) {
+	srv, _ := newTriggerGraphTestServer()
+	router := api.NewRouter(srv)
+
+	rec := createSuccessTrigger(t, router, "a", "a")
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, []string{"default/bronze/a", "default/bronze/a"}, decodeCycle(t, rec))
+}
+
+func TestUpdateTrigger_PipelineSuccessCycle_Returns422(t *testing.T) {
+	srv, triggerStore := newTriggerGraphTestServer()
+	router := api.NewRouter(srv)
+
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "a", "b").Code)
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "b", "c").Code)
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "d", "a").Code)
+	triggerID := triggerStore.triggers[2].ID.String()
+
+	update := func(upstream string) *httptest.ResponseRecorder {
+		body := `{"config":{"namespace":"default","layer":"bronze","pipeline":"` + upstream + `"}}`
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/a/triggers/"+triggerID, bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Re-pointing a at c closes a -> b -> c -> a.
+	rec := update("c")
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, []string{"default/bronze/a", "default/bronze/b", "default/bronze/c", "default/bronze/a"}, decodeCycle(t, rec))
+
+	// The trigger's own current edge is replaced, not counted twice.
+	assert.Equal(t, http.StatusOK, update("d").Code)
+}
+
+func TestPublishPipeline_OnTriggerCycle_Returns422(t *testing.T) {
+	srv, triggerStore := newTriggerGraphTestServer()
+	router := api.NewRouter(srv)
+
+	require.Equal(t, http.StatusCreated, createSuccessTrigger(t, router, "a", "b").Code)
+	// Seed b -> a directly, as if created before cycle checks existed.
+	triggerStore.triggers = append(triggerStore.triggers, domain.PipelineTrigger{
+		ID:         uuid.New(),
+		PipelineID: srv.Pipelines.(*memoryPipelineStore).pipelines[0].ID,
+		Type:       domain.TriggerTypePipelineSuccess,
+		Config:     json.RawMessage(`{"namespace":"default","layer":"bronze","pipeline":"b"}`),
+		Enabled:    true,
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/a/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, []string{"default/bronze/a", "default/bronze/b", "default/bronze/a"}, decodeCycle(t, rec))
+
+	// c is not on the cycle and still publishes.
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/c/publish", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
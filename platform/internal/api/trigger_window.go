@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// weekdayNames maps active_window day names to time.Weekday.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// activeWindow limits when an event-driven trigger may fire, e.g. weekdays
+// 08:00–20:00 Europe/Paris. Events outside the window are dropped, not
+// queued. A window whose end is before its start runs overnight; Days then
+// names the day it opens on. Empty Days means every day; empty TZ is UTC.
+type activeWindow struct {
+	Days  []string `json:"days,omitempty"`
+	Start string   `json:"start"` // HH:MM, inclusive
+	End   string   `json:"end"`   // HH:MM, exclusive
+	TZ    string   `json:"tz,omitempty"`
+}
+
+// triggerActiveWindowConfig is the part of any trigger config read for the
+// active window.
+type triggerActiveWindowConfig struct {
+	ActiveWindow *activeWindow `json:"active_window,omitempty"`
+}
+
+// triggerActiveWindow returns the active window in a trigger config, or nil
+// if it has none.
+func triggerActiveWindow(config json.RawMessage) (*activeWindow, error) {
+	if len(config) == 0 {
+		return nil, nil
+	}
+	var cfg triggerActiveWindowConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg.ActiveWindow, nil
+}
+
+// validateTriggerActiveWindow checks the optional active_window of a trigger
+// config. Returns a client-facing error message, or "" when valid.
+func validateTriggerActiveWindow(t domain.TriggerType, config json.RawMessage) string {
+	aw, err := triggerActiveWindow(config)
+	if err != nil {
+		return "active_window must be an object with start, end, and optional days and tz"
+	}
+	if aw == nil {
+		return ""
+	}
+	if t == domain.TriggerTypeCron || t == domain.TriggerTypeCronDependency {
+		return "active_window is only supported on event-driven triggers; narrow the cron expression instead"
+	}
+	return aw.validate()
+}
+
+func (aw *activeWindow) validate() string {
+	start, errS := parseClock(aw.Start)
+	end, errE := parseClock(aw.End)
+	if errS != nil || errE != nil {
+		return "active_window start and end must be HH:MM (24-hour)"
+	}
+	if start == end {
+		return "active_window start and end must differ"
+	}
+	for _, d := range aw.Days {
+		if _, ok := weekdayNames[d]; !ok {
+			return fmt.Sprintf("active_window day %q must be one of mon, tue, wed, thu, fri, sat, sun", d)
+		}
+	}
+	if _, err := time.LoadLocation(aw.TZ); err != nil {
+		return fmt.Sprintf("active_window tz %q is not a known time zone", aw.TZ)
+	}
+	return ""
+}
+
+// contains reports whether t falls inside the window. Assumes validate passed.
+func (aw *activeWindow) contains(t time.Time) bool {
+	loc, err := time.LoadLocation(aw.TZ)
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+	start, _ := parseClock(aw.Start)
+	end, _ := parseClock(aw.End)
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+
+	openedOn := t.Weekday()
+	switch {
+	case start < end:
+		if now < start || now >= end {
+			return false
+		}
+	case now >= start:
+		// Overnight window, evening part: opened today.
+	case now < end:
+		// Overnight window, morning part: opened yesterday.
+		openedOn = (openedOn + 6) % 7
+	default:
+		return false
+	}
+	if len(aw.Days) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(aw.Days, func(d string) bool { return weekdayNames[d] == openedOn })
+}
+
+// parseClock parses HH:MM into the offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// triggerOutsideWindow reports whether trigger has an active window that
+// now falls outside of. An unreadable config counts as no window.
+func triggerOutsideWindow(trigger domain.PipelineTrigger, now time.Time) bool {
+	aw, err := triggerActiveWindow(trigger.Config)
+	if err != nil || aw == nil {
+		return false
+	}
+	return !aw.contains(now)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+func TestActiveWindowContains(t *testing.T) {
+	// 2026-03-02 is a Monday.
+	at := func(day int, hhmm string) time.Time {
+		c, _ := time.Parse("15:04", hhmm)
+		return time.Date(2026, 3, day, c.Hour(), c.Minute(), 0, 0, time.UTC)
+	}
+	weekdays := []string{"mon", "tue", "wed", "thu", "fri"}
+
+	tests := []struct {
+		name   string
+		window activeWindow
+		at     time.Time
+		want   bool
+	}{
+		{"inside", activeWindow{Start: "08:00", End: "20:00"}, at(2, "12:00"), true},
+		{"start is inclusive", activeWindow{Start: "08:00", End: "20:00"}, at(2, "08:00"), true},
+		{"end is exclusive", activeWindow{Start: "08:00", End: "20:00"}, at(2, "20:00"), false},
+		{"before start", activeWindow{Start: "08:00", End: "20:00"}, at(2, "03:00"), false},
+		{"allowed day", activeWindow{Days: weekdays, Start: "08:00", End: "20:00"}, at(6, "12:00"), true},
+		{"other day", activeWindow{Days: weekdays, Start: "08:00", End: "20:00"}, at(7, "12:00"), false},
+		{"overnight evening", activeWindow{Start: "22:00", End: "06:00"}, at(2, "23:30"), true},
+		{"overnight morning", activeWindow{Start: "22:00", End: "06:00"}, at(3, "05:59"), true},
+		{"overnight gap", activeWindow{Start: "22:00", End: "06:00"}, at(3, "12:00"), false},
+		// Friday night's window runs into Saturday morning.
+		{"overnight opened on allowed day", activeWindow{Days: []string{"fri"}, Start: "22:00", End: "06:00"}, at(7, "02:00"), true},
+		{"overnight opened on other day", activeWindow{Days: []string{"fri"}, Start: "22:00", End: "06:00"}, at(6, "02:00"), false},
+		// 07:30 UTC is 08:30 in Paris (CET).
+		{"time zone", activeWindow{Start: "08:00", End: "20:00", TZ: "Europe/Paris"}, at(2, "07:30"), true},
+		{"time zone before start", activeWindow{Start: "08:00", End: "20:00", TZ: "Europe/Paris"}, at(2, "06:30"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.window.contains(tt.at))
+		})
+	}
+}
+
+func TestTriggerOutsideWindow(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	trigger := func(config string) domain.PipelineTrigger {
+		return domain.PipelineTrigger{Type: domain.TriggerTypeLandingZoneUpload, Config: json.RawMessage(config)}
+	}
+
+	tests := []struct {
+		name   string
+		config string
+		now    time.Time
+		want   bool
+	}{
+		{"no window", `{"namespace":"default","zone_name":"orders"}`, monday, false},
+		{"inside", `{"active_window":{"days":["mon"],"start":"08:00","end":"20:00"}}`, monday, false},
+		{"outside hours", `{"active_window":{"days":["mon"],"start":"08:00","end":"20:00"}}`, monday.Add(9 * time.Hour), true},
+		{"other day", `{"active_window":{"days":["tue"],"start":"08:00","end":"20:00"}}`, monday, true},
+		{"unreadable window", `{"active_window":"business hours"}`, monday, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, triggerOutsideWindow(trigger(tt.config), tt.now))
+		})
+	}
+}
+
+func TestValidateTriggerActiveWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     domain.TriggerType
+		config  string
+		wantErr bool
+	}{
+		{"no window", domain.TriggerTypeWebhook, `{}`, false},
+		{"valid", domain.TriggerTypeLandingZoneUpload, `{"active_window":{"days":["mon"],"start":"08:00","end":"20:00","tz":"America/New_York"}}`, false},
+		{"bad clock", domain.TriggerTypeLandingZoneUpload, `{"active_window":{"start":"8am","end":"20:00"}}`, true},
+		{"empty window", domain.TriggerTypeLandingZoneUpload, `{"active_window":{"start":"08:00","end":"08:00"}}`, true},
+		{"bad day", domain.TriggerTypePipelineSuccess, `{"active_window":{"days":["monday"],"start":"08:00","end":"20:00"}}`, true},
+		{"bad tz", domain.TriggerTypePipelineSuccess, `{"active_window":{"start":"08:00","end":"20:00","tz":"Mars/Olympus"}}`, true},
+		{"cron trigger", domain.TriggerTypeCron, `{"cron_expr":"0 * * * *","active_window":{"start":"08:00","end":"20:00"}}`, true},
+		{"not an object", domain.TriggerTypeWebhook, `{"active_window":"business hours"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := validateTriggerActiveWindow(tt.typ, json.RawMessage(tt.config))
+			assert.Equal(t, tt.wantErr, msg != "", msg)
+		})
+	}
+}
@@ -5,9 +5,13 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +19,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/robfig/cron/v3"
 	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
 )
 
 // contextKey is an unexported type for context value keys in this package.
@@ -24,6 +29,14 @@ type contextKey string
 // shown to the user when a webhook trigger is first created.
 const webhookPlaintextTokenKey contextKey = "webhookPlaintextToken"
 
+// webhookPlaintextSigningSecretKey carries the one-time plaintext signing
+// secret for webhook triggers created with HMAC verification.
+const webhookPlaintextSigningSecretKey contextKey = "webhookPlaintextSigningSecret"
+
+// minWebhookSigningSecretLength is the shortest caller-supplied signing secret
+// accepted. Generated secrets are 32 random bytes (64 hex chars).
+const minWebhookSigningSecretLength = 16
+
 // PipelineTriggerStore defines the persistence interface for pipeline triggers.
 type PipelineTriggerStore interface {
 	ListTriggers(ctx context.Context, pipelineID uuid.UUID) ([]domain.PipelineTrigger, error)
@@ -31,6 +44,9 @@ type PipelineTriggerStore interface {
 	CreateTrigger(ctx context.Context, trigger *domain.PipelineTrigger) error
 	UpdateTrigger(ctx context.Context, triggerID string, update UpdateTriggerRequest) (*domain.PipelineTrigger, error)
 	DeleteTrigger(ctx context.Context, triggerID string) error
+	// SetTriggersEnabledByPipeline flips enabled on all of a pipeline's
+	// triggers in one query. Returns the number whose state changed.
+	SetTriggersEnabledByPipeline(ctx context.Context, pipelineID uuid.UUID, enabled bool) (int, error)
 	FindTriggersByLandingZone(ctx context.Context, namespace, zoneName string) ([]domain.PipelineTrigger, error)
 	FindTriggersByType(ctx context.Context, triggerType string) ([]domain.PipelineTrigger, error)
 	// FindTriggerByWebhookToken looks up a webhook trigger by token hash
@@ -46,6 +62,21 @@ type PipelineTriggerStore interface {
 	// Used by the trigger evaluator to prevent duplicate runs when tick() and
 	// the run_completed LISTEN/NOTIFY handler race on the same trigger.
 	UpdateTriggerFiredCAS(ctx context.Context, triggerID string, newTriggeredAt time.Time, runID uuid.UUID, expectedPrev *time.Time) (bool, error)
+	// UpdateTriggerBackoffStreak records how many consecutive rapid fires a
+	// backoff trigger has seen; see triggerBackoff.
+	UpdateTriggerBackoffStreak(ctx context.Context, triggerID string, streak int) error
+	// ListAllTriggers returns triggers across all (non-deleted) pipelines,
+	// newest first, with the total number matching filter ignoring paging.
+	ListAllTriggers(ctx context.Context, filter TriggerFilter) ([]domain.PipelineTriggerListItem, int, error)
+}
+
+// TriggerFilter narrows the global trigger listing. Zero values match all.
+type TriggerFilter struct {
+	Type      string
+	Enabled   *bool
+	Namespace string // pipeline namespace
+	Limit     int
+	Offset    int
 }
 
 // CreateTriggerRequest is the JSON body for POST /api/v1/pipelines/{namespace}/{layer}/{name}/triggers.
@@ -64,9 +95,44 @@ type UpdateTriggerRequest struct {
 }
 
 // landingZoneUploadConfig is the expected shape for landing_zone_upload trigger config.
+// MinFiles and MinBytes (0 = unset) hold the trigger back until the zone has
+// accumulated at least that many pending files or bytes since the trigger last
+// fired; with both set, reaching either one fires it.
 type landingZoneUploadConfig struct {
 	Namespace string `json:"namespace"`
 	ZoneName  string `json:"zone_name"`
+	MinFiles  int    `json:"min_files,omitempty"`
+	MinBytes  int64  `json:"min_bytes,omitempty"`
+}
+
+// hasThreshold reports whether the trigger waits for files to accumulate.
+func (c landingZoneUploadConfig) hasThreshold() bool {
+	return c.MinFiles > 0 || c.MinBytes > 0
+}
+
+// thresholdMet reports whether a zone holding fileCount files totalling
+// totalBytes satisfies the config's thresholds.
+func (c landingZoneUploadConfig) thresholdMet(fileCount int, totalBytes int64) bool {
+	if !c.hasThreshold() {
+		return true
+	}
+	return (c.MinFiles > 0 && fileCount >= c.MinFiles) || (c.MinBytes > 0 && totalBytes >= c.MinBytes)
+}
+
+// pendingLandingFiles counts the files, and their total size, uploaded after
+// a trigger last fired. Older files were picked up by that run, so they don't
+// count towards the next threshold; a trigger that never fired counts them all.
+func pendingLandingFiles(files []domain.LandingFile, lastTriggeredAt *time.Time) (int, int64) {
+	var count int
+	var size int64
+	for _, f := range files {
+		if lastTriggeredAt != nil && !f.UploadedAt.After(*lastTriggeredAt) {
+			continue
+		}
+		count++
+		size += f.SizeBytes
+	}
+	return count, size
 }
 
 type cronConfig struct {
@@ -83,32 +149,147 @@ type webhookConfig struct {
 	// TokenHash is the SHA-256 hex digest of the plaintext webhook token.
 	// The plaintext token is never stored — only shown once on creation.
 	TokenHash string `json:"token_hash"`
+	// MetadataFields lists JSON body fields (dot paths into nested objects,
+	// e.g. "repository.name") copied into the fired run's metadata.
+	MetadataFields []string `json:"metadata_fields,omitempty"`
+	// ParamMapping maps run parameter names to JSON body fields (dot paths,
+	// optionally "$."-prefixed). The values are stored in the fired run's
+	// metadata as parameters and forwarded to the runner; see RunParameters.
+	ParamMapping map[string]string `json:"param_mapping,omitempty"`
+	// SigningSecret, when set, requires every webhook request to carry an
+	// X-Signature-256 header with the hex HMAC-SHA256 of the raw body.
+	// On create it holds the caller's plaintext secret; it is stored
+	// AES-GCM encrypted (see encryptWebhookSecret) and shown only once.
+	SigningSecret string `json:"signing_secret,omitempty"`
+	// GenerateSigningSecret asks the server to generate the signing secret.
+	// Request-only — never persisted.
+	GenerateSigningSecret bool `json:"generate_signing_secret,omitempty"`
+	// ActiveWindow is carried through so re-marshaling the config on
+	// create keeps it; see activeWindow.
+	ActiveWindow *activeWindow `json:"active_window,omitempty"`
+	// Backoff is carried through for the same reason; see triggerBackoff.
+	Backoff *triggerBackoff `json:"backoff,omitempty"`
 }
 
 type filePatternConfig struct {
 	Namespace string `json:"namespace"`
 	ZoneName  string `json:"zone_name"`
-	Pattern   string `json:"pattern"`
+	// Pattern is the original single-glob form, kept for backward compat.
+	Pattern string `json:"pattern,omitempty"`
+	// Patterns fire the trigger when the filename matches any of them.
+	Patterns []string `json:"patterns,omitempty"`
+	// MatchType selects how patterns are interpreted: "glob" (default,
+	// filepath.Match) or "regex" (regexp.MatchString, unanchored).
+	MatchType string `json:"match_type,omitempty"`
+}
+
+const (
+	filePatternMatchGlob  = "glob"
+	filePatternMatchRegex = "regex"
+)
+
+// maxFilePatterns caps how many globs one file_pattern trigger may carry.
+const maxFilePatterns = 32
+
+// maxFilePatternRegexLength caps regex pattern length. Go's RE2 engine is
+// linear-time so there is no catastrophic backtracking, but compile cost and
+// memory still grow with pattern size and the regex runs on every upload.
+const maxFilePatternRegexLength = 256
+
+// validate checks match_type and that every pattern compiles. Returns a
+// client-facing error message, or "".
+func (c filePatternConfig) validate() string {
+	globs := c.globs()
+	if len(globs) > maxFilePatterns {
+		return fmt.Sprintf("too many patterns (max %d)", maxFilePatterns)
+	}
+	switch c.MatchType {
+	case "", filePatternMatchGlob:
+		for _, glob := range globs {
+			if _, err := filepath.Match(glob, "test"); err != nil {
+				return "invalid glob pattern " + strconv.Quote(glob) + ": " + err.Error()
+			}
+		}
+	case filePatternMatchRegex:
+		for _, expr := range globs {
+			if len(expr) > maxFilePatternRegexLength {
+				return fmt.Sprintf("regex pattern too long (%d chars, max %d)", len(expr), maxFilePatternRegexLength)
+			}
+			if _, err := regexp.Compile(expr); err != nil {
+				return "invalid regex pattern " + strconv.Quote(expr) + ": " + err.Error()
+			}
+		}
+	default:
+		return "match_type must be \"glob\" or \"regex\""
+	}
+	return ""
+}
+
+// globs returns the legacy pattern followed by patterns, skipping empties.
+func (c filePatternConfig) globs() []string {
+	globs := make([]string, 0, len(c.Patterns)+1)
+	if c.Pattern != "" {
+		globs = append(globs, c.Pattern)
+	}
+	for _, p := range c.Patterns {
+		if p != "" {
+			globs = append(globs, p)
+		}
+	}
+	return globs
+}
+
+// match returns the first pattern that matches filename. Invalid patterns
+// never match (they are rejected at create time, but stored configs may
+// predate validation).
+func (c filePatternConfig) match(filename string) (string, bool) {
+	for _, pattern := range c.globs() {
+		if c.MatchType == filePatternMatchRegex {
+			if len(pattern) > maxFilePatternRegexLength {
+				continue
+			}
+			re, err := regexp.Compile(pattern)
+			if err == nil && re.MatchString(filename) {
+				return pattern, true
+			}
+			continue
+		}
+		if ok, err := filepath.Match(pattern, filename); err == nil && ok {
+			return pattern, true
+		}
+	}
+	return "", false
 }
 
 type cronDependencyConfig struct {
-	CronExpr     string   `json:"cron_expr"`
-	Dependencies []string `json:"dependencies"`
+	CronExpr      string   `json:"cron_expr"`
+	Dependencies  []string `json:"dependencies"`
+	WindowMinutes int      `json:"window_minutes,omitempty"`
+	FailOpen      bool     `json:"fail_open,omitempty"`
 }
 
+// maxCronDependencyWindowMinutes caps window_minutes at 7 days.
+const maxCronDependencyWindowMinutes = 7 * 24 * 60
+
 // cronParser accepts both 5-field cron (minute granularity, e.g.
 // "0 * * * *") and 6-field cron with an optional leading seconds field
 // (e.g. "*/30 * * * * *" for every 30 seconds). Same flags as the
 // scheduler so validation and execution agree on what's valid.
 var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 
-// MountTriggerRoutes registers trigger endpoints nested under pipelines.
+// MountTriggerRoutes registers trigger endpoints nested under pipelines,
+// plus the global cross-pipeline listing.
 func MountTriggerRoutes(r chi.Router, srv *Server) {
+	r.Get("/triggers", srv.HandleListAllTriggers)
 	r.Get("/pipelines/{namespace}/{layer}/{name}/triggers", srv.HandleListTriggers)
 	r.Post("/pipelines/{namespace}/{layer}/{name}/triggers", srv.HandleCreateTrigger)
+	r.Post("/pipelines/{namespace}/{layer}/{name}/triggers/disable-all", srv.HandleDisableAllTriggers)
+	r.Post("/pipelines/{namespace}/{layer}/{name}/triggers/enable-all", srv.HandleEnableAllTriggers)
 	r.Get("/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}", srv.HandleGetTrigger)
 	r.Put("/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}", srv.HandleUpdateTrigger)
 	r.Delete("/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}", srv.HandleDeleteTrigger)
+	r.Post("/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}/rotate-token", srv.HandleRotateWebhookToken)
+	r.Post("/pipelines/{namespace}/{layer}/{name}/triggers/{triggerID}/fire", srv.HandleFireTrigger)
 }
 
 // HandleListTriggers returns all triggers for a pipeline.
@@ -134,10 +315,7 @@ func (s *Server) HandleListTriggers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Enrich webhook triggers with computed webhook_url
-	enriched := make([]map[string]interface{}, len(triggers))
-	for i, t := range triggers {
-		enriched[i] = triggerToResponse(t, r)
-	}
+	enriched := s.triggersToResponse(triggers, r)
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
 		"triggers": enriched,
@@ -145,21 +323,153 @@ func (s *Server) HandleListTriggers(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// HandleGetTrigger returns a single trigger by ID.
-func (s *Server) HandleGetTrigger(w http.ResponseWriter, r *http.Request) {
-	triggerID := chi.URLParam(r, "triggerID")
+// HandleListAllTriggers lists triggers across every pipeline, each tagged with
+// its pipeline's namespace/layer/name. Optional filters: ?type=, ?enabled=,
+// ?namespace=. Paginated with ?limit=/?offset=.
+func (s *Server) HandleListAllTriggers(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePagination(r)
+	filter := TriggerFilter{
+		Type:      r.URL.Query().Get("type"),
+		Namespace: r.URL.Query().Get("namespace"),
+		Limit:     limit,
+		Offset:    offset,
+	}
+	if filter.Type != "" && !domain.ValidTriggerType(filter.Type) {
+		errorJSON(w, "invalid trigger type: "+filter.Type, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+	if v := r.URL.Query().Get("enabled"); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			errorJSON(w, "enabled must be true or false", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
+		filter.Enabled = &enabled
+	}
 
-	trigger, err := s.Triggers.GetTrigger(r.Context(), triggerID)
+	items, total, err := s.Triggers.ListAllTriggers(r.Context(), filter)
 	if err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
-	if trigger == nil {
-		errorJSON(w, "trigger not found", "NOT_FOUND", http.StatusNotFound)
+
+	// Drop triggers on pipelines the caller cannot read.
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.PipelineID.String()
+	}
+	allowed := make(map[string]bool, len(ids))
+	for _, id := range s.filterAccess(r.Context(), "pipeline", "read", ids) {
+		allowed[id] = true
+	}
+
+	visible := make([]domain.PipelineTriggerListItem, 0, len(items))
+	triggers := make([]domain.PipelineTrigger, 0, len(items))
+	for _, item := range items {
+		if allowed[item.PipelineID.String()] {
+			visible = append(visible, item)
+			triggers = append(triggers, item.PipelineTrigger)
+		}
+	}
+	enriched := s.triggersToResponse(triggers, r)
+	for i, item := range visible {
+		enriched[i]["namespace"] = item.Namespace
+		enriched[i]["layer"] = item.Layer
+		enriched[i]["pipeline_name"] = item.PipelineName
+	}
+	// In Pro mode the SQL count overstates the user's visible set — same
+	// trade-off as HandleListPipelines.
+	if plugins.UserFromContext(r.Context()) != nil {
+		total = len(enriched)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"triggers": enriched,
+		"total":    total,
+	})
+}
+
+// HandleDisableAllTriggers disables every trigger of a pipeline at once —
+// e.g. to silence a pipeline during an incident.
+func (s *Server) HandleDisableAllTriggers(w http.ResponseWriter, r *http.Request) {
+	s.setAllTriggersEnabled(w, r, false)
+}
+
+// HandleEnableAllTriggers re-enables every trigger of a pipeline at once.
+func (s *Server) HandleEnableAllTriggers(w http.ResponseWriter, r *http.Request) {
+	s.setAllTriggersEnabled(w, r, true)
+}
+
+// setAllTriggersEnabled flips enabled on all triggers of the URL's pipeline
+// and responds with how many triggers changed state.
+func (s *Server) setAllTriggersEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, triggerToResponse(*trigger, r))
+	updated, err := s.Triggers.SetTriggersEnabledByPipeline(r.Context(), pipeline.ID, enabled)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	slog.Info("pipeline triggers toggled", "pipeline_id", pipeline.ID, "enabled", enabled, "updated", updated)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"enabled": enabled,
+		"updated": updated,
+	})
+}
+
+// HandleGetTrigger returns a single trigger by ID.
+func (s *Server) HandleGetTrigger(w http.ResponseWriter, r *http.Request) {
+	_, trigger, ok := s.lookupPipelineTrigger(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.triggerToResponse(*trigger, r))
+}
+
+// lookupPipelineTrigger loads the pipeline named in the URL and its trigger
+// {triggerID}. A trigger that belongs to another pipeline is reported as not
+// found, so a trigger ID can't be used through a pipeline (or namespace) it
+// isn't part of. On failure the error response is written and ok is false.
+func (s *Server) lookupPipelineTrigger(w http.ResponseWriter, r *http.Request) (*domain.Pipeline, *domain.PipelineTrigger, bool) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return nil, nil, false
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	trigger, err := s.Triggers.GetTrigger(r.Context(), chi.URLParam(r, "triggerID"))
+	if err != nil {
+		internalError(w, "internal error", err)
+		return nil, nil, false
+	}
+	if trigger == nil || trigger.PipelineID != pipeline.ID {
+		errorJSON(w, "trigger not found", "NOT_FOUND", http.StatusNotFound)
+		return nil, nil, false
+	}
+	return pipeline, trigger, true
 }
 
 // HandleCreateTrigger creates a new trigger for a pipeline.
@@ -184,187 +494,416 @@ func (s *Server) HandleCreateTrigger(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	trigger, r, ok := s.prepareTrigger(w, r, pipeline, req)
+	if !ok {
+		return
+	}
+
+	if err := s.Triggers.CreateTrigger(r.Context(), trigger); err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.triggerToResponse(*trigger, r))
+}
+
+// prepareTrigger validates req as a new trigger of pipeline and returns it
+// ready for CreateTrigger, along with r carrying a webhook's one-time
+// plaintext secrets for triggerToResponse. Only pipeline's name is used, so
+// it may not be created yet. On failure the error response is written and ok
+// is false.
+func (s *Server) prepareTrigger(w http.ResponseWriter, r *http.Request, pipeline *domain.Pipeline, req CreateTriggerRequest) (*domain.PipelineTrigger, *http.Request, bool) {
 	if req.Type == "" {
 		errorJSON(w, "type is required", "INVALID_ARGUMENT", http.StatusBadRequest)
-		return
+		return nil, r, false
 	}
 
 	// Validate trigger type
 	triggerType := domain.TriggerType(req.Type)
 	if !domain.ValidTriggerType(req.Type) {
 		errorJSON(w, "unknown trigger type", "INVALID_ARGUMENT", http.StatusBadRequest)
-		return
+		return nil, r, false
+	}
+	if !s.checkTriggerConfig(w, r, pipeline, uuid.Nil, triggerType, req.Config) {
+		return nil, r, false
+	}
+
+	if triggerType == domain.TriggerTypeWebhook {
+		// Client-settable webhook options are metadata_fields, param_mapping
+		// and the signing secret; any token_hash in the request is
+		// overwritten below. The config was validated above.
+		var cfg webhookConfig
+		if len(req.Config) > 0 {
+			_ = json.Unmarshal(req.Config, &cfg)
+		}
+
+		// Auto-generate token — 32 random bytes → 64-char hex string.
+		// Only the SHA-256 hash is stored; the plaintext is returned once.
+		tokenBytes := make([]byte, 32)
+		if _, err := rand.Read(tokenBytes); err != nil {
+			internalError(w, "internal error", err)
+			return nil, r, false
+		}
+		plaintextToken := hex.EncodeToString(tokenBytes)
+		cfg.TokenHash = HashWebhookToken(plaintextToken)
+
+		// Optional HMAC signing secret — caller-supplied or generated, stored
+		// encrypted since verification needs the raw key.
+		signingSecret := cfg.SigningSecret
+		if cfg.GenerateSigningSecret {
+			if signingSecret != "" {
+				errorJSON(w, "set either signing_secret or generate_signing_secret, not both", "INVALID_ARGUMENT", http.StatusBadRequest)
+				return nil, r, false
+			}
+			secretBytes := make([]byte, 32)
+			if _, err := rand.Read(secretBytes); err != nil {
+				internalError(w, "internal error", err)
+				return nil, r, false
+			}
+			signingSecret = hex.EncodeToString(secretBytes)
+		}
+		cfg.GenerateSigningSecret = false
+		if signingSecret != "" {
+			if len(signingSecret) < minWebhookSigningSecretLength {
+				errorJSON(w, fmt.Sprintf("signing_secret must be at least %d characters", minWebhookSigningSecretLength), "INVALID_ARGUMENT", http.StatusBadRequest)
+				return nil, r, false
+			}
+			if len(s.WebhookSecretKey) == 0 {
+				errorJSON(w, "webhook signing requires RAT_WEBHOOK_SECRET_KEY to be configured", "INVALID_ARGUMENT", http.StatusBadRequest)
+				return nil, r, false
+			}
+			encrypted, err := encryptWebhookSecret(s.WebhookSecretKey, signingSecret)
+			if err != nil {
+				internalError(w, "internal error", err)
+				return nil, r, false
+			}
+			cfg.SigningSecret = encrypted
+			r = r.WithContext(context.WithValue(r.Context(), webhookPlaintextSigningSecretKey, signingSecret))
+		}
+
+		configJSON, _ := json.Marshal(cfg)
+		req.Config = configJSON
+
+		// Stash the plaintext token so we can return it once in the response.
+		// We use the request context to pass it down without changing signatures.
+		r = r.WithContext(context.WithValue(r.Context(), webhookPlaintextTokenKey, plaintextToken))
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	cooldown := 0
+	if req.CooldownSeconds != nil {
+		cooldown = *req.CooldownSeconds
+	}
+	if msg := validateTriggerBackoff(triggerType, req.Config, cooldown); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return nil, r, false
+	}
+
+	trigger := &domain.PipelineTrigger{
+		PipelineID:      pipeline.ID,
+		Type:            triggerType,
+		Config:          req.Config,
+		Enabled:         enabled,
+		CooldownSeconds: cooldown,
+	}
+	return trigger, r, true
+}
+
+// checkTriggerConfig validates config as the config of a triggerType trigger
+// of pipeline: the per-type required fields and options, and that the
+// landing zones and pipelines it references exist. triggerID is the trigger
+// being updated, or uuid.Nil on create. Writes the error response and
+// returns false on rejection. Shared by create and update so both accept
+// the same configs.
+func (s *Server) checkTriggerConfig(w http.ResponseWriter, r *http.Request, pipeline *domain.Pipeline, triggerID uuid.UUID, triggerType domain.TriggerType, config json.RawMessage) bool {
+	if msg := validateTriggerActiveWindow(triggerType, config); msg != "" {
+		errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+		return false
 	}
 
 	switch triggerType {
 	case domain.TriggerTypeLandingZoneUpload:
 		var cfg landingZoneUploadConfig
-		if err := json.Unmarshal(req.Config, &cfg); err != nil || cfg.Namespace == "" || cfg.ZoneName == "" {
+		if err := json.Unmarshal(config, &cfg); err != nil || cfg.Namespace == "" || cfg.ZoneName == "" {
 			errorJSON(w, "config must include namespace and zone_name", "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+			return false
+		}
+		if cfg.MinFiles < 0 || cfg.MinBytes < 0 {
+			errorJSON(w, "min_files and min_bytes must not be negative", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return false
 		}
 		if s.LandingZones != nil {
 			zone, err := s.LandingZones.GetZone(r.Context(), cfg.Namespace, cfg.ZoneName)
 			if err != nil {
 				internalError(w, "internal error", err)
-				return
+				return false
 			}
 			if zone == nil {
 				errorJSON(w, "landing zone not found", "NOT_FOUND", http.StatusNotFound)
-				return
+				return false
 			}
 		}
 
 	case domain.TriggerTypeCron:
 		var cfg cronConfig
-		if err := json.Unmarshal(req.Config, &cfg); err != nil || cfg.CronExpr == "" {
+		if err := json.Unmarshal(config, &cfg); err != nil || cfg.CronExpr == "" {
 			errorJSON(w, "config must include cron_expr", "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+			return false
 		}
 		if _, err := cronParser.Parse(cfg.CronExpr); err != nil {
 			errorJSON(w, "invalid cron expression: "+err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+			return false
 		}
 
 	case domain.TriggerTypePipelineSuccess:
 		var cfg pipelineSuccessConfig
-		if err := json.Unmarshal(req.Config, &cfg); err != nil || cfg.Namespace == "" || cfg.Layer == "" || cfg.Pipeline == "" {
+		if err := json.Unmarshal(config, &cfg); err != nil || cfg.Namespace == "" || cfg.Layer == "" || cfg.Pipeline == "" {
 			errorJSON(w, "config must include namespace, layer, and pipeline", "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+			return false
 		}
 		// Verify upstream pipeline exists
 		upstream, err := s.Pipelines.GetPipeline(r.Context(), cfg.Namespace, cfg.Layer, cfg.Pipeline)
 		if err != nil {
 			internalError(w, "internal error", err)
-			return
+			return false
 		}
 		if upstream == nil {
 			errorJSON(w, "upstream pipeline not found", "NOT_FOUND", http.StatusNotFound)
-			return
+			return false
 		}
-
-	case domain.TriggerTypeWebhook:
-		// Auto-generate token — 32 random bytes → 64-char hex string.
-		// Only the SHA-256 hash is stored; the plaintext is returned once.
-		tokenBytes := make([]byte, 32)
-		if _, err := rand.Read(tokenBytes); err != nil {
+		// Reject chains that loop back (A fires B fires A).
+		cycle, err := s.pipelineSuccessCycle(r.Context(), triggerID, cfg, pipeline)
+		if err != nil {
 			internalError(w, "internal error", err)
-			return
+			return false
+		}
+		if cycle != nil {
+			writeTriggerCycle(w, cycle)
+			return false
 		}
-		plaintextToken := hex.EncodeToString(tokenBytes)
-		cfg := webhookConfig{TokenHash: HashWebhookToken(plaintextToken)}
-		configJSON, _ := json.Marshal(cfg)
-		req.Config = configJSON
 
-		// Stash the plaintext token so we can return it once in the response.
-		// We use the request context to pass it down without changing signatures.
-		r = r.WithContext(context.WithValue(r.Context(), webhookPlaintextTokenKey, plaintextToken))
+	case domain.TriggerTypeWebhook:
+		var cfg webhookConfig
+		if len(config) > 0 {
+			if err := json.Unmarshal(config, &cfg); err != nil {
+				errorJSON(w, "invalid webhook config", "INVALID_ARGUMENT", http.StatusBadRequest)
+				return false
+			}
+		}
+		if msg := validateWebhookMetadataFields(cfg.MetadataFields); msg != "" {
+			errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+			return false
+		}
+		if msg := validateWebhookParamMapping(cfg.ParamMapping, len(cfg.MetadataFields)); msg != "" {
+			errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+			return false
+		}
 
 	case domain.TriggerTypeFilePattern:
 		var cfg filePatternConfig
-		if err := json.Unmarshal(req.Config, &cfg); err != nil || cfg.Namespace == "" || cfg.ZoneName == "" || cfg.Pattern == "" {
-			errorJSON(w, "config must include namespace, zone_name, and pattern", "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+		if err := json.Unmarshal(config, &cfg); err != nil || cfg.Namespace == "" || cfg.ZoneName == "" || len(cfg.globs()) == 0 {
+			errorJSON(w, "config must include namespace, zone_name, and pattern or patterns", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return false
 		}
-		// Verify glob pattern compiles
-		if _, err := filepath.Match(cfg.Pattern, "test"); err != nil {
-			errorJSON(w, "invalid glob pattern: "+err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+		// Verify match_type and that every pattern compiles
+		if msg := cfg.validate(); msg != "" {
+			errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+			return false
 		}
 		if s.LandingZones != nil {
 			zone, err := s.LandingZones.GetZone(r.Context(), cfg.Namespace, cfg.ZoneName)
 			if err != nil {
 				internalError(w, "internal error", err)
-				return
+				return false
 			}
 			if zone == nil {
 				errorJSON(w, "landing zone not found", "NOT_FOUND", http.StatusNotFound)
-				return
+				return false
 			}
 		}
 
 	case domain.TriggerTypeCronDependency:
 		var cfg cronDependencyConfig
-		if err := json.Unmarshal(req.Config, &cfg); err != nil || cfg.CronExpr == "" || len(cfg.Dependencies) == 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil || cfg.CronExpr == "" || len(cfg.Dependencies) == 0 {
 			errorJSON(w, "config must include cron_expr and at least one dependency", "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+			return false
 		}
 		if _, err := cronParser.Parse(cfg.CronExpr); err != nil {
 			errorJSON(w, "invalid cron expression: "+err.Error(), "INVALID_ARGUMENT", http.StatusBadRequest)
-			return
+			return false
+		}
+		if cfg.WindowMinutes < 0 || cfg.WindowMinutes > maxCronDependencyWindowMinutes {
+			errorJSON(w, fmt.Sprintf("window_minutes must be between 0 and %d", maxCronDependencyWindowMinutes), "INVALID_ARGUMENT", http.StatusBadRequest)
+			return false
+		}
+		if cfg.FailOpen && cfg.WindowMinutes == 0 {
+			errorJSON(w, "fail_open requires window_minutes", "INVALID_ARGUMENT", http.StatusBadRequest)
+			return false
 		}
 		// Validate each dependency pipeline exists (format: "ns.layer.pipeline")
 		for _, dep := range cfg.Dependencies {
 			parts := strings.SplitN(dep, ".", 3)
 			if len(parts) != 3 {
 				errorJSON(w, "dependency must be in format namespace.layer.pipeline: "+dep, "INVALID_ARGUMENT", http.StatusBadRequest)
-				return
+				return false
 			}
 			p, err := s.Pipelines.GetPipeline(r.Context(), parts[0], parts[1], parts[2])
 			if err != nil {
 				internalError(w, "internal error", err)
-				return
+				return false
 			}
 			if p == nil {
 				errorJSON(w, "dependency pipeline not found: "+dep, "NOT_FOUND", http.StatusNotFound)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// HandleUpdateTrigger updates a trigger's config, enabled state, or cooldown.
+func (s *Server) HandleUpdateTrigger(w http.ResponseWriter, r *http.Request) {
+	var req UpdateTriggerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	pipeline, existing, ok := s.lookupPipelineTrigger(w, r)
+	if !ok {
+		return
+	}
+	triggerID := existing.ID.String()
+
+	if req.Config != nil || req.CooldownSeconds != nil {
+		config, cooldown := existing.Config, existing.CooldownSeconds
+		if req.Config != nil {
+			config = *req.Config
+			if existing.Type == domain.TriggerTypeWebhook {
+				merged, msg := mergeWebhookConfigUpdate(existing.Config, config)
+				if msg != "" {
+					errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+					return
+				}
+				config = merged
+				req.Config = &merged
+			}
+			// Same checks as on create.
+			if !s.checkTriggerConfig(w, r, pipeline, existing.ID, existing.Type, config) {
 				return
 			}
 		}
+		if req.CooldownSeconds != nil {
+			cooldown = *req.CooldownSeconds
+		}
+		if msg := validateTriggerBackoff(existing.Type, config, cooldown); msg != "" {
+			errorJSON(w, msg, "INVALID_ARGUMENT", http.StatusBadRequest)
+			return
+		}
 	}
 
-	enabled := true
-	if req.Enabled != nil {
-		enabled = *req.Enabled
+	trigger, err := s.Triggers.UpdateTrigger(r.Context(), triggerID, req)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
 	}
-	cooldown := 0
-	if req.CooldownSeconds != nil {
-		cooldown = *req.CooldownSeconds
+	if trigger == nil {
+		errorJSON(w, "trigger not found", "NOT_FOUND", http.StatusNotFound)
+		return
 	}
 
-	trigger := &domain.PipelineTrigger{
-		PipelineID:      pipeline.ID,
-		Type:            triggerType,
-		Config:          req.Config,
-		Enabled:         enabled,
-		CooldownSeconds: cooldown,
+	writeJSON(w, http.StatusOK, s.triggerToResponse(*trigger, r))
+}
+
+// HandleFireTrigger forces a trigger to fire once so users can test it end to
+// end. Cooldown (and the enabled flag) are bypassed, but the run goes through
+// the normal trigger path: it is created atomically with the trigger's fire
+// record and submitted to the executor. The run is labeled
+// "trigger:manual:{triggerID}".
+func (s *Server) HandleFireTrigger(w http.ResponseWriter, r *http.Request) {
+	pipeline, trigger, ok := s.lookupPipelineTrigger(w, r)
+	if !ok {
+		return
 	}
 
-	if err := s.Triggers.CreateTrigger(r.Context(), trigger); err != nil {
+	run, err := s.fireTrigger(r.Context(), *trigger, pipeline, nil, "trigger:manual:"+trigger.ID.String(),
+		map[string]string{"trigger_type": string(trigger.Type)})
+	if err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, triggerToResponse(*trigger, r))
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"run_id": run.ID.String(),
+		"status": run.Status,
+	})
 }
 
-// HandleUpdateTrigger updates a trigger's config, enabled state, or cooldown.
-func (s *Server) HandleUpdateTrigger(w http.ResponseWriter, r *http.Request) {
-	triggerID := chi.URLParam(r, "triggerID")
+// HandleRotateWebhookToken replaces a webhook trigger's token. The old token
+// stops working immediately; the new plaintext is returned once, exactly as
+// on creation. The trigger keeps its id, history and other config.
+func (s *Server) HandleRotateWebhookToken(w http.ResponseWriter, r *http.Request) {
+	_, trigger, ok := s.lookupPipelineTrigger(w, r)
+	if !ok {
+		return
+	}
+	triggerID := trigger.ID.String()
+	if trigger.Type != domain.TriggerTypeWebhook {
+		errorJSON(w, "only webhook triggers have a token to rotate", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
 
-	var req UpdateTriggerRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		errorJSON(w, "invalid request body", "INVALID_ARGUMENT", http.StatusBadRequest)
+	// Decode into a raw map so config keys this handler doesn't know about
+	// survive the rewrite untouched.
+	cfg := map[string]json.RawMessage{}
+	if len(trigger.Config) > 0 {
+		if err := json.Unmarshal(trigger.Config, &cfg); err != nil {
+			internalError(w, "internal error", fmt.Errorf("decode webhook config for trigger %s: %w", triggerID, err))
+			return
+		}
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		internalError(w, "internal error", err)
 		return
 	}
+	plaintextToken := hex.EncodeToString(tokenBytes)
+	cfg["token_hash"], _ = json.Marshal(HashWebhookToken(plaintextToken))
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	rawConfig := json.RawMessage(configJSON)
 
-	trigger, err := s.Triggers.UpdateTrigger(r.Context(), triggerID, req)
+	updated, err := s.Triggers.UpdateTrigger(r.Context(), triggerID, UpdateTriggerRequest{Config: &rawConfig})
 	if err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
-	if trigger == nil {
+	if updated == nil {
 		errorJSON(w, "trigger not found", "NOT_FOUND", http.StatusNotFound)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, trigger)
+	slog.Info("webhook token rotated", "trigger_id", updated.ID)
+
+	r = r.WithContext(context.WithValue(r.Context(), webhookPlaintextTokenKey, plaintextToken))
+	writeJSON(w, http.StatusOK, s.triggerToResponse(*updated, r))
 }
 
 // HandleDeleteTrigger deletes a trigger.
 func (s *Server) HandleDeleteTrigger(w http.ResponseWriter, r *http.Request) {
-	triggerID := chi.URLParam(r, "triggerID")
+	_, trigger, ok := s.lookupPipelineTrigger(w, r)
+	if !ok {
+		return
+	}
 
-	if err := s.Triggers.DeleteTrigger(r.Context(), triggerID); err != nil {
+	if err := s.Triggers.DeleteTrigger(r.Context(), trigger.ID.String()); err != nil {
 		internalError(w, "internal error", err)
 		return
 	}
@@ -373,23 +912,49 @@ func (s *Server) HandleDeleteTrigger(w http.ResponseWriter, r *http.Request) {
 }
 
 // triggerToResponse converts a domain trigger to a JSON-serializable map,
-// enriching webhook triggers with a computed webhook_url.
+// enriching webhook triggers with a computed webhook_url and every trigger
+// with next_fire_at and last_run_status (null when not applicable).
 //
 // For webhook triggers the plaintext token is ONLY included when the request
 // context carries the one-time value (i.e. at creation time). Subsequent
 // reads never expose the token or its hash.
-func triggerToResponse(t domain.PipelineTrigger, r *http.Request) map[string]interface{} {
+func (s *Server) triggerToResponse(t domain.PipelineTrigger, r *http.Request) map[string]interface{} {
+	return triggerResponse(t, r, s.triggerLastRunStatuses(r.Context(), []domain.PipelineTrigger{t}))
+}
+
+// triggersToResponse is triggerToResponse for a list of triggers, looking up
+// all their last run statuses in one query.
+func (s *Server) triggersToResponse(triggers []domain.PipelineTrigger, r *http.Request) []map[string]interface{} {
+	statuses := s.triggerLastRunStatuses(r.Context(), triggers)
+	resps := make([]map[string]interface{}, len(triggers))
+	for i, t := range triggers {
+		resps[i] = triggerResponse(t, r, statuses)
+	}
+	return resps
+}
+
+// triggerResponse builds the response for t, taking its last_run_status from
+// statuses (keyed by run ID).
+func triggerResponse(t domain.PipelineTrigger, r *http.Request, statuses map[uuid.UUID]domain.RunStatus) map[string]interface{} {
+	var lastRunStatus *domain.RunStatus
+	if t.LastRunID != nil {
+		if status, ok := statuses[*t.LastRunID]; ok {
+			lastRunStatus = &status
+		}
+	}
 	resp := map[string]interface{}{
 		"id":               t.ID,
 		"pipeline_id":      t.PipelineID,
 		"type":             t.Type,
-		"config":           json.RawMessage(t.Config),
+		"config":           redactTriggerConfig(t),
 		"enabled":          t.Enabled,
 		"cooldown_seconds": t.CooldownSeconds,
 		"last_triggered_at": t.LastTriggeredAt,
 		"last_run_id":      t.LastRunID,
 		"created_at":       t.CreatedAt,
 		"updated_at":       t.UpdatedAt,
+		"next_fire_at":     triggerNextFireAt(t, time.Now()),
+		"last_run_status":  lastRunStatus,
 	}
 	if t.Type == domain.TriggerTypeWebhook {
 		scheme := "http"
@@ -408,10 +973,101 @@ func triggerToResponse(t domain.PipelineTrigger, r *http.Request) map[string]int
 		if plaintext, ok := r.Context().Value(webhookPlaintextTokenKey).(string); ok && plaintext != "" {
 			resp["webhook_token"] = plaintext
 		}
+		if secret, ok := r.Context().Value(webhookPlaintextSigningSecretKey).(string); ok && secret != "" {
+			resp["webhook_signing_secret"] = secret
+		}
 	}
 	return resp
 }
 
+// triggerNextFireAt computes when a schedule-driven trigger (cron,
+// cron_dependency) is next due, mirroring the evaluator: the first cron tick
+// after last_triggered_at, or after now for a trigger that never fired. An
+// overdue trigger reports now — it fires on the evaluator's next tick.
+// cron_dependency triggers additionally need fresh upstream data, so theirs
+// is the earliest possible time. Returns nil for disabled triggers, event-
+// driven types, and unparseable configs.
+func triggerNextFireAt(t domain.PipelineTrigger, now time.Time) *time.Time {
+	if !t.Enabled {
+		return nil
+	}
+	if t.Type != domain.TriggerTypeCron && t.Type != domain.TriggerTypeCronDependency {
+		return nil
+	}
+	var cfg cronConfig
+	if err := json.Unmarshal(t.Config, &cfg); err != nil || cfg.CronExpr == "" {
+		return nil
+	}
+	sched, err := cronParser.Parse(cfg.CronExpr)
+	if err != nil {
+		return nil
+	}
+	base := now
+	if t.LastTriggeredAt != nil {
+		base = *t.LastTriggeredAt
+	}
+	next := sched.Next(base)
+	if next.Before(now) {
+		next = now
+	}
+	return &next
+}
+
+// triggerLastRunStatuses returns the status of the run each trigger last
+// fired, keyed by run ID, in one query. Triggers that never fired or whose
+// run is gone (pruned by retention) have no entry. Lookup errors are logged
+// and reported as no statuses — the status is decoration, not worth failing
+// the trigger read over.
+func (s *Server) triggerLastRunStatuses(ctx context.Context, triggers []domain.PipelineTrigger) map[uuid.UUID]domain.RunStatus {
+	if s.Runs == nil {
+		return nil
+	}
+	var runIDs []uuid.UUID
+	for _, t := range triggers {
+		if t.LastRunID != nil && *t.LastRunID != uuid.Nil {
+			runIDs = append(runIDs, *t.LastRunID)
+		}
+	}
+	if len(runIDs) == 0 {
+		return nil
+	}
+	statuses, err := s.Runs.RunStatuses(ctx, runIDs)
+	if err != nil {
+		slog.Warn("failed to look up triggers' last runs", "runs", len(runIDs), "error", err)
+		return nil
+	}
+	return statuses
+}
+
+// webhookSecretConfigKeys are webhook config keys that must never leave the
+// server: the token hash and the encrypted signing secret.
+var webhookSecretConfigKeys = []string{"token_hash", "signing_secret"}
+
+// redactTriggerConfig returns the trigger config safe for API responses. For
+// webhook triggers the secret-bearing keys are removed and replaced by a
+// "signing_enabled" flag; other trigger types pass through unchanged.
+func redactTriggerConfig(t domain.PipelineTrigger) json.RawMessage {
+	if t.Type != domain.TriggerTypeWebhook || len(t.Config) == 0 {
+		return json.RawMessage(t.Config)
+	}
+	cfg := map[string]json.RawMessage{}
+	if err := json.Unmarshal(t.Config, &cfg); err != nil {
+		return json.RawMessage(`{}`)
+	}
+	_, signed := cfg["signing_secret"]
+	for _, key := range webhookSecretConfigKeys {
+		delete(cfg, key)
+	}
+	if signed {
+		cfg["signing_enabled"] = json.RawMessage(`true`)
+	}
+	redacted, err := json.Marshal(cfg)
+	if err != nil {
+		return json.RawMessage(`{}`)
+	}
+	return redacted
+}
+
 // HandleEvaluateLandingZoneTriggers is the exported entry point for evaluating
 // landing zone triggers. Used by the upload handler and tests.
 func (s *Server) HandleEvaluateLandingZoneTriggers(ctx context.Context, namespace, zoneName, filename string) {
@@ -429,8 +1085,37 @@ func (s *Server) evaluateLandingZoneTriggers(ctx context.Context, namespace, zon
 	}
 
 	now := time.Now()
+	var files []domain.LandingFile // fetched once, only if a trigger has a threshold
+	filesLoaded := false
 	for _, trigger := range triggers {
-		s.fireTriggerIfReady(ctx, trigger, now, "trigger:landing_zone_upload:"+namespace+"/"+zoneName)
+		var cfg landingZoneUploadConfig
+		if err := json.Unmarshal(trigger.Config, &cfg); err == nil && cfg.hasThreshold() {
+			if !filesLoaded {
+				if s.LandingZones == nil {
+					continue
+				}
+				zone, err := s.LandingZones.GetZone(ctx, namespace, zoneName)
+				if err != nil || zone == nil {
+					slog.Error("failed to get landing zone for trigger threshold", "namespace", namespace, "zone", zoneName, "error", err)
+					continue
+				}
+				if files, err = s.LandingZones.ListFiles(ctx, zone.ID); err != nil {
+					slog.Error("failed to list landing files for trigger threshold", "namespace", namespace, "zone", zoneName, "error", err)
+					continue
+				}
+				filesLoaded = true
+			}
+			// Below threshold: skip without firing, so no cooldown starts.
+			fileCount, totalBytes := pendingLandingFiles(files, trigger.LastTriggeredAt)
+			if !cfg.thresholdMet(fileCount, totalBytes) {
+				slog.Debug("landing zone trigger below threshold, skipping",
+					"trigger_id", trigger.ID, "files", fileCount, "bytes", totalBytes,
+					"min_files", cfg.MinFiles, "min_bytes", cfg.MinBytes)
+				continue
+			}
+		}
+		s.fireTriggerIfReady(ctx, trigger, now, nil, "trigger:landing_zone_upload:"+namespace+"/"+zoneName,
+			map[string]string{"landing_zone": namespace + "/" + zoneName, "filename": filename})
 	}
 
 	// Evaluate file_pattern triggers for this zone
@@ -446,12 +1131,13 @@ func (s *Server) evaluateLandingZoneTriggers(ctx context.Context, namespace, zon
 				slog.Warn("invalid file_pattern trigger config", "trigger_id", trigger.ID, "error", err)
 				continue
 			}
-			matched, err := filepath.Match(cfg.Pattern, filename)
-			if err != nil || !matched {
-				slog.Debug("file does not match pattern", "trigger_id", trigger.ID, "pattern", cfg.Pattern, "filename", filename)
+			glob, matched := cfg.match(filename)
+			if !matched {
+				slog.Debug("file does not match any pattern", "trigger_id", trigger.ID, "patterns", cfg.globs(), "filename", filename)
 				continue
 			}
-			s.fireTriggerIfReady(ctx, trigger, now, "trigger:file_pattern:"+namespace+"/"+zoneName+":"+cfg.Pattern)
+			s.fireTriggerIfReady(ctx, trigger, now, nil, "trigger:file_pattern:"+namespace+"/"+zoneName+":"+glob,
+				map[string]string{"landing_zone": namespace + "/" + zoneName, "filename": filename})
 		}
 	}
 }
@@ -473,38 +1159,80 @@ func (s *Server) EvaluatePipelineSuccessTriggers(ctx context.Context, run *domai
 	now := time.Now()
 	for _, trigger := range triggers {
 		triggerLabel := "trigger:pipeline_success:" + pipeline.Namespace + "/" + string(pipeline.Layer) + "/" + pipeline.Name
-		s.fireTriggerIfReady(ctx, trigger, now, triggerLabel)
+		s.fireTriggerIfReady(ctx, trigger, now, &run.ID, triggerLabel, map[string]string{"upstream_run_id": run.ID.String()})
 	}
 }
 
-// fireTriggerIfReady checks cooldown, creates a run, submits to executor, and updates trigger state.
-func (s *Server) fireTriggerIfReady(ctx context.Context, trigger domain.PipelineTrigger, now time.Time, triggerLabel string) {
-	// Check cooldown
-	if trigger.CooldownSeconds > 0 && trigger.LastTriggeredAt != nil {
-		cooldownEnd := trigger.LastTriggeredAt.Add(time.Duration(trigger.CooldownSeconds) * time.Second)
+// Reasons tryFireTrigger declines to fire a trigger.
+var (
+	errTriggerCooldown        = errors.New("cooldown active")
+	errTriggerOutsideWindow   = errors.New("outside the trigger's active window")
+	errTriggerPipelineMissing = errors.New("pipeline not found")
+)
+
+// fireTriggerIfReady fires an event-driven trigger through tryFireTrigger,
+// logging instead of returning a failure: the event that fired it has no
+// caller to report to.
+func (s *Server) fireTriggerIfReady(ctx context.Context, trigger domain.PipelineTrigger, now time.Time, parentRunID *uuid.UUID, triggerLabel string, metadata map[string]string) {
+	_, err := s.tryFireTrigger(ctx, trigger, now, parentRunID, triggerLabel, metadata)
+	if err != nil && !errors.Is(err, errTriggerCooldown) && !errors.Is(err, errTriggerOutsideWindow) &&
+		!errors.Is(err, errTriggerPipelineMissing) {
+		slog.Error("failed to fire trigger atomically", "trigger_id", trigger.ID, "error", err)
+	}
+}
+
+// tryFireTrigger checks cooldown and the active window, looks up the
+// pipeline and fires the trigger (see fireTrigger). It is the one fire path
+// shared by event-driven triggers and webhooks, which pass their payload
+// fields as metadata. Returns errTriggerCooldown, errTriggerOutsideWindow or
+// errTriggerPipelineMissing when nothing was fired for those reasons.
+// metadata (may be nil) is attached to the created run alongside the trigger ID.
+// parentRunID (may be nil) links the new run to the upstream run that fired it.
+func (s *Server) tryFireTrigger(ctx context.Context, trigger domain.PipelineTrigger, now time.Time, parentRunID *uuid.UUID, triggerLabel string, metadata map[string]string) (*domain.Run, error) {
+	// Check cooldown (grown by backoff for a flapping trigger)
+	if cooldown := triggerCooldown(trigger); cooldown > 0 && trigger.LastTriggeredAt != nil {
+		cooldownEnd := trigger.LastTriggeredAt.Add(cooldown)
 		if now.Before(cooldownEnd) {
 			slog.Debug("trigger cooldown active, skipping",
-				"trigger_id", trigger.ID, "cooldown_until", cooldownEnd)
-			return
+				"trigger_id", trigger.ID, "cooldown_until", cooldownEnd, "backoff_streak", trigger.BackoffStreak)
+			return nil, errTriggerCooldown
 		}
 	}
+	if triggerOutsideWindow(trigger, now) {
+		slog.Debug("trigger outside its active window, skipping", "trigger_id", trigger.ID)
+		return nil, errTriggerOutsideWindow
+	}
 
 	// Look up pipeline
 	pipeline, err := s.Pipelines.GetPipelineByID(ctx, trigger.PipelineID.String())
 	if err != nil {
-		slog.Error("failed to get pipeline for trigger", "trigger_id", trigger.ID, "error", err)
-		return
+		return nil, fmt.Errorf("get pipeline for trigger: %w", err)
 	}
 	if pipeline == nil {
 		slog.Warn("trigger references missing pipeline", "trigger_id", trigger.ID, "pipeline_id", trigger.PipelineID)
-		return
+		return nil, errTriggerPipelineMissing
 	}
 
+	return s.fireTrigger(ctx, trigger, pipeline, parentRunID, triggerLabel, metadata)
+}
+
+// triggerSubmitTimeout bounds submitting a triggered run to the executor.
+const triggerSubmitTimeout = 30 * time.Second
+
+// fireTrigger creates a run for the trigger, records the trigger as fired and
+// submits the run. It skips the cooldown check — callers decide readiness.
+func (s *Server) fireTrigger(ctx context.Context, trigger domain.PipelineTrigger, pipeline *domain.Pipeline, parentRunID *uuid.UUID, triggerLabel string, metadata map[string]string) (*domain.Run, error) {
 	// Create run
+	if metadata == nil {
+		metadata = make(map[string]string, 1)
+	}
+	metadata["trigger_id"] = trigger.ID.String()
 	run := &domain.Run{
-		PipelineID: pipeline.ID,
-		Status:     domain.RunStatusPending,
-		Trigger:    triggerLabel,
+		PipelineID:  pipeline.ID,
+		Status:      domain.RunStatusPending,
+		Trigger:     triggerLabel,
+		Metadata:    metadata,
+		ParentRunID: parentRunID,
 	}
 
 	// Atomic: create the run AND mark the trigger as fired in one tx so a
@@ -516,21 +1244,46 @@ func (s *Server) fireTriggerIfReady(ctx context.Context, trigger domain.Pipeline
 		if err := t.Runs.CreateRun(ctx, run); err != nil {
 			return err
 		}
-		return t.Triggers.UpdateTriggerFired(ctx, trigger.ID.String(), run.ID)
+		return recordTriggerFired(ctx, t.Triggers, trigger, run.ID)
 	}
 	if err := s.runFireTx(ctx, createAndRecord); err != nil {
-		slog.Error("failed to fire trigger atomically", "trigger_id", trigger.ID, "error", err)
-		return
+		return nil, err
 	}
 
-	// Submit to executor AFTER the tx commits. The run is already pending —
-	// if Submit fails the reaper will fail or retry it later, but we never
-	// leave the trigger state inconsistent with the run.
+	// Submit to executor AFTER the tx commits. Nothing resubmits a
+	// triggered run, so a failed submit fails it (see SubmitOrFail). Either
+	// way the trigger state stays consistent with the run. The submit gets
+	// its own deadline, detached from ctx: a webhook's request context is
+	// cancelled as soon as the response is sent.
 	if s.Executor != nil {
-		if err := s.Executor.Submit(ctx, run, pipeline); err != nil {
+		submitCtx, submitCancel := context.WithTimeout(context.WithoutCancel(ctx), triggerSubmitTimeout)
+		defer submitCancel()
+		if err := SubmitOrFail(submitCtx, s.Executor, s.Runs, run, pipeline); err != nil {
 			slog.Error("executor submit failed for triggered run", "run_id", run.ID, "error", err)
 		}
 	}
 
+	s.publishTriggerFired(ctx, trigger, run)
+
 	slog.Info("trigger fired", "trigger_id", trigger.ID, "trigger_type", trigger.Type, "run_id", run.ID)
+	return run, nil
+}
+
+// publishTriggerFired announces a trigger firing on the event bus so
+// subscribers (notifications, outbound webhooks) can react in real time.
+// Best-effort: the run already exists and is submitted, so a failed NOTIFY
+// is logged and otherwise ignored.
+func (s *Server) publishTriggerFired(ctx context.Context, trigger domain.PipelineTrigger, run *domain.Run) {
+	if s.EventBus == nil {
+		return
+	}
+	err := s.EventBus.Publish(ctx, plugins.ChannelTriggerFired, map[string]interface{}{
+		"trigger_id":  trigger.ID.String(),
+		"run_id":      run.ID.String(),
+		"pipeline_id": run.PipelineID.String(),
+		"type":        string(trigger.Type),
+	})
+	if err != nil {
+		slog.Warn("failed to publish trigger_fired event", "trigger_id", trigger.ID, "run_id", run.ID, "error", err)
+	}
 }
@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,6 +25,9 @@ import (
 type memoryTriggerStore struct {
 	mu       sync.Mutex
 	triggers []domain.PipelineTrigger
+	// pipelines resolves pipeline identity for ListAllTriggers. Triggers
+	// whose pipeline is unknown are omitted, mirroring the SQL join.
+	pipelines *memoryPipelineStore
 }
 
 func newMemoryTriggerStore() *memoryTriggerStore {
@@ -219,6 +225,71 @@ func (m *memoryTriggerStore) FindTriggersByFilePattern(_ context.Context, namesp
 	return result, nil
 }
 
+func (m *memoryTriggerStore) SetTriggersEnabledByPipeline(_ context.Context, pipelineID uuid.UUID, enabled bool) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := 0
+	for i, t := range m.triggers {
+		if t.PipelineID == pipelineID && t.Enabled != enabled {
+			m.triggers[i].Enabled = enabled
+			m.triggers[i].UpdatedAt = time.Now()
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (m *memoryTriggerStore) ListAllTriggers(_ context.Context, filter api.TriggerFilter) ([]domain.PipelineTriggerListItem, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pipelines := map[uuid.UUID]domain.Pipeline{}
+	if m.pipelines != nil {
+		m.pipelines.mu.Lock()
+		for _, p := range m.pipelines.pipelines {
+			if p.DeletedAt == nil {
+				pipelines[p.ID] = p
+			}
+		}
+		m.pipelines.mu.Unlock()
+	}
+
+	matched := []domain.PipelineTriggerListItem{}
+	for i := len(m.triggers) - 1; i >= 0; i-- { // newest first
+		t := m.triggers[i]
+		p, ok := pipelines[t.PipelineID]
+		if !ok {
+			continue
+		}
+		if filter.Type != "" && string(t.Type) != filter.Type {
+			continue
+		}
+		if filter.Enabled != nil && t.Enabled != *filter.Enabled {
+			continue
+		}
+		if filter.Namespace != "" && p.Namespace != filter.Namespace {
+			continue
+		}
+		matched = append(matched, domain.PipelineTriggerListItem{
+			PipelineTrigger: t,
+			Namespace:       p.Namespace,
+			Layer:           p.Layer,
+			PipelineName:    p.Name,
+		})
+	}
+
+	total := len(matched)
+	if filter.Offset >= len(matched) {
+		return []domain.PipelineTriggerListItem{}, total, nil
+	}
+	matched = matched[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(matched) {
+		matched = matched[:filter.Limit]
+	}
+	return matched, total, nil
+}
+
 func (m *memoryTriggerStore) UpdateTriggerFired(_ context.Context, triggerID string, runID uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -238,6 +309,19 @@ func (m *memoryTriggerStore) UpdateTriggerFired(_ context.Context, triggerID str
 	return fmt.Errorf("trigger not found")
 }
 
+func (m *memoryTriggerStore) UpdateTriggerBackoffStreak(_ context.Context, triggerID string, streak int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, t := range m.triggers {
+		if t.ID.String() == triggerID {
+			m.triggers[i].BackoffStreak = streak
+			return nil
+		}
+	}
+	return fmt.Errorf("trigger not found")
+}
+
 // UpdateTriggerFiredCAS is the race-safe variant used by the trigger
 // evaluator. It mirrors the SQL CAS at the in-memory layer: the update only
 // applies when the stored last_triggered_at matches expectedPrev (treating
@@ -280,9 +364,11 @@ func (m *memoryTriggerStore) UpdateTriggerFiredCAS(
 
 // mockExecutor records Submit calls for assertion.
 type mockExecutor struct {
-	mu      sync.Mutex
-	calls   []mockSubmitCall
-	failErr error
+	mu        sync.Mutex
+	calls     []mockSubmitCall
+	failErr   error
+	cancelled []string
+	cancelErr error
 }
 
 type mockSubmitCall struct {
@@ -298,7 +384,10 @@ func (m *mockExecutor) Submit(_ context.Context, run *domain.Run, pipeline *doma
 }
 
 func (m *mockExecutor) Cancel(_ context.Context, runID string) error {
-	return nil
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cancelled = append(m.cancelled, runID)
+	return m.cancelErr
 }
 
 func (m *mockExecutor) GetLogs(_ context.Context, runID string) ([]api.LogEntry, error) {
@@ -323,6 +412,7 @@ func (m *mockExecutor) submitCount() int {
 func newTriggerTestServer() (*api.Server, *memoryPipelineStore, *memoryTriggerStore) {
 	pipelineStore := newMemoryPipelineStore()
 	triggerStore := newMemoryTriggerStore()
+	triggerStore.pipelines = pipelineStore
 	srv := &api.Server{
 		Pipelines:    pipelineStore,
 		Runs:         newMemoryRunStore(),
@@ -495,6 +585,79 @@ func TestCreateTrigger_MissingConfig_Returns400(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestCreateTrigger_CronDependencyWindow_Validated(t *testing.T) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "orders"},
+	}
+	router := api.NewRouter(srv)
+
+	cases := map[string]int{
+		`{"window_minutes":60}`:                  http.StatusCreated,
+		`{"window_minutes":60,"fail_open":true}`: http.StatusCreated,
+		`{"window_minutes":-1}`:                  http.StatusBadRequest,
+		`{"window_minutes":10081}`:               http.StatusBadRequest,
+		`{"fail_open":true}`:                     http.StatusBadRequest,
+	}
+	for extra, want := range cases {
+		cfg := `{"cron_expr":"0 * * * *","dependencies":["default.bronze.orders"],` + extra[1:]
+		body := `{"type":"cron_dependency","config":` + cfg + `}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, "%s: %s", extra, rec.Body.String())
+	}
+}
+
+func TestCreateTrigger_WebhookInvalidMetadataField_Returns400(t *testing.T) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"type":"webhook","config":{"metadata_fields":["repository..name"]}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid metadata field")
+}
+
+func TestCreateTrigger_WebhookInvalidParamMapping_Returns400(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping string
+		wantMsg string
+	}{
+		{"bad parameter name", `{"run-date":"$.date"}`, "invalid param_mapping parameter"},
+		{"bad path", `{"run_date":"$.commit..date"}`, "invalid param_mapping path"},
+		{"empty path", `{"run_date":"$."}`, "invalid param_mapping path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, pipelineStore, _ := newTriggerTestServer()
+			pipelineStore.pipelines = []domain.Pipeline{
+				{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+			}
+			router := api.NewRouter(srv)
+
+			body := `{"type":"webhook","config":{"param_mapping":` + tt.mapping + `}}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantMsg)
+		})
+	}
+}
+
 func TestCreateTrigger_PipelineNotFound_Returns404(t *testing.T) {
 	srv, _, _ := newTriggerTestServer()
 	router := api.NewRouter(srv)
@@ -604,6 +767,168 @@ func TestUpdateTrigger_Cooldown_ReturnsUpdated(t *testing.T) {
 	assert.Equal(t, float64(120), resp["cooldown_seconds"])
 }
 
+func TestUpdateTrigger_Webhook_RedactsSecrets(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	triggerID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Config: json.RawMessage(`{"token_hash":"abc123","signing_secret":"enc:deadbeef"}`), Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"enabled":false}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String(), bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "abc123")
+	assert.NotContains(t, rec.Body.String(), "deadbeef")
+}
+
+func TestUpdateTrigger_Webhook_MergesConfigAndKeepsSecrets(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	triggerID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Enabled: true,
+			Config: json.RawMessage(`{"token_hash":"abc123","signing_secret":"enc:deadbeef","metadata_fields":["source"]}`)},
+	}
+	router := api.NewRouter(srv)
+
+	// A config as read back from GET, edited: the secrets are redacted and
+	// signing_enabled is response-only.
+	body := `{"config":{"signing_enabled":true,"param_mapping":{"run_date":"$.date"}}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String(), bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var stored map[string]interface{}
+	require.NoError(t, json.Unmarshal(triggerStore.triggers[0].Config, &stored))
+	assert.Equal(t, "abc123", stored["token_hash"])
+	assert.Equal(t, "enc:deadbeef", stored["signing_secret"])
+	assert.Equal(t, []interface{}{"source"}, stored["metadata_fields"])
+	assert.Equal(t, map[string]interface{}{"run_date": "$.date"}, stored["param_mapping"])
+	assert.NotContains(t, stored, "signing_enabled")
+}
+
+func TestUpdateTrigger_Webhook_RejectsSecretsAndInvalidOptions(t *testing.T) {
+	cases := map[string]string{
+		"token hash":      `{"token_hash":"mine"}`,
+		"signing secret":  `{"signing_secret":"0123456789abcdef0123456789abcdef"}`,
+		"generate secret": `{"generate_signing_secret":true}`,
+		"bad mapping":     `{"param_mapping":{"run-date":"$.date"}}`,
+		"bad field":       `{"metadata_fields":["a..b"]}`,
+	}
+	for name, cfg := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, pipelineStore, triggerStore := newTriggerTestServer()
+			pipelineID := uuid.New()
+			triggerID := uuid.New()
+			pipelineStore.pipelines = []domain.Pipeline{
+				{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+			}
+			stored := json.RawMessage(`{"token_hash":"abc123"}`)
+			triggerStore.triggers = []domain.PipelineTrigger{
+				{ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Config: stored, Enabled: true},
+			}
+			router := api.NewRouter(srv)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String(),
+				bytes.NewBufferString(`{"config":`+cfg+`}`))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.JSONEq(t, string(stored), string(triggerStore.triggers[0].Config))
+		})
+	}
+}
+
+// Updates run the same per-type config checks as create.
+func TestUpdateTrigger_InvalidConfig_Returns400(t *testing.T) {
+	cases := map[string]struct {
+		triggerType domain.TriggerType
+		stored      string
+		update      string
+	}{
+		"negative min_files": {domain.TriggerTypeLandingZoneUpload,
+			`{"namespace":"default","zone_name":"orders"}`,
+			`{"namespace":"default","zone_name":"orders","min_files":-1}`},
+		"bad match_type": {domain.TriggerTypeFilePattern,
+			`{"namespace":"default","zone_name":"orders","pattern":"*.csv"}`,
+			`{"namespace":"default","zone_name":"orders","pattern":"*.csv","match_type":"fuzzy"}`},
+		"bad regex": {domain.TriggerTypeFilePattern,
+			`{"namespace":"default","zone_name":"orders","pattern":"*.csv"}`,
+			`{"namespace":"default","zone_name":"orders","patterns":["(unclosed"],"match_type":"regex"}`},
+		"missing patterns": {domain.TriggerTypeFilePattern,
+			`{"namespace":"default","zone_name":"orders","pattern":"*.csv"}`,
+			`{"namespace":"default","zone_name":"orders"}`},
+		"window too long": {domain.TriggerTypeCronDependency,
+			`{"cron_expr":"0 * * * *","dependencies":["default.bronze.ingest"]}`,
+			`{"cron_expr":"0 * * * *","dependencies":["default.bronze.ingest"],"window_minutes":100000}`},
+		"fail_open without window": {domain.TriggerTypeCronDependency,
+			`{"cron_expr":"0 * * * *","dependencies":["default.bronze.ingest"]}`,
+			`{"cron_expr":"0 * * * *","dependencies":["default.bronze.ingest"],"fail_open":true}`},
+		"bad cron": {domain.TriggerTypeCron,
+			`{"cron_expr":"0 * * * *"}`,
+			`{"cron_expr":"not a cron"}`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv, pipelineStore, triggerStore := newTriggerTestServer()
+			pipelineID := uuid.New()
+			triggerID := uuid.New()
+			pipelineStore.pipelines = []domain.Pipeline{
+				{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+			}
+			srv.LandingZones.(*memoryLandingZoneStore).zones = []api.LandingZoneListItem{
+				{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "orders"}},
+			}
+			triggerStore.triggers = []domain.PipelineTrigger{
+				{ID: triggerID, PipelineID: pipelineID, Type: tc.triggerType, Config: json.RawMessage(tc.stored), Enabled: true},
+			}
+			router := api.NewRouter(srv)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String(),
+				bytes.NewBufferString(`{"config":`+tc.update+`}`))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+			assert.JSONEq(t, tc.stored, string(triggerStore.triggers[0].Config))
+		})
+	}
+}
+
+func TestUpdateTrigger_UnknownLandingZone_Returns404(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	triggerID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeLandingZoneUpload, Config: json.RawMessage(`{}`), Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"config":{"namespace":"default","zone_name":"missing"}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String(), bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
 func TestUpdateTrigger_NotFound_Returns404(t *testing.T) {
 	srv, pipelineStore, _ := newTriggerTestServer()
 	pipelineStore.pipelines = []domain.Pipeline{
@@ -681,11 +1006,43 @@ func TestEvaluateTriggers_MatchingTrigger_FiresRun(t *testing.T) {
 	runStore.mu.Lock()
 	assert.Len(t, runStore.runs, 1)
 	assert.Equal(t, "trigger:landing_zone_upload:default/orders", runStore.runs[0].Trigger)
+	assert.Equal(t, triggerStore.triggers[0].ID.String(), runStore.runs[0].Metadata["trigger_id"])
+	assert.Equal(t, "default/orders", runStore.runs[0].Metadata["landing_zone"])
 	runStore.mu.Unlock()
 
 	assert.Equal(t, 1, exec.submitCount())
 }
 
+func TestEvaluatePipelineSuccessTriggers_SetsParentRunID(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	upstreamID := uuid.New()
+	downstreamID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: upstreamID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+		{ID: downstreamID, Namespace: "default", Layer: domain.LayerSilver, Name: "clean"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{
+			ID:         uuid.New(),
+			PipelineID: downstreamID,
+			Type:       domain.TriggerTypePipelineSuccess,
+			Config:     json.RawMessage(`{"namespace":"default","layer":"bronze","pipeline":"ingest"}`),
+			Enabled:    true,
+		},
+	}
+
+	upstream := &domain.Run{ID: uuid.New(), PipelineID: upstreamID, Status: domain.RunStatusSuccess}
+	srv.EvaluatePipelineSuccessTriggers(context.Background(), upstream)
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1)
+	require.NotNil(t, runStore.runs[0].ParentRunID)
+	assert.Equal(t, upstream.ID, *runStore.runs[0].ParentRunID)
+	assert.Equal(t, downstreamID, runStore.runs[0].PipelineID)
+}
+
 func TestEvaluateTriggers_CooldownActive_SkipsRun(t *testing.T) {
 	srv, pipelineStore, triggerStore := newTriggerTestServer()
 	pipelineID := uuid.New()
@@ -764,66 +1121,1404 @@ func TestEvaluateTriggers_DisabledTrigger_SkipsRun(t *testing.T) {
 	assert.Len(t, runStore.runs, 0)
 }
 
-func TestEvaluateTriggers_MultiplePipelines_AllFire(t *testing.T) {
+// newLandingTriggerServer returns a server with an empty default/orders
+// landing zone watched by one landing_zone_upload trigger with config cfg and
+// the given cooldown in seconds.
+func newLandingTriggerServer(cfg string, cooldown int) (*api.Server, *memoryTriggerStore) {
 	srv, pipelineStore, triggerStore := newTriggerTestServer()
-	pipeline1ID := uuid.New()
-	pipeline2ID := uuid.New()
 	pipelineStore.pipelines = []domain.Pipeline{
-		{ID: pipeline1ID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest-a"},
-		{ID: pipeline2ID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest-b"},
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
 	}
-	triggerStore.triggers = []domain.PipelineTrigger{
-		{
-			ID:         uuid.New(),
-			PipelineID: pipeline1ID,
-			Type:       domain.TriggerTypeLandingZoneUpload,
-			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
-			Enabled:    true,
-		},
-		{
-			ID:         uuid.New(),
-			PipelineID: pipeline2ID,
-			Type:       domain.TriggerTypeLandingZoneUpload,
-			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
-			Enabled:    true,
-		},
+	lzStore := srv.LandingZones.(*memoryLandingZoneStore)
+	lzStore.zones = []api.LandingZoneListItem{{LandingZone: domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "orders"}}}
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID:              uuid.New(),
+		PipelineID:      pipelineStore.pipelines[0].ID,
+		Type:            domain.TriggerTypeLandingZoneUpload,
+		Config:          json.RawMessage(cfg),
+		Enabled:         true,
+		CooldownSeconds: cooldown,
+	}}
+	srv.Executor = &mockExecutor{}
+	return srv, triggerStore
+}
+
+// addLandingFiles uploads files of the given sizes to srv's default/orders
+// zone, named f0.csv, f1.csv, ...
+func addLandingFiles(srv *api.Server, sizes ...int64) {
+	lzStore := srv.LandingZones.(*memoryLandingZoneStore)
+	zoneID := lzStore.zones[0].ID
+	for i, size := range sizes {
+		lzStore.files = append(lzStore.files, domain.LandingFile{ID: uuid.New(), ZoneID: zoneID, Filename: fmt.Sprintf("f%d.csv", i), SizeBytes: size})
 	}
+}
 
-	exec := &mockExecutor{}
-	srv.Executor = exec
+func TestEvaluateTriggers_BelowThreshold_SkipsWithoutCooldown(t *testing.T) {
+	srv, triggerStore := newLandingTriggerServer(`{"namespace":"default","zone_name":"orders","min_files":3,"min_bytes":1000}`, 60)
+	addLandingFiles(srv, 100, 200)
 
-	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "")
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f1.csv")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	assert.Len(t, runStore.runs, 0)
+	runStore.mu.Unlock()
+	assert.Nil(t, triggerStore.triggers[0].LastTriggeredAt, "a skipped trigger must not start its cooldown")
+}
+
+func TestEvaluateTriggers_FileCountThresholdReached_FiresRun(t *testing.T) {
+	srv, triggerStore := newLandingTriggerServer(`{"namespace":"default","zone_name":"orders","min_files":3}`, 60)
+	addLandingFiles(srv, 100, 200, 300)
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f2.csv")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	assert.Len(t, runStore.runs, 1)
+	runStore.mu.Unlock()
+	assert.NotNil(t, triggerStore.triggers[0].LastTriggeredAt)
+}
+
+func TestEvaluateTriggers_ByteThresholdReached_FiresRun(t *testing.T) {
+	// Either threshold is enough: two files is below min_files, but the bytes add up.
+	srv, _ := newLandingTriggerServer(`{"namespace":"default","zone_name":"orders","min_files":10,"min_bytes":1000}`, 60)
+	addLandingFiles(srv, 600, 500)
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f1.csv")
 
 	runStore := srv.Runs.(*memoryRunStore)
 	runStore.mu.Lock()
 	defer runStore.mu.Unlock()
-	assert.Len(t, runStore.runs, 2)
-	assert.Equal(t, 2, exec.submitCount())
+	assert.Len(t, runStore.runs, 1)
 }
 
-func TestEvaluateTriggers_ExecutorFailure_StillCreatesRun(t *testing.T) {
+func TestEvaluateTriggers_ProcessedFilesDontCountTowardsThreshold(t *testing.T) {
+	srv, triggerStore := newLandingTriggerServer(`{"namespace":"default","zone_name":"orders","min_files":3}`, 60)
+	addLandingFiles(srv, 100, 200, 300, 400)
+	// The first three files were uploaded before the last fire, so its run took them.
+	lastFired := time.Now().Add(-time.Hour)
+	triggerStore.triggers[0].LastTriggeredAt = &lastFired
+	lzStore := srv.LandingZones.(*memoryLandingZoneStore)
+	for i := range lzStore.files {
+		lzStore.files[i].UploadedAt = lastFired.Add(-time.Minute)
+	}
+	lzStore.files[3].UploadedAt = lastFired.Add(time.Minute)
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f3.csv")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	assert.Len(t, runStore.runs, 0, "one new file is below min_files")
+	runStore.mu.Unlock()
+	assert.Equal(t, lastFired, *triggerStore.triggers[0].LastTriggeredAt)
+}
+
+func TestCreateTrigger_NegativeThreshold_Returns400(t *testing.T) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"type":"landing_zone_upload","config":{"namespace":"default","zone_name":"orders","min_files":-1}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestEvaluateTriggers_OutsideActiveWindow_SkipsRun(t *testing.T) {
+	// An hour-long window opening 12 hours from now is closed whatever the
+	// time of day.
+	start := time.Now().UTC().Add(12 * time.Hour)
+	window := `{"start":"` + start.Format("15:04") + `","end":"` + start.Add(time.Hour).Format("15:04") + `"}`
+	srv, triggerStore := newLandingTriggerServer(`{"namespace":"default","zone_name":"orders","active_window":`+window+`}`, 0)
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f.csv")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	assert.Len(t, runStore.runs, 0)
+	runStore.mu.Unlock()
+	assert.Nil(t, triggerStore.triggers[0].LastTriggeredAt)
+}
+
+func TestCreateTrigger_InvalidActiveWindow_Returns400(t *testing.T) {
 	srv, pipelineStore, triggerStore := newTriggerTestServer()
-	pipelineID := uuid.New()
 	pipelineStore.pipelines = []domain.Pipeline{
-		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
 	}
-	triggerStore.triggers = []domain.PipelineTrigger{
-		{
-			ID:         uuid.New(),
-			PipelineID: pipelineID,
-			Type:       domain.TriggerTypeLandingZoneUpload,
-			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
-			Enabled:    true,
-		},
+	router := api.NewRouter(srv)
+
+	body := `{"type":"webhook","config":{"active_window":{"start":"25:00","end":"08:00"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, triggerStore.triggers)
+}
+
+func TestCreateTrigger_WebhookKeepsActiveWindow(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
 	}
+	router := api.NewRouter(srv)
 
-	exec := &mockExecutor{failErr: fmt.Errorf("executor unavailable")}
-	srv.Executor = exec
+	body := `{"type":"webhook","config":{"active_window":{"days":["mon","tue"],"start":"08:00","end":"20:00","tz":"Europe/Paris"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
 
-	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "")
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	require.Len(t, triggerStore.triggers, 1)
+	assert.Contains(t, string(triggerStore.triggers[0].Config), `"active_window":{"days":["mon","tue"],"start":"08:00","end":"20:00","tz":"Europe/Paris"}`)
+}
 
+// backoffTriggerConfig is a landing zone trigger config whose 60s cooldown
+// backs off up to 480s and resets after an hour of quiet.
+const backoffTriggerConfig = `{"namespace":"default","zone_name":"orders","backoff":{"max_cooldown_seconds":480,"reset_after_seconds":3600}}`
+
+func TestEvaluateTriggers_Backoff_CooldownGrowsAcrossRapidFires(t *testing.T) {
+	srv, triggerStore := newLandingTriggerServer(backoffTriggerConfig, 60)
 	runStore := srv.Runs.(*memoryRunStore)
-	runStore.mu.Lock()
-	defer runStore.mu.Unlock()
-	assert.Len(t, runStore.runs, 1) // Run was still created even though executor failed
+	runCount := func() int {
+		runStore.mu.Lock()
+		defer runStore.mu.Unlock()
+		return len(runStore.runs)
+	}
+	// lastFiredAgo rewinds the trigger's last fire so each step can check
+	// the effective cooldown without sleeping.
+	lastFiredAgo := func(d time.Duration) {
+		triggerStore.mu.Lock()
+		defer triggerStore.mu.Unlock()
+		at := time.Now().Add(-d)
+		triggerStore.triggers[0].LastTriggeredAt = &at
+	}
+	evaluate := func() {
+		srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f.csv")
+	}
+
+	evaluate()
+	require.Equal(t, 1, runCount())
+	assert.Equal(t, 0, triggerStore.triggers[0].BackoffStreak, "first fire starts with the base cooldown")
+
+	steps := []struct {
+		cooldown time.Duration
+		streak   int
+	}{
+		{60 * time.Second, 1},
+		{120 * time.Second, 2},
+		{240 * time.Second, 3},
+		{480 * time.Second, 3}, // capped at max_cooldown_seconds
+		{480 * time.Second, 3},
+	}
+	for i, step := range steps {
+		wantRuns := runCount()
+
+		lastFiredAgo(step.cooldown - 5*time.Second)
+		evaluate()
+		require.Equal(t, wantRuns, runCount(), "step %d: fired inside its %s cooldown", i, step.cooldown)
+
+		lastFiredAgo(step.cooldown + 5*time.Second)
+		evaluate()
+		require.Equal(t, wantRuns+1, runCount(), "step %d: did not fire after its %s cooldown", i, step.cooldown)
+		assert.Equal(t, step.streak, triggerStore.triggers[0].BackoffStreak, "step %d", i)
+	}
+}
+
+func TestEvaluateTriggers_Backoff_ResetsAfterQuietPeriod(t *testing.T) {
+	srv, triggerStore := newLandingTriggerServer(backoffTriggerConfig, 60)
+	at := time.Now().Add(-61 * time.Minute)
+	triggerStore.triggers[0].LastTriggeredAt = &at
+	triggerStore.triggers[0].BackoffStreak = 3
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f.csv")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	assert.Len(t, runStore.runs, 1)
+	runStore.mu.Unlock()
+	assert.Equal(t, 0, triggerStore.triggers[0].BackoffStreak)
+
+	// Back to the base cooldown: 65s after the fire is enough again.
+	at = time.Now().Add(-65 * time.Second)
+	triggerStore.triggers[0].LastTriggeredAt = &at
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "f.csv")
+
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Len(t, runStore.runs, 2)
+	assert.Equal(t, 1, triggerStore.triggers[0].BackoffStreak)
+}
+
+func TestWebhookTrigger_Backoff_GrowsStreakAcrossRapidFires(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	cfg, err := json.Marshal(map[string]interface{}{
+		"token_hash": api.HashWebhookToken("secret-token"),
+		"backoff":    map[string]int{"max_cooldown_seconds": 480, "reset_after_seconds": 3600},
+	})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID: uuid.New(), PipelineID: pipelineStore.pipelines[0].ID, Type: domain.TriggerTypeWebhook,
+		Config: cfg, Enabled: true, CooldownSeconds: 60,
+	}}
+	router := api.NewRouter(srv)
+
+	require.Equal(t, http.StatusCreated, postWebhook(router, "").Code)
+	assert.Equal(t, 0, triggerStore.triggers[0].BackoffStreak)
+
+	// Fired again just past the base cooldown: the streak grows, so the
+	// next cooldown is doubled.
+	at := time.Now().Add(-65 * time.Second)
+	triggerStore.triggers[0].LastTriggeredAt = &at
+	require.Equal(t, http.StatusCreated, postWebhook(router, "").Code)
+	assert.Equal(t, 1, triggerStore.triggers[0].BackoffStreak)
+
+	at = time.Now().Add(-65 * time.Second)
+	triggerStore.triggers[0].LastTriggeredAt = &at
+	assert.Equal(t, http.StatusTooManyRequests, postWebhook(router, "").Code)
+}
+
+func TestWebhookTrigger_MissingPipeline_Returns404(t *testing.T) {
+	srv, _, triggerStore := newTriggerTestServer()
+	cfg, err := json.Marshal(map[string]interface{}{"token_hash": api.HashWebhookToken("secret-token")})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID: uuid.New(), PipelineID: uuid.New(), Type: domain.TriggerTypeWebhook, Config: cfg, Enabled: true,
+	}}
+	router := api.NewRouter(srv)
+
+	rec := postWebhook(router, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "pipeline not found")
+	assert.Nil(t, triggerStore.triggers[0].LastTriggeredAt, "nothing fired")
+}
+
+func TestCreateTrigger_InvalidBackoff_Returns400(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	router := api.NewRouter(srv)
+
+	for _, body := range []string{
+		`{"type":"webhook","config":{"backoff":{"max_cooldown_seconds":600}}}`,
+		`{"type":"webhook","cooldown_seconds":60,"config":{"backoff":{"max_cooldown_seconds":30}}}`,
+		`{"type":"webhook","cooldown_seconds":60,"config":{"backoff":{"max_cooldown_seconds":600,"reset_after_seconds":300}}}`,
+		`{"type":"cron","cooldown_seconds":60,"config":{"cron_expr":"0 * * * *","backoff":{"max_cooldown_seconds":600}}}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	assert.Empty(t, triggerStore.triggers)
+}
+
+func TestCreateTrigger_WebhookKeepsBackoff(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"type":"webhook","cooldown_seconds":30,"config":{"backoff":{"max_cooldown_seconds":600,"reset_after_seconds":1800}}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	require.Len(t, triggerStore.triggers, 1)
+	assert.Contains(t, string(triggerStore.triggers[0].Config), `"backoff":{"max_cooldown_seconds":600,"reset_after_seconds":1800}`)
+}
+
+func TestEvaluateTriggers_MultiplePipelines_AllFire(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipeline1ID := uuid.New()
+	pipeline2ID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipeline1ID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest-a"},
+		{ID: pipeline2ID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest-b"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{
+			ID:         uuid.New(),
+			PipelineID: pipeline1ID,
+			Type:       domain.TriggerTypeLandingZoneUpload,
+			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
+			Enabled:    true,
+		},
+		{
+			ID:         uuid.New(),
+			PipelineID: pipeline2ID,
+			Type:       domain.TriggerTypeLandingZoneUpload,
+			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
+			Enabled:    true,
+		},
+	}
+
+	exec := &mockExecutor{}
+	srv.Executor = exec
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Len(t, runStore.runs, 2)
+	assert.Equal(t, 2, exec.submitCount())
+}
+
+func TestEvaluateTriggers_ExecutorFailure_StillCreatesRun(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{
+			ID:         uuid.New(),
+			PipelineID: pipelineID,
+			Type:       domain.TriggerTypeLandingZoneUpload,
+			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
+			Enabled:    true,
+		},
+	}
+
+	exec := &mockExecutor{failErr: fmt.Errorf("submit pipeline: %w", api.ErrRunnerUnavailable)}
+	srv.Executor = exec
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1) // Run was still created even though executor failed
+	// Nothing resubmits a triggered run, so a transient outage fails it.
+	assert.Equal(t, domain.RunStatusFailed, runStore.runs[0].Status)
+}
+
+// --- Webhook ---
+
+func TestWebhookTrigger_MetadataFields_CopiedIntoRun(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	triggerID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	cfg, err := json.Marshal(map[string]interface{}{
+		"token_hash":      api.HashWebhookToken("secret-token"),
+		"metadata_fields": []string{"source", "repository.name", "attempt", "missing", "nothing"},
+	})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Config: cfg, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"source":"github","repository":{"name":"rat"},"attempt":3,"nothing":null,"ignored":"x"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBufferString(body))
+	req.Header.Set("X-Webhook-Token", "secret-token")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1)
+	assert.Equal(t, map[string]string{
+		"source":          "github",
+		"repository.name": "rat",
+		"attempt":         "3",
+		"trigger_id":      triggerID.String(),
+	}, runStore.runs[0].Metadata)
+}
+
+func TestWebhookTrigger_LongMultiByteField_TruncatedOnRuneBoundary(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	cfg, err := json.Marshal(map[string]interface{}{
+		"token_hash":      api.HashWebhookToken("secret-token"),
+		"metadata_fields": []string{"message"},
+	})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: pipelineStore.pipelines[0].ID, Type: domain.TriggerTypeWebhook, Config: cfg, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	// "ab" shifts the 3-byte runes so byte 1024 falls inside one.
+	message := "ab" + strings.Repeat("€", 400)
+	body, err := json.Marshal(map[string]string{"message": message})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(body))
+	req.Header.Set("X-Webhook-Token", "secret-token")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1)
+	got := runStore.runs[0].Metadata["message"]
+	assert.True(t, utf8.ValidString(got), "truncation must not split a rune")
+	assert.Equal(t, "ab"+strings.Repeat("€", 340), got)
+}
+
+func TestWebhookTrigger_ParamMapping_MapsPayloadIntoRunParameters(t *testing.T) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"type":"webhook","config":{"metadata_fields":["source"],"param_mapping":{"run_date":"$.commit.date","limit":"batch.size","branch":"ref"}}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	token, _ := created["webhook_token"].(string)
+	require.NotEmpty(t, token)
+
+	payload := `{"source":"ci","commit":{"date":"2026-03-01"},"batch":{"size":500}}`
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBufferString(payload))
+	req.Header.Set("X-Webhook-Token", token)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1)
+	run := runStore.runs[0]
+	assert.Equal(t, "ci", run.Metadata["source"])
+	assert.Equal(t, "2026-03-01", run.Metadata["param.run_date"])
+	assert.Equal(t, map[string]string{"run_date": "2026-03-01", "limit": "500"}, api.RunParameters(&run),
+		"fields missing from the payload are skipped")
+}
+
+func TestWebhookTrigger_NonJSONBody_StillFires(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	cfg, err := json.Marshal(map[string]interface{}{
+		"token_hash":      api.HashWebhookToken("secret-token"),
+		"metadata_fields": []string{"source"},
+	})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Config: cfg, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBufferString("not json"))
+	req.Header.Set("X-Webhook-Token", "secret-token")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1)
+	assert.NotContains(t, runStore.runs[0].Metadata, "source")
+}
+
+// --- Webhook idempotency keys ---
+
+func newIdempotentWebhookTestServer(t *testing.T) (*api.Server, http.Handler) {
+	t.Helper()
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	cfg, err := json.Marshal(map[string]interface{}{"token_hash": api.HashWebhookToken("secret-token")})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Config: cfg, Enabled: true},
+	}
+	return srv, api.NewRouter(srv)
+}
+
+func postWebhook(router http.Handler, idempotencyKey string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", http.NoBody)
+	req.Header.Set("X-Webhook-Token", "secret-token")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWebhookTrigger_SameIdempotencyKey_CreatesOneRun(t *testing.T) {
+	srv, router := newIdempotentWebhookTestServer(t)
+
+	first := postWebhook(router, "delivery-1")
+	require.Equal(t, http.StatusCreated, first.Code, first.Body.String())
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	second := postWebhook(router, "delivery-1")
+	require.Equal(t, http.StatusCreated, second.Code, second.Body.String())
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, first.Body.String(), second.Body.String(), "replay returns the original run")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Len(t, runStore.runs, 1)
+}
+
+func TestWebhookTrigger_DifferentIdempotencyKeys_CreateSeparateRuns(t *testing.T) {
+	srv, router := newIdempotentWebhookTestServer(t)
+
+	require.Equal(t, http.StatusCreated, postWebhook(router, "delivery-1").Code)
+	require.Equal(t, http.StatusCreated, postWebhook(router, "delivery-2").Code)
+	require.Equal(t, http.StatusCreated, postWebhook(router, "").Code)
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Len(t, runStore.runs, 3)
+}
+
+func TestWebhookTrigger_IdempotencyKeyTooLong_Returns400(t *testing.T) {
+	_, router := newIdempotentWebhookTestServer(t)
+
+	rec := postWebhook(router, strings.Repeat("k", 256))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// --- Webhook HMAC signatures ---
+
+// createSignedWebhook creates a webhook trigger with a generated signing
+// secret via the API and returns the one-time token and secret.
+func createSignedWebhook(t *testing.T, router http.Handler) (token, secret string) {
+	t.Helper()
+	body := `{"type":"webhook","config":{"generate_signing_secret":true}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	token, _ = resp["webhook_token"].(string)
+	secret, _ = resp["webhook_signing_secret"].(string)
+	require.NotEmpty(t, token)
+	require.Len(t, secret, 64)
+	assert.NotContains(t, rec.Body.String(), `"signing_secret":"`+secret)
+	return token, secret
+}
+
+func newSignedWebhookTestServer() (*api.Server, http.Handler) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	srv.WebhookSecretKey = api.WebhookSecretKey("test-passphrase")
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	return srv, api.NewRouter(srv)
+}
+
+func TestWebhookTrigger_ValidSignature_Fires(t *testing.T) {
+	_, router := newSignedWebhookTestServer()
+	token, secret := createSignedWebhook(t, router)
+
+	payload := []byte(`{"event":"push"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(payload))
+	req.Header.Set("X-Webhook-Token", token)
+	req.Header.Set("X-Signature-256", "sha256="+api.SignWebhookBody(secret, payload))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+}
+
+func TestWebhookTrigger_InvalidSignature_Returns401(t *testing.T) {
+	srv, router := newSignedWebhookTestServer()
+	token, secret := createSignedWebhook(t, router)
+
+	payload := []byte(`{"event":"push"}`)
+	for name, sig := range map[string]string{
+		"missing":       "",
+		"wrong secret":  api.SignWebhookBody("another-secret-value", payload),
+		"tampered body": api.SignWebhookBody(secret, []byte(`{"event":"delete"}`)),
+		"not hex":       "zzzz",
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewReader(payload))
+			req.Header.Set("X-Webhook-Token", token)
+			if sig != "" {
+				req.Header.Set("X-Signature-256", sig)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	assert.Empty(t, runStore.runs, "no run should fire on a bad signature")
+}
+
+func TestWebhookTrigger_NoSigningSecret_SignatureNotRequired(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	cfg, err := json.Marshal(map[string]interface{}{"token_hash": api.HashWebhookToken("secret-token")})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Config: cfg, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBufferString(`{}`))
+	req.Header.Set("X-Webhook-Token", "secret-token")
+	req.Header.Set("X-Signature-256", "deadbeef")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateTrigger_WebhookSigningSecretWithoutKey_Returns400(t *testing.T) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"type":"webhook","config":{"signing_secret":"0123456789abcdef0123"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "RAT_WEBHOOK_SECRET_KEY")
+}
+
+// --- Rotate webhook token ---
+
+func TestRotateWebhookToken_OldTokenStopsWorking_NewTokenFires(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	triggerID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	cfg, err := json.Marshal(map[string]interface{}{
+		"token_hash":      api.HashWebhookToken("old-token"),
+		"metadata_fields": []string{"source"},
+	})
+	require.NoError(t, err)
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeWebhook, Config: cfg, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String()+"/rotate-token", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	newToken, _ := resp["webhook_token"].(string)
+	require.Len(t, newToken, 64)
+	assert.Equal(t, triggerID.String(), resp["id"])
+
+	fire := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", bytes.NewBufferString(`{}`))
+		req.Header.Set("X-Webhook-Token", token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusNotFound, fire("old-token"))
+	assert.Equal(t, http.StatusCreated, fire(newToken))
+
+	// Other config survives the rotation.
+	stored, err := triggerStore.GetTrigger(context.Background(), triggerID.String())
+	require.NoError(t, err)
+	assert.Contains(t, string(stored.Config), `"metadata_fields":["source"]`)
+}
+
+func TestRotateWebhookToken_NonWebhookTrigger_Returns400(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	triggerID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeCron, Config: json.RawMessage(`{"cron_expr":"0 * * * *"}`), Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String()+"/rotate-token", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRotateWebhookToken_UnknownTrigger_Returns404(t *testing.T) {
+	srv, _, _ := newTriggerTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers/"+uuid.New().String()+"/rotate-token", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// --- File pattern triggers ---
+
+func TestEvaluateTriggers_FilePatternMultipleGlobs_FiresOnlyForMatches(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{
+			ID:         uuid.New(),
+			PipelineID: pipelineID,
+			Type:       domain.TriggerTypeFilePattern,
+			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders","patterns":["*.csv","*.parquet"]}`),
+			Enabled:    true,
+		},
+	}
+
+	for _, filename := range []string{"a.csv", "b.json", "c.parquet", "d.txt", "e.csv.bak"} {
+		srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", filename)
+	}
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 2)
+	fired := []string{runStore.runs[0].Metadata["filename"], runStore.runs[1].Metadata["filename"]}
+	assert.ElementsMatch(t, []string{"a.csv", "c.parquet"}, fired)
+	labels := []string{runStore.runs[0].Trigger, runStore.runs[1].Trigger}
+	assert.ElementsMatch(t, []string{
+		"trigger:file_pattern:default/orders:*.csv",
+		"trigger:file_pattern:default/orders:*.parquet",
+	}, labels)
+}
+
+func TestEvaluateTriggers_FilePatternLegacyPattern_StillMatches(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{
+			ID:         uuid.New(),
+			PipelineID: pipelineID,
+			Type:       domain.TriggerTypeFilePattern,
+			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders","pattern":"orders_*.csv"}`),
+			Enabled:    true,
+		},
+	}
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "orders_2026.csv")
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "returns_2026.csv")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1)
+	assert.Equal(t, "orders_2026.csv", runStore.runs[0].Metadata["filename"])
+}
+
+func TestCreateTrigger_FilePatternInvalidGlobInPatterns_Returns400(t *testing.T) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	router := api.NewRouter(srv)
+
+	body := `{"type":"file_pattern","config":{"namespace":"default","zone_name":"orders","patterns":["*.csv","[bad"]}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "[bad")
+}
+
+func TestEvaluateTriggers_FilePatternRegex_FiresOnlyForMatches(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{
+			ID:         uuid.New(),
+			PipelineID: pipelineID,
+			Type:       domain.TriggerTypeFilePattern,
+			Config:     json.RawMessage(`{"namespace":"default","zone_name":"orders","match_type":"regex","pattern":"_\\d{4}-\\d{2}-\\d{2}\\.csv$"}`),
+			Enabled:    true,
+		},
+	}
+
+	for _, filename := range []string{"orders_2026-10-16.csv", "orders_latest.csv", "orders_2026-10-16.csv.tmp"} {
+		srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", filename)
+	}
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	defer runStore.mu.Unlock()
+	require.Len(t, runStore.runs, 1)
+	assert.Equal(t, "orders_2026-10-16.csv", runStore.runs[0].Metadata["filename"])
+}
+
+func TestCreateTrigger_FilePatternRegexValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantMsg string
+	}{
+		{"invalid regex", `{"namespace":"default","zone_name":"orders","match_type":"regex","pattern":"(unclosed"}`, "invalid regex pattern"},
+		{"regex too long", `{"namespace":"default","zone_name":"orders","match_type":"regex","pattern":"` + strings.Repeat("a", 257) + `"}`, "regex pattern too long"},
+		{"unknown match type", `{"namespace":"default","zone_name":"orders","match_type":"fuzzy","pattern":"*.csv"}`, "match_type"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, pipelineStore, _ := newTriggerTestServer()
+			pipelineStore.pipelines = []domain.Pipeline{
+				{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+			}
+			router := api.NewRouter(srv)
+
+			body := `{"type":"file_pattern","config":` + tc.config + `}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.wantMsg)
+		})
+	}
+}
+
+func TestCreateTrigger_FilePatternRegex_Returns201(t *testing.T) {
+	srv, pipelineStore, _ := newTriggerTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	require.NoError(t, srv.LandingZones.CreateZone(context.Background(), &domain.LandingZone{Namespace: "default", Name: "orders"}))
+	router := api.NewRouter(srv)
+
+	body := `{"type":"file_pattern","config":{"namespace":"default","zone_name":"orders","match_type":"regex","patterns":["\\.csv$","^export_"]}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+}
+
+// --- Global trigger listing ---
+
+func seedGlobalTriggers(t *testing.T) (http.Handler, *memoryTriggerStore) {
+	t.Helper()
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	ingest, report := uuid.New(), uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: ingest, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+		{ID: report, Namespace: "finance", Layer: domain.LayerGold, Name: "report"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: ingest, Type: domain.TriggerTypeCron, Config: json.RawMessage(`{"cron_expr":"0 * * * *"}`), Enabled: true},
+		{ID: uuid.New(), PipelineID: ingest, Type: domain.TriggerTypeWebhook, Config: json.RawMessage(`{"token_hash":"deadbeefcafe","signing_secret":"c2VjcmV0","metadata_fields":["source"]}`), Enabled: true},
+		{ID: uuid.New(), PipelineID: report, Type: domain.TriggerTypeCron, Config: json.RawMessage(`{"cron_expr":"0 6 * * *"}`), Enabled: false},
+		{ID: uuid.New(), PipelineID: uuid.New(), Type: domain.TriggerTypeCron, Config: json.RawMessage(`{"cron_expr":"0 6 * * *"}`), Enabled: true}, // orphan
+	}
+	return api.NewRouter(srv), triggerStore
+}
+
+func listAllTriggers(t *testing.T, router http.Handler, query string) (int, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/triggers"+query, http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body
+}
+
+func TestListAllTriggers_ReturnsTriggersWithPipelineIdentity(t *testing.T) {
+	router, _ := seedGlobalTriggers(t)
+
+	code, body := listAllTriggers(t, router, "")
+
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(3), body["total"])
+	triggers := body["triggers"].([]interface{})
+	require.Len(t, triggers, 3)
+	first := triggers[0].(map[string]interface{})
+	assert.Equal(t, "finance", first["namespace"])
+	assert.Equal(t, "gold", first["layer"])
+	assert.Equal(t, "report", first["pipeline_name"])
+}
+
+func TestListAllTriggers_Filters(t *testing.T) {
+	router, _ := seedGlobalTriggers(t)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"?type=cron", 2},
+		{"?type=webhook", 1},
+		{"?enabled=false", 1},
+		{"?enabled=true", 2},
+		{"?namespace=finance", 1},
+		{"?namespace=default&type=cron", 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			code, body := listAllTriggers(t, router, tc.query)
+			require.Equal(t, http.StatusOK, code)
+			assert.Equal(t, float64(tc.want), body["total"])
+			assert.Len(t, body["triggers"], tc.want)
+		})
+	}
+}
+
+func TestListAllTriggers_Paginates(t *testing.T) {
+	router, _ := seedGlobalTriggers(t)
+
+	code, body := listAllTriggers(t, router, "?limit=2&offset=2")
+
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(3), body["total"])
+	assert.Len(t, body["triggers"], 1)
+}
+
+func TestListAllTriggers_OmitsWebhookSecrets(t *testing.T) {
+	router, _ := seedGlobalTriggers(t)
+
+	code, body := listAllTriggers(t, router, "?type=webhook")
+
+	require.Equal(t, http.StatusOK, code)
+	trig := body["triggers"].([]interface{})[0].(map[string]interface{})
+	cfg := trig["config"].(map[string]interface{})
+	assert.NotContains(t, cfg, "token_hash")
+	assert.NotContains(t, cfg, "signing_secret")
+	assert.Equal(t, true, cfg["signing_enabled"])
+	assert.Equal(t, []interface{}{"source"}, cfg["metadata_fields"])
+}
+
+func TestListAllTriggers_InvalidFilters_Return400(t *testing.T) {
+	router, _ := seedGlobalTriggers(t)
+
+	for _, query := range []string{"?type=bogus", "?enabled=maybe"} {
+		code, _ := listAllTriggers(t, router, query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
+
+// --- next_fire_at / last_run_status ---
+
+func getTriggerJSON(t *testing.T, router http.Handler, triggerID uuid.UUID) map[string]interface{} {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String(), http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body
+}
+
+func TestGetTrigger_Cron_IncludesNextFireAtAndLastRunStatus(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	runID := uuid.New()
+	srv.Runs.(*memoryRunStore).runs = []domain.Run{{ID: runID, PipelineID: pipelineID, Status: domain.RunStatusFailed}}
+
+	// Fired on a quarter-hour boundary → next tick is 15 minutes later
+	// (quarter hours line up in every time zone, so cron's local time is fine).
+	lastFired := time.Now().Truncate(15 * time.Minute)
+	triggerID := uuid.New()
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeCron,
+		Config: json.RawMessage(`{"cron_expr":"*/15 * * * *"}`), Enabled: true,
+		LastTriggeredAt: &lastFired, LastRunID: &runID,
+	}}
+	router := api.NewRouter(srv)
+
+	body := getTriggerJSON(t, router, triggerID)
+
+	assert.Equal(t, "failed", body["last_run_status"])
+	require.NotNil(t, body["next_fire_at"])
+	next, err := time.Parse(time.RFC3339, body["next_fire_at"].(string))
+	require.NoError(t, err)
+	assert.True(t, next.Equal(lastFired.Add(15*time.Minute)), "next_fire_at = %s", next)
+}
+
+func TestGetTrigger_DisabledCron_NoNextFireAt(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerID := uuid.New()
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeCron,
+		Config: json.RawMessage(`{"cron_expr":"0 * * * *"}`), Enabled: false,
+	}}
+	router := api.NewRouter(srv)
+
+	body := getTriggerJSON(t, router, triggerID)
+
+	assert.Nil(t, body["next_fire_at"])
+	assert.Nil(t, body["last_run_status"])
+}
+
+func TestGetTrigger_Webhook_LastRunStatusWithoutNextFireAt(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	runID := uuid.New()
+	srv.Runs.(*memoryRunStore).runs = []domain.Run{{ID: runID, PipelineID: pipelineID, Status: domain.RunStatusSuccess}}
+	lastFired := time.Now().Add(-time.Minute)
+	triggerID := uuid.New()
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeWebhook,
+		Config: json.RawMessage(`{"token_hash":"abc"}`), Enabled: true,
+		LastTriggeredAt: &lastFired, LastRunID: &runID,
+	}}
+	router := api.NewRouter(srv)
+
+	body := getTriggerJSON(t, router, triggerID)
+
+	assert.Equal(t, "success", body["last_run_status"])
+	assert.Contains(t, body, "next_fire_at")
+	assert.Nil(t, body["next_fire_at"])
+}
+
+func TestListTriggers_LastRunStatusesLookedUpInOneQuery(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	okRun, failedRun, prunedRun := uuid.New(), uuid.New(), uuid.New()
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.runs = []domain.Run{
+		{ID: okRun, PipelineID: pipelineID, Status: domain.RunStatusSuccess},
+		{ID: failedRun, PipelineID: pipelineID, Status: domain.RunStatusFailed},
+	}
+	lastRuns := []*uuid.UUID{&okRun, &failedRun, &prunedRun, nil}
+	for _, runID := range lastRuns {
+		triggerStore.triggers = append(triggerStore.triggers, domain.PipelineTrigger{
+			ID: uuid.New(), PipelineID: pipelineID, Type: domain.TriggerTypeLandingZoneUpload,
+			Config: json.RawMessage(`{"namespace":"default","zone_name":"orders"}`), Enabled: true,
+			LastRunID: runID,
+		})
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/ingest/triggers", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var body struct {
+		Triggers []map[string]interface{} `json:"triggers"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	statuses := map[string]interface{}{}
+	for _, tr := range body.Triggers {
+		statuses[tr["id"].(string)] = tr["last_run_status"]
+	}
+	assert.Equal(t, map[string]interface{}{
+		triggerStore.triggers[0].ID.String(): "success",
+		triggerStore.triggers[1].ID.String(): "failed",
+		triggerStore.triggers[2].ID.String(): nil,
+		triggerStore.triggers[3].ID.String(): nil,
+	}, statuses)
+	assert.Equal(t, 1, runStore.statusLookups)
+}
+
+// --- Bulk enable/disable ---
+
+func TestDisableAllTriggers_DisablesOnlyThatPipeline(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	ingest, other := uuid.New(), uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: ingest, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+		{ID: other, Namespace: "default", Layer: domain.LayerBronze, Name: "other"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: ingest, Type: domain.TriggerTypeCron, Enabled: true},
+		{ID: uuid.New(), PipelineID: ingest, Type: domain.TriggerTypeWebhook, Enabled: true},
+		{ID: uuid.New(), PipelineID: ingest, Type: domain.TriggerTypeCron, Enabled: false},
+		{ID: uuid.New(), PipelineID: other, Type: domain.TriggerTypeCron, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers/disable-all", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, float64(2), body["updated"])
+	assert.Equal(t, false, body["enabled"])
+
+	for _, trig := range triggerStore.triggers {
+		assert.Equal(t, trig.PipelineID == other, trig.Enabled, "trigger %s", trig.ID)
+	}
+}
+
+func TestEnableAllTriggers_ReturnsCount(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	ingest := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: ingest, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: uuid.New(), PipelineID: ingest, Type: domain.TriggerTypeCron, Enabled: false},
+		{ID: uuid.New(), PipelineID: ingest, Type: domain.TriggerTypeWebhook, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers/enable-all", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, float64(1), body["updated"])
+	assert.True(t, triggerStore.triggers[0].Enabled)
+}
+
+func TestDisableAllTriggers_PipelineNotFound_Returns404(t *testing.T) {
+	srv, _, _ := newTriggerTestServer()
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/missing/triggers/disable-all", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// --- Manual fire ---
+
+func TestFireTrigger_BypassesCooldown_CreatesAndSubmitsRun(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	justFired := time.Now().Add(-5 * time.Second)
+	triggerID := uuid.New()
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeLandingZoneUpload,
+		Config:  json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
+		Enabled: true, CooldownSeconds: 3600, LastTriggeredAt: &justFired,
+	}}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+	router := api.NewRouter(srv)
+
+	// The normal path is blocked by the cooldown...
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "")
+	require.Equal(t, 0, exec.submitCount())
+
+	// ...but a manual fire goes through.
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String()+"/fire", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.NotEmpty(t, body["run_id"])
+	assert.Equal(t, 1, exec.submitCount())
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	require.Len(t, runStore.runs, 1)
+	run := runStore.runs[0]
+	runStore.mu.Unlock()
+	assert.Equal(t, body["run_id"], run.ID.String())
+	assert.Equal(t, "trigger:manual:"+triggerID.String(), run.Trigger)
+	assert.Equal(t, triggerID.String(), run.Metadata["trigger_id"])
+
+	stored, err := triggerStore.GetTrigger(context.Background(), triggerID.String())
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastRunID)
+	assert.Equal(t, run.ID, *stored.LastRunID)
+}
+
+func TestFireTrigger_TriggerOfOtherPipeline_Returns404(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	ingest, other := uuid.New(), uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: ingest, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+		{ID: other, Namespace: "default", Layer: domain.LayerBronze, Name: "other"},
+	}
+	triggerID := uuid.New()
+	triggerStore.triggers = []domain.PipelineTrigger{
+		{ID: triggerID, PipelineID: other, Type: domain.TriggerTypeCron, Enabled: true},
+	}
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/ingest/triggers/"+triggerID.String()+"/fire", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// publishRecorder is an api.EventPublisher that records published events and
+// can be told to fail.
+type publishRecorder struct {
+	mu     sync.Mutex
+	events []plugins.DispatchEvent
+	err    error
+}
+
+func (p *publishRecorder) Publish(_ context.Context, channel string, payload interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, plugins.DispatchEvent{Channel: channel, Payload: data})
+	return nil
+}
+
+func landingZoneTriggerFixture(pipelineStore *memoryPipelineStore, triggerStore *memoryTriggerStore) uuid.UUID {
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"},
+	}
+	triggerID := uuid.New()
+	triggerStore.triggers = []domain.PipelineTrigger{{
+		ID: triggerID, PipelineID: pipelineID, Type: domain.TriggerTypeLandingZoneUpload,
+		Config:  json.RawMessage(`{"namespace":"default","zone_name":"orders"}`),
+		Enabled: true,
+	}}
+	return triggerID
+}
+
+func TestEvaluateTriggers_Fire_PublishesTriggerFired(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	triggerID := landingZoneTriggerFixture(pipelineStore, triggerStore)
+	bus := &publishRecorder{}
+	srv.EventBus = bus
+	srv.Executor = &mockExecutor{}
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "")
+
+	runStore := srv.Runs.(*memoryRunStore)
+	runStore.mu.Lock()
+	require.Len(t, runStore.runs, 1)
+	run := runStore.runs[0]
+	runStore.mu.Unlock()
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	require.Len(t, bus.events, 1)
+	assert.Equal(t, plugins.ChannelTriggerFired, bus.events[0].Channel)
+	assert.JSONEq(t, fmt.Sprintf(`{"trigger_id":%q,"run_id":%q,"pipeline_id":%q,"type":"landing_zone_upload"}`,
+		triggerID, run.ID, run.PipelineID), string(bus.events[0].Payload))
+}
+
+func TestEvaluateTriggers_PublishFailure_StillSubmitsRun(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	landingZoneTriggerFixture(pipelineStore, triggerStore)
+	srv.EventBus = &publishRecorder{err: fmt.Errorf("notify: connection refused")}
+	exec := &mockExecutor{}
+	srv.Executor = exec
+
+	srv.HandleEvaluateLandingZoneTriggers(context.Background(), "default", "orders", "")
+
+	assert.Equal(t, 1, exec.submitCount())
+	stored, err := triggerStore.GetTrigger(context.Background(), triggerStore.triggers[0].ID.String())
+	require.NoError(t, err)
+	assert.NotNil(t, stored.LastRunID)
+}
+
+func TestTriggerEndpoints_TriggerOfOtherPipeline_Returns404(t *testing.T) {
+	srv, pipelineStore, triggerStore := newTriggerTestServer()
+	ingest := domain.Pipeline{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "ingest"}
+	other := domain.Pipeline{ID: uuid.New(), Namespace: "default", Layer: domain.LayerBronze, Name: "other"}
+	pipelineStore.pipelines = []domain.Pipeline{ingest, other}
+	trigger := domain.PipelineTrigger{ID: uuid.New(), PipelineID: other.ID, Type: domain.TriggerTypeWebhook, Config: json.RawMessage(`{}`), Enabled: true}
+	triggerStore.triggers = []domain.PipelineTrigger{trigger}
+	router := api.NewRouter(srv)
+
+	base := "/api/v1/pipelines/default/bronze/ingest/triggers/" + trigger.ID.String()
+	for _, tc := range []struct{ method, path, body string }{
+		{http.MethodGet, base, ""},
+		{http.MethodPut, base, `{"enabled":false}`},
+		{http.MethodDelete, base, ""},
+		{http.MethodPost, base + "/rotate-token", ""},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code, "%s %s", tc.method, tc.path)
+	}
+
+	require.Len(t, triggerStore.triggers, 1)
+	assert.True(t, triggerStore.triggers[0].Enabled)
 }
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/rat-data/rat/platform/internal/domain"
 )
 
@@ -20,6 +24,8 @@ type rollbackRequest struct {
 func MountVersionRoutes(r chi.Router, srv *Server) {
 	r.Get("/pipelines/{namespace}/{layer}/{name}/versions", srv.HandleListVersions)
 	r.Get("/pipelines/{namespace}/{layer}/{name}/versions/{number}", srv.HandleGetVersion)
+	r.Get("/pipelines/{namespace}/{layer}/{name}/versions/{a}/diff/{b}", srv.HandleDiffVersions)
+	r.Post("/pipelines/{namespace}/{layer}/{name}/versions/{number}/restore-draft", srv.HandleRestoreDraft)
 	r.Post("/pipelines/{namespace}/{layer}/{name}/rollback", srv.HandleRollback)
 }
 
@@ -190,3 +196,255 @@ func (s *Server) HandleRollback(w http.ResponseWriter, r *http.Request) {
 		"message":      message,
 	})
 }
+
+// HandleRestoreDraft copies a version's file contents back into the draft
+// working files, overwriting whatever is there, and marks the pipeline
+// draft-dirty. Nothing is published — the user iterates from the old snapshot
+// and publishes when ready. Draft files absent from the version are left alone.
+// POST /api/v1/pipelines/{namespace}/{layer}/{name}/versions/{number}/restore-draft
+func (s *Server) HandleRestoreDraft(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	number, err := strconv.Atoi(chi.URLParam(r, "number"))
+	if err != nil || number < 1 {
+		errorJSON(w, "invalid version number", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	if !s.requireAccess(w, r, "namespace", namespace, "write") {
+		return
+	}
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	version, err := s.Versions.GetVersion(r.Context(), pipeline.ID, number)
+	if err != nil {
+		internalError(w, "failed to get version", err)
+		return
+	}
+	if version == nil {
+		errorJSON(w, "version not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	paths := make([]string, 0, len(version.PublishedVersions))
+	for path := range version.PublishedVersions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	// Check every object version exists before copying any, so a missing
+	// version doesn't leave the draft half-restored. The copies themselves
+	// are server-side; file contents never pass through ratd.
+	for _, path := range paths {
+		fi, err := s.Storage.StatFileVersion(r.Context(), path, version.PublishedVersions[path])
+		if err != nil {
+			internalError(w, "failed to stat version file", err)
+			return
+		}
+		if fi == nil {
+			errorJSON(w, fmt.Sprintf("file %s is no longer available at version %d", path, number), "FAILED_PRECONDITION", http.StatusConflict)
+			return
+		}
+	}
+
+	for _, path := range paths {
+		if _, err := s.Storage.CopyFileVersion(r.Context(), path, version.PublishedVersions[path], path); err != nil {
+			if errors.Is(err, ErrFileNotFound) {
+				errorJSON(w, fmt.Sprintf("file %s is no longer available at version %d", path, number), "FAILED_PRECONDITION", http.StatusConflict)
+				return
+			}
+			internalError(w, "failed to restore draft file", err)
+			return
+		}
+	}
+
+	if err := s.Pipelines.SetDraftDirty(r.Context(), namespace, layer, name, true); err != nil {
+		internalError(w, "failed to mark draft dirty", err)
+		return
+	}
+	if s.PipelineCache != nil {
+		s.PipelineCache.Delete(pipelineCacheKey(namespace, layer, name))
+	}
+	s.invalidatePreview(namespace, layer, name)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":  "restored",
+		"version": number,
+		"files":   paths,
+	})
+}
+
+// maxDiffFileBytes bounds the size of a file version included in a content
+// diff. Larger files are still listed, just without a diff.
+const maxDiffFileBytes = 1 << 20
+
+// File change kinds in a version diff.
+const (
+	fileChangeAdded    = "added"
+	fileChangeRemoved  = "removed"
+	fileChangeModified = "modified"
+)
+
+// VersionFileDiff is one changed file between two pipeline versions.
+type VersionFileDiff struct {
+	Path   string `json:"path"`
+	Status string `json:"status"` // added, removed, or modified
+	// Diff is a unified diff of the file contents. Empty when the content
+	// couldn't be fetched or is larger than maxDiffFileBytes.
+	Diff string `json:"diff,omitempty"`
+}
+
+// VersionDiffResponse is the JSON body for GET .../versions/{a}/diff/{b}.
+type VersionDiffResponse struct {
+	From     int               `json:"from"`
+	To       int               `json:"to"`
+	Files    []VersionFileDiff `json:"files"`
+	Added    []string          `json:"added"`
+	Removed  []string          `json:"removed"`
+	Modified []string          `json:"modified"`
+}
+
+// HandleDiffVersions compares the published snapshots of two versions. A file
+// counts as modified when its pinned S3 version ID differs; unchanged files
+// are omitted.
+// GET /api/v1/pipelines/{namespace}/{layer}/{name}/versions/{a}/diff/{b}
+func (s *Server) HandleDiffVersions(w http.ResponseWriter, r *http.Request) {
+	namespace := chi.URLParam(r, "namespace")
+	layer := chi.URLParam(r, "layer")
+	name := chi.URLParam(r, "name")
+
+	a, errA := strconv.Atoi(chi.URLParam(r, "a"))
+	b, errB := strconv.Atoi(chi.URLParam(r, "b"))
+	if errA != nil || errB != nil || a < 1 || b < 1 {
+		errorJSON(w, "invalid version number", "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
+	}
+
+	pipeline, err := s.Pipelines.GetPipeline(r.Context(), namespace, layer, name)
+	if err != nil {
+		internalError(w, "internal error", err)
+		return
+	}
+	if pipeline == nil {
+		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	from, err := s.Versions.GetVersion(r.Context(), pipeline.ID, a)
+	if err != nil {
+		internalError(w, "failed to get version", err)
+		return
+	}
+	to, err := s.Versions.GetVersion(r.Context(), pipeline.ID, b)
+	if err != nil {
+		internalError(w, "failed to get version", err)
+		return
+	}
+	if from == nil || to == nil {
+		errorJSON(w, "version not found", "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	resp := VersionDiffResponse{
+		From:     a,
+		To:       b,
+		Files:    []VersionFileDiff{},
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{},
+	}
+	for _, path := range versionDiffPaths(from.PublishedVersions, to.PublishedVersions) {
+		oldID, inOld := from.PublishedVersions[path]
+		newID, inNew := to.PublishedVersions[path]
+
+		var status string
+		switch {
+		case !inOld:
+			status = fileChangeAdded
+			resp.Added = append(resp.Added, path)
+		case !inNew:
+			status = fileChangeRemoved
+			resp.Removed = append(resp.Removed, path)
+		case oldID != newID:
+			status = fileChangeModified
+			resp.Modified = append(resp.Modified, path)
+		default:
+			continue
+		}
+
+		fd := VersionFileDiff{Path: path, Status: status}
+		if s.Storage != nil {
+			fd.Diff = s.diffFileVersions(r.Context(), path, oldID, newID, a, b)
+		}
+		resp.Files = append(resp.Files, fd)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// versionDiffPaths returns the union of both snapshots' file paths, sorted.
+func versionDiffPaths(from, to map[string]string) []string {
+	seen := make(map[string]struct{}, len(from)+len(to))
+	for p := range from {
+		seen[p] = struct{}{}
+	}
+	for p := range to {
+		seen[p] = struct{}{}
+	}
+	paths := make([]string, 0, len(seen))
+	for p := range seen {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// diffFileVersions returns a unified diff between two S3 versions of path.
+// An empty version ID stands for "file absent" (diffed as empty content).
+// Returns "" when either side can't be read or is too large — the caller
+// still reports the file as changed.
+func (s *Server) diffFileVersions(ctx context.Context, path, oldID, newID string, from, to int) string {
+	oldContent, ok := s.readVersionForDiff(ctx, path, oldID)
+	if !ok {
+		return ""
+	}
+	newContent, ok := s.readVersionForDiff(ctx, path, newID)
+	if !ok {
+		return ""
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(oldContent),
+		B:        difflib.SplitLines(newContent),
+		FromFile: fmt.Sprintf("v%d/%s", from, path),
+		ToFile:   fmt.Sprintf("v%d/%s", to, path),
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// readVersionForDiff reads one version of a file for diffing. An empty
+// versionID yields empty content.
+func (s *Server) readVersionForDiff(ctx context.Context, path, versionID string) (string, bool) {
+	if versionID == "" {
+		return "", true
+	}
+	fc, err := s.Storage.ReadFileVersion(ctx, path, versionID)
+	if err != nil || fc == nil || len(fc.Content) > maxDiffFileBytes {
+		return "", false
+	}
+	return fc.Content, true
+}
@@ -13,7 +13,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/cache"
 	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -265,6 +267,43 @@ func TestRollback_CreatesNewVersionWithOldSnapshot(t *testing.T) {
 	assert.Equal(t, "version-a", p.PublishedVersions["pipeline.sql"])
 }
 
+func TestRollback_WithCache_GetReturnsRolledBackVersions(t *testing.T) {
+	srv, pipelineStore, versionStore := newVersionTestServer()
+	srv.PipelineCache = cache.New[string, *domain.Pipeline](cache.Options{TTL: time.Minute})
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerBronze, Name: "events", Type: "sql", MaxVersions: 50,
+			PublishedVersions: map[string]string{"pipeline.sql": "version-b"}},
+	}
+	versionStore.versions = []domain.PipelineVersion{
+		{ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 1, PublishedVersions: map[string]string{"pipeline.sql": "version-a"}, CreatedAt: time.Now()},
+		{ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 2, PublishedVersions: map[string]string{"pipeline.sql": "version-b"}, CreatedAt: time.Now()},
+	}
+	router := api.NewRouter(srv)
+
+	publishedSQL := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/bronze/events", http.NoBody)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			PublishedVersions map[string]string `json:"published_versions"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp.PublishedVersions["pipeline.sql"]
+	}
+
+	assert.Equal(t, "version-b", publishedSQL())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/bronze/events/rollback", bytes.NewBufferString(`{"version": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, "version-a", publishedSQL())
+}
+
 func TestRollback_NonexistentVersion_Returns404(t *testing.T) {
 	srv, pipelineStore, _ := newVersionTestServer()
 	pipelineStore.pipelines = []domain.Pipeline{
@@ -319,6 +358,54 @@ func TestPublish_WithMessage_CreatesVersion(t *testing.T) {
 	assert.Equal(t, "Fixed join condition", versions[0].Message)
 }
 
+func TestPublish_CustomS3Prefix_FindsFilesAndQualityTestsUnderPrefix(t *testing.T) {
+	srv, pipelineStore, versionStore := newVersionTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql", S3Path: "default/shared/orders/"},
+	}
+	storageStore := srv.Storage.(*memoryStorageStore)
+	srv.Quality = storage.NewS3QualityStore(storageStore)
+	storageStore.files["default/shared/orders/pipeline.sql"] = []byte("SELECT 1")
+	storageStore.files["default/shared/orders/config.yaml"] = []byte("merge_strategy: full_refresh")
+	storageStore.files["default/shared/orders/tests/quality/no_nulls.sql"] = []byte("SELECT 1 WHERE false")
+	storageStore.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT 'stale'")
+	router := api.NewRouter(srv)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/publish", http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	versions, _ := versionStore.ListVersions(context.Background(), pipelineID)
+	require.Len(t, versions, 1)
+	paths := make([]string, 0, len(versions[0].PublishedVersions))
+	for path := range versions[0].PublishedVersions {
+		paths = append(paths, path)
+	}
+	assert.ElementsMatch(t, []string{
+		"default/shared/orders/pipeline.sql",
+		"default/shared/orders/config.yaml",
+		"default/shared/orders/tests/quality/no_nulls.sql",
+	}, paths)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/tests", http.NoBody)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var body struct {
+		Tests []struct {
+			Name      string `json:"name"`
+			Published bool   `json:"published"`
+		} `json:"tests"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	require.Len(t, body.Tests, 1)
+	assert.Equal(t, "no_nulls", body.Tests[0].Name)
+	assert.True(t, body.Tests[0].Published)
+}
+
 func TestPublish_PrunesOldVersions(t *testing.T) {
 	srv, pipelineStore, versionStore := newVersionTestServer()
 	pipelineID := uuid.New()
@@ -531,3 +618,176 @@ func TestGetPipeline_ReturnsVersioningFields(t *testing.T) {
 	assert.Equal(t, "v1-id", pv["pipeline.sql"])
 	assert.Equal(t, float64(25), body["max_versions"])
 }
+
+// --- Restore Draft ---
+
+func TestRestoreDraft_CopiesVersionFilesAndMarksDirty(t *testing.T) {
+	srv, pipelineStore, versionStore := newVersionTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	versionStore.versions = []domain.PipelineVersion{
+		{
+			ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 1,
+			PublishedVersions: map[string]string{
+				"default/pipelines/silver/orders/pipeline.sql": "sql-v1",
+				"default/pipelines/silver/orders/config.yaml":  "cfg-v1",
+			},
+		},
+	}
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.versions = map[string][]byte{
+		"default/pipelines/silver/orders/pipeline.sql@sql-v1": []byte("SELECT id FROM raw.orders"),
+		"default/pipelines/silver/orders/config.yaml@cfg-v1":  []byte("merge_strategy: full_refresh"),
+	}
+	storage.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT id, total FROM raw.orders")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/versions/1/restore-draft", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "SELECT id FROM raw.orders", string(storage.files["default/pipelines/silver/orders/pipeline.sql"]))
+	assert.Equal(t, "merge_strategy: full_refresh", string(storage.files["default/pipelines/silver/orders/config.yaml"]))
+	assert.True(t, pipelineStore.pipelines[0].DraftDirty)
+	assert.Nil(t, pipelineStore.pipelines[0].PublishedVersions, "restore must not publish")
+	assert.Equal(t, 2, storage.copies, "restore should copy server-side")
+}
+
+func TestRestoreDraft_MissingObjectVersion_Returns409AndLeavesDraft(t *testing.T) {
+	srv, pipelineStore, versionStore := newVersionTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	versionStore.versions = []domain.PipelineVersion{
+		{
+			ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 1,
+			PublishedVersions: map[string]string{
+				"default/pipelines/silver/orders/pipeline.sql": "sql-v1",
+				"default/pipelines/silver/orders/tests/t.sql":  "gone",
+			},
+		},
+	}
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.versions = map[string][]byte{
+		"default/pipelines/silver/orders/pipeline.sql@sql-v1": []byte("SELECT id FROM raw.orders"),
+	}
+	storage.files["default/pipelines/silver/orders/pipeline.sql"] = []byte("SELECT id, total FROM raw.orders")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/versions/1/restore-draft", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, 0, storage.copies)
+	assert.Equal(t, "SELECT id, total FROM raw.orders", string(storage.files["default/pipelines/silver/orders/pipeline.sql"]))
+	assert.False(t, pipelineStore.pipelines[0].DraftDirty)
+}
+
+func TestRestoreDraft_MissingVersion_Returns404(t *testing.T) {
+	srv, pipelineStore, _ := newVersionTestServer()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: uuid.New(), Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/versions/3/restore-draft", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.False(t, pipelineStore.pipelines[0].DraftDirty)
+}
+
+// --- Diff Versions ---
+
+func TestDiffVersions_OneFileModified_ReturnsUnifiedDiff(t *testing.T) {
+	srv, pipelineStore, versionStore := newVersionTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	versionStore.versions = []domain.PipelineVersion{
+		{
+			ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 1,
+			PublishedVersions: map[string]string{
+				"default/pipelines/silver/orders/pipeline.sql": "sql-v1",
+				"default/pipelines/silver/orders/config.yaml":  "cfg-v1",
+			},
+		},
+		{
+			ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 2,
+			PublishedVersions: map[string]string{
+				"default/pipelines/silver/orders/pipeline.sql": "sql-v2",
+				"default/pipelines/silver/orders/config.yaml":  "cfg-v1",
+			},
+		},
+	}
+	storage := srv.Storage.(*memoryStorageStore)
+	storage.versions = map[string][]byte{
+		"default/pipelines/silver/orders/pipeline.sql@sql-v1": []byte("SELECT id\nFROM raw.orders\n"),
+		"default/pipelines/silver/orders/pipeline.sql@sql-v2": []byte("SELECT id, total\nFROM raw.orders\n"),
+		"default/pipelines/silver/orders/config.yaml@cfg-v1":  []byte("merge_strategy: full_refresh\n"),
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/versions/1/diff/2", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var body api.VersionDiffResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, 1, body.From)
+	assert.Equal(t, 2, body.To)
+	assert.Empty(t, body.Added)
+	assert.Empty(t, body.Removed)
+	assert.Equal(t, []string{"default/pipelines/silver/orders/pipeline.sql"}, body.Modified)
+
+	require.Len(t, body.Files, 1)
+	assert.Equal(t, "modified", body.Files[0].Status)
+	assert.Contains(t, body.Files[0].Diff, "-SELECT id\n")
+	assert.Contains(t, body.Files[0].Diff, "+SELECT id, total\n")
+	assert.Contains(t, body.Files[0].Diff, " FROM raw.orders\n")
+}
+
+func TestDiffVersions_AddedAndRemovedFiles(t *testing.T) {
+	srv, pipelineStore, versionStore := newVersionTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	versionStore.versions = []domain.PipelineVersion{
+		{ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 1, PublishedVersions: map[string]string{"a.sql": "a1"}},
+		{ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 2, PublishedVersions: map[string]string{"b.sql": "b1"}},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/versions/1/diff/2", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body api.VersionDiffResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, []string{"b.sql"}, body.Added)
+	assert.Equal(t, []string{"a.sql"}, body.Removed)
+	assert.Empty(t, body.Modified)
+}
+
+func TestDiffVersions_MissingVersion_Returns404(t *testing.T) {
+	srv, pipelineStore, versionStore := newVersionTestServer()
+	pipelineID := uuid.New()
+	pipelineStore.pipelines = []domain.Pipeline{
+		{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders", Type: "sql"},
+	}
+	versionStore.versions = []domain.PipelineVersion{
+		{ID: uuid.New(), PipelineID: pipelineID, VersionNumber: 1},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pipelines/default/silver/orders/versions/1/diff/9", http.NoBody)
+	rec := httptest.NewRecorder()
+	api.NewRouter(srv).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
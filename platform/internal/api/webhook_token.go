@@ -1,9 +1,16 @@
 package api
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"strings"
 )
 
 // HashWebhookToken returns the hex-encoded SHA-256 hash of a webhook token.
@@ -20,3 +27,80 @@ func HashWebhookToken(token string) string {
 func webhookTokenHashesEqual(a, b string) bool {
 	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
 }
+
+// WebhookSecretKey derives the AES-256 key used to encrypt webhook signing
+// secrets from an operator-supplied passphrase (RAT_WEBHOOK_SECRET_KEY).
+func WebhookSecretKey(passphrase string) []byte {
+	h := sha256.Sum256([]byte(passphrase))
+	return h[:]
+}
+
+// encryptWebhookSecret seals a signing secret with AES-GCM. Unlike the token,
+// the signing secret cannot be stored as a hash — verifying an HMAC needs the
+// raw key — so it is encrypted at rest instead. Output is base64(nonce||ct).
+func encryptWebhookSecret(key []byte, secret string) (string, error) {
+	gcm, err := webhookSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptWebhookSecret reverses encryptWebhookSecret.
+func decryptWebhookSecret(key []byte, encoded string) (string, error) {
+	gcm, err := webhookSecretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("webhook signing secret: ciphertext too short")
+	}
+	nonce, ct := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ct, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func webhookSecretCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) == 0 {
+		return nil, errors.New("webhook signing secret: no encryption key configured")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SignWebhookBody returns the hex-encoded HMAC-SHA256 of body under secret —
+// the value callers send in the X-Signature-256 header.
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookSignatureValid checks an X-Signature-256 header value against the
+// body. The GitHub-style "sha256=" prefix is accepted; comparison is
+// constant-time on the decoded MAC.
+func webhookSignatureValid(secret string, body []byte, header string) bool {
+	header = strings.TrimPrefix(strings.TrimSpace(header), "sha256=")
+	got, err := hex.DecodeString(header)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
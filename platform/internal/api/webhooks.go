@@ -1,14 +1,20 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/domain"
 )
 
@@ -31,9 +37,14 @@ func MountWebhookRoutes(r chi.Router, srv *Server) {
 // Security: The plaintext token is hashed (SHA-256) before the database lookup.
 // After retrieval the stored hash is verified again via constant-time comparison
 // to guard against timing side-channels.
+//
+// If the trigger has a signing secret, the X-Signature-256 header must carry
+// the hex HMAC-SHA256 of the raw body; mismatches are rejected with 401 before
+// anything fires. Token-only webhooks skip this check.
 func (s *Server) HandleWebhookTrigger(w http.ResponseWriter, r *http.Request) {
 	token := extractWebhookToken(r)
 	if token == "" {
+		s.auditAuthFailure(r, "missing webhook token")
 		errorJSON(w, "missing token: set X-Webhook-Token header or Authorization: Bearer <token>", "INVALID_ARGUMENT", http.StatusBadRequest)
 		return
 	}
@@ -47,6 +58,7 @@ func (s *Server) HandleWebhookTrigger(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if trigger == nil {
+		s.auditAuthFailure(r, "unknown webhook token")
 		errorJSON(w, "not found", "NOT_FOUND", http.StatusNotFound)
 		return
 	}
@@ -54,81 +66,124 @@ func (s *Server) HandleWebhookTrigger(w http.ResponseWriter, r *http.Request) {
 	// Constant-time comparison as a second verification against the stored hash.
 	var cfg webhookConfig
 	if err := json.Unmarshal(trigger.Config, &cfg); err != nil || !webhookTokenHashesEqual(tokenHash, cfg.TokenHash) {
+		s.auditAuthFailure(r, "unknown webhook token")
 		errorJSON(w, "not found", "NOT_FOUND", http.StatusNotFound)
 		return
 	}
 
-	// Check cooldown
-	now := time.Now()
-	if trigger.CooldownSeconds > 0 && trigger.LastTriggeredAt != nil {
-		cooldownEnd := trigger.LastTriggeredAt.Add(time.Duration(trigger.CooldownSeconds) * time.Second)
-		if now.Before(cooldownEnd) {
-			errorJSON(w, "cooldown active", "RESOURCE_EXHAUSTED", http.StatusTooManyRequests)
+	// The body is read once: it feeds both signature verification and
+	// metadata extraction. An unreadable body only fails signed webhooks —
+	// metadata alone is best-effort.
+	var body []byte
+	if cfg.SigningSecret != "" || len(cfg.MetadataFields) > 0 || len(cfg.ParamMapping) > 0 {
+		body, err = readWebhookBody(r)
+		if err != nil && cfg.SigningSecret != "" {
+			errorJSON(w, "request body too large or unreadable", "INVALID_ARGUMENT", http.StatusRequestEntityTooLarge)
 			return
 		}
 	}
 
-	// Look up pipeline
-	pipeline, err := s.Pipelines.GetPipelineByID(r.Context(), trigger.PipelineID.String())
-	if err != nil {
-		internalError(w, "internal error", err)
-		return
-	}
-	if pipeline == nil {
-		errorJSON(w, "pipeline not found", "NOT_FOUND", http.StatusNotFound)
-		return
+	if cfg.SigningSecret != "" {
+		secret, err := decryptWebhookSecret(s.WebhookSecretKey, cfg.SigningSecret)
+		if err != nil {
+			internalError(w, "internal error", fmt.Errorf("decrypt webhook signing secret for trigger %s: %w", trigger.ID, err))
+			return
+		}
+		if !webhookSignatureValid(secret, body, r.Header.Get("X-Signature-256")) {
+			s.auditAuthFailure(r, fmt.Sprintf("invalid webhook signature for trigger %s", trigger.ID))
+			errorJSON(w, "invalid or missing X-Signature-256", "UNAUTHENTICATED", http.StatusUnauthorized)
+			return
+		}
 	}
 
-	// Create run — use a prefix of the *hash* (not the plaintext) in the label.
-	hashPrefix := tokenHash
-	if len(hashPrefix) > 8 {
-		hashPrefix = hashPrefix[:8]
+	// Idempotency-Key: a caller retrying after a timeout gets the run its first
+	// attempt created instead of firing a second one. Keys are scoped to the
+	// trigger and remembered in memory (WebhookIdempotency), so they survive
+	// only as long as this replica does. Concurrent requests with the same key
+	// share one fire via GetOrLoad's singleflight; failed fires aren't
+	// remembered, so a retry after an error fires again.
+	key := strings.TrimSpace(r.Header.Get("Idempotency-Key"))
+	if len(key) > maxIdempotencyKeyLength {
+		errorJSON(w, fmt.Sprintf("Idempotency-Key too long (max %d chars)", maxIdempotencyKeyLength), "INVALID_ARGUMENT", http.StatusBadRequest)
+		return
 	}
-	triggerLabel := "trigger:webhook:" + hashPrefix
-	run := &domain.Run{
-		PipelineID: pipeline.ID,
-		Status:     domain.RunStatusPending,
-		Trigger:    triggerLabel,
+	fire := func() (uuid.UUID, error) {
+		run, err := s.fireWebhook(r.Context(), trigger, tokenHash, cfg, body)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return run.ID, nil
 	}
 
-	// Atomic: create the run AND record the trigger as fired in one tx.
-	// Previously these were separate statements; a crash between them left
-	// the run in pending but the trigger looking unfired — the next webhook
-	// hit (or trigger evaluator pass) would fire a duplicate run.
-	// Executor.Submit is HTTP IO and stays OUTSIDE the tx — see api.TxRunner
-	// contract: no IO inside the callback.
-	createAndRecord := func(t TxStores) error {
-		if err := t.Runs.CreateRun(r.Context(), run); err != nil {
-			return err
+	var runID uuid.UUID
+	if key != "" && s.WebhookIdempotency != nil {
+		fired := false
+		runID, _, err = s.WebhookIdempotency.GetOrLoad(trigger.ID.String()+":"+key, func() (uuid.UUID, bool, error) {
+			fired = true
+			id, err := fire()
+			return id, err == nil, err
+		})
+		if err == nil && !fired {
+			w.Header().Set("Idempotent-Replayed", "true")
+			slog.Info("webhook idempotent replay", "trigger_id", trigger.ID, "run_id", runID)
 		}
-		return t.Triggers.UpdateTriggerFired(r.Context(), trigger.ID.String(), run.ID)
+	} else {
+		runID, err = fire()
 	}
-	if err := s.runFireTx(r.Context(), createAndRecord); err != nil {
+	if err != nil {
+		var fireErr *webhookFireError
+		if errors.As(err, &fireErr) {
+			errorJSON(w, fireErr.msg, fireErr.code, fireErr.status)
+			return
+		}
 		internalError(w, "internal error", err)
 		return
 	}
 
-	// Submit to executor AFTER the tx commits — the run already exists in
-	// pending state, so a Submit failure is recoverable: the reaper will
-	// either retry or fail the run. Never block tx commit on the runner.
-	// Use a dedicated context with timeout rather than the request context
-	// (which will be cancelled when the HTTP response is sent) or a bare
-	// context.Background() (which has no timeout and could hang indefinitely).
-	if s.Executor != nil {
-		submitCtx, submitCancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer submitCancel()
-		if err := s.Executor.Submit(submitCtx, run, pipeline); err != nil {
-			slog.Error("executor submit failed for webhook trigger", "run_id", run.ID, "error", err)
-		}
-	}
-
-	slog.Info("webhook trigger fired", "trigger_id", trigger.ID, "run_id", run.ID)
-
 	writeJSON(w, http.StatusCreated, map[string]interface{}{
-		"run_id": run.ID,
+		"run_id": runID,
 	})
 }
 
+// maxIdempotencyKeyLength bounds the Idempotency-Key header on webhooks.
+const maxIdempotencyKeyLength = 255
+
+// DefaultWebhookIdempotencyTTL is how long a webhook Idempotency-Key is
+// remembered when Server.WebhookIdempotency is not set explicitly.
+const DefaultWebhookIdempotencyTTL = time.Hour
+
+// webhookFireError is a client-facing failure from fireWebhook.
+type webhookFireError struct {
+	msg    string
+	code   string
+	status int
+}
+
+func (e *webhookFireError) Error() string { return e.msg }
+
+// fireWebhook fires the trigger through the shared tryFireTrigger path, with
+// the payload fields the trigger copies as run metadata. Client-facing
+// failures are returned as *webhookFireError.
+func (s *Server) fireWebhook(ctx context.Context, trigger *domain.PipelineTrigger, tokenHash string, cfg webhookConfig, body []byte) (*domain.Run, error) {
+	// Label with a prefix of the *hash*, never the plaintext token.
+	hashPrefix := tokenHash
+	if len(hashPrefix) > 8 {
+		hashPrefix = hashPrefix[:8]
+	}
+	metadata := webhookMetadata(body, cfg.MetadataFields, cfg.ParamMapping)
+
+	run, err := s.tryFireTrigger(ctx, *trigger, time.Now(), nil, "trigger:webhook:"+hashPrefix, metadata)
+	switch {
+	case errors.Is(err, errTriggerCooldown):
+		return nil, &webhookFireError{msg: "cooldown active", code: "RESOURCE_EXHAUSTED", status: http.StatusTooManyRequests}
+	case errors.Is(err, errTriggerOutsideWindow):
+		return nil, &webhookFireError{msg: "outside the trigger's active window", code: "FAILED_PRECONDITION", status: http.StatusConflict}
+	case errors.Is(err, errTriggerPipelineMissing):
+		return nil, &webhookFireError{msg: "pipeline not found", code: "NOT_FOUND", status: http.StatusNotFound}
+	}
+	return run, err
+}
+
 // extractWebhookToken reads the webhook token from request headers.
 // It checks X-Webhook-Token first, then falls back to Authorization: Bearer <token>.
 // Returns empty string if no token is found.
@@ -148,3 +203,184 @@ func extractWebhookToken(r *http.Request) string {
 
 	return ""
 }
+
+// maxWebhookBodyBytes bounds how much of a webhook request body is read for
+// signature verification and metadata extraction.
+const maxWebhookBodyBytes = 1 << 20
+
+// errWebhookBodyTooLarge is returned by readWebhookBody for oversized bodies.
+var errWebhookBodyTooLarge = errors.New("webhook body exceeds limit")
+
+// readWebhookBody reads the raw request body, up to maxWebhookBodyBytes.
+func readWebhookBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxWebhookBodyBytes {
+		return nil, errWebhookBodyTooLarge
+	}
+	return body, nil
+}
+
+// validateWebhookMetadataFields checks the metadata_fields option of a webhook
+// trigger config. Returns a client-facing error message, or "".
+func validateWebhookMetadataFields(fields []string) string {
+	if len(fields) > maxRunMetadataEntries {
+		return fmt.Sprintf("too many metadata_fields (%d, max %d)", len(fields), maxRunMetadataEntries)
+	}
+	for _, f := range fields {
+		if !validWebhookFieldPath(f) {
+			return fmt.Sprintf("invalid metadata field %q: dot-separated JSON field path (max 63 chars)", f)
+		}
+	}
+	return ""
+}
+
+// validateWebhookParamMapping checks the param_mapping option of a webhook
+// trigger config. The mapped parameters share the run's metadata with the
+// metadataFields copied fields. Returns a client-facing error message, or "".
+func validateWebhookParamMapping(mapping map[string]string, metadataFields int) string {
+	if len(mapping)+metadataFields > maxRunMetadataEntries {
+		return fmt.Sprintf("too many param_mapping and metadata_fields entries (%d, max %d)", len(mapping)+metadataFields, maxRunMetadataEntries)
+	}
+	for param, path := range mapping {
+		if !validRunParameterKeyRe.MatchString(param) {
+			return fmt.Sprintf("invalid param_mapping parameter %q: letters, digits, '_', must not start with a digit (max 57 chars)", param)
+		}
+		if !validWebhookFieldPath(strings.TrimPrefix(path, "$.")) {
+			return fmt.Sprintf("invalid param_mapping path %q for %q: dot-separated JSON field path, optionally starting with \"$.\"", path, param)
+		}
+	}
+	return ""
+}
+
+// webhookUpdatableConfigKeys are the webhook config keys a trigger update may
+// change. The token and the signing secret are set only by the server: on
+// create and through rotate-token.
+var webhookUpdatableConfigKeys = []string{"metadata_fields", "param_mapping", "active_window", "backoff"}
+
+// mergeWebhookConfigUpdate applies a PUT config to a webhook trigger's stored
+// config. Updatable keys present in update replace the stored values (null
+// removes them); everything else is kept, so a config read back from GET
+// (which has the secrets redacted) can be edited and sent as is. The result
+// still needs checkTriggerConfig. Returns the merged config, or a
+// client-facing error message.
+func mergeWebhookConfigUpdate(stored, update json.RawMessage) (json.RawMessage, string) {
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(update, &changes); err != nil {
+		return nil, "invalid webhook config"
+	}
+	if _, ok := changes["token_hash"]; ok {
+		return nil, "token_hash cannot be set; use rotate-token to replace the webhook token"
+	}
+	for _, key := range []string{"signing_secret", "generate_signing_secret"} {
+		if _, ok := changes[key]; ok {
+			return nil, key + " cannot be changed on update; recreate the trigger to change signing"
+		}
+	}
+
+	merged := map[string]json.RawMessage{}
+	if len(stored) > 0 {
+		if err := json.Unmarshal(stored, &merged); err != nil {
+			return nil, "stored webhook config is invalid"
+		}
+	}
+	for _, key := range webhookUpdatableConfigKeys {
+		v, ok := changes[key]
+		switch {
+		case !ok:
+		case string(v) == "null":
+			delete(merged, key)
+		default:
+			merged[key] = v
+		}
+	}
+
+	raw, err := json.Marshal(merged)
+	if err != nil {
+		return nil, "invalid webhook config"
+	}
+	return raw, ""
+}
+
+// validWebhookFieldPath reports whether f is a dot path into a JSON body.
+func validWebhookFieldPath(f string) bool {
+	return validRunMetadataKeyRe.MatchString(f) && !strings.Contains(f, "..") && !strings.HasSuffix(f, ".")
+}
+
+// webhookMetadata builds the fired run's metadata from a JSON request body:
+// each of fields is copied under its path, and each param_mapping entry
+// under RunParameterMetadataPrefix plus the parameter name. Strings are
+// copied as-is; numbers, booleans, objects and arrays as compact JSON;
+// missing or null fields are skipped. A non-JSON body yields only an empty
+// map — metadata is best-effort and never fails the webhook.
+func webhookMetadata(body []byte, fields []string, params map[string]string) map[string]string {
+	metadata := make(map[string]string, len(fields)+len(params)+1)
+	if (len(fields) == 0 && len(params) == 0) || len(body) == 0 {
+		return metadata
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return metadata
+	}
+
+	for _, field := range fields {
+		if value, ok := webhookFieldValue(payload, field); ok {
+			metadata[field] = value
+		}
+	}
+	for param, path := range params {
+		if value, ok := webhookFieldValue(payload, strings.TrimPrefix(path, "$.")); ok {
+			metadata[RunParameterMetadataPrefix+param] = value
+		}
+	}
+	return metadata
+}
+
+// webhookFieldValue returns the field at the dot path in payload as a
+// metadata value, and false when it is missing or null.
+func webhookFieldValue(payload map[string]json.RawMessage, field string) (string, bool) {
+	raw, ok := lookupJSONPath(payload, strings.Split(field, "."))
+	if !ok {
+		return "", false
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return "", false
+		}
+		value = compact.String()
+	}
+	if len(value) > maxRunMetadataValueLength {
+		// Cut on a rune boundary so a multi-byte character isn't split.
+		end := maxRunMetadataValueLength
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		value = value[:end]
+	}
+	return value, true
+}
+
+// lookupJSONPath walks nested JSON objects along path. Reports ok = false when
+// a segment is missing, traverses a non-object, or ends on null.
+func lookupJSONPath(obj map[string]json.RawMessage, path []string) (json.RawMessage, bool) {
+	raw, ok := obj[path[0]]
+	if !ok {
+		return nil, false
+	}
+	if len(path) == 1 {
+		return raw, !bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+	}
+	var next map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &next); err != nil {
+		return nil, false
+	}
+	return lookupJSONPath(next, path[1:])
+}
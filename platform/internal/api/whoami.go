@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/rat-data/rat/platform/internal/auth"
+	"github.com/rat-data/rat/platform/internal/plugins"
+)
+
+// Auth modes reported by GET /api/v1/whoami.
+const (
+	AuthModePlugin = "plugin"  // auth plugin resolved a user (Pro)
+	AuthModeAPIKey = "api_key" // static RAT_API_KEY / RAT_API_KEYS key
+	AuthModeNone   = "none"    // Noop auth — single-user community default
+)
+
+// anonymousUserID is the principal reported when no auth is configured.
+const anonymousUserID = "anonymous"
+
+// WhoamiResponse is the JSON shape returned by GET /api/v1/whoami.
+type WhoamiResponse struct {
+	UserID      string   `json:"user_id"`
+	AuthMode    string   `json:"auth_mode"`
+	Anonymous   bool     `json:"anonymous"`
+	Scopes      []string `json:"scopes"`
+	Roles       []string `json:"roles"`
+	Namespaces  []string `json:"namespaces,omitempty"` // omitted = every namespace
+	LicenseTier string   `json:"license_tier"`
+}
+
+// HandleWhoami describes the principal behind the request: an auth-plugin
+// user, an API key (identified by fingerprint, never the key itself), or the
+// anonymous principal when auth is off. Unlike HandleMe it never returns 401 —
+// requests that fail auth are rejected by the middleware before reaching it.
+func (s *Server) HandleWhoami(w http.ResponseWriter, r *http.Request) {
+	resp := WhoamiResponse{
+		Scopes:      []string{string(auth.ScopeReadWrite)},
+		Roles:       []string{},
+		LicenseTier: s.licenseTier(),
+	}
+
+	user := plugins.UserFromContext(r.Context())
+	key, isKey := auth.APIKeyFromContext(r.Context())
+	switch {
+	case user != nil:
+		resp.UserID = user.UserID
+		resp.AuthMode = AuthModePlugin
+		if user.Roles != nil {
+			resp.Roles = user.Roles
+		}
+	case isKey:
+		resp.UserID = key.ID
+		resp.AuthMode = AuthModeAPIKey
+		resp.Scopes = []string{string(key.Scope)}
+	default:
+		resp.UserID = anonymousUserID
+		resp.AuthMode = AuthModeNone
+		resp.Anonymous = true
+	}
+
+	if namespaces, ok := auth.AllowedNamespaces(r.Context()); ok {
+		resp.Namespaces = namespaces
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// licenseTier returns the tier of a valid license, or "community" when no
+// license is configured or it failed validation.
+func (s *Server) licenseTier() string {
+	if s.LicenseInfo == nil || !s.LicenseInfo.Valid || s.LicenseInfo.Tier == "" {
+		return "community"
+	}
+	return s.LicenseInfo.Tier
+}
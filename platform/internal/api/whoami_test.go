@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/auth"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/plugins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveWhoami(t *testing.T, srv *api.Server, req *http.Request) api.WhoamiResponse {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.HandleWhoami(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body api.WhoamiResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	return body
+}
+
+func TestHandleWhoami_APIKey_ReturnsKeyIdentityAndScope(t *testing.T) {
+	srv := &api.Server{}
+	var body api.WhoamiResponse
+	handler := auth.APIKeys(
+		map[string]auth.Scope{"secret-key": auth.ScopeReadOnly},
+		map[string][]string{"secret-key": {"sales", "finance"}},
+	)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body = serveWhoami(t, srv, r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/whoami", http.NoBody)
+	req.Header.Set("Authorization", "Bearer secret-key")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, api.AuthModeAPIKey, body.AuthMode)
+	assert.Regexp(t, `^apikey:[0-9a-f]{16}$`, body.UserID)
+	assert.NotContains(t, body.UserID, "secret-key")
+	assert.False(t, body.Anonymous)
+	assert.Equal(t, []string{"ro"}, body.Scopes)
+	assert.Equal(t, []string{"sales", "finance"}, body.Namespaces)
+	assert.Equal(t, "community", body.LicenseTier)
+}
+
+func TestHandleWhoami_PluginUser_ReturnsUserAndLicenseTier(t *testing.T) {
+	srv := &api.Server{LicenseInfo: &domain.LicenseInfo{Valid: true, Tier: "pro"}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/whoami", http.NoBody)
+	req = req.WithContext(plugins.ContextWithUser(req.Context(), &domain.UserIdentity{
+		UserID: "usr_123",
+		Roles:  []string{"admin"},
+	}))
+
+	body := serveWhoami(t, srv, req)
+	assert.Equal(t, "usr_123", body.UserID)
+	assert.Equal(t, api.AuthModePlugin, body.AuthMode)
+	assert.False(t, body.Anonymous)
+	assert.Equal(t, []string{"admin"}, body.Roles)
+	assert.Empty(t, body.Namespaces)
+	assert.Equal(t, "pro", body.LicenseTier)
+}
+
+func TestHandleWhoami_Noop_ReturnsAnonymousPrincipal(t *testing.T) {
+	srv := &api.Server{LicenseInfo: &domain.LicenseInfo{Valid: false, Tier: "pro", Error: "expired"}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/whoami", http.NoBody)
+	body := serveWhoami(t, srv, req)
+
+	assert.Equal(t, "anonymous", body.UserID)
+	assert.Equal(t, api.AuthModeNone, body.AuthMode)
+	assert.True(t, body.Anonymous)
+	assert.Equal(t, []string{"rw"}, body.Scopes)
+	assert.Equal(t, []string{}, body.Roles)
+	assert.Nil(t, body.Namespaces)
+	assert.Equal(t, "community", body.LicenseTier)
+}
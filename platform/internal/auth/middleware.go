@@ -1,11 +1,18 @@
 // Package auth provides authentication middleware for the ratd API.
-// Community edition uses Noop (pass-through) or APIKey (static key).
+// Community edition uses Noop (pass-through) or APIKey/APIKeys (static keys,
+// optionally read-only).
 // Pro edition plugs in real auth middleware via the auth plugin slot.
 package auth
 
 import (
+	"context"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
+	"path"
 	"strings"
 )
 
@@ -17,6 +24,118 @@ func Noop() func(http.Handler) http.Handler {
 	}
 }
 
+// Scope is what an API key is allowed to do.
+type Scope string
+
+const (
+	// ScopeReadWrite grants every method. RAT_API_KEY is always read-write.
+	ScopeReadWrite Scope = "rw"
+	// ScopeReadOnly grants GET, HEAD, OPTIONS and the POST endpoints that
+	// only read (see IsReadRequest); anything else gets 403.
+	ScopeReadOnly Scope = "ro"
+)
+
+// ParseAPIKeys parses a RAT_API_KEYS value: comma-separated "key:scope"
+// pairs, e.g. "key1:rw,key2:ro". The scope follows the last colon, so keys
+// may themselves contain colons. Blank entries are skipped.
+func ParseAPIKeys(spec string) (map[string]Scope, error) {
+	keys := make(map[string]Scope)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, ":")
+		if i <= 0 {
+			return nil, errors.New("api key entry must be key:scope (scope rw or ro)")
+		}
+		key, scope := entry[:i], Scope(entry[i+1:])
+		if scope != ScopeReadWrite && scope != ScopeReadOnly {
+			return nil, fmt.Errorf("api key scope %q must be rw or ro", scope)
+		}
+		if _, dup := keys[key]; dup {
+			return nil, errors.New("api key listed more than once")
+		}
+		keys[key] = scope
+	}
+	return keys, nil
+}
+
+// ParseAPIKeyNamespaces parses a RAT_API_KEY_NAMESPACES value:
+// comma-separated "key:ns1|ns2" entries restricting each listed key to those
+// namespaces, e.g. "team-a-key:sales|marketing,team-b-key:finance". As with
+// ParseAPIKeys, the namespace list follows the last colon.
+func ParseAPIKeyNamespaces(spec string) (map[string][]string, error) {
+	keys := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, ":")
+		if i <= 0 {
+			return nil, errors.New("api key namespace entry must be key:ns1|ns2")
+		}
+		key := entry[:i]
+		if _, dup := keys[key]; dup {
+			return nil, errors.New("api key listed more than once")
+		}
+		var namespaces []string
+		for _, ns := range strings.Split(entry[i+1:], "|") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				namespaces = append(namespaces, ns)
+			}
+		}
+		if len(namespaces) == 0 {
+			return nil, errors.New("api key namespace entry lists no namespaces")
+		}
+		keys[key] = namespaces
+	}
+	return keys, nil
+}
+
+type namespacesCtxKey struct{}
+
+// AllowedNamespaces returns the namespaces the request's API key is
+// restricted to. ok is false when the request is not namespace-restricted.
+func AllowedNamespaces(ctx context.Context) (namespaces []string, ok bool) {
+	namespaces, ok = ctx.Value(namespacesCtxKey{}).([]string)
+	return namespaces, ok
+}
+
+// WithAllowedNamespaces returns a copy of ctx restricted to namespaces.
+func WithAllowedNamespaces(ctx context.Context, namespaces []string) context.Context {
+	return context.WithValue(ctx, namespacesCtxKey{}, namespaces)
+}
+
+type apiKeyCtxKey struct{}
+
+// APIKeyIdentity describes the API key that authenticated a request.
+type APIKeyIdentity struct {
+	// ID is a stable, non-secret fingerprint of the key ("apikey:" plus the
+	// first 16 hex chars of its SHA-256), safe to show to clients and in logs.
+	ID    string
+	Scope Scope
+}
+
+// APIKeyFromContext returns the API key that authenticated the request.
+// ok is false when the request was not authenticated by an API key.
+func APIKeyFromContext(ctx context.Context) (identity APIKeyIdentity, ok bool) {
+	identity, ok = ctx.Value(apiKeyCtxKey{}).(APIKeyIdentity)
+	return identity, ok
+}
+
+// WithAPIKey returns a copy of ctx carrying identity.
+func WithAPIKey(ctx context.Context, identity APIKeyIdentity) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, identity)
+}
+
+// keyFingerprint returns the APIKeyIdentity.ID for key.
+func keyFingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "apikey:" + hex.EncodeToString(sum[:8])
+}
+
 // APIKey returns a middleware that validates requests against a static API key.
 // The key is read from the "Authorization: Bearer <key>" header.
 // If the provided key is empty, the middleware behaves like Noop (no auth).
@@ -27,8 +146,32 @@ func APIKey(key string) func(http.Handler) http.Handler {
 	if key == "" {
 		return Noop()
 	}
+	return APIKeys(map[string]Scope{key: ScopeReadWrite}, nil)
+}
+
+// APIKeys is APIKey for several keys, each with a scope. A read-only key
+// making a mutating request gets 403. An empty map behaves like Noop.
+// Every key is compared on each request, so the match position doesn't
+// leak through timing.
+//
+// Keys listed in namespaces are restricted to those namespaces: the list is
+// attached to the request context (see AllowedNamespaces) for the API layer
+// to enforce once the route, and so the target namespace, is known.
+func APIKeys(keys map[string]Scope, namespaces map[string][]string) func(http.Handler) http.Handler {
+	if len(keys) == 0 {
+		return Noop()
+	}
 
-	keyBytes := []byte(key)
+	type scopedKey struct {
+		key        []byte
+		id         string
+		scope      Scope
+		namespaces []string
+	}
+	scoped := make([]scopedKey, 0, len(keys))
+	for k, scope := range keys {
+		scoped = append(scoped, scopedKey{key: []byte(k), id: keyFingerprint(k), scope: scope, namespaces: namespaces[k]})
+	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -44,16 +187,60 @@ func APIKey(key string) func(http.Handler) http.Handler {
 				return
 			}
 
-			if subtle.ConstantTimeCompare([]byte(token), keyBytes) != 1 {
+			var id string
+			var scope Scope
+			var allowed []string
+			for _, k := range scoped {
+				if subtle.ConstantTimeCompare([]byte(token), k.key) == 1 {
+					id, scope, allowed = k.id, k.scope, k.namespaces
+				}
+			}
+			if scope == "" {
 				http.Error(w, "invalid API key", http.StatusUnauthorized)
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			if scope == ScopeReadOnly && !IsReadRequest(r) {
+				http.Error(w, "API key is read-only", http.StatusForbidden)
+				return
+			}
+
+			ctx := WithAPIKey(r.Context(), APIKeyIdentity{ID: id, Scope: scope})
+			if allowed != nil {
+				ctx = WithAllowedNamespaces(ctx, allowed)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// readOnlyPOSTRoutes are POST endpoints that only read: their bodies carry
+// query input too large or structured for a URL. A "*" segment matches any
+// single path segment.
+var readOnlyPOSTRoutes = []string{
+	"/api/v1/runs/latest",
+	"/api/v1/query",
+	"/api/v1/pipelines/*/*/*/preview",
+	"/api/v1/pipelines/*/*/*/validate",
+}
+
+// IsReadRequest reports whether r cannot modify state: a read method, or a
+// POST to one of the read-only POST routes. Read-only API keys and the API's
+// read-only mode both allow exactly these requests.
+func IsReadRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		for _, route := range readOnlyPOSTRoutes {
+			if ok, _ := path.Match(route, r.URL.Path); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // extractBearerToken extracts the token from "Authorization: Bearer <token>".
 func extractBearerToken(r *http.Request) string {
 	h := r.Header.Get("Authorization")
@@ -8,6 +8,7 @@ import (
 
 	"github.com/rat-data/rat/platform/internal/auth"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNoop_PassesRequestThrough(t *testing.T) {
@@ -190,3 +191,186 @@ func TestAPIKey_PostToHealthRequiresAuth(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
+
+// --- Scoped API keys ---
+
+func TestParseAPIKeys_ParsesScopes(t *testing.T) {
+	keys, err := auth.ParseAPIKeys("key1:rw, key2:ro,,with:colon:ro")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]auth.Scope{
+		"key1":       auth.ScopeReadWrite,
+		"key2":       auth.ScopeReadOnly,
+		"with:colon": auth.ScopeReadOnly,
+	}, keys)
+}
+
+func TestParseAPIKeys_RejectsInvalidEntries(t *testing.T) {
+	for _, spec := range []string{"key1", ":rw", "key1:admin", "key1:rw,key1:ro"} {
+		_, err := auth.ParseAPIKeys(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestAPIKeys_ReadOnlyKeyBlockedOnPost(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be called")
+	})
+
+	wrapped := auth.APIKeys(map[string]auth.Scope{"reader": auth.ScopeReadOnly}, nil)(handler)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/api/v1/runs", http.NoBody)
+		req.Header.Set("Authorization", "Bearer reader")
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code, method)
+		assert.Contains(t, rec.Body.String(), "read-only", method)
+	}
+}
+
+func TestAPIKeys_ReadOnlyKeyAllowedOnGet(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	wrapped := auth.APIKeys(map[string]auth.Scope{"reader": auth.ScopeReadOnly}, nil)(handler)
+
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		req := httptest.NewRequest(method, "/api/v1/runs", http.NoBody)
+		req.Header.Set("Authorization", "Bearer reader")
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, method)
+	}
+}
+
+func TestAPIKeys_ReadOnlyKeyAllowedOnReadOnlyPosts(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	wrapped := auth.APIKeys(map[string]auth.Scope{"reader": auth.ScopeReadOnly}, nil)(handler)
+
+	for _, path := range []string{
+		"/api/v1/runs/latest",
+		"/api/v1/query",
+		"/api/v1/pipelines/default/silver/orders/preview",
+		"/api/v1/pipelines/default/silver/orders/validate",
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, http.NoBody)
+		req.Header.Set("Authorization", "Bearer reader")
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+
+	// The allowlist is exact: other POSTs under the same prefixes still write.
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pipelines/default/silver/orders/publish", http.NoBody)
+	req.Header.Set("Authorization", "Bearer reader")
+	rec := httptest.NewRecorder()
+	wrapped.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestAPIKeys_ReadWriteKeyAllowedOnPost(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	wrapped := auth.APIKeys(map[string]auth.Scope{
+		"reader": auth.ScopeReadOnly,
+		"writer": auth.ScopeReadWrite,
+	}, nil)(handler)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer writer")
+	rec := httptest.NewRecorder()
+	wrapped.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer unknown")
+	rec = httptest.NewRecorder()
+	wrapped.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestParseAPIKeyNamespaces_ParsesLists(t *testing.T) {
+	keys, err := auth.ParseAPIKeyNamespaces("team-a:sales|marketing, team-b:finance")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"team-a": {"sales", "marketing"},
+		"team-b": {"finance"},
+	}, keys)
+}
+
+func TestParseAPIKeyNamespaces_RejectsInvalidEntries(t *testing.T) {
+	for _, spec := range []string{"team-a", ":sales", "team-a:", "team-a:|", "team-a:sales,team-a:finance"} {
+		_, err := auth.ParseAPIKeyNamespaces(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestAPIKeys_AttachesAllowedNamespaces(t *testing.T) {
+	var got []string
+	var restricted bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, restricted = auth.AllowedNamespaces(r.Context())
+	})
+
+	wrapped := auth.APIKeys(
+		map[string]auth.Scope{"team": auth.ScopeReadWrite, "admin": auth.ScopeReadWrite},
+		map[string][]string{"team": {"sales"}},
+	)(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer team")
+	wrapped.ServeHTTP(httptest.NewRecorder(), req)
+	assert.True(t, restricted)
+	assert.Equal(t, []string{"sales"}, got)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer admin")
+	wrapped.ServeHTTP(httptest.NewRecorder(), req)
+	assert.False(t, restricted)
+}
+
+func TestAPIKeys_AttachesKeyIdentity(t *testing.T) {
+	var got auth.APIKeyIdentity
+	var ok bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = auth.APIKeyFromContext(r.Context())
+	})
+
+	wrapped := auth.APIKeys(map[string]auth.Scope{"reader": auth.ScopeReadOnly}, nil)(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer reader")
+	wrapped.ServeHTTP(httptest.NewRecorder(), req)
+	require.True(t, ok)
+	assert.Equal(t, auth.ScopeReadOnly, got.Scope)
+	assert.Regexp(t, `^apikey:[0-9a-f]{16}$`, got.ID)
+	assert.NotContains(t, got.ID, "reader")
+}
+
+func TestAPIKey_AttachesKeyIdentity(t *testing.T) {
+	var got auth.APIKeyIdentity
+	var ok bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok = auth.APIKeyFromContext(r.Context())
+	})
+
+	wrapped := auth.APIKey("secret")(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/runs", http.NoBody)
+	req.Header.Set("Authorization", "Bearer secret")
+	wrapped.ServeHTTP(httptest.NewRecorder(), req)
+	require.True(t, ok)
+	assert.Regexp(t, `^apikey:[0-9a-f]{16}$`, got.ID)
+	assert.NotContains(t, got.ID, "secret")
+}
@@ -2,12 +2,17 @@
 // Used to reduce redundant Postgres queries for slow-changing data like
 // namespace lists and pipeline metadata. Thread-safe via sync.RWMutex.
 //
-// Not intended for run data (changes frequently) or file content (too large).
+// Not intended for file content (too large). Run data is only cached with a
+// short TTL and explicit invalidation (see api.Server.LatestRunCache).
 package cache
 
 import (
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // DefaultTTL is the default time-to-live for cache entries (30 seconds).
@@ -23,12 +28,27 @@ type Options struct {
 
 	// MaxEntries is the maximum number of entries before eviction. Zero uses DefaultMaxEntries (1000).
 	MaxEntries int
+
+	// NegativeTTL is how long GetOrLoad remembers that a key was not found.
+	// Zero disables negative caching: every lookup of a missing key reloads.
+	NegativeTTL time.Duration
+}
+
+// Stats is a point-in-time snapshot of a cache's counters.
+// Counters are cumulative since the cache was created; Clear does not reset them.
+type Stats struct {
+	Hits      uint64 // Get calls that returned a live entry
+	Misses    uint64 // Get calls for a missing or expired key
+	Evictions uint64 // live entries dropped to make room under MaxEntries
+	Size      int    // current entry count, including expired-but-not-yet-cleaned
 }
 
 // entry holds a cached value and its expiration time.
+// Negative entries (key known not to exist) carry the zero value.
 type entry[V any] struct {
 	value     V
 	expiresAt time.Time
+	negative  bool
 }
 
 // Cache is a generic in-memory cache with TTL expiration and max-entries eviction.
@@ -37,11 +57,22 @@ type entry[V any] struct {
 // Eviction policy: when max entries is reached, expired entries are cleaned first.
 // If still at capacity, the oldest entry by insertion order is evicted.
 type Cache[K comparable, V any] struct {
-	mu         sync.RWMutex
-	entries    map[K]entry[V]
-	order      []K // insertion order for eviction
-	ttl        time.Duration
-	maxEntries int
+	mu          sync.RWMutex
+	entries     map[K]entry[V]
+	order       []K // insertion order for eviction
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxEntries  int
+
+	// loads deduplicates concurrent GetOrLoad misses for the same key.
+	loads singleflight.Group
+	// gen is bumped by Delete and Clear (under mu). A load that started
+	// before an invalidation does not store its possibly stale result.
+	gen uint64
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
 }
 
 // New creates a new Cache with the given options.
@@ -56,23 +87,83 @@ func New[K comparable, V any](opts Options) *Cache[K, V] {
 		maxEntries = DefaultMaxEntries
 	}
 	return &Cache[K, V]{
-		entries:    make(map[K]entry[V]),
-		order:      make([]K, 0),
-		ttl:        ttl,
-		maxEntries: maxEntries,
+		entries:     make(map[K]entry[V]),
+		order:       make([]K, 0),
+		ttl:         ttl,
+		negativeTTL: opts.NegativeTTL,
+		maxEntries:  maxEntries,
 	}
 }
 
 // Get retrieves a value by key. Returns the value and true if found and not expired.
 // Returns the zero value and false if the key is missing or expired.
+// A live negative entry (see GetOrLoad) returns the zero value and true.
 func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.lookup(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// GetOrLoad returns the cached value for key, calling load on a miss.
+// Concurrent misses for the same key share a single load call. load reports
+// found = false for keys that do not exist; with NegativeTTL set, that result
+// is cached too so repeated probes for a missing key skip the loader. Load
+// errors are returned to every waiting caller and never cached.
+func (c *Cache[K, V]) GetOrLoad(key K, load func() (value V, found bool, err error)) (V, bool, error) {
+	if e, ok := c.lookup(key); ok {
+		return e.value, !e.negative, nil
+	}
+
+	type result struct {
+		value V
+		found bool
+	}
+	v, err, _ := c.loads.Do(fmt.Sprint(key), func() (interface{}, error) {
+		c.mu.RLock()
+		gen := c.gen
+		c.mu.RUnlock()
+
+		value, found, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		var e entry[V]
+		switch {
+		case found:
+			e = entry[V]{value: value, expiresAt: time.Now().Add(c.ttl)}
+		case c.negativeTTL > 0:
+			e = entry[V]{expiresAt: time.Now().Add(c.negativeTTL), negative: true}
+		default:
+			return result{value: value, found: found}, nil
+		}
+		c.mu.Lock()
+		if c.gen == gen {
+			c.setLocked(key, e)
+		}
+		c.mu.Unlock()
+		return result{value: value, found: found}, nil
+	})
+	if err != nil {
+		var zero V
+		return zero, false, err
+	}
+	r := v.(result)
+	return r.value, r.found, nil
+}
+
+// lookup returns the live entry for key, counting the hit or miss.
+func (c *Cache[K, V]) lookup(key K) (entry[V], bool) {
 	c.mu.RLock()
 	e, ok := c.entries[key]
 	c.mu.RUnlock()
 
 	if !ok {
-		var zero V
-		return zero, false
+		c.misses.Add(1)
+		return entry[V]{}, false
 	}
 
 	if time.Now().After(e.expiresAt) {
@@ -80,11 +171,12 @@ func (c *Cache[K, V]) Get(key K) (V, bool) {
 		c.mu.Lock()
 		c.removeLocked(key)
 		c.mu.Unlock()
-		var zero V
-		return zero, false
+		c.misses.Add(1)
+		return entry[V]{}, false
 	}
 
-	return e.value, true
+	c.hits.Add(1)
+	return e, true
 }
 
 // Set adds or updates a cache entry. If the cache is at capacity, it first
@@ -92,13 +184,18 @@ func (c *Cache[K, V]) Get(key K) (V, bool) {
 func (c *Cache[K, V]) Set(key K, value V) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.setLocked(key, entry[V]{
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+}
 
+// setLocked stores e under key, making room first if the cache is full.
+// Caller must hold c.mu (write lock).
+func (c *Cache[K, V]) setLocked(key K, e entry[V]) {
 	// If key already exists, update in place (don't change order).
 	if _, exists := c.entries[key]; exists {
-		c.entries[key] = entry[V]{
-			value:     value,
-			expiresAt: time.Now().Add(c.ttl),
-		}
+		c.entries[key] = e
 		return
 	}
 
@@ -110,10 +207,7 @@ func (c *Cache[K, V]) Set(key K, value V) {
 		c.evictOldestLocked()
 	}
 
-	c.entries[key] = entry[V]{
-		value:     value,
-		expiresAt: time.Now().Add(c.ttl),
-	}
+	c.entries[key] = e
 	c.order = append(c.order, key)
 }
 
@@ -121,6 +215,7 @@ func (c *Cache[K, V]) Set(key K, value V) {
 func (c *Cache[K, V]) Delete(key K) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.gen++
 	c.removeLocked(key)
 }
 
@@ -128,6 +223,7 @@ func (c *Cache[K, V]) Delete(key K) {
 func (c *Cache[K, V]) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.gen++
 	c.entries = make(map[K]entry[V])
 	c.order = c.order[:0]
 }
@@ -139,6 +235,16 @@ func (c *Cache[K, V]) Len() int {
 	return len(c.entries)
 }
 
+// Stats returns the cache's hit, miss and eviction counters and current size.
+func (c *Cache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      c.Len(),
+	}
+}
+
 // TTL returns the configured time-to-live for cache entries.
 func (c *Cache[K, V]) TTL() time.Duration {
 	return c.ttl
@@ -188,4 +294,5 @@ func (c *Cache[K, V]) evictOldestLocked() {
 	oldest := c.order[0]
 	c.order = c.order[1:]
 	delete(c.entries, oldest)
+	c.evictions.Add(1)
 }
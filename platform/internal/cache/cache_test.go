@@ -1,7 +1,9 @@
 package cache_test
 
 import (
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -342,3 +344,206 @@ func TestCache_ExpiredEntries_CleanedOnEviction(t *testing.T) {
 	// Expired entries should be gone
 	assert.LessOrEqual(t, c.Len(), 1)
 }
+
+// --- Stats ---
+
+func TestCache_Stats_CountsHitsAndMisses(t *testing.T) {
+	c := cache.New[string, string](cache.Options{TTL: 5 * time.Second, MaxEntries: 100})
+
+	c.Set("a", "1")
+	c.Get("a")
+	c.Get("a")
+	c.Get("missing")
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(0), stats.Evictions)
+	assert.Equal(t, 1, stats.Size)
+}
+
+func TestCache_Stats_ExpiredGetCountsAsMiss(t *testing.T) {
+	c := cache.New[string, string](cache.Options{TTL: 10 * time.Millisecond, MaxEntries: 100})
+
+	c.Set("a", "1")
+	time.Sleep(20 * time.Millisecond)
+	c.Get("a")
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(0), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 0, stats.Size)
+}
+
+func TestCache_Stats_CountsCapacityEvictions(t *testing.T) {
+	c := cache.New[string, int](cache.Options{TTL: 5 * time.Second, MaxEntries: 2})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3) // evicts a
+	c.Set("d", 4) // evicts b
+	c.Set("d", 5) // update in place — no eviction
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(2), stats.Evictions)
+	assert.Equal(t, 2, stats.Size)
+}
+
+func TestCache_Stats_ExpiredCleanupIsNotEviction(t *testing.T) {
+	c := cache.New[string, int](cache.Options{TTL: 10 * time.Millisecond, MaxEntries: 2})
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	time.Sleep(20 * time.Millisecond)
+	c.Set("c", 3) // makes room by dropping expired entries
+
+	assert.Equal(t, uint64(0), c.Stats().Evictions)
+}
+
+func TestCache_Stats_SurviveClear(t *testing.T) {
+	c := cache.New[string, int](cache.Options{TTL: 5 * time.Second, MaxEntries: 10})
+
+	c.Set("a", 1)
+	c.Get("a")
+	c.Clear()
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, 0, stats.Size)
+}
+
+func TestCache_Stats_ConcurrentGets(t *testing.T) {
+	c := cache.New[int, int](cache.Options{TTL: 5 * time.Second, MaxEntries: 100})
+	c.Set(1, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Get(1)
+			c.Get(2)
+		}()
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(50), stats.Hits)
+	assert.Equal(t, uint64(50), stats.Misses)
+}
+
+// --- GetOrLoad ---
+
+func TestCache_GetOrLoad_ConcurrentMisses_LoadOnce(t *testing.T) {
+	c := cache.New[string, string](cache.Options{TTL: 5 * time.Second, MaxEntries: 100})
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() (string, bool, error) {
+		loads.Add(1)
+		<-release
+		return "value", true, nil
+	}
+
+	const n = 20
+	var started, wg sync.WaitGroup
+	results := make([]string, n)
+	for i := 0; i < n; i++ {
+		started.Add(1)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			started.Done()
+			v, found, err := c.GetOrLoad("key", load)
+			assert.NoError(t, err)
+			assert.True(t, found)
+			results[i] = v
+		}(i)
+	}
+	started.Wait()
+	// Give every goroutine time to join the in-flight load before releasing it.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+	for _, v := range results {
+		assert.Equal(t, "value", v)
+	}
+
+	// Subsequent lookups are served from the cache.
+	v, found, err := c.GetOrLoad("key", load)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "value", v)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestCache_GetOrLoad_NegativeTTL_CachesNotFound(t *testing.T) {
+	c := cache.New[string, *string](cache.Options{TTL: 5 * time.Second, NegativeTTL: 20 * time.Millisecond})
+
+	loads := 0
+	load := func() (*string, bool, error) {
+		loads++
+		return nil, false, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		v, found, err := c.GetOrLoad("missing", load)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Nil(t, v)
+	}
+	assert.Equal(t, 1, loads)
+
+	time.Sleep(30 * time.Millisecond)
+	_, _, _ = c.GetOrLoad("missing", load)
+	assert.Equal(t, 2, loads, "negative entry should expire after NegativeTTL")
+}
+
+func TestCache_GetOrLoad_NoNegativeTTL_ReloadsNotFound(t *testing.T) {
+	c := cache.New[string, string](cache.Options{TTL: 5 * time.Second})
+
+	loads := 0
+	load := func() (string, bool, error) {
+		loads++
+		return "", false, nil
+	}
+
+	_, _, _ = c.GetOrLoad("missing", load)
+	_, _, _ = c.GetOrLoad("missing", load)
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_GetOrLoad_ErrorNotCached(t *testing.T) {
+	c := cache.New[string, string](cache.Options{TTL: 5 * time.Second, NegativeTTL: time.Minute})
+
+	_, _, err := c.GetOrLoad("key", func() (string, bool, error) {
+		return "", false, errors.New("db down")
+	})
+	require.Error(t, err)
+
+	v, found, err := c.GetOrLoad("key", func() (string, bool, error) {
+		return "recovered", true, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "recovered", v)
+}
+
+func TestCache_GetOrLoad_DeleteDuringLoad_DiscardsResult(t *testing.T) {
+	c := cache.New[string, string](cache.Options{TTL: 5 * time.Second})
+
+	v, found, err := c.GetOrLoad("key", func() (string, bool, error) {
+		// An invalidation lands while the (now stale) value is being read.
+		c.Delete("key")
+		return "stale", true, nil
+	})
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "stale", v, "the caller still gets what was loaded")
+
+	_, ok := c.Get("key")
+	assert.False(t, ok, "a load that raced an invalidation must not be cached")
+}
@@ -15,6 +15,7 @@
 //
 // Internal-only fields are tagged with `json:"-"` to prevent accidental exposure:
 //   - Pipeline.DeletedAt (soft-delete timestamp, DB-only)
+//   - LandingZone.DeletedAt (soft-delete timestamp, DB-only)
 //   - Run.S3Overrides (transient cloud credentials, never persisted or serialized)
 package domain
 
@@ -29,6 +30,10 @@ import (
 // ErrAlreadyExists indicates a create operation conflicted with an existing resource.
 var ErrAlreadyExists = errors.New("resource already exists")
 
+// ErrDraftRevisionMismatch indicates a draft save whose base revision is no
+// longer the pipeline's current draft_revision (another save landed first).
+var ErrDraftRevisionMismatch = errors.New("draft revision mismatch")
+
 // Layer represents a medallion architecture layer.
 type Layer string
 
@@ -60,13 +65,53 @@ type Pipeline struct {
 	PublishedAt       *time.Time        `json:"published_at,omitempty"`
 	PublishedVersions map[string]string `json:"published_versions,omitempty"` // file path → S3 version ID
 	DraftDirty        bool              `json:"draft_dirty"`
+	DraftRevision     int64             `json:"draft_revision"` // bumped on every draft write; base for conflict-checked draft saves
 	MaxVersions       int               `json:"max_versions"`
 	RetentionConfig   json.RawMessage   `json:"retention_config,omitempty"` // per-pipeline overrides (null = system default)
+	Labels            map[string]string `json:"labels,omitempty"`           // free-form grouping, e.g. team → analytics
+	Priority          int               `json:"priority"`                   // dispatch priority copied onto new runs; higher goes first
+	Validation        *ValidationResult `json:"validation,omitempty"`       // latest publish-time template validation (nil = never validated)
 	CreatedAt         time.Time         `json:"created_at"`
 	UpdatedAt         time.Time         `json:"updated_at"`
 	DeletedAt         *time.Time        `json:"-"`
 }
 
+// ValidationResult holds the outcome of template validation for a pipeline.
+type ValidationResult struct {
+	Valid bool             `json:"valid"`
+	Files []FileValidation `json:"files"`
+}
+
+// FileValidation holds per-file validation results.
+type FileValidation struct {
+	Path     string   `json:"path"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+// PipelineS3Path returns the default storage prefix for a pipeline's files:
+// {namespace}/pipelines/{layer}/{name}/.
+func PipelineS3Path(namespace, layer, name string) string {
+	return namespace + "/pipelines/" + layer + "/" + name + "/"
+}
+
+// StoragePrefix returns the prefix the pipeline's files live under: S3Path
+// when set (it may be a custom prefix chosen at create time), otherwise the
+// default layout.
+func (p *Pipeline) StoragePrefix() string {
+	if p.S3Path != "" {
+		return p.S3Path
+	}
+	return PipelineS3Path(p.Namespace, string(p.Layer), p.Name)
+}
+
+// HasCustomS3Prefix reports whether the pipeline's files live outside the
+// default {namespace}/pipelines/{layer}/{name}/ layout.
+func (p *Pipeline) HasCustomS3Prefix() bool {
+	return p.StoragePrefix() != PipelineS3Path(p.Namespace, string(p.Layer), p.Name)
+}
+
 // PipelineVersion represents a published version snapshot of a pipeline.
 type PipelineVersion struct {
 	ID                uuid.UUID         `json:"id"`
@@ -102,11 +147,35 @@ type Run struct {
 	LogsS3Path  *string    `json:"logs_s3_path"`
 	CreatedAt   time.Time  `json:"created_at"`
 
+	// Metadata holds string annotations set when the run is created — by the
+	// caller of a manual run, or by the trigger that fired it.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// ParentRunID is the upstream run whose success fired this run through a
+	// pipeline_success trigger. Nil for runs started any other way.
+	ParentRunID *uuid.UUID `json:"parent_run_id,omitempty"`
+
+	// Priority is the pipeline's priority when the run was created. Higher
+	// priority runs are submitted first when several are ready at once.
+	Priority int `json:"priority"`
+
+	// Phases is the per-phase timing breakdown the runner reported when the
+	// run finished. Empty until then, and for runners that don't report it.
+	Phases []PhaseProfile `json:"phases,omitempty"`
+
 	// S3Overrides holds per-run S3 credentials injected by the cloud plugin.
 	// Transient — not persisted in Postgres. Passed to the executor on submit.
 	S3Overrides map[string]string `json:"-"`
 }
 
+// PhaseProfile captures timing for a single execution phase of a run or a
+// preview (e.g. "detect", "compile", "execute").
+type PhaseProfile struct {
+	Name       string            `json:"name"`
+	DurationMs int64             `json:"duration_ms"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
 // Schedule represents a cron-based trigger for a pipeline.
 type Schedule struct {
 	ID         uuid.UUID  `json:"id"`
@@ -154,10 +223,13 @@ type LandingZone struct {
 	Description         string    `json:"description"`
 	Owner               *string   `json:"owner,omitempty"`
 	ExpectedSchema      string    `json:"expected_schema"`
+	SchemaStrict        bool      `json:"schema_strict"`                    // reject uploads that don't match expected_schema
+	Dedupe              bool      `json:"dedupe"`                           // skip uploads whose content matches a pending file
 	ProcessedMaxAgeDays *int      `json:"processed_max_age_days,omitempty"` // _processed/ file retention (nil = never auto-purge)
-	AutoPurge           bool      `json:"auto_purge"`                       // enable automatic _processed/ cleanup
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	AutoPurge           bool       `json:"auto_purge"`                       // enable automatic _processed/ cleanup
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DeletedAt           *time.Time `json:"-"`
 }
 
 // TableMetadata stores user-maintained documentation for Iceberg tables.
@@ -184,6 +256,7 @@ type LandingFile struct {
 	ContentType string    `json:"content_type"`
 	UploadedBy  *string   `json:"uploaded_by,omitempty"`
 	UploadedAt  time.Time `json:"uploaded_at"`
+	Checksum    string    `json:"checksum_sha256,omitempty"` // hex SHA-256 of the content; empty for files uploaded before checksums
 }
 
 // TriggerType represents the type of pipeline trigger.
@@ -218,8 +291,20 @@ type PipelineTrigger struct {
 	CooldownSeconds int             `json:"cooldown_seconds"`
 	LastTriggeredAt *time.Time      `json:"last_triggered_at"`
 	LastRunID       *uuid.UUID      `json:"last_run_id"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
+	// BackoffStreak counts consecutive rapid fires of a trigger with a
+	// backoff config; each one doubles its effective cooldown.
+	BackoffStreak int       `json:"backoff_streak"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PipelineTriggerListItem is a trigger joined to its pipeline's identity,
+// returned by the global (cross-pipeline) trigger listing.
+type PipelineTriggerListItem struct {
+	PipelineTrigger
+	Namespace    string `json:"namespace"`
+	Layer        Layer  `json:"layer"`
+	PipelineName string `json:"pipeline_name"`
 }
 
 // AuditEntry represents a single audit log record.
@@ -292,9 +377,40 @@ type ReaperStatus struct {
 	BranchesCleaned int       `json:"branches_cleaned"`
 	LZFilesCleaned int        `json:"lz_files_cleaned"`
 	AuditPruned    int        `json:"audit_pruned"`
+	ZonesPurged    int        `json:"zones_purged"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 }
 
+// RetentionPreview is what a reaper pass would clean up right now, computed
+// without deleting anything. Each rule is counted independently, so a run
+// that is both beyond the per-pipeline limit and past the max age appears in
+// both RunsBeyondLimit and RunsOlderThan.
+type RetentionPreview struct {
+	RunsBeyondLimit int `json:"runs_beyond_limit"`
+	RunsOlderThan   int `json:"runs_older_than"`
+	RunsFailed      int `json:"runs_failed"`
+	PipelinesPurged int `json:"pipelines_purged"`
+	ZonesPurged     int `json:"zones_purged"`
+	BranchesCleaned int `json:"branches_cleaned"`
+	LZFilesCleaned  int `json:"lz_files_cleaned"`
+	AuditPruned     int `json:"audit_pruned"`
+}
+
+// Run-completion notification event filters.
+const (
+	NotificationEventsSuccess = "success"
+	NotificationEventsFailure = "failure"
+	NotificationEventsAll     = "all"
+)
+
+// NotificationConfig is a namespace's outbound run-completion webhook.
+// Stored as JSONB in platform_settings under key "notifications:{namespace}".
+type NotificationConfig struct {
+	URL    string `json:"url"`
+	Events string `json:"events"`           // success, failure, or all
+	Secret string `json:"secret,omitempty"` // HMAC signing secret, encrypted at rest
+}
+
 // FeatureFlags holds runtime-configurable feature toggles.
 // Stored as JSONB in platform_settings under key "feature_flags".
 // Community defaults enable all community features. Pro features default to false.
@@ -385,6 +501,24 @@ type RunnerPlugin struct {
 	PackageName string `json:"package_name"` // Python package name ("rat-plugin-soft-delete")
 }
 
+// RunnerCapacity is the runner concurrency snapshot exposed via
+// GET /api/v1/runner/capacity. With several runners (round-robin) the totals
+// are summed over the runners that answered; Runners has the breakdown.
+type RunnerCapacity struct {
+	MaxConcurrentRuns int                      `json:"max_concurrent_runs"`
+	ActiveRuns        int                      `json:"active_runs"`
+	Runners           []RunnerInstanceCapacity `json:"runners"`
+}
+
+// RunnerInstanceCapacity is one runner's answer to the GetCapacity gRPC call.
+// Error is set, and the counts left at zero, when the runner didn't answer.
+type RunnerInstanceCapacity struct {
+	Addr              string `json:"addr"`
+	MaxConcurrentRuns int    `json:"max_concurrent_runs"`
+	ActiveRuns        int    `json:"active_runs"`
+	Error             string `json:"error,omitempty"`
+}
+
 // ── Plugin Catalog ─────────────────────────────────────────────
 
 // PluginStatus represents the lifecycle state of a registered plugin.
@@ -74,6 +74,30 @@ func (a *AtomicExecutor) GetLogs(ctx context.Context, runID string) ([]api.LogEn
 	return exec.GetLogs(ctx, runID)
 }
 
+// StreamLogs delegates to the inner executor if it implements api.LogStreamer.
+// Returns ErrLogStreamingUnsupported otherwise so callers can fall back to polling.
+func (a *AtomicExecutor) StreamLogs(ctx context.Context, runID string, send func(api.LogEntry) error) error {
+	exec := a.Get()
+	if exec == nil {
+		return ErrNoExecutor
+	}
+	if streamer, ok := exec.(api.LogStreamer); ok {
+		return streamer.StreamLogs(ctx, runID, send)
+	}
+	return api.ErrLogStreamingUnsupported
+}
+
+// InferSampleSchema delegates to the inner executor if it implements
+// api.SampleSchemaInferrer. Returns ErrSchemaInferenceUnsupported otherwise —
+// including when no executor is loaded — so callers fall back to server-side
+// parsing.
+func (a *AtomicExecutor) InferSampleSchema(ctx context.Context, paths []string) ([]api.QueryColumn, error) {
+	if inferrer, ok := a.Get().(api.SampleSchemaInferrer); ok {
+		return inferrer.InferSampleSchema(ctx, paths)
+	}
+	return nil, api.ErrSchemaInferenceUnsupported
+}
+
 // Preview delegates to the inner executor.
 func (a *AtomicExecutor) Preview(ctx context.Context, pipeline *domain.Pipeline, limit int, sampleFiles []string, code string) (*api.PreviewResult, error) {
 	exec := a.Get()
@@ -106,3 +130,31 @@ func (a *AtomicExecutor) HandleStatusCallback(ctx context.Context, update api.Ru
 	// Inner executor doesn't support callbacks — accept gracefully.
 	return nil
 }
+
+// ExecutorStats delegates to the inner executor if it implements
+// api.ExecutorStatsReporter. Reports ok = false otherwise so /metrics omits
+// the executor series instead of publishing misleading zeros.
+func (a *AtomicExecutor) ExecutorStats() (api.ExecutorStats, bool) {
+	exec := a.Get()
+	if exec == nil {
+		return api.ExecutorStats{}, false
+	}
+	if reporter, ok := exec.(api.ExecutorStatsReporter); ok {
+		return reporter.ExecutorStats()
+	}
+	return api.ExecutorStats{}, false
+}
+
+// HealthCheck reports whether the executor can accept work: an executor must
+// be loaded, and if it implements api.HealthChecker it must pass. Wired as
+// the "executor" dependency of GET /health/ready.
+func (a *AtomicExecutor) HealthCheck(ctx context.Context) error {
+	exec := a.Get()
+	if exec == nil {
+		return ErrNoExecutor
+	}
+	if checker, ok := exec.(api.HealthChecker); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return nil
+}
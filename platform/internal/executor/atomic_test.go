@@ -216,3 +216,43 @@ func TestAtomicExecutor_StatusCallback_GracefulWhenNotSupported(t *testing.T) {
 	err := ae.HandleStatusCallback(context.Background(), update)
 	assert.NoError(t, err, "should accept gracefully when inner doesn't support callbacks")
 }
+
+func TestAtomicExecutor_ExecutorStats_OmittedWhenNotSupported(t *testing.T) {
+	a := NewAtomicExecutor()
+	_, ok := a.ExecutorStats()
+	assert.False(t, ok, "empty executor reports nothing")
+
+	a.Swap(&mockExec{})
+	_, ok = a.ExecutorStats()
+	assert.False(t, ok, "executor without stats reports nothing")
+
+	// atomic.Value requires a consistent concrete type — use a fresh wrapper.
+	b := NewAtomicExecutor()
+	b.Swap(newWarmPoolExecutorWithClient(&mockRunnerClient{}, newMockRunStore()))
+	_, ok = b.ExecutorStats()
+	assert.True(t, ok)
+}
+
+func TestAtomicExecutor_InferSampleSchema_UnsupportedFallsBack(t *testing.T) {
+	a := NewAtomicExecutor()
+	_, err := a.InferSampleSchema(context.Background(), []string{"default/landing/z/_samples/a.csv"})
+	assert.ErrorIs(t, err, api.ErrSchemaInferenceUnsupported, "no executor loaded still falls back")
+
+	a.Swap(&mockExec{})
+	_, err = a.InferSampleSchema(context.Background(), []string{"default/landing/z/_samples/a.csv"})
+	assert.ErrorIs(t, err, api.ErrSchemaInferenceUnsupported)
+}
+
+func TestAtomicExecutor_HealthCheck(t *testing.T) {
+	a := NewAtomicExecutor()
+	assert.ErrorIs(t, a.HealthCheck(context.Background()), ErrNoExecutor, "empty executor is not ready")
+
+	a.Swap(&mockExec{})
+	assert.NoError(t, a.HealthCheck(context.Background()), "executor without HealthCheck is assumed ready")
+
+	// atomic.Value requires a consistent concrete type — use a fresh wrapper.
+	wp := newWarmPoolExecutorWithClient(&mockRunnerClient{}, newMockRunStore())
+	b := NewAtomicExecutor()
+	b.Swap(wp)
+	assert.ErrorIs(t, b.HealthCheck(context.Background()), errExecutorNotStarted, "delegates to the inner executor")
+}
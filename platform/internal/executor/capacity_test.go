@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	runnerv1 "github.com/rat-data/rat/platform/gen/runner/v1"
+	"github.com/rat-data/rat/platform/gen/runner/v1/runnerv1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capacityRunner is a runner service that only answers GetCapacity.
+type capacityRunner struct {
+	runnerv1connect.UnimplementedRunnerServiceHandler
+	max, active int32
+}
+
+func (c *capacityRunner) GetCapacity(_ context.Context, _ *connect.Request[runnerv1.GetCapacityRequest]) (*connect.Response[runnerv1.GetCapacityResponse], error) {
+	return connect.NewResponse(&runnerv1.GetCapacityResponse{MaxConcurrentRuns: c.max, ActiveRuns: c.active}), nil
+}
+
+// startStubRunner serves handler as a ConnectRPC runner over h2c.
+func startStubRunner(t *testing.T, handler runnerv1connect.RunnerServiceHandler) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(runnerv1connect.NewRunnerServiceHandler(handler))
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestWarmPool_RunnerCapacity_ReportsStubRunner(t *testing.T) {
+	addr := startStubRunner(t, &capacityRunner{max: 10, active: 8})
+	exec := NewWarmPoolExecutor(addr, newMockRunStore())
+
+	capacity, err := exec.RunnerCapacity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 10, capacity.MaxConcurrentRuns)
+	assert.Equal(t, 8, capacity.ActiveRuns)
+	require.Len(t, capacity.Runners, 1)
+	assert.Equal(t, addr, capacity.Runners[0].Addr)
+}
+
+func TestWarmPool_RunnerCapacity_RunnerWithoutRPC_ReturnsError(t *testing.T) {
+	addr := startStubRunner(t, runnerv1connect.UnimplementedRunnerServiceHandler{})
+	exec := NewWarmPoolExecutor(addr, newMockRunStore())
+
+	_, err := exec.RunnerCapacity(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestRoundRobin_RunnerCapacity_SumsStubRunners(t *testing.T) {
+	addrs := []string{
+		startStubRunner(t, &capacityRunner{max: 10, active: 8}),
+		startStubRunner(t, &capacityRunner{max: 10, active: 2}),
+	}
+	rr := NewRoundRobinExecutor(addrs, newMockRunStore())
+
+	capacity, err := rr.RunnerCapacity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 20, capacity.MaxConcurrentRuns)
+	assert.Equal(t, 10, capacity.ActiveRuns)
+	require.Len(t, capacity.Runners, 2)
+	assert.Equal(t, addrs[0], capacity.Runners[0].Addr)
+}
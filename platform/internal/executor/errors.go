@@ -0,0 +1,60 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	connect "connectrpc.com/connect"
+	"github.com/rat-data/rat/platform/internal/api"
+)
+
+// Submit failure classes, re-exported from api so callers that already
+// import executor (scheduler, tests) don't need both packages.
+var (
+	// ErrRunnerBusy is returned when the runner rejects a submission because
+	// it has reached its maximum concurrent run limit (RESOURCE_EXHAUSTED).
+	ErrRunnerBusy = api.ErrRunnerBusy
+	// ErrRunnerUnavailable is returned for transient failures: the runner is
+	// unreachable, timed out, or failed internally.
+	ErrRunnerUnavailable = api.ErrRunnerUnavailable
+	// ErrPipelineInvalid is returned when the runner rejects the pipeline
+	// itself — retrying the same submission cannot succeed.
+	ErrPipelineInvalid = api.ErrPipelineInvalid
+)
+
+// Readiness errors reported by the executors' HealthCheck methods.
+var (
+	errExecutorNotStarted = errors.New("executor not started")
+	errExecutorStopped    = errors.New("executor poll loop stopped")
+)
+
+// pollLoopHealth reports the state of an executor's background poll loop
+// from its done channel: nil before Start, closed once the loop exits.
+func pollLoopHealth(done chan struct{}) error {
+	if done == nil {
+		return errExecutorNotStarted
+	}
+	select {
+	case <-done:
+		return errExecutorStopped
+	default:
+		return nil
+	}
+}
+
+// classifySubmitError wraps a Submit RPC error in the matching failure
+// class, keeping the original error in the chain. Codes that describe the
+// request itself are permanent; everything else — including non-connect
+// transport errors — is treated as a transient outage, because leaving a
+// run pending is recoverable and failing a good run is not.
+func classifySubmitError(err error) error {
+	switch connect.CodeOf(err) {
+	case connect.CodeResourceExhausted:
+		return fmt.Errorf("%w: %w", ErrRunnerBusy, err)
+	case connect.CodeInvalidArgument, connect.CodeFailedPrecondition,
+		connect.CodeNotFound, connect.CodeOutOfRange:
+		return fmt.Errorf("%w: %w", ErrPipelineInvalid, err)
+	default:
+		return fmt.Errorf("%w: %w", ErrRunnerUnavailable, err)
+	}
+}
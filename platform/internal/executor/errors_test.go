@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	connect "connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifySubmitError_MapsConnectCodes(t *testing.T) {
+	cases := map[connect.Code]error{
+		connect.CodeResourceExhausted:  ErrRunnerBusy,
+		connect.CodeInvalidArgument:    ErrPipelineInvalid,
+		connect.CodeFailedPrecondition: ErrPipelineInvalid,
+		connect.CodeNotFound:           ErrPipelineInvalid,
+		connect.CodeOutOfRange:         ErrPipelineInvalid,
+		connect.CodeUnavailable:        ErrRunnerUnavailable,
+		connect.CodeDeadlineExceeded:   ErrRunnerUnavailable,
+		connect.CodeCanceled:           ErrRunnerUnavailable,
+		connect.CodeAborted:            ErrRunnerUnavailable,
+		connect.CodeInternal:           ErrRunnerUnavailable,
+		connect.CodeUnknown:            ErrRunnerUnavailable,
+		connect.CodeUnimplemented:      ErrRunnerUnavailable,
+		connect.CodeUnauthenticated:    ErrRunnerUnavailable,
+		connect.CodePermissionDenied:   ErrRunnerUnavailable,
+		connect.CodeDataLoss:           ErrRunnerUnavailable,
+		connect.CodeAlreadyExists:      ErrRunnerUnavailable,
+	}
+	sentinels := []error{ErrRunnerBusy, ErrRunnerUnavailable, ErrPipelineInvalid}
+
+	for code, want := range cases {
+		t.Run(code.String(), func(t *testing.T) {
+			orig := connect.NewError(code, errors.New("boom"))
+			got := classifySubmitError(orig)
+
+			assert.ErrorIs(t, got, orig, "original error must stay in the chain")
+			for _, s := range sentinels {
+				assert.Equal(t, s == want, errors.Is(got, s), "errors.Is(%v)", s)
+			}
+		})
+	}
+}
+
+func TestClassifySubmitError_NonConnectError_IsUnavailable(t *testing.T) {
+	err := classifySubmitError(errors.New("dial tcp: connection refused"))
+	assert.ErrorIs(t, err, ErrRunnerUnavailable)
+}
+
+func TestErrExecutorDraining_IsUnavailable(t *testing.T) {
+	assert.ErrorIs(t, ErrExecutorDraining, ErrRunnerUnavailable)
+}
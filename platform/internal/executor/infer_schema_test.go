@@ -0,0 +1,65 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	runnerv1 "github.com/rat-data/rat/platform/gen/runner/v1"
+	"github.com/rat-data/rat/platform/gen/runner/v1/runnerv1connect"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaRunner is a runner service that only answers InferSampleSchema.
+type schemaRunner struct {
+	runnerv1connect.UnimplementedRunnerServiceHandler
+	columns []*runnerv1.ColumnInfo
+	err     error
+	paths   []string
+}
+
+func (s *schemaRunner) InferSampleSchema(_ context.Context, req *connect.Request[runnerv1.InferSampleSchemaRequest]) (*connect.Response[runnerv1.InferSampleSchemaResponse], error) {
+	s.paths = req.Msg.Paths
+	if s.err != nil {
+		return nil, s.err
+	}
+	return connect.NewResponse(&runnerv1.InferSampleSchemaResponse{Columns: s.columns}), nil
+}
+
+func TestWarmPool_InferSampleSchema_ReturnsRunnerColumns(t *testing.T) {
+	runner := &schemaRunner{columns: []*runnerv1.ColumnInfo{{Name: "id", Type: "INTEGER"}, {Name: "name", Type: "VARCHAR"}}}
+	exec := NewWarmPoolExecutor(startStubRunner(t, runner), newMockRunStore())
+
+	cols, err := exec.InferSampleSchema(context.Background(), []string{"default/landing/orders/_samples/a.csv"})
+	require.NoError(t, err)
+	assert.Equal(t, []api.QueryColumn{{Name: "id", Type: "INTEGER"}, {Name: "name", Type: "VARCHAR"}}, cols)
+	assert.Equal(t, []string{"default/landing/orders/_samples/a.csv"}, runner.paths)
+}
+
+func TestWarmPool_InferSampleSchema_RunnerWithoutRPC_ReturnsUnsupported(t *testing.T) {
+	addr := startStubRunner(t, runnerv1connect.UnimplementedRunnerServiceHandler{})
+	exec := NewWarmPoolExecutor(addr, newMockRunStore())
+
+	_, err := exec.InferSampleSchema(context.Background(), []string{"a.csv"})
+	assert.ErrorIs(t, err, api.ErrSchemaInferenceUnsupported)
+}
+
+func TestWarmPool_InferSampleSchema_UnreadableSamples_ReturnsInvalidSamples(t *testing.T) {
+	runner := &schemaRunner{err: connect.NewError(connect.CodeInvalidArgument, assert.AnError)}
+	exec := NewWarmPoolExecutor(startStubRunner(t, runner), newMockRunStore())
+
+	_, err := exec.InferSampleSchema(context.Background(), []string{"a.txt"})
+	assert.ErrorIs(t, err, api.ErrInvalidSamples)
+	assert.Contains(t, err.Error(), assert.AnError.Error())
+}
+
+func TestRoundRobin_InferSampleSchema_UsesStubRunner(t *testing.T) {
+	runner := &schemaRunner{columns: []*runnerv1.ColumnInfo{{Name: "id", Type: "BIGINT"}}}
+	rr := NewRoundRobinExecutor([]string{startStubRunner(t, runner), startStubRunner(t, runner)}, newMockRunStore())
+
+	cols, err := rr.InferSampleSchema(context.Background(), []string{"a.parquet"})
+	require.NoError(t, err)
+	assert.Equal(t, []api.QueryColumn{{Name: "id", Type: "BIGINT"}}, cols)
+}
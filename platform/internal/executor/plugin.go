@@ -5,6 +5,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -66,7 +67,7 @@ func newPluginExecutorWithClient(client executorv1connect.ExecutorServiceClient,
 
 // Submit sends a pipeline run to the executor plugin.
 // On success, updates the run status to "running" and tracks it in the active map.
-// On failure, updates the run status to "failed".
+// Only a rejected pipeline (ErrPipelineInvalid) marks the run failed.
 func (e *PluginExecutor) Submit(ctx context.Context, run *domain.Run, pipeline *domain.Pipeline) error {
 	req := connect.NewRequest(&executorv1.SubmitRequest{
 		RunId:        run.ID.String(),
@@ -75,13 +76,18 @@ func (e *PluginExecutor) Submit(ctx context.Context, run *domain.Run, pipeline *
 		PipelineName: pipeline.Name,
 		Trigger:      run.Trigger,
 		S3Credentials: s3OverridesToProto(run.S3Overrides),
+		Env:           runEnv(run, pipeline),
 	})
 	propagateRequestID(ctx, req)
 
 	_, err := e.executor.Submit(ctx, req)
 	if err != nil {
-		errMsg := fmt.Sprintf("executor plugin unavailable: %v", err)
-		_ = e.runs.UpdateRunStatus(ctx, run.ID.String(), domain.RunStatusFailed, &errMsg, nil, nil)
+		// Same contract as WarmPoolExecutor.Submit: only a rejected pipeline
+		// fails the run; busy/unavailable leave it pending.
+		err = classifySubmitError(err)
+		if errors.Is(err, ErrPipelineInvalid) {
+			api.FailRunAfterSubmit(ctx, e.runs, run, err)
+		}
 		return fmt.Errorf("submit pipeline: %w", err)
 	}
 
@@ -152,6 +158,12 @@ func (e *PluginExecutor) Start(ctx context.Context) {
 	}()
 }
 
+// HealthCheck reports whether the executor has been started and its poll
+// loop is still running.
+func (e *PluginExecutor) HealthCheck(_ context.Context) error {
+	return pollLoopHealth(e.done)
+}
+
 // Stop cancels the background goroutine and waits for it to finish.
 func (e *PluginExecutor) Stop() {
 	if e.cancel != nil {
@@ -252,3 +264,27 @@ func s3OverridesToProto(m map[string]string) *commonv1.S3Credentials {
 	}
 }
 
+
+// runEnv builds the per-run environment variables sent with a submission.
+// Returns nil when there are none. Only well-known metadata keys are
+// forwarded: run metadata can carry caller-supplied values (e.g. webhook
+// payload fields) that must not leak into the runner's environment.
+func runEnv(run *domain.Run, pipeline *domain.Pipeline) map[string]string {
+	env := pipelineEnv(pipeline)
+	if date, ok := run.Metadata[api.BackfillDateMetadataKey]; ok {
+		if env == nil {
+			env = make(map[string]string, 1)
+		}
+		env[api.BackfillDateEnvVar] = date
+	}
+	return env
+}
+
+// pipelineEnv returns the environment describing where a pipeline's files
+// live, or nil for the default layout.
+func pipelineEnv(pipeline *domain.Pipeline) map[string]string {
+	if !pipeline.HasCustomS3Prefix() {
+		return nil
+	}
+	return map[string]string{api.PipelineS3PrefixEnvVar: pipeline.StoragePrefix()}
+}
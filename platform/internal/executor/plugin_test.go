@@ -69,7 +69,7 @@ func TestPluginSubmit_Available_UpdatesToRunning(t *testing.T) {
 	assert.True(t, tracked)
 }
 
-func TestPluginSubmit_Unavailable_UpdatesToFailed(t *testing.T) {
+func TestPluginSubmit_Unavailable_LeavesRunPending(t *testing.T) {
 	mock := &mockExecutorClient{
 		submitFunc: func(_ context.Context, _ *connect.Request[executorv1.SubmitRequest]) (*connect.Response[executorv1.SubmitResponse], error) {
 			return nil, connect.NewError(connect.CodeUnavailable, errors.New("connection refused"))
@@ -82,7 +82,25 @@ func TestPluginSubmit_Unavailable_UpdatesToFailed(t *testing.T) {
 	pipeline := testPipeline()
 
 	err := exec.Submit(context.Background(), run, pipeline)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrRunnerUnavailable)
+
+	assert.NotEqual(t, domain.RunStatusFailed, store.getStatus(run.ID.String()))
+}
+
+func TestPluginSubmit_PipelineInvalid_UpdatesToFailed(t *testing.T) {
+	mock := &mockExecutorClient{
+		submitFunc: func(_ context.Context, _ *connect.Request[executorv1.SubmitRequest]) (*connect.Response[executorv1.SubmitResponse], error) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("image not found"))
+		},
+	}
+	store := newMockRunStore()
+	exec := newPluginExecutorWithClient(mock, store)
+
+	run := testRun()
+	pipeline := testPipeline()
+
+	err := exec.Submit(context.Background(), run, pipeline)
+	assert.ErrorIs(t, err, ErrPipelineInvalid)
 
 	assert.Equal(t, domain.RunStatusFailed, store.getStatus(run.ID.String()))
 	assert.NotNil(t, store.getError(run.ID.String()))
@@ -286,3 +304,55 @@ func TestPluginSubmit_NoOverrides_NilS3Credentials(t *testing.T) {
 	require.NotNil(t, captured)
 	assert.Nil(t, captured.S3Credentials, "S3Credentials must be nil without cloud overrides")
 }
+
+func TestPluginSubmit_BackfillDate_ForwardedAsEnv(t *testing.T) {
+	var captured *executorv1.SubmitRequest
+	mock := &mockExecutorClient{
+		submitFunc: func(_ context.Context, req *connect.Request[executorv1.SubmitRequest]) (*connect.Response[executorv1.SubmitResponse], error) {
+			captured = req.Msg
+			return connect.NewResponse(&executorv1.SubmitResponse{}), nil
+		},
+	}
+	exec := newPluginExecutorWithClient(mock, newMockRunStore())
+
+	run := testRun()
+	run.Metadata = map[string]string{"backfill_date": "2026-01-15", "bucket": "from-a-webhook"}
+	require.NoError(t, exec.Submit(context.Background(), run, testPipeline()))
+	require.NotNil(t, captured)
+	assert.Equal(t, map[string]string{"RAT_BACKFILL_DATE": "2026-01-15"}, captured.Env,
+		"only the backfill date is forwarded, never arbitrary metadata")
+
+	captured = nil
+	require.NoError(t, exec.Submit(context.Background(), testRun(), testPipeline()))
+	require.NotNil(t, captured)
+	assert.Empty(t, captured.Env)
+}
+
+func TestPluginSubmit_CustomS3Prefix_ForwardedAsEnv(t *testing.T) {
+	var captured *executorv1.SubmitRequest
+	mock := &mockExecutorClient{
+		submitFunc: func(_ context.Context, req *connect.Request[executorv1.SubmitRequest]) (*connect.Response[executorv1.SubmitResponse], error) {
+			captured = req.Msg
+			return connect.NewResponse(&executorv1.SubmitResponse{}), nil
+		},
+	}
+	exec := newPluginExecutorWithClient(mock, newMockRunStore())
+
+	run := testRun()
+	run.Metadata = map[string]string{"backfill_date": "2026-01-15"}
+	pipeline := testPipeline()
+	pipeline.S3Path = "default/shared/orders/"
+	require.NoError(t, exec.Submit(context.Background(), run, pipeline))
+	require.NotNil(t, captured)
+	assert.Equal(t, map[string]string{
+		"RAT_BACKFILL_DATE":      "2026-01-15",
+		"RAT_PIPELINE_S3_PREFIX": "default/shared/orders/",
+	}, captured.Env)
+
+	// The default layout sends nothing.
+	captured = nil
+	pipeline.S3Path = "default/pipelines/silver/orders/"
+	require.NoError(t, exec.Submit(context.Background(), testRun(), pipeline))
+	require.NotNil(t, captured)
+	assert.Empty(t, captured.Env)
+}
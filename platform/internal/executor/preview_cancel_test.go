@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	runnerv1 "github.com/rat-data/rat/platform/gen/runner/v1"
+	"github.com/rat-data/rat/platform/gen/runner/v1/runnerv1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingPreviewRunner is a runner service whose PreviewPipeline blocks until
+// the RPC's context is cancelled, reporting when it starts and when it sees
+// the cancellation.
+type blockingPreviewRunner struct {
+	runnerv1connect.UnimplementedRunnerServiceHandler
+	started   chan struct{}
+	cancelled chan struct{}
+}
+
+func (b *blockingPreviewRunner) PreviewPipeline(ctx context.Context, _ *connect.Request[runnerv1.PreviewPipelineRequest]) (*connect.Response[runnerv1.PreviewPipelineResponse], error) {
+	close(b.started)
+	select {
+	case <-ctx.Done():
+		close(b.cancelled)
+		return nil, ctx.Err()
+	case <-time.After(10 * time.Second):
+		return connect.NewResponse(&runnerv1.PreviewPipelineResponse{}), nil
+	}
+}
+
+// TestPreview_CallerCancel_CancelsRunnerRPC runs Preview against a real
+// ConnectRPC runner over h2c and checks that cancelling the caller's context
+// (what happens when an HTTP client aborts) reaches the runner's handler.
+func TestPreview_CallerCancel_CancelsRunnerRPC(t *testing.T) {
+	runner := &blockingPreviewRunner{started: make(chan struct{}), cancelled: make(chan struct{})}
+	mux := http.NewServeMux()
+	mux.Handle(runnerv1connect.NewRunnerServiceHandler(runner))
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	defer srv.Close()
+
+	exec := NewWarmPoolExecutor(srv.URL, newMockRunStore())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := exec.Preview(ctx, testPipeline(), 100, nil, "")
+		errCh <- err
+	}()
+
+	select {
+	case <-runner.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner never received the preview RPC")
+	}
+	cancel()
+
+	select {
+	case <-runner.cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runner RPC context was not cancelled")
+	}
+	select {
+	case err := <-errCh:
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeCanceled, connect.CodeOf(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("Preview did not return after cancellation")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/rat-data/rat/platform/internal/api"
@@ -127,6 +128,24 @@ func (rr *RoundRobinExecutor) GetLogs(ctx context.Context, runID string) ([]api.
 	return nil, lastErr
 }
 
+// StreamLogs relays live logs from whichever runner owns the run. Runners
+// that don't track the run fail fast without opening a stream, so trying
+// each in turn costs at most one map lookup per replica.
+func (rr *RoundRobinExecutor) StreamLogs(ctx context.Context, runID string, send func(api.LogEntry) error) error {
+	var lastErr error
+	for _, exec := range rr.executors {
+		exec.mu.Lock()
+		_, tracked := exec.runnerIDs[runID]
+		exec.mu.Unlock()
+		if !tracked {
+			lastErr = fmt.Errorf("run %s not tracked (may have completed)", runID)
+			continue
+		}
+		return exec.StreamLogs(ctx, runID, send)
+	}
+	return lastErr
+}
+
 // Preview sends the preview request to the next runner in round-robin order.
 // Preview is a stateless operation so any runner can handle it.
 func (rr *RoundRobinExecutor) Preview(ctx context.Context, pipeline *domain.Pipeline, limit int, sampleFiles []string, code string) (*api.PreviewResult, error) {
@@ -141,6 +160,13 @@ func (rr *RoundRobinExecutor) ValidatePipeline(ctx context.Context, pipeline *do
 	return rr.executors[idx].ValidatePipeline(ctx, pipeline)
 }
 
+// InferSampleSchema sends the request to the next runner in round-robin order.
+// Inference only reads the samples, so any runner can handle it.
+func (rr *RoundRobinExecutor) InferSampleSchema(ctx context.Context, paths []string) ([]api.QueryColumn, error) {
+	idx := rr.next()
+	return rr.executors[idx].InferSampleSchema(ctx, paths)
+}
+
 // Start begins the background polling goroutine on each underlying executor.
 func (rr *RoundRobinExecutor) Start(ctx context.Context) {
 	for _, exec := range rr.executors {
@@ -155,6 +181,34 @@ func (rr *RoundRobinExecutor) Stop() {
 	}
 }
 
+// HealthCheck passes while at least one runner's executor is accepting work,
+// since Submit fails over between them. Otherwise it joins every error.
+func (rr *RoundRobinExecutor) HealthCheck(ctx context.Context) error {
+	errs := make([]error, len(rr.executors))
+	for i, exec := range rr.executors {
+		if errs[i] = exec.HealthCheck(ctx); errs[i] == nil {
+			return nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Drain drains every runner's executor concurrently and returns once all of
+// them have finished. The returned error joins any per-runner drain errors.
+func (rr *RoundRobinExecutor) Drain(ctx context.Context) error {
+	errs := make([]error, len(rr.executors))
+	var wg sync.WaitGroup
+	for i, exec := range rr.executors {
+		wg.Add(1)
+		go func(i int, exec *WarmPoolExecutor) {
+			defer wg.Done()
+			errs[i] = exec.Drain(ctx)
+		}(i, exec)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
 // SetLandingZones sets the landing zone store on all underlying executors.
 func (rr *RoundRobinExecutor) SetLandingZones(lz api.LandingZoneStore) {
 	for _, exec := range rr.executors {
@@ -169,11 +223,61 @@ func (rr *RoundRobinExecutor) SetOnRunComplete(fn func(ctx context.Context, run
 	}
 }
 
+// ExecutorStats sums the metrics counters of every underlying executor so
+// /metrics reports fleet-wide totals rather than a single runner's view.
+func (rr *RoundRobinExecutor) ExecutorStats() (api.ExecutorStats, bool) {
+	total := api.ExecutorStats{SubmitFailures: map[string]uint64{}}
+	for _, exec := range rr.executors {
+		stats, _ := exec.ExecutorStats()
+		total.ActiveRuns += stats.ActiveRuns
+		total.SubmitsTotal += stats.SubmitsTotal
+		total.PollCount += stats.PollCount
+		total.PollDurationSeconds += stats.PollDurationSeconds
+		for reason, n := range stats.SubmitFailures {
+			total.SubmitFailures[reason] += n
+		}
+	}
+	return total, true
+}
+
 // ListRunnerPlugins delegates to the first runner (plugins are identical across replicas).
 func (rr *RoundRobinExecutor) ListRunnerPlugins(ctx context.Context) ([]domain.RunnerPlugin, error) {
 	return rr.executors[0].ListRunnerPlugins(ctx)
 }
 
+// RunnerCapacity asks every runner for its capacity concurrently and sums
+// the answers. A runner that doesn't answer is listed with its error and left
+// out of the totals; only when none answer is an error returned.
+func (rr *RoundRobinExecutor) RunnerCapacity(ctx context.Context) (*domain.RunnerCapacity, error) {
+	instances := make([]domain.RunnerInstanceCapacity, len(rr.executors))
+	errs := make([]error, len(rr.executors))
+	var wg sync.WaitGroup
+	for i, exec := range rr.executors {
+		wg.Add(1)
+		go func(i int, exec *WarmPoolExecutor) {
+			defer wg.Done()
+			instances[i], errs[i] = exec.instanceCapacity(ctx)
+		}(i, exec)
+	}
+	wg.Wait()
+
+	total := &domain.RunnerCapacity{Runners: instances}
+	answered := 0
+	for i, err := range errs {
+		if err != nil {
+			total.Runners[i].Error = err.Error()
+			continue
+		}
+		answered++
+		total.MaxConcurrentRuns += instances[i].MaxConcurrentRuns
+		total.ActiveRuns += instances[i].ActiveRuns
+	}
+	if answered == 0 {
+		return nil, errors.Join(errs...)
+	}
+	return total, nil
+}
+
 // ParseRunnerAddrs splits a comma-separated runner address string into
 // individual addresses, trimming whitespace. Returns nil if the input is empty.
 func ParseRunnerAddrs(raw string) []string {
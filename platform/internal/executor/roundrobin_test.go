@@ -123,8 +123,9 @@ func TestRoundRobin_NonCapacityError_ReturnsImmediately(t *testing.T) {
 	assert.Error(t, err)
 	assert.False(t, runner1Called, "should not try next runner on non-capacity errors")
 
-	// Run should be marked as failed (non-capacity error)
-	assert.Equal(t, domain.RunStatusFailed, store.getStatus(run.ID.String()))
+	// An outage is transient: the run is left pending, not failed.
+	assert.ErrorIs(t, err, ErrRunnerUnavailable)
+	assert.NotEqual(t, domain.RunStatusFailed, store.getStatus(run.ID.String()))
 }
 
 func TestRoundRobin_FailoverWrapsAround(t *testing.T) {
@@ -294,3 +295,73 @@ func TestRoundRobin_StartStop(t *testing.T) {
 	rr.Start(ctx)
 	rr.Stop() // Should not hang
 }
+
+func TestRoundRobin_HealthCheck_ReadyWhileAnyRunnerAccepts(t *testing.T) {
+	store := newMockRunStore()
+	exec1 := newWarmPoolExecutorWithClient(&mockRunnerClient{}, store)
+	exec2 := newWarmPoolExecutorWithClient(&mockRunnerClient{}, store)
+	rr := newRoundRobinExecutorFromPool([]*WarmPoolExecutor{exec1, exec2})
+	ctx := context.Background()
+
+	assert.ErrorIs(t, rr.HealthCheck(ctx), errExecutorNotStarted)
+
+	exec2.Start(ctx)
+	defer exec2.Stop()
+	assert.NoError(t, rr.HealthCheck(ctx), "one accepting runner is enough")
+}
+
+func TestRoundRobin_ExecutorStats_SumsAcrossRunners(t *testing.T) {
+	store := newMockRunStore()
+	e1 := newWarmPoolExecutorWithClient(&mockRunnerClient{}, store)
+	e2 := newWarmPoolExecutorWithClient(&mockRunnerClient{}, store)
+	rr := newRoundRobinExecutorFromPool([]*WarmPoolExecutor{e1, e2})
+
+	require.NoError(t, rr.Submit(context.Background(), testRun(), testPipeline()))
+	require.NoError(t, rr.Submit(context.Background(), testRun(), testPipeline()))
+	e2.recordSubmitFailure(failureReasonRunnerBusy)
+
+	stats, ok := rr.ExecutorStats()
+	require.True(t, ok)
+	assert.Equal(t, 2, stats.ActiveRuns)
+	assert.Equal(t, uint64(2), stats.SubmitsTotal)
+	assert.Equal(t, uint64(1), stats.SubmitFailures[failureReasonRunnerBusy])
+}
+
+// --- Runner capacity tests ---
+
+func capacityClient(max, active int32) *mockRunnerClient {
+	return &mockRunnerClient{
+		capacityFunc: func(_ context.Context, _ *connect.Request[runnerv1.GetCapacityRequest]) (*connect.Response[runnerv1.GetCapacityResponse], error) {
+			return connect.NewResponse(&runnerv1.GetCapacityResponse{MaxConcurrentRuns: max, ActiveRuns: active}), nil
+		},
+	}
+}
+
+func TestRoundRobin_RunnerCapacity_UnreachableRunnerLeftOutOfTotals(t *testing.T) {
+	down := &mockRunnerClient{
+		capacityFunc: func(_ context.Context, _ *connect.Request[runnerv1.GetCapacityRequest]) (*connect.Response[runnerv1.GetCapacityResponse], error) {
+			return nil, connect.NewError(connect.CodeUnavailable, errors.New("connection refused"))
+		},
+	}
+	rr, _ := newTestRRExecutor(capacityClient(10, 3), down)
+
+	capacity, err := rr.RunnerCapacity(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 10, capacity.MaxConcurrentRuns)
+	assert.Equal(t, 3, capacity.ActiveRuns)
+	require.Len(t, capacity.Runners, 2)
+	assert.Empty(t, capacity.Runners[0].Error)
+	assert.Contains(t, capacity.Runners[1].Error, "connection refused")
+}
+
+func TestRoundRobin_RunnerCapacity_AllUnreachable_ReturnsError(t *testing.T) {
+	down := &mockRunnerClient{
+		capacityFunc: func(_ context.Context, _ *connect.Request[runnerv1.GetCapacityRequest]) (*connect.Response[runnerv1.GetCapacityResponse], error) {
+			return nil, connect.NewError(connect.CodeUnavailable, errors.New("connection refused"))
+		},
+	}
+	rr, _ := newTestRRExecutor(down, down)
+
+	_, err := rr.RunnerCapacity(context.Background())
+	assert.Error(t, err)
+}
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	connect "connectrpc.com/connect"
@@ -34,11 +35,10 @@ func propagateRequestID[T any](ctx context.Context, req *connect.Request[T]) {
 	}
 }
 
-// ErrRunnerBusy is returned when the runner rejects a submission because it has
-// reached its maximum concurrent run limit (gRPC RESOURCE_EXHAUSTED).
-// Callers (e.g. the scheduler) should treat this as transient and retry later
-// instead of marking the run as permanently failed.
-var ErrRunnerBusy = errors.New("runner at capacity")
+// ErrExecutorDraining is returned by Submit once Drain has been called.
+// The run is left pending (not failed) so another ratd replica — or this
+// one after restart — can pick it up. It wraps ErrRunnerUnavailable.
+var ErrExecutorDraining = fmt.Errorf("executor is draining: %w", ErrRunnerUnavailable)
 
 // FallbackPollInterval is the reduced polling frequency used as a safety net
 // when push-based status callbacks are enabled. The runner pushes status changes
@@ -46,6 +46,11 @@ var ErrRunnerBusy = errors.New("runner at capacity")
 // network partitions, runner crashes).
 const FallbackPollInterval = 60 * time.Second
 
+// DrainPollInterval is how often Drain polls the runner while waiting for
+// in-flight runs to finish. Drain runs after the HTTP listeners have shut
+// down, so status callbacks can no longer arrive — it has to poll.
+const DrainPollInterval = 2 * time.Second
+
 // orphanNotFoundThreshold is the number of consecutive NotFound responses
 // from the runner that mark a run as orphaned. The runner keeps run state
 // in memory only, so a process restart (crash, plugin auto-install re-exec,
@@ -61,18 +66,37 @@ const orphanNotFoundThreshold = 3
 // at 60s serves as a fallback safety net for missed callbacks.
 type WarmPoolExecutor struct {
 	runner        runnerv1connect.RunnerServiceClient
+	addr          string // runner address, reported by RunnerCapacity
 	runs          api.RunStore
-	LandingZones  api.LandingZoneStore // optional — set to clean up files after archive
+	LandingZones  api.LandingZoneStore                                                // optional — set to clean up files after archive
 	OnRunComplete func(ctx context.Context, run *domain.Run, status domain.RunStatus) // optional callback
 	mu            sync.Mutex
 	active        map[string]*domain.Run // ratd run_id → Run
 	runnerIDs     map[string]string      // ratd run_id → runner run_id
 	notFoundCount map[string]int         // ratd run_id → consecutive NotFound polls
 	pollInterval  time.Duration
+	drainInterval time.Duration
+	pollMu        sync.Mutex // serializes poll() between the background loop and Drain
+	draining      atomic.Bool
 	cancel        context.CancelFunc
 	done          chan struct{}
+
+	// Metrics counters, surfaced on /metrics via ExecutorStats.
+	// submitFailures, pollCount and pollSeconds are guarded by mu.
+	submitsTotal   atomic.Uint64
+	submitFailures map[string]uint64 // reason → count
+	pollCount      uint64
+	pollSeconds    float64
 }
 
+// Submit failure reasons reported in rat_executor_submit_failures_total.
+const (
+	failureReasonRunnerBusy        = "runner_busy"        // runner returned RESOURCE_EXHAUSTED
+	failureReasonRunnerUnavailable = "runner_unavailable" // transient SubmitPipeline RPC error (see ErrRunnerUnavailable)
+	failureReasonPipelineInvalid   = "pipeline_invalid"   // runner rejected the pipeline (see ErrPipelineInvalid)
+	failureReasonStatusUpdate      = "status_update"      // runner accepted, but marking the run running failed
+)
+
 // NewWarmPoolExecutor creates an executor that talks to the runner at the given address.
 // Uses h2c (HTTP/2 cleartext) by default. Pass a TLS-enabled http.Client for encrypted transport.
 func NewWarmPoolExecutor(runnerAddr string, runs api.RunStore, httpClient ...*http.Client) *WarmPoolExecutor {
@@ -89,12 +113,15 @@ func NewWarmPoolExecutor(runnerAddr string, runs api.RunStore, httpClient ...*ht
 		connect.WithGRPC(),
 	)
 	return &WarmPoolExecutor{
-		runner:       client,
-		runs:         runs,
-		active:        make(map[string]*domain.Run),
-		runnerIDs:     make(map[string]string),
-		notFoundCount: make(map[string]int),
-		pollInterval:  FallbackPollInterval,
+		runner:         client,
+		addr:           runnerAddr,
+		runs:           runs,
+		active:         make(map[string]*domain.Run),
+		runnerIDs:      make(map[string]string),
+		notFoundCount:  make(map[string]int),
+		pollInterval:   FallbackPollInterval,
+		drainInterval:  DrainPollInterval,
+		submitFailures: make(map[string]uint64),
 	}
 }
 
@@ -113,18 +140,21 @@ func defaultH2CClient() *http.Client {
 // newWarmPoolExecutorWithClient creates an executor with an injected runner client (for testing).
 func newWarmPoolExecutorWithClient(client runnerv1connect.RunnerServiceClient, runs api.RunStore) *WarmPoolExecutor {
 	return &WarmPoolExecutor{
-		runner:       client,
-		runs:         runs,
-		active:        make(map[string]*domain.Run),
-		runnerIDs:     make(map[string]string),
-		notFoundCount: make(map[string]int),
-		pollInterval:  FallbackPollInterval,
+		runner:         client,
+		runs:           runs,
+		active:         make(map[string]*domain.Run),
+		runnerIDs:      make(map[string]string),
+		notFoundCount:  make(map[string]int),
+		pollInterval:   FallbackPollInterval,
+		drainInterval:  DrainPollInterval,
+		submitFailures: make(map[string]uint64),
 	}
 }
 
 // Submit sends a pipeline run to the runner service.
 // On success, updates the run status to "running" and tracks it in the active map.
-// On failure, updates the run status to "failed".
+// RPC failures are classified (see classifySubmitError); only ErrPipelineInvalid
+// marks the run failed — busy and unavailable leave it pending.
 //
 // When the cloud plugin has vended per-run credentials (see api/runs.go
 // HandleCreateRun), they are attached as S3Credentials on the proto request.
@@ -132,6 +162,10 @@ func newWarmPoolExecutorWithClient(client runnerv1connect.RunnerServiceClient, r
 // When the map is empty (no cloud plugin, or non-cloud-aware pipeline), the
 // field is left nil and the runner falls back to its env-level config.
 func (e *WarmPoolExecutor) Submit(ctx context.Context, run *domain.Run, pipeline *domain.Pipeline) error {
+	if e.draining.Load() {
+		return fmt.Errorf("submit pipeline: %w", ErrExecutorDraining)
+	}
+
 	req := connect.NewRequest(&runnerv1.SubmitPipelineRequest{
 		Namespace:         pipeline.Namespace,
 		Layer:             domainLayerToProto(pipeline.Layer),
@@ -140,27 +174,35 @@ func (e *WarmPoolExecutor) Submit(ctx context.Context, run *domain.Run, pipeline
 		PublishedVersions: pipeline.PublishedVersions,
 		RunId:             run.ID.String(),
 		S3Credentials:     s3OverridesToProto(run.S3Overrides),
+		Env:               runEnv(run, pipeline),
+		Parameters:        api.RunParameters(run),
 	})
 	propagateRequestID(ctx, req)
 
+	e.submitsTotal.Add(1)
 	resp, err := e.runner.SubmitPipeline(ctx, req)
 	if err != nil {
-		// RESOURCE_EXHAUSTED means the runner is at capacity — don't mark
-		// the run as failed. Return ErrRunnerBusy so the scheduler can leave
-		// the run in pending state and retry on the next tick.
-		if connectErr := new(connect.Error); errors.As(err, &connectErr) && connectErr.Code() == connect.CodeResourceExhausted {
-			slog.Warn("runner at capacity, will retry", "run_id", run.ID, "detail", connectErr.Message())
-			return fmt.Errorf("submit pipeline: %w", ErrRunnerBusy)
+		err = classifySubmitError(err)
+		switch {
+		case errors.Is(err, ErrRunnerBusy):
+			// At capacity — leave the run pending so the caller can retry.
+			e.recordSubmitFailure(failureReasonRunnerBusy)
+			slog.Warn("runner at capacity, will retry", "run_id", run.ID, "error", err)
+		case errors.Is(err, ErrPipelineInvalid):
+			// Permanent — no retry can succeed, so fail the run now.
+			e.recordSubmitFailure(failureReasonPipelineInvalid)
+			api.FailRunAfterSubmit(ctx, e.runs, run, err)
+		default:
+			// Transient outage — leave the run pending; the caller decides
+			// whether to retry or fail it.
+			e.recordSubmitFailure(failureReasonRunnerUnavailable)
 		}
-
-		// Runner unavailable for other reasons — mark run as failed
-		errMsg := fmt.Sprintf("runner unavailable: %v", err)
-		_ = e.runs.UpdateRunStatus(ctx, run.ID.String(), domain.RunStatusFailed, &errMsg, nil, nil)
 		return fmt.Errorf("submit pipeline: %w", err)
 	}
 
 	// Mark as running and track — map ratd run_id to runner run_id for polling
 	if err := e.runs.UpdateRunStatus(ctx, run.ID.String(), domain.RunStatusRunning, nil, nil, nil); err != nil {
+		e.recordSubmitFailure(failureReasonStatusUpdate)
 		return fmt.Errorf("update run status: %w", err)
 	}
 	run.Status = domain.RunStatusRunning
@@ -232,8 +274,85 @@ func (e *WarmPoolExecutor) Stop() {
 	}
 }
 
+// HealthCheck reports whether the executor is accepting work: started, not
+// draining, and with its poll loop still running. It doesn't call the runner —
+// RunnerHealth covers reachability.
+func (e *WarmPoolExecutor) HealthCheck(_ context.Context) error {
+	if e.draining.Load() {
+		return ErrExecutorDraining
+	}
+	return pollLoopHealth(e.done)
+}
+
+// Drain stops accepting new submits, waits for every active run to reach a
+// terminal state, then stops the poll loop. Returns an error wrapping
+// ctx.Err() if runs are still active when ctx is done — the poll loop is
+// stopped either way. Call before Stop during shutdown so in-flight runs get
+// their final status, logs and OnRunComplete instead of being left to the
+// reaper.
+func (e *WarmPoolExecutor) Drain(ctx context.Context) error {
+	e.draining.Store(true)
+	defer e.Stop()
+
+	ticker := time.NewTicker(e.drainInterval)
+	defer ticker.Stop()
+
+	for {
+		e.mu.Lock()
+		remaining := len(e.active)
+		e.mu.Unlock()
+		if remaining == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("drain: %d runs still active: %w", remaining, ctx.Err())
+		case <-ticker.C:
+			e.poll(ctx)
+		}
+	}
+}
+
+// recordSubmitFailure bumps the submit failure counter for the given reason.
+func (e *WarmPoolExecutor) recordSubmitFailure(reason string) {
+	e.mu.Lock()
+	e.submitFailures[reason]++
+	e.mu.Unlock()
+}
+
+// ExecutorStats returns a snapshot of the executor's metrics counters.
+// Implements api.ExecutorStatsReporter; always reports (ok = true).
+func (e *WarmPoolExecutor) ExecutorStats() (api.ExecutorStats, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	failures := make(map[string]uint64, len(e.submitFailures))
+	for reason, n := range e.submitFailures {
+		failures[reason] = n
+	}
+	return api.ExecutorStats{
+		ActiveRuns:          len(e.active),
+		SubmitsTotal:        e.submitsTotal.Load(),
+		SubmitFailures:      failures,
+		PollCount:           e.pollCount,
+		PollDurationSeconds: e.pollSeconds,
+	}, true
+}
+
 // poll checks the status of all active runs and updates the DB for terminal states.
 func (e *WarmPoolExecutor) poll(ctx context.Context) {
+	e.pollMu.Lock()
+	defer e.pollMu.Unlock()
+
+	start := time.Now()
+	defer func() {
+		elapsed := time.Since(start).Seconds()
+		e.mu.Lock()
+		e.pollCount++
+		e.pollSeconds += elapsed
+		e.mu.Unlock()
+	}()
+
 	e.mu.Lock()
 	ids := make([]string, 0, len(e.active))
 	for id := range e.active {
@@ -462,16 +581,7 @@ func (e *WarmPoolExecutor) GetLogs(ctx context.Context, runID string) ([]api.Log
 
 	var logs []api.LogEntry
 	for stream.Receive() {
-		entry := stream.Msg()
-		ts := ""
-		if entry.Timestamp != nil {
-			ts = time.Unix(entry.Timestamp.Seconds, int64(entry.Timestamp.Nanos)).UTC().Format(time.RFC3339)
-		}
-		logs = append(logs, api.LogEntry{
-			Timestamp: ts,
-			Level:     entry.Level,
-			Message:   entry.Message,
-		})
+		logs = append(logs, logEntryFromProto(stream.Msg()))
 	}
 	if err := stream.Err(); err != nil {
 		return logs, fmt.Errorf("stream logs: %w", err)
@@ -479,6 +589,54 @@ func (e *WarmPoolExecutor) GetLogs(ctx context.Context, runID string) ([]api.Log
 	return logs, nil
 }
 
+// StreamLogs relays a run's logs live via the runner's StreamLogs RPC with
+// Follow enabled. Each entry is forwarded to send as soon as it arrives; the
+// call returns when the runner closes the stream (the run finished), ctx is
+// cancelled, or send fails (typically a client disconnect).
+func (e *WarmPoolExecutor) StreamLogs(ctx context.Context, runID string, send func(api.LogEntry) error) error {
+	e.mu.Lock()
+	runnerID, ok := e.runnerIDs[runID]
+	e.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("run %s not tracked (may have completed)", runID)
+	}
+
+	req := connect.NewRequest(&commonv1.StreamLogsRequest{
+		RunId:  runnerID,
+		Follow: true,
+	})
+	propagateRequestID(ctx, req)
+
+	stream, err := e.runner.StreamLogs(ctx, req)
+	if err != nil {
+		return fmt.Errorf("stream logs: %w", err)
+	}
+	defer stream.Close()
+
+	for stream.Receive() {
+		if err := send(logEntryFromProto(stream.Msg())); err != nil {
+			return err
+		}
+	}
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("stream logs: %w", err)
+	}
+	return nil
+}
+
+// logEntryFromProto converts a runner LogEntry to the API representation.
+func logEntryFromProto(entry *commonv1.LogEntry) api.LogEntry {
+	ts := ""
+	if entry.Timestamp != nil {
+		ts = time.Unix(entry.Timestamp.Seconds, int64(entry.Timestamp.Nanos)).UTC().Format(time.RFC3339)
+	}
+	return api.LogEntry{
+		Timestamp: ts,
+		Level:     entry.Level,
+		Message:   entry.Message,
+	}
+}
+
 // Preview calls the runner's PreviewPipeline RPC and converts the response.
 func (e *WarmPoolExecutor) Preview(ctx context.Context, pipeline *domain.Pipeline, limit int, sampleFiles []string, code string) (*api.PreviewResult, error) {
 	req := connect.NewRequest(&runnerv1.PreviewPipelineRequest{
@@ -489,6 +647,7 @@ func (e *WarmPoolExecutor) Preview(ctx context.Context, pipeline *domain.Pipelin
 		SampleFiles:  sampleFiles,
 		Code:         code,
 		PipelineType: pipeline.Type,
+		Env:          pipelineEnv(pipeline),
 	})
 	propagateRequestID(ctx, req)
 
@@ -566,6 +725,7 @@ func (e *WarmPoolExecutor) ValidatePipeline(ctx context.Context, pipeline *domai
 		Namespace:    pipeline.Namespace,
 		Layer:        domainLayerToProto(pipeline.Layer),
 		PipelineName: pipeline.Name,
+		S3Prefix:     pipeline.StoragePrefix(),
 	})
 	propagateRequestID(ctx, req)
 
@@ -591,6 +751,33 @@ func (e *WarmPoolExecutor) ValidatePipeline(ctx context.Context, pipeline *domai
 	}, nil
 }
 
+// InferSampleSchema calls the runner's InferSampleSchema RPC, which reads the
+// sample files with DuckDB and returns their combined columns. A runner that
+// predates the RPC answers Unimplemented, reported as
+// api.ErrSchemaInferenceUnsupported so the caller parses the samples itself.
+func (e *WarmPoolExecutor) InferSampleSchema(ctx context.Context, paths []string) ([]api.QueryColumn, error) {
+	req := connect.NewRequest(&runnerv1.InferSampleSchemaRequest{Paths: paths})
+	propagateRequestID(ctx, req)
+
+	resp, err := e.runner.InferSampleSchema(ctx, req)
+	var connectErr *connect.Error
+	switch {
+	case err == nil:
+	case connect.CodeOf(err) == connect.CodeUnimplemented:
+		return nil, api.ErrSchemaInferenceUnsupported
+	case errors.As(err, &connectErr) && connectErr.Code() == connect.CodeInvalidArgument:
+		return nil, fmt.Errorf("%w: %s", api.ErrInvalidSamples, connectErr.Message())
+	default:
+		return nil, fmt.Errorf("infer sample schema: %w", err)
+	}
+
+	cols := make([]api.QueryColumn, 0, len(resp.Msg.Columns))
+	for _, c := range resp.Msg.Columns {
+		cols = append(cols, api.QueryColumn{Name: c.Name, Type: c.Type})
+	}
+	return cols, nil
+}
+
 // ListRunnerPlugins calls the runner's ListPlugins RPC and converts the response.
 func (e *WarmPoolExecutor) ListRunnerPlugins(ctx context.Context) ([]domain.RunnerPlugin, error) {
 	req := connect.NewRequest(&runnerv1.ListPluginsRequest{})
@@ -613,6 +800,36 @@ func (e *WarmPoolExecutor) ListRunnerPlugins(ctx context.Context) ([]domain.Runn
 	return plugins, nil
 }
 
+// RunnerCapacity calls the runner's GetCapacity RPC and reports its
+// concurrent run limit and current load.
+func (e *WarmPoolExecutor) RunnerCapacity(ctx context.Context) (*domain.RunnerCapacity, error) {
+	instance, err := e.instanceCapacity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RunnerCapacity{
+		MaxConcurrentRuns: instance.MaxConcurrentRuns,
+		ActiveRuns:        instance.ActiveRuns,
+		Runners:           []domain.RunnerInstanceCapacity{instance},
+	}, nil
+}
+
+// instanceCapacity calls GetCapacity on this executor's runner.
+func (e *WarmPoolExecutor) instanceCapacity(ctx context.Context) (domain.RunnerInstanceCapacity, error) {
+	req := connect.NewRequest(&runnerv1.GetCapacityRequest{})
+	propagateRequestID(ctx, req)
+
+	resp, err := e.runner.GetCapacity(ctx, req)
+	if err != nil {
+		return domain.RunnerInstanceCapacity{Addr: e.addr}, fmt.Errorf("get runner capacity: %w", err)
+	}
+	return domain.RunnerInstanceCapacity{
+		Addr:              e.addr,
+		MaxConcurrentRuns: int(resp.Msg.MaxConcurrentRuns),
+		ActiveRuns:        int(resp.Msg.ActiveRuns),
+	}, nil
+}
+
 // domainLayerToProto converts domain.Layer to proto Layer enum.
 func domainLayerToProto(l domain.Layer) commonv1.Layer {
 	switch l {
@@ -649,7 +866,8 @@ func protoStatusToDomain(s commonv1.RunStatus) domain.RunStatus {
 //
 // This method performs the same actions as the poll loop: update Postgres,
 // persist logs, clean up landing zones, fire OnRunComplete, and remove
-// the run from the active map.
+// the run from the active map. It also persists the per-phase timings the
+// runner reports, which the poll path doesn't receive.
 func (e *WarmPoolExecutor) HandleStatusCallback(ctx context.Context, update api.RunStatusUpdate) error {
 	id := update.RunID
 
@@ -696,6 +914,13 @@ func (e *WarmPoolExecutor) HandleStatusCallback(ctx context.Context, update api.
 		return fmt.Errorf("callback: update run status: %w", err)
 	}
 
+	// Per-phase timings are diagnostic — losing them must not fail the callback.
+	if len(update.Phases) > 0 {
+		if err := e.runs.SaveRunPhases(ctx, id, update.Phases); err != nil {
+			log.Error("callback: failed to save run phases", "error", err)
+		}
+	}
+
 	// Notify listeners (e.g., pipeline_success triggers).
 	// Use a fresh context with timeout — the caller's HTTP request context will
 	// be cancelled after the response is sent, but the callback may need more time.
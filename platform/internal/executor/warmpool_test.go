@@ -3,6 +3,9 @@ package executor
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -25,6 +28,8 @@ type mockRunnerClient struct {
 	cancelFunc    func(ctx context.Context, req *connect.Request[commonv1.CancelRunRequest]) (*connect.Response[commonv1.CancelRunResponse], error)
 	previewFunc   func(req *connect.Request[runnerv1.PreviewPipelineRequest]) (*connect.Response[runnerv1.PreviewPipelineResponse], error)
 	validateFunc  func(ctx context.Context, req *connect.Request[runnerv1.ValidatePipelineRequest]) (*connect.Response[runnerv1.ValidatePipelineResponse], error)
+	capacityFunc  func(ctx context.Context, req *connect.Request[runnerv1.GetCapacityRequest]) (*connect.Response[runnerv1.GetCapacityResponse], error)
+	inferFunc     func(ctx context.Context, req *connect.Request[runnerv1.InferSampleSchemaRequest]) (*connect.Response[runnerv1.InferSampleSchemaResponse], error)
 }
 
 func (m *mockRunnerClient) SubmitPipeline(ctx context.Context, req *connect.Request[runnerv1.SubmitPipelineRequest]) (*connect.Response[runnerv1.SubmitPipelineResponse], error) {
@@ -70,18 +75,34 @@ func (m *mockRunnerClient) ListPlugins(_ context.Context, _ *connect.Request[run
 	return connect.NewResponse(&runnerv1.ListPluginsResponse{}), nil
 }
 
+func (m *mockRunnerClient) GetCapacity(ctx context.Context, req *connect.Request[runnerv1.GetCapacityRequest]) (*connect.Response[runnerv1.GetCapacityResponse], error) {
+	if m.capacityFunc != nil {
+		return m.capacityFunc(ctx, req)
+	}
+	return connect.NewResponse(&runnerv1.GetCapacityResponse{MaxConcurrentRuns: 10}), nil
+}
+
+func (m *mockRunnerClient) InferSampleSchema(ctx context.Context, req *connect.Request[runnerv1.InferSampleSchemaRequest]) (*connect.Response[runnerv1.InferSampleSchemaResponse], error) {
+	if m.inferFunc != nil {
+		return m.inferFunc(ctx, req)
+	}
+	return connect.NewResponse(&runnerv1.InferSampleSchemaResponse{}), nil
+}
+
 // --- Mock run store ---
 
 type mockRunStore struct {
-	mu   sync.Mutex
-	runs map[string]domain.RunStatus
-	errs map[string]*string
+	mu     sync.Mutex
+	runs   map[string]domain.RunStatus
+	errs   map[string]*string
+	phases map[string][]api.PhaseProfile
 }
 
 func newMockRunStore() *mockRunStore {
 	return &mockRunStore{
-		runs: make(map[string]domain.RunStatus),
-		errs: make(map[string]*string),
+		runs:   make(map[string]domain.RunStatus),
+		errs:   make(map[string]*string),
+		phases: make(map[string][]api.PhaseProfile),
 	}
 }
 
@@ -101,7 +122,7 @@ func (m *mockRunStore) GetRun(_ context.Context, runID string) (*domain.Run, err
 		return nil, nil
 	}
 	id, _ := uuid.Parse(runID)
-	return &domain.Run{ID: id, Status: status}, nil
+	return &domain.Run{ID: id, Status: status, Phases: m.phases[runID]}, nil
 }
 
 func (m *mockRunStore) CreateRun(_ context.Context, run *domain.Run) error {
@@ -124,6 +145,13 @@ func (m *mockRunStore) SaveRunLogs(_ context.Context, _ string, _ []api.LogEntry
 	return nil
 }
 
+func (m *mockRunStore) SaveRunPhases(_ context.Context, runID string, phases []api.PhaseProfile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.phases[runID] = phases
+	return nil
+}
+
 func (m *mockRunStore) GetRunLogs(_ context.Context, _ string) ([]api.LogEntry, error) {
 	return nil, nil
 }
@@ -132,7 +160,15 @@ func (m *mockRunStore) DeleteRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ i
 	return 0, nil
 }
 
-func (m *mockRunStore) DeleteRunsOlderThan(_ context.Context, _ time.Time) (int, error) {
+func (m *mockRunStore) DeleteRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRunStore) CountRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ int) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRunStore) CountRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
 	return 0, nil
 }
 
@@ -148,6 +184,22 @@ func (m *mockRunStore) LatestRunPerPipeline(_ context.Context, _ []uuid.UUID) (m
 	return nil, nil
 }
 
+func (m *mockRunStore) RunStatuses(_ context.Context, _ []uuid.UUID) (map[uuid.UUID]domain.RunStatus, error) {
+	return nil, nil
+}
+
+func (m *mockRunStore) NamespaceRunStats(_ context.Context, _ string, _ time.Time) (*api.NamespaceRunStats, error) {
+	return &api.NamespaceRunStats{ByStatus: map[string]int{}}, nil
+}
+
+func (m *mockRunStore) PipelineStats(_ context.Context, _ uuid.UUID, _ time.Time) (*api.PipelineRunStats, error) {
+	return &api.PipelineRunStats{}, nil
+}
+
+func (m *mockRunStore) RecordSubmitFailure(_ context.Context, _ string, _ string, _ int) (bool, error) {
+	return false, nil
+}
+
 func (m *mockRunStore) getStatus(runID string) domain.RunStatus {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -200,7 +252,7 @@ func TestSubmit_RunnerAvailable_UpdatesToRunning(t *testing.T) {
 	assert.True(t, tracked)
 }
 
-func TestSubmit_RunnerUnavailable_UpdatesToFailed(t *testing.T) {
+func TestSubmit_RunnerUnavailable_LeavesRunPending(t *testing.T) {
 	mock := &mockRunnerClient{
 		submitFunc: func(_ context.Context, _ *connect.Request[runnerv1.SubmitPipelineRequest]) (*connect.Response[runnerv1.SubmitPipelineResponse], error) {
 			return nil, connect.NewError(connect.CodeUnavailable, errors.New("connection refused"))
@@ -213,10 +265,30 @@ func TestSubmit_RunnerUnavailable_UpdatesToFailed(t *testing.T) {
 	pipeline := testPipeline()
 
 	err := exec.Submit(context.Background(), run, pipeline)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrRunnerUnavailable)
+
+	assert.NotEqual(t, domain.RunStatusFailed, store.getStatus(run.ID.String()))
+	assert.Nil(t, store.getError(run.ID.String()))
+}
+
+func TestSubmit_PipelineInvalid_UpdatesToFailed(t *testing.T) {
+	mock := &mockRunnerClient{
+		submitFunc: func(_ context.Context, _ *connect.Request[runnerv1.SubmitPipelineRequest]) (*connect.Response[runnerv1.SubmitPipelineResponse], error) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("pipeline.sql not found"))
+		},
+	}
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(mock, store)
+
+	run := testRun()
+	pipeline := testPipeline()
+
+	err := exec.Submit(context.Background(), run, pipeline)
+	assert.ErrorIs(t, err, ErrPipelineInvalid)
 
 	assert.Equal(t, domain.RunStatusFailed, store.getStatus(run.ID.String()))
-	assert.NotNil(t, store.getError(run.ID.String()))
+	require.NotNil(t, store.getError(run.ID.String()))
+	assert.Contains(t, *store.getError(run.ID.String()), "pipeline.sql not found")
 }
 
 func TestSubmit_BuildsCorrectRequest(t *testing.T) {
@@ -283,6 +355,30 @@ func TestSubmit_ForwardsS3OverridesToRunner(t *testing.T) {
 	assert.Equal(t, "eu-west-3", captured.S3Credentials.Region)
 }
 
+func TestSubmit_ForwardsRunParametersToRunner(t *testing.T) {
+	var captured *runnerv1.SubmitPipelineRequest
+	mock := &mockRunnerClient{
+		submitFunc: func(_ context.Context, req *connect.Request[runnerv1.SubmitPipelineRequest]) (*connect.Response[runnerv1.SubmitPipelineResponse], error) {
+			captured = req.Msg
+			return connect.NewResponse(&runnerv1.SubmitPipelineResponse{}), nil
+		},
+	}
+	exec := newWarmPoolExecutorWithClient(mock, newMockRunStore())
+
+	run := testRun()
+	run.Metadata = map[string]string{
+		"ticket":                                   "OPS-12",
+		api.RunParameterMetadataPrefix + "limit":   "500",
+		api.RunParameterMetadataPrefix + "dry_run": "true",
+	}
+
+	err := exec.Submit(context.Background(), run, testPipeline())
+	require.NoError(t, err)
+	require.NotNil(t, captured)
+	assert.Equal(t, map[string]string{"limit": "500", "dry_run": "true"}, captured.Parameters)
+	assert.NotContains(t, captured.Env, "ticket", "plain metadata is not forwarded")
+}
+
 func TestPoll_RunCompletes_UpdatesDB(t *testing.T) {
 	runID := uuid.New().String()
 
@@ -366,6 +462,14 @@ func TestCancel_RunnerUnavailable_ReturnsError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestStreamLogs_UntrackedRun_ReturnsError(t *testing.T) {
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(&mockRunnerClient{}, store)
+
+	err := exec.StreamLogs(context.Background(), uuid.New().String(), func(api.LogEntry) error { return nil })
+	assert.Error(t, err)
+}
+
 func TestPreview_ForwardsInlineCode(t *testing.T) {
 	var captured *runnerv1.PreviewPipelineRequest
 	mock := &mockRunnerClient{
@@ -405,6 +509,44 @@ func TestPreview_EmptyCodeNotForwarded(t *testing.T) {
 	assert.Empty(t, captured.Code)
 }
 
+func TestPreview_CustomS3Prefix_ForwardedAsEnv(t *testing.T) {
+	var captured *runnerv1.PreviewPipelineRequest
+	mock := &mockRunnerClient{
+		previewFunc: func(req *connect.Request[runnerv1.PreviewPipelineRequest]) (*connect.Response[runnerv1.PreviewPipelineResponse], error) {
+			captured = req.Msg
+			return connect.NewResponse(&runnerv1.PreviewPipelineResponse{}), nil
+		},
+	}
+	exec := newWarmPoolExecutorWithClient(mock, newMockRunStore())
+
+	pipeline := testPipeline()
+	pipeline.S3Path = "default/shared/orders/"
+	_, err := exec.Preview(context.Background(), pipeline, 100, nil, "")
+	require.NoError(t, err)
+
+	require.NotNil(t, captured)
+	assert.Equal(t, map[string]string{"RAT_PIPELINE_S3_PREFIX": "default/shared/orders/"}, captured.Env)
+}
+
+func TestValidate_CustomS3Prefix_SentToRunner(t *testing.T) {
+	var captured *runnerv1.ValidatePipelineRequest
+	mock := &mockRunnerClient{
+		validateFunc: func(_ context.Context, req *connect.Request[runnerv1.ValidatePipelineRequest]) (*connect.Response[runnerv1.ValidatePipelineResponse], error) {
+			captured = req.Msg
+			return connect.NewResponse(&runnerv1.ValidatePipelineResponse{Valid: true}), nil
+		},
+	}
+	exec := newWarmPoolExecutorWithClient(mock, newMockRunStore())
+
+	pipeline := testPipeline()
+	pipeline.S3Path = "default/shared/orders/"
+	_, err := exec.ValidatePipeline(context.Background(), pipeline)
+	require.NoError(t, err)
+
+	require.NotNil(t, captured)
+	assert.Equal(t, "default/shared/orders/", captured.S3Prefix)
+}
+
 func TestSubmit_ResourceExhausted_ReturnsErrRunnerBusy(t *testing.T) {
 	mock := &mockRunnerClient{
 		submitFunc: func(_ context.Context, _ *connect.Request[runnerv1.SubmitPipelineRequest]) (*connect.Response[runnerv1.SubmitPipelineResponse], error) {
@@ -465,6 +607,124 @@ func TestStartStop_BackgroundPollRuns(t *testing.T) {
 	// Should not hang — goroutine exited
 }
 
+// --- Drain ---
+
+func TestDrain_BlocksUntilActiveRunsComplete(t *testing.T) {
+	var mu sync.Mutex
+	finished := map[string]bool{}
+	mock := &mockRunnerClient{
+		getStatusFunc: func(_ context.Context, req *connect.Request[commonv1.GetRunStatusRequest]) (*connect.Response[commonv1.GetRunStatusResponse], error) {
+			mu.Lock()
+			defer mu.Unlock()
+			status := commonv1.RunStatus_RUN_STATUS_RUNNING
+			if finished[req.Msg.RunId] {
+				status = commonv1.RunStatus_RUN_STATUS_SUCCESS
+			}
+			return connect.NewResponse(&commonv1.GetRunStatusResponse{Status: status}), nil
+		},
+	}
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(mock, store)
+	exec.drainInterval = 10 * time.Millisecond
+
+	runIDs := []string{uuid.New().String(), uuid.New().String()}
+	for _, id := range runIDs {
+		store.runs[id] = domain.RunStatusRunning
+		exec.active[id] = &domain.Run{Status: domain.RunStatusRunning}
+		exec.runnerIDs[id] = id
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- exec.Drain(context.Background()) }()
+
+	finish := func(id string) {
+		mu.Lock()
+		finished[id] = true
+		mu.Unlock()
+	}
+
+	select {
+	case <-done:
+		t.Fatal("Drain returned while two runs were still active")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	finish(runIDs[0])
+	select {
+	case <-done:
+		t.Fatal("Drain returned while one run was still active")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	finish(runIDs[1])
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Drain did not return after both runs completed")
+	}
+
+	assert.Equal(t, domain.RunStatusSuccess, store.getStatus(runIDs[0]))
+	assert.Equal(t, domain.RunStatusSuccess, store.getStatus(runIDs[1]))
+}
+
+func TestDrain_DeadlineWithActiveRuns_ReturnsError(t *testing.T) {
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(&mockRunnerClient{}, store)
+	exec.drainInterval = 10 * time.Millisecond
+
+	for i := 0; i < 2; i++ {
+		id := uuid.New().String()
+		store.runs[id] = domain.RunStatusRunning
+		exec.active[id] = &domain.Run{Status: domain.RunStatusRunning}
+		exec.runnerIDs[id] = id
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := exec.Drain(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "2 runs still active")
+}
+
+func TestDrain_RejectsNewSubmits(t *testing.T) {
+	mock := &mockRunnerClient{}
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(mock, store)
+
+	require.NoError(t, exec.Drain(context.Background()))
+
+	run := testRun()
+	store.runs[run.ID.String()] = domain.RunStatusPending
+	err := exec.Submit(context.Background(), run, testPipeline())
+	assert.ErrorIs(t, err, ErrExecutorDraining)
+	// Run left pending for another replica — not marked failed.
+	assert.Equal(t, domain.RunStatusPending, store.getStatus(run.ID.String()))
+}
+
+func TestHealthCheck_ReflectsLifecycle(t *testing.T) {
+	exec := newWarmPoolExecutorWithClient(&mockRunnerClient{}, newMockRunStore())
+	ctx := context.Background()
+
+	assert.ErrorIs(t, exec.HealthCheck(ctx), errExecutorNotStarted)
+
+	exec.Start(ctx)
+	assert.NoError(t, exec.HealthCheck(ctx))
+
+	exec.Stop()
+	assert.ErrorIs(t, exec.HealthCheck(ctx), errExecutorStopped)
+}
+
+func TestHealthCheck_DrainingNotReady(t *testing.T) {
+	exec := newWarmPoolExecutorWithClient(&mockRunnerClient{}, newMockRunStore())
+	exec.Start(context.Background())
+
+	require.NoError(t, exec.Drain(context.Background()))
+	assert.ErrorIs(t, exec.HealthCheck(context.Background()), ErrExecutorDraining)
+}
+
 // --- Status Callback Tests ---
 
 func TestCallback_SuccessUpdatesDB(t *testing.T) {
@@ -496,6 +756,57 @@ func TestCallback_SuccessUpdatesDB(t *testing.T) {
 	assert.False(t, tracked, "run should be removed from active map after callback")
 }
 
+func TestCallback_PhasesRoundTripThroughStatusEndpoint(t *testing.T) {
+	mock := &mockRunnerClient{}
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(mock, store)
+
+	runID := uuid.New().String()
+	store.runs[runID] = domain.RunStatusRunning
+	exec.active[runID] = &domain.Run{Status: domain.RunStatusRunning}
+	exec.runnerIDs[runID] = runID
+
+	router := api.NewInternalRouter(&api.Server{Executor: exec, Runs: store})
+	body := `{"status":"success","duration_ms":4500,"rows_written":10,"phases":[` +
+		`{"name":"detect","duration_ms":12},` +
+		`{"name":"execute","duration_ms":4300,"metadata":{"pipeline_type":"sql"}},` +
+		`{"name":"merge","duration_ms":188}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/internal/runs/"+runID+"/status", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	run, err := store.GetRun(context.Background(), runID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RunStatusSuccess, run.Status)
+	assert.Equal(t, []domain.PhaseProfile{
+		{Name: "detect", DurationMs: 12},
+		{Name: "execute", DurationMs: 4300, Metadata: map[string]string{"pipeline_type": "sql"}},
+		{Name: "merge", DurationMs: 188},
+	}, run.Phases)
+}
+
+func TestCallback_WithoutPhasesLeavesPhasesUnset(t *testing.T) {
+	mock := &mockRunnerClient{}
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(mock, store)
+
+	runID := uuid.New().String()
+	store.runs[runID] = domain.RunStatusRunning
+	exec.active[runID] = &domain.Run{Status: domain.RunStatusRunning}
+	exec.runnerIDs[runID] = runID
+
+	require.NoError(t, exec.HandleStatusCallback(context.Background(), api.RunStatusUpdate{
+		RunID:  runID,
+		Status: "success",
+	}))
+
+	run, err := store.GetRun(context.Background(), runID)
+	require.NoError(t, err)
+	assert.Empty(t, run.Phases)
+}
+
 func TestCallback_FailedUpdatesDBWithError(t *testing.T) {
 	mock := &mockRunnerClient{}
 	store := newMockRunStore()
@@ -664,6 +975,89 @@ func (m *mockLandingZoneStore) UpdateZoneLifecycle(_ context.Context, _ uuid.UUI
 	return nil
 }
 
+func (m *mockLandingZoneStore) UpdateZoneUploadPolicy(_ context.Context, _ uuid.UUID, _, _ *bool) error {
+	return nil
+}
+
+func (m *mockLandingZoneStore) FindFileByChecksum(_ context.Context, _ uuid.UUID, _ string) (*domain.LandingFile, error) {
+	return nil, nil
+}
+
+func (m *mockLandingZoneStore) FindFileByPath(_ context.Context, _ uuid.UUID, _ string) (*domain.LandingFile, error) {
+	return nil, nil
+}
+
 func (m *mockLandingZoneStore) ListZonesWithAutoPurge(_ context.Context) ([]domain.LandingZone, error) {
 	return nil, nil
 }
+
+func (m *mockLandingZoneStore) RestoreZone(_ context.Context, _, _ string) (*domain.LandingZone, error) {
+	return nil, nil
+}
+
+func (m *mockLandingZoneStore) ListSoftDeletedZones(_ context.Context, _ time.Time) ([]domain.LandingZone, error) {
+	return nil, nil
+}
+
+func (m *mockLandingZoneStore) HardDeleteZone(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+// --- Metrics ---
+
+func TestExecutorStats_SubmitAndComplete_UpdatesGauges(t *testing.T) {
+	mock := &mockRunnerClient{}
+	store := newMockRunStore()
+	exec := newWarmPoolExecutorWithClient(mock, store)
+
+	run := testRun()
+	require.NoError(t, exec.Submit(context.Background(), run, testPipeline()))
+
+	stats, ok := exec.ExecutorStats()
+	require.True(t, ok)
+	assert.Equal(t, 1, stats.ActiveRuns)
+	assert.Equal(t, uint64(1), stats.SubmitsTotal)
+	assert.Empty(t, stats.SubmitFailures)
+
+	err := exec.HandleStatusCallback(context.Background(), api.RunStatusUpdate{
+		RunID:  run.ID.String(),
+		Status: "success",
+	})
+	require.NoError(t, err)
+
+	stats, _ = exec.ExecutorStats()
+	assert.Equal(t, 0, stats.ActiveRuns, "completed run must leave the active gauge")
+	assert.Equal(t, uint64(1), stats.SubmitsTotal, "submits counter is monotonic")
+}
+
+func TestExecutorStats_SubmitFailures_CountedByReason(t *testing.T) {
+	code := connect.CodeResourceExhausted
+	mock := &mockRunnerClient{
+		submitFunc: func(_ context.Context, _ *connect.Request[runnerv1.SubmitPipelineRequest]) (*connect.Response[runnerv1.SubmitPipelineResponse], error) {
+			return nil, connect.NewError(code, errors.New("nope"))
+		},
+	}
+	exec := newWarmPoolExecutorWithClient(mock, newMockRunStore())
+
+	_ = exec.Submit(context.Background(), testRun(), testPipeline())
+	_ = exec.Submit(context.Background(), testRun(), testPipeline())
+	code = connect.CodeUnavailable
+	_ = exec.Submit(context.Background(), testRun(), testPipeline())
+
+	stats, _ := exec.ExecutorStats()
+	assert.Equal(t, uint64(3), stats.SubmitsTotal)
+	assert.Equal(t, uint64(2), stats.SubmitFailures[failureReasonRunnerBusy])
+	assert.Equal(t, uint64(1), stats.SubmitFailures[failureReasonRunnerUnavailable])
+	assert.Equal(t, 0, stats.ActiveRuns)
+}
+
+func TestExecutorStats_PollRecordsDuration(t *testing.T) {
+	exec := newWarmPoolExecutorWithClient(&mockRunnerClient{}, newMockRunStore())
+
+	exec.poll(context.Background())
+	exec.poll(context.Background())
+
+	stats, _ := exec.ExecutorStats()
+	assert.Equal(t, uint64(2), stats.PollCount)
+	assert.GreaterOrEqual(t, stats.PollDurationSeconds, 0.0)
+}
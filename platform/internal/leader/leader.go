@@ -11,7 +11,9 @@
 // goroutine that pings Postgres every heartbeatInterval. Two consecutive
 // failures trigger a voluntary release of the advisory lock so another
 // replica can take over on its next poll cycle. Graceful shutdown also
-// explicit-unlocks the advisory lock instead of relying on session death.
+// explicit-unlocks the advisory lock instead of relying on session death;
+// StepDown does so at the start of shutdown, as soon as the workers have
+// stopped, so failover doesn't wait on the rest of the shutdown sequence.
 package leader
 
 import (
@@ -39,22 +41,16 @@ const heartbeatFailureThreshold = 2
 
 // TryLockFunc attempts to acquire the advisory lock.
 // Returns true if the lock was acquired, false if another session holds it.
-// In production, the caller provides this using pgxpool.Pool.QueryRow:
+// In production, the caller provides postgres.AdvisoryLock's methods, which
+// keep the lock, unlock and ownership check on one dedicated session:
 //
-//	leader.New(func(ctx context.Context) (bool, error) {
-//	    var acquired bool
-//	    err := pool.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", leader.AdvisoryLockID).Scan(&acquired)
-//	    return acquired, err
-//	}, ...)
+//	lock := postgres.NewAdvisoryLock(pool, leader.AdvisoryLockID)
+//	leader.New(lock.TryLock, leader.RetryInterval, onElected,
+//	    leader.WithUnlock(lock.Unlock), leader.WithLockCheck(lock.Held))
 type TryLockFunc func(ctx context.Context) (acquired bool, err error)
 
-// UnlockFunc releases the advisory lock held by the current session.
-// In production, the caller provides this using pgxpool.Pool.Exec:
-//
-//	func(ctx context.Context) error {
-//	    _, err := pool.Exec(ctx, "SELECT pg_advisory_unlock($1)", leader.AdvisoryLockID)
-//	    return err
-//	}
+// UnlockFunc releases the advisory lock held by the current session. It must
+// run on the session that took the lock (see TryLockFunc).
 //
 // If nil, the leader cannot voluntarily release the lock and falls back to
 // session-death behaviour (Postgres releases on connection close). This is
@@ -62,6 +58,13 @@ type TryLockFunc func(ctx context.Context) (acquired bool, err error)
 // net — production callers should always provide an UnlockFunc.
 type UnlockFunc func(ctx context.Context) error
 
+// CheckLockFunc reports whether this replica's session still holds the
+// advisory lock. A dropped connection takes a session lock with it, and
+// without a check the replica would keep running workers alongside the new
+// leader. Implementations typically query pg_locks for pg_backend_pid() on
+// the connection that took the lock. If nil, ownership is never re-verified.
+type CheckLockFunc func(ctx context.Context) (held bool, err error)
+
 // PingFunc probes the database to prove the leader can still reach it.
 // Implementations typically run `SELECT 1`. If nil, the heartbeat goroutine
 // does not run (legacy behaviour).
@@ -82,6 +85,12 @@ func WithUnlock(fn UnlockFunc) Option {
 	return func(e *Elector) { e.unlock = fn }
 }
 
+// WithLockCheck supplies the function used to re-verify lock ownership on
+// every retry tick while leader.
+func WithLockCheck(fn CheckLockFunc) Option {
+	return func(e *Elector) { e.checkLock = fn }
+}
+
 // WithPing supplies the function used by the heartbeat goroutine to verify
 // the leader can still reach Postgres. Required for heartbeat-based liveness.
 func WithPing(fn PingFunc) Option {
@@ -109,6 +118,7 @@ func WithClock(c Clock) Option {
 type Elector struct {
 	tryLock           TryLockFunc
 	unlock            UnlockFunc
+	checkLock         CheckLockFunc
 	ping              PingFunc
 	retryInterval     time.Duration
 	heartbeatInterval time.Duration
@@ -117,6 +127,7 @@ type Elector struct {
 
 	mu              sync.Mutex
 	isLeader        bool
+	steppedDown     bool   // set by StepDown; blocks re-acquiring the lock
 	stopFn          func() // stop function returned by OnElected
 	heartbeatCancel context.CancelFunc
 	heartbeatDone   chan struct{}
@@ -148,7 +159,8 @@ func New(tryLock TryLockFunc, retryInterval time.Duration, onElected OnElected,
 
 // Start begins the leader election loop in a background goroutine.
 // It immediately tries to acquire the lock, then retries at the configured
-// interval if not acquired.
+// interval if not acquired. While leader, the same tick re-verifies lock
+// ownership when a CheckLockFunc was provided.
 func (e *Elector) Start(ctx context.Context) {
 	ctx, e.cancel = context.WithCancel(ctx)
 	e.done = make(chan struct{})
@@ -170,6 +182,7 @@ func (e *Elector) Start(ctx context.Context) {
 				e.relinquish(context.Background(), false)
 				return
 			case <-ticker.C():
+				e.verifyLock(ctx)
 				e.tryAcquire(ctx)
 			}
 		}
@@ -188,6 +201,22 @@ func (e *Elector) Stop() {
 	}
 }
 
+// StepDown gives up leadership for good, for use at the start of graceful
+// shutdown: it stops the background workers and then explicitly releases the
+// advisory lock, so a standby can take over while this replica is still
+// draining HTTP and closing its stores instead of after. The workers stop
+// before the unlock so a standby never runs schedulers alongside them. The
+// election loop is stopped too, so the lock is never re-acquired. Safe to
+// call when not leader, and Stop may still be called afterwards.
+func (e *Elector) StepDown(ctx context.Context) {
+	e.mu.Lock()
+	e.steppedDown = true
+	e.mu.Unlock()
+
+	e.relinquish(ctx, false)
+	e.Stop()
+}
+
 // IsLeader returns whether this replica currently holds the leader lock.
 func (e *Elector) IsLeader() bool {
 	e.mu.Lock()
@@ -198,7 +227,7 @@ func (e *Elector) IsLeader() bool {
 // tryAcquire attempts to acquire the advisory lock if not already the leader.
 func (e *Elector) tryAcquire(ctx context.Context) {
 	e.mu.Lock()
-	if e.isLeader {
+	if e.isLeader || e.steppedDown {
 		e.mu.Unlock()
 		return
 	}
@@ -234,6 +263,26 @@ func (e *Elector) tryAcquire(ctx context.Context) {
 	}
 }
 
+// verifyLock checks that a leader still holds the advisory lock and, if the
+// lock is gone, stops the workers so the caller's tryAcquire can compete for
+// it again. A failed check is only logged: it usually means Postgres is
+// unreachable, which the heartbeat already handles.
+func (e *Elector) verifyLock(ctx context.Context) {
+	if e.checkLock == nil || !e.IsLeader() {
+		return
+	}
+	held, err := e.checkLock(ctx)
+	if err != nil {
+		slog.Warn("leader: failed to verify advisory lock ownership", "error", err)
+		return
+	}
+	if held {
+		return
+	}
+	slog.Error("leader: advisory lock no longer held, stopping background workers")
+	e.relinquish(ctx, false)
+}
+
 // startHeartbeat launches the heartbeat goroutine that pings Postgres on a
 // fixed interval. Two consecutive failures cause a voluntary step-down.
 func (e *Elector) startHeartbeat(parent context.Context) {
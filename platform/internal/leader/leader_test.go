@@ -484,3 +484,176 @@ func TestLeader_NoPingFunc_LegacyBehaviour_NoHeartbeat(t *testing.T) {
 	// Unlock is still called on graceful shutdown.
 	assert.Equal(t, 1, unlock.getCalls(), "graceful shutdown still unlocks even without ping")
 }
+
+func TestLeader_StepDown_StopsWorkersBeforeUnlocking(t *testing.T) {
+	lock := &mockLock{acquired: true}
+	clock := newFakeClock()
+
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, s)
+	}
+
+	elector := New(
+		lock.tryLock,
+		1*time.Hour,
+		func(_ context.Context) func() {
+			return func() { record("stop-workers") }
+		},
+		WithUnlock(func(_ context.Context) error {
+			record("unlock")
+			return nil
+		}),
+		WithClock(clock),
+	)
+	elector.Start(context.Background())
+	require.Eventually(t, elector.IsLeader, time.Second, time.Millisecond,
+		"should become leader")
+
+	elector.StepDown(context.Background())
+
+	mu.Lock()
+	assert.Equal(t, []string{"stop-workers", "unlock"}, order,
+		"workers must stop before the lock is released so a standby never overlaps them")
+	mu.Unlock()
+	assert.False(t, elector.IsLeader())
+
+	// The election loop is gone: the lock is never re-acquired, and a later
+	// Stop (main.go still calls it) is a no-op.
+	calls := lock.getCalls()
+	clock.Advance(2 * time.Hour)
+	elector.Stop()
+	assert.Equal(t, calls, lock.getCalls())
+	mu.Lock()
+	assert.Len(t, order, 2, "Stop after StepDown must not unlock or stop workers again")
+	mu.Unlock()
+}
+
+func TestLeader_StepDown_NotLeader_DoesNotUnlock(t *testing.T) {
+	lock := &mockLock{acquired: false}
+	unlock := &mockUnlock{}
+
+	elector := New(lock.tryLock, 1*time.Hour, func(_ context.Context) func() {
+		t.Fatal("onElected should not be called")
+		return nil
+	}, WithUnlock(unlock.unlock), WithClock(newFakeClock()))
+	elector.Start(context.Background())
+	require.Eventually(t, func() bool { return lock.getCalls() > 0 }, time.Second, time.Millisecond)
+
+	elector.StepDown(context.Background())
+
+	assert.Equal(t, 0, unlock.getCalls(), "a follower holds no lock to release")
+	assert.False(t, elector.IsLeader())
+}
+
+// mockLockCheck is a controllable CheckLockFunc.
+type mockLockCheck struct {
+	mu    sync.Mutex
+	held  bool
+	calls int
+}
+
+func (c *mockLockCheck) check(_ context.Context) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return c.held, nil
+}
+
+func (c *mockLockCheck) setHeld(v bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.held = v
+}
+
+func (c *mockLockCheck) getCalls() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func TestLeader_LockLost_StopsWorkersAndReacquires(t *testing.T) {
+	lock := &mockLock{acquired: true}
+	check := &mockLockCheck{held: true}
+	clock := newFakeClock()
+	var elected, stopped atomic.Int32
+
+	elector := New(
+		lock.tryLock,
+		30*time.Second,
+		func(_ context.Context) func() {
+			elected.Add(1)
+			return func() { stopped.Add(1) }
+		},
+		WithLockCheck(check.check),
+		WithClock(clock),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		elector.Stop()
+	}()
+	elector.Start(ctx)
+	require.Eventually(t, elector.IsLeader, time.Second, time.Millisecond)
+
+	// Healthy tick: ownership verified, workers untouched. The loop registers
+	// its ticker asynchronously, so keep advancing until a check lands.
+	require.Eventually(t, func() bool {
+		clock.Advance(30 * time.Second)
+		return check.getCalls() >= 1
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, elector.IsLeader())
+	assert.Equal(t, int32(0), stopped.Load())
+
+	// The session dies and another replica grabs the lock.
+	check.setHeld(false)
+	lock.setAcquired(false)
+	clock.Advance(30 * time.Second)
+	require.Eventually(t, func() bool { return stopped.Load() == 1 }, time.Second, time.Millisecond,
+		"workers must stop once the lock is found missing")
+	assert.False(t, elector.IsLeader())
+
+	// Once the lock is free again this replica re-acquires it.
+	check.setHeld(true)
+	lock.setAcquired(true)
+	require.Eventually(t, func() bool {
+		clock.Advance(30 * time.Second)
+		return elected.Load() == 2
+	}, time.Second, 10*time.Millisecond, "should re-elect after the lock frees up")
+	assert.True(t, elector.IsLeader())
+}
+
+func TestLeader_LockCheckError_KeepsLeadership(t *testing.T) {
+	lock := &mockLock{acquired: true}
+	clock := newFakeClock()
+	var checks atomic.Int32
+	var stopped atomic.Bool
+
+	elector := New(
+		lock.tryLock,
+		30*time.Second,
+		func(_ context.Context) func() { return func() { stopped.Store(true) } },
+		WithLockCheck(func(context.Context) (bool, error) {
+			checks.Add(1)
+			return false, fmt.Errorf("i/o timeout")
+		}),
+		WithClock(clock),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		elector.Stop()
+	}()
+	elector.Start(ctx)
+	require.Eventually(t, elector.IsLeader, time.Second, time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		clock.Advance(30 * time.Second)
+		return checks.Load() >= 2
+	}, time.Second, 10*time.Millisecond)
+	assert.True(t, elector.IsLeader(), "an inconclusive check must not drop leadership")
+	assert.False(t, stopped.Load())
+}
@@ -24,6 +24,7 @@ const (
 	ChannelFileUploaded      = "file_uploaded"
 	ChannelQualityFailed     = "quality_failed"
 	ChannelScheduleFired     = "schedule_fired"
+	ChannelTriggerFired      = "trigger_fired"
 )
 
 // DispatchEvent represents a notification from the event bus.
@@ -70,6 +71,7 @@ func (d *EventDispatcher) Start(ctx context.Context) {
 		ChannelFileUploaded,
 		ChannelQualityFailed,
 		ChannelScheduleFired,
+		ChannelTriggerFired,
 	}
 
 	go func() {
@@ -0,0 +1,137 @@
+package plugins
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultSeatIdleWindow is how long a user keeps their seat without making a
+// request. After that the seat is free for someone else.
+const DefaultSeatIdleWindow = 30 * 24 * time.Hour
+
+// seatSyncInterval is how long an admitted user is let through on the local
+// record before their seat is confirmed (and its activity saved) in the
+// SeatStore again. It keeps the store off the per-request path.
+const seatSyncInterval = time.Minute
+
+// SeatStore persists seats so they survive restarts and replicas share one
+// count. Implemented by postgres.SeatStore.
+type SeatStore interface {
+	// ClaimSeat records userID as active at now. A user that already holds a
+	// seat keeps it; a new one gets a seat only while fewer than limit users
+	// have been active since idleSince. Reports whether userID holds a seat.
+	ClaimSeat(ctx context.Context, userID string, limit int, idleSince, now time.Time) (bool, error)
+}
+
+// SeatTracker counts the distinct users active within an idle window and
+// refuses new users once the license seat limit is reached. Users that
+// already hold a seat are always admitted. Without a store, state is
+// in-memory: a restart frees every seat, and replicas each count their own
+// users. With one (SetStore), seats are claimed in the store, and the
+// in-memory records only spare admitted users a store call per request.
+type SeatTracker struct {
+	limit int
+	idle  time.Duration
+	now   func() time.Time
+
+	mu       sync.Mutex
+	store    SeatStore
+	lastSeen map[string]time.Time // with a store: when the seat was last confirmed there
+}
+
+// NewSeatTracker returns a tracker admitting at most limit users. A
+// non-positive idle uses DefaultSeatIdleWindow.
+func NewSeatTracker(limit int, idle time.Duration) *SeatTracker {
+	if idle <= 0 {
+		idle = DefaultSeatIdleWindow
+	}
+	return &SeatTracker{
+		limit:    limit,
+		idle:     idle,
+		now:      time.Now,
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+// SetStore makes the tracker claim seats in store. Seats admitted in memory
+// before that are claimed again on the users' next requests.
+func (t *SeatTracker) SetStore(store SeatStore) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.store = store
+	clear(t.lastSeen)
+}
+
+// Limit returns the seat limit.
+func (t *SeatTracker) Limit() int { return t.limit }
+
+// Admit records activity for userID and reports whether it may proceed.
+// A new user is refused when every seat is held by a user active within the
+// idle window. If the store fails, the in-memory count decides.
+func (t *SeatTracker) Admit(ctx context.Context, userID string) bool {
+	t.mu.Lock()
+	now := t.now()
+	store := t.store
+	if store == nil {
+		defer t.mu.Unlock()
+		return t.admitLocked(userID, now)
+	}
+	if confirmed, ok := t.lastSeen[userID]; ok && now.Sub(confirmed) < seatSyncInterval {
+		t.mu.Unlock()
+		return true
+	}
+	t.mu.Unlock()
+
+	admitted, err := store.ClaimSeat(ctx, userID, t.limit, now.Add(-t.idle), now)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		slog.Warn("license seat store unavailable, counting seats in memory", "user_id", userID, "error", err)
+		return t.admitLocked(userID, now)
+	}
+	if admitted {
+		t.lastSeen[userID] = now
+		if len(t.lastSeen) > t.limit {
+			// Records past the sync interval are stale either way.
+			for id, confirmed := range t.lastSeen {
+				if now.Sub(confirmed) >= seatSyncInterval {
+					delete(t.lastSeen, id)
+				}
+			}
+		}
+	} else {
+		delete(t.lastSeen, userID)
+	}
+	return admitted
+}
+
+// admitLocked is Admit against the in-memory records. t.mu must be held.
+func (t *SeatTracker) admitLocked(userID string, now time.Time) bool {
+	if _, ok := t.lastSeen[userID]; ok {
+		t.lastSeen[userID] = now
+		return true
+	}
+	if len(t.lastSeen) >= t.limit {
+		// Only sweep idle seats when full, so the common path stays O(1).
+		for id, seen := range t.lastSeen {
+			if now.Sub(seen) > t.idle {
+				delete(t.lastSeen, id)
+			}
+		}
+		if len(t.lastSeen) >= t.limit {
+			return false
+		}
+	}
+	t.lastSeen[userID] = now
+	return true
+}
+
+// Active returns the number of seats this tracker currently knows to be held.
+func (t *SeatTracker) Active() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.lastSeen)
+}
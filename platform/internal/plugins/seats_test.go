@@ -0,0 +1,109 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeatTracker_LimitOne_BlocksSecondUser(t *testing.T) {
+	tr := NewSeatTracker(1, time.Hour)
+	ctx := context.Background()
+
+	assert.True(t, tr.Admit(ctx, "alice"))
+	assert.False(t, tr.Admit(ctx, "bob"))
+	assert.True(t, tr.Admit(ctx, "alice"), "seat holder keeps access")
+	assert.Equal(t, 1, tr.Active())
+}
+
+func TestSeatTracker_IdleSeatIsFreed(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := NewSeatTracker(1, time.Hour)
+	tr.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.True(t, tr.Admit(ctx, "alice"))
+	now = now.Add(30 * time.Minute)
+	assert.False(t, tr.Admit(ctx, "bob"))
+
+	now = now.Add(2 * time.Hour)
+	assert.True(t, tr.Admit(ctx, "bob"))
+	assert.False(t, tr.Admit(ctx, "alice"), "alice's seat was freed while idle")
+}
+
+// memorySeatStore is a SeatStore shared by several trackers, standing in for
+// the database replicas share.
+type memorySeatStore struct {
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+	claims   int
+	err      error
+}
+
+func (m *memorySeatStore) ClaimSeat(_ context.Context, userID string, limit int, idleSince, now time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.claims++
+	if m.err != nil {
+		return false, m.err
+	}
+	for id, seen := range m.lastSeen {
+		if seen.Before(idleSince) {
+			delete(m.lastSeen, id)
+		}
+	}
+	if _, ok := m.lastSeen[userID]; !ok && len(m.lastSeen) >= limit {
+		return false, nil
+	}
+	m.lastSeen[userID] = now
+	return true, nil
+}
+
+func TestSeatTracker_Store_SharesSeatsAcrossTrackers(t *testing.T) {
+	store := &memorySeatStore{lastSeen: make(map[string]time.Time)}
+	a, b := NewSeatTracker(1, time.Hour), NewSeatTracker(1, time.Hour)
+	a.SetStore(store)
+	b.SetStore(store)
+	ctx := context.Background()
+
+	assert.True(t, a.Admit(ctx, "alice"))
+	assert.False(t, b.Admit(ctx, "bob"), "the seat is taken on another replica")
+
+	// A fresh tracker (a restart) still sees alice's seat.
+	restarted := NewSeatTracker(1, time.Hour)
+	restarted.SetStore(store)
+	assert.False(t, restarted.Admit(ctx, "bob"))
+	assert.True(t, restarted.Admit(ctx, "alice"))
+}
+
+func TestSeatTracker_Store_ConfirmsSeatOncePerSyncInterval(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memorySeatStore{lastSeen: make(map[string]time.Time)}
+	tr := NewSeatTracker(1, time.Hour)
+	tr.now = func() time.Time { return now }
+	tr.SetStore(store)
+	ctx := context.Background()
+
+	assert.True(t, tr.Admit(ctx, "alice"))
+	now = now.Add(seatSyncInterval / 2)
+	assert.True(t, tr.Admit(ctx, "alice"))
+	assert.Equal(t, 1, store.claims)
+
+	now = now.Add(seatSyncInterval)
+	assert.True(t, tr.Admit(ctx, "alice"))
+	assert.Equal(t, 2, store.claims)
+}
+
+func TestSeatTracker_StoreError_FallsBackToMemory(t *testing.T) {
+	store := &memorySeatStore{lastSeen: make(map[string]time.Time), err: errors.New("connection refused")}
+	tr := NewSeatTracker(1, time.Hour)
+	tr.SetStore(store)
+	ctx := context.Background()
+
+	assert.True(t, tr.Admit(ctx, "alice"))
+	assert.False(t, tr.Admit(ctx, "bob"))
+}
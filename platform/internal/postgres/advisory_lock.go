@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLock holds a session-level advisory lock on a dedicated connection.
+//
+// Advisory locks belong to the session that took them. Taking one through the
+// pool would leave it on whichever connection happened to run the query, and
+// a later unlock or ownership check could land on a different one. The lock
+// connection is opened outside the pool (like the event bus's LISTEN
+// connection) so it is never recycled. If it drops, the lock is gone with it;
+// the next TryLock reconnects.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  int64
+
+	mu   sync.Mutex
+	conn *pgx.Conn
+}
+
+// NewAdvisoryLock returns an unlocked AdvisoryLock for key. Connection
+// settings come from the pool's config.
+func NewAdvisoryLock(pool *pgxpool.Pool, key int64) *AdvisoryLock {
+	return &AdvisoryLock{pool: pool, key: key}
+}
+
+// TryLock attempts to take the lock without blocking (pg_try_advisory_lock),
+// connecting first if there is no live lock connection.
+func (l *AdvisoryLock) TryLock(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil || l.conn.IsClosed() {
+		conn, err := pgx.ConnectConfig(ctx, l.pool.Config().ConnConfig.Copy())
+		if err != nil {
+			return false, fmt.Errorf("advisory lock: connect: %w", err)
+		}
+		l.conn = conn
+	}
+
+	var acquired bool
+	if err := l.conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		l.closeLocked()
+		return false, fmt.Errorf("advisory lock: try lock: %w", err)
+	}
+	return acquired, nil
+}
+
+// Unlock releases the lock (pg_advisory_unlock). A no-op without a live lock
+// connection, since the lock died with the session.
+func (l *AdvisoryLock) Unlock(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil || l.conn.IsClosed() {
+		return nil
+	}
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		return fmt.Errorf("advisory lock: unlock: %w", err)
+	}
+	return nil
+}
+
+// Held reports whether the lock connection's session still holds the lock.
+// A dead connection means the lock is gone, so it reports false, not an error.
+// A bigint advisory key is stored in pg_locks split across classid (high 32
+// bits) and objid (low 32 bits), with objsubid 1.
+func (l *AdvisoryLock) Held(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil || l.conn.IsClosed() {
+		return false, nil
+	}
+	var held bool
+	err := l.conn.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_locks
+			WHERE locktype = 'advisory' AND granted
+			  AND pid = pg_backend_pid()
+			  AND classid = $1::bigint::oid AND objid = $2::bigint::oid AND objsubid = 1
+		)`, int64(uint32(l.key>>32)), int64(uint32(l.key))).Scan(&held)
+	if err != nil {
+		if l.conn.IsClosed() {
+			l.conn = nil
+			return false, nil
+		}
+		return false, fmt.Errorf("advisory lock: check: %w", err)
+	}
+	return held, nil
+}
+
+// Close closes the lock connection, which releases the lock if held.
+func (l *AdvisoryLock) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closeLocked()
+}
+
+// closeLocked closes and forgets the lock connection. Caller holds l.mu.
+func (l *AdvisoryLock) closeLocked() {
+	if l.conn != nil {
+		_ = l.conn.Close(context.Background())
+		l.conn = nil
+	}
+}
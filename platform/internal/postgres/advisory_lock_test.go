@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAdvisoryKey is above 2^32 so the classid/objid split in Held is exercised.
+var testAdvisoryKey int64 = 7526700533049 + 1
+
+func TestAdvisoryLock_ExclusiveAndHeld(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+
+	a := postgres.NewAdvisoryLock(pool, testAdvisoryKey)
+	b := postgres.NewAdvisoryLock(pool, testAdvisoryKey)
+	t.Cleanup(a.Close)
+	t.Cleanup(b.Close)
+
+	held, err := a.Held(ctx)
+	require.NoError(t, err)
+	assert.False(t, held, "nothing held before TryLock")
+
+	ok, err := a.TryLock(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	held, err = a.Held(ctx)
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	ok, err = b.TryLock(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok, "a second session must not get the lock")
+
+	require.NoError(t, a.Unlock(ctx))
+	held, err = a.Held(ctx)
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	ok, err = b.TryLock(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok, "lock is free after Unlock")
+}
+
+func TestAdvisoryLock_ConnectionKilled_NotHeldThenReacquires(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+
+	lock := postgres.NewAdvisoryLock(pool, testAdvisoryKey)
+	t.Cleanup(lock.Close)
+	ok, err := lock.TryLock(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Kill the lock's session, as a connection reset or failover would.
+	_, err = pool.Exec(ctx, `SELECT pg_terminate_backend(pid) FROM pg_locks
+		WHERE locktype = 'advisory' AND classid = $1::bigint::oid AND objid = $2::bigint::oid`,
+		int64(uint32(testAdvisoryKey>>32)), int64(uint32(testAdvisoryKey)))
+	require.NoError(t, err)
+
+	held, err := lock.Held(ctx)
+	require.NoError(t, err)
+	assert.False(t, held, "a dead session holds nothing")
+
+	ok, err = lock.TryLock(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok, "TryLock reconnects and re-acquires")
+}
@@ -5,23 +5,25 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
 )
 
 // AuditStore provides audit log persistence.
 type AuditStore struct {
-	pool *pgxpool.Pool
+	db *timeoutDB
 }
 
 // NewAuditStore creates an AuditStore backed by the given pool.
 func NewAuditStore(pool *pgxpool.Pool) *AuditStore {
-	return &AuditStore{pool: pool}
+	return &AuditStore{db: newTimeoutDB(pool)}
 }
 
 // Log records an audit entry.
 func (s *AuditStore) Log(ctx context.Context, userID, action, resource, detail, ip string) error {
-	_, err := s.pool.Exec(ctx,
+	_, err := s.db.Exec(ctx,
 		`INSERT INTO audit_log (user_id, action, resource, detail, ip) VALUES ($1, $2, $3, $4, $5)`,
 		userID, action, resource, detail, ip,
 	)
@@ -33,7 +35,7 @@ func (s *AuditStore) Log(ctx context.Context, userID, action, resource, detail,
 
 // List returns recent audit entries, most recent first.
 func (s *AuditStore) List(ctx context.Context, limit, offset int) ([]domain.AuditEntry, error) {
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		`SELECT id, user_id, action, resource, detail, COALESCE(ip, ''), created_at
 		 FROM audit_log ORDER BY created_at DESC LIMIT $1 OFFSET $2`,
 		limit, offset,
@@ -60,13 +62,134 @@ func (s *AuditStore) List(ctx context.Context, limit, offset int) ([]domain.Audi
 	return entries, nil
 }
 
+// ListFiltered returns audit entries matching filter, most recent first.
+// With filter.Cursor set it pages by keyset instead of OFFSET.
+func (s *AuditStore) ListFiltered(ctx context.Context, filter api.AuditFilter) ([]domain.AuditEntry, error) {
+	where, args, argN := auditWhereClause(filter)
+	query := `SELECT id, user_id, action, resource, detail, COALESCE(ip, ''), created_at
+		 FROM audit_log` + where + ` ORDER BY created_at DESC, id DESC`
+	switch {
+	case filter.Limit > 0 && filter.Cursor != nil:
+		query += fmt.Sprintf(" LIMIT $%d", argN)
+		args = append(args, filter.Limit)
+	case filter.Limit > 0:
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argN, argN+1)
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []domain.AuditEntry{}
+	for rows.Next() {
+		var e domain.AuditEntry
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Action, &e.Resource, &e.Detail, &e.IP, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+// auditExportBatchSize is how many entries each EachFiltered page fetches.
+const auditExportBatchSize = 1000
+
+// EachFiltered streams audit entries matching filter to fn, most recent first.
+// Entries are fetched in keyset pages on (created_at, id), so memory stays
+// flat, no connection is held while fn runs, and a deep export never pays
+// for an OFFSET re-scan. filter.Limit, Offset and Cursor are ignored.
+func (s *AuditStore) EachFiltered(ctx context.Context, filter api.AuditFilter, fn func(domain.AuditEntry) error) error {
+	ctx = heavyQuery(ctx)
+	filter.Limit = auditExportBatchSize
+	filter.Offset = 0
+	filter.Cursor = nil
+	for {
+		page, err := s.ListFiltered(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("export audit entries: %w", err)
+		}
+		for _, e := range page {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		if len(page) < auditExportBatchSize {
+			return nil
+		}
+		last := page[len(page)-1]
+		id, err := uuid.Parse(last.ID)
+		if err != nil {
+			return fmt.Errorf("export audit entries: cursor id: %w", err)
+		}
+		filter.Cursor = &api.AuditCursor{CreatedAt: last.CreatedAt, ID: id}
+	}
+}
+
+// auditWhereClause builds the WHERE clause and args for filtered audit queries.
+func auditWhereClause(filter api.AuditFilter) (string, []interface{}, int) {
+	where := ` WHERE 1=1`
+	args := []interface{}{}
+	argN := 1
+
+	if filter.UserID != "" {
+		where += fmt.Sprintf(" AND user_id = $%d", argN)
+		args = append(args, filter.UserID)
+		argN++
+	}
+	if filter.Action != "" {
+		where += fmt.Sprintf(" AND action = $%d", argN)
+		args = append(args, filter.Action)
+		argN++
+	}
+	if filter.ResourcePrefix != "" {
+		where += fmt.Sprintf(" AND resource LIKE $%d", argN)
+		args = append(args, escapeLike(filter.ResourcePrefix)+"%")
+		argN++
+	}
+	if filter.After != nil {
+		where += fmt.Sprintf(" AND created_at >= $%d", argN)
+		args = append(args, *filter.After)
+		argN++
+	}
+	if filter.Before != nil {
+		where += fmt.Sprintf(" AND created_at < $%d", argN)
+		args = append(args, *filter.Before)
+		argN++
+	}
+	if filter.Cursor != nil {
+		where += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", argN, argN+1)
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		argN += 2
+	}
+	return where, args, argN
+}
+
 // DeleteOlderThan removes audit entries older than the given time.
 // Returns the number of entries deleted.
 func (s *AuditStore) DeleteOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
-	tag, err := s.pool.Exec(ctx,
+	ctx = heavyQuery(ctx)
+	tag, err := s.db.Exec(ctx,
 		`DELETE FROM audit_log WHERE created_at < $1`, olderThan)
 	if err != nil {
 		return 0, fmt.Errorf("delete old audit entries: %w", err)
 	}
 	return int(tag.RowsAffected()), nil
 }
+
+// CountOlderThan returns how many entries DeleteOlderThan would delete.
+func (s *AuditStore) CountOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
+	ctx = heavyQuery(ctx)
+	var count int
+	err := s.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM audit_log WHERE created_at < $1`, olderThan).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count old audit entries: %w", err)
+	}
+	return count, nil
+}
@@ -10,6 +10,7 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -28,6 +29,7 @@ const (
 	ChannelFileUploaded      = "file_uploaded"
 	ChannelQualityFailed     = "quality_failed"
 	ChannelScheduleFired     = "schedule_fired"
+	ChannelTriggerFired      = "trigger_fired"
 )
 
 // allChannels lists every channel PgEventBus listens on. They are LISTENed
@@ -42,6 +44,7 @@ var allChannels = []string{
 	ChannelFileUploaded,
 	ChannelQualityFailed,
 	ChannelScheduleFired,
+	ChannelTriggerFired,
 }
 
 // Event represents a single notification received from Postgres NOTIFY.
@@ -90,6 +93,14 @@ type ScheduleFiredPayload struct {
 	CronExpr   string `json:"cron_expr"`
 }
 
+// TriggerFiredPayload is the JSON payload for trigger_fired events.
+type TriggerFiredPayload struct {
+	TriggerID  string `json:"trigger_id"`
+	RunID      string `json:"run_id"`
+	PipelineID string `json:"pipeline_id"`
+	Type       string `json:"type"`
+}
+
 // EventBus defines the interface for publishing and subscribing to events.
 // This allows non-Postgres implementations (e.g. in-memory for tests).
 type EventBus interface {
@@ -111,6 +122,7 @@ type PgEventBus struct {
 	mu          sync.Mutex
 	subscribers map[string][]subscriber // channel -> list of subscribers
 	listening   map[string]bool         // channels we've already LISTENed on
+	loopErr     error                   // why listenLoop exited, if not shutdown
 
 	cancel context.CancelFunc
 	done   chan struct{}
@@ -180,6 +192,27 @@ func (eb *PgEventBus) Stop() {
 	slog.Info("event bus stopped")
 }
 
+// HealthCheck reports whether the bus is still receiving notifications. The
+// listen loop has no reconnect, so once the LISTEN connection drops this
+// replica stops seeing events until restart — readiness should say so.
+// Wired as the "event_bus" dependency of GET /health/ready.
+func (eb *PgEventBus) HealthCheck(_ context.Context) error {
+	if eb.done == nil {
+		return errors.New("event bus: not started")
+	}
+	select {
+	case <-eb.done:
+		eb.mu.Lock()
+		defer eb.mu.Unlock()
+		if eb.loopErr != nil {
+			return fmt.Errorf("event bus: LISTEN connection lost: %w", eb.loopErr)
+		}
+		return errors.New("event bus: stopped")
+	default:
+		return nil
+	}
+}
+
 // Publish sends a NOTIFY on the given channel. The payload is JSON-serialized.
 // Uses the pool (not the dedicated listen connection).
 func (eb *PgEventBus) Publish(ctx context.Context, channel string, payload interface{}) error {
@@ -250,6 +283,9 @@ func (eb *PgEventBus) listenLoop(ctx context.Context) {
 				return
 			}
 			slog.Error("event bus: wait for notification failed", "error", err)
+			eb.mu.Lock()
+			eb.loopErr = err
+			eb.mu.Unlock()
 			return
 		}
 
@@ -169,3 +169,28 @@ func TestEventBus_ChannelConstants(t *testing.T) {
 	assert.Equal(t, "pipeline_created", postgres.ChannelPipelineCreated)
 	assert.Equal(t, "pipeline_updated", postgres.ChannelPipelineUpdated)
 }
+
+func TestPgEventBus_HealthCheck_NotStarted(t *testing.T) {
+	bus := postgres.NewPgEventBus(nil)
+	assert.Error(t, bus.HealthCheck(context.Background()))
+}
+
+func TestPgEventBus_HealthCheck_LostListenConnection(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+
+	bus := postgres.NewPgEventBus(pool)
+	require.NoError(t, bus.Start(ctx))
+	defer bus.Stop()
+	require.NoError(t, bus.HealthCheck(ctx))
+
+	// Kill the bus's dedicated LISTEN backend, as a Postgres failover would.
+	_, err := pool.Exec(ctx, `SELECT pg_terminate_backend(pid) FROM pg_stat_activity
+		WHERE pid <> pg_backend_pid() AND datname = current_database() AND query LIKE 'LISTEN%'`)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return bus.HealthCheck(ctx) != nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.ErrorContains(t, bus.HealthCheck(ctx), "LISTEN connection lost")
+}
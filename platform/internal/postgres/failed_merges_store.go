@@ -17,12 +17,12 @@ import (
 // recover the data. This store records what failed and why; the reaper
 // consults it to avoid sweeping retained branches.
 type FailedMergesStore struct {
-	pool *pgxpool.Pool
+	db *timeoutDB
 }
 
 // NewFailedMergesStore creates a FailedMergesStore backed by the given pool.
 func NewFailedMergesStore(pool *pgxpool.Pool) *FailedMergesStore {
-	return &FailedMergesStore{pool: pool}
+	return &FailedMergesStore{db: newTimeoutDB(pool)}
 }
 
 // Create inserts a single failed-merge audit row. RunID and BranchName are
@@ -32,7 +32,7 @@ func (s *FailedMergesStore) Create(ctx context.Context, fm domain.FailedMerge) e
 	if err != nil {
 		return fmt.Errorf("invalid run_id %q: %w", fm.RunID, err)
 	}
-	_, err = s.pool.Exec(ctx, `
+	_, err = s.db.Exec(ctx, `
 		INSERT INTO failed_merges
 			(run_id, branch_name, source_hash, target_hash, error_kind, error_message)
 		VALUES ($1, $2, $3, $4, $5, $6)
@@ -54,7 +54,7 @@ func (s *FailedMergesStore) Create(ctx context.Context, fm domain.FailedMerge) e
 // rows newer than `since`. The reaper uses this to skip branches that a
 // human may still need to recover.
 func (s *FailedMergesStore) RecentBranchNames(ctx context.Context, since time.Time) ([]string, error) {
-	rows, err := s.pool.Query(ctx, `
+	rows, err := s.db.Query(ctx, `
 		SELECT DISTINCT branch_name
 		FROM failed_merges
 		WHERE created_at >= $1
@@ -14,18 +14,19 @@ import (
 )
 
 const createLandingFile = `-- name: CreateLandingFile :one
-INSERT INTO landing_files (zone_id, filename, s3_path, size_bytes, content_type, uploaded_by)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at
+INSERT INTO landing_files (zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, checksum_sha256)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
 `
 
 type CreateLandingFileParams struct {
-	ZoneID      uuid.UUID
-	Filename    string
-	S3Path      string
-	SizeBytes   int64
-	ContentType string
-	UploadedBy  pgtype.Text
+	ZoneID         uuid.UUID
+	Filename       string
+	S3Path         string
+	SizeBytes      int64
+	ContentType    string
+	UploadedBy     pgtype.Text
+	ChecksumSha256 string
 }
 
 func (q *Queries) CreateLandingFile(ctx context.Context, arg CreateLandingFileParams) (LandingFile, error) {
@@ -36,6 +37,7 @@ func (q *Queries) CreateLandingFile(ctx context.Context, arg CreateLandingFilePa
 		arg.SizeBytes,
 		arg.ContentType,
 		arg.UploadedBy,
+		arg.ChecksumSha256,
 	)
 	var i LandingFile
 	err := row.Scan(
@@ -47,6 +49,7 @@ func (q *Queries) CreateLandingFile(ctx context.Context, arg CreateLandingFilePa
 		&i.ContentType,
 		&i.UploadedBy,
 		&i.UploadedAt,
+		&i.ChecksumSha256,
 	)
 	return i, err
 }
@@ -107,8 +110,8 @@ func (q *Queries) DeleteLandingFile(ctx context.Context, id uuid.UUID) error {
 }
 
 const deleteLandingZone = `-- name: DeleteLandingZone :exec
-DELETE FROM landing_zones
-WHERE namespace = $1 AND name = $2
+UPDATE landing_zones SET deleted_at = NOW(), updated_at = NOW()
+WHERE namespace = $1 AND name = $2 AND deleted_at IS NULL
 `
 
 type DeleteLandingZoneParams struct {
@@ -121,8 +124,68 @@ func (q *Queries) DeleteLandingZone(ctx context.Context, arg DeleteLandingZonePa
 	return err
 }
 
+const findLandingFileByChecksum = `-- name: FindLandingFileByChecksum :one
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
+FROM landing_files
+WHERE zone_id = $1 AND checksum_sha256 = $2
+ORDER BY uploaded_at DESC
+LIMIT 1
+`
+
+type FindLandingFileByChecksumParams struct {
+	ZoneID         uuid.UUID
+	ChecksumSha256 string
+}
+
+func (q *Queries) FindLandingFileByChecksum(ctx context.Context, arg FindLandingFileByChecksumParams) (LandingFile, error) {
+	row := q.db.QueryRow(ctx, findLandingFileByChecksum, arg.ZoneID, arg.ChecksumSha256)
+	var i LandingFile
+	err := row.Scan(
+		&i.ID,
+		&i.ZoneID,
+		&i.Filename,
+		&i.S3Path,
+		&i.SizeBytes,
+		&i.ContentType,
+		&i.UploadedBy,
+		&i.UploadedAt,
+		&i.ChecksumSha256,
+	)
+	return i, err
+}
+
+const findLandingFileByPath = `-- name: FindLandingFileByPath :one
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
+FROM landing_files
+WHERE zone_id = $1 AND s3_path = $2
+ORDER BY uploaded_at DESC
+LIMIT 1
+`
+
+type FindLandingFileByPathParams struct {
+	ZoneID uuid.UUID
+	S3Path string
+}
+
+func (q *Queries) FindLandingFileByPath(ctx context.Context, arg FindLandingFileByPathParams) (LandingFile, error) {
+	row := q.db.QueryRow(ctx, findLandingFileByPath, arg.ZoneID, arg.S3Path)
+	var i LandingFile
+	err := row.Scan(
+		&i.ID,
+		&i.ZoneID,
+		&i.Filename,
+		&i.S3Path,
+		&i.SizeBytes,
+		&i.ContentType,
+		&i.UploadedBy,
+		&i.UploadedAt,
+		&i.ChecksumSha256,
+	)
+	return i, err
+}
+
 const getLandingFile = `-- name: GetLandingFile :one
-SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
 FROM landing_files
 WHERE id = $1
 `
@@ -139,18 +202,19 @@ func (q *Queries) GetLandingFile(ctx context.Context, id uuid.UUID) (LandingFile
 		&i.ContentType,
 		&i.UploadedBy,
 		&i.UploadedAt,
+		&i.ChecksumSha256,
 	)
 	return i, err
 }
 
 const getLandingZone = `-- name: GetLandingZone :one
-SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema,
+SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema, lz.schema_strict, lz.dedupe,
        lz.created_at, lz.updated_at,
        COALESCE(COUNT(lf.id), 0)::bigint AS file_count,
        COALESCE(SUM(lf.size_bytes), 0)::bigint AS total_bytes
 FROM landing_zones lz
 LEFT JOIN landing_files lf ON lf.zone_id = lz.id
-WHERE lz.namespace = $1 AND lz.name = $2
+WHERE lz.namespace = $1 AND lz.name = $2 AND lz.deleted_at IS NULL
 GROUP BY lz.id
 `
 
@@ -166,6 +230,8 @@ type GetLandingZoneRow struct {
 	Description    string
 	Owner          pgtype.Text
 	ExpectedSchema string
+	SchemaStrict   bool
+	Dedupe         bool
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	FileCount      int64
@@ -182,6 +248,8 @@ func (q *Queries) GetLandingZone(ctx context.Context, arg GetLandingZoneParams)
 		&i.Description,
 		&i.Owner,
 		&i.ExpectedSchema,
+		&i.SchemaStrict,
+		&i.Dedupe,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.FileCount,
@@ -193,7 +261,7 @@ func (q *Queries) GetLandingZone(ctx context.Context, arg GetLandingZoneParams)
 const getLandingZoneByID = `-- name: GetLandingZoneByID :one
 SELECT id, namespace, name, description, owner, expected_schema, created_at, updated_at
 FROM landing_zones
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 `
 
 type GetLandingZoneByIDRow struct {
@@ -224,7 +292,7 @@ func (q *Queries) GetLandingZoneByID(ctx context.Context, id uuid.UUID) (GetLand
 }
 
 const listLandingFiles = `-- name: ListLandingFiles :many
-SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
 FROM landing_files
 WHERE zone_id = $1
 ORDER BY uploaded_at DESC
@@ -248,6 +316,7 @@ func (q *Queries) ListLandingFiles(ctx context.Context, zoneID uuid.UUID) ([]Lan
 			&i.ContentType,
 			&i.UploadedBy,
 			&i.UploadedAt,
+			&i.ChecksumSha256,
 		); err != nil {
 			return nil, err
 		}
@@ -260,13 +329,14 @@ func (q *Queries) ListLandingFiles(ctx context.Context, zoneID uuid.UUID) ([]Lan
 }
 
 const listLandingZones = `-- name: ListLandingZones :many
-SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema,
+SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema, lz.schema_strict, lz.dedupe,
        lz.created_at, lz.updated_at,
        COALESCE(COUNT(lf.id), 0)::bigint AS file_count,
        COALESCE(SUM(lf.size_bytes), 0)::bigint AS total_bytes
 FROM landing_zones lz
 LEFT JOIN landing_files lf ON lf.zone_id = lz.id
-WHERE ($1::text IS NULL OR lz.namespace = $1)
+WHERE lz.deleted_at IS NULL
+  AND ($1::text IS NULL OR lz.namespace = $1)
 GROUP BY lz.id
 ORDER BY lz.created_at DESC
 `
@@ -278,6 +348,8 @@ type ListLandingZonesRow struct {
 	Description    string
 	Owner          pgtype.Text
 	ExpectedSchema string
+	SchemaStrict   bool
+	Dedupe         bool
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	FileCount      int64
@@ -300,6 +372,9 @@ func (q *Queries) ListLandingZones(ctx context.Context, filterNamespace pgtype.T
 			&i.Description,
 			&i.Owner,
 			&i.ExpectedSchema,
+			&i.SchemaStrict,
+			&i.Dedupe,
+			&i.Dedupe,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.FileCount,
@@ -321,8 +396,8 @@ SET description = COALESCE($3, description),
     owner = COALESCE($4, owner),
     expected_schema = COALESCE($5, expected_schema),
     updated_at = NOW()
-WHERE namespace = $1 AND name = $2
-RETURNING id, namespace, name, description, owner, expected_schema, created_at, updated_at
+WHERE namespace = $1 AND name = $2 AND deleted_at IS NULL
+RETURNING id, namespace, name, description, owner, expected_schema, schema_strict, dedupe, created_at, updated_at
 `
 
 type UpdateLandingZoneParams struct {
@@ -340,6 +415,8 @@ type UpdateLandingZoneRow struct {
 	Description    string
 	Owner          pgtype.Text
 	ExpectedSchema string
+	SchemaStrict   bool
+	Dedupe         bool
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -360,6 +437,8 @@ func (q *Queries) UpdateLandingZone(ctx context.Context, arg UpdateLandingZonePa
 		&i.Description,
 		&i.Owner,
 		&i.ExpectedSchema,
+		&i.SchemaStrict,
+		&i.Dedupe,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
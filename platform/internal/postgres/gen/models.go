@@ -22,14 +22,15 @@ type AuditLog struct {
 }
 
 type LandingFile struct {
-	ID          uuid.UUID
-	ZoneID      uuid.UUID
-	Filename    string
-	S3Path      string
-	SizeBytes   int64
-	ContentType string
-	UploadedBy  pgtype.Text
-	UploadedAt  time.Time
+	ID             uuid.UUID
+	ZoneID         uuid.UUID
+	Filename       string
+	S3Path         string
+	SizeBytes      int64
+	ContentType    string
+	UploadedBy     pgtype.Text
+	UploadedAt     time.Time
+	ChecksumSha256 string
 }
 
 type LandingZone struct {
@@ -43,6 +44,9 @@ type LandingZone struct {
 	ExpectedSchema      string
 	ProcessedMaxAgeDays pgtype.Int4
 	AutoPurge           bool
+	DeletedAt           *time.Time
+	SchemaStrict        bool
+	Dedupe              bool
 }
 
 type Namespace struct {
@@ -70,6 +74,8 @@ type Pipeline struct {
 	DraftDirty        bool
 	MaxVersions       int32
 	RetentionConfig   []byte
+	Labels            []byte
+	Priority          int32
 }
 
 type PipelineTrigger struct {
@@ -83,6 +89,7 @@ type PipelineTrigger struct {
 	LastRunID       pgtype.UUID
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	BackoffStreak   int32
 }
 
 type PipelineVersion struct {
@@ -167,6 +174,7 @@ type ReaperStatus struct {
 	LzFilesCleaned  int32
 	AuditPruned     int32
 	UpdatedAt       time.Time
+	ZonesPurged     int32
 }
 
 type Run struct {
@@ -183,6 +191,9 @@ type Run struct {
 	CreatedAt     time.Time
 	Logs          []byte
 	PhaseProfiles []byte
+	Metadata      []byte
+	ParentRunID   pgtype.UUID
+	Priority      int32
 }
 
 type Schedule struct {
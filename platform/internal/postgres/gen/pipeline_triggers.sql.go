@@ -17,7 +17,7 @@ const createPipelineTrigger = `-- name: CreatePipelineTrigger :one
 INSERT INTO pipeline_triggers (pipeline_id, type, config, enabled, cooldown_seconds)
 VALUES ($1, $2, $3, $4, $5)
 RETURNING id, pipeline_id, type, config, enabled, cooldown_seconds,
-          last_triggered_at, last_run_id, created_at, updated_at
+          last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 `
 
 type CreatePipelineTriggerParams struct {
@@ -48,6 +48,7 @@ func (q *Queries) CreatePipelineTrigger(ctx context.Context, arg CreatePipelineT
 		&i.LastRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackoffStreak,
 	)
 	return i, err
 }
@@ -64,7 +65,7 @@ func (q *Queries) DeletePipelineTrigger(ctx context.Context, id uuid.UUID) error
 
 const findTriggerByWebhookToken = `-- name: FindTriggerByWebhookToken :one
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'webhook' AND enabled = true
   AND config->>'token_hash' = $1::text
@@ -84,13 +85,14 @@ func (q *Queries) FindTriggerByWebhookToken(ctx context.Context, token string) (
 		&i.LastRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackoffStreak,
 	)
 	return i, err
 }
 
 const findTriggersByFilePattern = `-- name: FindTriggersByFilePattern :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'file_pattern' AND enabled = true
   AND config->>'namespace' = $1::text
@@ -122,6 +124,7 @@ func (q *Queries) FindTriggersByFilePattern(ctx context.Context, arg FindTrigger
 			&i.LastRunID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BackoffStreak,
 		); err != nil {
 			return nil, err
 		}
@@ -135,7 +138,7 @@ func (q *Queries) FindTriggersByFilePattern(ctx context.Context, arg FindTrigger
 
 const findTriggersByLandingZone = `-- name: FindTriggersByLandingZone :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'landing_zone_upload'
   AND enabled = true
@@ -162,6 +165,7 @@ func (q *Queries) FindTriggersByLandingZone(ctx context.Context, dollar_1 []byte
 			&i.LastRunID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BackoffStreak,
 		); err != nil {
 			return nil, err
 		}
@@ -175,7 +179,7 @@ func (q *Queries) FindTriggersByLandingZone(ctx context.Context, dollar_1 []byte
 
 const findTriggersByPipelineSuccess = `-- name: FindTriggersByPipelineSuccess :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'pipeline_success' AND enabled = true
   AND config->>'namespace' = $1::text
@@ -209,6 +213,7 @@ func (q *Queries) FindTriggersByPipelineSuccess(ctx context.Context, arg FindTri
 			&i.LastRunID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BackoffStreak,
 		); err != nil {
 			return nil, err
 		}
@@ -222,7 +227,7 @@ func (q *Queries) FindTriggersByPipelineSuccess(ctx context.Context, arg FindTri
 
 const findTriggersByType = `-- name: FindTriggersByType :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = $1 AND enabled = true
 `
@@ -247,6 +252,7 @@ func (q *Queries) FindTriggersByType(ctx context.Context, type_ string) ([]Pipel
 			&i.LastRunID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BackoffStreak,
 		); err != nil {
 			return nil, err
 		}
@@ -260,7 +266,7 @@ func (q *Queries) FindTriggersByType(ctx context.Context, type_ string) ([]Pipel
 
 const getPipelineTrigger = `-- name: GetPipelineTrigger :one
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE id = $1
 `
@@ -279,13 +285,14 @@ func (q *Queries) GetPipelineTrigger(ctx context.Context, id uuid.UUID) (Pipelin
 		&i.LastRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackoffStreak,
 	)
 	return i, err
 }
 
 const listPipelineTriggers = `-- name: ListPipelineTriggers :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE pipeline_id = $1
 ORDER BY created_at DESC
@@ -311,6 +318,7 @@ func (q *Queries) ListPipelineTriggers(ctx context.Context, pipelineID uuid.UUID
 			&i.LastRunID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.BackoffStreak,
 		); err != nil {
 			return nil, err
 		}
@@ -322,6 +330,28 @@ func (q *Queries) ListPipelineTriggers(ctx context.Context, pipelineID uuid.UUID
 	return items, nil
 }
 
+const setTriggersEnabledByPipeline = `-- name: SetTriggersEnabledByPipeline :execrows
+UPDATE pipeline_triggers
+SET enabled = $2,
+    updated_at = now()
+WHERE pipeline_id = $1 AND enabled <> $2
+`
+
+type SetTriggersEnabledByPipelineParams struct {
+	PipelineID uuid.UUID
+	Enabled    bool
+}
+
+// Flips enabled on every trigger of a pipeline. Only rows whose state
+// actually changes are touched, so the affected count is the number flipped.
+func (q *Queries) SetTriggersEnabledByPipeline(ctx context.Context, arg SetTriggersEnabledByPipelineParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setTriggersEnabledByPipeline, arg.PipelineID, arg.Enabled)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updatePipelineTrigger = `-- name: UpdatePipelineTrigger :one
 UPDATE pipeline_triggers
 SET config = COALESCE($2, config),
@@ -330,7 +360,7 @@ SET config = COALESCE($2, config),
     updated_at = now()
 WHERE id = $1
 RETURNING id, pipeline_id, type, config, enabled, cooldown_seconds,
-          last_triggered_at, last_run_id, created_at, updated_at
+          last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 `
 
 type UpdatePipelineTriggerParams struct {
@@ -359,10 +389,28 @@ func (q *Queries) UpdatePipelineTrigger(ctx context.Context, arg UpdatePipelineT
 		&i.LastRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackoffStreak,
 	)
 	return i, err
 }
 
+const updateTriggerBackoffStreak = `-- name: UpdateTriggerBackoffStreak :exec
+UPDATE pipeline_triggers
+SET backoff_streak = $2,
+    updated_at = now()
+WHERE id = $1
+`
+
+type UpdateTriggerBackoffStreakParams struct {
+	ID            uuid.UUID
+	BackoffStreak int32
+}
+
+func (q *Queries) UpdateTriggerBackoffStreak(ctx context.Context, arg UpdateTriggerBackoffStreakParams) error {
+	_, err := q.db.Exec(ctx, updateTriggerBackoffStreak, arg.ID, arg.BackoffStreak)
+	return err
+}
+
 const updateTriggerFired = `-- name: UpdateTriggerFired :exec
 UPDATE pipeline_triggers
 SET last_triggered_at = now(),
@@ -389,7 +437,7 @@ SET last_triggered_at = $3::timestamptz,
 WHERE id = $1
   AND last_triggered_at IS NOT DISTINCT FROM $4::timestamptz
 RETURNING id, pipeline_id, type, config, enabled, cooldown_seconds,
-          last_triggered_at, last_run_id, created_at, updated_at
+          last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 `
 
 type UpdateTriggerFiredCASParams struct {
@@ -424,6 +472,7 @@ func (q *Queries) UpdateTriggerFiredCAS(ctx context.Context, arg UpdateTriggerFi
 		&i.LastRunID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.BackoffStreak,
 	)
 	return i, err
 }
@@ -14,16 +14,20 @@ import (
 )
 
 const createRun = `-- name: CreateRun :one
-INSERT INTO runs (pipeline_id, status, trigger)
-VALUES ($1, $2, $3)
+INSERT INTO runs (pipeline_id, status, trigger, metadata, parent_run_id, priority)
+VALUES ($1, $2, $3, COALESCE($4::jsonb, '{}'), $5,
+        COALESCE((SELECT priority FROM pipelines WHERE id = $1), 0))
 RETURNING id, pipeline_id, status, trigger, started_at, finished_at,
-          duration_ms, rows_written, error, logs_s3_path, created_at
+          duration_ms, rows_written, error, logs_s3_path, created_at, metadata,
+          parent_run_id, priority
 `
 
 type CreateRunParams struct {
-	PipelineID uuid.UUID
-	Status     string
-	Trigger    string
+	PipelineID  uuid.UUID
+	Status      string
+	Trigger     string
+	Metadata    []byte
+	ParentRunID pgtype.UUID
 }
 
 type CreateRunRow struct {
@@ -38,10 +42,19 @@ type CreateRunRow struct {
 	Error       pgtype.Text
 	LogsS3Path  pgtype.Text
 	CreatedAt   time.Time
+	Metadata    []byte
+	ParentRunID pgtype.UUID
+	Priority    int32
 }
 
 func (q *Queries) CreateRun(ctx context.Context, arg CreateRunParams) (CreateRunRow, error) {
-	row := q.db.QueryRow(ctx, createRun, arg.PipelineID, arg.Status, arg.Trigger)
+	row := q.db.QueryRow(ctx, createRun,
+		arg.PipelineID,
+		arg.Status,
+		arg.Trigger,
+		arg.Metadata,
+		arg.ParentRunID,
+	)
 	var i CreateRunRow
 	err := row.Scan(
 		&i.ID,
@@ -55,29 +68,37 @@ func (q *Queries) CreateRun(ctx context.Context, arg CreateRunParams) (CreateRun
 		&i.Error,
 		&i.LogsS3Path,
 		&i.CreatedAt,
+		&i.Metadata,
+		&i.ParentRunID,
+		&i.Priority,
 	)
 	return i, err
 }
 
 const getRun = `-- name: GetRun :one
 SELECT id, pipeline_id, status, trigger, started_at, finished_at,
-       duration_ms, rows_written, error, logs_s3_path, created_at
+       duration_ms, rows_written, error, logs_s3_path, created_at, metadata,
+       parent_run_id, priority, phase_profiles
 FROM runs
 WHERE id = $1
 `
 
 type GetRunRow struct {
-	ID          uuid.UUID
-	PipelineID  uuid.UUID
-	Status      string
-	Trigger     string
-	StartedAt   *time.Time
-	FinishedAt  *time.Time
-	DurationMs  pgtype.Int4
-	RowsWritten pgtype.Int8
-	Error       pgtype.Text
-	LogsS3Path  pgtype.Text
-	CreatedAt   time.Time
+	ID            uuid.UUID
+	PipelineID    uuid.UUID
+	Status        string
+	Trigger       string
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	DurationMs    pgtype.Int4
+	RowsWritten   pgtype.Int8
+	Error         pgtype.Text
+	LogsS3Path    pgtype.Text
+	CreatedAt     time.Time
+	Metadata      []byte
+	ParentRunID   pgtype.UUID
+	Priority      int32
+	PhaseProfiles []byte
 }
 
 func (q *Queries) GetRun(ctx context.Context, id uuid.UUID) (GetRunRow, error) {
@@ -95,6 +116,10 @@ func (q *Queries) GetRun(ctx context.Context, id uuid.UUID) (GetRunRow, error) {
 		&i.Error,
 		&i.LogsS3Path,
 		&i.CreatedAt,
+		&i.Metadata,
+		&i.ParentRunID,
+		&i.Priority,
+		&i.PhaseProfiles,
 	)
 	return i, err
 }
@@ -194,6 +219,20 @@ func (q *Queries) SaveRunLogs(ctx context.Context, arg SaveRunLogsParams) error
 	return err
 }
 
+const saveRunPhases = `-- name: SaveRunPhases :exec
+UPDATE runs SET phase_profiles = $1 WHERE id = $2
+`
+
+type SaveRunPhasesParams struct {
+	PhaseProfiles []byte
+	ID            uuid.UUID
+}
+
+func (q *Queries) SaveRunPhases(ctx context.Context, arg SaveRunPhasesParams) error {
+	_, err := q.db.Exec(ctx, saveRunPhases, arg.PhaseProfiles, arg.ID)
+	return err
+}
+
 const updateRunStatus = `-- name: UpdateRunStatus :exec
 UPDATE runs
 SET status = $1::varchar(20),
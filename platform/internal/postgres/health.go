@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rat-data/rat/platform/internal/api"
 )
 
 // HealthChecker implements api.HealthChecker for Postgres.
@@ -25,3 +26,16 @@ func (h *HealthChecker) HealthCheck(ctx context.Context) error {
 	}
 	return nil
 }
+
+// PoolStats snapshots the pool's pgxpool.Stat for the rat_pg_pool_* metrics.
+func (h *HealthChecker) PoolStats() api.PoolStats {
+	st := h.pool.Stat()
+	return api.PoolStats{
+		Acquired:     st.AcquiredConns(),
+		Idle:         st.IdleConns(),
+		Total:        st.TotalConns(),
+		Max:          st.MaxConns(),
+		WaitCount:    st.EmptyAcquireCount(),
+		WaitDuration: st.EmptyAcquireWaitTime(),
+	}
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -35,6 +36,15 @@ func boolPtrToNullable(b *bool) pgtype.Bool {
 	return pgtype.Bool{Bool: *b, Valid: true}
 }
 
+// uuidPtrToNullable converts a *uuid.UUID to pgtype.UUID.
+// nil → NULL, non-nil → valid UUID.
+func uuidPtrToNullable(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *id, Valid: true}
+}
+
 // nullableTextToString converts pgtype.Text to a Go string.
 func nullableTextToString(t pgtype.Text) string {
 	if t.Valid {
@@ -51,28 +61,40 @@ func nullableTextToPtr(t pgtype.Text) *string {
 	return nil
 }
 
+// likeEscaper escapes LIKE/ILIKE metacharacters so user input matches literally
+// (Postgres' default escape character is backslash).
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike returns s with LIKE wildcards escaped.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 // pipelineRowToDomain maps a full pipeline row (including versioning columns) to domain.Pipeline.
 func pipelineRowToDomain(
 	id uuid.UUID, namespace, layer, name, typ, s3Path string,
 	description, owner pgtype.Text,
 	publishedAt *time.Time, publishedVersions []byte, draftDirty bool,
-	maxVersions int,
+	maxVersions int, labels []byte,
 	createdAt, updatedAt time.Time,
+	retentionConfig []byte, priority int, validation []byte, draftRevision int64,
 ) domain.Pipeline {
 	p := domain.Pipeline{
-		ID:          id,
-		Namespace:   namespace,
-		Layer:       domain.Layer(layer),
-		Name:        name,
-		Type:        typ,
-		S3Path:      s3Path,
-		Description: nullableTextToString(description),
-		Owner:       nullableTextToPtr(owner),
-		PublishedAt: publishedAt,
-		DraftDirty:  draftDirty,
-		MaxVersions: maxVersions,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+		ID:            id,
+		Namespace:     namespace,
+		Layer:         domain.Layer(layer),
+		Name:          name,
+		Type:          typ,
+		S3Path:        s3Path,
+		Description:   nullableTextToString(description),
+		Owner:         nullableTextToPtr(owner),
+		PublishedAt:   publishedAt,
+		DraftDirty:    draftDirty,
+		DraftRevision: draftRevision,
+		MaxVersions:   maxVersions,
+		Priority:      priority,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
 	}
 
 	if len(publishedVersions) > 0 {
@@ -82,5 +104,34 @@ func pipelineRowToDomain(
 		}
 	}
 
+	if len(labels) > 0 {
+		var l map[string]string
+		if err := json.Unmarshal(labels, &l); err == nil && len(l) > 0 {
+			p.Labels = l
+		}
+	}
+
+	if len(retentionConfig) > 0 && string(retentionConfig) != "null" {
+		p.RetentionConfig = retentionConfig
+	}
+
+	if len(validation) > 0 && string(validation) != "null" {
+		var v domain.ValidationResult
+		if err := json.Unmarshal(validation, &v); err == nil {
+			p.Validation = &v
+		}
+	}
+
 	return p
 }
+
+// stringMapToJSONB encodes a string map (pipeline labels, run metadata) for a
+// jsonb column. nil → nil, which callers turn into "leave unchanged" or the
+// column default via COALESCE.
+func stringMapToJSONB(m map[string]string) []byte {
+	if m == nil {
+		return nil
+	}
+	b, _ := json.Marshal(m)
+	return b
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
@@ -15,13 +17,14 @@ import (
 
 // LandingZoneStore implements api.LandingZoneStore backed by Postgres.
 type LandingZoneStore struct {
-	pool *pgxpool.Pool
-	q    *gen.Queries
+	db *timeoutDB
+	q  *gen.Queries
 }
 
 // NewLandingZoneStore creates a LandingZoneStore backed by the given pool.
 func NewLandingZoneStore(pool *pgxpool.Pool) *LandingZoneStore {
-	return &LandingZoneStore{pool: pool, q: gen.New(pool)}
+	db := newTimeoutDB(pool)
+	return &LandingZoneStore{db: db, q: gen.New(db)}
 }
 
 func (s *LandingZoneStore) ListZones(ctx context.Context, filter api.LandingZoneFilter) ([]api.LandingZoneListItem, error) {
@@ -40,6 +43,8 @@ func (s *LandingZoneStore) ListZones(ctx context.Context, filter api.LandingZone
 				Description:    r.Description,
 				Owner:          nullableTextToPtr(r.Owner),
 				ExpectedSchema: r.ExpectedSchema,
+				SchemaStrict:   r.SchemaStrict,
+				Dedupe:         r.Dedupe,
 				CreatedAt:      r.CreatedAt,
 				UpdatedAt:      r.UpdatedAt,
 			},
@@ -70,6 +75,8 @@ func (s *LandingZoneStore) GetZone(ctx context.Context, namespace, name string)
 			Description:    row.Description,
 			Owner:          nullableTextToPtr(row.Owner),
 			ExpectedSchema: row.ExpectedSchema,
+			SchemaStrict:   row.SchemaStrict,
+			Dedupe:         row.Dedupe,
 			CreatedAt:      row.CreatedAt,
 			UpdatedAt:      row.UpdatedAt,
 		},
@@ -125,6 +132,8 @@ func (s *LandingZoneStore) UpdateZone(ctx context.Context, namespace, name strin
 		Description:    row.Description,
 		Owner:          nullableTextToPtr(row.Owner),
 		ExpectedSchema: row.ExpectedSchema,
+		SchemaStrict:   row.SchemaStrict,
+		Dedupe:         row.Dedupe,
 		CreatedAt:      row.CreatedAt,
 		UpdatedAt:      row.UpdatedAt,
 	}, nil
@@ -147,6 +156,7 @@ func (s *LandingZoneStore) ListFiles(ctx context.Context, zoneID uuid.UUID) ([]d
 			ContentType: r.ContentType,
 			UploadedBy:  nullableTextToPtr(r.UploadedBy),
 			UploadedAt:  r.UploadedAt,
+			Checksum:    r.ChecksumSha256,
 		}
 	}
 	return result, nil
@@ -154,12 +164,13 @@ func (s *LandingZoneStore) ListFiles(ctx context.Context, zoneID uuid.UUID) ([]d
 
 func (s *LandingZoneStore) CreateFile(ctx context.Context, f *domain.LandingFile) error {
 	row, err := s.q.CreateLandingFile(ctx, gen.CreateLandingFileParams{
-		ZoneID:      f.ZoneID,
-		Filename:    f.Filename,
-		S3Path:      f.S3Path,
-		SizeBytes:   f.SizeBytes,
-		ContentType: f.ContentType,
-		UploadedBy:  textPtrToNullable(f.UploadedBy),
+		ZoneID:         f.ZoneID,
+		Filename:       f.Filename,
+		S3Path:         f.S3Path,
+		SizeBytes:      f.SizeBytes,
+		ContentType:    f.ContentType,
+		UploadedBy:     textPtrToNullable(f.UploadedBy),
+		ChecksumSha256: f.Checksum,
 	})
 	if err != nil {
 		return fmt.Errorf("create landing file: %w", err)
@@ -188,6 +199,62 @@ func (s *LandingZoneStore) GetFile(ctx context.Context, fileID uuid.UUID) (*doma
 		ContentType: row.ContentType,
 		UploadedBy:  nullableTextToPtr(row.UploadedBy),
 		UploadedAt:  row.UploadedAt,
+		Checksum:    row.ChecksumSha256,
+	}, nil
+}
+
+// FindFileByChecksum returns the most recent pending file in the zone with
+// the given content checksum, or nil if there is none. Processed files have
+// their records removed, so only unprocessed uploads can match.
+func (s *LandingZoneStore) FindFileByChecksum(ctx context.Context, zoneID uuid.UUID, checksum string) (*domain.LandingFile, error) {
+	row, err := s.q.FindLandingFileByChecksum(ctx, gen.FindLandingFileByChecksumParams{
+		ZoneID:         zoneID,
+		ChecksumSha256: checksum,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find landing file by checksum: %w", err)
+	}
+
+	return &domain.LandingFile{
+		ID:          row.ID,
+		ZoneID:      row.ZoneID,
+		Filename:    row.Filename,
+		S3Path:      row.S3Path,
+		SizeBytes:   row.SizeBytes,
+		ContentType: row.ContentType,
+		UploadedBy:  nullableTextToPtr(row.UploadedBy),
+		UploadedAt:  row.UploadedAt,
+		Checksum:    row.ChecksumSha256,
+	}, nil
+}
+
+// FindFileByPath returns the file record for an S3 key in the zone, or nil
+// if the object was never registered.
+func (s *LandingZoneStore) FindFileByPath(ctx context.Context, zoneID uuid.UUID, s3Path string) (*domain.LandingFile, error) {
+	row, err := s.q.FindLandingFileByPath(ctx, gen.FindLandingFileByPathParams{
+		ZoneID: zoneID,
+		S3Path: s3Path,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find landing file by path: %w", err)
+	}
+
+	return &domain.LandingFile{
+		ID:          row.ID,
+		ZoneID:      row.ZoneID,
+		Filename:    row.Filename,
+		S3Path:      row.S3Path,
+		SizeBytes:   row.SizeBytes,
+		ContentType: row.ContentType,
+		UploadedBy:  nullableTextToPtr(row.UploadedBy),
+		UploadedAt:  row.UploadedAt,
+		Checksum:    row.ChecksumSha256,
 	}, nil
 }
 
@@ -236,19 +303,50 @@ func (s *LandingZoneStore) UpdateZoneLifecycle(ctx context.Context, zoneID uuid.
 	query += fmt.Sprintf(" WHERE id = $%d", argN)
 	args = append(args, zoneID)
 
-	_, err := s.pool.Exec(ctx, query, args...)
+	_, err := s.db.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("update zone lifecycle: %w", err)
 	}
 	return nil
 }
 
+// UpdateZoneUploadPolicy updates the upload checks for a landing zone. Nil
+// arguments leave the corresponding setting unchanged.
+func (s *LandingZoneStore) UpdateZoneUploadPolicy(ctx context.Context, zoneID uuid.UUID, schemaStrict, dedupe *bool) error {
+	query := `UPDATE landing_zones SET updated_at = NOW()`
+	args := []interface{}{}
+	argN := 1
+
+	if schemaStrict != nil {
+		query += fmt.Sprintf(", schema_strict = $%d", argN)
+		args = append(args, *schemaStrict)
+		argN++
+	}
+	if dedupe != nil {
+		query += fmt.Sprintf(", dedupe = $%d", argN)
+		args = append(args, *dedupe)
+		argN++
+	}
+
+	query += fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL", argN)
+	args = append(args, zoneID)
+
+	tag, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("update zone upload policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("landing zone %s not found", zoneID)
+	}
+	return nil
+}
+
 // ListZonesWithAutoPurge returns all landing zones with auto_purge enabled.
 func (s *LandingZoneStore) ListZonesWithAutoPurge(ctx context.Context) ([]domain.LandingZone, error) {
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		`SELECT id, namespace, name, description, owner, expected_schema,
 		        processed_max_age_days, auto_purge, created_at, updated_at
-		 FROM landing_zones WHERE auto_purge = true`)
+		 FROM landing_zones WHERE auto_purge = true AND deleted_at IS NULL`)
 	if err != nil {
 		return nil, fmt.Errorf("list zones with auto purge: %w", err)
 	}
@@ -275,3 +373,67 @@ func (s *LandingZoneStore) ListZonesWithAutoPurge(ctx context.Context) ([]domain
 	}
 	return result, rows.Err()
 }
+
+// RestoreZone clears deleted_at on the most recently soft-deleted zone with
+// the given namespace and name. Returns nil, nil when there is nothing to
+// restore, and domain.ErrAlreadyExists when a live zone has taken the name.
+func (s *LandingZoneStore) RestoreZone(ctx context.Context, namespace, name string) (*domain.LandingZone, error) {
+	var z domain.LandingZone
+	var owner *string
+	err := s.db.QueryRow(ctx,
+		`UPDATE landing_zones SET deleted_at = NULL, updated_at = NOW()
+		 WHERE id = (
+		     SELECT id FROM landing_zones
+		     WHERE namespace = $1 AND name = $2 AND deleted_at IS NOT NULL
+		     ORDER BY deleted_at DESC LIMIT 1
+		 )
+		 RETURNING id, namespace, name, description, owner, expected_schema, created_at, updated_at`,
+		namespace, name,
+	).Scan(&z.ID, &z.Namespace, &z.Name, &z.Description, &owner, &z.ExpectedSchema, &z.CreatedAt, &z.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("landing zone %s/%s: %w", namespace, name, domain.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("restore landing zone: %w", err)
+	}
+	z.Owner = owner
+	return &z, nil
+}
+
+// ListSoftDeletedZones returns landing zones that were soft-deleted before the given time.
+func (s *LandingZoneStore) ListSoftDeletedZones(ctx context.Context, olderThan time.Time) ([]domain.LandingZone, error) {
+	rows, err := s.db.Query(ctx,
+		`SELECT id, namespace, name, description, owner, expected_schema, created_at, updated_at, deleted_at
+		 FROM landing_zones WHERE deleted_at IS NOT NULL AND deleted_at < $1`,
+		olderThan,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list soft-deleted landing zones: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.LandingZone
+	for rows.Next() {
+		var z domain.LandingZone
+		if err := rows.Scan(&z.ID, &z.Namespace, &z.Name, &z.Description, &z.Owner,
+			&z.ExpectedSchema, &z.CreatedAt, &z.UpdatedAt, &z.DeletedAt); err != nil {
+			return nil, fmt.Errorf("scan soft-deleted landing zone: %w", err)
+		}
+		result = append(result, z)
+	}
+	return result, rows.Err()
+}
+
+// HardDeleteZone permanently removes a landing zone row and, via cascade, its
+// file rows (after soft-delete purge period).
+func (s *LandingZoneStore) HardDeleteZone(ctx context.Context, zoneID uuid.UUID) error {
+	_, err := s.db.Exec(ctx, `DELETE FROM landing_zones WHERE id = $1`, zoneID)
+	if err != nil {
+		return fmt.Errorf("hard delete landing zone: %w", err)
+	}
+	return nil
+}
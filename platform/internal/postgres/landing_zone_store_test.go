@@ -3,6 +3,7 @@ package postgres_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
@@ -167,10 +168,101 @@ func TestLandingZoneStore_CascadeDelete(t *testing.T) {
 	}
 	require.NoError(t, store.CreateFile(ctx, f))
 
-	// Delete zone — should cascade delete files
+	// Soft delete keeps the file rows so the zone can be restored.
 	require.NoError(t, store.DeleteZone(ctx, "default", "cascade-test"))
-
 	got, err := store.GetFile(ctx, f.ID)
 	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	// Hard delete — should cascade delete files
+	require.NoError(t, store.HardDeleteZone(ctx, z.ID))
+
+	got, err = store.GetFile(ctx, f.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestLandingZoneStore_SoftDeleteAndRestore(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewLandingZoneStore(pool)
+	ctx := context.Background()
+
+	z := &domain.LandingZone{Namespace: "default", Name: "restorable"}
+	require.NoError(t, store.CreateZone(ctx, z))
+	require.NoError(t, store.DeleteZone(ctx, "default", "restorable"))
+
+	zones, err := store.ListZones(ctx, api.LandingZoneFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, zones)
+
+	deleted, err := store.ListSoftDeletedZones(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, z.ID, deleted[0].ID)
+	assert.NotNil(t, deleted[0].DeletedAt)
+
+	restored, err := store.RestoreZone(ctx, "default", "restorable")
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	assert.Equal(t, z.ID, restored.ID)
+
+	got, err := store.GetZone(ctx, "default", "restorable")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+
+	again, err := store.RestoreZone(ctx, "default", "restorable")
+	require.NoError(t, err)
+	assert.Nil(t, again, "nothing left to restore")
+}
+
+func TestLandingZoneStore_RestoreZone_NameTaken_ReturnsAlreadyExists(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewLandingZoneStore(pool)
+	ctx := context.Background()
+
+	require.NoError(t, store.CreateZone(ctx, &domain.LandingZone{Namespace: "default", Name: "reused"}))
+	require.NoError(t, store.DeleteZone(ctx, "default", "reused"))
+	// The partial unique index lets the name be reused while the old zone is in the trash.
+	require.NoError(t, store.CreateZone(ctx, &domain.LandingZone{Namespace: "default", Name: "reused"}))
+
+	_, err := store.RestoreZone(ctx, "default", "reused")
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
+func TestLandingZoneStore_FindFileByChecksumAndUploadPolicy(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewLandingZoneStore(pool)
+	ctx := context.Background()
+
+	z := &domain.LandingZone{Namespace: "default", Name: "dedupe"}
+	require.NoError(t, store.CreateZone(ctx, z))
+
+	f := &domain.LandingFile{ZoneID: z.ID, Filename: "a.csv", S3Path: "default/landing/dedupe/a.csv", Checksum: "abc123"}
+	require.NoError(t, store.CreateFile(ctx, f))
+
+	got, err := store.FindFileByChecksum(ctx, z.ID, "abc123")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, f.ID, got.ID)
+	assert.Equal(t, "abc123", got.Checksum)
+
+	got, err = store.FindFileByChecksum(ctx, z.ID, "other")
+	require.NoError(t, err)
 	assert.Nil(t, got)
+
+	got, err = store.FindFileByPath(ctx, z.ID, "default/landing/dedupe/a.csv")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, f.ID, got.ID)
+
+	got, err = store.FindFileByPath(ctx, z.ID, "default/landing/dedupe/b.csv")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	dedupe, strict := true, false
+	require.NoError(t, store.UpdateZoneUploadPolicy(ctx, z.ID, &strict, &dedupe))
+	zone, err := store.GetZone(ctx, "default", "dedupe")
+	require.NoError(t, err)
+	assert.True(t, zone.Dedupe)
+	assert.False(t, zone.SchemaStrict)
 }
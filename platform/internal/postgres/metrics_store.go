@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rat-data/rat/platform/internal/api"
+)
+
+// MetricsStore implements api.PlatformCounter backed by Postgres.
+type MetricsStore struct {
+	db *timeoutDB
+}
+
+// NewMetricsStore creates a MetricsStore backed by the given pool.
+func NewMetricsStore(pool *pgxpool.Pool) *MetricsStore {
+	return &MetricsStore{db: newTimeoutDB(pool)}
+}
+
+// runTriggerSourceExpr buckets runs.trigger labels into a low-cardinality
+// source: the first segment ("manual", "schedule", "backfill", "retry"), or
+// "trigger:<type>" for runs fired by a pipeline trigger. The rest of the label
+// (cron expression, zone, token hash) would explode the metric's label set.
+const runTriggerSourceExpr = `CASE WHEN trigger LIKE 'trigger:%'
+	THEN 'trigger:' || split_part(trigger, ':', 2)
+	ELSE split_part(trigger, ':', 1) END`
+
+// PlatformCounts counts runs by status and trigger source, and the schedules
+// and triggers of live (not soft-deleted) pipelines by enabled flag.
+func (s *MetricsStore) PlatformCounts(ctx context.Context) (*api.PlatformCounts, error) {
+	ctx = heavyQuery(ctx)
+	counts := &api.PlatformCounts{
+		RunsByStatus:   map[string]int{},
+		RunsByTrigger:  map[string]int{},
+		TriggersByType: map[string]api.EnabledCount{},
+	}
+
+	if err := s.countGrouped(ctx, `SELECT status, COUNT(*) FROM runs GROUP BY status`, counts.RunsByStatus); err != nil {
+		return nil, fmt.Errorf("count runs by status: %w", err)
+	}
+	if err := s.countGrouped(ctx,
+		`SELECT `+runTriggerSourceExpr+` AS source, COUNT(*) FROM runs GROUP BY source`,
+		counts.RunsByTrigger); err != nil {
+		return nil, fmt.Errorf("count runs by trigger: %w", err)
+	}
+
+	err := s.db.QueryRow(ctx,
+		`SELECT COUNT(*) FILTER (WHERE s.enabled), COUNT(*) FILTER (WHERE NOT s.enabled)
+		 FROM schedules s JOIN pipelines p ON s.pipeline_id = p.id
+		 WHERE p.deleted_at IS NULL`).Scan(&counts.Schedules.Enabled, &counts.Schedules.Disabled)
+	if err != nil {
+		return nil, fmt.Errorf("count schedules: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		`SELECT t.type, COUNT(*) FILTER (WHERE t.enabled), COUNT(*) FILTER (WHERE NOT t.enabled)
+		 FROM pipeline_triggers t JOIN pipelines p ON t.pipeline_id = p.id
+		 WHERE p.deleted_at IS NULL
+		 GROUP BY t.type`)
+	if err != nil {
+		return nil, fmt.Errorf("count triggers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var typ string
+		var c api.EnabledCount
+		if err := rows.Scan(&typ, &c.Enabled, &c.Disabled); err != nil {
+			return nil, fmt.Errorf("scan trigger counts: %w", err)
+		}
+		counts.TriggersByType[typ] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate trigger counts: %w", err)
+	}
+	return counts, nil
+}
+
+// countGrouped runs a "SELECT key, COUNT(*) ... GROUP BY key" query into out.
+func (s *MetricsStore) countGrouped(ctx context.Context, query string, out map[string]int) error {
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		out[key] = count
+	}
+	return rows.Err()
+}
@@ -0,0 +1,43 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsStore_PlatformCounts_GroupsRunsSchedulesAndTriggers(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	sStore := postgres.NewScheduleStore(pool)
+	tStore := postgres.NewTriggerStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "orders")
+	for _, run := range []*domain.Run{
+		{PipelineID: pipeline.ID, Status: domain.RunStatusSuccess, Trigger: "schedule:0 * * * *"},
+		{PipelineID: pipeline.ID, Status: domain.RunStatusSuccess, Trigger: "trigger:webhook:abc"},
+		{PipelineID: pipeline.ID, Status: domain.RunStatusFailed, Trigger: "trigger:webhook:def"},
+		{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "manual"},
+	} {
+		require.NoError(t, rStore.CreateRun(ctx, run))
+	}
+	require.NoError(t, sStore.CreateSchedule(ctx, &domain.Schedule{PipelineID: pipeline.ID, CronExpr: "0 * * * *", Enabled: true}))
+	require.NoError(t, sStore.CreateSchedule(ctx, &domain.Schedule{PipelineID: pipeline.ID, CronExpr: "*/5 * * * *", Enabled: false}))
+	createTestTrigger(t, tStore, pipeline.ID, domain.TriggerTypeWebhook, json.RawMessage(`{}`))
+
+	counts, err := postgres.NewMetricsStore(pool).PlatformCounts(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"success": 2, "failed": 1, "pending": 1}, counts.RunsByStatus)
+	assert.Equal(t, map[string]int{"schedule": 1, "trigger:webhook": 2, "manual": 1}, counts.RunsByTrigger)
+	assert.Equal(t, api.EnabledCount{Enabled: 1, Disabled: 1}, counts.Schedules)
+	assert.Equal(t, map[string]api.EnabledCount{"webhook": {Enabled: 1}}, counts.TriggersByType)
+}
@@ -0,0 +1,5 @@
+-- 019_runs_keyset_index.sql
+-- Supports cursor pagination on GET /api/v1/runs: ListRuns orders by
+-- (created_at DESC, id DESC) and seeks with (created_at, id) < (cursor).
+-- The existing idx_runs_pipeline only helps when filtering by pipeline.
+CREATE INDEX IF NOT EXISTS idx_runs_created_at_id ON runs (created_at DESC, id DESC);
@@ -0,0 +1,27 @@
+-- 020_pipeline_search_trgm.sql
+-- Trigram indexes for GET /api/v1/pipelines?search= (ILIKE '%term%' on
+-- name and description). A plain btree can't serve a leading wildcard, so
+-- without these every search is a sequential scan — fine for dozens of
+-- pipelines, noticeable at thousands.
+--
+-- pg_trgm needs CREATE privilege on the database. Managed Postgres offerings
+-- that withhold it still work: search falls back to a sequential scan, and an
+-- operator can run the statements below by hand.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+EXCEPTION WHEN insufficient_privilege THEN
+    RAISE NOTICE 'pg_trgm unavailable (insufficient privilege); pipeline search will not be indexed';
+END
+$$;
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') THEN
+        CREATE INDEX IF NOT EXISTS idx_pipelines_name_trgm
+            ON pipelines USING gin (name gin_trgm_ops);
+        CREATE INDEX IF NOT EXISTS idx_pipelines_description_trgm
+            ON pipelines USING gin (description gin_trgm_ops);
+    END IF;
+END
+$$;
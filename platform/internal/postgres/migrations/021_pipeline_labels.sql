@@ -0,0 +1,7 @@
+-- 021_pipeline_labels.sql
+-- Free-form key/value labels on pipelines (team, domain, sensitivity, …).
+-- Filtered with jsonb containment (labels @> '{"team":"analytics"}'),
+-- which the GIN index serves.
+ALTER TABLE pipelines
+    ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_pipelines_labels ON pipelines USING gin (labels);
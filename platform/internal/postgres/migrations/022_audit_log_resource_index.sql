@@ -0,0 +1,6 @@
+-- 022_audit_log_resource_index.sql
+-- Serves GET /api/v1/audit?resource=<prefix>. text_pattern_ops lets the
+-- planner use the index for LIKE 'prefix%' regardless of the database
+-- collation.
+CREATE INDEX IF NOT EXISTS idx_audit_log_resource
+    ON audit_log (resource text_pattern_ops, created_at DESC);
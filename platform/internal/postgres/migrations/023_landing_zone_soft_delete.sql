@@ -0,0 +1,18 @@
+-- 023_landing_zone_soft_delete.sql
+-- Landing zones are soft-deleted like pipelines: DELETE sets deleted_at and
+-- the reaper hard-deletes (including S3 files) after the purge period.
+-- The unconditional UNIQUE(namespace, name) becomes a partial index over live
+-- rows so a zone name can be reused while its deleted predecessor awaits purge.
+
+ALTER TABLE landing_zones ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+ALTER TABLE landing_zones DROP CONSTRAINT IF EXISTS landing_zones_namespace_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_landing_zones_unique_active
+    ON landing_zones(namespace, name)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_landing_zones_deleted_at
+    ON landing_zones(deleted_at)
+    WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE reaper_status ADD COLUMN IF NOT EXISTS zones_purged INT NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- 024_run_metadata.sql
+-- Free-form string annotations attached when a run is created (e.g. fields
+-- copied from a webhook payload, or the file that fired a landing zone trigger).
+
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,6 @@
+-- 025_run_parent.sql
+-- Links a run to the upstream run whose success fired it (pipeline_success
+-- triggers), so cancellation can cascade down a trigger chain.
+
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS parent_run_id UUID REFERENCES runs(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_runs_parent_run_id ON runs (parent_run_id) WHERE parent_run_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- 026_landing_zone_schema_strict.sql
+-- Opt-in enforcement of expected_schema: when set, CSV/Parquet uploads whose
+-- columns don't match the zone's expected_schema are rejected.
+
+ALTER TABLE landing_zones ADD COLUMN IF NOT EXISTS schema_strict BOOLEAN NOT NULL DEFAULT false;
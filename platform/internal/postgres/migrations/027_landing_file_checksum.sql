@@ -0,0 +1,11 @@
+-- 027_landing_file_checksum.sql
+-- SHA-256 of each landing file's content, and an opt-in per-zone dedupe flag:
+-- when set, re-uploading content that matches a pending (not yet processed)
+-- file returns the existing file instead of storing a copy and firing triggers.
+
+ALTER TABLE landing_files ADD COLUMN IF NOT EXISTS checksum_sha256 VARCHAR(64) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_landing_files_checksum
+    ON landing_files(zone_id, checksum_sha256)
+    WHERE checksum_sha256 <> '';
+
+ALTER TABLE landing_zones ADD COLUMN IF NOT EXISTS dedupe BOOLEAN NOT NULL DEFAULT false;
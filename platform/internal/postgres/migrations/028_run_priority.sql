@@ -0,0 +1,7 @@
+-- 028_run_priority.sql
+-- Dispatch priority. A pipeline's priority is copied onto each run when the
+-- run is created; higher runs are submitted first when ratd has several
+-- ready at once (e.g. a gold SLA pipeline ahead of a bronze backfill).
+
+ALTER TABLE pipelines ADD COLUMN IF NOT EXISTS priority INT NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS priority INT NOT NULL DEFAULT 0;
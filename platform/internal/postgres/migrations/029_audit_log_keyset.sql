@@ -0,0 +1,7 @@
+-- 029_audit_log_keyset.sql
+-- Keyset pagination for audit exports: pages walk (created_at, id) newest
+-- first, so the index needs id as a tiebreaker. Supersedes the created_at
+-- index from 003.
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_id ON audit_log (created_at DESC, id DESC);
+DROP INDEX IF EXISTS idx_audit_log_created;
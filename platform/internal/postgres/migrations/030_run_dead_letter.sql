@@ -0,0 +1,8 @@
+-- 030_run_dead_letter.sql
+-- Dead-lettering for runs that repeatedly fail to submit. submit_attempts
+-- counts retryable submit failures; once it reaches the cap the run is
+-- failed and dead_lettered_at is set, so no dispatcher retries it again.
+
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS submit_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE runs ADD COLUMN IF NOT EXISTS dead_lettered_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_runs_dead_lettered ON runs (dead_lettered_at DESC) WHERE dead_lettered_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- 031_trigger_backoff.sql
+-- Exponential cooldown for flapping triggers. backoff_streak counts the
+-- consecutive rapid fires of a trigger whose config has a backoff; each one
+-- doubles its effective cooldown until a quiet period resets it to 0.
+
+ALTER TABLE pipeline_triggers ADD COLUMN IF NOT EXISTS backoff_streak INT NOT NULL DEFAULT 0;
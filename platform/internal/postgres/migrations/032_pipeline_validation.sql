@@ -0,0 +1,6 @@
+-- 032_pipeline_validation.sql
+-- Latest publish-time template validation result (per-file errors and
+-- warnings), so warnings stay visible after a successful publish.
+-- NULL = never validated.
+
+ALTER TABLE pipelines ADD COLUMN IF NOT EXISTS validation JSONB;
@@ -0,0 +1,7 @@
+-- 033_pipeline_draft_revision.sql
+-- Draft revision counter for optimistic concurrency on draft saves. Every
+-- draft write bumps it; the draft save endpoint rejects a save whose base
+-- revision is no longer current, so concurrent editors can't clobber each
+-- other.
+
+ALTER TABLE pipelines ADD COLUMN IF NOT EXISTS draft_revision BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+-- 034_license_seats.sql
+-- License seats held by users, with when each was last active. Persisting
+-- them means a restart doesn't free every seat and replicas share one count
+-- instead of each admitting up to the limit.
+
+CREATE TABLE IF NOT EXISTS license_seats (
+    user_id      TEXT PRIMARY KEY,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
@@ -2,21 +2,28 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/rat-data/rat/platform/internal/postgres/gen"
 )
 
 // NamespaceStore implements api.NamespaceStore backed by Postgres.
 type NamespaceStore struct {
-	q *gen.Queries
+	pool *pgxpool.Pool
+	q    *gen.Queries
 }
 
 // NewNamespaceStore creates a NamespaceStore backed by the given pool.
 func NewNamespaceStore(pool *pgxpool.Pool) *NamespaceStore {
-	return &NamespaceStore{q: gen.New(pool)}
+	return &NamespaceStore{pool: pool, q: gen.New(newTimeoutDB(pool))}
 }
 
 func (s *NamespaceStore) ListNamespaces(ctx context.Context) ([]domain.Namespace, error) {
@@ -51,3 +58,113 @@ func (s *NamespaceStore) DeleteNamespace(ctx context.Context, name string) error
 func (s *NamespaceStore) UpdateNamespace(ctx context.Context, name, description string) error {
 	return s.q.UpdateNamespace(ctx, gen.UpdateNamespaceParams{Name: name, Description: description})
 }
+
+// renameNamespaceStatements move every row that refers to a namespace by name
+// from $1 to $2. S3 paths stored in rows are rewritten from the "$1/" prefix
+// to "$2/". Run log paths and landing files are matched through their
+// pipeline or zone while those still carry the old name, so they go first.
+var renameNamespaceStatements = []string{
+	`UPDATE runs SET logs_s3_path = $2::text || substr(logs_s3_path, length($1::text) + 1)
+	 WHERE starts_with(logs_s3_path, $1::text || '/')
+	   AND pipeline_id IN (SELECT id FROM pipelines WHERE namespace = $1)`,
+	`UPDATE landing_files SET s3_path = $2::text || substr(s3_path, length($1::text) + 1)
+	 WHERE starts_with(s3_path, $1::text || '/')
+	   AND zone_id IN (SELECT id FROM landing_zones WHERE namespace = $1)`,
+	`UPDATE pipelines SET namespace = $2,
+	        s3_path = CASE WHEN starts_with(s3_path, $1::text || '/')
+	                       THEN $2::text || substr(s3_path, length($1::text) + 1)
+	                       ELSE s3_path END,
+	        updated_at = now()
+	 WHERE namespace = $1`,
+	`UPDATE landing_zones SET namespace = $2, updated_at = now() WHERE namespace = $1`,
+	`UPDATE table_metadata SET namespace = $2, updated_at = now() WHERE namespace = $1`,
+	// landing_zone_upload, file_pattern and pipeline_success triggers name
+	// their source namespace in config.
+	`UPDATE pipeline_triggers SET config = jsonb_set(config, '{namespace}', to_jsonb($2::text)), updated_at = now()
+	 WHERE config->>'namespace' = $1::text`,
+	// cron_dependency triggers list upstreams as "namespace.layer.pipeline".
+	`UPDATE pipeline_triggers SET config = jsonb_set(config, '{dependencies}', (
+	        SELECT jsonb_agg(CASE WHEN jsonb_typeof(dep) = 'string' AND starts_with(dep #>> '{}', $1::text || '.')
+	                              THEN to_jsonb($2::text || substr(dep #>> '{}', length($1::text) + 1))
+	                              ELSE dep END ORDER BY ord)
+	          FROM jsonb_array_elements(config->'dependencies') WITH ORDINALITY AS d(dep, ord))),
+	        updated_at = now()
+	 WHERE type = 'cron_dependency'
+	   AND jsonb_typeof(config->'dependencies') = 'array'
+	   AND EXISTS (SELECT 1 FROM jsonb_array_elements_text(config->'dependencies') AS d(dep)
+	                WHERE starts_with(dep, $1::text || '.'))`,
+}
+
+// RenameNamespace implements api.NamespaceRenamer. The foreign keys on
+// namespaces(name) don't cascade updates, so the new namespace row is
+// inserted first, dependents are moved onto it, and the old row is deleted.
+// The row keeps its id.
+func (s *NamespaceStore) RenameNamespace(ctx context.Context, oldName, newName string, publishedVersions map[uuid.UUID]map[string]string, history []domain.PipelineVersion) error {
+	return InTx(ctx, s.pool, func(tx pgx.Tx) error {
+		var id uuid.UUID
+		err := tx.QueryRow(ctx, `SELECT id FROM namespaces WHERE name = $1 FOR UPDATE`, oldName).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("rename namespace %q: %w", oldName, api.ErrNamespaceNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("lock namespace %q: %w", oldName, err)
+		}
+
+		_, err = tx.Exec(ctx,
+			`INSERT INTO namespaces (name, description, created_by, created_at)
+			 SELECT $2, description, created_by, created_at FROM namespaces WHERE name = $1`,
+			oldName, newName)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("rename namespace to %q: %w", newName, api.ErrNamespaceExists)
+		}
+		if err != nil {
+			return fmt.Errorf("create namespace %q: %w", newName, err)
+		}
+
+		for _, stmt := range renameNamespaceStatements {
+			if _, err := tx.Exec(ctx, stmt, oldName, newName); err != nil {
+				return fmt.Errorf("rename namespace %q: %w", oldName, err)
+			}
+		}
+
+		for _, key := range [][2]string{
+			{api.NamespaceRetentionKey(oldName), api.NamespaceRetentionKey(newName)},
+			{api.NamespaceNotificationsKey(oldName), api.NamespaceNotificationsKey(newName)},
+		} {
+			if _, err := tx.Exec(ctx, `UPDATE platform_settings SET key = $2, updated_at = now() WHERE key = $1`, key[0], key[1]); err != nil {
+				return fmt.Errorf("move setting %q: %w", key[0], err)
+			}
+		}
+
+		for pipelineID, versions := range publishedVersions {
+			data, err := json.Marshal(versions)
+			if err != nil {
+				return fmt.Errorf("marshal published versions: %w", err)
+			}
+			if _, err := tx.Exec(ctx, `UPDATE pipelines SET published_versions = $2 WHERE id = $1`, pipelineID, data); err != nil {
+				return fmt.Errorf("update published versions of pipeline %s: %w", pipelineID, err)
+			}
+		}
+
+		for _, v := range history {
+			data, err := json.Marshal(v.PublishedVersions)
+			if err != nil {
+				return fmt.Errorf("marshal version snapshot: %w", err)
+			}
+			if _, err := tx.Exec(ctx,
+				`UPDATE pipeline_versions SET published_versions = $3 WHERE pipeline_id = $1 AND version_number = $2`,
+				v.PipelineID, v.VersionNumber, data); err != nil {
+				return fmt.Errorf("update version %d of pipeline %s: %w", v.VersionNumber, v.PipelineID, err)
+			}
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM namespaces WHERE name = $1`, oldName); err != nil {
+			return fmt.Errorf("delete namespace %q: %w", oldName, err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE namespaces SET id = $2 WHERE name = $1`, newName, id); err != nil {
+			return fmt.Errorf("restore namespace id: %w", err)
+		}
+		return nil
+	})
+}
@@ -2,8 +2,12 @@ package postgres_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/rat-data/rat/platform/internal/postgres"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -52,3 +56,93 @@ func TestNamespaceStore_DeleteNamespace(t *testing.T) {
 	require.Len(t, namespaces, 1)
 	assert.Equal(t, "default", namespaces[0].Name)
 }
+
+func TestNamespaceStore_RenameNamespace_CascadesToDependents(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewNamespaceStore(pool)
+	pipelines := postgres.NewPipelineStore(pool)
+	zones := postgres.NewLandingZoneStore(pool)
+	triggers := postgres.NewTriggerStore(pool)
+	ctx := context.Background()
+
+	require.NoError(t, store.CreateNamespace(ctx, "sales", nil))
+	p := newTestPipeline("sales", "bronze", "orders")
+	require.NoError(t, pipelines.CreatePipeline(ctx, p))
+	require.NoError(t, zones.CreateZone(ctx, &domain.LandingZone{Namespace: "sales", Name: "uploads"}))
+	trigger := &domain.PipelineTrigger{
+		PipelineID: p.ID,
+		Type:       domain.TriggerType("landing_zone_upload"),
+		Config:     json.RawMessage(`{"namespace":"sales","zone_name":"uploads"}`),
+		Enabled:    true,
+	}
+	require.NoError(t, triggers.CreateTrigger(ctx, trigger))
+	depTrigger := &domain.PipelineTrigger{
+		PipelineID: p.ID,
+		Type:       domain.TriggerTypeCronDependency,
+		Config:     json.RawMessage(`{"cron_expr":"0 * * * *","dependencies":["sales.silver.customers","salesforce.bronze.leads","default.bronze.events"]}`),
+		Enabled:    true,
+	}
+	require.NoError(t, triggers.CreateTrigger(ctx, depTrigger))
+
+	versions := postgres.NewVersionStore(pool)
+	require.NoError(t, versions.CreateVersion(ctx, &domain.PipelineVersion{
+		PipelineID: p.ID, VersionNumber: 1,
+		PublishedVersions: map[string]string{"sales/pipelines/bronze/orders/pipeline.sql": "v1"},
+	}))
+
+	published := map[uuid.UUID]map[string]string{
+		p.ID: {"revenue/pipelines/bronze/orders/pipeline.sql": "v2"},
+	}
+	history := []domain.PipelineVersion{
+		{PipelineID: p.ID, VersionNumber: 1, PublishedVersions: map[string]string{"revenue/pipelines/bronze/orders/pipeline.sql": "v1-copy"}},
+	}
+	require.NoError(t, store.RenameNamespace(ctx, "sales", "revenue", published, history))
+
+	namespaces, err := store.ListNamespaces(ctx)
+	require.NoError(t, err)
+	names := make([]string, len(namespaces))
+	for i, ns := range namespaces {
+		names[i] = ns.Name
+	}
+	assert.ElementsMatch(t, []string{"default", "revenue"}, names)
+
+	moved, err := pipelines.GetPipelineByID(ctx, p.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, moved)
+	assert.Equal(t, "revenue", moved.Namespace)
+	assert.Equal(t, "revenue/pipelines/bronze/orders/", moved.S3Path)
+	assert.Equal(t, published[p.ID], moved.PublishedVersions)
+
+	snapshot, err := versions.GetVersion(ctx, p.ID, 1)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, history[0].PublishedVersions, snapshot.PublishedVersions)
+
+	zone, err := zones.GetZone(ctx, "revenue", "uploads")
+	require.NoError(t, err)
+	assert.NotNil(t, zone)
+
+	got, err := triggers.GetTrigger(ctx, trigger.ID.String())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"namespace":"revenue","zone_name":"uploads"}`, string(got.Config))
+
+	// Only entries in the renamed namespace move; "salesforce." merely shares the prefix.
+	got, err = triggers.GetTrigger(ctx, depTrigger.ID.String())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cron_expr":"0 * * * *","dependencies":["revenue.silver.customers","salesforce.bronze.leads","default.bronze.events"]}`, string(got.Config))
+}
+
+func TestNamespaceStore_RenameNamespace_TargetExists(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewNamespaceStore(pool)
+	ctx := context.Background()
+
+	require.NoError(t, store.CreateNamespace(ctx, "sales", nil))
+	require.NoError(t, store.CreateNamespace(ctx, "revenue", nil))
+
+	err := store.RenameNamespace(ctx, "sales", "revenue", nil, nil)
+	assert.ErrorIs(t, err, api.ErrNamespaceExists)
+
+	err = store.RenameNamespace(ctx, "ghost", "spirit", nil, nil)
+	assert.ErrorIs(t, err, api.ErrNamespaceNotFound)
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rat-data/rat/platform/internal/api"
+	"github.com/rat-data/rat/platform/internal/domain"
+)
+
+// NotificationStore implements api.NotificationStore on top of the
+// platform_settings table, one row per namespace.
+type NotificationStore struct {
+	db *timeoutDB
+}
+
+// NewNotificationStore creates a NotificationStore backed by the given pool.
+func NewNotificationStore(pool *pgxpool.Pool) *NotificationStore {
+	return &NotificationStore{db: newTimeoutDB(pool)}
+}
+
+// GetNotificationConfig returns the namespace's notification config, or nil
+// when none is configured.
+func (s *NotificationStore) GetNotificationConfig(ctx context.Context, namespace string) (*domain.NotificationConfig, error) {
+	var value []byte
+	err := s.db.QueryRow(ctx,
+		`SELECT value FROM platform_settings WHERE key = $1`, api.NamespaceNotificationsKey(namespace),
+	).Scan(&value)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get notification config %q: %w", namespace, err)
+	}
+
+	var cfg domain.NotificationConfig
+	if err := json.Unmarshal(value, &cfg); err != nil {
+		return nil, fmt.Errorf("decode notification config %q: %w", namespace, err)
+	}
+	return &cfg, nil
+}
+
+// PutNotificationConfig upserts the namespace's notification config.
+func (s *NotificationStore) PutNotificationConfig(ctx context.Context, namespace string, cfg *domain.NotificationConfig) error {
+	value, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encode notification config %q: %w", namespace, err)
+	}
+	_, err = s.db.Exec(ctx,
+		`INSERT INTO platform_settings (key, value, updated_at) VALUES ($1, $2, NOW())
+		 ON CONFLICT (key) DO UPDATE SET value = $2, updated_at = NOW()`,
+		api.NamespaceNotificationsKey(namespace), value,
+	)
+	if err != nil {
+		return fmt.Errorf("put notification config %q: %w", namespace, err)
+	}
+	return nil
+}
+
+// DeleteNotificationConfig removes the namespace's notification config.
+// Deleting a config that doesn't exist is a no-op.
+func (s *NotificationStore) DeleteNotificationConfig(ctx context.Context, namespace string) error {
+	_, err := s.db.Exec(ctx,
+		`DELETE FROM platform_settings WHERE key = $1`, api.NamespaceNotificationsKey(namespace),
+	)
+	if err != nil {
+		return fmt.Errorf("delete notification config %q: %w", namespace, err)
+	}
+	return nil
+}
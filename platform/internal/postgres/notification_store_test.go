@@ -0,0 +1,34 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationStore_PutGetDelete(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewNotificationStore(pool)
+	ctx := context.Background()
+	t.Cleanup(func() { _ = store.DeleteNotificationConfig(ctx, "default") })
+
+	cfg, err := store.GetNotificationConfig(ctx, "default")
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	want := &domain.NotificationConfig{URL: "https://hooks.example.com/rat", Events: domain.NotificationEventsAll, Secret: "sealed"}
+	require.NoError(t, store.PutNotificationConfig(ctx, "default", want))
+
+	cfg, err = store.GetNotificationConfig(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, want, cfg)
+
+	require.NoError(t, store.DeleteNotificationConfig(ctx, "default"))
+	cfg, err = store.GetNotificationConfig(ctx, "default")
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+}
@@ -18,17 +18,19 @@ import (
 
 // pipelineColumns is the full column list for pipeline queries.
 const pipelineColumns = `id, namespace, layer, name, type, s3_path, description, owner,
-	published_at, published_versions, draft_dirty, max_versions, created_at, updated_at`
+	published_at, published_versions, draft_dirty, max_versions, labels, created_at, updated_at,
+	retention_config, priority, validation, draft_revision`
 
 // PipelineStore implements api.PipelineStore backed by Postgres.
 type PipelineStore struct {
-	pool     *pgxpool.Pool
+	pool     *pgxpool.Pool // for InTx; plain queries go through db
+	db       *timeoutDB
 	EventBus EventBus // optional — publishes pipeline_created/updated events when set
 }
 
 // NewPipelineStore creates a PipelineStore backed by the given pool.
 func NewPipelineStore(pool *pgxpool.Pool) *PipelineStore {
-	return &PipelineStore{pool: pool}
+	return &PipelineStore{pool: pool, db: newTimeoutDB(pool)}
 }
 
 // scanPipeline scans a single pipeline row into domain.Pipeline.
@@ -43,20 +45,25 @@ func scanPipeline(row pgx.Row) (*domain.Pipeline, error) {
 		publishedVersions []byte
 		draftDirty        bool
 		maxVersions       int
+		labels            []byte
 		createdAt         time.Time
 		updatedAt         time.Time
+		retentionConfig   []byte
+		priority          int
+		validation        []byte
+		draftRevision     int64
 	)
 
 	err := row.Scan(&id, &namespace, &layer, &name, &typ, &s3Path,
 		&description, &owner, &publishedAt, &publishedVersions,
-		&draftDirty, &maxVersions, &createdAt, &updatedAt)
+		&draftDirty, &maxVersions, &labels, &createdAt, &updatedAt, &retentionConfig, &priority, &validation, &draftRevision)
 	if err != nil {
 		return nil, err
 	}
 
 	p := pipelineRowToDomain(id, namespace, layer, name, typ, s3Path,
 		description, owner, publishedAt, publishedVersions, draftDirty,
-		maxVersions, createdAt, updatedAt)
+		maxVersions, labels, createdAt, updatedAt, retentionConfig, priority, validation, draftRevision)
 	return &p, nil
 }
 
@@ -76,6 +83,22 @@ func pipelineWhereClause(filter api.PipelineFilter) (string, []interface{}, int)
 		args = append(args, filter.Layer)
 		argN++
 	}
+	if len(filter.Labels) > 0 {
+		// jsonb containment — served by the GIN index on labels.
+		where += fmt.Sprintf(" AND labels @> $%d::jsonb", argN)
+		args = append(args, stringMapToJSONB(filter.Labels))
+		argN++
+	}
+	if filter.Search != "" {
+		// Case-insensitive substring match on name, description, or the
+		// documented description of the pipeline's output table.
+		where += fmt.Sprintf(` AND (name ILIKE $%[1]d OR description ILIKE $%[1]d
+			OR EXISTS (SELECT 1 FROM table_metadata tm
+				WHERE tm.namespace = pipelines.namespace AND tm.layer = pipelines.layer
+				AND tm.name = pipelines.name AND tm.description ILIKE $%[1]d))`, argN)
+		args = append(args, "%"+escapeLike(filter.Search)+"%")
+		argN++
+	}
 	return where, args, argN
 }
 
@@ -88,7 +111,7 @@ func (s *PipelineStore) ListPipelines(ctx context.Context, filter api.PipelineFi
 		args = append(args, filter.Limit, filter.Offset)
 	}
 
-	rows, err := s.pool.Query(ctx, query, args...)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("list pipelines: %w", err)
 	}
@@ -106,19 +129,24 @@ func (s *PipelineStore) ListPipelines(ctx context.Context, filter api.PipelineFi
 			publishedVersions []byte
 			draftDirty        bool
 			maxVersions       int
+			labels            []byte
 			createdAt         time.Time
 			updatedAt         time.Time
+			retentionConfig   []byte
+			priority          int
+			validation        []byte
+			draftRevision     int64
 		)
 
 		if err := rows.Scan(&id, &namespace, &layer, &name, &typ, &s3Path,
 			&description, &owner, &publishedAt, &publishedVersions,
-			&draftDirty, &maxVersions, &createdAt, &updatedAt); err != nil {
+			&draftDirty, &maxVersions, &labels, &createdAt, &updatedAt, &retentionConfig, &priority, &validation, &draftRevision); err != nil {
 			return nil, fmt.Errorf("scan pipeline: %w", err)
 		}
 
 		result = append(result, pipelineRowToDomain(id, namespace, layer, name, typ, s3Path,
 			description, owner, publishedAt, publishedVersions, draftDirty,
-			maxVersions, createdAt, updatedAt))
+			maxVersions, labels, createdAt, updatedAt, retentionConfig, priority, validation, draftRevision))
 	}
 	return result, rows.Err()
 }
@@ -129,7 +157,7 @@ func (s *PipelineStore) CountPipelines(ctx context.Context, filter api.PipelineF
 	query := `SELECT COUNT(*) FROM pipelines` + where
 
 	var count int
-	err := s.pool.QueryRow(ctx, query, args...).Scan(&count)
+	err := s.db.QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("count pipelines: %w", err)
 	}
@@ -140,7 +168,7 @@ func (s *PipelineStore) GetPipeline(ctx context.Context, namespace, layer, name
 	query := `SELECT ` + pipelineColumns + ` FROM pipelines
 		WHERE namespace = $1 AND layer = $2 AND name = $3 AND deleted_at IS NULL`
 
-	p, err := scanPipeline(s.pool.QueryRow(ctx, query, namespace, layer, name))
+	p, err := scanPipeline(s.db.QueryRow(ctx, query, namespace, layer, name))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -159,7 +187,7 @@ func (s *PipelineStore) GetPipelineByID(ctx context.Context, id string) (*domain
 	query := `SELECT ` + pipelineColumns + ` FROM pipelines
 		WHERE id = $1 AND deleted_at IS NULL`
 
-	p, err := scanPipeline(s.pool.QueryRow(ctx, query, uid))
+	p, err := scanPipeline(s.db.QueryRow(ctx, query, uid))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -170,14 +198,16 @@ func (s *PipelineStore) GetPipelineByID(ctx context.Context, id string) (*domain
 }
 
 func (s *PipelineStore) CreatePipeline(ctx context.Context, p *domain.Pipeline) error {
-	query := `INSERT INTO pipelines (namespace, layer, name, type, s3_path, description, owner)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	query := `INSERT INTO pipelines (namespace, layer, name, type, s3_path, description, owner, labels, priority)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8::jsonb, '{}'), $9)
 		RETURNING ` + pipelineColumns
 
-	row := s.pool.QueryRow(ctx, query,
+	row := s.db.QueryRow(ctx, query,
 		p.Namespace, string(p.Layer), p.Name, p.Type, p.S3Path,
 		pgtype.Text{String: p.Description, Valid: true},
-		textPtrToNullable(p.Owner))
+		textPtrToNullable(p.Owner),
+		stringMapToJSONB(p.Labels),
+		p.Priority)
 
 	created, err := scanPipeline(row)
 	if err != nil {
@@ -206,20 +236,79 @@ func (s *PipelineStore) CreatePipeline(ctx context.Context, p *domain.Pipeline)
 	return nil
 }
 
+// CreatePipelinesBatch inserts pipelines in a single transaction. Name clashes
+// (with existing rows or earlier items in the batch) are reported per item as
+// domain.ErrAlreadyExists via ON CONFLICT DO NOTHING, so they don't abort the
+// transaction. Any other error rolls back the whole batch.
+func (s *PipelineStore) CreatePipelinesBatch(ctx context.Context, pipelines []*domain.Pipeline) ([]error, error) {
+	query := `INSERT INTO pipelines (namespace, layer, name, type, s3_path, description, owner, labels, priority)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8::jsonb, '{}'), $9)
+		ON CONFLICT DO NOTHING
+		RETURNING ` + pipelineColumns
+
+	results := make([]error, len(pipelines))
+	err := InTx(ctx, s.pool, func(tx pgx.Tx) error {
+		for i, p := range pipelines {
+			created, err := scanPipeline(tx.QueryRow(ctx, query,
+				p.Namespace, string(p.Layer), p.Name, p.Type, p.S3Path,
+				pgtype.Text{String: p.Description, Valid: true},
+				textPtrToNullable(p.Owner),
+				stringMapToJSONB(p.Labels),
+				p.Priority))
+			if errors.Is(err, pgx.ErrNoRows) {
+				results[i] = fmt.Errorf("pipeline %s/%s/%s: %w", p.Namespace, p.Layer, p.Name, domain.ErrAlreadyExists)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("create pipeline %s/%s/%s: %w", p.Namespace, p.Layer, p.Name, err)
+			}
+			p.ID = created.ID
+			p.CreatedAt = created.CreatedAt
+			p.UpdatedAt = created.UpdatedAt
+			p.MaxVersions = created.MaxVersions
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create pipelines batch: %w", err)
+	}
+
+	// Publish only after commit so listeners never see rolled-back pipelines.
+	if s.EventBus != nil {
+		for i, p := range pipelines {
+			if results[i] != nil {
+				continue
+			}
+			_ = s.EventBus.Publish(ctx, ChannelPipelineCreated, PipelineEventPayload{
+				PipelineID: p.ID.String(),
+				Namespace:  p.Namespace,
+				Layer:      string(p.Layer),
+				Name:       p.Name,
+			})
+		}
+	}
+
+	return results, nil
+}
+
 func (s *PipelineStore) UpdatePipeline(ctx context.Context, namespace, layer, name string, update api.UpdatePipelineRequest) (*domain.Pipeline, error) {
 	query := `UPDATE pipelines SET
 		description = COALESCE($4, description),
 		type = COALESCE($5, type),
 		owner = COALESCE($6, owner),
+		labels = COALESCE($7::jsonb, labels),
+		priority = COALESCE($8, priority),
 		updated_at = NOW()
 		WHERE namespace = $1 AND layer = $2 AND name = $3 AND deleted_at IS NULL
 		RETURNING ` + pipelineColumns
 
-	p, err := scanPipeline(s.pool.QueryRow(ctx, query,
+	p, err := scanPipeline(s.db.QueryRow(ctx, query,
 		namespace, layer, name,
 		textPtrToNullable(update.Description),
 		textPtrToNullable(update.Type),
-		textPtrToNullable(update.Owner)))
+		textPtrToNullable(update.Owner),
+		stringMapToJSONB(update.Labels),
+		update.Priority))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -240,8 +329,44 @@ func (s *PipelineStore) UpdatePipeline(ctx context.Context, namespace, layer, na
 	return p, nil
 }
 
+// MovePipeline renames a pipeline and/or changes its layer, keeping its ID so
+// runs, versions, schedules and triggers stay attached.
+func (s *PipelineStore) MovePipeline(ctx context.Context, pipelineID uuid.UUID, layer, name, s3Path string, publishedVersions map[string]string) (*domain.Pipeline, error) {
+	versionsJSON, err := json.Marshal(publishedVersions)
+	if err != nil {
+		return nil, fmt.Errorf("marshal published versions: %w", err)
+	}
+	query := `UPDATE pipelines SET layer = $2, name = $3, s3_path = $4, published_versions = $5, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + pipelineColumns
+
+	p, err := scanPipeline(s.db.QueryRow(ctx, query, pipelineID, layer, name, s3Path, versionsJSON))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("pipeline %s: %w", name, domain.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("move pipeline: %w", err)
+	}
+
+	// Best-effort event publishing — does not fail the move.
+	if s.EventBus != nil {
+		_ = s.EventBus.Publish(ctx, ChannelPipelineUpdated, PipelineEventPayload{
+			PipelineID: p.ID.String(),
+			Namespace:  p.Namespace,
+			Layer:      string(p.Layer),
+			Name:       p.Name,
+		})
+	}
+
+	return p, nil
+}
+
 func (s *PipelineStore) DeletePipeline(ctx context.Context, namespace, layer, name string) error {
-	_, err := s.pool.Exec(ctx,
+	_, err := s.db.Exec(ctx,
 		`UPDATE pipelines SET deleted_at = NOW() WHERE namespace = $1 AND layer = $2 AND name = $3 AND deleted_at IS NULL`,
 		namespace, layer, name)
 	if err != nil {
@@ -261,19 +386,64 @@ func (s *PipelineStore) DeletePipeline(ctx context.Context, namespace, layer, na
 }
 
 func (s *PipelineStore) SetDraftDirty(ctx context.Context, namespace, layer, name string, dirty bool) error {
-	_, err := s.pool.Exec(ctx,
-		`UPDATE pipelines SET draft_dirty = $4, updated_at = NOW()
+	_, err := s.db.Exec(ctx,
+		`UPDATE pipelines SET draft_dirty = $4,
+		   draft_revision = draft_revision + CASE WHEN $4 THEN 1 ELSE 0 END, updated_at = NOW()
 		 WHERE namespace = $1 AND layer = $2 AND name = $3 AND deleted_at IS NULL`,
 		namespace, layer, name, dirty)
 	return err
 }
 
+// BumpDraftRevision advances the pipeline's draft revision and marks the
+// draft dirty, but only if the current revision is base. On a stale base it
+// returns the current revision with domain.ErrDraftRevisionMismatch. Returns
+// (0, nil) when the pipeline doesn't exist.
+func (s *PipelineStore) BumpDraftRevision(ctx context.Context, pipelineID uuid.UUID, base int64) (int64, error) {
+	var revision int64
+	err := s.db.QueryRow(ctx,
+		`UPDATE pipelines SET draft_revision = draft_revision + 1, draft_dirty = true, updated_at = NOW()
+		 WHERE id = $1 AND draft_revision = $2 AND deleted_at IS NULL
+		 RETURNING draft_revision`,
+		pipelineID, base).Scan(&revision)
+	if err == nil {
+		return revision, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("bump draft revision: %w", err)
+	}
+
+	err = s.db.QueryRow(ctx,
+		`SELECT draft_revision FROM pipelines WHERE id = $1 AND deleted_at IS NULL`,
+		pipelineID).Scan(&revision)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("get draft revision: %w", err)
+	}
+	return revision, domain.ErrDraftRevisionMismatch
+}
+
+// RollbackDraftRevision undoes a BumpDraftRevision whose draft write failed:
+// it sets draft_revision back to revision-1 and draft_dirty back to dirty,
+// but only while the revision is still the one the bump returned.
+func (s *PipelineStore) RollbackDraftRevision(ctx context.Context, pipelineID uuid.UUID, revision int64, dirty bool) error {
+	_, err := s.db.Exec(ctx,
+		`UPDATE pipelines SET draft_revision = draft_revision - 1, draft_dirty = $3, updated_at = NOW()
+		 WHERE id = $1 AND draft_revision = $2 AND deleted_at IS NULL`,
+		pipelineID, revision, dirty)
+	if err != nil {
+		return fmt.Errorf("rollback draft revision: %w", err)
+	}
+	return nil
+}
+
 func (s *PipelineStore) PublishPipeline(ctx context.Context, namespace, layer, name string, versions map[string]string) error {
 	versionsJSON, err := json.Marshal(versions)
 	if err != nil {
 		return fmt.Errorf("marshal published versions: %w", err)
 	}
-	_, err = s.pool.Exec(ctx,
+	_, err = s.db.Exec(ctx,
 		`UPDATE pipelines SET published_at = NOW(), published_versions = $4, draft_dirty = false, updated_at = NOW()
 		 WHERE namespace = $1 AND layer = $2 AND name = $3 AND deleted_at IS NULL`,
 		namespace, layer, name, versionsJSON)
@@ -295,7 +465,7 @@ func (s *PipelineStore) PublishPipeline(ctx context.Context, namespace, layer, n
 
 // UpdatePipelineRetention sets per-pipeline retention overrides (JSONB).
 func (s *PipelineStore) UpdatePipelineRetention(ctx context.Context, pipelineID uuid.UUID, config json.RawMessage) error {
-	_, err := s.pool.Exec(ctx,
+	_, err := s.db.Exec(ctx,
 		`UPDATE pipelines SET retention_config = $2, updated_at = NOW() WHERE id = $1`,
 		pipelineID, config,
 	)
@@ -305,9 +475,25 @@ func (s *PipelineStore) UpdatePipelineRetention(ctx context.Context, pipelineID
 	return nil
 }
 
+// UpdatePipelineValidation stores the latest publish-time validation result (JSONB).
+func (s *PipelineStore) UpdatePipelineValidation(ctx context.Context, pipelineID uuid.UUID, result *domain.ValidationResult) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal validation result: %w", err)
+	}
+	_, err = s.db.Exec(ctx,
+		`UPDATE pipelines SET validation = $2, updated_at = NOW() WHERE id = $1`,
+		pipelineID, resultJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("update pipeline validation: %w", err)
+	}
+	return nil
+}
+
 // ListSoftDeletedPipelines returns pipelines that were soft-deleted before the given time.
 func (s *PipelineStore) ListSoftDeletedPipelines(ctx context.Context, olderThan time.Time) ([]domain.Pipeline, error) {
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		`SELECT `+pipelineColumns+`, deleted_at FROM pipelines WHERE deleted_at IS NOT NULL AND deleted_at < $1`,
 		olderThan,
 	)
@@ -328,18 +514,23 @@ func (s *PipelineStore) ListSoftDeletedPipelines(ctx context.Context, olderThan
 			publishedVersions []byte
 			draftDirty        bool
 			maxVersions       int
+			labels            []byte
 			createdAt         time.Time
 			updatedAt         time.Time
+			retentionConfig   []byte
+			priority          int
+			validation        []byte
+			draftRevision     int64
 			deletedAt         *time.Time
 		)
 		if err := rows.Scan(&id, &namespace, &layer, &name, &typ, &s3Path,
 			&description, &owner, &publishedAt, &publishedVersions,
-			&draftDirty, &maxVersions, &createdAt, &updatedAt, &deletedAt); err != nil {
+			&draftDirty, &maxVersions, &labels, &createdAt, &updatedAt, &retentionConfig, &priority, &validation, &draftRevision, &deletedAt); err != nil {
 			return nil, fmt.Errorf("scan soft-deleted pipeline: %w", err)
 		}
 		p := pipelineRowToDomain(id, namespace, layer, name, typ, s3Path,
 			description, owner, publishedAt, publishedVersions, draftDirty,
-			maxVersions, createdAt, updatedAt)
+			maxVersions, labels, createdAt, updatedAt, retentionConfig, priority, validation, draftRevision)
 		p.DeletedAt = deletedAt
 		result = append(result, p)
 	}
@@ -348,7 +539,7 @@ func (s *PipelineStore) ListSoftDeletedPipelines(ctx context.Context, olderThan
 
 // HardDeletePipeline permanently removes a pipeline row (after soft-delete purge period).
 func (s *PipelineStore) HardDeletePipeline(ctx context.Context, pipelineID uuid.UUID) error {
-	_, err := s.pool.Exec(ctx, `DELETE FROM pipelines WHERE id = $1`, pipelineID)
+	_, err := s.db.Exec(ctx, `DELETE FROM pipelines WHERE id = $1`, pipelineID)
 	if err != nil {
 		return fmt.Errorf("hard delete pipeline: %w", err)
 	}
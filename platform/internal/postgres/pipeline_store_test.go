@@ -56,6 +56,31 @@ func TestPipelineStore_CreateDuplicate_ReturnsError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestPipelineStore_CreatePipelinesBatch_ReportsConflictsPerItem(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewPipelineStore(pool)
+	ctx := context.Background()
+
+	require.NoError(t, store.CreatePipeline(ctx, newTestPipeline("default", "bronze", "orders")))
+
+	batch := []*domain.Pipeline{
+		newTestPipeline("default", "bronze", "orders"),
+		newTestPipeline("default", "bronze", "customers"),
+		newTestPipeline("default", "bronze", "customers"),
+	}
+	results, err := store.CreatePipelinesBatch(ctx, batch)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.ErrorIs(t, results[0], domain.ErrAlreadyExists)
+	assert.NoError(t, results[1])
+	assert.NotEmpty(t, batch[1].ID)
+	assert.ErrorIs(t, results[2], domain.ErrAlreadyExists)
+
+	pipelines, err := store.ListPipelines(ctx, api.PipelineFilter{Namespace: "default"})
+	require.NoError(t, err)
+	assert.Len(t, pipelines, 2)
+}
+
 func TestPipelineStore_ListFilterByNamespace(t *testing.T) {
 	pool := testPool(t)
 	store := postgres.NewPipelineStore(pool)
@@ -179,3 +204,91 @@ func TestPipelineStore_DeletePipeline_PublishesEvent(t *testing.T) {
 	}
 	assert.True(t, found, "expected pipeline_deleted event")
 }
+
+func TestPipelineStore_Search(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewPipelineStore(pool)
+	ctx := context.Background()
+
+	orders := newTestPipeline("default", "bronze", "raw_orders")
+	orders.Description = "Order events from the storefront"
+	customers := newTestPipeline("default", "silver", "customers")
+	customers.Description = "Deduplicated CRM contacts"
+	require.NoError(t, store.CreatePipeline(ctx, orders))
+	require.NoError(t, store.CreatePipeline(ctx, customers))
+	_, err := pool.Exec(ctx, `INSERT INTO table_metadata (namespace, layer, name, description)
+		VALUES ('default', 'bronze', 'raw_orders', 'Checkout ledger')`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		search string
+		want   []string
+	}{
+		{"matches name", "ORDERS", []string{"raw_orders"}},
+		{"matches description", "crm", []string{"customers"}},
+		{"matches table metadata description", "ledger", []string{"raw_orders"}},
+		{"no match", "inventory", []string{}},
+		{"wildcards are literal", "%", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := api.PipelineFilter{Search: tt.search}
+			pipelines, err := store.ListPipelines(ctx, filter)
+			require.NoError(t, err)
+			got := []string{}
+			for _, p := range pipelines {
+				got = append(got, p.Name)
+			}
+			assert.Equal(t, tt.want, got)
+
+			count, err := store.CountPipelines(ctx, filter)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.want), count)
+		})
+	}
+}
+
+func TestPipelineStore_Labels_CreateUpdateFilterAndList(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewPipelineStore(pool)
+	ctx := context.Background()
+
+	orders := newTestPipeline("default", "bronze", "orders")
+	orders.Labels = map[string]string{"team": "analytics", "pii": "true"}
+	events := newTestPipeline("default", "bronze", "events")
+	events.Labels = map[string]string{"team": "analytics"}
+	unlabeled := newTestPipeline("default", "silver", "campaigns")
+	require.NoError(t, store.CreatePipeline(ctx, orders))
+	require.NoError(t, store.CreatePipeline(ctx, events))
+	require.NoError(t, store.CreatePipeline(ctx, unlabeled))
+
+	got, err := store.GetPipeline(ctx, "default", "bronze", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, orders.Labels, got.Labels)
+
+	pipelines, err := store.ListPipelines(ctx, api.PipelineFilter{Labels: map[string]string{"team": "analytics"}})
+	require.NoError(t, err)
+	assert.Len(t, pipelines, 2)
+
+	pipelines, err = store.ListPipelines(ctx, api.PipelineFilter{Labels: map[string]string{"team": "analytics", "pii": "true"}})
+	require.NoError(t, err)
+	require.Len(t, pipelines, 1)
+	assert.Equal(t, "orders", pipelines[0].Name)
+
+	count, err := store.CountPipelines(ctx, api.PipelineFilter{Labels: map[string]string{"team": "marketing"}})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// nil Labels leaves labels untouched; a non-nil map replaces them.
+	desc := "updated"
+	updated, err := store.UpdatePipeline(ctx, "default", "bronze", "events", api.UpdatePipelineRequest{Description: &desc})
+	require.NoError(t, err)
+	assert.Equal(t, events.Labels, updated.Labels)
+
+	updated, err = store.UpdatePipeline(ctx, "default", "bronze", "events", api.UpdatePipelineRequest{
+		Labels: map[string]string{"team": "marketing"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "marketing"}, updated.Labels)
+}
@@ -22,7 +22,7 @@ type PluginStore struct {
 
 // NewPluginStore creates a PluginStore backed by the given pool.
 func NewPluginStore(pool *pgxpool.Pool) *PluginStore {
-	return &PluginStore{q: gen.New(pool)}
+	return &PluginStore{q: gen.New(newTimeoutDB(pool))}
 }
 
 func (s *PluginStore) ListPlugins(ctx context.Context, filter domain.PluginFilter) ([]domain.PluginEntry, error) {
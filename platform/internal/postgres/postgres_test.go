@@ -3,6 +3,7 @@ package postgres_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -361,10 +362,15 @@ func TestAuditStore_DeleteOlderThan(t *testing.T) {
 
 	require.NoError(t, store.Log(ctx, "user-1", "action", "resource", "old entry", ""))
 
+	cutoff := time.Now().Add(1 * time.Second)
+	wouldDelete, err := store.CountOlderThan(ctx, cutoff)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, wouldDelete, 1)
+
 	// Delete entries older than 1 second in the future (should delete everything)
-	deleted, err := store.DeleteOlderThan(ctx, time.Now().Add(1*time.Second))
+	deleted, err := store.DeleteOlderThan(ctx, cutoff)
 	require.NoError(t, err)
-	assert.GreaterOrEqual(t, deleted, 1)
+	assert.Equal(t, wouldDelete, deleted)
 
 	entries, err := store.List(ctx, 10, 0)
 	require.NoError(t, err)
@@ -389,6 +395,175 @@ func TestAuditStore_DeleteOlderThan_KeepsRecent(t *testing.T) {
 	assert.Len(t, entries, 1)
 }
 
+// seedAuditEntries logs a fixed set of entries and backdates them so each
+// filter has something to include and something to exclude.
+func seedAuditEntries(t *testing.T, pool *pgxpool.Pool, store *postgres.AuditStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	seed := []struct {
+		user, action, resource string
+		at                     time.Time
+	}{
+		{"alice", "delete", "pipeline/default/bronze/orders", time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)},
+		{"alice", "update", "pipeline/default/bronze/orders", time.Date(2026, 2, 4, 9, 0, 0, 0, time.UTC)},
+		{"bob", "delete", "pipeline/default/silver/customers", time.Date(2026, 2, 5, 9, 0, 0, 0, time.UTC)},
+		{"alice", "delete", "namespace/scratch", time.Date(2026, 2, 6, 9, 0, 0, 0, time.UTC)},
+		{"alice", "delete", "pipeline/default/bronze/legacy", time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, e := range seed {
+		require.NoError(t, store.Log(ctx, e.user, e.action, e.resource, "", ""))
+		_, err := pool.Exec(ctx,
+			`UPDATE audit_log SET created_at = $1 WHERE id = (SELECT id FROM audit_log ORDER BY created_at DESC LIMIT 1)`, e.at)
+		require.NoError(t, err)
+	}
+}
+
+func TestAuditStore_ListFiltered(t *testing.T) {
+	pool := testPool(t)
+	cleanExtraTables(t, pool, "audit_log")
+	store := postgres.NewAuditStore(pool)
+	ctx := context.Background()
+	seedAuditEntries(t, pool, store)
+
+	at := func(day int) *time.Time {
+		v := time.Date(2026, 2, day, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+
+	tests := []struct {
+		name      string
+		filter    api.AuditFilter
+		resources []string // expected, most recent first
+	}{
+		{"no filter", api.AuditFilter{}, []string{
+			"namespace/scratch", "pipeline/default/silver/customers", "pipeline/default/bronze/orders",
+			"pipeline/default/bronze/orders", "pipeline/default/bronze/legacy",
+		}},
+		{"by user", api.AuditFilter{UserID: "bob"}, []string{"pipeline/default/silver/customers"}},
+		{"by action", api.AuditFilter{Action: "update"}, []string{"pipeline/default/bronze/orders"}},
+		{"by resource prefix", api.AuditFilter{ResourcePrefix: "pipeline/default/bronze/"}, []string{
+			"pipeline/default/bronze/orders", "pipeline/default/bronze/orders", "pipeline/default/bronze/legacy",
+		}},
+		{"resource prefix is literal", api.AuditFilter{ResourcePrefix: "pipeline/%"}, []string{}},
+		{"after", api.AuditFilter{After: at(5)}, []string{"namespace/scratch", "pipeline/default/silver/customers"}},
+		{"before", api.AuditFilter{Before: at(1)}, []string{"pipeline/default/bronze/legacy"}},
+		{"deletes by alice last week", api.AuditFilter{
+			UserID: "alice", Action: "delete", After: at(2), Before: at(9),
+		}, []string{"namespace/scratch", "pipeline/default/bronze/orders"}},
+		{"all filters", api.AuditFilter{
+			UserID: "alice", Action: "delete", ResourcePrefix: "pipeline/", After: at(2), Before: at(9),
+		}, []string{"pipeline/default/bronze/orders"}},
+		{"paginated", api.AuditFilter{UserID: "alice", Limit: 2, Offset: 1}, []string{
+			"pipeline/default/bronze/orders", "pipeline/default/bronze/orders",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := store.ListFiltered(ctx, tt.filter)
+			require.NoError(t, err)
+			got := []string{}
+			for _, e := range entries {
+				got = append(got, e.Resource)
+			}
+			assert.Equal(t, tt.resources, got)
+		})
+	}
+}
+
+func TestAuditStore_EachFiltered_StreamsMatchingEntries(t *testing.T) {
+	pool := testPool(t)
+	cleanExtraTables(t, pool, "audit_log")
+	store := postgres.NewAuditStore(pool)
+	ctx := context.Background()
+	seedAuditEntries(t, pool, store)
+
+	var got []string
+	err := store.EachFiltered(ctx, api.AuditFilter{UserID: "alice", Action: "delete"}, func(e domain.AuditEntry) error {
+		got = append(got, e.Resource)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"namespace/scratch", "pipeline/default/bronze/orders", "pipeline/default/bronze/legacy"}, got)
+
+	// An error from fn stops iteration and is returned as-is.
+	stop := errors.New("stop")
+	calls := 0
+	err = store.EachFiltered(ctx, api.AuditFilter{}, func(domain.AuditEntry) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+// seedTiedAuditEntries inserts n entries sharing timestamps ten at a time, so
+// keyset pages must break ties on id.
+func seedTiedAuditEntries(t *testing.T, pool *pgxpool.Pool, n int) {
+	t.Helper()
+	_, err := pool.Exec(context.Background(),
+		`INSERT INTO audit_log (user_id, action, resource, created_at)
+		 SELECT 'alice', 'create', 'pipeline/default/bronze/p' || g, $1 - (g / 10) * interval '1 second'
+		 FROM generate_series(1, $2) g`,
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), n)
+	require.NoError(t, err)
+}
+
+func TestAuditStore_ListFiltered_CursorPagesCoverTableOnce(t *testing.T) {
+	pool := testPool(t)
+	cleanExtraTables(t, pool, "audit_log")
+	store := postgres.NewAuditStore(pool)
+	ctx := context.Background()
+	seedTiedAuditEntries(t, pool, 53)
+
+	seen := map[string]bool{}
+	var prev *domain.AuditEntry
+	filter := api.AuditFilter{Limit: 7}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 20, "cursor pagination did not terminate")
+		page, err := store.ListFiltered(ctx, filter)
+		require.NoError(t, err)
+		for i := range page {
+			e := page[i]
+			assert.False(t, seen[e.ID], "entry %s returned twice", e.ID)
+			seen[e.ID] = true
+			if prev != nil {
+				assert.False(t, e.CreatedAt.After(prev.CreatedAt), "newest first")
+			}
+			prev = &e
+		}
+		if len(page) < filter.Limit {
+			break
+		}
+		last := page[len(page)-1]
+		filter.Cursor = &api.AuditCursor{CreatedAt: last.CreatedAt, ID: uuid.MustParse(last.ID)}
+	}
+	assert.Len(t, seen, 53)
+}
+
+func TestAuditStore_EachFiltered_IteratesAcrossBatches(t *testing.T) {
+	pool := testPool(t)
+	cleanExtraTables(t, pool, "audit_log")
+	store := postgres.NewAuditStore(pool)
+	ctx := context.Background()
+	// More than two export batches, with ties straddling the batch edges.
+	seedTiedAuditEntries(t, pool, 2505)
+
+	seen := map[string]bool{}
+	var prev time.Time
+	err := store.EachFiltered(ctx, api.AuditFilter{UserID: "alice"}, func(e domain.AuditEntry) error {
+		assert.False(t, seen[e.ID], "entry %s exported twice", e.ID)
+		seen[e.ID] = true
+		if !prev.IsZero() {
+			assert.False(t, e.CreatedAt.After(prev), "newest first")
+		}
+		prev = e.CreatedAt
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, seen, 2505)
+}
+
 // ---------------------------------------------------------------------------
 // SettingsStore tests
 // ---------------------------------------------------------------------------
@@ -657,6 +832,90 @@ func TestTriggerStore_DeleteTrigger(t *testing.T) {
 	assert.Nil(t, got)
 }
 
+func TestTriggerStore_ListAllTriggers_FiltersAndPaginates(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	nsStore := postgres.NewNamespaceStore(pool)
+	tStore := postgres.NewTriggerStore(pool)
+	ctx := context.Background()
+
+	require.NoError(t, nsStore.CreateNamespace(ctx, "finance", nil))
+	ingest := createTestPipeline(t, pStore, "default", "bronze", "t-all-ingest")
+	report := createTestPipeline(t, pStore, "finance", "gold", "t-all-report")
+	gone := createTestPipeline(t, pStore, "default", "silver", "t-all-gone")
+
+	createTestTrigger(t, tStore, ingest.ID, domain.TriggerTypeCron, json.RawMessage(`{"cron_expr": "0 * * * *"}`))
+	createTestTrigger(t, tStore, ingest.ID, domain.TriggerTypeWebhook, json.RawMessage(`{"token_hash": "abc123"}`))
+	disabled := createTestTrigger(t, tStore, report.ID, domain.TriggerTypeCron, json.RawMessage(`{"cron_expr": "0 6 * * *"}`))
+	off := false
+	_, err := tStore.UpdateTrigger(ctx, disabled.ID.String(), api.UpdateTriggerRequest{Enabled: &off})
+	require.NoError(t, err)
+	createTestTrigger(t, tStore, gone.ID, domain.TriggerTypeCron, json.RawMessage(`{"cron_expr": "0 * * * *"}`))
+	require.NoError(t, pStore.DeletePipeline(ctx, "default", "silver", "t-all-gone"))
+
+	// All live triggers, joined to pipeline identity.
+	items, total, err := tStore.ListAllTriggers(ctx, api.TriggerFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, items, 3)
+	assert.Equal(t, "finance", items[0].Namespace)
+	assert.Equal(t, domain.Layer("gold"), items[0].Layer)
+	assert.Equal(t, "t-all-report", items[0].PipelineName)
+
+	// Filters.
+	_, total, err = tStore.ListAllTriggers(ctx, api.TriggerFilter{Type: "cron"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	enabled := true
+	_, total, err = tStore.ListAllTriggers(ctx, api.TriggerFilter{Enabled: &enabled})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	_, total, err = tStore.ListAllTriggers(ctx, api.TriggerFilter{Namespace: "finance"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	// Pagination keeps the unpaged total.
+	items, total, err = tStore.ListAllTriggers(ctx, api.TriggerFilter{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, items, 1)
+}
+
+func TestTriggerStore_SetTriggersEnabledByPipeline(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	tStore := postgres.NewTriggerStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "t-bulk")
+	other := createTestPipeline(t, pStore, "default", "bronze", "t-bulk-other")
+	createTestTrigger(t, tStore, pipeline.ID, domain.TriggerTypeCron, json.RawMessage(`{"cron_expr": "0 * * * *"}`))
+	createTestTrigger(t, tStore, pipeline.ID, domain.TriggerTypeWebhook, json.RawMessage(`{"token_hash": "abc123"}`))
+	untouched := createTestTrigger(t, tStore, other.ID, domain.TriggerTypeCron, json.RawMessage(`{"cron_expr": "0 * * * *"}`))
+
+	n, err := tStore.SetTriggersEnabledByPipeline(ctx, pipeline.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// Already disabled — nothing changes state.
+	n, err = tStore.SetTriggersEnabledByPipeline(ctx, pipeline.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	triggers, err := tStore.ListTriggers(ctx, pipeline.ID)
+	require.NoError(t, err)
+	for _, trig := range triggers {
+		assert.False(t, trig.Enabled)
+	}
+	got, err := tStore.GetTrigger(ctx, untouched.ID.String())
+	require.NoError(t, err)
+	assert.True(t, got.Enabled)
+
+	n, err = tStore.SetTriggersEnabledByPipeline(ctx, pipeline.ID, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
 func TestTriggerStore_FindTriggersByType(t *testing.T) {
 	pool := testPool(t)
 	pStore := postgres.NewPipelineStore(pool)
@@ -939,6 +1198,30 @@ func TestHealthChecker_Ping(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestHealthChecker_PoolStats_ReflectsAcquiredConns(t *testing.T) {
+	pool := testPool(t)
+	checker := postgres.NewHealthChecker(pool)
+	ctx := context.Background()
+
+	before := checker.PoolStats()
+	assert.Equal(t, pool.Config().MaxConns, before.Max)
+
+	c1, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	c2, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+
+	during := checker.PoolStats()
+	assert.Equal(t, before.Acquired+2, during.Acquired)
+	assert.GreaterOrEqual(t, during.Total, during.Acquired+during.Idle)
+
+	c1.Release()
+	c2.Release()
+	after := checker.PoolStats()
+	assert.Equal(t, before.Acquired, after.Acquired)
+	assert.GreaterOrEqual(t, after.Idle, int32(2))
+}
+
 // ---------------------------------------------------------------------------
 // PipelineStore — additional operations
 // ---------------------------------------------------------------------------
@@ -1109,6 +1392,16 @@ func TestPipelineStore_UpdatePipelineRetention(t *testing.T) {
 	retentionJSON := json.RawMessage(`{"runs_max_per_pipeline": 50, "runs_max_age_days": 30}`)
 	err := store.UpdatePipelineRetention(ctx, p.ID, retentionJSON)
 	require.NoError(t, err)
+
+	// The overrides round-trip through GetPipeline and ListPipelines (the reaper reads them).
+	got, err := store.GetPipeline(ctx, "default", "bronze", "retention-test")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(retentionJSON), string(got.RetentionConfig))
+
+	listed, err := store.ListPipelines(ctx, api.PipelineFilter{Namespace: "default", Search: "retention-test"})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.JSONEq(t, string(retentionJSON), string(listed[0].RetentionConfig))
 }
 
 // ---------------------------------------------------------------------------
@@ -1136,6 +1429,35 @@ func TestRunStore_CountRuns(t *testing.T) {
 	assert.Equal(t, 3, count)
 }
 
+func TestRunStore_ParentRunID_RoundTripAndChildFilter(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "parent-runs")
+
+	parent := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusSuccess, Trigger: "manual"}
+	require.NoError(t, rStore.CreateRun(ctx, parent))
+	assert.Nil(t, parent.ParentRunID)
+
+	child := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "trigger:pipeline_success:x", ParentRunID: &parent.ID}
+	require.NoError(t, rStore.CreateRun(ctx, child))
+	require.NoError(t, rStore.CreateRun(ctx, &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "manual"}))
+
+	got, err := rStore.GetRun(ctx, child.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, got.ParentRunID)
+	assert.Equal(t, parent.ID, *got.ParentRunID)
+
+	children, err := rStore.ListRuns(ctx, api.RunFilter{ParentRunID: parent.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Equal(t, child.ID, children[0].ID)
+	require.NotNil(t, children[0].ParentRunID)
+	assert.Equal(t, parent.ID, *children[0].ParentRunID)
+}
+
 func TestRunStore_SaveAndGetRunLogs(t *testing.T) {
 	pool := testPool(t)
 	pStore := postgres.NewPipelineStore(pool)
@@ -1176,9 +1498,13 @@ func TestRunStore_DeleteRunsBeyondLimit(t *testing.T) {
 		}))
 	}
 
+	wouldDelete, err := rStore.CountRunsBeyondLimit(ctx, pipeline.ID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, wouldDelete)
+
 	deleted, err := rStore.DeleteRunsBeyondLimit(ctx, pipeline.ID, 3)
 	require.NoError(t, err)
-	assert.Equal(t, 2, deleted)
+	assert.Equal(t, wouldDelete, deleted)
 
 	runs, err := rStore.ListRuns(ctx, api.RunFilter{PipelineID: pipeline.ID.String()})
 	require.NoError(t, err)
@@ -1199,7 +1525,7 @@ func TestRunStore_DeleteRunsOlderThan(t *testing.T) {
 	require.NoError(t, rStore.UpdateRunStatus(ctx, run.ID.String(), domain.RunStatusSuccess, nil, nil, nil))
 
 	// Delete all terminal runs created before the future
-	deleted, err := rStore.DeleteRunsOlderThan(ctx, time.Now().Add(1*time.Second))
+	deleted, err := rStore.DeleteRunsOlderThan(ctx, pipeline.ID, time.Now().Add(1*time.Second))
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, deleted, 1)
 }
@@ -1219,7 +1545,11 @@ func TestRunStore_DeleteRunsOlderThan_SkipsPendingRuns(t *testing.T) {
 		Trigger:    "manual",
 	}))
 
-	deleted, err := rStore.DeleteRunsOlderThan(ctx, time.Now().Add(1*time.Second))
+	wouldDelete, err := rStore.CountRunsOlderThan(ctx, pipeline.ID, time.Now().Add(1*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 0, wouldDelete)
+
+	deleted, err := rStore.DeleteRunsOlderThan(ctx, pipeline.ID, time.Now().Add(1*time.Second))
 	require.NoError(t, err)
 	assert.Equal(t, 0, deleted)
 }
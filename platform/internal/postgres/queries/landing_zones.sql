@@ -1,28 +1,29 @@
 -- name: ListLandingZones :many
-SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema,
+SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema, lz.schema_strict, lz.dedupe,
        lz.created_at, lz.updated_at,
        COALESCE(COUNT(lf.id), 0)::bigint AS file_count,
        COALESCE(SUM(lf.size_bytes), 0)::bigint AS total_bytes
 FROM landing_zones lz
 LEFT JOIN landing_files lf ON lf.zone_id = lz.id
-WHERE (sqlc.narg('filter_namespace')::text IS NULL OR lz.namespace = sqlc.narg('filter_namespace'))
+WHERE lz.deleted_at IS NULL
+  AND (sqlc.narg('filter_namespace')::text IS NULL OR lz.namespace = sqlc.narg('filter_namespace'))
 GROUP BY lz.id
 ORDER BY lz.created_at DESC;
 
 -- name: GetLandingZone :one
-SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema,
+SELECT lz.id, lz.namespace, lz.name, lz.description, lz.owner, lz.expected_schema, lz.schema_strict, lz.dedupe,
        lz.created_at, lz.updated_at,
        COALESCE(COUNT(lf.id), 0)::bigint AS file_count,
        COALESCE(SUM(lf.size_bytes), 0)::bigint AS total_bytes
 FROM landing_zones lz
 LEFT JOIN landing_files lf ON lf.zone_id = lz.id
-WHERE lz.namespace = $1 AND lz.name = $2
+WHERE lz.namespace = $1 AND lz.name = $2 AND lz.deleted_at IS NULL
 GROUP BY lz.id;
 
 -- name: GetLandingZoneByID :one
 SELECT id, namespace, name, description, owner, expected_schema, created_at, updated_at
 FROM landing_zones
-WHERE id = $1;
+WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: CreateLandingZone :one
 INSERT INTO landing_zones (namespace, name, description, owner)
@@ -30,8 +31,8 @@ VALUES ($1, $2, $3, $4)
 RETURNING id, namespace, name, description, owner, expected_schema, created_at, updated_at;
 
 -- name: DeleteLandingZone :exec
-DELETE FROM landing_zones
-WHERE namespace = $1 AND name = $2;
+UPDATE landing_zones SET deleted_at = NOW(), updated_at = NOW()
+WHERE namespace = $1 AND name = $2 AND deleted_at IS NULL;
 
 -- name: UpdateLandingZone :one
 UPDATE landing_zones
@@ -39,25 +40,39 @@ SET description = COALESCE(sqlc.narg('description'), description),
     owner = COALESCE(sqlc.narg('owner'), owner),
     expected_schema = COALESCE(sqlc.narg('expected_schema'), expected_schema),
     updated_at = NOW()
-WHERE namespace = $1 AND name = $2
-RETURNING id, namespace, name, description, owner, expected_schema, created_at, updated_at;
+WHERE namespace = $1 AND name = $2 AND deleted_at IS NULL
+RETURNING id, namespace, name, description, owner, expected_schema, schema_strict, dedupe, created_at, updated_at;
 
 -- name: ListLandingFiles :many
-SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
 FROM landing_files
 WHERE zone_id = $1
 ORDER BY uploaded_at DESC;
 
 -- name: CreateLandingFile :one
-INSERT INTO landing_files (zone_id, filename, s3_path, size_bytes, content_type, uploaded_by)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at;
+INSERT INTO landing_files (zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, checksum_sha256)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256;
 
 -- name: GetLandingFile :one
-SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
 FROM landing_files
 WHERE id = $1;
 
+-- name: FindLandingFileByChecksum :one
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
+FROM landing_files
+WHERE zone_id = $1 AND checksum_sha256 = $2
+ORDER BY uploaded_at DESC
+LIMIT 1;
+
+-- name: FindLandingFileByPath :one
+SELECT id, zone_id, filename, s3_path, size_bytes, content_type, uploaded_by, uploaded_at, checksum_sha256
+FROM landing_files
+WHERE zone_id = $1 AND s3_path = $2
+ORDER BY uploaded_at DESC
+LIMIT 1;
+
 -- name: DeleteLandingFile :exec
 DELETE FROM landing_files
 WHERE id = $1;
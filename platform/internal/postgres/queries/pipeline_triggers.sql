@@ -1,13 +1,13 @@
 -- name: ListPipelineTriggers :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE pipeline_id = $1
 ORDER BY created_at DESC;
 
 -- name: GetPipelineTrigger :one
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE id = $1;
 
@@ -15,7 +15,7 @@ WHERE id = $1;
 INSERT INTO pipeline_triggers (pipeline_id, type, config, enabled, cooldown_seconds)
 VALUES ($1, $2, $3, $4, $5)
 RETURNING id, pipeline_id, type, config, enabled, cooldown_seconds,
-          last_triggered_at, last_run_id, created_at, updated_at;
+          last_triggered_at, last_run_id, created_at, updated_at, backoff_streak;
 
 -- name: UpdatePipelineTrigger :one
 UPDATE pipeline_triggers
@@ -25,7 +25,15 @@ SET config = COALESCE(sqlc.narg('config'), config),
     updated_at = now()
 WHERE id = $1
 RETURNING id, pipeline_id, type, config, enabled, cooldown_seconds,
-          last_triggered_at, last_run_id, created_at, updated_at;
+          last_triggered_at, last_run_id, created_at, updated_at, backoff_streak;
+
+-- name: SetTriggersEnabledByPipeline :execrows
+-- Flips enabled on every trigger of a pipeline. Only rows whose state
+-- actually changes are touched, so the affected count is the number flipped.
+UPDATE pipeline_triggers
+SET enabled = $2,
+    updated_at = now()
+WHERE pipeline_id = $1 AND enabled <> $2;
 
 -- name: DeletePipelineTrigger :exec
 DELETE FROM pipeline_triggers
@@ -33,7 +41,7 @@ WHERE id = $1;
 
 -- name: FindTriggersByLandingZone :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'landing_zone_upload'
   AND enabled = true
@@ -46,6 +54,12 @@ SET last_triggered_at = now(),
     updated_at = now()
 WHERE id = $1;
 
+-- name: UpdateTriggerBackoffStreak :exec
+UPDATE pipeline_triggers
+SET backoff_streak = $2,
+    updated_at = now()
+WHERE id = $1;
+
 -- name: UpdateTriggerFiredCAS :one
 -- Compare-and-swap fire of a trigger. Only updates when the current
 -- last_triggered_at matches the expected value (or both are NULL).
@@ -60,24 +74,24 @@ SET last_triggered_at = sqlc.arg('new_triggered_at')::timestamptz,
 WHERE id = $1
   AND last_triggered_at IS NOT DISTINCT FROM sqlc.narg('expected_last_triggered_at')::timestamptz
 RETURNING id, pipeline_id, type, config, enabled, cooldown_seconds,
-          last_triggered_at, last_run_id, created_at, updated_at;
+          last_triggered_at, last_run_id, created_at, updated_at, backoff_streak;
 
 -- name: FindTriggersByType :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = $1 AND enabled = true;
 
 -- name: FindTriggerByWebhookToken :one
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'webhook' AND enabled = true
   AND config->>'token_hash' = sqlc.arg('token')::text;
 
 -- name: FindTriggersByPipelineSuccess :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'pipeline_success' AND enabled = true
   AND config->>'namespace' = sqlc.arg('namespace')::text
@@ -86,7 +100,7 @@ WHERE type = 'pipeline_success' AND enabled = true
 
 -- name: FindTriggersByFilePattern :many
 SELECT id, pipeline_id, type, config, enabled, cooldown_seconds,
-       last_triggered_at, last_run_id, created_at, updated_at
+       last_triggered_at, last_run_id, created_at, updated_at, backoff_streak
 FROM pipeline_triggers
 WHERE type = 'file_pattern' AND enabled = true
   AND config->>'namespace' = sqlc.arg('namespace')::text
@@ -11,15 +11,18 @@ ORDER BY r.created_at DESC;
 
 -- name: GetRun :one
 SELECT id, pipeline_id, status, trigger, started_at, finished_at,
-       duration_ms, rows_written, error, logs_s3_path, created_at
+       duration_ms, rows_written, error, logs_s3_path, created_at, metadata,
+       parent_run_id, priority, phase_profiles
 FROM runs
 WHERE id = $1;
 
 -- name: CreateRun :one
-INSERT INTO runs (pipeline_id, status, trigger)
-VALUES ($1, $2, $3)
+INSERT INTO runs (pipeline_id, status, trigger, metadata, parent_run_id, priority)
+VALUES ($1, $2, $3, COALESCE(sqlc.narg('metadata')::jsonb, '{}'), sqlc.narg('parent_run_id'),
+        COALESCE((SELECT priority FROM pipelines WHERE id = $1), 0))
 RETURNING id, pipeline_id, status, trigger, started_at, finished_at,
-          duration_ms, rows_written, error, logs_s3_path, created_at;
+          duration_ms, rows_written, error, logs_s3_path, created_at, metadata,
+          parent_run_id, priority;
 
 -- name: UpdateRunStatus :exec
 UPDATE runs
@@ -48,5 +51,8 @@ WHERE id = @id;
 -- name: SaveRunLogs :exec
 UPDATE runs SET logs = @logs WHERE id = @id;
 
+-- name: SaveRunPhases :exec
+UPDATE runs SET phase_profiles = @phase_profiles WHERE id = @id;
+
 -- name: GetRunLogsByID :one
 SELECT logs FROM runs WHERE id = @id;
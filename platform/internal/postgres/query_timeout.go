@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rat-data/rat/platform/internal/postgres/gen"
+)
+
+// Default statement timeouts for store queries. Overridable via environment:
+//   - DB_QUERY_TIMEOUT: bound on every store query (default 10s)
+//   - DB_HEAVY_QUERY_TIMEOUT: bound on known-heavy queries — exports, stats,
+//     retention deletes (default 2m)
+//
+// A value <= 0 disables that bound. The caller's own deadline still applies
+// when it is sooner.
+const (
+	defaultQueryTimeout      = 10 * time.Second
+	defaultHeavyQueryTimeout = 2 * time.Minute
+)
+
+// queryTimeouts reads the timeout env vars once; stores are built at startup
+// but TxRunner builds tx-scoped ones per transaction.
+var queryTimeouts = sync.OnceValues(func() (time.Duration, time.Duration) {
+	return envDuration("DB_QUERY_TIMEOUT", defaultQueryTimeout),
+		envDuration("DB_HEAVY_QUERY_TIMEOUT", defaultHeavyQueryTimeout)
+})
+
+type heavyQueryKey struct{}
+
+// heavyQuery marks ctx so store queries made with it get the heavy timeout.
+// Use it at the top of store methods that scan or delete unbounded row sets.
+func heavyQuery(ctx context.Context) context.Context {
+	return context.WithValue(ctx, heavyQueryKey{}, true)
+}
+
+// timeoutDB wraps a pool (or any gen.DBTX) so each Exec/Query/QueryRow runs
+// under its own context.WithTimeout. A query that hangs on a lock or scans
+// far more than expected is cancelled instead of holding its connection.
+//
+// Statements inside an explicit transaction (InTx) run on the tx directly and
+// are bounded by the context the transaction was started with.
+type timeoutDB struct {
+	db           gen.DBTX
+	timeout      time.Duration
+	heavyTimeout time.Duration
+}
+
+// newTimeoutDB wraps db with the env-configured store query timeouts.
+func newTimeoutDB(db gen.DBTX) *timeoutDB {
+	timeout, heavy := queryTimeouts()
+	return &timeoutDB{db: db, timeout: timeout, heavyTimeout: heavy}
+}
+
+func (t *timeoutDB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	d := t.timeout
+	if heavy, _ := ctx.Value(heavyQueryKey{}).(bool); heavy {
+		d = t.heavyTimeout
+	}
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// Exec runs sql under the store timeout.
+func (t *timeoutDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	defer cancel()
+	return t.db.Exec(ctx, sql, args...)
+}
+
+// Query runs sql under the store timeout. The timeout covers reading the
+// rows too; it is released when the rows are exhausted or closed.
+func (t *timeoutDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, cancel := t.withTimeout(ctx)
+	rows, err := t.db.Query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+// QueryRow runs sql under the store timeout, released once the row is scanned.
+func (t *timeoutDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, cancel := t.withTimeout(ctx)
+	return timeoutRow{row: t.db.QueryRow(ctx, sql, args...), cancel: cancel}
+}
+
+// timeoutRows releases the query timeout when iteration ends.
+type timeoutRows struct {
+	pgx.Rows
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.cancel()
+	return false
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+// timeoutRow releases the query timeout after Scan.
+type timeoutRow struct {
+	row    pgx.Row
+	cancel context.CancelFunc
+}
+
+func (r timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.row.Scan(dest...)
+}
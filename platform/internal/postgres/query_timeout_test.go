@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowDB blocks every call until its context ends, like a query stuck
+// behind a lock. Query returns rows that expose the query context.
+type slowDB struct {
+	queryCtx context.Context
+}
+
+func (d *slowDB) Exec(ctx context.Context, _ string, _ ...interface{}) (pgconn.CommandTag, error) {
+	<-ctx.Done()
+	return pgconn.CommandTag{}, ctx.Err()
+}
+
+func (d *slowDB) Query(ctx context.Context, _ string, _ ...interface{}) (pgx.Rows, error) {
+	d.queryCtx = ctx
+	return &stubRows{}, nil
+}
+
+func (d *slowDB) QueryRow(ctx context.Context, _ string, _ ...interface{}) pgx.Row {
+	return slowRow{ctx: ctx}
+}
+
+type slowRow struct{ ctx context.Context }
+
+func (r slowRow) Scan(_ ...any) error {
+	<-r.ctx.Done()
+	return r.ctx.Err()
+}
+
+type stubRows struct {
+	pgx.Rows
+	remaining int
+	closed    bool
+}
+
+func (r *stubRows) Next() bool {
+	if r.remaining == 0 {
+		return false
+	}
+	r.remaining--
+	return true
+}
+
+func (r *stubRows) Close() { r.closed = true }
+
+func TestTimeoutDB_SlowExecAndQueryRow_Cancelled(t *testing.T) {
+	db := &timeoutDB{db: &slowDB{}, timeout: 20 * time.Millisecond, heavyTimeout: time.Hour}
+
+	start := time.Now()
+	_, err := db.Exec(context.Background(), "SELECT pg_sleep(60)")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var n int
+	err = db.QueryRow(context.Background(), "SELECT pg_sleep(60)").Scan(&n)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestTimeoutDB_HeavyQuery_GetsLongerTimeout(t *testing.T) {
+	stub := &slowDB{}
+	db := &timeoutDB{db: stub, timeout: time.Second, heavyTimeout: time.Hour}
+
+	rows, err := db.Query(context.Background(), "SELECT 1")
+	require.NoError(t, err)
+	dl, ok := stub.queryCtx.Deadline()
+	require.True(t, ok)
+	assert.LessOrEqual(t, time.Until(dl), time.Second)
+	rows.Close()
+
+	rows, err = db.Query(heavyQuery(context.Background()), "SELECT 1")
+	require.NoError(t, err)
+	dl, ok = stub.queryCtx.Deadline()
+	require.True(t, ok)
+	assert.Greater(t, time.Until(dl), time.Minute)
+	rows.Close()
+}
+
+func TestTimeoutDB_Query_TimeoutReleasedWithRows(t *testing.T) {
+	stub := &slowDB{}
+	db := &timeoutDB{db: stub, timeout: time.Minute, heavyTimeout: time.Minute}
+
+	rows, err := db.Query(context.Background(), "SELECT 1")
+	require.NoError(t, err)
+	assert.NoError(t, stub.queryCtx.Err(), "rows are still being read")
+	assert.False(t, rows.Next())
+	assert.ErrorIs(t, stub.queryCtx.Err(), context.Canceled, "exhausted rows release the timeout")
+	rows.Close()
+	assert.True(t, rows.(*timeoutRows).Rows.(*stubRows).closed)
+}
+
+func TestTimeoutDB_ZeroTimeout_NoDeadline(t *testing.T) {
+	stub := &slowDB{}
+	db := &timeoutDB{db: stub, timeout: 0, heavyTimeout: 0}
+
+	rows, err := db.Query(context.Background(), "SELECT 1")
+	require.NoError(t, err)
+	defer rows.Close()
+	_, ok := stub.queryCtx.Deadline()
+	assert.False(t, ok)
+}
@@ -19,19 +19,21 @@ import (
 
 // RunStore implements api.RunStore backed by Postgres.
 type RunStore struct {
-	pool     *pgxpool.Pool
+	db       *timeoutDB
 	q        *gen.Queries
 	EventBus EventBus // optional — publishes run_completed events when set
 }
 
 // NewRunStore creates a RunStore backed by the given pool.
 func NewRunStore(pool *pgxpool.Pool) *RunStore {
-	return &RunStore{pool: pool, q: gen.New(pool)}
+	db := newTimeoutDB(pool)
+	return &RunStore{db: db, q: gen.New(db)}
 }
 
 // runListColumns is the column list for run list queries.
 const runListColumns = `r.id, r.pipeline_id, r.status, r.trigger, r.started_at, r.finished_at,
-       r.duration_ms, r.rows_written, r.error, r.logs_s3_path, r.created_at`
+       r.duration_ms, r.rows_written, r.error, r.logs_s3_path, r.created_at, r.metadata,
+       r.parent_run_id, r.priority`
 
 // runWhereClause builds the shared WHERE clause and args for run list/count queries.
 func runWhereClause(filter api.RunFilter) (string, []interface{}, int) {
@@ -59,24 +61,76 @@ func runWhereClause(filter api.RunFilter) (string, []interface{}, int) {
 		args = append(args, filter.PipelineID)
 		argN++
 	}
+	if filter.ParentRunID != "" {
+		where += fmt.Sprintf(" AND r.parent_run_id = $%d", argN)
+		args = append(args, filter.ParentRunID)
+		argN++
+	}
 	if filter.Status != "" {
 		where += fmt.Sprintf(" AND r.status = $%d", argN)
 		args = append(args, filter.Status)
 		argN++
 	}
+	if filter.Trigger != "" {
+		// Prefix match so "schedule:" selects every scheduled run and
+		// "trigger:webhook" every webhook-fired one.
+		where += fmt.Sprintf(" AND r.trigger LIKE $%d", argN)
+		args = append(args, escapeLike(filter.Trigger)+"%")
+		argN++
+	}
+	if filter.DeadLettered {
+		where += " AND r.dead_lettered_at IS NOT NULL"
+	}
+	if filter.CreatedAfter != nil {
+		where += fmt.Sprintf(" AND r.created_at >= $%d", argN)
+		args = append(args, *filter.CreatedAfter)
+		argN++
+	}
+	if filter.CreatedBefore != nil {
+		where += fmt.Sprintf(" AND r.created_at < $%d", argN)
+		args = append(args, *filter.CreatedBefore)
+		argN++
+	}
+	if filter.FinishedAfter != nil {
+		where += fmt.Sprintf(" AND r.finished_at >= $%d", argN)
+		args = append(args, *filter.FinishedAfter)
+		argN++
+	}
+	if filter.StartedAfter != nil {
+		where += fmt.Sprintf(" AND r.started_at >= $%d", argN)
+		args = append(args, *filter.StartedAfter)
+		argN++
+	}
+	if filter.StartedBefore != nil {
+		where += fmt.Sprintf(" AND r.started_at < $%d", argN)
+		args = append(args, *filter.StartedBefore)
+		argN++
+	}
 	return where, args, argN
 }
 
+// ListRuns returns runs newest first. When filter.After is set it pages by
+// keyset — (created_at, id) strictly below the cursor — instead of OFFSET, so
+// runs inserted between page fetches never shift or duplicate rows. id breaks
+// ties between runs created in the same microsecond.
 func (s *RunStore) ListRuns(ctx context.Context, filter api.RunFilter) ([]domain.Run, error) {
 	where, args, argN := runWhereClause(filter)
-	query := `SELECT ` + runListColumns + ` FROM runs r JOIN pipelines p ON r.pipeline_id = p.id` + where + ` ORDER BY r.created_at DESC`
+	if filter.After != nil {
+		where += fmt.Sprintf(" AND (r.created_at, r.id) < ($%d, $%d)", argN, argN+1)
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		argN += 2
+	}
+	query := `SELECT ` + runListColumns + ` FROM runs r JOIN pipelines p ON r.pipeline_id = p.id` + where + ` ORDER BY r.created_at DESC, r.id DESC`
 
-	if filter.Limit > 0 {
+	if filter.Limit > 0 && filter.After != nil {
+		query += fmt.Sprintf(" LIMIT $%d", argN)
+		args = append(args, filter.Limit)
+	} else if filter.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argN, argN+1)
 		args = append(args, filter.Limit, filter.Offset)
 	}
 
-	rows, err := s.pool.Query(ctx, query, args...)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("list runs: %w", err)
 	}
@@ -93,10 +147,13 @@ func (s *RunStore) ListRuns(ctx context.Context, filter api.RunFilter) ([]domain
 			errText               pgtype.Text
 			logsS3Path            pgtype.Text
 			createdAt             time.Time
+			metadata              []byte
+			parentRunID           pgtype.UUID
+			priority              int32
 		)
 		if err := rows.Scan(&id, &pipelineID, &status, &trigger,
 			&startedAt, &finishedAt, &durationMs, &rowsWritten,
-			&errText, &logsS3Path, &createdAt); err != nil {
+			&errText, &logsS3Path, &createdAt, &metadata, &parentRunID, &priority); err != nil {
 			return nil, fmt.Errorf("scan run: %w", err)
 		}
 		result = append(result, runRowToDomain(gen.Run{
@@ -105,7 +162,8 @@ func (s *RunStore) ListRuns(ctx context.Context, filter api.RunFilter) ([]domain
 			StartedAt: startedAt, FinishedAt: finishedAt,
 			DurationMs: durationMs, RowsWritten: rowsWritten,
 			Error: errText, LogsS3Path: logsS3Path,
-			CreatedAt: createdAt,
+			CreatedAt: createdAt, Metadata: metadata,
+			ParentRunID: parentRunID, Priority: priority,
 		}))
 	}
 	if result == nil {
@@ -120,7 +178,7 @@ func (s *RunStore) CountRuns(ctx context.Context, filter api.RunFilter) (int, er
 	query := `SELECT COUNT(*) FROM runs r JOIN pipelines p ON r.pipeline_id = p.id` + where
 
 	var count int
-	err := s.pool.QueryRow(ctx, query, args...).Scan(&count)
+	err := s.db.QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("count runs: %w", err)
 	}
@@ -142,17 +200,21 @@ func (s *RunStore) GetRun(ctx context.Context, runID string) (*domain.Run, error
 	}
 
 	run := runRowToDomain(gen.Run{
-		ID:          row.ID,
-		PipelineID:  row.PipelineID,
-		Status:      row.Status,
-		Trigger:     row.Trigger,
-		StartedAt:   row.StartedAt,
-		FinishedAt:  row.FinishedAt,
-		DurationMs:  row.DurationMs,
-		RowsWritten: row.RowsWritten,
-		Error:       row.Error,
-		LogsS3Path:  row.LogsS3Path,
-		CreatedAt:   row.CreatedAt,
+		ID:            row.ID,
+		PipelineID:    row.PipelineID,
+		Status:        row.Status,
+		Trigger:       row.Trigger,
+		StartedAt:     row.StartedAt,
+		FinishedAt:    row.FinishedAt,
+		DurationMs:    row.DurationMs,
+		RowsWritten:   row.RowsWritten,
+		Error:         row.Error,
+		LogsS3Path:    row.LogsS3Path,
+		CreatedAt:     row.CreatedAt,
+		Metadata:      row.Metadata,
+		ParentRunID:   row.ParentRunID,
+		Priority:      row.Priority,
+		PhaseProfiles: row.PhaseProfiles,
 	})
 	return &run, nil
 }
@@ -162,6 +224,8 @@ func (s *RunStore) CreateRun(ctx context.Context, run *domain.Run) error {
 		PipelineID: run.PipelineID,
 		Status:     string(run.Status),
 		Trigger:    run.Trigger,
+		Metadata:   stringMapToJSONB(run.Metadata),
+		ParentRunID: uuidPtrToNullable(run.ParentRunID),
 	})
 	if err != nil {
 		return fmt.Errorf("create run: %w", err)
@@ -169,6 +233,7 @@ func (s *RunStore) CreateRun(ctx context.Context, run *domain.Run) error {
 
 	run.ID = row.ID
 	run.CreatedAt = row.CreatedAt
+	run.Priority = int(row.Priority)
 	return nil
 }
 
@@ -210,6 +275,51 @@ func (s *RunStore) UpdateRunStatus(ctx context.Context, runID string, status dom
 	return nil
 }
 
+// RecordSubmitFailure bumps the run's submit_attempts and, once it reaches
+// maxAttempts, fails the run and stamps dead_lettered_at in the same
+// statement, so concurrent dispatchers can't both dead-letter it. Only
+// pending runs are touched. Publishes run_completed when the run is
+// dead-lettered, like any other transition to failed.
+func (s *RunStore) RecordSubmitFailure(ctx context.Context, runID string, reason string, maxAttempts int) (bool, error) {
+	id, err := uuid.Parse(runID)
+	if err != nil {
+		return false, fmt.Errorf("invalid run id: %w", err)
+	}
+
+	var (
+		pipelineID   uuid.UUID
+		deadLettered bool
+	)
+	err = s.db.QueryRow(ctx,
+		`UPDATE runs SET
+		        submit_attempts  = submit_attempts + 1,
+		        status           = CASE WHEN submit_attempts + 1 >= $2 THEN 'failed' ELSE status END,
+		        error            = CASE WHEN submit_attempts + 1 >= $2
+		                                THEN 'dead-lettered after ' || (submit_attempts + 1) || ' failed submit attempts: ' || $3
+		                                ELSE error END,
+		        finished_at      = CASE WHEN submit_attempts + 1 >= $2 THEN NOW() ELSE finished_at END,
+		        dead_lettered_at = CASE WHEN submit_attempts + 1 >= $2 THEN NOW() ELSE dead_lettered_at END
+		 WHERE id = $1 AND status = 'pending'
+		 RETURNING pipeline_id, dead_lettered_at IS NOT NULL`,
+		id, maxAttempts, reason,
+	).Scan(&pipelineID, &deadLettered)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil // no longer pending: cancelled, submitted, or already dead-lettered
+	}
+	if err != nil {
+		return false, fmt.Errorf("record submit failure: %w", err)
+	}
+
+	if deadLettered && s.EventBus != nil {
+		_ = s.EventBus.Publish(ctx, ChannelRunCompleted, RunCompletedPayload{
+			RunID:      runID,
+			PipelineID: pipelineID.String(),
+			Status:     string(domain.RunStatusFailed),
+		})
+	}
+	return deadLettered, nil
+}
+
 // isTerminalStatus returns true if the run status is a final state.
 func isTerminalStatus(s domain.RunStatus) bool {
 	return s == domain.RunStatusSuccess || s == domain.RunStatusFailed || s == domain.RunStatusCancelled
@@ -252,6 +362,25 @@ func (s *RunStore) SaveRunLogs(ctx context.Context, runID string, logs []api.Log
 	})
 }
 
+// SaveRunPhases persists the run's per-phase timings as JSONB on the run
+// record (phase_profiles column).
+func (s *RunStore) SaveRunPhases(ctx context.Context, runID string, phases []api.PhaseProfile) error {
+	id, err := uuid.Parse(runID)
+	if err != nil {
+		return fmt.Errorf("invalid run id: %w", err)
+	}
+
+	data, err := json.Marshal(phases)
+	if err != nil {
+		return fmt.Errorf("marshal phases: %w", err)
+	}
+
+	return s.q.SaveRunPhases(ctx, gen.SaveRunPhasesParams{
+		ID:            id,
+		PhaseProfiles: data,
+	})
+}
+
 func runRowToDomain(r gen.Run) domain.Run {
 	run := domain.Run{
 		ID:         r.ID,
@@ -261,6 +390,7 @@ func runRowToDomain(r gen.Run) domain.Run {
 		StartedAt:  r.StartedAt,
 		FinishedAt: r.FinishedAt,
 		CreatedAt:  r.CreatedAt,
+		Priority:   int(r.Priority),
 	}
 	if r.DurationMs.Valid {
 		v := int(r.DurationMs.Int32)
@@ -276,36 +406,137 @@ func runRowToDomain(r gen.Run) domain.Run {
 	if r.LogsS3Path.Valid {
 		run.LogsS3Path = &r.LogsS3Path.String
 	}
+	if r.ParentRunID.Valid {
+		id := uuid.UUID(r.ParentRunID.Bytes)
+		run.ParentRunID = &id
+	}
+	if len(r.Metadata) > 0 {
+		var m map[string]string
+		if err := json.Unmarshal(r.Metadata, &m); err == nil && len(m) > 0 {
+			run.Metadata = m
+		}
+	}
+	if len(r.PhaseProfiles) > 0 {
+		var phases []domain.PhaseProfile
+		if err := json.Unmarshal(r.PhaseProfiles, &phases); err == nil && len(phases) > 0 {
+			run.Phases = phases
+		}
+	}
 	return run
 }
 
+// runsBeyondLimitIDs selects the runs of pipeline $1 past the newest $2.
+// Shared by DeleteRunsBeyondLimit and its count-only dry-run variant.
+const runsBeyondLimitIDs = `SELECT id FROM runs WHERE pipeline_id = $1
+			ORDER BY created_at DESC
+			OFFSET $2`
+
+// runsOlderThanWhere matches terminal runs of pipeline $1 created before $2.
+// Shared by DeleteRunsOlderThan and its count-only dry-run variant.
+const runsOlderThanWhere = `pipeline_id = $1 AND created_at < $2 AND status IN ('success', 'failed', 'cancelled')`
+
 // DeleteRunsBeyondLimit deletes the oldest runs for a pipeline, keeping the most recent keepCount.
 // Returns the number of runs deleted.
 func (s *RunStore) DeleteRunsBeyondLimit(ctx context.Context, pipelineID uuid.UUID, keepCount int) (int, error) {
-	tag, err := s.pool.Exec(ctx,
-		`DELETE FROM runs WHERE id IN (
-			SELECT id FROM runs WHERE pipeline_id = $1
-			ORDER BY created_at DESC
-			OFFSET $2
-		)`, pipelineID, keepCount)
+	ctx = heavyQuery(ctx)
+	tag, err := s.db.Exec(ctx,
+		`DELETE FROM runs WHERE id IN (`+runsBeyondLimitIDs+`)`, pipelineID, keepCount)
 	if err != nil {
 		return 0, fmt.Errorf("delete runs beyond limit: %w", err)
 	}
 	return int(tag.RowsAffected()), nil
 }
 
-// DeleteRunsOlderThan deletes runs (in terminal states) older than the given time.
+// CountRunsBeyondLimit returns how many runs DeleteRunsBeyondLimit would delete.
+func (s *RunStore) CountRunsBeyondLimit(ctx context.Context, pipelineID uuid.UUID, keepCount int) (int, error) {
+	ctx = heavyQuery(ctx)
+	var count int
+	err := s.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM (`+runsBeyondLimitIDs+`) beyond`, pipelineID, keepCount).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count runs beyond limit: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteRunsOlderThan deletes a pipeline's runs (in terminal states) older than the given time.
 // Returns the number of runs deleted.
-func (s *RunStore) DeleteRunsOlderThan(ctx context.Context, olderThan time.Time) (int, error) {
-	tag, err := s.pool.Exec(ctx,
-		`DELETE FROM runs WHERE created_at < $1 AND status IN ('success', 'failed', 'cancelled')`,
-		olderThan)
+func (s *RunStore) DeleteRunsOlderThan(ctx context.Context, pipelineID uuid.UUID, olderThan time.Time) (int, error) {
+	ctx = heavyQuery(ctx)
+	tag, err := s.db.Exec(ctx,
+		`DELETE FROM runs WHERE `+runsOlderThanWhere, pipelineID, olderThan)
 	if err != nil {
 		return 0, fmt.Errorf("delete old runs: %w", err)
 	}
 	return int(tag.RowsAffected()), nil
 }
 
+// CountRunsOlderThan returns how many runs DeleteRunsOlderThan would delete.
+func (s *RunStore) CountRunsOlderThan(ctx context.Context, pipelineID uuid.UUID, olderThan time.Time) (int, error) {
+	ctx = heavyQuery(ctx)
+	var count int
+	err := s.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM runs WHERE `+runsOlderThanWhere, pipelineID, olderThan).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count old runs: %w", err)
+	}
+	return count, nil
+}
+
+// NamespaceRunStats counts a namespace's runs created at or after since,
+// grouped by status.
+func (s *RunStore) NamespaceRunStats(ctx context.Context, namespace string, since time.Time) (*api.NamespaceRunStats, error) {
+	ctx = heavyQuery(ctx)
+	rows, err := s.db.Query(ctx,
+		`SELECT r.status, COUNT(*) FROM runs r JOIN pipelines p ON r.pipeline_id = p.id
+		 WHERE p.namespace = $1 AND r.created_at >= $2
+		 GROUP BY r.status`, namespace, since)
+	if err != nil {
+		return nil, fmt.Errorf("namespace run stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &api.NamespaceRunStats{ByStatus: map[string]int{}}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("scan namespace run stats: %w", err)
+		}
+		stats.ByStatus[status] = count
+		stats.Total += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate namespace run stats: %w", err)
+	}
+	return stats, nil
+}
+
+// PipelineStats rolls up a pipeline's runs created at or after since.
+// percentile_cont interpolates between neighbouring durations, matching the
+// usual SLA-dashboard definition of p50/p95/p99.
+func (s *RunStore) PipelineStats(ctx context.Context, pipelineID uuid.UUID, since time.Time) (*api.PipelineRunStats, error) {
+	ctx = heavyQuery(ctx)
+	var stats api.PipelineRunStats
+	err := s.db.QueryRow(ctx,
+		`SELECT COUNT(*),
+		        COUNT(*) FILTER (WHERE status = 'success'),
+		        COUNT(*) FILTER (WHERE status = 'failed'),
+		        percentile_cont(0.50) WITHIN GROUP (ORDER BY duration_ms) FILTER (WHERE status = 'success'),
+		        percentile_cont(0.95) WITHIN GROUP (ORDER BY duration_ms) FILTER (WHERE status = 'success'),
+		        percentile_cont(0.99) WITHIN GROUP (ORDER BY duration_ms) FILTER (WHERE status = 'success'),
+		        AVG(rows_written) FILTER (WHERE status = 'success')::float8
+		 FROM runs
+		 WHERE pipeline_id = $1 AND created_at >= $2`, pipelineID, since).Scan(
+		&stats.Runs, &stats.Succeeded, &stats.Failed,
+		&stats.DurationP50Ms, &stats.DurationP95Ms, &stats.DurationP99Ms,
+		&stats.AvgRowsWritten)
+	if err != nil {
+		return nil, fmt.Errorf("pipeline run stats: %w", err)
+	}
+	return &stats, nil
+}
+
 // LatestRunPerPipeline returns the most recent run for each of the given pipeline IDs
 // in a single query using DISTINCT ON, avoiding N+1 queries for lineage.
 func (s *RunStore) LatestRunPerPipeline(ctx context.Context, pipelineIDs []uuid.UUID) (map[uuid.UUID]*domain.Run, error) {
@@ -313,7 +544,7 @@ func (s *RunStore) LatestRunPerPipeline(ctx context.Context, pipelineIDs []uuid.
 		return map[uuid.UUID]*domain.Run{}, nil
 	}
 
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		`SELECT DISTINCT ON (r.pipeline_id)
 		        r.id, r.pipeline_id, r.status, r.trigger, r.started_at, r.finished_at,
 		        r.duration_ms, r.rows_written, r.error, r.logs_s3_path, r.created_at
@@ -356,10 +587,36 @@ func (s *RunStore) LatestRunPerPipeline(ctx context.Context, pipelineIDs []uuid.
 	return result, rows.Err()
 }
 
+// RunStatuses returns the status of each of the given runs in one query.
+func (s *RunStore) RunStatuses(ctx context.Context, runIDs []uuid.UUID) (map[uuid.UUID]domain.RunStatus, error) {
+	if len(runIDs) == 0 {
+		return map[uuid.UUID]domain.RunStatus{}, nil
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT id, status FROM runs WHERE id = ANY($1)`, runIDs)
+	if err != nil {
+		return nil, fmt.Errorf("run statuses: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]domain.RunStatus, len(runIDs))
+	for rows.Next() {
+		var (
+			id     uuid.UUID
+			status string
+		)
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, fmt.Errorf("scan run status: %w", err)
+		}
+		result[id] = domain.RunStatus(status)
+	}
+	return result, rows.Err()
+}
+
 // ListStuckRuns returns runs in running state created before the given cutoff.
 // Stuck PENDING runs use a separate, longer threshold — see ListStuckPendingRuns.
 func (s *RunStore) ListStuckRuns(ctx context.Context, olderThan time.Time) ([]domain.Run, error) {
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		`SELECT id, pipeline_id, status, trigger, started_at, finished_at,
 		        duration_ms, rows_written, error, logs_s3_path, created_at
 		 FROM runs
@@ -416,7 +673,7 @@ func (s *RunStore) ListStuckRuns(ctx context.Context, olderThan time.Time) ([]do
 // threshold than running runs because a queued run waiting for the executor
 // is a less alarming signal than a run that started but never completed.
 func (s *RunStore) ListStuckPendingRuns(ctx context.Context, olderThan time.Time) ([]domain.Run, error) {
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		`SELECT id, pipeline_id, status, trigger, started_at, finished_at,
 		        duration_ms, rows_written, error, logs_s3_path, created_at
 		 FROM runs
@@ -3,7 +3,9 @@ package postgres_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
 	"github.com/rat-data/rat/platform/internal/postgres"
@@ -43,6 +45,40 @@ func TestRunStore_CreateAndGet(t *testing.T) {
 	assert.Equal(t, "manual", got.Trigger)
 }
 
+func TestRunStore_Metadata_RoundTrips(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "orders")
+
+	withMeta := &domain.Run{
+		PipelineID: pipeline.ID,
+		Status:     domain.RunStatusPending,
+		Trigger:    "trigger:webhook:abcd1234",
+		Metadata:   map[string]string{"source": "github", "trigger_id": "t-1"},
+	}
+	require.NoError(t, rStore.CreateRun(ctx, withMeta))
+	plain := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "manual"}
+	require.NoError(t, rStore.CreateRun(ctx, plain))
+
+	got, err := rStore.GetRun(ctx, withMeta.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, withMeta.Metadata, got.Metadata)
+
+	got, err = rStore.GetRun(ctx, plain.ID.String())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Nil(t, got.Metadata)
+
+	runs, err := rStore.ListRuns(ctx, api.RunFilter{Trigger: "trigger:webhook"})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, withMeta.Metadata, runs[0].Metadata)
+}
+
 func TestRunStore_ListFilterByStatus(t *testing.T) {
 	pool := testPool(t)
 	pStore := postgres.NewPipelineStore(pool)
@@ -121,3 +157,295 @@ func TestRunStore_GetRunLogs_ReturnsEmpty(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, logs)
 }
+
+func TestRunStore_ListCursor_StableWhileRunsInserted(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "orders")
+
+	createRun := func() *domain.Run {
+		run := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "manual"}
+		require.NoError(t, rStore.CreateRun(ctx, run))
+		return run
+	}
+
+	// r[4] is the newest.
+	var r []*domain.Run
+	for i := 0; i < 5; i++ {
+		r = append(r, createRun())
+	}
+
+	page1, err := rStore.ListRuns(ctx, api.RunFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page1, 2)
+	assert.Equal(t, r[4].ID, page1[0].ID)
+	assert.Equal(t, r[3].ID, page1[1].ID)
+
+	// New runs land between page fetches — with OFFSET they'd shift page 2.
+	createRun()
+	createRun()
+
+	last := page1[len(page1)-1]
+	page2, err := rStore.ListRuns(ctx, api.RunFilter{
+		Limit: 2,
+		After: &api.RunCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, page2, 2)
+	assert.Equal(t, r[2].ID, page2[0].ID)
+	assert.Equal(t, r[1].ID, page2[1].ID)
+
+	last = page2[len(page2)-1]
+	page3, err := rStore.ListRuns(ctx, api.RunFilter{
+		Limit: 2,
+		After: &api.RunCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, page3, 1)
+	assert.Equal(t, r[0].ID, page3[0].ID)
+}
+
+func TestRunStore_ListCursor_TiesOnCreatedAtBrokenByID(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "orders")
+
+	for i := 0; i < 3; i++ {
+		run := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "manual"}
+		require.NoError(t, rStore.CreateRun(ctx, run))
+	}
+	_, err := pool.Exec(ctx, "UPDATE runs SET created_at = '2026-01-01T00:00:00Z'")
+	require.NoError(t, err)
+
+	seen := map[string]bool{}
+	var after *api.RunCursor
+	for {
+		page, err := rStore.ListRuns(ctx, api.RunFilter{Limit: 1, After: after})
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		id := page[0].ID.String()
+		assert.False(t, seen[id], "run %s returned twice", id)
+		seen[id] = true
+		after = &api.RunCursor{CreatedAt: page[0].CreatedAt, ID: page[0].ID}
+	}
+	assert.Len(t, seen, 3)
+}
+
+// seedRunsForFilters creates three runs with distinct triggers and created_at
+// values: a scheduled run on Feb 10, a webhook run on Feb 11, a manual run on
+// Feb 12 (all noon UTC).
+func seedRunsForFilters(t *testing.T, pool *pgxpool.Pool, rStore *postgres.RunStore, pipeline *domain.Pipeline) (scheduled, webhook, manual *domain.Run) {
+	t.Helper()
+	ctx := context.Background()
+
+	create := func(trigger string, createdAt time.Time) *domain.Run {
+		run := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusSuccess, Trigger: trigger}
+		require.NoError(t, rStore.CreateRun(ctx, run))
+		_, err := pool.Exec(ctx, "UPDATE runs SET created_at = $1 WHERE id = $2", createdAt, run.ID)
+		require.NoError(t, err)
+		return run
+	}
+
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 12, 0, 0, 0, time.UTC) }
+	scheduled = create("schedule:hourly", day(10))
+	webhook = create("trigger:webhook:abc", day(11))
+	manual = create("manual", day(12))
+	return scheduled, webhook, manual
+}
+
+func TestRunStore_ListFilterByCreatedRangeAndTrigger(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "orders")
+	scheduled, webhook, manual := seedRunsForFilters(t, pool, rStore, pipeline)
+
+	ts := func(d, h int) *time.Time {
+		v := time.Date(2026, 2, d, h, 0, 0, 0, time.UTC)
+		return &v
+	}
+
+	tests := []struct {
+		name   string
+		filter api.RunFilter
+		want   []*domain.Run
+	}{
+		{"created after", api.RunFilter{CreatedAfter: ts(11, 0)}, []*domain.Run{manual, webhook}},
+		{"created before", api.RunFilter{CreatedBefore: ts(11, 0)}, []*domain.Run{scheduled}},
+		{"created window", api.RunFilter{CreatedAfter: ts(11, 0), CreatedBefore: ts(12, 0)}, []*domain.Run{webhook}},
+		{"trigger prefix", api.RunFilter{Trigger: "schedule:"}, []*domain.Run{scheduled}},
+		{"trigger full prefix", api.RunFilter{Trigger: "trigger:webhook"}, []*domain.Run{webhook}},
+		{"trigger and window", api.RunFilter{Trigger: "schedule:", CreatedAfter: ts(11, 0)}, nil},
+		{"trigger and status", api.RunFilter{Trigger: "manual", Status: "success"}, []*domain.Run{manual}},
+		{"trigger wildcard is literal", api.RunFilter{Trigger: "%"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs, err := rStore.ListRuns(ctx, tt.filter)
+			require.NoError(t, err)
+			got := make([]string, len(runs))
+			for i, r := range runs {
+				got[i] = r.ID.String()
+			}
+			want := make([]string, len(tt.want))
+			for i, r := range tt.want {
+				want[i] = r.ID.String()
+			}
+			assert.Equal(t, want, got)
+
+			count, err := rStore.CountRuns(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.want), count)
+		})
+	}
+}
+
+func TestRunStore_NamespaceRunStats_GroupsByStatusWithinWindow(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	require.NoError(t, postgres.NewNamespaceStore(pool).CreateNamespace(ctx, "analytics", nil))
+	orders := createTestPipeline(t, pStore, "default", "bronze", "orders")
+	clicks := createTestPipeline(t, pStore, "analytics", "bronze", "clicks")
+
+	create := func(p *domain.Pipeline, status domain.RunStatus, createdAt time.Time) {
+		run := &domain.Run{PipelineID: p.ID, Status: status, Trigger: "manual"}
+		require.NoError(t, rStore.CreateRun(ctx, run))
+		_, err := pool.Exec(ctx, "UPDATE runs SET status = $1, created_at = $2 WHERE id = $3", string(status), createdAt, run.ID)
+		require.NoError(t, err)
+	}
+	now := time.Now()
+	create(orders, domain.RunStatusSuccess, now.Add(-time.Hour))
+	create(orders, domain.RunStatusSuccess, now.Add(-2*time.Hour))
+	create(orders, domain.RunStatusFailed, now.Add(-3*time.Hour))
+	create(orders, domain.RunStatusFailed, now.Add(-10*24*time.Hour)) // before the window
+	create(clicks, domain.RunStatusFailed, now.Add(-time.Hour))       // other namespace
+
+	stats, err := rStore.NamespaceRunStats(ctx, "default", now.Add(-7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, map[string]int{"success": 2, "failed": 1}, stats.ByStatus)
+
+	empty, err := rStore.NamespaceRunStats(ctx, "missing", now.Add(-7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Total)
+	assert.Empty(t, empty.ByStatus)
+}
+
+func TestRunStore_PipelineStats_Percentiles(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "silver", "orders")
+	create := func(status domain.RunStatus, durationMs int, rows int64, createdAt time.Time) {
+		run := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "manual"}
+		require.NoError(t, rStore.CreateRun(ctx, run))
+		_, err := pool.Exec(ctx,
+			"UPDATE runs SET status = $1, duration_ms = $2, rows_written = $3, created_at = $4 WHERE id = $5",
+			string(status), durationMs, rows, createdAt, run.ID)
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+	// Successful durations 100..1000ms, rows 10..100.
+	for i := 1; i <= 10; i++ {
+		create(domain.RunStatusSuccess, i*100, int64(i*10), now.Add(-time.Duration(i)*time.Hour))
+	}
+	create(domain.RunStatusFailed, 60000, 0, now.Add(-time.Hour))        // counted, not in percentiles
+	create(domain.RunStatusSuccess, 60000, 0, now.Add(-30*24*time.Hour)) // before the window
+
+	stats, err := rStore.PipelineStats(ctx, pipeline.ID, now.Add(-7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 11, stats.Runs)
+	assert.Equal(t, 10, stats.Succeeded)
+	assert.Equal(t, 1, stats.Failed)
+	require.NotNil(t, stats.DurationP50Ms)
+	// percentile_cont interpolates: p50 between 500 and 600, p95 = 900 + 0.55*100, p99 = 900 + 0.91*100.
+	assert.InDelta(t, 550, *stats.DurationP50Ms, 1e-9)
+	assert.InDelta(t, 955, *stats.DurationP95Ms, 1e-9)
+	assert.InDelta(t, 991, *stats.DurationP99Ms, 1e-9)
+	require.NotNil(t, stats.AvgRowsWritten)
+	assert.InDelta(t, 55, *stats.AvgRowsWritten, 1e-9)
+
+	empty, err := rStore.PipelineStats(ctx, pipeline.ID, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, empty.Runs)
+	assert.Nil(t, empty.DurationP50Ms)
+	assert.Nil(t, empty.AvgRowsWritten)
+}
+
+func TestRunStore_RecordSubmitFailure_DeadLettersAtMaxAttempts(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "orders")
+	run := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusPending, Trigger: "manual"}
+	require.NoError(t, rStore.CreateRun(ctx, run))
+
+	for i := 1; i < 3; i++ {
+		dead, err := rStore.RecordSubmitFailure(ctx, run.ID.String(), "runner unavailable", 3)
+		require.NoError(t, err)
+		assert.False(t, dead, "attempt %d", i)
+	}
+	dead, err := rStore.RecordSubmitFailure(ctx, run.ID.String(), "runner unavailable", 3)
+	require.NoError(t, err)
+	assert.True(t, dead)
+
+	got, err := rStore.GetRun(ctx, run.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.RunStatusFailed, got.Status)
+	require.NotNil(t, got.Error)
+	assert.Equal(t, "dead-lettered after 3 failed submit attempts: runner unavailable", *got.Error)
+	assert.NotNil(t, got.FinishedAt)
+
+	// No longer pending: further failures are ignored.
+	dead, err = rStore.RecordSubmitFailure(ctx, run.ID.String(), "runner unavailable", 3)
+	require.NoError(t, err)
+	assert.False(t, dead)
+
+	listed, err := rStore.ListRuns(ctx, api.RunFilter{DeadLettered: true})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, run.ID, listed[0].ID)
+}
+
+func TestRunStore_SaveRunPhases_RoundTripsThroughGetRun(t *testing.T) {
+	pool := testPool(t)
+	pStore := postgres.NewPipelineStore(pool)
+	rStore := postgres.NewRunStore(pool)
+	ctx := context.Background()
+
+	pipeline := createTestPipeline(t, pStore, "default", "bronze", "orders")
+	run := &domain.Run{PipelineID: pipeline.ID, Status: domain.RunStatusRunning, Trigger: "manual"}
+	require.NoError(t, rStore.CreateRun(ctx, run))
+
+	got, err := rStore.GetRun(ctx, run.ID.String())
+	require.NoError(t, err)
+	assert.Empty(t, got.Phases)
+
+	phases := []api.PhaseProfile{
+		{Name: "detect", DurationMs: 12},
+		{Name: "execute", DurationMs: 4300, Metadata: map[string]string{"pipeline_type": "sql"}},
+	}
+	require.NoError(t, rStore.SaveRunPhases(ctx, run.ID.String(), phases))
+
+	got, err = rStore.GetRun(ctx, run.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, phases, got.Phases)
+}
@@ -22,7 +22,7 @@ type ScheduleStore struct {
 
 // NewScheduleStore creates a ScheduleStore backed by the given pool.
 func NewScheduleStore(pool *pgxpool.Pool) *ScheduleStore {
-	return &ScheduleStore{q: gen.New(pool)}
+	return &ScheduleStore{q: gen.New(newTimeoutDB(pool))}
 }
 
 func (s *ScheduleStore) ListSchedules(ctx context.Context) ([]domain.Schedule, error) {
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SeatStore implements plugins.SeatStore on the license_seats table.
+type SeatStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewSeatStore creates a SeatStore backed by the given pool.
+func NewSeatStore(pool *pgxpool.Pool) *SeatStore {
+	return &SeatStore{pool: pool}
+}
+
+// ClaimSeat implements plugins.SeatStore. A seat holder active since
+// idleSince only has its timestamp bumped. Anyone else takes the table lock,
+// so two replicas can't both hand out the last seat, sweeps idle seats and
+// claims one if any is left.
+func (s *SeatStore) ClaimSeat(ctx context.Context, userID string, limit int, idleSince, now time.Time) (bool, error) {
+	admitted := false
+	err := InTx(ctx, s.pool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx,
+			`UPDATE license_seats SET last_seen_at = $2 WHERE user_id = $1 AND last_seen_at >= $3`,
+			userID, now, idleSince)
+		if err != nil {
+			return fmt.Errorf("touch seat: %w", err)
+		}
+		if tag.RowsAffected() > 0 {
+			admitted = true
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, `LOCK TABLE license_seats IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return fmt.Errorf("lock seats: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM license_seats WHERE last_seen_at < $1`, idleSince); err != nil {
+			return fmt.Errorf("free idle seats: %w", err)
+		}
+		var held int
+		if err := tx.QueryRow(ctx, `SELECT count(*) FROM license_seats`).Scan(&held); err != nil {
+			return fmt.Errorf("count seats: %w", err)
+		}
+		if held >= limit {
+			return nil
+		}
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO license_seats (user_id, last_seen_at) VALUES ($1, $2)
+			 ON CONFLICT (user_id) DO UPDATE SET last_seen_at = EXCLUDED.last_seen_at`,
+			userID, now); err != nil {
+			return fmt.Errorf("claim seat: %w", err)
+		}
+		admitted = true
+		return nil
+	})
+	return admitted, err
+}
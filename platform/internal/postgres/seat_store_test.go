@@ -0,0 +1,53 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rat-data/rat/platform/internal/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeatStore_ClaimSeat_EnforcesLimitAcrossStores(t *testing.T) {
+	pool := testPool(t)
+	// Two stores on one database stand in for two replicas.
+	a, b := postgres.NewSeatStore(pool), postgres.NewSeatStore(pool)
+	ctx := context.Background()
+	now := time.Now()
+	idleSince := now.Add(-time.Hour)
+
+	ok, err := a.ClaimSeat(ctx, "alice", 1, idleSince, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = b.ClaimSeat(ctx, "bob", 1, idleSince, now)
+	require.NoError(t, err)
+	assert.False(t, ok, "the other replica sees alice's seat")
+
+	ok, err = b.ClaimSeat(ctx, "alice", 1, idleSince, now)
+	require.NoError(t, err)
+	assert.True(t, ok, "a seat holder is admitted anywhere")
+}
+
+func TestSeatStore_ClaimSeat_FreesIdleSeats(t *testing.T) {
+	pool := testPool(t)
+	store := postgres.NewSeatStore(pool)
+	ctx := context.Background()
+	start := time.Now().Add(-2 * time.Hour)
+
+	ok, err := store.ClaimSeat(ctx, "alice", 1, start.Add(-time.Hour), start)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Alice has been idle past the window, so her seat goes to bob.
+	now := time.Now()
+	ok, err = store.ClaimSeat(ctx, "bob", 1, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = store.ClaimSeat(ctx, "alice", 1, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
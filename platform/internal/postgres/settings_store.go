@@ -8,28 +8,29 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
 )
 
 // SettingsStore implements api.SettingsStore backed by Postgres.
 type SettingsStore struct {
-	pool *pgxpool.Pool
+	db *timeoutDB
 }
 
 // NewSettingsStore creates a SettingsStore backed by the given pool.
 func NewSettingsStore(pool *pgxpool.Pool) *SettingsStore {
-	return &SettingsStore{pool: pool}
+	return &SettingsStore{db: newTimeoutDB(pool)}
 }
 
 // GetSetting returns the JSONB value for a given key from platform_settings.
 func (s *SettingsStore) GetSetting(ctx context.Context, key string) (json.RawMessage, error) {
 	var value json.RawMessage
-	err := s.pool.QueryRow(ctx,
+	err := s.db.QueryRow(ctx,
 		`SELECT value FROM platform_settings WHERE key = $1`, key,
 	).Scan(&value)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("setting %q not found", key)
+			return nil, fmt.Errorf("setting %q: %w", key, api.ErrSettingNotFound)
 		}
 		return nil, fmt.Errorf("get setting %q: %w", key, err)
 	}
@@ -38,7 +39,7 @@ func (s *SettingsStore) GetSetting(ctx context.Context, key string) (json.RawMes
 
 // PutSetting upserts a JSONB value for a given key in platform_settings.
 func (s *SettingsStore) PutSetting(ctx context.Context, key string, value json.RawMessage) error {
-	_, err := s.pool.Exec(ctx,
+	_, err := s.db.Exec(ctx,
 		`INSERT INTO platform_settings (key, value, updated_at) VALUES ($1, $2, NOW())
 		 ON CONFLICT (key) DO UPDATE SET value = $2, updated_at = NOW()`,
 		key, value,
@@ -61,15 +62,16 @@ func (s *SettingsStore) GetReaperStatus(ctx context.Context) (*domain.ReaperStat
 		branchesCleaned int
 		lzFilesCleaned int
 		auditPruned    int
+		zonesPurged    int
 		updatedAt      time.Time
 	)
 
-	err := s.pool.QueryRow(ctx,
+	err := s.db.QueryRow(ctx,
 		`SELECT last_run_at, runs_pruned, logs_pruned, quality_pruned, pipelines_purged,
-		        runs_failed, branches_cleaned, lz_files_cleaned, audit_pruned, updated_at
+		        runs_failed, branches_cleaned, lz_files_cleaned, audit_pruned, zones_purged, updated_at
 		 FROM reaper_status WHERE id = 1`,
 	).Scan(&lastRunAt, &runsPruned, &logsPruned, &qualityPruned, &pipelinesPurged,
-		&runsFailed, &branchesCleaned, &lzFilesCleaned, &auditPruned, &updatedAt)
+		&runsFailed, &branchesCleaned, &lzFilesCleaned, &auditPruned, &zonesPurged, &updatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("get reaper status: %w", err)
 	}
@@ -84,6 +86,7 @@ func (s *SettingsStore) GetReaperStatus(ctx context.Context) (*domain.ReaperStat
 		BranchesCleaned: branchesCleaned,
 		LZFilesCleaned:  lzFilesCleaned,
 		AuditPruned:     auditPruned,
+		ZonesPurged:     zonesPurged,
 		UpdatedAt:       updatedAt,
 	}, nil
 }
@@ -116,7 +119,7 @@ func (s *SettingsStore) PutFeatureFlags(ctx context.Context, flags *domain.Featu
 
 // UpdateReaperStatus updates the singleton reaper status row with the latest run stats.
 func (s *SettingsStore) UpdateReaperStatus(ctx context.Context, status *domain.ReaperStatus) error {
-	_, err := s.pool.Exec(ctx,
+	_, err := s.db.Exec(ctx,
 		`UPDATE reaper_status SET
 			last_run_at = NOW(),
 			runs_pruned = $1,
@@ -127,10 +130,12 @@ func (s *SettingsStore) UpdateReaperStatus(ctx context.Context, status *domain.R
 			branches_cleaned = $6,
 			lz_files_cleaned = $7,
 			audit_pruned = $8,
+			zones_purged = $9,
 			updated_at = NOW()
 		 WHERE id = 1`,
 		status.RunsPruned, status.LogsPruned, status.QualityPruned, status.PipelinesPurged,
 		status.RunsFailed, status.BranchesCleaned, status.LZFilesCleaned, status.AuditPruned,
+		status.ZonesPurged,
 	)
 	if err != nil {
 		return fmt.Errorf("update reaper status: %w", err)
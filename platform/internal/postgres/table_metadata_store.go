@@ -19,7 +19,7 @@ type TableMetadataStore struct {
 
 // NewTableMetadataStore creates a TableMetadataStore backed by the given pool.
 func NewTableMetadataStore(pool *pgxpool.Pool) *TableMetadataStore {
-	return &TableMetadataStore{q: gen.New(pool)}
+	return &TableMetadataStore{q: gen.New(newTimeoutDB(pool))}
 }
 
 func tableMetadatumToDomain(r gen.TableMetadatum) domain.TableMetadata {
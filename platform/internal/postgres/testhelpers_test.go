@@ -47,9 +47,11 @@ func cleanTables(t *testing.T, pool *pgxpool.Pool) {
 		"landing_files", "landing_zones",
 		"quality_results", "quality_tests",
 		"pipeline_triggers", "schedules", "runs", "pipelines", "namespaces",
+		"table_metadata",
 		// Renamed from "plugins" in migration 016. The old slot-based table
 		// no longer exists.
 		"plugin_catalog",
+		"license_seats",
 	}
 	for _, table := range tables {
 		if _, err := pool.Exec(ctx, "TRUNCATE "+table+" CASCADE"); err != nil {
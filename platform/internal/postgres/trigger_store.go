@@ -18,12 +18,14 @@ import (
 
 // TriggerStore implements api.PipelineTriggerStore backed by Postgres.
 type TriggerStore struct {
-	q *gen.Queries
+	db *timeoutDB
+	q  *gen.Queries
 }
 
 // NewTriggerStore creates a TriggerStore backed by the given pool.
 func NewTriggerStore(pool *pgxpool.Pool) *TriggerStore {
-	return &TriggerStore{q: gen.New(pool)}
+	db := newTimeoutDB(pool)
+	return &TriggerStore{db: db, q: gen.New(db)}
 }
 
 func (s *TriggerStore) ListTriggers(ctx context.Context, pipelineID uuid.UUID) ([]domain.PipelineTrigger, error) {
@@ -106,6 +108,19 @@ func (s *TriggerStore) UpdateTrigger(ctx context.Context, triggerID string, upda
 	return &trigger, nil
 }
 
+// SetTriggersEnabledByPipeline enables or disables every trigger of a pipeline
+// in one statement. Returns how many triggers changed state.
+func (s *TriggerStore) SetTriggersEnabledByPipeline(ctx context.Context, pipelineID uuid.UUID, enabled bool) (int, error) {
+	n, err := s.q.SetTriggersEnabledByPipeline(ctx, gen.SetTriggersEnabledByPipelineParams{
+		PipelineID: pipelineID,
+		Enabled:    enabled,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("set triggers enabled by pipeline: %w", err)
+	}
+	return int(n), nil
+}
+
 func (s *TriggerStore) DeleteTrigger(ctx context.Context, triggerID string) error {
 	uid, err := uuid.Parse(triggerID)
 	if err != nil {
@@ -201,6 +216,17 @@ func (s *TriggerStore) UpdateTriggerFired(ctx context.Context, triggerID string,
 	})
 }
 
+func (s *TriggerStore) UpdateTriggerBackoffStreak(ctx context.Context, triggerID string, streak int) error {
+	uid, err := uuid.Parse(triggerID)
+	if err != nil {
+		return fmt.Errorf("invalid trigger id: %w", err)
+	}
+	return s.q.UpdateTriggerBackoffStreak(ctx, gen.UpdateTriggerBackoffStreakParams{
+		ID:            uid,
+		BackoffStreak: int32(streak),
+	})
+}
+
 // UpdateTriggerFiredCAS performs a compare-and-swap on the trigger fire state.
 // It only updates when the row's current last_triggered_at matches expectedPrev
 // (NULL == NULL counts as a match — handled by IS NOT DISTINCT FROM at the SQL
@@ -235,6 +261,76 @@ func (s *TriggerStore) UpdateTriggerFiredCAS(
 	return true, nil
 }
 
+// triggerWhereClause builds the WHERE clause and args for the global trigger
+// listing. Soft-deleted pipelines are always excluded.
+func triggerWhereClause(filter api.TriggerFilter) (string, []interface{}, int) {
+	where := ` WHERE p.deleted_at IS NULL`
+	args := []interface{}{}
+	argN := 1
+
+	if filter.Type != "" {
+		where += fmt.Sprintf(" AND t.type = $%d", argN)
+		args = append(args, filter.Type)
+		argN++
+	}
+	if filter.Enabled != nil {
+		where += fmt.Sprintf(" AND t.enabled = $%d", argN)
+		args = append(args, *filter.Enabled)
+		argN++
+	}
+	if filter.Namespace != "" {
+		where += fmt.Sprintf(" AND p.namespace = $%d", argN)
+		args = append(args, filter.Namespace)
+		argN++
+	}
+	return where, args, argN
+}
+
+// ListAllTriggers returns triggers across all pipelines joined to their
+// pipeline's identity, newest first, plus the unpaged match count.
+func (s *TriggerStore) ListAllTriggers(ctx context.Context, filter api.TriggerFilter) ([]domain.PipelineTriggerListItem, int, error) {
+	where, args, argN := triggerWhereClause(filter)
+	from := ` FROM pipeline_triggers t JOIN pipelines p ON p.id = t.pipeline_id`
+
+	var total int
+	if err := s.db.QueryRow(ctx, `SELECT COUNT(*)`+from+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count triggers: %w", err)
+	}
+
+	query := `SELECT t.id, t.pipeline_id, t.type, t.config, t.enabled, t.cooldown_seconds,
+	       t.last_triggered_at, t.last_run_id, t.created_at, t.updated_at, t.backoff_streak,
+	       p.namespace, p.layer, p.name` + from + where + ` ORDER BY t.created_at DESC, t.id`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argN, argN+1)
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list all triggers: %w", err)
+	}
+	defer rows.Close()
+
+	items := []domain.PipelineTriggerListItem{}
+	for rows.Next() {
+		var r gen.PipelineTrigger
+		var item domain.PipelineTriggerListItem
+		var layer string
+		if err := rows.Scan(&r.ID, &r.PipelineID, &r.Type, &r.Config, &r.Enabled, &r.CooldownSeconds,
+			&r.LastTriggeredAt, &r.LastRunID, &r.CreatedAt, &r.UpdatedAt, &r.BackoffStreak,
+			&item.Namespace, &layer, &item.PipelineName); err != nil {
+			return nil, 0, fmt.Errorf("scan trigger: %w", err)
+		}
+		item.PipelineTrigger = triggerRowToDomain(r)
+		item.Layer = domain.Layer(layer)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate triggers: %w", err)
+	}
+	return items, total, nil
+}
+
 func triggerRowToDomain(r gen.PipelineTrigger) domain.PipelineTrigger {
 	trigger := domain.PipelineTrigger{
 		ID:              r.ID,
@@ -244,6 +340,7 @@ func triggerRowToDomain(r gen.PipelineTrigger) domain.PipelineTrigger {
 		Enabled:         r.Enabled,
 		CooldownSeconds: int(r.CooldownSeconds),
 		LastTriggeredAt: r.LastTriggeredAt,
+		BackoffStreak:   int(r.BackoffStreak),
 		CreatedAt:       r.CreatedAt,
 		UpdatedAt:       r.UpdatedAt,
 	}
@@ -71,8 +71,8 @@ func (t *TxRunner) InTx(ctx context.Context, fn func(api.TxStores) error) error
 	return InTx(ctx, t.pool, func(tx pgx.Tx) error {
 		txQ := gen.New(tx)
 		return fn(api.TxStores{
-			Runs:      &RunStore{pool: t.pool, q: txQ},
-			Triggers:  &TriggerStore{q: txQ},
+			Runs:      &RunStore{db: newTimeoutDB(t.pool), q: txQ},
+			Triggers:  &TriggerStore{db: newTimeoutDB(t.pool), q: txQ},
 			Schedules: &ScheduleStore{q: txQ},
 		})
 	})
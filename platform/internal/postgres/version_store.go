@@ -14,16 +14,16 @@ import (
 
 // VersionStore implements api.VersionStore backed by Postgres.
 type VersionStore struct {
-	pool *pgxpool.Pool
+	db *timeoutDB
 }
 
 // NewVersionStore creates a VersionStore backed by the given pool.
 func NewVersionStore(pool *pgxpool.Pool) *VersionStore {
-	return &VersionStore{pool: pool}
+	return &VersionStore{db: newTimeoutDB(pool)}
 }
 
 func (s *VersionStore) ListVersions(ctx context.Context, pipelineID uuid.UUID) ([]domain.PipelineVersion, error) {
-	rows, err := s.pool.Query(ctx,
+	rows, err := s.db.Query(ctx,
 		`SELECT id, pipeline_id, version_number, message, published_versions, created_at
 		 FROM pipeline_versions WHERE pipeline_id = $1
 		 ORDER BY version_number DESC`, pipelineID)
@@ -44,7 +44,7 @@ func (s *VersionStore) ListVersions(ctx context.Context, pipelineID uuid.UUID) (
 }
 
 func (s *VersionStore) GetVersion(ctx context.Context, pipelineID uuid.UUID, versionNumber int) (*domain.PipelineVersion, error) {
-	row := s.pool.QueryRow(ctx,
+	row := s.db.QueryRow(ctx,
 		`SELECT id, pipeline_id, version_number, message, published_versions, created_at
 		 FROM pipeline_versions WHERE pipeline_id = $1 AND version_number = $2`,
 		pipelineID, versionNumber)
@@ -65,7 +65,7 @@ func (s *VersionStore) CreateVersion(ctx context.Context, v *domain.PipelineVers
 		return fmt.Errorf("marshal published versions: %w", err)
 	}
 
-	err = s.pool.QueryRow(ctx,
+	err = s.db.QueryRow(ctx,
 		`INSERT INTO pipeline_versions (pipeline_id, version_number, message, published_versions)
 		 VALUES ($1, $2, $3, $4)
 		 RETURNING id, created_at`,
@@ -77,7 +77,7 @@ func (s *VersionStore) CreateVersion(ctx context.Context, v *domain.PipelineVers
 }
 
 func (s *VersionStore) PruneVersions(ctx context.Context, pipelineID uuid.UUID, keepCount int) error {
-	_, err := s.pool.Exec(ctx,
+	_, err := s.db.Exec(ctx,
 		`DELETE FROM pipeline_versions
 		 WHERE pipeline_id = $1 AND version_number NOT IN (
 			SELECT version_number FROM pipeline_versions
@@ -92,7 +92,7 @@ func (s *VersionStore) PruneVersions(ctx context.Context, pipelineID uuid.UUID,
 
 func (s *VersionStore) LatestVersionNumber(ctx context.Context, pipelineID uuid.UUID) (int, error) {
 	var n int
-	err := s.pool.QueryRow(ctx,
+	err := s.db.QueryRow(ctx,
 		`SELECT COALESCE(MAX(version_number), 0) FROM pipeline_versions WHERE pipeline_id = $1`,
 		pipelineID).Scan(&n)
 	if err != nil {
@@ -3,8 +3,10 @@ package reaper
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rat-data/rat/platform/internal/api"
@@ -20,7 +22,7 @@ const stuckPendingTimeout = 24 * time.Hour
 
 // Reaper is a background daemon that enforces data retention policies.
 // It periodically cleans up old runs, logs, quality results, orphan branches,
-// soft-deleted pipelines, and processed landing zone files.
+// soft-deleted pipelines and landing zones, and processed landing zone files.
 type Reaper struct {
 	settings     api.SettingsStore
 	runs         api.RunStore
@@ -30,6 +32,8 @@ type Reaper struct {
 	audit        api.AuditStore
 	failedMerges api.FailedMergesStore // optional: branches with recent rows are NOT swept.
 	nessie       NessieClient
+	dryRun       bool
+	tickMu       sync.Mutex // serialises ticks: scheduled and RunNow cycles never overlap
 	cancel       context.CancelFunc
 	done         chan struct{}
 }
@@ -40,6 +44,14 @@ type Reaper struct {
 // tested but couldn't merge to main — an operator needs time to recover.
 const failedMergeRetentionDays = 7
 
+// Options tunes Reaper behaviour.
+type Options struct {
+	// DryRun makes every tick count what it would clean up instead of
+	// deleting or failing anything. Counts are logged; the stored reaper
+	// status is left untouched.
+	DryRun bool
+}
+
 // New creates a Reaper with the given store dependencies.
 //
 // `failedMerges` may be nil (dev mode without Postgres) — in that case the
@@ -54,6 +66,7 @@ func New(
 	audit api.AuditStore,
 	failedMerges api.FailedMergesStore,
 	nessie NessieClient,
+	opts Options,
 ) *Reaper {
 	return &Reaper{
 		settings:     settings,
@@ -64,6 +77,7 @@ func New(
 		audit:        audit,
 		failedMerges: failedMerges,
 		nessie:       nessie,
+		dryRun:       opts.DryRun,
 	}
 }
 
@@ -123,120 +137,185 @@ func (r *Reaper) Stop() {
 	}
 }
 
-// RunNow triggers a manual reaper run and returns the resulting stats.
+// RunNow triggers a manual reaper run and returns the resulting stats — the
+// counts from this run alone. If a cycle is already in progress it waits for
+// it to finish first, so the two never delete concurrently.
 func (r *Reaper) RunNow(ctx context.Context) (*domain.ReaperStatus, error) {
 	return r.tick(ctx), nil
 }
 
-// tick executes all retention tasks. Each task is isolated — a failure in one
-// does not prevent the others from running.
+// Preview computes what a reaper run would clean up right now without
+// deleting anything, regardless of Options.DryRun.
+func (r *Reaper) Preview(ctx context.Context) (*domain.RetentionPreview, error) {
+	return r.sweep(ctx, true), nil
+}
+
+// tick executes all retention tasks (or, in dry-run mode, counts what they
+// would do) and records the outcome as the reaper status.
 func (r *Reaper) tick(ctx context.Context) *domain.ReaperStatus {
+	r.tickMu.Lock()
+	defer r.tickMu.Unlock()
+
+	preview := r.sweep(ctx, r.dryRun)
+	status := &domain.ReaperStatus{
+		RunsPruned:      preview.RunsBeyondLimit + preview.RunsOlderThan,
+		RunsFailed:      preview.RunsFailed,
+		PipelinesPurged: preview.PipelinesPurged,
+		ZonesPurged:     preview.ZonesPurged,
+		BranchesCleaned: preview.BranchesCleaned,
+		LZFilesCleaned:  preview.LZFilesCleaned,
+		AuditPruned:     preview.AuditPruned,
+	}
+
+	if r.dryRun {
+		slog.Info("reaper: dry-run tick complete (nothing deleted)",
+			"runs_beyond_limit", preview.RunsBeyondLimit,
+			"runs_older_than", preview.RunsOlderThan,
+			"runs_failed", preview.RunsFailed,
+			"pipelines_purged", preview.PipelinesPurged,
+			"zones_purged", preview.ZonesPurged,
+			"branches_cleaned", preview.BranchesCleaned,
+			"lz_files_cleaned", preview.LZFilesCleaned,
+			"audit_pruned", preview.AuditPruned,
+		)
+		return status
+	}
+
+	// Save status
+	if r.settings != nil {
+		if err := r.settings.UpdateReaperStatus(ctx, status); err != nil {
+			slog.Error("reaper: failed to update status", "error", err)
+		}
+	}
+
+	slog.Info("reaper: tick complete",
+		"runs_pruned", status.RunsPruned,
+		"runs_failed", status.RunsFailed,
+		"pipelines_purged", status.PipelinesPurged,
+		"zones_purged", status.ZonesPurged,
+		"branches_cleaned", status.BranchesCleaned,
+		"lz_files_cleaned", status.LZFilesCleaned,
+		"audit_pruned", status.AuditPruned,
+	)
+
+	return status
+}
+
+// sweep runs all retention tasks. With dryRun every task only counts what it
+// would delete or fail. Each task is isolated — a failure in one does not
+// prevent the others from running.
+func (r *Reaper) sweep(ctx context.Context, dryRun bool) *domain.RetentionPreview {
 	cfg := r.loadConfig(ctx)
 	now := time.Now()
-	status := &domain.ReaperStatus{}
+	res := &domain.RetentionPreview{}
 
 	// Task 1: Prune old runs per pipeline
 	r.safeRun("pruneRuns", func() {
-		count := r.pruneRuns(ctx, cfg, now)
-		status.RunsPruned = count
+		res.RunsBeyondLimit, res.RunsOlderThan = r.pruneRuns(ctx, cfg, now, dryRun)
 	})
 
 	// Task 2: Fail stuck runs (RUNNING > StuckRunTimeoutMinutes)
 	r.safeRun("failStuckRuns", func() {
-		count := r.failStuckRuns(ctx, cfg, now)
-		status.RunsFailed = count
+		res.RunsFailed = r.failStuckRuns(ctx, cfg, now, dryRun)
 	})
 
 	// Task 2b: Fail stuck PENDING runs (PENDING > 24h — executor never picked them up)
 	r.safeRun("failStuckPendingRuns", func() {
-		count := r.failStuckPendingRuns(ctx, now)
-		status.RunsFailed += count
+		res.RunsFailed += r.failStuckPendingRuns(ctx, now, dryRun)
 	})
 
 	// Task 3: Purge soft-deleted pipelines
 	r.safeRun("purgeSoftDeleted", func() {
-		count := r.purgeSoftDeletedPipelines(ctx, cfg, now)
-		status.PipelinesPurged = count
+		res.PipelinesPurged = r.purgeSoftDeletedPipelines(ctx, cfg, now, dryRun)
+	})
+
+	// Task 3b: Purge soft-deleted landing zones (same grace period as pipelines)
+	r.safeRun("purgeSoftDeletedZones", func() {
+		res.ZonesPurged = r.purgeSoftDeletedZones(ctx, cfg, now, dryRun)
 	})
 
 	// Task 4: Clean orphan Nessie branches
 	r.safeRun("cleanOrphanBranches", func() {
-		count := r.cleanOrphanBranches(ctx, cfg, now)
-		status.BranchesCleaned = count
+		res.BranchesCleaned = r.cleanOrphanBranches(ctx, cfg, now, dryRun)
 	})
 
 	// Task 5: Purge processed landing zone files
 	r.safeRun("purgeProcessedLZ", func() {
-		count := r.purgeProcessedLZFiles(ctx, now)
-		status.LZFilesCleaned = count
+		res.LZFilesCleaned = r.purgeProcessedLZFiles(ctx, now, dryRun)
 	})
 
 	// Task 6: Prune audit log
 	r.safeRun("pruneAuditLog", func() {
-		count := r.pruneAuditLog(ctx, cfg, now)
-		status.AuditPruned = count
+		res.AuditPruned = r.pruneAuditLog(ctx, cfg, now, dryRun)
 	})
 
-	// Save status
-	if r.settings != nil {
-		if err := r.settings.UpdateReaperStatus(ctx, status); err != nil {
-			slog.Error("reaper: failed to update status", "error", err)
-		}
-	}
-
-	slog.Info("reaper: tick complete",
-		"runs_pruned", status.RunsPruned,
-		"runs_failed", status.RunsFailed,
-		"pipelines_purged", status.PipelinesPurged,
-		"branches_cleaned", status.BranchesCleaned,
-		"lz_files_cleaned", status.LZFilesCleaned,
-		"audit_pruned", status.AuditPruned,
-	)
-
-	return status
+	return res
 }
 
-// pruneRuns deletes runs beyond the per-pipeline limit and past the max age.
-func (r *Reaper) pruneRuns(ctx context.Context, cfg domain.RetentionConfig, now time.Time) int {
+// pruneRuns deletes runs beyond the per-pipeline limit and past the max age,
+// returning both counts. With dryRun the runs are only counted.
+//
+// Both limits come from each pipeline's effective retention config:
+// pipeline overrides > namespace overrides > the system config. Soft-deleted
+// pipelines are pruned too until purgeSoftDeletedPipelines hard-deletes them,
+// which can be long after their runs age out.
+func (r *Reaper) pruneRuns(ctx context.Context, cfg domain.RetentionConfig, now time.Time, dryRun bool) (beyondLimit, olderThan int) {
 	if r.runs == nil || r.pipelines == nil {
-		return 0
+		return 0, 0
 	}
 
-	total := 0
+	deleteBeyondLimit, deleteOlderThan := r.runs.DeleteRunsBeyondLimit, r.runs.DeleteRunsOlderThan
+	if dryRun {
+		deleteBeyondLimit, deleteOlderThan = r.runs.CountRunsBeyondLimit, r.runs.CountRunsOlderThan
+	}
 
-	// Per-pipeline count-based pruning
 	pipelines, err := r.pipelines.ListPipelines(ctx, api.PipelineFilter{})
 	if err != nil {
 		slog.Error("reaper: failed to list pipelines for run pruning", "error", err)
-		return 0
+		return 0, 0
 	}
+	// ListPipelines hides soft-deleted pipelines; every deletion is before now.
+	deleted, err := r.pipelines.ListSoftDeletedPipelines(ctx, now)
+	if err != nil {
+		slog.Warn("reaper: failed to list soft-deleted pipelines for run pruning", "error", err)
+	}
+	pipelines = append(pipelines, deleted...)
 
+	nsOverrides := map[string]json.RawMessage{}
 	for _, p := range pipelines {
-		count, err := r.runs.DeleteRunsBeyondLimit(ctx, p.ID, cfg.RunsMaxPerPipeline)
-		if err != nil {
-			slog.Warn("reaper: failed to prune runs for pipeline", "pipeline_id", p.ID, "error", err)
-			continue
+		overrides, ok := nsOverrides[p.Namespace]
+		if !ok {
+			overrides = r.loadNamespaceOverrides(ctx, p.Namespace)
+			nsOverrides[p.Namespace] = overrides
 		}
-		total += count
-	}
+		effective := api.EffectiveRetentionConfig(cfg, overrides, p.RetentionConfig)
 
-	// Age-based pruning
-	if cfg.RunsMaxAgeDays > 0 {
-		cutoff := now.Add(-time.Duration(cfg.RunsMaxAgeDays) * 24 * time.Hour)
-		count, err := r.runs.DeleteRunsOlderThan(ctx, cutoff)
+		// Count-based pruning
+		count, err := deleteBeyondLimit(ctx, p.ID, effective.RunsMaxPerPipeline)
 		if err != nil {
-			slog.Error("reaper: failed to delete old runs", "error", err)
+			slog.Warn("reaper: failed to prune runs for pipeline", "pipeline_id", p.ID, "error", err)
 		} else {
-			total += count
+			beyondLimit += count
+		}
+
+		// Age-based pruning
+		if effective.RunsMaxAgeDays > 0 {
+			cutoff := now.Add(-time.Duration(effective.RunsMaxAgeDays) * 24 * time.Hour)
+			count, err := deleteOlderThan(ctx, p.ID, cutoff)
+			if err != nil {
+				slog.Warn("reaper: failed to delete old runs for pipeline", "pipeline_id", p.ID, "error", err)
+			} else {
+				olderThan += count
+			}
 		}
 	}
 
-	return total
+	return beyondLimit, olderThan
 }
 
 // failStuckRuns marks RUNNING runs as failed if they exceed the timeout.
 // PENDING runs use a separate, longer grace window — see failStuckPendingRuns.
-func (r *Reaper) failStuckRuns(ctx context.Context, cfg domain.RetentionConfig, now time.Time) int {
+func (r *Reaper) failStuckRuns(ctx context.Context, cfg domain.RetentionConfig, now time.Time, dryRun bool) int {
 	if r.runs == nil {
 		return 0
 	}
@@ -247,6 +326,9 @@ func (r *Reaper) failStuckRuns(ctx context.Context, cfg domain.RetentionConfig,
 		slog.Error("reaper: failed to list stuck runs", "error", err)
 		return 0
 	}
+	if dryRun {
+		return len(stuckRuns)
+	}
 
 	count := 0
 	for _, run := range stuckRuns {
@@ -265,7 +347,7 @@ func (r *Reaper) failStuckRuns(ctx context.Context, cfg domain.RetentionConfig,
 // case where the executor crashed during dispatch and the run was never started.
 // Without this, the run stays PENDING forever and its Nessie branch is never
 // reaped — branches accumulate slowly until Nessie disk fills.
-func (r *Reaper) failStuckPendingRuns(ctx context.Context, now time.Time) int {
+func (r *Reaper) failStuckPendingRuns(ctx context.Context, now time.Time, dryRun bool) int {
 	if r.runs == nil {
 		return 0
 	}
@@ -276,6 +358,9 @@ func (r *Reaper) failStuckPendingRuns(ctx context.Context, now time.Time) int {
 		slog.Error("reaper: failed to list stuck pending runs", "error", err)
 		return 0
 	}
+	if dryRun {
+		return len(stuck)
+	}
 
 	count := 0
 	for _, run := range stuck {
@@ -290,7 +375,7 @@ func (r *Reaper) failStuckPendingRuns(ctx context.Context, now time.Time) int {
 }
 
 // purgeSoftDeletedPipelines hard-deletes pipelines that were soft-deleted beyond the purge period.
-func (r *Reaper) purgeSoftDeletedPipelines(ctx context.Context, cfg domain.RetentionConfig, now time.Time) int {
+func (r *Reaper) purgeSoftDeletedPipelines(ctx context.Context, cfg domain.RetentionConfig, now time.Time, dryRun bool) int {
 	if r.pipelines == nil {
 		return 0
 	}
@@ -301,6 +386,9 @@ func (r *Reaper) purgeSoftDeletedPipelines(ctx context.Context, cfg domain.Reten
 		slog.Error("reaper: failed to list soft-deleted pipelines", "error", err)
 		return 0
 	}
+	if dryRun {
+		return len(pipelines)
+	}
 
 	count := 0
 	for _, p := range pipelines {
@@ -323,13 +411,61 @@ func (r *Reaper) purgeSoftDeletedPipelines(ctx context.Context, cfg domain.Reten
 	return count
 }
 
+// purgeSoftDeletedZones hard-deletes landing zones that were soft-deleted
+// beyond the purge period, removing their uploaded and sample files from S3.
+func (r *Reaper) purgeSoftDeletedZones(ctx context.Context, cfg domain.RetentionConfig, now time.Time, dryRun bool) int {
+	if r.zones == nil {
+		return 0
+	}
+
+	cutoff := now.Add(-time.Duration(cfg.SoftDeletePurgeDays) * 24 * time.Hour)
+	zones, err := r.zones.ListSoftDeletedZones(ctx, cutoff)
+	if err != nil {
+		slog.Error("reaper: failed to list soft-deleted landing zones", "error", err)
+		return 0
+	}
+	if dryRun {
+		return len(zones)
+	}
+
+	count := 0
+	for _, z := range zones {
+		// Delete S3 files first (best-effort). Uploads are removed by their
+		// tracked paths; the shared _samples/ prefix only when no live zone
+		// has since reused the name.
+		if r.storage != nil {
+			files, err := r.zones.ListFiles(ctx, z.ID)
+			if err == nil {
+				for _, f := range files {
+					_ = r.storage.DeleteFile(ctx, f.S3Path)
+				}
+			}
+			if live, err := r.zones.GetZone(ctx, z.Namespace, z.Name); err == nil && live == nil {
+				samplesPrefix := z.Namespace + "/landing/" + z.Name + "/_samples/"
+				if samples, err := r.storage.ListFiles(ctx, samplesPrefix); err == nil {
+					for _, sf := range samples {
+						_ = r.storage.DeleteFile(ctx, sf.Path)
+					}
+				}
+			}
+		}
+
+		if err := r.zones.HardDeleteZone(ctx, z.ID); err != nil {
+			slog.Warn("reaper: failed to hard-delete landing zone", "zone_id", z.ID, "error", err)
+			continue
+		}
+		count++
+	}
+	return count
+}
+
 // cleanOrphanBranches deletes Nessie branches named "run-*" that have no active run.
 //
 // Branches that appear in failed_merges within the last failedMergeRetentionDays
 // are SKIPPED — they hold data that Phase 3 wrote and Phase 4 quality-tested,
 // but which couldn't reach main, and a human needs to recover them.
 // Stuck-PENDING runs (>24h) also free their branches as a safety net.
-func (r *Reaper) cleanOrphanBranches(ctx context.Context, _ domain.RetentionConfig, now time.Time) int {
+func (r *Reaper) cleanOrphanBranches(ctx context.Context, cfg domain.RetentionConfig, now time.Time, dryRun bool) int {
 	if r.nessie == nil || r.runs == nil {
 		return 0
 	}
@@ -358,6 +494,7 @@ func (r *Reaper) cleanOrphanBranches(ctx context.Context, _ domain.RetentionConf
 	}
 
 	pendingCutoff := now.Add(-stuckPendingTimeout)
+	runningCutoff := now.Add(-time.Duration(cfg.StuckRunTimeoutMinutes) * time.Minute)
 
 	count := 0
 	for _, b := range branches {
@@ -391,7 +528,16 @@ func (r *Reaper) cleanOrphanBranches(ctx context.Context, _ domain.RetentionConf
 		terminal := run != nil && (run.Status == domain.RunStatusSuccess ||
 			run.Status == domain.RunStatusFailed || run.Status == domain.RunStatusCancelled)
 
-		if run == nil || terminal || stalePending {
+		// A dry run fails nothing, so runs failStuckRuns would have failed
+		// earlier in the tick are still RUNNING here — count them as terminal.
+		wouldFail := dryRun && run != nil && run.Status == domain.RunStatusRunning &&
+			run.CreatedAt.Before(runningCutoff)
+
+		if run == nil || terminal || stalePending || wouldFail {
+			if dryRun {
+				count++
+				continue
+			}
 			if err := r.nessie.DeleteBranch(ctx, b.Name, b.Hash); err != nil {
 				slog.Warn("reaper: failed to delete orphan branch", "branch", b.Name, "error", err)
 				continue
@@ -403,7 +549,7 @@ func (r *Reaper) cleanOrphanBranches(ctx context.Context, _ domain.RetentionConf
 }
 
 // purgeProcessedLZFiles deletes _processed/ files from landing zones with auto_purge enabled.
-func (r *Reaper) purgeProcessedLZFiles(ctx context.Context, now time.Time) int {
+func (r *Reaper) purgeProcessedLZFiles(ctx context.Context, now time.Time, dryRun bool) int {
 	if r.zones == nil || r.storage == nil {
 		return 0
 	}
@@ -431,6 +577,10 @@ func (r *Reaper) purgeProcessedLZFiles(ctx context.Context, now time.Time) int {
 
 		for _, f := range files {
 			if f.Modified.Before(cutoff) {
+				if dryRun {
+					count++
+					continue
+				}
 				if err := r.storage.DeleteFile(ctx, f.Path); err != nil {
 					slog.Warn("reaper: failed to delete processed file", "path", f.Path, "error", err)
 					continue
@@ -442,14 +592,26 @@ func (r *Reaper) purgeProcessedLZFiles(ctx context.Context, now time.Time) int {
 	return count
 }
 
-// pruneAuditLog deletes audit entries older than the configured max age.
-func (r *Reaper) pruneAuditLog(ctx context.Context, cfg domain.RetentionConfig, now time.Time) int {
+// pruneAuditLog deletes audit entries older than AuditLogMaxAgeDays. The
+// audit horizon is independent of run and log retention: compliance usually
+// wants audit entries kept far longer than run history. A non-positive
+// horizon skips pruning rather than wiping the log.
+func (r *Reaper) pruneAuditLog(ctx context.Context, cfg domain.RetentionConfig, now time.Time, dryRun bool) int {
 	if r.audit == nil {
 		return 0
 	}
+	if cfg.AuditLogMaxAgeDays <= 0 {
+		slog.Warn("reaper: audit_log_max_age_days is not positive, skipping audit pruning",
+			"audit_log_max_age_days", cfg.AuditLogMaxAgeDays)
+		return 0
+	}
 
+	prune := r.audit.DeleteOlderThan
+	if dryRun {
+		prune = r.audit.CountOlderThan
+	}
 	cutoff := now.Add(-time.Duration(cfg.AuditLogMaxAgeDays) * 24 * time.Hour)
-	count, err := r.audit.DeleteOlderThan(ctx, cutoff)
+	count, err := prune(ctx, cutoff)
 	if err != nil {
 		slog.Error("reaper: failed to prune audit log", "error", err)
 		return 0
@@ -470,7 +632,9 @@ func (r *Reaper) loadConfig(ctx context.Context) domain.RetentionConfig {
 		return domain.DefaultRetentionConfig()
 	}
 
-	var cfg domain.RetentionConfig
+	// Unmarshal over the defaults so a config saved before a field existed
+	// keeps that field's default instead of zero.
+	cfg := domain.DefaultRetentionConfig()
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		slog.Warn("reaper: failed to unmarshal retention config, using defaults", "error", err)
 		return domain.DefaultRetentionConfig()
@@ -478,6 +642,23 @@ func (r *Reaper) loadConfig(ctx context.Context) domain.RetentionConfig {
 	return cfg
 }
 
+// loadNamespaceOverrides returns a namespace's retention overrides, or nil
+// when it has none. Read errors are logged and treated as no overrides.
+func (r *Reaper) loadNamespaceOverrides(ctx context.Context, namespace string) json.RawMessage {
+	if r.settings == nil {
+		return nil
+	}
+	data, err := r.settings.GetSetting(ctx, api.NamespaceRetentionKey(namespace))
+	if err != nil {
+		if !errors.Is(err, api.ErrSettingNotFound) {
+			slog.Warn("reaper: failed to load namespace retention, using system config",
+				"namespace", namespace, "error", err)
+		}
+		return nil
+	}
+	return data
+}
+
 // safeRun executes fn with panic recovery to isolate task failures.
 func (r *Reaper) safeRun(name string, fn func()) {
 	defer func() {
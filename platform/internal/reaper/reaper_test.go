@@ -36,7 +36,7 @@ func (m *mockSettingsStore) GetSetting(_ context.Context, key string) (json.RawM
 	defer m.mu.Unlock()
 	v, ok := m.settings[key]
 	if !ok {
-		return nil, fmt.Errorf("not found")
+		return nil, fmt.Errorf("setting %q: %w", key, api.ErrSettingNotFound)
 	}
 	return v, nil
 }
@@ -65,12 +65,16 @@ type mockRunStore struct {
 	mu   sync.Mutex
 	runs []domain.Run
 	// Track calls
-	deletedBeyondLimit map[uuid.UUID]int
+	deletedBeyondLimit map[uuid.UUID]int       // pipeline → keepCount
+	olderThanCutoffs   map[uuid.UUID]time.Time // pipeline → age cutoff
 	deletedOlderThan   int
 }
 
 func newMockRunStore() *mockRunStore {
-	return &mockRunStore{deletedBeyondLimit: make(map[uuid.UUID]int)}
+	return &mockRunStore{
+		deletedBeyondLimit: make(map[uuid.UUID]int),
+		olderThanCutoffs:   make(map[uuid.UUID]time.Time),
+	}
 }
 
 func (m *mockRunStore) ListRuns(_ context.Context, _ api.RunFilter) ([]domain.Run, error) {
@@ -113,18 +117,29 @@ func (m *mockRunStore) GetRunLogs(_ context.Context, _ string) ([]api.LogEntry,
 func (m *mockRunStore) SaveRunLogs(_ context.Context, _ string, _ []api.LogEntry) error {
 	return nil
 }
+
+func (m *mockRunStore) SaveRunPhases(_ context.Context, _ string, _ []api.PhaseProfile) error {
+	return nil
+}
 func (m *mockRunStore) DeleteRunsBeyondLimit(_ context.Context, pipelineID uuid.UUID, keepCount int) (int, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.deletedBeyondLimit[pipelineID] = keepCount
 	return 5, nil // pretend we deleted 5
 }
-func (m *mockRunStore) DeleteRunsOlderThan(_ context.Context, _ time.Time) (int, error) {
+func (m *mockRunStore) DeleteRunsOlderThan(_ context.Context, pipelineID uuid.UUID, cutoff time.Time) (int, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.deletedOlderThan = 3
+	m.olderThanCutoffs[pipelineID] = cutoff
+	m.deletedOlderThan += 3
 	return 3, nil
 }
+func (m *mockRunStore) CountRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ int) (int, error) {
+	return 5, nil // matches DeleteRunsBeyondLimit
+}
+func (m *mockRunStore) CountRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
+	return 3, nil // matches DeleteRunsOlderThan
+}
 func (m *mockRunStore) ListStuckRuns(_ context.Context, cutoff time.Time) ([]domain.Run, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -153,6 +168,22 @@ func (m *mockRunStore) LatestRunPerPipeline(_ context.Context, _ []uuid.UUID) (m
 	return nil, nil
 }
 
+func (m *mockRunStore) RunStatuses(_ context.Context, _ []uuid.UUID) (map[uuid.UUID]domain.RunStatus, error) {
+	return nil, nil
+}
+
+func (m *mockRunStore) NamespaceRunStats(_ context.Context, _ string, _ time.Time) (*api.NamespaceRunStats, error) {
+	return &api.NamespaceRunStats{ByStatus: map[string]int{}}, nil
+}
+
+func (m *mockRunStore) PipelineStats(_ context.Context, _ uuid.UUID, _ time.Time) (*api.PipelineRunStats, error) {
+	return &api.PipelineRunStats{}, nil
+}
+
+func (m *mockRunStore) RecordSubmitFailure(_ context.Context, _ string, _ string, _ int) (bool, error) {
+	return false, nil
+}
+
 type mockPipelineStore struct {
 	mu             sync.Mutex
 	pipelines      []domain.Pipeline
@@ -185,6 +216,9 @@ func (m *mockPipelineStore) GetPipelineByID(_ context.Context, id string) (*doma
 	return nil, nil
 }
 func (m *mockPipelineStore) CreatePipeline(_ context.Context, _ *domain.Pipeline) error { return nil }
+func (m *mockPipelineStore) CreatePipelinesBatch(_ context.Context, p []*domain.Pipeline) ([]error, error) {
+	return make([]error, len(p)), nil
+}
 func (m *mockPipelineStore) UpdatePipeline(_ context.Context, _, _, _ string, _ api.UpdatePipelineRequest) (*domain.Pipeline, error) {
 	return nil, nil
 }
@@ -192,6 +226,12 @@ func (m *mockPipelineStore) DeletePipeline(_ context.Context, _, _, _ string) er
 func (m *mockPipelineStore) SetDraftDirty(_ context.Context, _, _, _ string, _ bool) error {
 	return nil
 }
+func (m *mockPipelineStore) BumpDraftRevision(_ context.Context, _ uuid.UUID, _ int64) (int64, error) {
+	return 0, nil
+}
+func (m *mockPipelineStore) RollbackDraftRevision(_ context.Context, _ uuid.UUID, _ int64, _ bool) error {
+	return nil
+}
 func (m *mockPipelineStore) PublishPipeline(_ context.Context, _, _, _ string, _ map[string]string) error {
 	return nil
 }
@@ -201,9 +241,17 @@ func (m *mockPipelineStore) UpdatePipelineRetention(_ context.Context, id uuid.U
 	m.retentionCalls[id] = cfg
 	return nil
 }
+func (m *mockPipelineStore) UpdatePipelineValidation(_ context.Context, _ uuid.UUID, _ *domain.ValidationResult) error {
+	return nil
+}
+
 func (m *mockPipelineStore) ListSoftDeletedPipelines(_ context.Context, _ time.Time) ([]domain.Pipeline, error) {
 	return m.softDeleted, nil
 }
+func (m *mockPipelineStore) MovePipeline(_ context.Context, _ uuid.UUID, _, _, _ string, _ map[string]string) (*domain.Pipeline, error) {
+	return nil, nil
+}
+
 func (m *mockPipelineStore) HardDeletePipeline(_ context.Context, id uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -212,7 +260,10 @@ func (m *mockPipelineStore) HardDeletePipeline(_ context.Context, id uuid.UUID)
 }
 
 type mockLandingZoneStore struct {
-	zones []domain.LandingZone
+	zones       []domain.LandingZone
+	softDeleted []domain.LandingZone
+	files       map[uuid.UUID][]domain.LandingFile
+	hardDeleted []uuid.UUID
 }
 
 func (m *mockLandingZoneStore) ListZones(_ context.Context, _ api.LandingZoneFilter) ([]api.LandingZoneListItem, error) {
@@ -228,8 +279,8 @@ func (m *mockLandingZoneStore) DeleteZone(_ context.Context, _, _ string) error
 func (m *mockLandingZoneStore) UpdateZone(_ context.Context, _, _ string, _, _, _ *string) (*domain.LandingZone, error) {
 	return nil, nil
 }
-func (m *mockLandingZoneStore) ListFiles(_ context.Context, _ uuid.UUID) ([]domain.LandingFile, error) {
-	return nil, nil
+func (m *mockLandingZoneStore) ListFiles(_ context.Context, zoneID uuid.UUID) ([]domain.LandingFile, error) {
+	return m.files[zoneID], nil
 }
 func (m *mockLandingZoneStore) CreateFile(_ context.Context, _ *domain.LandingFile) error {
 	return nil
@@ -244,9 +295,34 @@ func (m *mockLandingZoneStore) GetZoneByID(_ context.Context, _ uuid.UUID) (*dom
 func (m *mockLandingZoneStore) UpdateZoneLifecycle(_ context.Context, _ uuid.UUID, _ *int, _ *bool) error {
 	return nil
 }
+func (m *mockLandingZoneStore) UpdateZoneUploadPolicy(_ context.Context, _ uuid.UUID, _, _ *bool) error {
+	return nil
+}
+func (m *mockLandingZoneStore) FindFileByChecksum(_ context.Context, _ uuid.UUID, _ string) (*domain.LandingFile, error) {
+	return nil, nil
+}
+func (m *mockLandingZoneStore) FindFileByPath(_ context.Context, _ uuid.UUID, _ string) (*domain.LandingFile, error) {
+	return nil, nil
+}
 func (m *mockLandingZoneStore) ListZonesWithAutoPurge(_ context.Context) ([]domain.LandingZone, error) {
 	return m.zones, nil
 }
+func (m *mockLandingZoneStore) RestoreZone(_ context.Context, _, _ string) (*domain.LandingZone, error) {
+	return nil, nil
+}
+func (m *mockLandingZoneStore) ListSoftDeletedZones(_ context.Context, olderThan time.Time) ([]domain.LandingZone, error) {
+	var result []domain.LandingZone
+	for _, z := range m.softDeleted {
+		if z.DeletedAt.Before(olderThan) {
+			result = append(result, z)
+		}
+	}
+	return result, nil
+}
+func (m *mockLandingZoneStore) HardDeleteZone(_ context.Context, zoneID uuid.UUID) error {
+	m.hardDeleted = append(m.hardDeleted, zoneID)
+	return nil
+}
 
 type mockStorageStore struct {
 	mu      sync.Mutex
@@ -266,6 +342,9 @@ func (m *mockStorageStore) ListFiles(_ context.Context, prefix string) ([]api.Fi
 func (m *mockStorageStore) ReadFile(_ context.Context, _ string) (*api.FileContent, error) {
 	return nil, nil
 }
+func (m *mockStorageStore) OpenFile(_ context.Context, _ string) (api.FileReader, error) {
+	return nil, nil
+}
 func (m *mockStorageStore) WriteFile(_ context.Context, _ string, _ []byte) (string, error) {
 	return "", nil
 }
@@ -281,17 +360,37 @@ func (m *mockStorageStore) StatFile(_ context.Context, _ string) (*api.FileInfo,
 func (m *mockStorageStore) ReadFileVersion(_ context.Context, _, _ string) (*api.FileContent, error) {
 	return nil, nil
 }
+func (m *mockStorageStore) StatFileVersion(_ context.Context, _, _ string) (*api.FileInfo, error) {
+	return nil, nil
+}
+func (m *mockStorageStore) CopyFile(_ context.Context, _, _ string) (string, error) {
+	return "", nil
+}
+func (m *mockStorageStore) CopyFileVersion(_ context.Context, _, _, _ string) (string, error) {
+	return "", nil
+}
 
 type mockAuditStore struct {
 	deleted int
+	cutoff  time.Time // olderThan of the last DeleteOlderThan call
 }
 
 func (m *mockAuditStore) Log(_ context.Context, _, _, _, _, _ string) error { return nil }
 func (m *mockAuditStore) List(_ context.Context, _, _ int) ([]domain.AuditEntry, error) {
 	return nil, nil
 }
-func (m *mockAuditStore) DeleteOlderThan(_ context.Context, _ time.Time) (int, error) {
+func (m *mockAuditStore) ListFiltered(_ context.Context, _ api.AuditFilter) ([]domain.AuditEntry, error) {
+	return nil, nil
+}
+func (m *mockAuditStore) EachFiltered(_ context.Context, _ api.AuditFilter, _ func(domain.AuditEntry) error) error {
+	return nil
+}
+func (m *mockAuditStore) DeleteOlderThan(_ context.Context, olderThan time.Time) (int, error) {
 	m.deleted = 42
+	m.cutoff = olderThan
+	return 42, nil
+}
+func (m *mockAuditStore) CountOlderThan(_ context.Context, _ time.Time) (int, error) {
 	return 42, nil
 }
 
@@ -319,7 +418,7 @@ func TestPruneRuns_DeletesExcess(t *testing.T) {
 	p1 := domain.Pipeline{ID: uuid.New(), Namespace: "default", Layer: "bronze", Name: "test"}
 	pipelines.pipelines = []domain.Pipeline{p1}
 
-	r := New(settings, runs, pipelines, nil, nil, nil, nil, nil)
+	r := New(settings, runs, pipelines, nil, nil, nil, nil, nil, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 8, status.RunsPruned) // 5 from limit + 3 from age
@@ -336,12 +435,64 @@ func TestPruneRuns_PreservesActive(t *testing.T) {
 	p1 := domain.Pipeline{ID: uuid.New()}
 	pipelines.pipelines = []domain.Pipeline{p1}
 
-	r := New(settings, runs, pipelines, nil, nil, nil, nil, nil)
+	r := New(settings, runs, pipelines, nil, nil, nil, nil, nil, Options{})
 	r.tick(context.Background())
 
 	assert.Equal(t, 50, runs.deletedBeyondLimit[p1.ID])
 }
 
+func TestPruneRuns_ResolvesPipelineOverNamespaceOverSystem(t *testing.T) {
+	cfg := domain.DefaultRetentionConfig()
+	settings := newMockSettingsStore(cfg)
+	settings.settings[api.NamespaceRetentionKey("team-a")] = json.RawMessage(`{"runs_max_per_pipeline": 20, "runs_max_age_days": 30}`)
+	runs := newMockRunStore()
+	pipelines := newMockPipelineStore()
+
+	pipelineOverride := domain.Pipeline{ID: uuid.New(), Namespace: "team-a",
+		RetentionConfig: json.RawMessage(`{"runs_max_per_pipeline": 5}`)}
+	namespaceOnly := domain.Pipeline{ID: uuid.New(), Namespace: "team-a"}
+	systemOnly := domain.Pipeline{ID: uuid.New(), Namespace: "default"}
+	pipelines.pipelines = []domain.Pipeline{pipelineOverride, namespaceOnly, systemOnly}
+
+	before := time.Now()
+	r := New(settings, runs, pipelines, nil, nil, nil, nil, nil, Options{})
+	status := r.tick(context.Background())
+
+	assert.Equal(t, 24, status.RunsPruned) // (5 + 3) per pipeline
+	assert.Equal(t, map[uuid.UUID]int{
+		pipelineOverride.ID: 5,
+		namespaceOnly.ID:    20,
+		systemOnly.ID:       cfg.RunsMaxPerPipeline,
+	}, runs.deletedBeyondLimit)
+
+	ageDays := func(id uuid.UUID) int {
+		return int(before.Sub(runs.olderThanCutoffs[id]).Hours()/24 + 0.5)
+	}
+	assert.Equal(t, 30, ageDays(pipelineOverride.ID), "age inherited from namespace")
+	assert.Equal(t, 30, ageDays(namespaceOnly.ID))
+	assert.Equal(t, cfg.RunsMaxAgeDays, ageDays(systemOnly.ID))
+}
+
+func TestPruneRuns_IncludesSoftDeletedPipelines(t *testing.T) {
+	cfg := domain.DefaultRetentionConfig()
+	settings := newMockSettingsStore(cfg)
+	runs := newMockRunStore()
+	pipelines := newMockPipelineStore()
+
+	deletedAt := time.Now().Add(-24 * time.Hour)
+	live := domain.Pipeline{ID: uuid.New(), Namespace: "default"}
+	deleted := domain.Pipeline{ID: uuid.New(), Namespace: "default", DeletedAt: &deletedAt}
+	pipelines.pipelines = []domain.Pipeline{live}
+	pipelines.softDeleted = []domain.Pipeline{deleted}
+
+	r := New(settings, runs, pipelines, nil, nil, nil, nil, nil, Options{})
+	r.pruneRuns(context.Background(), cfg, time.Now(), false)
+
+	assert.Contains(t, runs.olderThanCutoffs, live.ID)
+	assert.Contains(t, runs.olderThanCutoffs, deleted.ID, "soft-deleted pipeline history is still aged out")
+	assert.Contains(t, runs.deletedBeyondLimit, deleted.ID)
+}
+
 func TestFailStuckRuns(t *testing.T) {
 	cfg := domain.DefaultRetentionConfig()
 	cfg.StuckRunTimeoutMinutes = 60
@@ -355,7 +506,7 @@ func TestFailStuckRuns(t *testing.T) {
 	}
 	runs.runs = []domain.Run{stuckRun}
 
-	r := New(settings, runs, nil, nil, nil, nil, nil, nil)
+	r := New(settings, runs, nil, nil, nil, nil, nil, nil, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 1, status.RunsFailed)
@@ -373,7 +524,7 @@ func TestFailStuckPendingRuns_TimesOutOldPending(t *testing.T) {
 	}
 	runs.runs = []domain.Run{pending}
 
-	r := New(settings, runs, nil, nil, nil, nil, nil, nil)
+	r := New(settings, runs, nil, nil, nil, nil, nil, nil, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 1, status.RunsFailed, "25h-old PENDING run should be failed")
@@ -393,7 +544,7 @@ func TestFailStuckPendingRuns_LeavesYoungPendingAlone(t *testing.T) {
 	}
 	runs.runs = []domain.Run{pending}
 
-	r := New(settings, runs, nil, nil, nil, nil, nil, nil)
+	r := New(settings, runs, nil, nil, nil, nil, nil, nil, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 0, status.RunsFailed, "1h-old PENDING run should be left alone")
@@ -421,7 +572,7 @@ func TestCleanupOrphanBranches_NowReapsFormerPendingBranches(t *testing.T) {
 		},
 	}
 
-	r := New(settings, runs, nil, nil, nil, nil, nil, nessie)
+	r := New(settings, runs, nil, nil, nil, nil, nil, nessie, Options{})
 	status := r.tick(context.Background())
 
 	// The 25h-old PENDING run is marked failed in this tick (Task 2b runs
@@ -455,7 +606,7 @@ func TestCleanOrphanBranches_PreservesYoungPendingBranch(t *testing.T) {
 		},
 	}
 
-	r := New(settings, runs, nil, nil, nil, nil, nil, nessie)
+	r := New(settings, runs, nil, nil, nil, nil, nil, nessie, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 0, status.RunsFailed)
@@ -475,13 +626,43 @@ func TestPurgeSoftDeletedPipelines(t *testing.T) {
 
 	storage := newMockStorageStore()
 
-	r := New(settings, nil, pipelines, nil, storage, nil, nil, nil)
+	r := New(settings, nil, pipelines, nil, storage, nil, nil, nil, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 1, status.PipelinesPurged)
 	assert.Contains(t, pipelines.hardDeleted, p.ID)
 }
 
+func TestPurgeSoftDeletedZones(t *testing.T) {
+	cfg := domain.DefaultRetentionConfig()
+	cfg.SoftDeletePurgeDays = 7
+
+	settings := newMockSettingsStore(cfg)
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	recent := time.Now().Add(-1 * time.Hour)
+	expired := domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "uploads", DeletedAt: &old}
+	inGrace := domain.LandingZone{ID: uuid.New(), Namespace: "default", Name: "staging", DeletedAt: &recent}
+	zones := &mockLandingZoneStore{
+		softDeleted: []domain.LandingZone{expired, inGrace},
+		files: map[uuid.UUID][]domain.LandingFile{
+			expired.ID: {{ZoneID: expired.ID, S3Path: "default/landing/uploads/a.csv"}},
+		},
+	}
+
+	storage := newMockStorageStore()
+	storage.files["default/landing/uploads/_samples/"] = []api.FileInfo{
+		{Path: "default/landing/uploads/_samples/sample.csv"},
+	}
+
+	r := New(settings, nil, nil, zones, storage, nil, nil, nil, Options{})
+	status := r.tick(context.Background())
+
+	assert.Equal(t, 1, status.ZonesPurged)
+	assert.Equal(t, []uuid.UUID{expired.ID}, zones.hardDeleted)
+	assert.Contains(t, storage.deleted, "default/landing/uploads/a.csv")
+	assert.Contains(t, storage.deleted, "default/landing/uploads/_samples/sample.csv")
+}
+
 func TestCleanOrphanBranches(t *testing.T) {
 	cfg := domain.DefaultRetentionConfig()
 	settings := newMockSettingsStore(cfg)
@@ -503,7 +684,7 @@ func TestCleanOrphanBranches(t *testing.T) {
 		},
 	}
 
-	r := New(settings, runs, nil, nil, nil, nil, nil, nessie)
+	r := New(settings, runs, nil, nil, nil, nil, nil, nessie, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 1, status.BranchesCleaned)
@@ -551,7 +732,7 @@ func TestCleanOrphanBranches_SkipsBranchesWithRecentFailedMerges(t *testing.T) {
 		names: []string{"run-" + orphanA.String()}, // protect A
 	}
 
-	r := New(settings, runs, nil, nil, nil, nil, failedMerges, nessie)
+	r := New(settings, runs, nil, nil, nil, nil, failedMerges, nessie, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 1, status.BranchesCleaned,
@@ -580,7 +761,7 @@ func TestPurgeProcessedLZFiles(t *testing.T) {
 		{Path: "default/landing/uploads/_processed/recent/file.csv", Modified: time.Now()},
 	}
 
-	r := New(settings, nil, nil, zones, storage, nil, nil, nil)
+	r := New(settings, nil, nil, zones, storage, nil, nil, nil, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 1, status.LZFilesCleaned)
@@ -594,18 +775,61 @@ func TestPruneAuditLog(t *testing.T) {
 	settings := newMockSettingsStore(cfg)
 	audit := &mockAuditStore{}
 
-	r := New(settings, nil, nil, nil, nil, audit, nil, nil)
+	r := New(settings, nil, nil, nil, nil, audit, nil, nil, Options{})
 	status := r.tick(context.Background())
 
 	assert.Equal(t, 42, status.AuditPruned)
 }
 
+func TestPruneAuditLog_UsesOwnHorizon(t *testing.T) {
+	cfg := domain.DefaultRetentionConfig()
+	cfg.RunsMaxAgeDays = 7
+	cfg.LogsMaxAgeDays = 3
+	cfg.AuditLogMaxAgeDays = 400
+
+	settings := newMockSettingsStore(cfg)
+	audit := &mockAuditStore{}
+
+	r := New(settings, nil, nil, nil, nil, audit, nil, nil, Options{})
+	r.tick(context.Background())
+
+	want := time.Now().Add(-400 * 24 * time.Hour)
+	assert.WithinDuration(t, want, audit.cutoff, time.Minute)
+}
+
+func TestPruneAuditLog_ConfigWithoutAuditField_UsesDefaultHorizon(t *testing.T) {
+	// A retention config saved before audit_log_max_age_days existed.
+	settings := newMockSettingsStore(domain.DefaultRetentionConfig())
+	settings.settings["retention"] = json.RawMessage(`{"runs_max_per_pipeline": 50, "runs_max_age_days": 30, "reaper_interval_minutes": 15}`)
+	audit := &mockAuditStore{}
+
+	r := New(settings, nil, nil, nil, nil, audit, nil, nil, Options{})
+	r.tick(context.Background())
+
+	want := time.Now().Add(-time.Duration(domain.DefaultRetentionConfig().AuditLogMaxAgeDays) * 24 * time.Hour)
+	assert.WithinDuration(t, want, audit.cutoff, time.Minute)
+}
+
+func TestPruneAuditLog_NonPositiveHorizon_Skips(t *testing.T) {
+	cfg := domain.DefaultRetentionConfig()
+	cfg.AuditLogMaxAgeDays = 0
+
+	settings := newMockSettingsStore(cfg)
+	audit := &mockAuditStore{}
+
+	r := New(settings, nil, nil, nil, nil, audit, nil, nil, Options{})
+	status := r.tick(context.Background())
+
+	assert.Equal(t, 0, status.AuditPruned)
+	assert.True(t, audit.cutoff.IsZero(), "audit log must not be pruned")
+}
+
 func TestRunNow_ReturnsStatus(t *testing.T) {
 	cfg := domain.DefaultRetentionConfig()
 	settings := newMockSettingsStore(cfg)
 	audit := &mockAuditStore{}
 
-	r := New(settings, nil, nil, nil, nil, audit, nil, nil)
+	r := New(settings, nil, nil, nil, nil, audit, nil, nil, Options{})
 	status, err := r.RunNow(context.Background())
 
 	require.NoError(t, err)
@@ -613,12 +837,75 @@ func TestRunNow_ReturnsStatus(t *testing.T) {
 	assert.Equal(t, 42, status.AuditPruned)
 }
 
+func TestRunNow_RecordsStatusViaUpdateReaperStatus(t *testing.T) {
+	cfg := domain.DefaultRetentionConfig()
+	settings := newMockSettingsStore(cfg)
+	runs := newMockRunStore()
+	pipelines := newMockPipelineStore()
+	pipelines.pipelines = []domain.Pipeline{{ID: uuid.New()}}
+	audit := &mockAuditStore{}
+
+	r := New(settings, runs, pipelines, nil, nil, audit, nil, nil, Options{})
+	status, err := r.RunNow(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 8, status.RunsPruned)
+	assert.Equal(t, 42, status.AuditPruned)
+	assert.Same(t, status, settings.status, "RunNow must persist its stats via UpdateReaperStatus")
+}
+
+// overlapAuditStore records how many DeleteOlderThan calls run at once.
+type overlapAuditStore struct {
+	mockAuditStore
+	mu      sync.Mutex
+	active  int
+	maxSeen int
+	calls   int
+}
+
+func (m *overlapAuditStore) DeleteOlderThan(_ context.Context, _ time.Time) (int, error) {
+	m.mu.Lock()
+	m.active++
+	m.calls++
+	if m.active > m.maxSeen {
+		m.maxSeen = m.active
+	}
+	m.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	m.mu.Lock()
+	m.active--
+	m.mu.Unlock()
+	return 1, nil
+}
+
+func TestRunNow_ConcurrentCallsDoNotOverlap(t *testing.T) {
+	settings := newMockSettingsStore(domain.DefaultRetentionConfig())
+	audit := &overlapAuditStore{}
+	r := New(settings, nil, nil, nil, nil, audit, nil, nil, Options{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.RunNow(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, audit.calls)
+	assert.Equal(t, 1, audit.maxSeen, "reaper cycles must run one at a time")
+}
+
 func TestStartStop(t *testing.T) {
 	cfg := domain.DefaultRetentionConfig()
 	cfg.ReaperIntervalMinutes = 1
 
 	settings := newMockSettingsStore(cfg)
-	r := New(settings, nil, nil, nil, nil, nil, nil, nil)
+	r := New(settings, nil, nil, nil, nil, nil, nil, nil, Options{})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	r.Start(ctx)
@@ -635,9 +922,125 @@ func TestTaskIsolation_PanicDoesNotCrash(t *testing.T) {
 	settings := newMockSettingsStore(cfg)
 
 	// Create a reaper with nil stores — some tasks will panic
-	r := New(settings, nil, nil, nil, nil, nil, nil, nil)
+	r := New(settings, nil, nil, nil, nil, nil, nil, nil, Options{})
 
 	// Should not panic
 	status := r.tick(context.Background())
 	assert.NotNil(t, status)
 }
+
+// dryRunFixture wires every store with something for each task to clean up:
+// runs to prune, a stuck RUNNING and a stuck PENDING run (each with a branch),
+// an orphan branch, a soft-deleted pipeline and zone, an old processed file,
+// and audit entries.
+type dryRunFixture struct {
+	settings  *mockSettingsStore
+	runs      *mockRunStore
+	pipelines *mockPipelineStore
+	zones     *mockLandingZoneStore
+	storage   *mockStorageStore
+	audit     *mockAuditStore
+	nessie    *mockNessieClient
+}
+
+func newDryRunFixture() *dryRunFixture {
+	cfg := domain.DefaultRetentionConfig()
+	cfg.StuckRunTimeoutMinutes = 60
+	f := &dryRunFixture{
+		settings:  newMockSettingsStore(cfg),
+		runs:      newMockRunStore(),
+		pipelines: newMockPipelineStore(),
+		zones:     &mockLandingZoneStore{files: map[uuid.UUID][]domain.LandingFile{}},
+		storage:   newMockStorageStore(),
+		audit:     &mockAuditStore{},
+	}
+
+	stuckRunning := uuid.New()
+	stuckPending := uuid.New()
+	f.runs.runs = []domain.Run{
+		{ID: stuckRunning, Status: domain.RunStatusRunning, CreatedAt: time.Now().Add(-2 * time.Hour)},
+		{ID: stuckPending, Status: domain.RunStatusPending, CreatedAt: time.Now().Add(-25 * time.Hour)},
+	}
+	f.nessie = &mockNessieClient{branches: []NessieBranch{
+		{Name: "main", Hash: "m"},
+		{Name: "run-" + stuckRunning.String(), Hash: "a"},
+		{Name: "run-" + stuckPending.String(), Hash: "b"},
+		{Name: "run-" + uuid.New().String(), Hash: "c"},
+	}}
+
+	deleted := time.Now().Add(-60 * 24 * time.Hour)
+	f.pipelines.pipelines = []domain.Pipeline{{ID: uuid.New()}}
+	f.pipelines.softDeleted = []domain.Pipeline{{ID: uuid.New(), S3Path: "old/pipeline", DeletedAt: &deleted}}
+	f.storage.files["old/pipeline"] = []api.FileInfo{{Path: "old/pipeline/pipeline.sql"}}
+
+	maxAge := 7
+	f.zones.zones = []domain.LandingZone{
+		{ID: uuid.New(), Namespace: "default", Name: "uploads", AutoPurge: true, ProcessedMaxAgeDays: &maxAge},
+	}
+	f.zones.softDeleted = []domain.LandingZone{{ID: uuid.New(), Namespace: "default", Name: "gone", DeletedAt: &deleted}}
+	f.storage.files["default/landing/uploads/_processed/"] = []api.FileInfo{
+		{Path: "default/landing/uploads/_processed/old/file.csv", Modified: time.Now().Add(-10 * 24 * time.Hour)},
+		{Path: "default/landing/uploads/_processed/new/file.csv", Modified: time.Now()},
+	}
+	return f
+}
+
+func (f *dryRunFixture) reaper(opts Options) *Reaper {
+	return New(f.settings, f.runs, f.pipelines, f.zones, f.storage, f.audit, nil, f.nessie, opts)
+}
+
+// assertNothingDeleted fails if any store saw a destructive call.
+func (f *dryRunFixture) assertNothingDeleted(t *testing.T) {
+	t.Helper()
+	assert.Empty(t, f.runs.deletedBeyondLimit)
+	assert.Empty(t, f.runs.olderThanCutoffs)
+	assert.Zero(t, f.runs.deletedOlderThan)
+	for _, run := range f.runs.runs {
+		assert.NotEqual(t, domain.RunStatusFailed, run.Status, "run %s was failed", run.ID)
+	}
+	assert.Empty(t, f.pipelines.hardDeleted)
+	assert.Empty(t, f.zones.hardDeleted)
+	assert.Empty(t, f.storage.deleted)
+	assert.Empty(t, f.nessie.deleted)
+	assert.Zero(t, f.audit.deleted)
+}
+
+func TestDryRun_DeletesNothing_CountsMatchActualRun(t *testing.T) {
+	f := newDryRunFixture()
+
+	dry := f.reaper(Options{DryRun: true}).tick(context.Background())
+
+	f.assertNothingDeleted(t)
+	assert.Equal(t, &domain.ReaperStatus{}, f.settings.status, "dry run must not record a reaper status")
+
+	actual := f.reaper(Options{}).tick(context.Background())
+
+	assert.Equal(t, actual, dry)
+	assert.Equal(t, 16, actual.RunsPruned) // (5 + 3) for the live and the soft-deleted pipeline
+	assert.Equal(t, 2, actual.RunsFailed)
+	assert.Equal(t, 1, actual.PipelinesPurged)
+	assert.Equal(t, 1, actual.ZonesPurged)
+	assert.Equal(t, 3, actual.BranchesCleaned)
+	assert.Equal(t, 1, actual.LZFilesCleaned)
+	assert.Equal(t, 42, actual.AuditPruned)
+	assert.Len(t, f.nessie.deleted, 3)
+}
+
+func TestPreview_DeletesNothing_EvenWhenNotDryRun(t *testing.T) {
+	f := newDryRunFixture()
+
+	preview, err := f.reaper(Options{}).Preview(context.Background())
+
+	require.NoError(t, err)
+	f.assertNothingDeleted(t)
+	assert.Equal(t, &domain.RetentionPreview{
+		RunsBeyondLimit: 10,
+		RunsOlderThan:   6,
+		RunsFailed:      2,
+		PipelinesPurged: 1,
+		ZonesPurged:     1,
+		BranchesCleaned: 3,
+		LZFilesCleaned:  1,
+		AuditPruned:     42,
+	}, preview)
+}
@@ -5,8 +5,10 @@ package scheduler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -35,14 +37,32 @@ type Scheduler struct {
 	done      chan struct{}
 	EventBus  EventPublisher // Optional: publishes schedule_fired events when set.
 
+	// Settings, when set, is checked for maintenance mode at the top of
+	// every tick; while it is on, the tick is skipped and no schedule advances.
+	// It also supplies the TickSecondsKey interval override.
+	Settings api.SettingsStore
+
 	// Last-tick observability — updated atomically at the end of every tick()
 	// so the /metrics handler can read them without locking. Exposed via
 	// LastTickStats(); see ratd_scheduler_last_tick_* metrics in
 	// platform/internal/api/health.go.
 	lastTickDuration   atomic.Int64 // nanoseconds of the most recent tick
 	lastTickDispatched atomic.Int32 // count of schedules dispatched in the most recent tick
+
+	currentInterval atomic.Int64 // nanoseconds between ticks, after settings overrides
 }
 
+// TickSecondsKey is the platform_settings key overriding the tick interval
+// passed to New (a JSON integer, seconds). The scheduler re-reads it after
+// every tick, so a change takes effect within one tick of the old interval.
+const TickSecondsKey = "scheduler_tick_seconds"
+
+// Bounds on TickSecondsKey. Values outside them are ignored with a warning.
+const (
+	minTickSeconds = 1
+	maxTickSeconds = 3600
+)
+
 // New creates a Scheduler with the given stores and check interval.
 func New(
 	schedules api.ScheduleStore,
@@ -73,7 +93,9 @@ func (s *Scheduler) Start(ctx context.Context) {
 
 	go func() {
 		defer close(s.done)
-		ticker := time.NewTicker(s.interval)
+		interval := s.tickInterval(ctx)
+		s.currentInterval.Store(int64(interval))
+		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
 		for {
@@ -82,11 +104,48 @@ func (s *Scheduler) Start(ctx context.Context) {
 				return
 			case <-ticker.C:
 				s.tick(ctx)
+
+				// Re-read the interval from settings and reset the ticker if it changed.
+				if newInterval := s.tickInterval(ctx); newInterval != interval {
+					interval = newInterval
+					s.currentInterval.Store(int64(interval))
+					ticker.Reset(interval)
+					slog.Info("scheduler: tick interval updated", "interval", interval)
+				}
 			}
 		}
 	}()
 }
 
+// tickInterval returns the interval until the next tick: TickSecondsKey from
+// settings when set and in bounds, otherwise the interval passed to New.
+func (s *Scheduler) tickInterval(ctx context.Context) time.Duration {
+	if s.Settings == nil {
+		return s.interval
+	}
+	raw, err := s.Settings.GetSetting(ctx, TickSecondsKey)
+	if errors.Is(err, api.ErrSettingNotFound) {
+		return s.interval
+	}
+	if err != nil {
+		slog.Warn("scheduler: failed to read tick interval", "error", err)
+		return s.interval
+	}
+	var seconds int
+	if err := json.Unmarshal(raw, &seconds); err != nil || seconds < minTickSeconds || seconds > maxTickSeconds {
+		slog.Warn("scheduler: ignoring invalid tick interval setting",
+			"key", TickSecondsKey, "value", string(raw), "min", minTickSeconds, "max", maxTickSeconds)
+		return s.interval
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Interval returns the interval currently between ticks, including any
+// settings override. Zero before Start.
+func (s *Scheduler) Interval() time.Duration {
+	return time.Duration(s.currentInterval.Load())
+}
+
 // Stop cancels the background goroutine and waits for it to finish.
 func (s *Scheduler) Stop() {
 	if s.cancel != nil {
@@ -97,6 +156,21 @@ func (s *Scheduler) Stop() {
 	}
 }
 
+// maintenanceMode reports whether ticks should be skipped. A settings
+// read failure is logged and treated as off, so a flaky settings store
+// can't silently stop scheduling.
+func (s *Scheduler) maintenanceMode(ctx context.Context) bool {
+	if s.Settings == nil {
+		return false
+	}
+	on, err := api.MaintenanceModeEnabled(ctx, s.Settings)
+	if err != nil {
+		slog.Warn("scheduler: failed to read maintenance mode", "error", err)
+		return false
+	}
+	return on
+}
+
 // maxConcurrentScheduleDispatches caps the number of in-flight submit
 // RPCs the scheduler will fan out per tick. Matches the runner's default
 // concurrent-run capacity (RUNNER_MAX_CONCURRENT=10) — exceeding it just
@@ -149,6 +223,11 @@ func (s *Scheduler) tick(ctx context.Context) {
 		s.lastTickDispatched.Store(int32(dispatched))
 	}()
 
+	if s.maintenanceMode(ctx) {
+		slog.Info("scheduler: maintenance mode on, skipping tick")
+		return
+	}
+
 	schedules, err := s.schedules.ListSchedules(ctx)
 	if err != nil {
 		slog.Error("scheduler: failed to list schedules", "error", err)
@@ -197,9 +276,21 @@ func (s *Scheduler) tick(ctx context.Context) {
 
 		// Skip if pipeline already has a pending or running run — avoids
 		// piling up duplicate runs when the runner is slow or at capacity.
-		if s.hasActiveRun(ctx, sched.PipelineID.String()) {
-			slog.Debug("scheduler: skipping — pipeline already has an active run",
-				"schedule_id", sched.ID, "pipeline_id", sched.PipelineID)
+		// The exception is this schedule's own run left pending by a busy or
+		// unavailable runner in an earlier tick: that one is retried.
+		if active := s.activeRun(ctx, sched.PipelineID.String()); active != nil {
+			if !isStrandedScheduleRun(sched, active) {
+				slog.Debug("scheduler: skipping — pipeline already has an active run",
+					"schedule_id", sched.ID, "pipeline_id", sched.PipelineID)
+				continue
+			}
+			slog.Info("scheduler: retrying pending run", "schedule_id", sched.ID, "run_id", active.ID)
+			dispatches = append(dispatches, dueDispatch{
+				schedule: sched,
+				pipeline: pipeline,
+				run:      active,
+				nextRun:  cronSched.Next(now),
+			})
 			continue
 		}
 
@@ -209,6 +300,8 @@ func (s *Scheduler) tick(ctx context.Context) {
 			PipelineID: pipeline.ID,
 			Status:     domain.RunStatusPending,
 			Trigger:    "schedule:" + sched.CronExpr,
+			Metadata:   map[string]string{"schedule_id": sched.ID.String()},
+			Priority:   pipeline.Priority,
 		}
 		if err := s.runs.CreateRun(ctx, run); err != nil {
 			slog.Error("scheduler: failed to create run", "schedule_id", sched.ID, "error", err)
@@ -242,31 +335,61 @@ func (s *Scheduler) LastTickStats() (time.Duration, int) {
 // dispatchDue fans out the actual executor.Submit calls for the planned
 // dispatches, capped at maxConcurrentScheduleDispatches in flight. Each
 // successful dispatch advances its schedule's next_run_at; ErrRunnerBusy
-// leaves the schedule alone so the next tick retries. Other submit
-// errors are logged but the schedule still advances (the run row was
-// already created in the planning phase).
+// and ErrRunnerUnavailable leave the schedule alone so the next tick
+// retries. Other submit errors are logged but the schedule still advances
+// (the run row was already created in the planning phase).
+//
+// Dispatches go out in priority tiers, highest run priority first: a tier
+// is submitted (concurrently) and finished before the next one starts, so
+// when the runner fills up it is the lower tiers that get ErrRunnerBusy.
+// With every pipeline at the default priority there is a single tier.
 func (s *Scheduler) dispatchDue(ctx context.Context, now time.Time, dispatches []dueDispatch) {
-	// Plain errgroup (NOT WithContext) — we don't want a single
-	// dispatch's timeout error to cancel sibling Submit RPCs via a
-	// shared context. Each dispatchOne builds its own bounded ctx.
-	var g errgroup.Group
-	g.SetLimit(maxConcurrentScheduleDispatches)
-
 	var mu sync.Mutex // serialises slog calls only — not required for correctness, just neater output.
 
-	for _, d := range dispatches {
-		d := d // capture for closure
-		g.Go(func() error {
-			return s.dispatchOne(ctx, now, d, &mu)
-		})
+	for _, tier := range priorityTiers(dispatches) {
+		// Plain errgroup (NOT WithContext) — we don't want a single
+		// dispatch's timeout error to cancel sibling Submit RPCs via a
+		// shared context. Each dispatchOne builds its own bounded ctx.
+		var g errgroup.Group
+		g.SetLimit(maxConcurrentScheduleDispatches)
+
+		for _, d := range tier {
+			d := d // capture for closure
+			g.Go(func() error {
+				return s.dispatchOne(ctx, now, d, &mu)
+			})
+		}
+
+		// We never return an error from dispatchOne (each path logs its own
+		// failure), so g.Wait should always be nil — but keep the defensive
+		// check in case future contributors propagate one.
+		if err := g.Wait(); err != nil {
+			slog.Warn("scheduler: at least one dispatch failed", "error", err)
+		}
 	}
+}
+
+// priorityTiers groups dispatches by run priority (runs.priority, copied
+// from the pipeline when the run was created), highest first. A retried run
+// keeps the priority it was created with. Within a tier, older runs come
+// first (FIFO), so a retried run goes ahead of runs created this tick.
+func priorityTiers(dispatches []dueDispatch) [][]dueDispatch {
+	sorted := append([]dueDispatch(nil), dispatches...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if pi, pj := sorted[i].run.Priority, sorted[j].run.Priority; pi != pj {
+			return pi > pj
+		}
+		return sorted[i].run.CreatedAt.Before(sorted[j].run.CreatedAt)
+	})
 
-	// We never return an error from dispatchOne (each path logs its own
-	// failure), so g.Wait should always be nil — but keep the defensive
-	// check in case future contributors propagate one.
-	if err := g.Wait(); err != nil {
-		slog.Warn("scheduler: at least one dispatch failed", "error", err)
+	var tiers [][]dueDispatch
+	for i, d := range sorted {
+		if i == 0 || d.run.Priority != sorted[i-1].run.Priority {
+			tiers = append(tiers, nil)
+		}
+		tiers[len(tiers)-1] = append(tiers[len(tiers)-1], d)
 	}
+	return tiers
 }
 
 // dispatchOne submits a single planned dispatch and advances (or not)
@@ -276,33 +399,49 @@ func (s *Scheduler) dispatchOne(ctx context.Context, now time.Time, d dueDispatc
 	submitCtx, cancel := context.WithTimeout(ctx, submitTimeout)
 	defer cancel()
 	if err := s.executor.Submit(submitCtx, d.run, d.pipeline); err != nil {
-		// If the runner is at capacity, don't advance the schedule —
-		// the next tick will retry. The run stays in pending state.
-		if errors.Is(err, executor.ErrRunnerBusy) {
+		switch {
+		case errors.Is(err, executor.ErrRunnerBusy):
+			// At capacity — don't advance the schedule; the next tick
+			// will retry. The run stays in pending state.
 			mu.Lock()
 			slog.Warn("scheduler: runner busy, will retry next tick",
 				"schedule_id", d.schedule.ID, "run_id", d.run.ID)
 			mu.Unlock()
 			return nil
-		}
-		// Per-submit timeout — log loud and advance the schedule so the
-		// same slot doesn't re-fire next tick (run stays PENDING; reaper
-		// will catch it after stuckPendingTimeout).
-		if errors.Is(err, context.DeadlineExceeded) || errors.Is(submitCtx.Err(), context.DeadlineExceeded) {
+		case errors.Is(err, context.DeadlineExceeded) || errors.Is(submitCtx.Err(), context.DeadlineExceeded):
+			// Per-submit timeout — log loud and advance the schedule so the
+			// same slot doesn't re-fire next tick (run stays PENDING; reaper
+			// will catch it after stuckPendingTimeout).
 			mu.Lock()
 			slog.Warn("scheduler: executor submit timed out, advancing schedule",
 				"schedule_id", d.schedule.ID, "run_id", d.run.ID,
 				"pipeline_id", d.schedule.PipelineID, "timeout_secs", int(submitTimeout/time.Second))
 			mu.Unlock()
-			// fall through to schedule advance below
-		} else {
+		case errors.Is(err, executor.ErrRunnerUnavailable):
+			// Transient outage — treated like busy: the run stays pending
+			// and the schedule is not advanced. Unlike busy, it counts
+			// towards api.MaxSubmitAttempts; once the run is dead-lettered
+			// the schedule advances past it.
+			if !api.DeadLetterAfterSubmit(ctx, s.runs, d.run, err) {
+				mu.Lock()
+				slog.Warn("scheduler: runner unavailable, will retry next tick",
+					"schedule_id", d.schedule.ID, "run_id", d.run.ID, "error", err)
+				mu.Unlock()
+				return nil
+			}
+		case errors.Is(err, executor.ErrPipelineInvalid):
+			// Permanent — the executor already failed the run. Advance so
+			// the broken pipeline isn't resubmitted every tick.
+			mu.Lock()
+			slog.Error("scheduler: pipeline rejected by runner", "schedule_id", d.schedule.ID, "run_id", d.run.ID, "error", err)
+			mu.Unlock()
+		default:
 			mu.Lock()
 			slog.Error("scheduler: executor submit failed", "run_id", d.run.ID, "error", err)
 			mu.Unlock()
 		}
-		// Fall through — run was created, just not dispatched. The
-		// schedule still advances so we don't fire the same slot again
-		// next tick.
+		// Fall through — the run row exists either way. The schedule
+		// advances so we don't fire the same slot again next tick.
 	}
 
 	// Publish schedule_fired event (best-effort).
@@ -328,10 +467,10 @@ func (s *Scheduler) dispatchOne(ctx context.Context, now time.Time, d dueDispatc
 	return nil
 }
 
-// hasActiveRun checks whether the given pipeline already has a pending or
-// running run. Used to avoid scheduling duplicate runs when the runner is
-// slow or at capacity.
-func (s *Scheduler) hasActiveRun(ctx context.Context, pipelineID string) bool {
+// activeRun returns a pending or running run of the given pipeline (pending
+// preferred), or nil when there is none. Used to avoid scheduling duplicate
+// runs when the runner is slow or at capacity.
+func (s *Scheduler) activeRun(ctx context.Context, pipelineID string) *domain.Run {
 	// Check pending runs
 	pendingRuns, err := s.runs.ListRuns(ctx, api.RunFilter{
 		PipelineID: pipelineID,
@@ -340,10 +479,10 @@ func (s *Scheduler) hasActiveRun(ctx context.Context, pipelineID string) bool {
 	})
 	if err != nil {
 		slog.Warn("scheduler: failed to check pending runs", "pipeline_id", pipelineID, "error", err)
-		return false // on error, allow the run (don't block scheduling)
+		return nil // on error, allow the run (don't block scheduling)
 	}
 	if len(pendingRuns) > 0 {
-		return true
+		return &pendingRuns[0]
 	}
 
 	// Check running runs
@@ -354,7 +493,22 @@ func (s *Scheduler) hasActiveRun(ctx context.Context, pipelineID string) bool {
 	})
 	if err != nil {
 		slog.Warn("scheduler: failed to check running runs", "pipeline_id", pipelineID, "error", err)
+		return nil
+	}
+	if len(runningRuns) > 0 {
+		return &runningRuns[0]
+	}
+	return nil
+}
+
+// isStrandedScheduleRun reports whether run is sched's own pending run that
+// an earlier tick failed to submit (busy or unavailable runner). Those ticks
+// leave the schedule un-advanced, so last_run_id still points elsewhere; a
+// run the schedule advanced past (e.g. a submit timeout, where the runner
+// may have accepted it) is not retried.
+func isStrandedScheduleRun(sched domain.Schedule, run *domain.Run) bool {
+	if run.Status != domain.RunStatusPending || run.Metadata["schedule_id"] != sched.ID.String() {
 		return false
 	}
-	return len(runningRuns) > 0
+	return sched.LastRunID == nil || *sched.LastRunID != run.ID
 }
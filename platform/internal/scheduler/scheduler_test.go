@@ -110,6 +110,10 @@ func (m *mockPipelineStore) CreatePipeline(_ context.Context, p *domain.Pipeline
 	return nil
 }
 
+func (m *mockPipelineStore) CreatePipelinesBatch(_ context.Context, p []*domain.Pipeline) ([]error, error) {
+	return make([]error, len(p)), nil
+}
+
 func (m *mockPipelineStore) UpdatePipeline(_ context.Context, _, _, _ string, _ api.UpdatePipelineRequest) (*domain.Pipeline, error) {
 	return nil, nil
 }
@@ -122,10 +126,18 @@ func (m *mockPipelineStore) UpdatePipelineRetention(_ context.Context, _ uuid.UU
 	return nil
 }
 
+func (m *mockPipelineStore) UpdatePipelineValidation(_ context.Context, _ uuid.UUID, _ *domain.ValidationResult) error {
+	return nil
+}
+
 func (m *mockPipelineStore) ListSoftDeletedPipelines(_ context.Context, _ time.Time) ([]domain.Pipeline, error) {
 	return nil, nil
 }
 
+func (m *mockPipelineStore) MovePipeline(_ context.Context, _ uuid.UUID, _, _, _ string, _ map[string]string) (*domain.Pipeline, error) {
+	return nil, nil
+}
+
 func (m *mockPipelineStore) HardDeletePipeline(_ context.Context, _ uuid.UUID) error {
 	return nil
 }
@@ -134,13 +146,22 @@ func (m *mockPipelineStore) SetDraftDirty(_ context.Context, _, _, _ string, _ b
 	return nil
 }
 
+func (m *mockPipelineStore) BumpDraftRevision(_ context.Context, _ uuid.UUID, _ int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockPipelineStore) RollbackDraftRevision(_ context.Context, _ uuid.UUID, _ int64, _ bool) error {
+	return nil
+}
+
 func (m *mockPipelineStore) PublishPipeline(_ context.Context, _, _, _ string, _ map[string]string) error {
 	return nil
 }
 
 type mockRunStore struct {
-	mu   sync.Mutex
-	runs []domain.Run
+	mu             sync.Mutex
+	runs           []domain.Run
+	submitAttempts map[string]int
 }
 
 func newMockRunStore() *mockRunStore {
@@ -190,6 +211,10 @@ func (m *mockRunStore) SaveRunLogs(_ context.Context, _ string, _ []api.LogEntry
 	return nil
 }
 
+func (m *mockRunStore) SaveRunPhases(_ context.Context, _ string, _ []api.PhaseProfile) error {
+	return nil
+}
+
 func (m *mockRunStore) GetRunLogs(_ context.Context, _ string) ([]api.LogEntry, error) {
 	return nil, nil
 }
@@ -198,7 +223,15 @@ func (m *mockRunStore) DeleteRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ i
 	return 0, nil
 }
 
-func (m *mockRunStore) DeleteRunsOlderThan(_ context.Context, _ time.Time) (int, error) {
+func (m *mockRunStore) DeleteRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRunStore) CountRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ int) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRunStore) CountRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
 	return 0, nil
 }
 
@@ -214,6 +247,38 @@ func (m *mockRunStore) LatestRunPerPipeline(_ context.Context, _ []uuid.UUID) (m
 	return nil, nil
 }
 
+func (m *mockRunStore) RunStatuses(_ context.Context, _ []uuid.UUID) (map[uuid.UUID]domain.RunStatus, error) {
+	return nil, nil
+}
+
+func (m *mockRunStore) NamespaceRunStats(_ context.Context, _ string, _ time.Time) (*api.NamespaceRunStats, error) {
+	return &api.NamespaceRunStats{ByStatus: map[string]int{}}, nil
+}
+
+func (m *mockRunStore) PipelineStats(_ context.Context, _ uuid.UUID, _ time.Time) (*api.PipelineRunStats, error) {
+	return &api.PipelineRunStats{}, nil
+}
+
+func (m *mockRunStore) RecordSubmitFailure(_ context.Context, runID string, _ string, maxAttempts int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.runs {
+		if m.runs[i].ID.String() != runID || m.runs[i].Status != domain.RunStatusPending {
+			continue
+		}
+		if m.submitAttempts == nil {
+			m.submitAttempts = map[string]int{}
+		}
+		m.submitAttempts[runID]++
+		if m.submitAttempts[runID] < maxAttempts {
+			return false, nil
+		}
+		m.runs[i].Status = domain.RunStatusFailed
+		return true, nil
+	}
+	return false, nil
+}
+
 func (m *mockRunStore) getRuns() []domain.Run {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -723,6 +788,67 @@ func TestTick_RunnerBusy_DoesNotAdvanceSchedule(t *testing.T) {
 	assert.False(t, ok, "schedule should NOT advance when runner is busy")
 }
 
+func TestTick_SubmitErrorClasses_AdvanceOnlyForPermanentFailures(t *testing.T) {
+	cases := map[string]struct {
+		err     error
+		advance bool
+	}{
+		"unavailable": {fmt.Errorf("submit pipeline: %w", executor.ErrRunnerUnavailable), false},
+		"invalid":     {fmt.Errorf("submit pipeline: %w", executor.ErrPipelineInvalid), true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			schedStore, pipelineStore, runStore := makeDueSchedules(t, 1)
+			exec := newMockExecutor()
+			exec.submitFn = func(_ context.Context, _ *domain.Run, _ *domain.Pipeline) error {
+				return tc.err
+			}
+
+			sched := New(schedStore, pipelineStore, runStore, exec, 30*time.Second)
+			sched.tick(context.Background())
+
+			require.Len(t, runStore.getRuns(), 1)
+			_, advanced := schedStore.getUpdate(schedStore.schedules[0].ID.String())
+			assert.Equal(t, tc.advance, advanced)
+		})
+	}
+}
+
+func TestTick_RunnerUnavailable_DeadLettersAfterMaxAttempts(t *testing.T) {
+	schedStore, pipelineStore, runStore := makeDueSchedules(t, 1)
+	exec := newMockExecutor()
+	exec.submitFn = func(_ context.Context, _ *domain.Run, _ *domain.Pipeline) error {
+		return fmt.Errorf("submit pipeline: %w", executor.ErrRunnerUnavailable)
+	}
+	schedID := schedStore.schedules[0].ID.String()
+
+	sched := New(schedStore, pipelineStore, runStore, exec, 30*time.Second)
+	for i := 1; i < api.MaxSubmitAttempts; i++ {
+		sched.tick(context.Background())
+		_, advanced := schedStore.getUpdate(schedID)
+		require.False(t, advanced, "attempt %d: schedule must wait for the pending run", i)
+	}
+	runs := runStore.getRuns()
+	require.Len(t, runs, 1, "the stranded run is retried, not duplicated")
+	assert.Equal(t, domain.RunStatusPending, runs[0].Status)
+
+	// The MaxSubmitAttempts-th failure dead-letters the run and advances
+	// the schedule past it.
+	sched.tick(context.Background())
+	deadRun := runStore.getRuns()[0]
+	assert.Equal(t, domain.RunStatusFailed, deadRun.Status)
+	update, advanced := schedStore.getUpdate(schedID)
+	require.True(t, advanced)
+	assert.Equal(t, deadRun.ID.String(), update.lastRunID)
+	require.Len(t, exec.getSubmits(), api.MaxSubmitAttempts)
+
+	// Later ticks never submit the dead-lettered run again.
+	sched.tick(context.Background())
+	for _, call := range exec.getSubmits()[api.MaxSubmitAttempts:] {
+		assert.NotEqual(t, deadRun.ID, call.runID)
+	}
+}
+
 // --- dispatchDue concurrency / latency tests ---
 
 // makeDueSchedules wires N schedules + their pipelines + an empty run
@@ -910,3 +1036,216 @@ func TestScheduler_LastTickStats_CountsDispatches(t *testing.T) {
 	assert.Greater(t, dur, time.Duration(0))
 	assert.Equal(t, totalSchedules, dispatched)
 }
+
+// TestTick_HigherPriorityDispatchedFirst asserts due schedules are submitted
+// in priority order, highest first.
+func TestTick_HigherPriorityDispatchedFirst(t *testing.T) {
+	schedStore, pipelineStore, runStore := makeDueSchedules(t, 3)
+	priorities := []int{-5, 10, 0}
+	for i, s := range schedStore.schedules {
+		pipelineStore.pipelines[s.PipelineID.String()].Priority = priorities[i]
+	}
+	exec := newMockExecutor()
+
+	sched := New(schedStore, pipelineStore, runStore, exec, 30*time.Second)
+	sched.tick(context.Background())
+
+	submits := exec.getSubmits()
+	require.Len(t, submits, 3)
+	assert.Equal(t, schedStore.schedules[1].PipelineID, submits[0].pipelineID)
+	assert.Equal(t, schedStore.schedules[2].PipelineID, submits[1].pipelineID)
+	assert.Equal(t, schedStore.schedules[0].PipelineID, submits[2].pipelineID)
+}
+
+// TestTick_StrandedPendingRuns_RetriedByPriority asserts that runs left
+// pending by a busy runner are re-submitted on the next tick (no new rows),
+// higher-priority pipelines first.
+func TestTick_StrandedPendingRuns_RetriedByPriority(t *testing.T) {
+	schedStore, pipelineStore, runStore := makeDueSchedules(t, 2)
+	pipelineStore.pipelines[schedStore.schedules[1].PipelineID.String()].Priority = 5
+
+	exec := newMockExecutor()
+	exec.submitFn = func(_ context.Context, _ *domain.Run, _ *domain.Pipeline) error {
+		return fmt.Errorf("submit pipeline: %w", executor.ErrRunnerBusy)
+	}
+	sched := New(schedStore, pipelineStore, runStore, exec, 30*time.Second)
+	sched.tick(context.Background())
+
+	runs := runStore.getRuns()
+	require.Len(t, runs, 2)
+	runByPipeline := map[uuid.UUID]uuid.UUID{}
+	for _, r := range runs {
+		runByPipeline[r.PipelineID] = r.ID
+	}
+
+	// Runner frees up — the pending runs are retried, not duplicated.
+	retry := newMockExecutor()
+	sched.executor = retry
+	sched.tick(context.Background())
+
+	assert.Len(t, runStore.getRuns(), 2, "retry must not create new runs")
+	submits := retry.getSubmits()
+	require.Len(t, submits, 2)
+	high, low := schedStore.schedules[1].PipelineID, schedStore.schedules[0].PipelineID
+	assert.Equal(t, runByPipeline[high], submits[0].runID)
+	assert.Equal(t, runByPipeline[low], submits[1].runID)
+
+	update, ok := schedStore.getUpdate(schedStore.schedules[0].ID.String())
+	require.True(t, ok)
+	assert.Equal(t, runByPipeline[low].String(), update.lastRunID)
+}
+
+// TestTick_StrandedRun_KeepsItsOwnPriority asserts a retried run is ordered
+// by the priority stored on the run, not the pipeline's current one.
+func TestTick_StrandedRun_KeepsItsOwnPriority(t *testing.T) {
+	schedStore, pipelineStore, runStore := makeDueSchedules(t, 2)
+	urgent, routine := schedStore.schedules[0], schedStore.schedules[1]
+	pipelineStore.pipelines[urgent.PipelineID.String()].Priority = 5
+
+	exec := newMockExecutor()
+	exec.submitFn = func(_ context.Context, _ *domain.Run, _ *domain.Pipeline) error {
+		return fmt.Errorf("submit pipeline: %w", executor.ErrRunnerBusy)
+	}
+	sched := New(schedStore, pipelineStore, runStore, exec, 30*time.Second)
+	sched.tick(context.Background())
+
+	// Lowering the pipeline's priority later doesn't demote its pending run.
+	pipelineStore.pipelines[urgent.PipelineID.String()].Priority = -5
+	retry := newMockExecutor()
+	sched.executor = retry
+	sched.tick(context.Background())
+
+	submits := retry.getSubmits()
+	require.Len(t, submits, 2)
+	assert.Equal(t, urgent.PipelineID, submits[0].pipelineID)
+	assert.Equal(t, routine.PipelineID, submits[1].pipelineID)
+}
+
+// TestTick_AdvancedPendingRun_NotRetried asserts a pending run the schedule
+// already advanced past (e.g. after a submit timeout) is not re-submitted.
+func TestTick_AdvancedPendingRun_NotRetried(t *testing.T) {
+	schedStore, pipelineStore, runStore := makeDueSchedules(t, 1)
+	s := &schedStore.schedules[0]
+	runID := uuid.New()
+	s.LastRunID = &runID
+	runStore.runs = []domain.Run{{
+		ID:         runID,
+		PipelineID: s.PipelineID,
+		Status:     domain.RunStatusPending,
+		Metadata:   map[string]string{"schedule_id": s.ID.String()},
+	}}
+	exec := newMockExecutor()
+
+	sched := New(schedStore, pipelineStore, runStore, exec, 30*time.Second)
+	sched.tick(context.Background())
+
+	assert.Empty(t, exec.getSubmits())
+}
+
+// stubSettings is an in-memory SettingsStore.
+type stubSettings struct {
+	mu     sync.Mutex
+	values map[string]json.RawMessage
+}
+
+func newStubSettings() *stubSettings {
+	return &stubSettings{values: map[string]json.RawMessage{}}
+}
+
+func (m *stubSettings) set(key string, value any) {
+	raw, _ := json.Marshal(value)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = raw
+}
+
+func (m *stubSettings) GetSetting(_ context.Context, key string) (json.RawMessage, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.values[key]
+	if !ok {
+		return nil, fmt.Errorf("setting %q: %w", key, api.ErrSettingNotFound)
+	}
+	return v, nil
+}
+
+func (m *stubSettings) PutSetting(_ context.Context, key string, value json.RawMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	return nil
+}
+
+func (m *stubSettings) GetReaperStatus(_ context.Context) (*domain.ReaperStatus, error) {
+	return nil, nil
+}
+
+func (m *stubSettings) UpdateReaperStatus(_ context.Context, _ *domain.ReaperStatus) error {
+	return nil
+}
+
+func TestTick_MaintenanceMode_DoesNothing(t *testing.T) {
+	pipelineID := uuid.New()
+	schedID := uuid.New()
+	past := time.Now().Add(-5 * time.Minute)
+
+	schedStore := newMockScheduleStore()
+	schedStore.schedules = []domain.Schedule{
+		{ID: schedID, PipelineID: pipelineID, CronExpr: "* * * * *", Enabled: true, NextRunAt: &past},
+	}
+	pipelineStore := newMockPipelineStore()
+	pipelineStore.pipelines[pipelineID.String()] = &domain.Pipeline{
+		ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "orders",
+	}
+	runStore := newMockRunStore()
+	exec := newMockExecutor()
+	settings := newStubSettings()
+	settings.set(api.MaintenanceModeKey, true)
+
+	sched := New(schedStore, pipelineStore, runStore, exec, 30*time.Second)
+	sched.Settings = settings
+	sched.tick(context.Background())
+
+	assert.Empty(t, runStore.getRuns())
+	assert.Empty(t, exec.getSubmits())
+	_, advanced := schedStore.getUpdate(schedID.String())
+	assert.False(t, advanced, "schedule must not advance during maintenance")
+
+	// Turning maintenance off fires the overdue schedule on the next tick.
+	settings.set(api.MaintenanceModeKey, false)
+	sched.tick(context.Background())
+	assert.Len(t, runStore.getRuns(), 1)
+}
+
+func TestTickInterval_SettingOverridesDefault(t *testing.T) {
+	settings := newStubSettings()
+	sched := New(newMockScheduleStore(), newMockPipelineStore(), newMockRunStore(), newMockExecutor(), 30*time.Second)
+	sched.Settings = settings
+
+	assert.Equal(t, 30*time.Second, sched.tickInterval(context.Background()), "unset falls back to New's interval")
+
+	settings.set(TickSecondsKey, 120)
+	assert.Equal(t, 2*time.Minute, sched.tickInterval(context.Background()))
+
+	for _, invalid := range []any{0, -5, maxTickSeconds + 1, "fast"} {
+		settings.set(TickSecondsKey, invalid)
+		assert.Equal(t, 30*time.Second, sched.tickInterval(context.Background()), "invalid %v is ignored", invalid)
+	}
+}
+
+func TestStart_TickIntervalSettingHotReloads(t *testing.T) {
+	settings := newStubSettings()
+	settings.set(TickSecondsKey, 1)
+	sched := New(newMockScheduleStore(), newMockPipelineStore(), newMockRunStore(), newMockExecutor(), time.Hour)
+	sched.Settings = settings
+
+	sched.Start(context.Background())
+	defer sched.Stop()
+
+	require.Eventually(t, func() bool { return sched.Interval() == time.Second }, time.Second, 10*time.Millisecond,
+		"the setting replaces New's interval from the first tick")
+
+	// The change is picked up after the next tick, without a restart.
+	settings.set(TickSecondsKey, 600)
+	require.Eventually(t, func() bool { return sched.Interval() == 10*time.Minute }, 3*time.Second, 10*time.Millisecond)
+}
@@ -3,27 +3,48 @@ package storage
 import (
 	"context"
 	"fmt"
+
+	"github.com/minio/minio-go/v7"
 )
 
+// bucketInspector is the subset of *minio.Client the health check needs.
+type bucketInspector interface {
+	BucketExists(ctx context.Context, bucket string) (bool, error)
+	GetBucketVersioning(ctx context.Context, bucket string) (minio.BucketVersioningConfiguration, error)
+}
+
 // HealthChecker implements api.HealthChecker for S3/MinIO.
-// It checks whether the configured bucket exists and is reachable.
+// It checks whether the configured bucket exists, is reachable, and has
+// object versioning enabled.
 type HealthChecker struct {
-	store *S3Store
+	client bucketInspector
+	bucket string
 }
 
 // NewHealthChecker creates an S3 health checker for the given store.
 func NewHealthChecker(store *S3Store) *HealthChecker {
-	return &HealthChecker{store: store}
+	return &HealthChecker{client: store.client, bucket: store.bucket}
 }
 
-// HealthCheck verifies S3 connectivity by checking if the bucket exists.
+// HealthCheck verifies S3 connectivity by checking if the bucket exists, then
+// that versioning is enabled on it. Publish snapshots pin S3 version IDs; on an
+// unversioned bucket every write overwrites the pinned object, so published
+// pipelines silently run draft code and rollback restores nothing.
 func (h *HealthChecker) HealthCheck(ctx context.Context) error {
-	exists, err := h.store.client.BucketExists(ctx, h.store.bucket)
+	exists, err := h.client.BucketExists(ctx, h.bucket)
 	if err != nil {
 		return fmt.Errorf("s3 bucket check: %w", err)
 	}
 	if !exists {
-		return fmt.Errorf("s3 bucket %q does not exist", h.store.bucket)
+		return fmt.Errorf("s3 bucket %q does not exist", h.bucket)
+	}
+
+	versioning, err := h.client.GetBucketVersioning(ctx, h.bucket)
+	if err != nil {
+		return fmt.Errorf("s3 bucket versioning check: %w", err)
+	}
+	if !versioning.Enabled() {
+		return fmt.Errorf("s3 bucket %q does not have versioning enabled: published pipeline versions cannot be pinned (enable with `mc version enable`)", h.bucket)
 	}
 	return nil
 }
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBucket is a bucketInspector with canned answers.
+type stubBucket struct {
+	exists        bool
+	existsErr     error
+	versioning    string // "Enabled", "Suspended" or "" (never enabled)
+	versioningErr error
+}
+
+func (s stubBucket) BucketExists(_ context.Context, _ string) (bool, error) {
+	return s.exists, s.existsErr
+}
+
+func (s stubBucket) GetBucketVersioning(_ context.Context, _ string) (minio.BucketVersioningConfiguration, error) {
+	return minio.BucketVersioningConfiguration{Status: s.versioning}, s.versioningErr
+}
+
+func TestHealthCheck_VersioningEnabled_OK(t *testing.T) {
+	h := &HealthChecker{client: stubBucket{exists: true, versioning: "Enabled"}, bucket: "rat"}
+
+	require.NoError(t, h.HealthCheck(context.Background()))
+}
+
+func TestHealthCheck_VersioningDisabled_Fails(t *testing.T) {
+	for _, status := range []string{"", "Suspended"} {
+		h := &HealthChecker{client: stubBucket{exists: true, versioning: status}, bucket: "rat"}
+
+		err := h.HealthCheck(context.Background())
+		require.Error(t, err, "versioning status %q", status)
+		assert.Contains(t, err.Error(), "versioning enabled")
+		assert.Contains(t, err.Error(), `"rat"`)
+	}
+}
+
+func TestHealthCheck_MissingBucket_Fails(t *testing.T) {
+	h := &HealthChecker{client: stubBucket{exists: false}, bucket: "rat"}
+
+	err := h.HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestHealthCheck_VersioningLookupError_Fails(t *testing.T) {
+	h := &HealthChecker{client: stubBucket{exists: true, versioningErr: errors.New("access denied")}, bucket: "rat"}
+
+	err := h.HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
@@ -9,8 +9,8 @@ import (
 )
 
 // S3QualityStore implements api.QualityStore backed by S3.
-// Quality tests are SQL files stored at {ns}/pipelines/{layer}/{name}/tests/quality/{testName}.sql
-// with --@ annotations for severity and description.
+// Quality tests are SQL files stored at {prefix}tests/quality/{testName}.sql,
+// where prefix is the pipeline's storage prefix, with --@ annotations for severity and description.
 type S3QualityStore struct {
 	store api.StorageStore
 }
@@ -20,12 +20,12 @@ func NewS3QualityStore(store api.StorageStore) *S3QualityStore {
 	return &S3QualityStore{store: store}
 }
 
-func qualityPrefix(ns, layer, pipeline string) string {
-	return fmt.Sprintf("%s/pipelines/%s/%s/tests/quality/", ns, layer, pipeline)
+func qualityPrefix(prefix string) string {
+	return prefix + "tests/quality/"
 }
 
-func qualityPath(ns, layer, pipeline, testName string) string {
-	return qualityPrefix(ns, layer, pipeline) + testName + ".sql"
+func qualityPath(prefix, testName string) string {
+	return qualityPrefix(prefix) + testName + ".sql"
 }
 
 // parseAnnotations extracts --@key: value annotations from SQL content.
@@ -75,8 +75,8 @@ func parseTags(raw string) []string {
 }
 
 // ListTests lists all quality tests for a pipeline by scanning S3.
-func (q *S3QualityStore) ListTests(ctx context.Context, ns, layer, pipeline string) ([]api.QualityTest, error) {
-	prefix := qualityPrefix(ns, layer, pipeline)
+func (q *S3QualityStore) ListTests(ctx context.Context, pipelinePrefix string) ([]api.QualityTest, error) {
+	prefix := qualityPrefix(pipelinePrefix)
 	files, err := q.store.ListFiles(ctx, prefix)
 	if err != nil {
 		return nil, fmt.Errorf("list quality tests: %w", err)
@@ -111,8 +111,8 @@ func (q *S3QualityStore) ListTests(ctx context.Context, ns, layer, pipeline stri
 }
 
 // CreateTest writes a quality test SQL file to S3.
-func (q *S3QualityStore) CreateTest(ctx context.Context, ns, layer, pipeline string, test api.QualityTest) error {
-	path := qualityPath(ns, layer, pipeline, test.Name)
+func (q *S3QualityStore) CreateTest(ctx context.Context, prefix string, test api.QualityTest) error {
+	path := qualityPath(prefix, test.Name)
 
 	// Check if test already exists
 	existing, err := q.store.StatFile(ctx, path)
@@ -131,8 +131,8 @@ func (q *S3QualityStore) CreateTest(ctx context.Context, ns, layer, pipeline str
 }
 
 // DeleteTest removes a quality test SQL file from S3.
-func (q *S3QualityStore) DeleteTest(ctx context.Context, ns, layer, pipeline, testName string) error {
-	path := qualityPath(ns, layer, pipeline, testName)
+func (q *S3QualityStore) DeleteTest(ctx context.Context, prefix, testName string) error {
+	path := qualityPath(prefix, testName)
 	if err := q.store.DeleteFile(ctx, path); err != nil {
 		return fmt.Errorf("delete quality test: %w", err)
 	}
@@ -141,7 +141,7 @@ func (q *S3QualityStore) DeleteTest(ctx context.Context, ns, layer, pipeline, te
 
 // RunTests is a no-op at the storage level — test execution is handled by the runner.
 // This returns an empty slice to indicate no tests were run server-side.
-func (q *S3QualityStore) RunTests(_ context.Context, _, _, _ string) ([]api.QualityTestResult, error) {
+func (q *S3QualityStore) RunTests(_ context.Context, _ string) ([]api.QualityTestResult, error) {
 	return []api.QualityTestResult{}, nil
 }
 
@@ -160,6 +160,7 @@ func (s *S3Store) ListFiles(ctx context.Context, prefix string) ([]api.FileInfo,
 			Size:     obj.Size,
 			Modified: obj.LastModified,
 			Type:     detectFileType(obj.Key),
+			ETag:     obj.ETag,
 		})
 	}
 
@@ -200,6 +201,39 @@ func (s *S3Store) ReadFile(ctx context.Context, path string) (*api.FileContent,
 	}, nil
 }
 
+// s3FileReader is an open S3 object. Closing it also releases the data
+// timeout the object was opened with.
+type s3FileReader struct {
+	*minio.Object
+	cancel context.CancelFunc
+}
+
+func (r *s3FileReader) Close() error {
+	defer r.cancel()
+	return r.Object.Close()
+}
+
+// OpenFile opens an object for streaming reads. Returns nil, nil if the
+// object does not exist. The data timeout covers the reader's whole life.
+func (s *S3Store) OpenFile(ctx context.Context, path string) (api.FileReader, error) {
+	ctx, cancel := s.withDataTimeout(ctx)
+
+	obj, err := s.client.GetObject(ctx, s.bucket, path, minio.GetObjectOptions{})
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("get object %s: %w", path, err)
+	}
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		cancel()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat object %s: %w", path, err)
+	}
+	return &s3FileReader{Object: obj, cancel: cancel}, nil
+}
+
 // WriteFile creates or overwrites an object with the given content.
 // Returns the S3 version ID of the written object (empty if versioning is not enabled).
 func (s *S3Store) WriteFile(ctx context.Context, path string, content []byte) (string, error) {
@@ -218,6 +252,16 @@ func (s *S3Store) WriteFile(ctx context.Context, path string, content []byte) (s
 	return info.VersionID, nil
 }
 
+// PresignPutURL returns a URL that allows a single PUT of path without
+// credentials until expiry. Implements api.PresignedUploader.
+func (s *S3Store) PresignPutURL(ctx context.Context, path string, expiry time.Duration) (string, error) {
+	u, err := s.client.PresignedPutObject(ctx, s.bucket, path, expiry)
+	if err != nil {
+		return "", fmt.Errorf("presign put %s: %w", path, err)
+	}
+	return u.String(), nil
+}
+
 // ReadFileVersion reads a specific version of a file from S3.
 // Returns nil, nil if the version does not exist.
 func (s *S3Store) ReadFileVersion(ctx context.Context, path, versionID string) (*api.FileContent, error) {
@@ -278,6 +322,61 @@ func (s *S3Store) StatFile(ctx context.Context, path string) (*api.FileInfo, err
 	}, nil
 }
 
+// StatFileVersion returns metadata about a specific version of an object.
+// Returns nil, nil if the version does not exist.
+func (s *S3Store) StatFileVersion(ctx context.Context, path, versionID string) (*api.FileInfo, error) {
+	ctx, cancel := s.withMetadataTimeout(ctx)
+	defer cancel()
+
+	info, err := s.client.StatObject(ctx, s.bucket, path, minio.StatObjectOptions{
+		VersionID: versionID,
+	})
+	if err != nil {
+		resp := minio.ToErrorResponse(err)
+		if resp.Code == "NoSuchKey" || resp.Code == "NoSuchVersion" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("stat object version %s@%s: %w", path, versionID, err)
+	}
+	return &api.FileInfo{
+		Path:      info.Key,
+		Size:      info.Size,
+		Modified:  info.LastModified,
+		Type:      detectFileType(info.Key),
+		VersionID: info.VersionID,
+	}, nil
+}
+
+// CopyFile copies the current version of srcPath to dstPath server-side.
+// Returns the S3 version ID of the new object, or api.ErrFileNotFound if
+// srcPath does not exist.
+func (s *S3Store) CopyFile(ctx context.Context, srcPath, dstPath string) (string, error) {
+	return s.CopyFileVersion(ctx, srcPath, "", dstPath)
+}
+
+// CopyFileVersion copies a specific version of srcPath to dstPath server-side
+// (an empty srcVersionID copies the current version). Copying a version onto
+// its own path makes it the new HEAD. Content type and metadata are carried
+// over from the source. Single-request copies are limited to 5 GiB, far above
+// anything ratd writes.
+func (s *S3Store) CopyFileVersion(ctx context.Context, srcPath, srcVersionID, dstPath string) (string, error) {
+	ctx, cancel := s.withDataTimeout(ctx)
+	defer cancel()
+
+	info, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.bucket, Object: dstPath},
+		minio.CopySrcOptions{Bucket: s.bucket, Object: srcPath, VersionID: srcVersionID},
+	)
+	if err != nil {
+		resp := minio.ToErrorResponse(err)
+		if resp.Code == "NoSuchKey" || resp.Code == "NoSuchVersion" {
+			return "", fmt.Errorf("copy object %s@%s: %w", srcPath, srcVersionID, api.ErrFileNotFound)
+		}
+		return "", fmt.Errorf("copy object %s@%s to %s: %w", srcPath, srcVersionID, dstPath, err)
+	}
+	return info.VersionID, nil
+}
+
 // DeleteFile removes an object. S3 delete is idempotent — deleting a
 // non-existent object is not an error. This avoids an unnecessary StatObject
 // round-trip before every delete.
@@ -1,10 +1,13 @@
 package storage_test
 
 import (
+	"bytes"
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,6 +45,27 @@ func TestS3Store_ReadNotFound_ReturnsNil(t *testing.T) {
 	assert.Nil(t, file)
 }
 
+func TestS3Store_OpenFile_StreamsContent(t *testing.T) {
+	store := testS3Store(t)
+	ctx := context.Background()
+
+	require.NoError(t, writeFileHelper(ctx, store, "default/landing/raw/a.csv", []byte("id,name\n1,a\n")))
+
+	f, err := store.OpenFile(ctx, "default/landing/raw/a.csv")
+	require.NoError(t, err)
+	require.NotNil(t, f)
+	defer f.Close()
+
+	header := make([]byte, 7)
+	_, err = f.ReadAt(header, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "id,name", string(header))
+
+	missing, err := store.OpenFile(ctx, "default/landing/raw/missing.csv")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
 func TestS3Store_ListWithPrefix(t *testing.T) {
 	store := testS3Store(t)
 	ctx := context.Background()
@@ -144,6 +168,82 @@ func TestS3Store_WriteFile_ReturnsVersionID(t *testing.T) {
 	_ = versionID
 }
 
+func TestS3Store_CopyFile_CopiesServerSide(t *testing.T) {
+	store := testS3Store(t)
+	ctx := context.Background()
+
+	require.NoError(t, writeFileHelper(ctx, store, "default/pipelines/silver/orders/pipeline.sql", []byte("SELECT 1")))
+
+	_, err := store.CopyFile(ctx, "default/pipelines/silver/orders/pipeline.sql", "default/pipelines/gold/orders/pipeline.sql")
+	require.NoError(t, err)
+
+	file, err := store.ReadFile(ctx, "default/pipelines/gold/orders/pipeline.sql")
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.Equal(t, "SELECT 1", file.Content)
+
+	info, err := store.StatFile(ctx, "default/pipelines/gold/orders/pipeline.sql")
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "pipeline-sql", info.Type)
+}
+
+func TestS3Store_CopyFile_MissingSource_ReturnsErrFileNotFound(t *testing.T) {
+	store := testS3Store(t)
+	ctx := context.Background()
+
+	_, err := store.CopyFile(ctx, "nonexistent/path.sql", "copy/path.sql")
+	require.ErrorIs(t, err, api.ErrFileNotFound)
+}
+
+func TestS3Store_CopyFileVersion_RestoresOlderVersion(t *testing.T) {
+	store := testS3Store(t)
+	ctx := context.Background()
+
+	v1, err := store.WriteFile(ctx, "versioned/restore.sql", []byte("v1"))
+	require.NoError(t, err)
+	if v1 == "" {
+		t.Skip("bucket versioning not enabled, skipping version copy test")
+	}
+	require.NoError(t, writeFileHelper(ctx, store, "versioned/restore.sql", []byte("v2")))
+
+	info, err := store.StatFileVersion(ctx, "versioned/restore.sql", v1)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, v1, info.VersionID)
+
+	newVersion, err := store.CopyFileVersion(ctx, "versioned/restore.sql", v1, "versioned/restore.sql")
+	require.NoError(t, err)
+	assert.NotEqual(t, v1, newVersion)
+
+	file, err := store.ReadFile(ctx, "versioned/restore.sql")
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.Equal(t, "v1", file.Content)
+}
+
+func TestS3Store_PresignPutURL_AllowsDirectUpload(t *testing.T) {
+	store := testS3Store(t)
+	ctx := context.Background()
+
+	uploadURL, err := store.PresignPutURL(ctx, "default/landing/uploads/orders.csv", time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, uploadURL, "default/landing/uploads/orders.csv")
+	assert.Contains(t, uploadURL, "X-Amz-Signature=")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewBufferString("id\n1\n"))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	file, err := store.ReadFile(ctx, "default/landing/uploads/orders.csv")
+	require.NoError(t, err)
+	require.NotNil(t, file)
+	assert.Equal(t, "id\n1\n", file.Content)
+}
+
 func TestS3Config_DefaultTimeouts(t *testing.T) {
 	assert.Equal(t, 10*time.Second, storage.DefaultMetadataTimeout)
 	assert.Equal(t, 60*time.Second, storage.DefaultDataTimeout)
@@ -30,6 +30,12 @@ type cronConfig struct {
 type cronDependencyConfig struct {
 	CronExpr     string   `json:"cron_expr"`
 	Dependencies []string `json:"dependencies"` // "ns.layer.pipeline"
+	// WindowMinutes > 0 switches from "any dependency has new data" to "every
+	// dependency succeeded within the last WindowMinutes".
+	WindowMinutes int `json:"window_minutes,omitempty"`
+	// FailOpen fires a windowed trigger on schedule even when a dependency is
+	// stale (logged as a warning) instead of waiting for it.
+	FailOpen bool `json:"fail_open,omitempty"`
 }
 
 // Evaluator checks cron and cron_dependency triggers and fires runs when they're due.
@@ -47,6 +53,14 @@ type Evaluator struct {
 	EventCh       <-chan postgres.Event
 	eventCancel   func() // cancel function for unsubscribing from event bus
 
+	// EventBus, when set, receives a trigger_fired event for every run the
+	// evaluator fires. Publishing is best-effort.
+	EventBus api.EventPublisher
+
+	// Settings, when set, is checked for maintenance mode before every
+	// evaluation; while it is on, ticks and run_completed events are skipped.
+	Settings api.SettingsStore
+
 	cancel    context.CancelFunc
 	done      chan struct{}
 }
@@ -140,6 +154,10 @@ func (e *Evaluator) handleRunCompleted(ctx context.Context, event postgres.Event
 	if payload.Status != string(domain.RunStatusSuccess) {
 		return
 	}
+	if e.maintenanceMode(ctx) {
+		slog.Info("trigger evaluator: maintenance mode on, ignoring run_completed event", "run_id", payload.RunID)
+		return
+	}
 
 	slog.Debug("trigger evaluator: run_completed event received",
 		"run_id", payload.RunID, "pipeline_id", payload.PipelineID)
@@ -159,6 +177,10 @@ func (e *Evaluator) handleRunCompleted(ctx context.Context, event postgres.Event
 
 // tick evaluates all enabled cron and cron_dependency triggers.
 func (e *Evaluator) tick(ctx context.Context) {
+	if e.maintenanceMode(ctx) {
+		slog.Info("trigger evaluator: maintenance mode on, skipping tick")
+		return
+	}
 	now := time.Now()
 
 	// Evaluate cron triggers
@@ -182,6 +204,20 @@ func (e *Evaluator) tick(ctx context.Context) {
 	}
 }
 
+// maintenanceMode reports whether evaluation should be skipped. A settings
+// read failure is logged and treated as off.
+func (e *Evaluator) maintenanceMode(ctx context.Context) bool {
+	if e.Settings == nil {
+		return false
+	}
+	on, err := api.MaintenanceModeEnabled(ctx, e.Settings)
+	if err != nil {
+		slog.Warn("trigger evaluator: failed to read maintenance mode", "error", err)
+		return false
+	}
+	return on
+}
+
 // evaluateCron fires a cron trigger if its schedule is due.
 func (e *Evaluator) evaluateCron(ctx context.Context, t domain.PipelineTrigger, now time.Time) {
 	var cfg cronConfig
@@ -204,7 +240,13 @@ func (e *Evaluator) evaluateCron(ctx context.Context, t domain.PipelineTrigger,
 }
 
 // evaluateCronDependency fires a cron_dependency trigger if its schedule is due
-// AND at least one upstream dependency has new successful data since last trigger.
+// AND its dependencies are satisfied: by default, at least one upstream
+// dependency has new successful data since last trigger; with window_minutes,
+// every dependency has a successful run that finished within the window.
+//
+// An unsatisfied trigger is skipped without touching last_triggered_at, so it
+// stays due and fires on the first tick (or run_completed event) after its
+// dependencies catch up.
 func (e *Evaluator) evaluateCronDependency(ctx context.Context, t domain.PipelineTrigger, now time.Time) {
 	var cfg cronDependencyConfig
 	if err := json.Unmarshal(t.Config, &cfg); err != nil {
@@ -222,6 +264,22 @@ func (e *Evaluator) evaluateCronDependency(ctx context.Context, t domain.Pipelin
 		return
 	}
 
+	if cfg.WindowMinutes > 0 {
+		windowStart := now.Add(-time.Duration(cfg.WindowMinutes) * time.Minute)
+		stale := e.staleDependencies(ctx, t, cfg.Dependencies, windowStart)
+		if len(stale) > 0 {
+			if !cfg.FailOpen {
+				slog.Debug("trigger evaluator: cron_dependency skipped (dependencies not fresh)",
+					"trigger_id", t.ID, "window_minutes", cfg.WindowMinutes, "stale", stale)
+				return
+			}
+			slog.Warn("trigger evaluator: cron_dependency firing with stale dependencies (fail_open)",
+				"trigger_id", t.ID, "window_minutes", cfg.WindowMinutes, "stale", stale)
+		}
+		e.fireAndUpdate(ctx, t, "trigger:cron_dependency:"+cfg.CronExpr)
+		return
+	}
+
 	// Check if any dependency has a successful run after last_triggered_at
 	hasNewData := false
 	for _, dep := range cfg.Dependencies {
@@ -266,6 +324,41 @@ func (e *Evaluator) evaluateCronDependency(ctx context.Context, t domain.Pipelin
 	e.fireAndUpdate(ctx, t, "trigger:cron_dependency:"+cfg.CronExpr)
 }
 
+// staleDependencies returns the dependencies without a successful run that
+// finished at or after windowStart. Malformed references and lookup failures
+// count as stale — an unknown dependency is not a fresh one.
+func (e *Evaluator) staleDependencies(ctx context.Context, t domain.PipelineTrigger, deps []string, windowStart time.Time) []string {
+	var stale []string
+	for _, dep := range deps {
+		parts := strings.SplitN(dep, ".", 3)
+		if len(parts) != 3 {
+			stale = append(stale, dep)
+			continue
+		}
+
+		// One successful run inside the window is enough; don't load the
+		// dependency's whole history to find it.
+		runs, err := e.runs.ListRuns(ctx, api.RunFilter{
+			Namespace:     parts[0],
+			Layer:         parts[1],
+			Pipeline:      parts[2],
+			Status:        string(domain.RunStatusSuccess),
+			FinishedAfter: &windowStart,
+			Limit:         1,
+		})
+		if err != nil {
+			slog.Warn("trigger evaluator: failed to list runs for dependency",
+				"trigger_id", t.ID, "dependency", dep, "error", err)
+			stale = append(stale, dep)
+			continue
+		}
+		if len(runs) == 0 {
+			stale = append(stale, dep)
+		}
+	}
+	return stale
+}
+
 // isDue checks whether a trigger's cron schedule is due based on last_triggered_at.
 // Uses catch-up-once policy: if last_triggered_at is nil, initialize and don't fire.
 func (e *Evaluator) isDue(t domain.PipelineTrigger, cronSched cron.Schedule, now time.Time) bool {
@@ -329,6 +422,7 @@ func (e *Evaluator) fireAndUpdate(ctx context.Context, t domain.PipelineTrigger,
 		PipelineID: pipeline.ID,
 		Status:     domain.RunStatusPending,
 		Trigger:    triggerLabel,
+		Metadata:   map[string]string{"trigger_id": t.ID.String()},
 	}
 
 	if err := e.runs.CreateRun(ctx, run); err != nil {
@@ -336,7 +430,8 @@ func (e *Evaluator) fireAndUpdate(ctx context.Context, t domain.PipelineTrigger,
 		return
 	}
 
-	if err := e.executor.Submit(ctx, run, pipeline); err != nil {
+	// Nothing resubmits a triggered run, so a failed submit fails it.
+	if err := api.SubmitOrFail(ctx, e.executor, e.runs, run, pipeline); err != nil {
 		slog.Error("trigger evaluator: executor submit failed", "run_id", run.ID, "error", err)
 	}
 
@@ -347,5 +442,17 @@ func (e *Evaluator) fireAndUpdate(ctx context.Context, t domain.PipelineTrigger,
 		slog.Error("trigger evaluator: failed to backfill last_run_id", "trigger_id", t.ID, "error", err)
 	}
 
+	if e.EventBus != nil {
+		err := e.EventBus.Publish(ctx, postgres.ChannelTriggerFired, postgres.TriggerFiredPayload{
+			TriggerID:  t.ID.String(),
+			RunID:      run.ID.String(),
+			PipelineID: pipeline.ID.String(),
+			Type:       string(t.Type),
+		})
+		if err != nil {
+			slog.Warn("trigger evaluator: failed to publish trigger_fired event", "trigger_id", t.ID, "run_id", run.ID, "error", err)
+		}
+	}
+
 	slog.Info("trigger evaluator: fired run", "trigger_id", t.ID, "trigger_type", t.Type, "run_id", run.ID)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/rat-data/rat/platform/internal/api"
 	"github.com/rat-data/rat/platform/internal/domain"
+	"github.com/rat-data/rat/platform/internal/postgres"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -86,6 +87,14 @@ func (s *raceTriggerStore) FindTriggersByFilePattern(_ context.Context, _, _ str
 	return nil, nil
 }
 
+func (s *raceTriggerStore) SetTriggersEnabledByPipeline(_ context.Context, _ uuid.UUID, _ bool) (int, error) {
+	return 0, nil
+}
+
+func (s *raceTriggerStore) ListAllTriggers(_ context.Context, _ api.TriggerFilter) ([]domain.PipelineTriggerListItem, int, error) {
+	return nil, 0, nil
+}
+
 func (s *raceTriggerStore) UpdateTriggerFired(_ context.Context, triggerID string, runID uuid.UUID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -105,6 +114,10 @@ func (s *raceTriggerStore) UpdateTriggerFired(_ context.Context, triggerID strin
 	return nil
 }
 
+func (s *raceTriggerStore) UpdateTriggerBackoffStreak(_ context.Context, _ string, _ int) error {
+	return nil
+}
+
 func (s *raceTriggerStore) UpdateTriggerFiredCAS(
 	_ context.Context,
 	triggerID string,
@@ -160,6 +173,9 @@ func (s *stubPipelineStore) GetPipelineByID(_ context.Context, _ string) (*domai
 func (s *stubPipelineStore) CreatePipeline(_ context.Context, _ *domain.Pipeline) error {
 	return nil
 }
+func (s *stubPipelineStore) CreatePipelinesBatch(_ context.Context, p []*domain.Pipeline) ([]error, error) {
+	return make([]error, len(p)), nil
+}
 func (s *stubPipelineStore) UpdatePipeline(_ context.Context, _, _, _ string, _ api.UpdatePipelineRequest) (*domain.Pipeline, error) {
 	return nil, nil
 }
@@ -167,15 +183,30 @@ func (s *stubPipelineStore) DeletePipeline(_ context.Context, _, _, _ string) er
 func (s *stubPipelineStore) SetDraftDirty(_ context.Context, _, _, _ string, _ bool) error {
 	return nil
 }
+func (s *stubPipelineStore) BumpDraftRevision(_ context.Context, _ uuid.UUID, _ int64) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubPipelineStore) RollbackDraftRevision(_ context.Context, _ uuid.UUID, _ int64, _ bool) error {
+	return nil
+}
+
 func (s *stubPipelineStore) PublishPipeline(_ context.Context, _, _, _ string, _ map[string]string) error {
 	return nil
 }
 func (s *stubPipelineStore) UpdatePipelineRetention(_ context.Context, _ uuid.UUID, _ json.RawMessage) error {
 	return nil
 }
+func (s *stubPipelineStore) UpdatePipelineValidation(_ context.Context, _ uuid.UUID, _ *domain.ValidationResult) error {
+	return nil
+}
+
 func (s *stubPipelineStore) ListSoftDeletedPipelines(_ context.Context, _ time.Time) ([]domain.Pipeline, error) {
 	return nil, nil
 }
+func (s *stubPipelineStore) MovePipeline(_ context.Context, _ uuid.UUID, _, _, _ string, _ map[string]string) (*domain.Pipeline, error) {
+	return nil, nil
+}
 func (s *stubPipelineStore) HardDeletePipeline(_ context.Context, _ uuid.UUID) error { return nil }
 
 // raceRunStore is a thread-safe in-memory RunStore. ListRuns returns a fixed
@@ -185,12 +216,29 @@ type raceRunStore struct {
 	mu             sync.Mutex
 	created        []*domain.Run
 	dependencyRuns []domain.Run
+	// runsByDependency, when set, replaces dependencyRuns with per-dependency
+	// runs keyed "ns.layer.pipeline".
+	runsByDependency map[string][]domain.Run
 }
 
-func (s *raceRunStore) ListRuns(_ context.Context, _ api.RunFilter) ([]domain.Run, error) {
+func (s *raceRunStore) ListRuns(_ context.Context, filter api.RunFilter) ([]domain.Run, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	return append([]domain.Run(nil), s.dependencyRuns...), nil
+	runs := s.dependencyRuns
+	if s.runsByDependency != nil {
+		runs = s.runsByDependency[filter.Namespace+"."+filter.Layer+"."+filter.Pipeline]
+	}
+	var result []domain.Run
+	for _, run := range runs {
+		if filter.FinishedAfter != nil && (run.FinishedAt == nil || run.FinishedAt.Before(*filter.FinishedAfter)) {
+			continue
+		}
+		result = append(result, run)
+	}
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
 }
 func (s *raceRunStore) CountRuns(_ context.Context, _ api.RunFilter) (int, error) { return 0, nil }
 func (s *raceRunStore) GetRun(_ context.Context, _ string) (*domain.Run, error)   { return nil, nil }
@@ -208,10 +256,21 @@ func (s *raceRunStore) GetRunLogs(_ context.Context, _ string) ([]api.LogEntry,
 	return nil, nil
 }
 func (s *raceRunStore) SaveRunLogs(_ context.Context, _ string, _ []api.LogEntry) error { return nil }
+func (s *raceRunStore) SaveRunPhases(_ context.Context, _ string, _ []api.PhaseProfile) error {
+	return nil
+}
 func (s *raceRunStore) DeleteRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ int) (int, error) {
 	return 0, nil
 }
-func (s *raceRunStore) DeleteRunsOlderThan(_ context.Context, _ time.Time) (int, error) {
+func (s *raceRunStore) DeleteRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (s *raceRunStore) CountRunsBeyondLimit(_ context.Context, _ uuid.UUID, _ int) (int, error) {
+	return 0, nil
+}
+
+func (s *raceRunStore) CountRunsOlderThan(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
 	return 0, nil
 }
 func (s *raceRunStore) ListStuckRuns(_ context.Context, _ time.Time) ([]domain.Run, error) {
@@ -223,6 +282,21 @@ func (s *raceRunStore) ListStuckPendingRuns(_ context.Context, _ time.Time) ([]d
 func (s *raceRunStore) LatestRunPerPipeline(_ context.Context, _ []uuid.UUID) (map[uuid.UUID]*domain.Run, error) {
 	return nil, nil
 }
+func (s *raceRunStore) RunStatuses(_ context.Context, _ []uuid.UUID) (map[uuid.UUID]domain.RunStatus, error) {
+	return nil, nil
+}
+
+func (s *raceRunStore) NamespaceRunStats(_ context.Context, _ string, _ time.Time) (*api.NamespaceRunStats, error) {
+	return &api.NamespaceRunStats{ByStatus: map[string]int{}}, nil
+}
+
+func (s *raceRunStore) PipelineStats(_ context.Context, _ uuid.UUID, _ time.Time) (*api.PipelineRunStats, error) {
+	return &api.PipelineRunStats{}, nil
+}
+
+func (s *raceRunStore) RecordSubmitFailure(_ context.Context, _ string, _ string, _ int) (bool, error) {
+	return false, nil
+}
 
 // raceExecutor records every Submit call so the test can assert the count.
 // The remaining Executor methods are no-ops — they exist only to satisfy
@@ -339,3 +413,207 @@ func TestEvaluator_ConcurrentTickAndEvent_OnlyOneFire(t *testing.T) {
 	exec.mu.Unlock()
 	assert.Equal(t, 1, execCalls, "executor must see exactly one Submit")
 }
+
+func TestEvaluator_Fire_PublishesTriggerFired(t *testing.T) {
+	pipelineID := uuid.New()
+	triggerID := uuid.New()
+	pastFire := time.Now().Add(-2 * time.Hour)
+
+	triggers := &raceTriggerStore{}
+	triggers.addTrigger(domain.PipelineTrigger{
+		ID:              triggerID,
+		PipelineID:      pipelineID,
+		Type:            domain.TriggerTypeCron,
+		Config:          json.RawMessage(`{"cron_expr":"* * * * *"}`),
+		Enabled:         true,
+		LastTriggeredAt: &pastFire,
+	})
+	pipelines := &stubPipelineStore{pipeline: &domain.Pipeline{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "downstream"}}
+	runs := &raceRunStore{}
+
+	bus := postgres.NewMemoryEventBus()
+	ch, cancel := bus.Subscribe(postgres.ChannelTriggerFired)
+	defer cancel()
+
+	eval := NewEvaluator(triggers, pipelines, runs, &raceExecutor{}, time.Minute)
+	eval.EventBus = bus
+
+	observed, err := triggers.FindTriggersByType(context.Background(), string(domain.TriggerTypeCron))
+	require.NoError(t, err)
+	require.Len(t, observed, 1)
+	eval.fireAndUpdate(context.Background(), observed[0], "trigger:cron:* * * * *")
+
+	runs.mu.Lock()
+	require.Len(t, runs.created, 1)
+	runID := runs.created[0].ID
+	runs.mu.Unlock()
+
+	select {
+	case event := <-ch:
+		var got postgres.TriggerFiredPayload
+		require.NoError(t, json.Unmarshal(event.Payload, &got))
+		assert.Equal(t, postgres.TriggerFiredPayload{
+			TriggerID:  triggerID.String(),
+			RunID:      runID.String(),
+			PipelineID: pipelineID.String(),
+			Type:       string(domain.TriggerTypeCron),
+		}, got)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for trigger_fired event")
+	}
+}
+
+// evaluateWindowed runs one evaluateCronDependency pass for an overdue
+// windowed trigger over deps (dependency → finished_at of its one successful
+// run, nil for none) and returns how many runs were created.
+func evaluateWindowed(t *testing.T, config string, now time.Time, deps map[string]*time.Time) int {
+	t.Helper()
+	pipelineID := uuid.New()
+	pastFire := now.Add(-2 * time.Hour)
+
+	triggers := &raceTriggerStore{}
+	triggers.addTrigger(domain.PipelineTrigger{
+		ID:              uuid.New(),
+		PipelineID:      pipelineID,
+		Type:            domain.TriggerTypeCronDependency,
+		Config:          json.RawMessage(config),
+		Enabled:         true,
+		LastTriggeredAt: &pastFire,
+	})
+	pipelines := &stubPipelineStore{pipeline: &domain.Pipeline{ID: pipelineID, Namespace: "default", Layer: domain.LayerGold, Name: "report"}}
+	runs := &raceRunStore{runsByDependency: map[string][]domain.Run{}}
+	for dep, finished := range deps {
+		if finished != nil {
+			runs.runsByDependency[dep] = []domain.Run{{ID: uuid.New(), Status: domain.RunStatusSuccess, FinishedAt: finished}}
+		}
+	}
+
+	eval := NewEvaluator(triggers, pipelines, runs, &raceExecutor{}, time.Minute)
+	observed, err := triggers.FindTriggersByType(context.Background(), string(domain.TriggerTypeCronDependency))
+	require.NoError(t, err)
+	require.Len(t, observed, 1)
+	eval.evaluateCronDependency(context.Background(), observed[0], now)
+
+	runs.mu.Lock()
+	defer runs.mu.Unlock()
+	return len(runs.created)
+}
+
+const windowedConfig = `{"cron_expr":"* * * * *","dependencies":["default.bronze.orders","default.bronze.customers"],"window_minutes":60}`
+
+func ago(now time.Time, d time.Duration) *time.Time {
+	t := now.Add(-d)
+	return &t
+}
+
+func TestEvaluator_CronDependencyWindow_AllFresh_Fires(t *testing.T) {
+	now := time.Now()
+	created := evaluateWindowed(t, windowedConfig, now, map[string]*time.Time{
+		"default.bronze.orders":    ago(now, 5*time.Minute),
+		"default.bronze.customers": ago(now, 59*time.Minute),
+	})
+	assert.Equal(t, 1, created)
+}
+
+func TestEvaluator_CronDependencyWindow_OneStale_Skips(t *testing.T) {
+	now := time.Now()
+	created := evaluateWindowed(t, windowedConfig, now, map[string]*time.Time{
+		"default.bronze.orders":    ago(now, 5*time.Minute),
+		"default.bronze.customers": ago(now, 61*time.Minute),
+	})
+	assert.Equal(t, 0, created, "one stale dependency must hold the trigger back")
+}
+
+func TestEvaluator_CronDependencyWindow_NeverSucceeded_Skips(t *testing.T) {
+	now := time.Now()
+	created := evaluateWindowed(t, windowedConfig, now, map[string]*time.Time{
+		"default.bronze.orders":    ago(now, 5*time.Minute),
+		"default.bronze.customers": nil,
+	})
+	assert.Equal(t, 0, created)
+}
+
+func TestEvaluator_CronDependencyWindow_Boundary(t *testing.T) {
+	now := time.Now()
+
+	atStart := evaluateWindowed(t, windowedConfig, now, map[string]*time.Time{
+		"default.bronze.orders":    ago(now, 5*time.Minute),
+		"default.bronze.customers": ago(now, time.Hour),
+	})
+	assert.Equal(t, 1, atStart, "a run finishing exactly at the window start is fresh")
+
+	justBefore := evaluateWindowed(t, windowedConfig, now, map[string]*time.Time{
+		"default.bronze.orders":    ago(now, 5*time.Minute),
+		"default.bronze.customers": ago(now, time.Hour+time.Second),
+	})
+	assert.Equal(t, 0, justBefore, "a run finishing before the window start is stale")
+}
+
+func TestEvaluator_CronDependencyWindow_FailOpen_FiresWhenStale(t *testing.T) {
+	now := time.Now()
+	config := `{"cron_expr":"* * * * *","dependencies":["default.bronze.orders","default.bronze.customers"],"window_minutes":60,"fail_open":true}`
+	created := evaluateWindowed(t, config, now, map[string]*time.Time{
+		"default.bronze.orders":    ago(now, 5*time.Minute),
+		"default.bronze.customers": ago(now, 3*time.Hour),
+	})
+	assert.Equal(t, 1, created)
+}
+
+// maintenanceSettings is a SettingsStore holding only the maintenance flag.
+type maintenanceSettings struct {
+	enabled bool
+}
+
+func (m *maintenanceSettings) GetSetting(_ context.Context, key string) (json.RawMessage, error) {
+	if key != api.MaintenanceModeKey {
+		return nil, api.ErrSettingNotFound
+	}
+	return json.Marshal(m.enabled)
+}
+
+func (m *maintenanceSettings) PutSetting(_ context.Context, _ string, _ json.RawMessage) error {
+	return nil
+}
+
+func (m *maintenanceSettings) GetReaperStatus(_ context.Context) (*domain.ReaperStatus, error) {
+	return nil, nil
+}
+
+func (m *maintenanceSettings) UpdateReaperStatus(_ context.Context, _ *domain.ReaperStatus) error {
+	return nil
+}
+
+func TestEvaluator_MaintenanceMode_SkipsTick(t *testing.T) {
+	pipelineID := uuid.New()
+	pastFire := time.Now().Add(-2 * time.Hour)
+
+	triggers := &raceTriggerStore{}
+	triggers.addTrigger(domain.PipelineTrigger{
+		ID:              uuid.New(),
+		PipelineID:      pipelineID,
+		Type:            domain.TriggerTypeCron,
+		Config:          json.RawMessage(`{"cron_expr":"* * * * *"}`),
+		Enabled:         true,
+		LastTriggeredAt: &pastFire,
+	})
+	pipelines := &stubPipelineStore{pipeline: &domain.Pipeline{ID: pipelineID, Namespace: "default", Layer: domain.LayerSilver, Name: "downstream"}}
+	runs := &raceRunStore{}
+	exec := &raceExecutor{}
+	settings := &maintenanceSettings{enabled: true}
+
+	eval := NewEvaluator(triggers, pipelines, runs, exec, time.Minute)
+	eval.Settings = settings
+	eval.tick(context.Background())
+
+	runs.mu.Lock()
+	assert.Empty(t, runs.created)
+	runs.mu.Unlock()
+	assert.Zero(t, exec.calls)
+
+	settings.enabled = false
+	eval.tick(context.Background())
+
+	runs.mu.Lock()
+	defer runs.mu.Unlock()
+	assert.Len(t, runs.created, 1)
+}
@@ -25,7 +25,7 @@ _sym_db = _symbol_database.Default()
 from common.v1 import common_pb2 as common_dot_v1_dot_common__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x16runner/v1/runner.proto\x12\x15ratatouille.runner.v1\x1a\x16\x63ommon/v1/common.proto\"\xc7\x04\n\x15SubmitPipelineRequest\x12\x1c\n\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x32\n\x05layer\x18\x02 \x01(\x0e\x32\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n\rpipeline_name\x18\x03 \x01(\tR\x0cpipelineName\x12\x18\n\x07trigger\x18\x04 \x01(\tR\x07trigger\x12K\n\x0es3_credentials\x18\x05 \x01(\x0b\x32$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\x12G\n\x03\x65nv\x18\x06 \x03(\x0b\x32\x35.ratatouille.runner.v1.SubmitPipelineRequest.EnvEntryR\x03\x65nv\x12r\n\x12published_versions\x18\x07 \x03(\x0b\x32\x43.ratatouille.runner.v1.SubmitPipelineRequest.PublishedVersionsEntryR\x11publishedVersions\x12\x15\n\x06run_id\x18\x08 \x01(\tR\x05runId\x1a\x36\n\x08\x45nvEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\x1a\x44\n\x16PublishedVersionsEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"i\n\x16SubmitPipelineResponse\x12\x15\n\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x38\n\x06status\x18\x02 \x01(\x0e\x32 .ratatouille.common.v1.RunStatusR\x06status\"\xdf\x03\n\x16PreviewPipelineRequest\x12\x1c\n\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x32\n\x05layer\x18\x02 \x01(\x0e\x32\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n\rpipeline_name\x18\x03 \x01(\tR\x0cpipelineName\x12K\n\x0es3_credentials\x18\x04 \x01(\x0b\x32$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\x12H\n\x03\x65nv\x18\x05 \x03(\x0b\x32\x36.ratatouille.runner.v1.PreviewPipelineRequest.EnvEntryR\x03\x65nv\x12#\n\rpreview_limit\x18\x06 \x01(\x05R\x0cpreviewLimit\x12!\n\x0csample_files\x18\x07 \x03(\tR\x0bsampleFiles\x12\x12\n\x04\x63ode\x18\x08 \x01(\tR\x04\x63ode\x12#\n\rpipeline_type\x18\t \x01(\tR\x0cpipelineType\x1a\x36\n\x08\x45nvEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"\xa7\x04\n\x17PreviewPipelineResponse\x12;\n\x04\x64\x61ta\x18\n \x01(\x0b\x32%.ratatouille.runner.v1.PreviewSuccessH\x00R\x04\x64\x61ta\x12L\n\rpreview_error\x18\x0b \x01(\x0b\x32%.ratatouille.runner.v1.PreviewFailureH\x00R\x0cpreviewError\x12\x33\n\x04logs\x18\x07 \x03(\x0b\x32\x1f.ratatouille.common.v1.LogEntryR\x04logs\x12\x1a\n\x08warnings\x18\t \x03(\tR\x08warnings\x12\x1b\n\tarrow_ipc\x18\x01 \x01(\x0cR\x08\x61rrowIpc\x12;\n\x07\x63olumns\x18\x02 \x03(\x0b\x32!.ratatouille.runner.v1.ColumnInfoR\x07\x63olumns\x12&\n\x0ftotal_row_count\x18\x03 \x01(\x03R\rtotalRowCount\x12;\n\x06phases\x18\x04 \x03(\x0b\x32#.ratatouille.runner.v1.PhaseProfileR\x06phases\x12%\n\x0e\x65xplain_output\x18\x05 \x01(\tR\rexplainOutput\x12*\n\x11memory_peak_bytes\x18\x06 \x01(\x03R\x0fmemoryPeakBytes\x12\x14\n\x05\x65rror\x18\x08 \x01(\tR\x05\x65rrorB\x08\n\x06result\"\xa2\x02\n\x0ePreviewSuccess\x12\x1b\n\tarrow_ipc\x18\x01 \x01(\x0cR\x08\x61rrowIpc\x12;\n\x07\x63olumns\x18\x02 \x03(\x0b\x32!.ratatouille.runner.v1.ColumnInfoR\x07\x63olumns\x12&\n\x0ftotal_row_count\x18\x03 \x01(\x03R\rtotalRowCount\x12;\n\x06phases\x18\x04 \x03(\x0b\x32#.ratatouille.runner.v1.PhaseProfileR\x06phases\x12%\n\x0e\x65xplain_output\x18\x05 \x01(\tR\rexplainOutput\x12*\n\x11memory_peak_bytes\x18\x06 \x01(\x03R\x0fmemoryPeakBytes\"@\n\x0ePreviewFailure\x12\x18\n\x07message\x18\x01 \x01(\tR\x07message\x12\x14\n\x05phase\x18\x02 \x01(\tR\x05phase\"4\n\nColumnInfo\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n\x04type\x18\x02 \x01(\tR\x04type\"\xcf\x01\n\x0cPhaseProfile\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n\x0b\x64uration_ms\x18\x02 \x01(\x03R\ndurationMs\x12M\n\x08metadata\x18\x03 \x03(\x0b\x32\x31.ratatouille.runner.v1.PhaseProfile.MetadataEntryR\x08metadata\x1a;\n\rMetadataEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"\xdd\x01\n\x17ValidatePipelineRequest\x12\x1c\n\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x32\n\x05layer\x18\x02 \x01(\x0e\x32\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n\rpipeline_name\x18\x03 \x01(\tR\x0cpipelineName\x12K\n\x0es3_credentials\x18\x04 \x01(\x0b\x32$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\"m\n\x18ValidatePipelineResponse\x12\x14\n\x05valid\x18\x01 \x01(\x08R\x05valid\x12;\n\x05\x66iles\x18\x02 \x03(\x0b\x32%.ratatouille.runner.v1.FileValidationR\x05\x66iles\"n\n\x0e\x46ileValidation\x12\x12\n\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n\x05valid\x18\x02 \x01(\x08R\x05valid\x12\x16\n\x06\x65rrors\x18\x03 \x03(\tR\x06\x65rrors\x12\x1a\n\x08warnings\x18\x04 \x03(\tR\x08warnings\"\x14\n\x12ListPluginsRequest\"T\n\x13ListPluginsResponse\x12=\n\x07plugins\x18\x01 \x03(\x0b\x32#.ratatouille.runner.v1.RunnerPluginR\x07plugins\"u\n\x0cRunnerPlugin\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n\x07version\x18\x03 \x01(\tR\x07version\x12!\n\x0cpackage_name\x18\x04 \x01(\tR\x0bpackageName2\xef\x05\n\rRunnerService\x12m\n\x0eSubmitPipeline\x12,.ratatouille.runner.v1.SubmitPipelineRequest\x1a-.ratatouille.runner.v1.SubmitPipelineResponse\x12g\n\x0cGetRunStatus\x12*.ratatouille.common.v1.GetRunStatusRequest\x1a+.ratatouille.common.v1.GetRunStatusResponse\x12Y\n\nStreamLogs\x12(.ratatouille.common.v1.StreamLogsRequest\x1a\x1f.ratatouille.common.v1.LogEntry0\x01\x12^\n\tCancelRun\x12\'.ratatouille.common.v1.CancelRunRequest\x1a(.ratatouille.common.v1.CancelRunResponse\x12p\n\x0fPreviewPipeline\x12-.ratatouille.runner.v1.PreviewPipelineRequest\x1a..ratatouille.runner.v1.PreviewPipelineResponse\x12s\n\x10ValidatePipeline\x12..ratatouille.runner.v1.ValidatePipelineRequest\x1a/.ratatouille.runner.v1.ValidatePipelineResponse\x12\x64\n\x0bListPlugins\x12).ratatouille.runner.v1.ListPluginsRequest\x1a*.ratatouille.runner.v1.ListPluginsResponseB\xd7\x01\n\x19\x63om.ratatouille.runner.v1B\x0bRunnerProtoP\x01Z7github.com/rat-data/rat/platform/gen/runner/v1;runnerv1\xa2\x02\x03RRX\xaa\x02\x15Ratatouille.Runner.V1\xca\x02\x15Ratatouille\\Runner\\V1\xe2\x02!Ratatouille\\Runner\\V1\\GPBMetadata\xea\x02\x17Ratatouille::Runner::V1b\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x16runner/v1/runner.proto\x12\x15ratatouille.runner.v1\x1a\x16\x63ommon/v1/common.proto\"\xe4\x05\n\x15SubmitPipelineRequest\x12\x1c\n\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x32\n\x05layer\x18\x02 \x01(\x0e\x32\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n\rpipeline_name\x18\x03 \x01(\tR\x0cpipelineName\x12\x18\n\x07trigger\x18\x04 \x01(\tR\x07trigger\x12K\n\x0es3_credentials\x18\x05 \x01(\x0b\x32$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\x12G\n\x03\x65nv\x18\x06 \x03(\x0b\x32\x35.ratatouille.runner.v1.SubmitPipelineRequest.EnvEntryR\x03\x65nv\x12r\n\x12published_versions\x18\x07 \x03(\x0b\x32\x43.ratatouille.runner.v1.SubmitPipelineRequest.PublishedVersionsEntryR\x11publishedVersions\x12\x15\n\x06run_id\x18\x08 \x01(\tR\x05runId\x12\\\n\nparameters\x18\t \x03(\x0b\x32<.ratatouille.runner.v1.SubmitPipelineRequest.ParametersEntryR\nparameters\x1a\x36\n\x08\x45nvEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\x1a\x44\n\x16PublishedVersionsEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\x1a=\n\x0fParametersEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"i\n\x16SubmitPipelineResponse\x12\x15\n\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x38\n\x06status\x18\x02 \x01(\x0e\x32 .ratatouille.common.v1.RunStatusR\x06status\"\xdf\x03\n\x16PreviewPipelineRequest\x12\x1c\n\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x32\n\x05layer\x18\x02 \x01(\x0e\x32\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n\rpipeline_name\x18\x03 \x01(\tR\x0cpipelineName\x12K\n\x0es3_credentials\x18\x04 \x01(\x0b\x32$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\x12H\n\x03\x65nv\x18\x05 \x03(\x0b\x32\x36.ratatouille.runner.v1.PreviewPipelineRequest.EnvEntryR\x03\x65nv\x12#\n\rpreview_limit\x18\x06 \x01(\x05R\x0cpreviewLimit\x12!\n\x0csample_files\x18\x07 \x03(\tR\x0bsampleFiles\x12\x12\n\x04\x63ode\x18\x08 \x01(\tR\x04\x63ode\x12#\n\rpipeline_type\x18\t \x01(\tR\x0cpipelineType\x1a\x36\n\x08\x45nvEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"\xa7\x04\n\x17PreviewPipelineResponse\x12;\n\x04\x64\x61ta\x18\n \x01(\x0b\x32%.ratatouille.runner.v1.PreviewSuccessH\x00R\x04\x64\x61ta\x12L\n\rpreview_error\x18\x0b \x01(\x0b\x32%.ratatouille.runner.v1.PreviewFailureH\x00R\x0cpreviewError\x12\x33\n\x04logs\x18\x07 \x03(\x0b\x32\x1f.ratatouille.common.v1.LogEntryR\x04logs\x12\x1a\n\x08warnings\x18\t \x03(\tR\x08warnings\x12\x1b\n\tarrow_ipc\x18\x01 \x01(\x0cR\x08\x61rrowIpc\x12;\n\x07\x63olumns\x18\x02 \x03(\x0b\x32!.ratatouille.runner.v1.ColumnInfoR\x07\x63olumns\x12&\n\x0ftotal_row_count\x18\x03 \x01(\x03R\rtotalRowCount\x12;\n\x06phases\x18\x04 \x03(\x0b\x32#.ratatouille.runner.v1.PhaseProfileR\x06phases\x12%\n\x0e\x65xplain_output\x18\x05 \x01(\tR\rexplainOutput\x12*\n\x11memory_peak_bytes\x18\x06 \x01(\x03R\x0fmemoryPeakBytes\x12\x14\n\x05\x65rror\x18\x08 \x01(\tR\x05\x65rrorB\x08\n\x06result\"\xa2\x02\n\x0ePreviewSuccess\x12\x1b\n\tarrow_ipc\x18\x01 \x01(\x0cR\x08\x61rrowIpc\x12;\n\x07\x63olumns\x18\x02 \x03(\x0b\x32!.ratatouille.runner.v1.ColumnInfoR\x07\x63olumns\x12&\n\x0ftotal_row_count\x18\x03 \x01(\x03R\rtotalRowCount\x12;\n\x06phases\x18\x04 \x03(\x0b\x32#.ratatouille.runner.v1.PhaseProfileR\x06phases\x12%\n\x0e\x65xplain_output\x18\x05 \x01(\tR\rexplainOutput\x12*\n\x11memory_peak_bytes\x18\x06 \x01(\x03R\x0fmemoryPeakBytes\"@\n\x0ePreviewFailure\x12\x18\n\x07message\x18\x01 \x01(\tR\x07message\x12\x14\n\x05phase\x18\x02 \x01(\tR\x05phase\"4\n\nColumnInfo\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n\x04type\x18\x02 \x01(\tR\x04type\"\xcf\x01\n\x0cPhaseProfile\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n\x0b\x64uration_ms\x18\x02 \x01(\x03R\ndurationMs\x12M\n\x08metadata\x18\x03 \x03(\x0b\x32\x31.ratatouille.runner.v1.PhaseProfile.MetadataEntryR\x08metadata\x1a;\n\rMetadataEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"\xfa\x01\n\x17ValidatePipelineRequest\x12\x1c\n\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x32\n\x05layer\x18\x02 \x01(\x0e\x32\x1c.ratatouille.common.v1.LayerR\x05layer\x12#\n\rpipeline_name\x18\x03 \x01(\tR\x0cpipelineName\x12K\n\x0es3_credentials\x18\x04 \x01(\x0b\x32$.ratatouille.common.v1.S3CredentialsR\rs3Credentials\x12\x1b\n\ts3_prefix\x18\x05 \x01(\tR\x08s3Prefix\"m\n\x18ValidatePipelineResponse\x12\x14\n\x05valid\x18\x01 \x01(\x08R\x05valid\x12;\n\x05\x66iles\x18\x02 \x03(\x0b\x32%.ratatouille.runner.v1.FileValidationR\x05\x66iles\"n\n\x0e\x46ileValidation\x12\x12\n\x04path\x18\x01 \x01(\tR\x04path\x12\x14\n\x05valid\x18\x02 \x01(\x08R\x05valid\x12\x16\n\x06\x65rrors\x18\x03 \x03(\tR\x06\x65rrors\x12\x1a\n\x08warnings\x18\x04 \x03(\tR\x08warnings\"\x14\n\x12ListPluginsRequest\"T\n\x13ListPluginsResponse\x12=\n\x07plugins\x18\x01 \x03(\x0b\x32#.ratatouille.runner.v1.RunnerPluginR\x07plugins\"u\n\x0cRunnerPlugin\x12\x12\n\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n\x05group\x18\x02 \x01(\tR\x05group\x12\x18\n\x07version\x18\x03 \x01(\tR\x07version\x12!\n\x0cpackage_name\x18\x04 \x01(\tR\x0bpackageName\"\x14\n\x12GetCapacityRequest\"f\n\x13GetCapacityResponse\x12.\n\x13max_concurrent_runs\x18\x01 \x01(\x05R\x11maxConcurrentRuns\x12\x1f\n\x0b\x61\x63tive_runs\x18\x02 \x01(\x05R\nactiveRuns\"0\n\x18InferSampleSchemaRequest\x12\x14\n\x05paths\x18\x01 \x03(\tR\x05paths\"X\n\x19InferSampleSchemaResponse\x12;\n\x07\x63olumns\x18\x01 \x03(\x0b\x32!.ratatouille.runner.v1.ColumnInfoR\x07\x63olumns2\xcd\x07\n\rRunnerService\x12m\n\x0eSubmitPipeline\x12,.ratatouille.runner.v1.SubmitPipelineRequest\x1a-.ratatouille.runner.v1.SubmitPipelineResponse\x12g\n\x0cGetRunStatus\x12*.ratatouille.common.v1.GetRunStatusRequest\x1a+.ratatouille.common.v1.GetRunStatusResponse\x12Y\n\nStreamLogs\x12(.ratatouille.common.v1.StreamLogsRequest\x1a\x1f.ratatouille.common.v1.LogEntry0\x01\x12^\n\tCancelRun\x12\'.ratatouille.common.v1.CancelRunRequest\x1a(.ratatouille.common.v1.CancelRunResponse\x12p\n\x0fPreviewPipeline\x12-.ratatouille.runner.v1.PreviewPipelineRequest\x1a..ratatouille.runner.v1.PreviewPipelineResponse\x12s\n\x10ValidatePipeline\x12..ratatouille.runner.v1.ValidatePipelineRequest\x1a/.ratatouille.runner.v1.ValidatePipelineResponse\x12\x64\n\x0bListPlugins\x12).ratatouille.runner.v1.ListPluginsRequest\x1a*.ratatouille.runner.v1.ListPluginsResponse\x12\x64\n\x0bGetCapacity\x12).ratatouille.runner.v1.GetCapacityRequest\x1a*.ratatouille.runner.v1.GetCapacityResponse\x12v\n\x11InferSampleSchema\x12/.ratatouille.runner.v1.InferSampleSchemaRequest\x1a\x30.ratatouille.runner.v1.InferSampleSchemaResponseB\xd7\x01\n\x19\x63om.ratatouille.runner.v1B\x0bRunnerProtoP\x01Z7github.com/rat-data/rat/platform/gen/runner/v1;runnerv1\xa2\x02\x03RRX\xaa\x02\x15Ratatouille.Runner.V1\xca\x02\x15Ratatouille\\Runner\\V1\xe2\x02!Ratatouille\\Runner\\V1\\GPBMetadata\xea\x02\x17Ratatouille::Runner::V1b\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -37,46 +37,58 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['_SUBMITPIPELINEREQUEST_ENVENTRY']._serialized_options = b'8\001'
   _globals['_SUBMITPIPELINEREQUEST_PUBLISHEDVERSIONSENTRY']._loaded_options = None
   _globals['_SUBMITPIPELINEREQUEST_PUBLISHEDVERSIONSENTRY']._serialized_options = b'8\001'
+  _globals['_SUBMITPIPELINEREQUEST_PARAMETERSENTRY']._loaded_options = None
+  _globals['_SUBMITPIPELINEREQUEST_PARAMETERSENTRY']._serialized_options = b'8\001'
   _globals['_PREVIEWPIPELINEREQUEST_ENVENTRY']._loaded_options = None
   _globals['_PREVIEWPIPELINEREQUEST_ENVENTRY']._serialized_options = b'8\001'
   _globals['_PHASEPROFILE_METADATAENTRY']._loaded_options = None
   _globals['_PHASEPROFILE_METADATAENTRY']._serialized_options = b'8\001'
   _globals['_SUBMITPIPELINEREQUEST']._serialized_start=74
-  _globals['_SUBMITPIPELINEREQUEST']._serialized_end=657
-  _globals['_SUBMITPIPELINEREQUEST_ENVENTRY']._serialized_start=533
-  _globals['_SUBMITPIPELINEREQUEST_ENVENTRY']._serialized_end=587
-  _globals['_SUBMITPIPELINEREQUEST_PUBLISHEDVERSIONSENTRY']._serialized_start=589
-  _globals['_SUBMITPIPELINEREQUEST_PUBLISHEDVERSIONSENTRY']._serialized_end=657
-  _globals['_SUBMITPIPELINERESPONSE']._serialized_start=659
-  _globals['_SUBMITPIPELINERESPONSE']._serialized_end=764
-  _globals['_PREVIEWPIPELINEREQUEST']._serialized_start=767
-  _globals['_PREVIEWPIPELINEREQUEST']._serialized_end=1246
-  _globals['_PREVIEWPIPELINEREQUEST_ENVENTRY']._serialized_start=533
-  _globals['_PREVIEWPIPELINEREQUEST_ENVENTRY']._serialized_end=587
-  _globals['_PREVIEWPIPELINERESPONSE']._serialized_start=1249
-  _globals['_PREVIEWPIPELINERESPONSE']._serialized_end=1800
-  _globals['_PREVIEWSUCCESS']._serialized_start=1803
-  _globals['_PREVIEWSUCCESS']._serialized_end=2093
-  _globals['_PREVIEWFAILURE']._serialized_start=2095
-  _globals['_PREVIEWFAILURE']._serialized_end=2159
-  _globals['_COLUMNINFO']._serialized_start=2161
-  _globals['_COLUMNINFO']._serialized_end=2213
-  _globals['_PHASEPROFILE']._serialized_start=2216
-  _globals['_PHASEPROFILE']._serialized_end=2423
-  _globals['_PHASEPROFILE_METADATAENTRY']._serialized_start=2364
-  _globals['_PHASEPROFILE_METADATAENTRY']._serialized_end=2423
-  _globals['_VALIDATEPIPELINEREQUEST']._serialized_start=2426
-  _globals['_VALIDATEPIPELINEREQUEST']._serialized_end=2647
-  _globals['_VALIDATEPIPELINERESPONSE']._serialized_start=2649
-  _globals['_VALIDATEPIPELINERESPONSE']._serialized_end=2758
-  _globals['_FILEVALIDATION']._serialized_start=2760
-  _globals['_FILEVALIDATION']._serialized_end=2870
-  _globals['_LISTPLUGINSREQUEST']._serialized_start=2872
-  _globals['_LISTPLUGINSREQUEST']._serialized_end=2892
-  _globals['_LISTPLUGINSRESPONSE']._serialized_start=2894
-  _globals['_LISTPLUGINSRESPONSE']._serialized_end=2978
-  _globals['_RUNNERPLUGIN']._serialized_start=2980
-  _globals['_RUNNERPLUGIN']._serialized_end=3097
-  _globals['_RUNNERSERVICE']._serialized_start=3100
-  _globals['_RUNNERSERVICE']._serialized_end=3851
+  _globals['_SUBMITPIPELINEREQUEST']._serialized_end=814
+  _globals['_SUBMITPIPELINEREQUEST_ENVENTRY']._serialized_start=627
+  _globals['_SUBMITPIPELINEREQUEST_ENVENTRY']._serialized_end=681
+  _globals['_SUBMITPIPELINEREQUEST_PUBLISHEDVERSIONSENTRY']._serialized_start=683
+  _globals['_SUBMITPIPELINEREQUEST_PUBLISHEDVERSIONSENTRY']._serialized_end=751
+  _globals['_SUBMITPIPELINEREQUEST_PARAMETERSENTRY']._serialized_start=753
+  _globals['_SUBMITPIPELINEREQUEST_PARAMETERSENTRY']._serialized_end=814
+  _globals['_SUBMITPIPELINERESPONSE']._serialized_start=816
+  _globals['_SUBMITPIPELINERESPONSE']._serialized_end=921
+  _globals['_PREVIEWPIPELINEREQUEST']._serialized_start=924
+  _globals['_PREVIEWPIPELINEREQUEST']._serialized_end=1403
+  _globals['_PREVIEWPIPELINEREQUEST_ENVENTRY']._serialized_start=627
+  _globals['_PREVIEWPIPELINEREQUEST_ENVENTRY']._serialized_end=681
+  _globals['_PREVIEWPIPELINERESPONSE']._serialized_start=1406
+  _globals['_PREVIEWPIPELINERESPONSE']._serialized_end=1957
+  _globals['_PREVIEWSUCCESS']._serialized_start=1960
+  _globals['_PREVIEWSUCCESS']._serialized_end=2250
+  _globals['_PREVIEWFAILURE']._serialized_start=2252
+  _globals['_PREVIEWFAILURE']._serialized_end=2316
+  _globals['_COLUMNINFO']._serialized_start=2318
+  _globals['_COLUMNINFO']._serialized_end=2370
+  _globals['_PHASEPROFILE']._serialized_start=2373
+  _globals['_PHASEPROFILE']._serialized_end=2580
+  _globals['_PHASEPROFILE_METADATAENTRY']._serialized_start=2521
+  _globals['_PHASEPROFILE_METADATAENTRY']._serialized_end=2580
+  _globals['_VALIDATEPIPELINEREQUEST']._serialized_start=2583
+  _globals['_VALIDATEPIPELINEREQUEST']._serialized_end=2833
+  _globals['_VALIDATEPIPELINERESPONSE']._serialized_start=2835
+  _globals['_VALIDATEPIPELINERESPONSE']._serialized_end=2944
+  _globals['_FILEVALIDATION']._serialized_start=2946
+  _globals['_FILEVALIDATION']._serialized_end=3056
+  _globals['_LISTPLUGINSREQUEST']._serialized_start=3058
+  _globals['_LISTPLUGINSREQUEST']._serialized_end=3078
+  _globals['_LISTPLUGINSRESPONSE']._serialized_start=3080
+  _globals['_LISTPLUGINSRESPONSE']._serialized_end=3164
+  _globals['_RUNNERPLUGIN']._serialized_start=3166
+  _globals['_RUNNERPLUGIN']._serialized_end=3283
+  _globals['_GETCAPACITYREQUEST']._serialized_start=3285
+  _globals['_GETCAPACITYREQUEST']._serialized_end=3305
+  _globals['_GETCAPACITYRESPONSE']._serialized_start=3307
+  _globals['_GETCAPACITYRESPONSE']._serialized_end=3409
+  _globals['_INFERSAMPLESCHEMAREQUEST']._serialized_start=3411
+  _globals['_INFERSAMPLESCHEMAREQUEST']._serialized_end=3459
+  _globals['_INFERSAMPLESCHEMARESPONSE']._serialized_start=3461
+  _globals['_INFERSAMPLESCHEMARESPONSE']._serialized_end=3549
+  _globals['_RUNNERSERVICE']._serialized_start=3552
+  _globals['_RUNNERSERVICE']._serialized_end=4525
 # @@protoc_insertion_point(module_scope)
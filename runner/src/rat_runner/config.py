@@ -461,6 +461,21 @@ def _boto3_client_cache_clear() -> None:
 _boto3_client.cache_clear = _boto3_client_cache_clear  # type: ignore[attr-defined]
 
 
+# Set by ratd in a run's or preview's env when the pipeline was created with a
+# custom s3_prefix. Absent for the default layout.
+PIPELINE_S3_PREFIX_ENV = "RAT_PIPELINE_S3_PREFIX"
+
+
+def pipeline_prefix(
+    namespace: str, layer: str, name: str, env: dict[str, str] | None = None
+) -> str:
+    """Return the S3 prefix (no trailing slash) holding a pipeline's source files."""
+    custom = (env or {}).get(PIPELINE_S3_PREFIX_ENV, "")
+    if custom:
+        return custom.rstrip("/")
+    return f"{namespace}/pipelines/{layer}/{name}"
+
+
 def read_s3_text(s3_config: S3Config, key: str) -> str | None:
     """Read a text file from S3. Returns None if the key doesn't exist."""
     client = _boto3_client(s3_config)
@@ -28,6 +28,7 @@ from rat_runner.config import (
     merge_configs,
     move_s3_keys,
     parse_pipeline_config,
+    pipeline_prefix,
     read_s3_text,
     read_s3_text_version,
 )
@@ -216,7 +217,7 @@ def _phase1_detect_and_load(ctx: _PipelineContext) -> None:
     """
     _check_cancelled(ctx.run)
     ns, layer, name = ctx.run.namespace, ctx.run.layer, ctx.run.pipeline_name
-    base_prefix = f"{ns}/pipelines/{layer}/{name}"
+    base_prefix = pipeline_prefix(ns, layer, name, ctx.run.env)
 
     py_key = f"{base_prefix}/pipeline.py"
     sql_key = f"{base_prefix}/pipeline.sql"
@@ -303,6 +304,7 @@ def _phase2_build_result(ctx: _PipelineContext) -> None:
             ctx.s3_config,
             ctx.nessie_config,
             ctx.config,
+            parameters=ctx.run.parameters,
         )
     elif ctx.pipeline_type == "sql":
         ctx.result = _execute_sql_path(ctx)
@@ -371,6 +373,7 @@ def _execute_sql_path(ctx: _PipelineContext) -> pa.Table:
         config=ctx.config,
         watermark_value=watermark_value,
         plugin_helpers=plugin_helpers or None,
+        parameters=ctx.run.parameters,
     )
     ctx.log.debug(f"Compiled SQL:\n{compiled_sql}")
 
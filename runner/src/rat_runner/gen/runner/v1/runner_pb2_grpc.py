@@ -56,6 +56,16 @@ class RunnerServiceStub(object):
                 request_serializer=runner_dot_v1_dot_runner__pb2.ListPluginsRequest.SerializeToString,
                 response_deserializer=runner_dot_v1_dot_runner__pb2.ListPluginsResponse.FromString,
                 _registered_method=True)
+        self.GetCapacity = channel.unary_unary(
+                '/ratatouille.runner.v1.RunnerService/GetCapacity',
+                request_serializer=runner_dot_v1_dot_runner__pb2.GetCapacityRequest.SerializeToString,
+                response_deserializer=runner_dot_v1_dot_runner__pb2.GetCapacityResponse.FromString,
+                _registered_method=True)
+        self.InferSampleSchema = channel.unary_unary(
+                '/ratatouille.runner.v1.RunnerService/InferSampleSchema',
+                request_serializer=runner_dot_v1_dot_runner__pb2.InferSampleSchemaRequest.SerializeToString,
+                response_deserializer=runner_dot_v1_dot_runner__pb2.InferSampleSchemaResponse.FromString,
+                _registered_method=True)
 
 
 class RunnerServiceServicer(object):
@@ -120,6 +130,24 @@ class RunnerServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def GetCapacity(self, request, context):
+        """Report the runner's concurrent run limit and how many runs it is executing.
+        Called by the platform so the UI can show runner saturation before submits
+        start failing with RESOURCE_EXHAUSTED.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def InferSampleSchema(self, request, context):
+        """Infer the columns of landing zone sample files with the runner's DuckDB readers.
+        Called by the platform for GET /landing-zones/{ns}/{name}/samples/schema, so the
+        schema matches what a bronze pipeline reading those files would see.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_RunnerServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -158,6 +186,16 @@ def add_RunnerServiceServicer_to_server(servicer, server):
                     request_deserializer=runner_dot_v1_dot_runner__pb2.ListPluginsRequest.FromString,
                     response_serializer=runner_dot_v1_dot_runner__pb2.ListPluginsResponse.SerializeToString,
             ),
+            'GetCapacity': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetCapacity,
+                    request_deserializer=runner_dot_v1_dot_runner__pb2.GetCapacityRequest.FromString,
+                    response_serializer=runner_dot_v1_dot_runner__pb2.GetCapacityResponse.SerializeToString,
+            ),
+            'InferSampleSchema': grpc.unary_unary_rpc_method_handler(
+                    servicer.InferSampleSchema,
+                    request_deserializer=runner_dot_v1_dot_runner__pb2.InferSampleSchemaRequest.FromString,
+                    response_serializer=runner_dot_v1_dot_runner__pb2.InferSampleSchemaResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'ratatouille.runner.v1.RunnerService', rpc_method_handlers)
@@ -363,3 +401,57 @@ class RunnerService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def GetCapacity(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/ratatouille.runner.v1.RunnerService/GetCapacity',
+            runner_dot_v1_dot_runner__pb2.GetCapacityRequest.SerializeToString,
+            runner_dot_v1_dot_runner__pb2.GetCapacityResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def InferSampleSchema(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/ratatouille.runner.v1.RunnerService/InferSampleSchema',
+            runner_dot_v1_dot_runner__pb2.InferSampleSchemaRequest.SerializeToString,
+            runner_dot_v1_dot_runner__pb2.InferSampleSchemaResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -70,6 +70,9 @@ class RunState:
     created_at: float = field(default_factory=time.time)
     branch: str = ""
     env: dict[str, str] = field(default_factory=dict)
+    # Per-run pipeline parameters from ratd (manual run overrides, webhook
+    # param_mapping). Exposed to templates and Python pipelines as ``params``.
+    parameters: dict[str, str] = field(default_factory=dict)
     quality_results: list[QualityTestResult] = field(default_factory=list)
     archived_zones: list[str] = field(default_factory=list)
     cancel_event: threading.Event = field(default_factory=threading.Event)
@@ -6,7 +6,7 @@ import time
 from dataclasses import dataclass, field
 from typing import TYPE_CHECKING
 
-from rat_runner.config import NessieConfig, S3Config, read_s3_text
+from rat_runner.config import NessieConfig, S3Config, pipeline_prefix, read_s3_text
 
 if TYPE_CHECKING:
     import pyarrow as pa
@@ -71,6 +71,7 @@ def preview_pipeline(
     preview_limit: int = DEFAULT_PREVIEW_LIMIT,
     code: str | None = None,
     pipeline_type: str | None = None,
+    env: dict[str, str] | None = None,
 ) -> PreviewResult:
     """Execute a pipeline in preview mode — no writes, no branches, no quality tests.
 
@@ -108,6 +109,7 @@ def preview_pipeline(
             registry,
             code=code,
             pipeline_type_hint=pipeline_type,
+            env=env,
         )
         pipeline_type = detected_type
         result.phases.append(
@@ -192,6 +194,7 @@ def _detect_pipeline(
     registry: PluginRegistry,
     code: str | None = None,
     pipeline_type_hint: str | None = None,
+    env: dict[str, str] | None = None,
 ) -> tuple[str, str, PipelineConfig | None]:
     """Detect pipeline type and read source + config.
 
@@ -199,7 +202,7 @@ def _detect_pipeline(
     ``pipeline_type_hint`` ("sql" or "python") disambiguates the type when
     inline code is given; defaults to "sql".
     """
-    prefix = f"{namespace}/pipelines/{layer}/{pipeline_name}"
+    prefix = pipeline_prefix(namespace, layer, pipeline_name, env)
 
     # Inline code path — skip S3 reads for the source file
     if code is not None:
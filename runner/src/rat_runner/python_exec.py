@@ -277,6 +277,7 @@ def execute_python_pipeline(
     run_started_at: str | None = None,
     logger: PipelineLogger | None = None,
     landing_zone_fn: Callable[[str], str] | None = None,
+    parameters: dict[str, str] | None = None,
 ) -> pa.Table:
     """Execute a Python pipeline via exec() and extract the `result` variable.
 
@@ -288,6 +289,7 @@ def execute_python_pipeline(
     - run_started_at: ISO timestamp
     - is_incremental: bool
     - config: PipelineConfig (or None)
+    - params: the run's parameters (string dict, empty when none were given)
 
     The script MUST set `result` to a PyArrow Table.
 
@@ -332,6 +334,7 @@ def execute_python_pipeline(
         "run_started_at": run_started_at,
         "is_incremental": is_incremental,
         "config": config,
+        "params": dict(parameters or {}),
         "result": None,
     }
     if logger is not None:
@@ -11,6 +11,7 @@ from rat_runner.config import (
     NessieConfig,
     S3Config,
     list_s3_keys,
+    pipeline_prefix,
     read_s3_text,
     read_s3_text_version,
 )
@@ -30,12 +31,14 @@ def discover_quality_tests(
     namespace: str,
     layer: str,
     name: str,
+    env: dict[str, str] | None = None,
 ) -> list[str]:
     """Discover quality test SQL files from S3.
 
-    Looks for: {namespace}/pipelines/{layer}/{name}/tests/quality/*.sql
+    Looks for: {pipeline prefix}/tests/quality/*.sql — the default
+    {namespace}/pipelines/{layer}/{name} unless env carries a custom prefix.
     """
-    prefix = f"{namespace}/pipelines/{layer}/{name}/tests/quality/"
+    prefix = f"{pipeline_prefix(namespace, layer, name, env)}/tests/quality/"
     return list_s3_keys(s3_config, prefix, suffix=".sql")
 
 
@@ -44,9 +47,10 @@ def discover_quality_tests_versioned(
     namespace: str,
     layer: str,
     name: str,
+    env: dict[str, str] | None = None,
 ) -> list[str]:
     """Discover quality test keys from published_versions map."""
-    prefix = f"{namespace}/pipelines/{layer}/{name}/tests/quality/"
+    prefix = f"{pipeline_prefix(namespace, layer, name, env)}/tests/quality/"
     return sorted(k for k in published_versions if k.startswith(prefix) and k.endswith(".sql"))
 
 
@@ -228,6 +232,7 @@ def run_quality_tests(
             run.namespace,
             run.layer,
             run.pipeline_name,
+            run.env,
         )
     else:
         log.info("Pipeline not published — skipping quality tests")
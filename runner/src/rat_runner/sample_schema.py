@@ -0,0 +1,56 @@
+"""Sample schema inference — reads landing zone samples with DuckDB's readers."""
+
+from __future__ import annotations
+
+import posixpath
+from typing import TYPE_CHECKING
+
+if TYPE_CHECKING:
+    import duckdb
+
+# DuckDB table function for each sample file extension.
+_READERS = {
+    ".csv": "read_csv_auto",
+    ".tsv": "read_csv_auto",
+    ".parquet": "read_parquet",
+    ".json": "read_json_auto",
+    ".jsonl": "read_json_auto",
+    ".ndjson": "read_json_auto",
+}
+
+
+class NoReadableSamplesError(ValueError):
+    """Raised when none of the sample files has a format DuckDB can read."""
+
+
+def _quote(value: str) -> str:
+    return "'" + value.replace("'", "''") + "'"
+
+
+def infer_sample_schema(
+    conn: duckdb.DuckDBPyConnection,
+    urls: list[str],
+) -> list[tuple[str, str]]:
+    """Return the (name, DuckDB type) columns across every readable sample.
+
+    Samples are grouped by reader and each group is described with
+    union_by_name, so columns missing from some files are still included.
+    A column seen in several groups keeps the type of the first group.
+    """
+    groups: dict[str, list[str]] = {}
+    for url in urls:
+        reader = _READERS.get(posixpath.splitext(url)[1].lower())
+        if reader is not None:
+            groups.setdefault(reader, []).append(url)
+    if not groups:
+        raise NoReadableSamplesError("no CSV, Parquet or JSON sample files")
+
+    columns: dict[str, str] = {}
+    for reader, files in groups.items():
+        file_list = ", ".join(_quote(f) for f in files)
+        rows = conn.execute(
+            f"DESCRIBE SELECT * FROM {reader}([{file_list}], union_by_name = true)"
+        ).fetchall()
+        for row in rows:
+            columns.setdefault(row[0], row[1])
+    return list(columns.items())
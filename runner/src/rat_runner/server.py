@@ -11,6 +11,7 @@ import uuid
 from concurrent import futures
 from typing import TYPE_CHECKING
 
+import duckdb
 import grpc
 import pyarrow as pa
 
@@ -28,11 +29,13 @@ from runner.v1 import (
 
 from rat_runner.callback import notify_run_complete
 from rat_runner.config import NessieConfig, S3Config, list_s3_keys, read_s3_text
+from rat_runner.engine import DuckDBEngine
 from rat_runner.executor import execute_pipeline
 from rat_runner.log import run_log_extras
 from rat_runner.models import RunState, RunStatus
 from rat_runner.plugin_registry import PluginRegistry
 from rat_runner.preview import preview_pipeline
+from rat_runner.sample_schema import NoReadableSamplesError, infer_sample_schema
 from rat_runner.state_dir import (
     collect_crashed_runs,
     get_state_dir,
@@ -294,10 +297,10 @@ class RunnerServiceImpl(runner_pb2_grpc.RunnerServiceServicer):
         Resets run state between attempts so the executor starts fresh.
         Respects the cancel_event to allow cancellation during retry delay.
         """
-        from rat_runner.config import parse_pipeline_config
+        from rat_runner.config import parse_pipeline_config, pipeline_prefix
 
         ns, layer, name = run.namespace, run.layer, run.pipeline_name
-        config_key = f"{ns}/pipelines/{layer}/{name}/config.yaml"
+        config_key = f"{pipeline_prefix(ns, layer, name, run.env)}/config.yaml"
         config_yaml = read_s3_text(s3_config, config_key)
         if not config_yaml:
             return
@@ -381,6 +384,9 @@ class RunnerServiceImpl(runner_pb2_grpc.RunnerServiceServicer):
         if hasattr(request, "env") and request.env:
             env = dict(request.env)
 
+        # Per-run pipeline parameters, rendered as ``params`` in templates
+        parameters = dict(request.parameters)
+
         # Extract X-Request-ID propagated by ratd so every log line + the
         # outbound status callback can echo it back for cross-service tracing.
         request_id = _request_id_from_context(context)
@@ -393,6 +399,7 @@ class RunnerServiceImpl(runner_pb2_grpc.RunnerServiceServicer):
             trigger=request.trigger,
             request_id=request_id,
             env=env,
+            parameters=parameters,
         )
 
         # Backpressure: reject submission when at capacity so the platform
@@ -598,6 +605,7 @@ class RunnerServiceImpl(runner_pb2_grpc.RunnerServiceServicer):
             preview_limit=preview_limit,
             code=code,
             pipeline_type=pipeline_type_hint,
+            env=dict(request.env),
         )
 
         # Serialize Arrow table to IPC bytes
@@ -668,7 +676,9 @@ class RunnerServiceImpl(runner_pb2_grpc.RunnerServiceServicer):
             )
 
         # List all .sql files under the pipeline prefix
-        prefix = f"{request.namespace}/pipelines/{layer_str}/{request.pipeline_name}/"
+        prefix = request.s3_prefix or (
+            f"{request.namespace}/pipelines/{layer_str}/{request.pipeline_name}/"
+        )
         keys = list_s3_keys(s3_config, prefix, suffix=".sql")
 
         logger.info(
@@ -725,6 +735,39 @@ class RunnerServiceImpl(runner_pb2_grpc.RunnerServiceServicer):
         ]
         return runner_pb2.ListPluginsResponse(plugins=plugins)
 
+    def GetCapacity(  # noqa: N802
+        self,
+        request: runner_pb2.GetCapacityRequest,
+        context: grpc.ServicerContext,
+    ) -> runner_pb2.GetCapacityResponse:
+        return runner_pb2.GetCapacityResponse(
+            max_concurrent_runs=self._max_concurrent_runs,
+            active_runs=self.active_run_count,
+        )
+
+    def InferSampleSchema(  # noqa: N802
+        self,
+        request: runner_pb2.InferSampleSchemaRequest,
+        context: grpc.ServicerContext,
+    ) -> runner_pb2.InferSampleSchemaResponse:
+        urls = [f"s3://{self._s3_config.bucket}/{path}" for path in request.paths]
+        logger.info("InferSampleSchema: %d files", len(urls))
+
+        engine = DuckDBEngine(self._s3_config)
+        try:
+            columns = infer_sample_schema(engine.conn, urls)
+        except (NoReadableSamplesError, duckdb.Error) as e:
+            logger.warning("InferSampleSchema failed: %s", e)
+            context.set_code(grpc.StatusCode.INVALID_ARGUMENT)
+            context.set_details(_sanitize_error(str(e)))
+            return runner_pb2.InferSampleSchemaResponse()
+        finally:
+            engine.close()
+
+        return runner_pb2.InferSampleSchemaResponse(
+            columns=[runner_pb2.ColumnInfo(name=name, type=typ) for name, typ in columns]
+        )
+
     @property
     def active_run_count(self) -> int:
         """Return the number of non-terminal runs currently tracked."""
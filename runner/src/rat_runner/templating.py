@@ -78,6 +78,7 @@ def compile_sql(
     watermark_value: str | None = None,
     landing_zone_fn: Callable[[str], str] | None = None,
     plugin_helpers: dict[str, Callable[..., object]] | None = None,
+    parameters: dict[str, str] | None = None,
 ) -> str:
     """Compile a Jinja SQL template with ref() resolution.
 
@@ -87,6 +88,7 @@ def compile_sql(
     - run_started_at — ISO timestamp of the current run
     - is_incremental() — True when config.merge_strategy == "incremental"
     - watermark_value — max value of the watermark column (incremental pipelines)
+    - params — the run's parameters (string dict, empty when none were given)
     """
     run_started_at = datetime.now(UTC).isoformat()
 
@@ -130,6 +132,7 @@ def compile_sql(
         "is_append_only": is_append_only,
         "is_delete_insert": is_delete_insert,
         "watermark_value": watermark_value,
+        "params": dict(parameters or {}),
     }
 
     # Register plugin Jinja helpers (won't override built-in vars)
@@ -20,6 +20,7 @@ from rat_runner.config import (
     merge_configs,
     move_s3_keys,
     parse_pipeline_config,
+    pipeline_prefix,
     read_s3_text,
     validate_pipeline_config,
 )
@@ -657,6 +658,19 @@ class TestMergeConfigs:
         assert result.archive_landing_zones is True
 
 
+class TestPipelinePrefix:
+    def test_default_layout(self):
+        assert pipeline_prefix("ecom", "silver", "orders") == "ecom/pipelines/silver/orders"
+
+    def test_custom_prefix_from_env(self):
+        env = {"RAT_PIPELINE_S3_PREFIX": "ecom/shared/orders/"}
+        assert pipeline_prefix("ecom", "silver", "orders", env) == "ecom/shared/orders"
+
+    def test_empty_env_value_uses_default(self):
+        env = {"RAT_PIPELINE_S3_PREFIX": ""}
+        assert pipeline_prefix("ecom", "silver", "orders", env) == "ecom/pipelines/silver/orders"
+
+
 class TestReadS3Text:
     def test_reads_file(self, s3_config: S3Config):
         mock_body = MagicMock()
@@ -74,6 +74,26 @@ result = pa.table({'ok': [True]})
 
         assert len(table) == 1
 
+    def test_params_injected(self, s3_config: S3Config, nessie_config: NessieConfig):
+        source = """
+assert params == {"run_date": "2026-01-31"}
+result = pa.table({'run_date': [params["run_date"]]})
+"""
+        engine = _make_engine()
+
+        table = execute_python_pipeline(
+            source,
+            engine,
+            "ns",
+            "silver",
+            "orders",
+            s3_config,
+            nessie_config,
+            parameters={"run_date": "2026-01-31"},
+        )
+
+        assert table.column("run_date").to_pylist() == ["2026-01-31"]
+
     def test_ref_works(self, s3_config: S3Config, nessie_config: NessieConfig):
         source = """
 path = ref('bronze.events')
@@ -230,6 +230,16 @@ class TestDiscoverQualityTestsVersioned:
             "myns/pipelines/silver/orders/tests/quality/z_test.sql",
         ]
 
+    def test_custom_prefix_from_env(self):
+        published_versions = {
+            "myns/shared/orders/pipeline.sql": "v1",
+            "myns/shared/orders/tests/quality/not_null.sql": "v2",
+            "myns/pipelines/silver/orders/tests/quality/stale.sql": "v3",
+        }
+        env = {"RAT_PIPELINE_S3_PREFIX": "myns/shared/orders/"}
+        keys = discover_quality_tests_versioned(published_versions, "myns", "silver", "orders", env)
+        assert keys == ["myns/shared/orders/tests/quality/not_null.sql"]
+
 
 class TestRunQualityTests:
     @patch("rat_runner.quality.read_s3_text_version")
@@ -263,6 +273,39 @@ class TestRunQualityTests:
         assert len(results) == 2
         assert all(r.status == "pass" for r in results)
 
+    @patch("rat_runner.quality.read_s3_text_version")
+    def test_runs_tests_under_custom_prefix(
+        self,
+        mock_read_version: MagicMock,
+        s3_config: S3Config,
+        nessie_config: NessieConfig,
+    ):
+        published_versions = {
+            "myns/shared/orders/pipeline.sql": "vid0",
+            "myns/shared/orders/tests/quality/test1.sql": "vid1",
+        }
+        mock_read_version.return_value = "SELECT 1 WHERE false"
+
+        engine = _make_engine()
+        engine.query_arrow.return_value = pa.table({"x": pa.array([], type=pa.int64())})
+
+        run = _make_run(env={"RAT_PIPELINE_S3_PREFIX": "myns/shared/orders/"})
+        log = RunLogger(run)
+
+        results = run_quality_tests(
+            run,
+            engine,
+            s3_config,
+            nessie_config,
+            log,
+            published_versions=published_versions,
+        )
+
+        assert len(results) == 1
+        mock_read_version.assert_called_once_with(
+            s3_config, "myns/shared/orders/tests/quality/test1.sql", "vid1"
+        )
+
     def test_empty_when_no_published_versions(
         self,
         s3_config: S3Config,
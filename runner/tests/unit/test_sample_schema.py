@@ -0,0 +1,46 @@
+"""Tests for sample_schema — landing zone sample schema inference."""
+
+from __future__ import annotations
+
+from typing import TYPE_CHECKING
+
+import duckdb
+import pytest
+
+from rat_runner.sample_schema import NoReadableSamplesError, infer_sample_schema
+
+if TYPE_CHECKING:
+    from pathlib import Path
+
+
+@pytest.fixture
+def conn():
+    c = duckdb.connect(":memory:")
+    yield c
+    c.close()
+
+
+class TestInferSampleSchema:
+    def test_unions_columns_across_csv_samples(self, conn, tmp_path: Path):
+        (tmp_path / "a.csv").write_text("id,name\n1,a\n")
+        (tmp_path / "b.csv").write_text("id,amount\n2,9.5\n")
+
+        columns = infer_sample_schema(conn, [str(tmp_path / "a.csv"), str(tmp_path / "b.csv")])
+
+        assert [name for name, _ in columns] == ["id", "name", "amount"]
+        assert dict(columns)["amount"] == "DOUBLE"
+
+    def test_reads_parquet_and_skips_unknown_formats(self, conn, tmp_path: Path):
+        parquet = tmp_path / "it's.parquet"
+        conn.execute(
+            f"COPY (SELECT 1::BIGINT AS id) TO '{str(parquet).replace(chr(39), chr(39) * 2)}'"
+        )
+        (tmp_path / "notes.txt").write_text("hello")
+
+        columns = infer_sample_schema(conn, [str(tmp_path / "notes.txt"), str(parquet)])
+
+        assert columns == [("id", "BIGINT")]
+
+    def test_no_readable_samples_raises(self, conn):
+        with pytest.raises(NoReadableSamplesError):
+            infer_sample_schema(conn, ["ns/landing/orders/_samples/notes.txt"])
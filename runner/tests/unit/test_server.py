@@ -93,6 +93,51 @@ class TestSubmitPipeline:
             )
         assert exc_info.value.code() == grpc.StatusCode.INVALID_ARGUMENT
 
+    @patch("rat_runner.server.execute_pipeline")
+    def test_parameters_reach_run_state(
+        self,
+        mock_exec: MagicMock,
+        stub: runner_pb2_grpc.RunnerServiceStub,
+        service: RunnerServiceImpl,
+    ):
+        """Per-run parameters from ratd are kept on the run for execution."""
+        resp = stub.SubmitPipeline(
+            runner_pb2.SubmitPipelineRequest(
+                namespace="myns",
+                layer=common_pb2.LAYER_SILVER,
+                pipeline_name="orders",
+                trigger="manual",
+                parameters={"run_date": "2026-01-31", "limit": "10"},
+            )
+        )
+
+        assert service._runs[resp.run_id].parameters == {
+            "run_date": "2026-01-31",
+            "limit": "10",
+        }
+        deadline = time.time() + 5
+        while not mock_exec.called and time.time() < deadline:
+            time.sleep(0.01)
+        assert mock_exec.call_args.args[0].parameters["run_date"] == "2026-01-31"
+
+    @patch("rat_runner.server.execute_pipeline")
+    def test_no_parameters_gives_empty_dict(
+        self,
+        _mock_exec: None,
+        stub: runner_pb2_grpc.RunnerServiceStub,
+        service: RunnerServiceImpl,
+    ):
+        resp = stub.SubmitPipeline(
+            runner_pb2.SubmitPipelineRequest(
+                namespace="myns",
+                layer=common_pb2.LAYER_SILVER,
+                pipeline_name="orders",
+                trigger="manual",
+            )
+        )
+
+        assert service._runs[resp.run_id].parameters == {}
+
     @patch("rat_runner.server.execute_pipeline")
     def test_s3_credentials_applied_without_logging_values(
         self,
@@ -533,6 +578,30 @@ class TestBackpressure:
 
         assert bp_service.active_run_count == 2  # RUNNING + PENDING
 
+    @patch("rat_runner.server.execute_pipeline")
+    def test_get_capacity_reports_limit_and_active_runs(
+        self,
+        mock_exec: None,
+        bp_stub: runner_pb2_grpc.RunnerServiceStub,
+    ):
+        """GetCapacity reports max_concurrent_runs and the current active count."""
+        resp = bp_stub.GetCapacity(runner_pb2.GetCapacityRequest())
+        assert resp.max_concurrent_runs == 2
+        assert resp.active_runs == 0
+
+        bp_stub.SubmitPipeline(
+            runner_pb2.SubmitPipelineRequest(
+                namespace="ns",
+                layer=common_pb2.LAYER_SILVER,
+                pipeline_name="pipeline-0",
+                trigger="manual",
+            )
+        )
+
+        resp = bp_stub.GetCapacity(runner_pb2.GetCapacityRequest())
+        assert resp.max_concurrent_runs == 2
+        assert resp.active_runs == 1
+
 
 class TestGRPCMaxWorkers:
     """Tests for RUNNER_MAX_WORKERS env var controlling gRPC thread pool size."""
@@ -955,6 +1024,64 @@ class TestValidatePipelineRPC:
         )
         assert resp.valid is True
 
+    @patch("rat_runner.server.read_s3_text", return_value="SELECT 1")
+    @patch(
+        "rat_runner.server.list_s3_keys", return_value=["myns/shared/orders/pipeline.sql"]
+    )
+    def test_validate_lists_custom_s3_prefix(
+        self,
+        mock_list: MagicMock,
+        mock_read: MagicMock,
+        stub: runner_pb2_grpc.RunnerServiceStub,
+    ):
+        resp = stub.ValidatePipeline(
+            runner_pb2.ValidatePipelineRequest(
+                namespace="myns",
+                layer=common_pb2.LAYER_SILVER,
+                pipeline_name="orders",
+                s3_prefix="myns/shared/orders/",
+            )
+        )
+        assert mock_list.call_args.args[1] == "myns/shared/orders/"
+        assert [f.path for f in resp.files] == ["myns/shared/orders/pipeline.sql"]
+
+
+class TestInferSampleSchemaRPC:
+    """Tests for the InferSampleSchema gRPC endpoint."""
+
+    @patch("rat_runner.server.DuckDBEngine")
+    @patch(
+        "rat_runner.server.infer_sample_schema",
+        return_value=[("id", "INTEGER"), ("name", "VARCHAR")],
+    )
+    def test_returns_columns_for_sample_urls(
+        self,
+        mock_infer: MagicMock,
+        mock_engine: MagicMock,
+        stub: runner_pb2_grpc.RunnerServiceStub,
+        s3_config: S3Config,
+    ):
+        resp = stub.InferSampleSchema(
+            runner_pb2.InferSampleSchemaRequest(paths=["ns/landing/orders/_samples/a.csv"])
+        )
+        assert [(c.name, c.type) for c in resp.columns] == [("id", "INTEGER"), ("name", "VARCHAR")]
+        assert mock_infer.call_args.args[1] == [
+            f"s3://{s3_config.bucket}/ns/landing/orders/_samples/a.csv"
+        ]
+        mock_engine.return_value.close.assert_called_once()
+
+    @patch("rat_runner.server.DuckDBEngine")
+    def test_no_readable_samples_returns_invalid_argument(
+        self,
+        mock_engine: MagicMock,
+        stub: runner_pb2_grpc.RunnerServiceStub,
+    ):
+        with pytest.raises(grpc.RpcError) as exc_info:
+            stub.InferSampleSchema(
+                runner_pb2.InferSampleSchemaRequest(paths=["ns/landing/orders/_samples/a.txt"])
+            )
+        assert exc_info.value.code() == grpc.StatusCode.INVALID_ARGUMENT
+
 
 # ── ListPlugins RPC tests ──────────────────────────────────────────
 
@@ -195,6 +195,24 @@ class TestCompileSql:
         # Should contain an ISO timestamp
         assert "T" in result  # ISO format has T separator
 
+    def test_injects_params(self):
+        sql = "SELECT * FROM t WHERE d = '{{ params.run_date }}' LIMIT {{ params.get('n', 100) }}"
+        result = compile_sql(
+            sql,
+            "ns",
+            "silver",
+            "p",
+            self._s3(),
+            self._nessie(),
+            parameters={"run_date": "2026-01-31"},
+        )
+        assert result == "SELECT * FROM t WHERE d = '2026-01-31' LIMIT 100"
+
+    def test_params_empty_without_parameters(self):
+        sql = "{% if params %}WHERE 1{% else %}WHERE 2{% endif %}"
+        result = compile_sql(sql, "ns", "silver", "p", self._s3(), self._nessie())
+        assert result == "WHERE 2"
+
     def test_is_incremental_returns_false(self):
         sql = "{% if is_incremental() %}WHERE 1{% else %}WHERE 2{% endif %}"
         result = compile_sql(sql, "ns", "silver", "p", self._s3(), self._nessie())
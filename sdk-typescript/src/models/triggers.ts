@@ -63,7 +63,12 @@ export interface WebhookConfig {
 export interface FilePatternConfig {
   namespace: string;
   zone_name: string;
-  pattern: string;
+  /** Single glob. Kept for backward compatibility; prefer `patterns`. */
+  pattern?: string;
+  /** Fires when the uploaded filename matches any of these globs. */
+  patterns?: string[];
+  /** How patterns are interpreted. Defaults to "glob". */
+  match_type?: "glob" | "regex";
 }
 
 export interface CronDependencyConfig {